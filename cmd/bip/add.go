@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/arxiv"
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/crossref"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addCmd.Flags().String("title", "", "Paper title (required unless --fetch or --arxiv)")
+	addCmd.Flags().String("authors", "", `Authors as "Last, First" pairs, e.g. "Smith, John, Doe, Jane" or "Smith, John; Consortium"`)
+	addCmd.Flags().Int("year", 0, "Publication year")
+	addCmd.Flags().String("doi", "", "DOI (e.g. 10.1234/abc)")
+	addCmd.Flags().String("venue", "", "Journal, conference, or preprint server")
+	addCmd.Flags().String("abstract", "", "Abstract text")
+	addCmd.Flags().String("id", "", "Reference ID (default: first author + year + title suffix)")
+	addCmd.Flags().Bool("fetch", false, "Fill in title, authors, venue, and date from Crossref by --doi")
+	addCmd.Flags().Bool("dry-run", false, "Show the reference that would be added without writing it")
+	addCmd.Flags().String("arxiv", "", "Fill in metadata from arXiv for this ID (e.g. 2401.12345 or 2401.12345v2)")
+	addCmd.Flags().Bool("yes", false, "With --fetch or --arxiv and --human, add without asking for confirmation")
+	addCmd.Flags().String("from-pdfs", "", "Add every PDF in this directory, resolving each by DOI or title on Semantic Scholar")
+	rootCmd.AddCommand(addCmd)
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a paper by hand",
+	Long: `Add a paper by hand, without fetching metadata from an external source.
+
+The reference is recorded with source type "manual". Unless --id is given,
+its ID is generated like imported papers: first author's last name, year,
+and a two-letter title suffix (e.g. Smith2024-ab), with -2, -3, ... appended
+if that ID is taken. The created reference is printed as JSON so the
+generated ID can be captured.
+
+Authors are "Last, First" pairs separated by commas. Use semicolons between
+authors instead when some have only one name.
+
+With --fetch, the title, authors, venue, publication date, and abstract are
+looked up in Crossref by --doi, and the source type is "crossref". Any of
+--title, --authors, --year, --venue, or --abstract given as well overrides
+the fetched value. With --human, the fetched reference is shown and added
+only once confirmed (or with --yes); use --dry-run to preview it in JSON.
+An unknown DOI fails with error_code "doi_not_found", and a failure to
+reach Crossref with "crossref_unavailable".
+
+--arxiv <id> works the same way for preprints, fetching the title, authors,
+abstract, and date from arXiv; the source type is "arxiv". A versioned ID
+fetches that version and is kept as the source ID, while arxiv_id is
+stored without the version. A paper whose arxiv_id names the same
+preprint, in any form ("arXiv:" prefix, a version), is a duplicate. The
+error codes are "arxiv_not_found" and "arxiv_unavailable".
+
+--from-pdfs <dir> adds every PDF in a directory (not recursive) instead,
+setting pdf_path on each. A file's DOI is taken from the PDF metadata or
+first pages and looked up on Semantic Scholar; without a DOI, the metadata
+or first-page title must match exactly one search result. Files that can't
+be resolved are reported as "manual" along with any title and author
+found, and are not added. It cannot be combined with the other flags.
+
+Examples:
+  bip add --title "An Unpublished Tech Report" --authors "Smith, John, Doe, Jane" --year 2024
+  bip add --title "Consortium Paper" --authors "Smith, John; Genome Consortium" --doi 10.1234/abc
+  bip add --id internal-memo-2023 --title "Internal Memo" --year 2023
+  bip add --fetch --doi 10.1371/journal.pcbi.1013758 --human
+  bip add --fetch --doi 10.1371/journal.pcbi.1013758 --dry-run
+  bip add --arxiv 2401.12345v2 --human
+  bip add --from-pdfs ~/Downloads/papers/ --human`,
+	Args: cobra.NoArgs,
+	RunE: runAdd,
+}
+
+func runAdd(cmd *cobra.Command, args []string) error {
+	if dir, _ := cmd.Flags().GetString("from-pdfs"); dir != "" {
+		for _, name := range []string{"title", "authors", "year", "doi", "venue", "abstract", "id", "fetch", "dry-run", "arxiv", "yes"} {
+			if cmd.Flags().Changed(name) {
+				exitWithError(ExitError, "--from-pdfs cannot be combined with --%s", name)
+			}
+		}
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			exitWithError(ExitError, "resolving --from-pdfs: %v", err)
+		}
+		if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+			exitWithError(ExitError, "--from-pdfs %s is not a directory", dir)
+		}
+		return runS2AddPdfDir(absDir)
+	}
+
+	repoRoot := mustFindRepository()
+
+	title, _ := cmd.Flags().GetString("title")
+	authorsFlag, _ := cmd.Flags().GetString("authors")
+	year, _ := cmd.Flags().GetInt("year")
+	doi, _ := cmd.Flags().GetString("doi")
+	venue, _ := cmd.Flags().GetString("venue")
+	abstract, _ := cmd.Flags().GetString("abstract")
+	id, _ := cmd.Flags().GetString("id")
+	fetch, _ := cmd.Flags().GetBool("fetch")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+	arxivID, _ := cmd.Flags().GetString("arxiv")
+
+	if fetch && arxivID != "" {
+		exitWithError(ExitError, "--fetch and --arxiv cannot be combined")
+	}
+	if fetch && doi == "" {
+		exitWithError(ExitError, "--fetch requires --doi")
+	}
+	fetching := fetch || arxivID != ""
+	if !fetching && title == "" {
+		exitWithError(ExitError, "--title is required unless --fetch or --arxiv is given")
+	}
+
+	authors, err := parseAuthorList(authorsFlag)
+	if err != nil {
+		exitWithError(ExitDataError, "--authors: %v", err)
+	}
+
+	refsPath := config.RefsPath(repoRoot)
+	refs, err := storage.ReadAll(refsPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+
+	if doi != "" {
+		if idx, found := storage.FindByDOI(refs, doi); found {
+			exitWithError(ExitDataError, "DOI %s already exists as %s", doi, refs[idx].ID)
+		}
+	}
+
+	ref := reference.Reference{
+		DOI:       doi,
+		Title:     title,
+		Authors:   authors,
+		Abstract:  abstract,
+		Venue:     venue,
+		Published: reference.PublicationDate{Year: year},
+		Source:    reference.ImportSource{Type: "manual"},
+	}
+	if fetch {
+		fetched := fetchCrossrefReference(doi)
+		ref = overrideFetchedFields(cmd, fetched, ref)
+		ref.DOI = fetched.DOI // Crossref's canonical form of the --doi looked up
+		if idx, found := storage.FindByDOI(refs, ref.DOI); found {
+			exitWithError(ExitDataError, "DOI %s already exists as %s", ref.DOI, refs[idx].ID)
+		}
+	}
+	if arxivID != "" {
+		ref = overrideFetchedFields(cmd, fetchArXivReference(arxivID), ref)
+		for _, r := range refs {
+			if r.ArXivID != "" && arxiv.NormalizeID(r.ArXivID) == arxiv.NormalizeID(ref.ArXivID) {
+				exitWithError(ExitDataError, "arXiv ID %s already exists as %s", ref.ArXivID, r.ID)
+			}
+		}
+		if ref.DOI != "" {
+			if idx, found := storage.FindByDOI(refs, ref.DOI); found {
+				exitWithError(ExitDataError, "DOI %s already exists as %s", ref.DOI, refs[idx].ID)
+			}
+		}
+	}
+
+	if id == "" {
+		last := ""
+		if len(ref.Authors) > 0 {
+			last = ref.Authors[0].Last
+		}
+		id = storage.GenerateUniqueID(refs, reference.CiteKey(last, ref.Published.Year, ref.Title))
+	} else if _, found := storage.FindByID(refs, id); found {
+		exitWithError(ExitDataError, "reference %s already exists", id)
+	}
+	ref.ID = id
+
+	if err := ref.Validate(); err != nil {
+		exitWithError(ExitDataError, "invalid reference: %v", err)
+	}
+
+	if dryRun {
+		if humanOutput {
+			fmt.Println("Would add:")
+			printRefDetail(RefDetail{Reference: ref})
+		} else {
+			outputJSON(ref)
+		}
+		return nil
+	}
+	if fetching && humanOutput && !yes {
+		printRefDetail(RefDetail{Reference: ref})
+		fmt.Print("\nAdd this reference? [y/N] ")
+		input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(input)) != "y" {
+			fmt.Println("Not added")
+			return nil
+		}
+	}
+
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexRefs, refsPath)
+
+	if err := storage.Append(refsPath, ref); err != nil {
+		exitWithError(ExitDataError, "writing refs: %v", err)
+	}
+	if err := db.ApplyIndexUpdate(storage.IndexRefs, refsPath, indexCurrent, func() error { return db.UpsertRef(ref) }); err != nil {
+		exitWithError(ExitDataError, "updating index: %v", err)
+	}
+
+	if humanOutput {
+		fmt.Printf("Added: %s\n", ref.ID)
+		fmt.Printf("  Title: %s\n", ref.Title)
+	} else {
+		outputJSON(ref)
+	}
+	return nil
+}
+
+// fetchCrossrefReference looks up doi in Crossref.
+func fetchCrossrefReference(doi string) reference.Reference {
+	fetched, err := crossref.NewClient().FetchByDOI(context.Background(), doi)
+	if err != nil {
+		if errors.Is(err, crossref.ErrNotFound) {
+			exitWithErrorCode(ExitDataError, ErrorCodeDOINotFound, "DOI %s not found in Crossref", doi)
+		}
+		exitWithErrorCode(ExitError, ErrorCodeCrossrefUnavailable, "fetching %s from Crossref: %v", doi, err)
+	}
+	return *fetched
+}
+
+// fetchArXivReference looks up an arXiv ID, with or without version.
+func fetchArXivReference(id string) reference.Reference {
+	fetched, err := arxiv.NewClient().FetchByID(context.Background(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, arxiv.ErrInvalidID):
+			exitWithError(ExitError, "%v", err)
+		case errors.Is(err, arxiv.ErrNotFound):
+			exitWithErrorCode(ExitDataError, ErrorCodeArXivNotFound, "arXiv ID %s not found", id)
+		}
+		exitWithErrorCode(ExitError, ErrorCodeArXivUnavailable, "fetching %s from arXiv: %v", id, err)
+	}
+	return *fetched
+}
+
+// overrideFetchedFields returns fetched with the fields given on the
+// command line, as recorded in manual, taking precedence.
+func overrideFetchedFields(cmd *cobra.Command, fetched, manual reference.Reference) reference.Reference {
+	ref := fetched
+	if cmd.Flags().Changed("title") {
+		ref.Title = manual.Title
+	}
+	if cmd.Flags().Changed("authors") {
+		ref.Authors = manual.Authors
+	}
+	if cmd.Flags().Changed("year") {
+		ref.Published = manual.Published
+	}
+	if cmd.Flags().Changed("venue") {
+		ref.Venue = manual.Venue
+	}
+	if cmd.Flags().Changed("abstract") {
+		ref.Abstract = manual.Abstract
+	}
+	if cmd.Flags().Changed("doi") && manual.DOI != "" {
+		ref.DOI = manual.DOI
+	}
+	return ref
+}
+
+// parseAuthorList parses --authors. With semicolons, each semicolon-separated
+// entry is one author ("Last, First" or a single name). Otherwise the value
+// is a comma-separated list of Last, First pairs.
+func parseAuthorList(s string) ([]reference.Author, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var authors []reference.Author
+	if strings.Contains(s, ";") {
+		for _, entry := range strings.Split(s, ";") {
+			last, first, _ := strings.Cut(entry, ",")
+			last, first = strings.TrimSpace(last), strings.TrimSpace(first)
+			if last == "" {
+				return nil, fmt.Errorf("empty author in %q", s)
+			}
+			authors = append(authors, reference.Author{First: first, Last: last})
+		}
+		return authors, nil
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts)%2 != 0 {
+		return nil, fmt.Errorf("expected Last, First pairs; got an odd number of names in %q (separate authors with ';' if some have a single name)", s)
+	}
+	for i := 0; i < len(parts); i += 2 {
+		last, first := strings.TrimSpace(parts[i]), strings.TrimSpace(parts[i+1])
+		if last == "" {
+			return nil, fmt.Errorf("empty last name in %q", s)
+		}
+		authors = append(authors, reference.Author{First: first, Last: last})
+	}
+	return authors, nil
+}
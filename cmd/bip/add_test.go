@@ -0,0 +1,35 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+func TestParseAuthorList(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []reference.Author
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"pairs", "Smith, John, Doe, Jane", []reference.Author{{First: "John", Last: "Smith"}, {First: "Jane", Last: "Doe"}}, false},
+		{"semicolons with single name", "Smith, John; Genome Consortium", []reference.Author{{First: "John", Last: "Smith"}, {Last: "Genome Consortium"}}, false},
+		{"odd number of names", "Smith, John, Consortium", nil, true},
+		{"empty last name", " , John", nil, true},
+		{"empty entry", "Smith, John;", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAuthorList(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAuthorList(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAuthorList(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
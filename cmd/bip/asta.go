@@ -1,9 +1,6 @@
 package main
 
-import (
-	"github.com/joho/godotenv"
-	"github.com/spf13/cobra"
-)
+import "github.com/spf13/cobra"
 
 var astaHuman bool
 
@@ -22,17 +19,14 @@ Environment Variables (take precedence over asta_api_key in config.yml):
   BIP_ASTA_API_KEY  Your ASTA API key (recommended, bip-scoped)
   ASTA_API_KEY      Your ASTA API key (fallback)
 
-Either variable may also be set in a local .env file, which is loaded
-automatically.
+Either variable may also be set in a .env file in or above the current
+directory or the nexus, which is loaded automatically.
 
 Without a key, requests are sent anonymously: the cheap endpoints work, but
 the search endpoint may time out. Register at https://allenai.org/asta/resources/mcp`,
 }
 
 func init() {
-	// Load .env file if present (for BIP_ASTA_API_KEY / ASTA_API_KEY)
-	_ = godotenv.Load()
-
 	astaCmd.PersistentFlags().BoolVar(&astaHuman, "human", false, "Output human-readable format instead of JSON")
 	rootCmd.AddCommand(astaCmd)
 }
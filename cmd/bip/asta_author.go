@@ -32,7 +32,7 @@ func runAstaAuthor(cmd *cobra.Command, args []string) {
 	name, err := validateQuery(args[0])
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(ExitError)
+		os.Exit(ExitError.Status())
 	}
 
 	astaExecute(
@@ -39,7 +39,7 @@ func runAstaAuthorPapers(cmd *cobra.Command, args []string) {
 	authorID, err := validateAuthorID(args[0])
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(ExitError)
+		os.Exit(ExitError.Status())
 	}
 
 	astaExecute(
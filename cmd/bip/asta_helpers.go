@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -13,7 +12,7 @@ import (
 
 // errorMapping holds the exit code and error code for a specific error type.
 type errorMapping struct {
-	exitCode int
+	exitCode ExitCode
 	errCode  string
 }
 
@@ -33,9 +32,7 @@ func classifyError(err error) errorMapping {
 
 // astaOutputJSON outputs data as JSON to stdout.
 func astaOutputJSON(data any) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(data)
+	return outputJSON(data)
 }
 
 // astaOutputError outputs an error in JSON or human format and returns the exit code.
@@ -50,8 +47,9 @@ func astaOutputError(err error, paperID string) int {
 	} else {
 		errResp := map[string]any{
 			"error": map[string]any{
-				"code":    mapping.errCode,
-				"message": err.Error(),
+				"code":       mapping.errCode,
+				"error_code": mapping.exitCode.ErrorCode(),
+				"message":    err.Error(),
 			},
 		}
 		if paperID != "" {
@@ -60,7 +58,7 @@ func astaOutputError(err error, paperID string) int {
 		_ = astaOutputJSON(errResp)
 	}
 
-	return mapping.exitCode
+	return mapping.exitCode.Status()
 }
 
 // astaExecute is a generic command executor that handles the common pattern of
@@ -70,7 +68,7 @@ func astaExecute(
 	humanFormatter func(result any),
 	paperID string,
 ) {
-	client := asta.NewClient()
+	client := newASTAClient()
 	result, err := apiCall(context.Background(), client)
 	if err != nil {
 		os.Exit(astaOutputError(err, paperID))
@@ -81,7 +79,7 @@ func astaExecute(
 	} else {
 		if err := astaOutputJSON(result); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
-			os.Exit(ExitError)
+			os.Exit(ExitError.Status())
 		}
 	}
 }
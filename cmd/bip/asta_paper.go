@@ -41,7 +41,7 @@ func runAstaPaper(cmd *cobra.Command, args []string) {
 	paperID, err := validatePaperID(args[0])
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(ExitError)
+		os.Exit(ExitError.Status())
 	}
 
 	astaExecute(
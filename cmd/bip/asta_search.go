@@ -39,7 +39,7 @@ func runAstaSearch(cmd *cobra.Command, args []string) {
 	query, err := validateQuery(args[0])
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(ExitError)
+		os.Exit(ExitError.Status())
 	}
 
 	astaExecute(
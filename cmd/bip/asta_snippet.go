@@ -42,7 +42,7 @@ func runAstaSnippet(cmd *cobra.Command, args []string) {
 	query, err := validateQuery(args[0])
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(ExitError)
+		os.Exit(ExitError.Status())
 	}
 
 	astaExecute(
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/ncbi"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/s2"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backfillIDsDryRun    bool
+	backfillIDsLimit     int
+	backfillIDsBatchSize int
+	backfillIDsEmail     string
+)
+
+func init() {
+	backfillIDsCmd.Flags().BoolVar(&backfillIDsDryRun, "dry-run", false, "Query and report but do not write refs.jsonl")
+	backfillIDsCmd.Flags().IntVar(&backfillIDsLimit, "limit", 0, "Cap the number of papers looked up (0 = no limit)")
+	backfillIDsCmd.Flags().IntVar(&backfillIDsBatchSize, "batch-size", 100, fmt.Sprintf("Papers per Semantic Scholar batch request (max %d)", s2.MaxBatchSize))
+	backfillIDsCmd.Flags().StringVar(&backfillIDsEmail, "email", "", "Identification email sent to NCBI (recommended)")
+	rootCmd.AddCommand(backfillIDsCmd)
+}
+
+var backfillIDsCmd = &cobra.Command{
+	Use:   "backfill-ids",
+	Short: "Fill missing S2, PubMed, PMC, and arXiv IDs for papers with a DOI",
+	Long: `Look up papers that have a DOI but are missing one or more of s2_id, pmid,
+pmcid, or arxiv_id, and fill in whatever the mapping services know.
+
+Papers are first looked up by DOI in Semantic Scholar batches, which supplies
+all four IDs. Papers still missing a PMID or PMCID are then sent to the NCBI
+ID Converter. Existing values are never overwritten. The index is rebuilt once
+at the end.
+
+If Semantic Scholar rate-limits the run, the remaining papers are still sent
+to NCBI and the summary is marked incomplete; rerun later to finish. Papers
+that genuinely have no PMC or arXiv record stay candidates, so a rerun queries
+them again.
+
+Examples:
+  bip backfill-ids --dry-run --human
+  bip backfill-ids --limit 200
+  bip backfill-ids --email you@example.com`,
+	Args: cobra.NoArgs,
+	RunE: runBackfillIDs,
+}
+
+// IDBackfillResult is the per-paper outcome of backfill-ids.
+type IDBackfillResult struct {
+	ID     string            `json:"id"`
+	DOI    string            `json:"doi"`
+	Status string            `json:"status"`           // updated, unchanged, not_found, failed
+	Filled map[string]string `json:"filled,omitempty"` // Field name -> new value
+	Error  string            `json:"error,omitempty"`  // Why a lookup could not be made
+}
+
+// BackfillIDsSummary is the JSON output for backfill-ids.
+type BackfillIDsSummary struct {
+	DryRun     bool               `json:"dry_run"`
+	Scanned    int                `json:"scanned"`
+	Candidates int                `json:"candidates"`
+	Updated    int                `json:"updated"`
+	Unchanged  int                `json:"unchanged"`
+	NotFound   int                `json:"not_found"`
+	Failed     int                `json:"failed"`
+	Incomplete bool               `json:"incomplete,omitempty"`
+	Warnings   []string           `json:"warnings,omitempty"`
+	Results    []IDBackfillResult `json:"results"`
+}
+
+// paperBatchFetcher is the subset of *s2.Client used by backfill-ids.
+type paperBatchFetcher interface {
+	GetPapersBatch(ctx context.Context, paperIDs []string) ([]*s2.S2Paper, error)
+}
+
+// idBackfillState tracks one candidate through both lookup phases.
+type idBackfillState struct {
+	idx     int // Index into refs
+	found   bool
+	s2Error string
+	filled  map[string]string
+}
+
+func runBackfillIDs(cmd *cobra.Command, args []string) error {
+	if backfillIDsBatchSize < 1 || backfillIDsBatchSize > s2.MaxBatchSize {
+		exitWithError(ExitError, "--batch-size must be between 1 and %d", s2.MaxBatchSize)
+	}
+
+	repoRoot := mustFindRepository()
+	refsPath := config.RefsPath(repoRoot)
+
+	refs, err := storage.ReadAll(refsPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+
+	summary := backfillExternalIDs(context.Background(), refs, newS2Client(), newNCBIClient(backfillIDsEmail),
+		backfillIDsBatchSize, backfillIDsLimit, func(done, total int) {
+			if humanOutput {
+				fmt.Fprintf(os.Stderr, "Looked up %d of %d papers in Semantic Scholar...\n", done, total)
+			}
+		})
+	summary.DryRun = backfillIDsDryRun
+
+	if !backfillIDsDryRun && summary.Updated > 0 {
+		if err := storage.WriteAll(refsPath, refs); err != nil {
+			exitWithError(ExitDataError, "writing refs: %v", err)
+		}
+		db := mustOpenDatabase(repoRoot)
+		defer db.Close()
+		if _, err := db.RebuildFromJSONL(refsPath); err != nil {
+			exitWithError(ExitDataError, "rebuilding index: %v", err)
+		}
+	}
+
+	if humanOutput {
+		printBackfillIDsSummary(summary)
+	} else {
+		outputJSON(summary)
+	}
+	return nil
+}
+
+// backfillExternalIDs fills missing external IDs on refs in place and
+// returns a per-paper report. progress, if non-nil, is called after each
+// Semantic Scholar batch.
+func backfillExternalIDs(ctx context.Context, refs []reference.Reference, papers paperBatchFetcher, converter Converter, batchSize, limit int, progress func(done, total int)) BackfillIDsSummary {
+	summary := BackfillIDsSummary{Scanned: len(refs), Results: []IDBackfillResult{}}
+
+	var states []*idBackfillState
+	for i, r := range refs {
+		if !isLikelyDOI(r.DOI) || !missingExternalIDs(r) {
+			continue
+		}
+		states = append(states, &idBackfillState{idx: i, filled: map[string]string{}})
+		if limit > 0 && len(states) >= limit {
+			break
+		}
+	}
+	summary.Candidates = len(states)
+	if len(states) == 0 {
+		return summary
+	}
+
+	// Phase 1: Semantic Scholar supplies all four IDs
+	for start := 0; start < len(states); start += batchSize {
+		batch := states[start:min(start+batchSize, len(states))]
+
+		if summary.Incomplete {
+			for _, st := range batch {
+				st.s2Error = "skipped after Semantic Scholar rate limit"
+			}
+			continue
+		}
+
+		ids := make([]string, len(batch))
+		for i, st := range batch {
+			ids[i] = "DOI:" + refs[st.idx].DOI
+		}
+		results, err := papers.GetPapersBatch(ctx, ids)
+		if err != nil {
+			msg := fmt.Sprintf("Semantic Scholar lookup failed: %v", err)
+			if s2.IsRateLimited(err) {
+				summary.Incomplete = true
+				msg = "Semantic Scholar rate limit reached; rerun later to finish"
+			}
+			summary.Warnings = append(summary.Warnings, msg)
+			for _, st := range batch {
+				st.s2Error = msg
+			}
+			continue
+		}
+
+		for i, p := range results {
+			if p == nil {
+				continue
+			}
+			st := batch[i]
+			st.found = true
+			ref := &refs[st.idx]
+			fillID(&ref.S2ID, p.PaperID, "s2_id", st.filled)
+			fillID(&ref.PMID, p.ExternalIDs.PubMed, "pmid", st.filled)
+			fillID(&ref.PMCID, normalizePMCID(p.ExternalIDs.PubMedCentral), "pmcid", st.filled)
+			fillID(&ref.ArXivID, p.ExternalIDs.ArXiv, "arxiv_id", st.filled)
+		}
+
+		if progress != nil {
+			progress(start+len(batch), len(states))
+		}
+	}
+
+	// Phase 2: NCBI for anything still missing a PubMed identifier
+	var inputs []ncbi.Input
+	stateByDOI := make(map[string]*idBackfillState)
+	for _, st := range states {
+		ref := refs[st.idx]
+		if ref.PMID != "" && ref.PMCID != "" {
+			continue
+		}
+		inputs = append(inputs, ncbi.Input{Type: ncbi.IDTypeDOI, ID: ref.DOI})
+		stateByDOI[ref.DOI] = st
+	}
+	ncbiError := ""
+	if len(inputs) > 0 {
+		records, err := converter.Convert(ctx, inputs)
+		if err != nil {
+			ncbiError = fmt.Sprintf("NCBI lookup failed: %v", err)
+			summary.Warnings = append(summary.Warnings, ncbiError)
+		}
+		for _, rec := range records {
+			st, ok := stateByDOI[rec.RequestedID]
+			if !ok || rec.Status == "error" {
+				continue
+			}
+			st.found = true
+			ref := &refs[st.idx]
+			if rec.PMID != 0 {
+				fillID(&ref.PMID, strconv.Itoa(rec.PMID), "pmid", st.filled)
+			}
+			fillID(&ref.PMCID, rec.PMCID, "pmcid", st.filled)
+		}
+	}
+
+	for _, st := range states {
+		ref := refs[st.idx]
+		r := IDBackfillResult{ID: ref.ID, DOI: ref.DOI}
+		_, askedNCBI := stateByDOI[ref.DOI]
+		switch {
+		case len(st.filled) > 0:
+			r.Status = "updated"
+			r.Filled = st.filled
+			summary.Updated++
+		case st.found:
+			r.Status = "unchanged"
+			summary.Unchanged++
+		case st.s2Error != "" || (askedNCBI && ncbiError != ""):
+			var errs []string
+			if st.s2Error != "" {
+				errs = append(errs, st.s2Error)
+			}
+			if askedNCBI && ncbiError != "" {
+				errs = append(errs, ncbiError)
+			}
+			r.Status = "failed"
+			r.Error = strings.Join(errs, "; ")
+			summary.Failed++
+		default:
+			r.Status = "not_found"
+			summary.NotFound++
+		}
+		summary.Results = append(summary.Results, r)
+	}
+
+	return summary
+}
+
+// missingExternalIDs reports whether any backfillable external ID is empty.
+func missingExternalIDs(r reference.Reference) bool {
+	return r.S2ID == "" || r.PMID == "" || r.PMCID == "" || r.ArXivID == ""
+}
+
+// fillID sets *dst to value if *dst is empty and value is not, recording the change.
+func fillID(dst *string, value, field string, filled map[string]string) {
+	if *dst != "" || value == "" {
+		return
+	}
+	*dst = value
+	filled[field] = value
+}
+
+// normalizePMCID returns id with the "PMC" prefix NCBI uses; Semantic Scholar
+// reports the bare number.
+func normalizePMCID(id string) string {
+	if id == "" || strings.HasPrefix(id, "PMC") {
+		return id
+	}
+	return "PMC" + id
+}
+
+// printBackfillIDsSummary prints backfill-ids results for --human output.
+func printBackfillIDsSummary(s BackfillIDsSummary) {
+	verb := "Updated"
+	if s.DryRun {
+		verb = "Would update"
+	}
+	for _, r := range s.Results {
+		switch r.Status {
+		case "updated":
+			var parts []string
+			for _, field := range []string{"s2_id", "pmid", "pmcid", "arxiv_id"} {
+				if v, ok := r.Filled[field]; ok {
+					parts = append(parts, fmt.Sprintf("%s=%s", field, v))
+				}
+			}
+			fmt.Printf("%s: %s (%s)\n", verb, r.ID, strings.Join(parts, ", "))
+		case "failed":
+			fmt.Printf("Failed: %s (%s)\n", r.ID, r.Error)
+		}
+	}
+	for _, w := range s.Warnings {
+		fmt.Printf("Warning: %s\n", w)
+	}
+	fmt.Printf("\n%d refs scanned, %d candidates: %d updated, %d unchanged, %d not found, %d failed\n",
+		s.Scanned, s.Candidates, s.Updated, s.Unchanged, s.NotFound, s.Failed)
+	if s.Incomplete {
+		fmt.Println("Incomplete: rerun later to look up the remaining papers.")
+	}
+}
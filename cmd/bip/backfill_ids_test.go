@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/ncbi"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/s2"
+)
+
+// fakePaperBatch answers GetPapersBatch from a map keyed by "DOI:<doi>".
+type fakePaperBatch struct {
+	calls  int
+	papers map[string]*s2.S2Paper
+	err    error
+}
+
+func (f *fakePaperBatch) GetPapersBatch(ctx context.Context, ids []string) ([]*s2.S2Paper, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([]*s2.S2Paper, len(ids))
+	for i, id := range ids {
+		out[i] = f.papers[id]
+	}
+	return out, nil
+}
+
+func TestBackfillExternalIDs(t *testing.T) {
+	refs := []reference.Reference{
+		{ID: "Legacy2019-ab", DOI: "10.1/legacy", PMID: "999"},
+		{ID: "NcbiOnly2020-cd", DOI: "10.1/ncbi"},
+		{ID: "Unknown2021-ef", DOI: "10.1/unknown"},
+		{ID: "Complete2022-gh", DOI: "10.1/done", S2ID: "s", PMID: "1", PMCID: "PMC1", ArXivID: "a"},
+		{ID: "NoDOI2023-ij"},
+	}
+	papers := &fakePaperBatch{papers: map[string]*s2.S2Paper{
+		"DOI:10.1/legacy": {PaperID: "s2legacy", ExternalIDs: s2.ExternalIDs{PubMed: "123", PubMedCentral: "456", ArXiv: "1901.00001"}},
+	}}
+	conv := &fakeConverter{respond: func(inputs []ncbi.Input) ([]ncbi.Record, error) {
+		return []ncbi.Record{{RequestedID: "10.1/ncbi", PMID: 42, PMCID: "PMC42"}}, nil
+	}}
+
+	summary := backfillExternalIDs(context.Background(), refs, papers, conv, 100, 0, nil)
+
+	if summary.Candidates != 3 || summary.Updated != 2 || summary.NotFound != 1 {
+		t.Errorf("candidates/updated/not_found = %d/%d/%d, want 3/2/1", summary.Candidates, summary.Updated, summary.NotFound)
+	}
+
+	// Existing PMID is kept; the others are filled and PMCID gets its prefix
+	legacy := refs[0]
+	if legacy.PMID != "999" || legacy.S2ID != "s2legacy" || legacy.PMCID != "PMC456" || legacy.ArXivID != "1901.00001" {
+		t.Errorf("legacy ref = %+v", legacy)
+	}
+	if _, ok := summary.Results[0].Filled["pmid"]; ok {
+		t.Error("pmid reported as filled despite existing value")
+	}
+
+	if refs[1].PMID != "42" || refs[1].PMCID != "PMC42" {
+		t.Errorf("NCBI fallback not applied: %+v", refs[1])
+	}
+	if summary.Results[2].Status != "not_found" {
+		t.Errorf("unknown paper status = %s", summary.Results[2].Status)
+	}
+}
+
+func TestBackfillExternalIDs_RateLimited(t *testing.T) {
+	refs := []reference.Reference{
+		{ID: "A2020-ab", DOI: "10.1/a"},
+		{ID: "B2020-cd", DOI: "10.1/b"},
+	}
+	papers := &fakePaperBatch{err: s2.ErrRateLimited}
+	conv := &fakeConverter{}
+
+	summary := backfillExternalIDs(context.Background(), refs, papers, conv, 1, 0, nil)
+
+	if papers.calls != 1 {
+		t.Errorf("S2 called %d times after rate limit, want 1", papers.calls)
+	}
+	if conv.calls != 1 {
+		t.Errorf("NCBI called %d times, want 1", conv.calls)
+	}
+	if !summary.Incomplete || summary.Failed != 2 {
+		t.Errorf("Incomplete = %v, Failed = %d; want true, 2", summary.Incomplete, summary.Failed)
+	}
+}
+
+func TestBackfillExternalIDs_NCBIError(t *testing.T) {
+	refs := []reference.Reference{{ID: "A2020-ab", DOI: "10.1/a"}}
+	conv := &fakeConverter{respond: func([]ncbi.Input) ([]ncbi.Record, error) {
+		return nil, errors.New("boom")
+	}}
+
+	summary := backfillExternalIDs(context.Background(), refs, &fakePaperBatch{}, conv, 100, 0, nil)
+
+	if summary.Failed != 1 || summary.Results[0].Error == "" {
+		t.Errorf("summary = %+v, want one failure with an error message", summary)
+	}
+}
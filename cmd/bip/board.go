@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -113,9 +112,7 @@ func runBoardList(cmd *cobra.Command, args []string) {
 
 	// Output
 	if boardListJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		enc.Encode(allItems)
+		outputJSON(allItems)
 		return
 	}
 
@@ -4,22 +4,49 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/matsen/bipartite/internal/config"
 	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/s2"
+	"github.com/matsen/bipartite/internal/semantic"
 	"github.com/matsen/bipartite/internal/storage"
 	"github.com/spf13/cobra"
 )
 
 func init() {
+	checkCmd.Flags().Bool("fix", false, "Remove orphaned edges and collapse duplicate edges")
+	checkCmd.Flags().Bool("strict", false, "Count informational issues (e.g. missing abstracts) toward the exit status")
 	rootCmd.AddCommand(checkCmd)
 }
 
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Verify repository integrity",
-	Long:  `Verify repository integrity, checking for missing PDFs and duplicate DOIs.`,
-	RunE:  runCheck,
+	Long: `Verify repository integrity, checking for missing PDFs and duplicate DOIs.
+
+DOIs are compared case-insensitively and without a https://doi.org/ prefix.
+Papers sharing a DOI are reported as duplicate_doi for you to merge; --fix
+leaves them alone.
+
+With --fix, edges whose paper endpoints no longer exist are removed, as
+'bip groom --fix' does, and duplicate edges (same source, target, and type)
+are collapsed to the copy with the earliest created_at. Repaired issues are
+reported with "action": "removed"; other issues are only reported. Without --fix, check never modifies the repository.
+
+References with no abstract, or one shorter than the semantic index
+minimum, are listed in a single missing_abstract entry of the separate
+"info" array. Such papers cannot be found by 'bip semantic' or 'bip
+similar'. Info entries do not affect the status or exit code unless
+--strict is given.
+
+Exit codes:
+  0  No issues found
+  7  Issues found (read-only, or some remain after --fix)
+  8  Issues found and all of them fixed by --fix`,
+	RunE: runCheck,
 }
 
 // CheckResult is the response for the check command.
@@ -30,23 +57,120 @@ type CheckResult struct {
 	Projects   int          `json:"projects"`
 	Repos      int          `json:"repos"`
 	Issues     []CheckIssue `json:"issues"`
+	Info       []CheckIssue `json:"info"` // Informational findings; count as issues only under --strict
 }
 
 // CheckIssue represents a single issue found during check.
 type CheckIssue struct {
-	Type     string   `json:"type"`
-	ID       string   `json:"id,omitempty"`
-	IDs      []string `json:"ids,omitempty"`
-	Expected string   `json:"expected,omitempty"`
-	DOI      string   `json:"doi,omitempty"`
-	SourceID string   `json:"source_id,omitempty"`
-	TargetID string   `json:"target_id,omitempty"`
-	Reason   string   `json:"reason,omitempty"`
+	Type       string   `json:"type"`
+	ID         string   `json:"id,omitempty"`
+	IDs        []string `json:"ids,omitempty"`
+	Expected   string   `json:"expected,omitempty"`
+	DOI        string   `json:"doi,omitempty"`
+	SourceID   string   `json:"source_id,omitempty"`
+	TargetID   string   `json:"target_id,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+	Count      int      `json:"count,omitempty"`       // Copies of a duplicate edge
+	CreatedAts []string `json:"created_ats,omitempty"` // created_at of each duplicate copy
+	Action     string   `json:"action,omitempty"`      // "removed" when --fix repaired it
+}
+
+// missingAbstractIssues reports, as one informational entry, the references
+// the semantic index skips because their abstract is missing or too short.
+func missingAbstractIssues(refs []reference.Reference) []CheckIssue {
+	var ids []string
+	for _, ref := range refs {
+		if semantic.AbstractTooShort(ref.Abstract) {
+			ids = append(ids, ref.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return []CheckIssue{{
+		Type:   "missing_abstract",
+		IDs:    ids,
+		Count:  len(ids),
+		Reason: fmt.Sprintf("no abstract or abstract shorter than %d characters", semantic.MinAbstractLength),
+	}}
+}
+
+// duplicateDOIIssues reports each DOI shared by more than one reference,
+// sorted by DOI. DOIs are compared after s2.NormalizeDOI, so a bare DOI and
+// its https://doi.org/ URL, in any case, collide. Merging is left to the user.
+func duplicateDOIIssues(refs []reference.Reference) []CheckIssue {
+	idsByDOI := make(map[string][]string)
+	for _, ref := range refs {
+		if doi := s2.NormalizeDOI(ref.DOI); doi != "" {
+			idsByDOI[doi] = append(idsByDOI[doi], ref.ID)
+		}
+	}
+
+	dois := make([]string, 0, len(idsByDOI))
+	for doi, ids := range idsByDOI {
+		if len(ids) > 1 {
+			dois = append(dois, doi)
+		}
+	}
+	sort.Strings(dois)
+
+	var issues []CheckIssue
+	for _, doi := range dois {
+		issues = append(issues, CheckIssue{
+			Type: "duplicate_doi",
+			IDs:  idsByDOI[doi],
+			DOI:  doi,
+		})
+	}
+	return issues
+}
+
+// duplicateEdgeIssues reports each duplicated edge key once, sorted by key,
+// with the created_at of every copy. action is recorded on each issue.
+func duplicateEdgeIssues(edges []edge.Edge, duplicates map[edge.EdgeKey]int, action string) []CheckIssue {
+	createdAts := make(map[edge.EdgeKey][]string)
+	for _, e := range edges {
+		if _, dup := duplicates[e.Key()]; dup {
+			createdAts[e.Key()] = append(createdAts[e.Key()], e.CreatedAt)
+		}
+	}
+
+	keys := make([]edge.EdgeKey, 0, len(duplicates))
+	for key := range duplicates {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.SourceID != b.SourceID {
+			return a.SourceID < b.SourceID
+		}
+		if a.TargetID != b.TargetID {
+			return a.TargetID < b.TargetID
+		}
+		return a.RelationshipType < b.RelationshipType
+	})
+
+	var issues []CheckIssue
+	for _, key := range keys {
+		count := duplicates[key]
+		issues = append(issues, CheckIssue{
+			Type:       "duplicate_edge",
+			SourceID:   key.SourceID,
+			TargetID:   key.TargetID,
+			Reason:     fmt.Sprintf("type=%s, count=%d", key.RelationshipType, count),
+			Count:      count,
+			CreatedAts: createdAts[key],
+			Action:     action,
+		})
+	}
+	return issues
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
 	cfg := mustLoadConfig(repoRoot)
+	fix, _ := cmd.Flags().GetBool("fix")
+	strict, _ := cmd.Flags().GetBool("strict")
 
 	// Read all references from JSONL (source of truth)
 	refsPath := config.RefsPath(repoRoot)
@@ -64,21 +188,10 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	var issues []CheckIssue
 
 	// Check for duplicate DOIs
-	doiMap := make(map[string][]string) // DOI -> list of IDs
-	for _, ref := range refs {
-		if ref.DOI != "" {
-			doiMap[ref.DOI] = append(doiMap[ref.DOI], ref.ID)
-		}
-	}
-	for doi, ids := range doiMap {
-		if len(ids) > 1 {
-			issues = append(issues, CheckIssue{
-				Type: "duplicate_doi",
-				IDs:  ids,
-				DOI:  doi,
-			})
-		}
-	}
+	issues = append(issues, duplicateDOIIssues(refs)...)
+
+	// Report papers the semantic index will skip
+	info := missingAbstractIssues(refs)
 
 	// Check for missing PDFs (only if pdf_root is configured)
 	if cfg.PDFRoot != "" {
@@ -104,27 +217,35 @@ func runCheck(cmd *cobra.Command, args []string) error {
 		exitWithError(ExitDataError, "reading edges: %v", err)
 	}
 
-	// Check for orphaned edges using shared detection function
-	orphaned, _ := edge.DetectOrphanedEdges(edges, validIDs)
+	// Check for orphaned and duplicate edges using shared detection functions
+	orphaned, validEdges := edge.DetectOrphanedEdges(edges, validIDs)
+	duplicates := edge.FindDuplicateEdges(edges)
+
+	// With --fix, drop orphans and collapse duplicates (keeping the earliest
+	// created_at, as concept merge does) in a single rewrite
+	fixAction := ""
+	if fix && (len(orphaned) > 0 || len(duplicates) > 0) {
+		fixedEdges, _ := edge.DedupeEdges(validEdges)
+		if err := storage.WriteAllEdges(edgesPath, fixedEdges); err != nil {
+			exitWithError(ExitDataError, "writing edges: %v", err)
+		}
+		db := mustOpenDatabase(repoRoot)
+		defer db.Close()
+		if _, err := db.RebuildEdgesFromJSONL(edgesPath); err != nil {
+			exitWithError(ExitDataError, "rebuilding index: %v", err)
+		}
+		fixAction = "removed"
+	}
 	for _, o := range orphaned {
 		issues = append(issues, CheckIssue{
 			Type:     "orphaned_edge",
 			SourceID: o.SourceID,
 			TargetID: o.TargetID,
 			Reason:   o.Reason,
+			Action:   fixAction,
 		})
 	}
-
-	// Check for duplicate edges using shared detection function
-	duplicates := edge.FindDuplicateEdges(edges)
-	for key, count := range duplicates {
-		issues = append(issues, CheckIssue{
-			Type:     "duplicate_edge",
-			SourceID: key.SourceID,
-			TargetID: key.TargetID,
-			Reason:   fmt.Sprintf("type=%s, count=%d", key.RelationshipType, count),
-		})
-	}
+	issues = append(issues, duplicateEdgeIssues(edges, duplicates, fixAction)...)
 
 	// Load projects and repos
 	projectsPath := config.ProjectsPath(repoRoot)
@@ -237,24 +358,51 @@ func runCheck(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Determine status
-	status := "ok"
-	if len(issues) > 0 {
-		status = "issues"
+	// Determine status: "fixed" only if --fix repaired every issue found.
+	// Info entries count, unfixed, only under --strict.
+	status, exitCode := "ok", ExitSuccess
+	counted := len(issues)
+	if strict {
+		counted += len(info)
+	}
+	for _, issue := range issues {
+		if issue.Action == "" {
+			status, exitCode = "issues", ExitCheckIssues
+		}
+	}
+	if strict && len(info) > 0 {
+		status, exitCode = "issues", ExitCheckIssues
+	}
+	if counted > 0 && exitCode == ExitSuccess {
+		status, exitCode = "fixed", ExitCheckFixed
 	}
 
-	// Ensure issues is an empty array, not null
+	// Ensure issues and info are empty arrays, not null
 	if issues == nil {
 		issues = []CheckIssue{}
 	}
+	if info == nil {
+		info = []CheckIssue{}
+	}
 
 	// Output results
 	if humanOutput {
-		if len(issues) == 0 {
-			fmt.Printf("Repository check: OK\n\n%d references, %d edges, %d projects, %d repos checked\n", len(refs), len(edges), len(projects), len(repos))
+		if counted == 0 {
+			fmt.Printf("Repository check: OK\n\n")
+			printInfoIssues(info)
+			fmt.Printf("%d references, %d edges, %d projects, %d repos checked\n", len(refs), len(edges), len(projects), len(repos))
 		} else {
-			fmt.Printf("Repository check: %d issues found\n\n", len(issues))
+			fmt.Printf("Repository check: %d issues found\n\n", counted)
+			printInfoIssues(info)
 			for _, issue := range issues {
+				if issue.Action == "removed" {
+					if issue.Type == "duplicate_edge" {
+						fmt.Printf("  [FIXED] Collapsed duplicate edge: %s --> %s (%s), kept earliest\n\n", issue.SourceID, issue.TargetID, issue.Reason)
+					} else {
+						fmt.Printf("  [FIXED] Removed orphaned edge: %s --> %s (%s)\n\n", issue.SourceID, issue.TargetID, issue.Reason)
+					}
+					continue
+				}
 				switch issue.Type {
 				case "missing_pdf":
 					fmt.Printf("  [WARN] Missing PDF for %s\n", issue.ID)
@@ -265,7 +413,8 @@ func runCheck(cmd *cobra.Command, args []string) error {
 				case "orphaned_edge":
 					fmt.Printf("  [WARN] Orphaned edge: %s --> %s (%s)\n\n", issue.SourceID, issue.TargetID, issue.Reason)
 				case "duplicate_edge":
-					fmt.Printf("  [WARN] Duplicate edge: %s --> %s (%s)\n\n", issue.SourceID, issue.TargetID, issue.Reason)
+					fmt.Printf("  [WARN] Duplicate edge: %s --> %s (%s)\n", issue.SourceID, issue.TargetID, issue.Reason)
+					fmt.Printf("         Created: %s\n\n", strings.Join(issue.CreatedAts, ", "))
 				case "orphaned_repo":
 					fmt.Printf("  [WARN] Orphaned repo: %s (%s)\n\n", issue.ID, issue.Reason)
 				case "invalid_repo_edge":
@@ -288,8 +437,20 @@ func runCheck(cmd *cobra.Command, args []string) error {
 			Projects:   len(projects),
 			Repos:      len(repos),
 			Issues:     issues,
+			Info:       info,
 		})
 	}
 
+	exitWithResultStatus(exitCode)
 	return nil
 }
+
+// printInfoIssues prints the info entries in human output.
+func printInfoIssues(info []CheckIssue) {
+	for _, issue := range info {
+		if issue.Type == "missing_abstract" {
+			fmt.Printf("  [INFO] %d references not semantically searchable (%s)\n", issue.Count, issue.Reason)
+			fmt.Printf("         IDs: %s\n\n", formatIDList(issue.IDs))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/semantic"
+)
+
+func TestDuplicateDOIIssues(t *testing.T) {
+	refs := []reference.Reference{
+		{ID: "A", DOI: "10.1000/XYZ"},
+		{ID: "B", DOI: "https://doi.org/10.1000/xyz"},
+		{ID: "C", DOI: "10.1000/other"},
+		{ID: "D"},
+		{ID: "E"},
+		{ID: "F", DOI: "10.1000/abc"},
+		{ID: "G", DOI: " 10.1000/ABC "},
+	}
+
+	issues := duplicateDOIIssues(refs)
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2: %+v", len(issues), issues)
+	}
+	want := []CheckIssue{
+		{Type: "duplicate_doi", DOI: "10.1000/abc", IDs: []string{"F", "G"}},
+		{Type: "duplicate_doi", DOI: "10.1000/xyz", IDs: []string{"A", "B"}},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("duplicateDOIIssues = %+v, want %+v", issues, want)
+	}
+
+	if issues := duplicateDOIIssues(refs[2:6]); len(issues) != 0 {
+		t.Errorf("expected no issues for distinct or empty DOIs, got %+v", issues)
+	}
+}
+
+func TestMissingAbstractIssues(t *testing.T) {
+	n := semantic.MinAbstractLength
+	long := strings.Repeat("x", n)
+	refs := []reference.Reference{
+		{ID: "A", Abstract: long},
+		{ID: "B"},
+		{ID: "C", Abstract: "Too short."},
+		{ID: "D", Abstract: long[:n-1]},
+		{ID: "E", Abstract: strings.Repeat("é", n-1)}, // More bytes than n, but too few characters
+		{ID: "F", Abstract: strings.Repeat("é", n)},
+	}
+
+	issues := missingAbstractIssues(refs)
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1: %+v", len(issues), issues)
+	}
+	issue := issues[0]
+	if issue.Type != "missing_abstract" || issue.Count != 4 {
+		t.Errorf("issue = %+v, want missing_abstract with count 4", issue)
+	}
+	if want := []string{"B", "C", "D", "E"}; !reflect.DeepEqual(issue.IDs, want) {
+		t.Errorf("IDs = %v, want %v", issue.IDs, want)
+	}
+
+	// check reports exactly the papers the semantic index skips
+	reported := make(map[string]bool)
+	for _, id := range issue.IDs {
+		reported[id] = true
+	}
+	for _, ref := range refs {
+		status := semantic.PaperIndexStatus(ref.Abstract, false, nil)
+		skipped := status == semantic.IndexStatusNoAbstract || status == semantic.IndexStatusTooShort
+		if reported[ref.ID] != skipped {
+			t.Errorf("%s: reported = %v, but index status is %s", ref.ID, reported[ref.ID], status)
+		}
+	}
+
+	if issues := missingAbstractIssues(refs[:1]); len(issues) != 0 {
+		t.Errorf("expected no issues when every abstract is long enough, got %+v", issues)
+	}
+}
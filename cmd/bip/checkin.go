@@ -23,6 +23,21 @@ the body, or have previously commented. Use --broad to restore the older
 behavior (every teammate item counts as needing review), or --all to
 disable ball-in-my-court filtering entirely.
 
+With --assignee-aware, an item assigned to someone else is in their court
+regardless of who acted last, unless you are a requested reviewer on it.
+Unassigned items are filtered as usual, so teams that don't use assignment
+see no change.
+
+"You" is the login given by --me, else github.user in
+~/.config/bip/config.yml, else the account gh is logged in as. If none
+of these yields a login, checkin exits with an error rather than guess.
+The gh login is cached in .bipartite/cache/github_user.json for 30 days;
+--refresh-user looks it up again, e.g. after switching gh accounts.
+
+--label keeps only items carrying one of the given labels, and
+--exclude-label drops items carrying any of them (exclusion wins). Both
+are repeatable and apply with or without ball-in-my-court filtering.
+
 The activity window defaults to the timestamp in .last-checkin.json (falling
 back to 3 days if the file doesn't exist). Each run updates .last-checkin.json
 so the next run picks up where you left off. Using --since overrides this
@@ -33,23 +48,33 @@ Requires nexus_path configured in ~/.config/bip/config.yml.`,
 }
 
 var (
-	checkinSince     string
-	checkinRepo      string
-	checkinCategory  string
-	checkinAll       bool
-	checkinBroad     bool
-	checkinSummarize bool
+	checkinSince       string
+	checkinRepo        string
+	checkinCategory    string
+	checkinAll         bool
+	checkinBroad       bool
+	checkinSummarize   bool
+	checkinAssignee    bool
+	checkinLabels      []string
+	checkinExclude     []string
+	checkinMe          string
+	checkinRefreshUser bool
 )
 
 func init() {
 	rootCmd.AddCommand(checkinCmd)
 
-	checkinCmd.Flags().StringVar(&checkinSince, "since", "3d", "Time period (e.g., 2d, 12h, 1w); does not update .last-checkin.json")
+	checkinCmd.Flags().StringVar(&checkinSince, "since", "3d", "Time period (e.g., 2d, 12h, 1w) or start time (YYYY-MM-DD, RFC3339); does not update .last-checkin.json")
 	checkinCmd.Flags().StringVar(&checkinRepo, "repo", "", "Check single repo only")
 	checkinCmd.Flags().StringVar(&checkinCategory, "category", "", "Check repos in category only (code, writing)")
 	checkinCmd.Flags().BoolVar(&checkinAll, "all", false, "Show all activity (disable ball-in-my-court filtering)")
 	checkinCmd.Flags().BoolVar(&checkinBroad, "broad", false, "Use the legacy broad filter (count every teammate item as needing review)")
+	checkinCmd.Flags().StringArrayVar(&checkinLabels, "label", nil, "Only show items with this label (repeatable)")
+	checkinCmd.Flags().StringArrayVar(&checkinExclude, "exclude-label", nil, "Hide items with this label (repeatable)")
 	checkinCmd.Flags().BoolVar(&checkinSummarize, "summarize", false, "Generate LLM take-home summaries")
+	checkinCmd.Flags().StringVar(&checkinMe, "me", "", "GitHub login to treat as you (overrides github.user and gh detection)")
+	checkinCmd.Flags().BoolVar(&checkinRefreshUser, "refresh-user", false, "Re-detect the GitHub login instead of using the cached one")
+	checkinCmd.Flags().BoolVar(&checkinAssignee, "assignee-aware", false, "Treat items assigned to someone else as in their court (unless you are a reviewer)")
 }
 
 func runCheckin(cmd *cobra.Command, args []string) {
@@ -62,12 +87,12 @@ func runCheckin(cmd *cobra.Command, args []string) {
 	// Otherwise, read from .last-checkin.json, falling back to 3d.
 	var since time.Time
 	if cmd.Flags().Changed("since") {
-		duration, err := flow.ParseDuration(checkinSince)
+		var err error
+		since, err = flow.ParseSince(checkinSince)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: invalid --since value: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		since = now.Add(-duration)
 	} else {
 		lastCheckin := flow.ReadLastCheckin(nexusPath)
 		if lastCheckin.IsZero() {
@@ -105,10 +130,11 @@ func runCheckin(cmd *cobra.Command, args []string) {
 	// Get GitHub user for ball-in-my-court filtering
 	var githubUser string
 	if !checkinAll {
-		githubUser, err = flow.GetGitHubUser()
+		githubUser, err = flow.ResolveGitHubUser(nexusPath, checkinMe, checkinRefreshUser)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not get GitHub user: %v\n", err)
-			fmt.Fprintf(os.Stderr, "Showing all activity (ball-in-my-court filtering disabled)\n\n")
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Or use --all to show all activity without ball-in-my-court filtering.\n")
+			os.Exit(1)
 		}
 	}
 
@@ -123,16 +149,6 @@ func runCheckin(cmd *cobra.Command, args []string) {
 			continue
 		}
 
-		issueComments, err := flow.FetchIssueComments(repo, since)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to fetch issue comments for %s: %v\n", repo, err)
-		}
-		prComments, err := flow.FetchPRComments(repo, since)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to fetch PR comments for %s: %v\n", repo, err)
-		}
-		allComments := append(issueComments, prComments...)
-
 		// Split into issues and PRs
 		var issues, prs []flow.GitHubItem
 		for _, item := range items {
@@ -142,60 +158,58 @@ func runCheckin(cmd *cobra.Command, args []string) {
 				issues = append(issues, item)
 			}
 		}
+		issues = flow.FilterByLabels(issues, checkinLabels, checkinExclude)
+		prs = flow.FilterByLabels(prs, checkinLabels, checkinExclude)
 
-		// Fetch ALL PR reviews (no time filter) in a single batch call.
-		// For display, only since-window reviews are added to allComments.
-		// For ball-in-court, all reviews are included as actions.
-		var allReviewComments []flow.GitHubComment
-		if len(prs) > 0 {
-			var prNumbers []int
-			for _, pr := range prs {
-				prNumbers = append(prNumbers, pr.Number)
-			}
-			allReviewComments = flow.FetchPRReviewsAsComments(repo, prNumbers, time.Time{})
-			// Add only since-window reviews to display comments
-			for _, rc := range allReviewComments {
-				if !rc.UpdatedAt.Before(since) {
-					allComments = append(allComments, rc)
-				}
-			}
-		}
-
-		// Convert to unified actions for ball-in-court filtering.
-		// Only comments and reviews drive ball-in-court logic;
-		// close/merge events are administrative, not conversational.
-		allActions := flow.CommentsToActions(allComments)
+		// Window comments for display, plus ALL reviews on the PRs (no time
+		// filter) for ball-in-court.
+		labeled := append(append([]flow.GitHubItem{}, issues...), prs...)
+		activity := flow.FetchCourtActivity(repo, labeled, since)
+		allComments := activity.Comments
 
 		// Apply ball-in-my-court filtering if enabled
+		var reasons map[int]string
 		if githubUser != "" {
-			// Include ALL PR reviews (not just since-filtered) for ball-in-court,
-			// since a review predating the window is still relevant. This may
-			// duplicate since-window reviews already in allActions; duplicates
-			// are harmless since only the last actor per item matters.
-			allActions = append(allActions, flow.CommentsToActions(allReviewComments)...)
-
-			// Enrich actions: for items with no actions at all, fetch their
-			// last comment so ball-in-court doesn't fall through to the default.
-			// This fixes the bug where the user's comment predates the since window.
-			itemsForEnrich := append(append([]flow.GitHubItem{}, issues...), prs...)
-			enriched := flow.EnrichActionsWithLastComments(repo, itemsForEnrich, allActions)
-			allActions = append(allActions, enriched...)
+			// The same timeline digest uses: window comments, every PR
+			// review, and the last comment on items with no other actions.
+			allActions := flow.CourtActions(repo, labeled, activity)
+
+			// Requested reviewers feed both the strict involvement check and
+			// the assignee-aware reviewer exception.
+			if !checkinBroad || checkinAssignee {
+				prs = enrichPRsWithRequestedReviewers(repo, prs)
+			}
+			if checkinAssignee {
+				issues = flow.FilterAssignedElsewhere(issues, githubUser)
+				prs = flow.FilterAssignedElsewhere(prs, githubUser)
+			}
 
+			// Each kept item's reason comes from the filter that kept it
+			var court func(flow.GitHubItem) (bool, string)
 			if checkinBroad {
-				issues = flow.FilterByBallInCourt(issues, allActions, githubUser)
-				prs = flow.FilterByBallInCourt(prs, allActions, githubUser)
+				court = func(item flow.GitHubItem) (bool, string) {
+					return flow.BallInMyCourtReason(item, allActions, githubUser)
+				}
 			} else {
 				// Strict filter: for teammate items with no window activity, require
-				// some signal of involvement. Populate PR requested reviewers and
-				// past commenters for items that would otherwise fall through.
-				prs = enrichPRsWithRequestedReviewers(repo, prs)
+				// some signal of involvement. Populate past commenters for items
+				// that would otherwise fall through.
 				inv := flow.Involvement{
 					Commenters: fetchCommentersForUnengaged(repo, issues, prs, allActions, githubUser),
 				}
-				issues = flow.FilterByBallInCourtStrict(issues, allActions, githubUser, inv)
-				prs = flow.FilterByBallInCourtStrict(prs, allActions, githubUser, inv)
+				court = func(item flow.GitHubItem) (bool, string) {
+					return flow.BallInMyCourtStrictReason(item, allActions, githubUser, inv)
+				}
+			}
+			var issueReasons map[int]string
+			issues, issueReasons = flow.FilterByCourtWithReasons(issues, court)
+			prs, reasons = flow.FilterByCourtWithReasons(prs, court)
+			for n, reason := range issueReasons {
+				reasons[n] = reason
 			}
 			allComments = flow.FilterCommentsByItems(allComments, append(issues, prs...))
+		} else if len(checkinLabels) > 0 || len(checkinExclude) > 0 {
+			allComments = flow.FilterCommentsByItems(allComments, append(issues, prs...))
 		}
 
 		if len(issues) == 0 && len(prs) == 0 && len(allComments) == 0 {
@@ -205,12 +219,12 @@ func runCheckin(cmd *cobra.Command, args []string) {
 		fmt.Printf("## %s\n", repo)
 
 		if len(issues) > 0 {
-			printItems(issues, "Issues", since)
+			printItems(issues, "Issues", since, reasons)
 			totalIssues += len(issues)
 		}
 
 		if len(prs) > 0 {
-			printItems(prs, "Pull Requests", since)
+			printItems(prs, "Pull Requests", since, reasons)
 			totalPRs += len(prs)
 		}
 
@@ -223,8 +237,13 @@ func runCheckin(cmd *cobra.Command, args []string) {
 
 		// Collect items for summarization
 		if checkinSummarize {
-			for _, item := range append(issues, prs...) {
-				comments, _ := flow.FetchItemComments(repo, item.Number, 10)
+			repoItems := append(issues, prs...)
+			var numbers []int
+			for _, item := range repoItems {
+				numbers = append(numbers, item.Number)
+			}
+			details := flow.FetchItemDetailsBatch(repo, numbers, 10)
+			for _, item := range repoItems {
 				allItems = append(allItems, flow.ItemDetails{
 					Ref:      fmt.Sprintf("%s#%d", repo, item.Number),
 					Title:    item.Title,
@@ -232,7 +251,7 @@ func runCheckin(cmd *cobra.Command, args []string) {
 					Body:     item.Body,
 					IsPR:     item.IsPR,
 					State:    item.State,
-					Comments: comments,
+					Comments: details[item.Number].Comments,
 				})
 			}
 		}
@@ -265,16 +284,19 @@ func runCheckin(cmd *cobra.Command, args []string) {
 }
 
 // enrichPRsWithRequestedReviewers populates RequestedReviewers on each PR via a
-// single batched GraphQL call. On failure, PRs keep empty reviewer lists and a
-// warning goes to stderr — the strict filter still runs using the other
-// involvement signals (assignees, mentions, past comments).
+// single batched GraphQL call; issues in the list are left alone. On failure,
+// PRs keep empty reviewer lists and a warning goes to stderr — the strict
+// filter still runs using the other involvement signals (assignees, mentions,
+// past comments).
 func enrichPRsWithRequestedReviewers(repo string, prs []flow.GitHubItem) []flow.GitHubItem {
-	if len(prs) == 0 {
-		return prs
-	}
 	var numbers []int
 	for _, p := range prs {
-		numbers = append(numbers, p.Number)
+		if p.IsPR {
+			numbers = append(numbers, p.Number)
+		}
+	}
+	if len(numbers) == 0 {
+		return prs
 	}
 	reviewers, err := flow.FetchPRsRequestedReviewers(repo, numbers)
 	if err != nil {
@@ -282,6 +304,9 @@ func enrichPRsWithRequestedReviewers(repo string, prs []flow.GitHubItem) []flow.
 		return prs
 	}
 	for i, pr := range prs {
+		if !pr.IsPR {
+			continue
+		}
 		logins := reviewers[pr.Number]
 		var users []flow.GitHubUser
 		for _, l := range logins {
@@ -419,7 +444,9 @@ func extractNumberFromRef(ref string) int {
 	return 0
 }
 
-func printItems(items []flow.GitHubItem, label string, since time.Time) {
+// printItems lists items under a heading. When reasons is non-nil, each
+// item's ball-in-court reason is appended.
+func printItems(items []flow.GitHubItem, label string, since time.Time, reasons map[int]string) {
 	fmt.Printf("\n### %s (%d)\n", label, len(items))
 
 	limit := 10
@@ -435,7 +462,11 @@ func printItems(items []flow.GitHubItem, label string, since time.Time) {
 		}
 
 		timeAgo := flow.FormatTimeAgo(item.UpdatedAt)
-		fmt.Printf("  [%s] %s - %s (%s)\n", marker, item.HTMLURL, item.Title, timeAgo)
+		fmt.Printf("  [%s] %s - %s (%s)", marker, item.HTMLURL, item.Title, timeAgo)
+		if reason := reasons[item.Number]; reason != "" {
+			fmt.Printf(" — %s", reason)
+		}
+		fmt.Println()
 	}
 }
 
@@ -3,21 +3,18 @@ package main
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/matsen/bipartite/internal/concept"
 	"github.com/matsen/bipartite/internal/config"
 	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/flow"
 	"github.com/matsen/bipartite/internal/storage"
 	"github.com/spf13/cobra"
 )
 
-// Exit codes for concept commands (per CLI contract)
-const (
-	ExitConceptNotFound   = 2 // Concept not found
-	ExitConceptValidation = 3 // Validation error (invalid ID, duplicate, has edges)
-)
-
 func init() {
 	rootCmd.AddCommand(conceptCmd)
 
@@ -31,7 +28,9 @@ func init() {
 	// concept get - no extra flags
 	conceptCmd.AddCommand(conceptGetCmd)
 
-	// concept list - no extra flags
+	// concept list flags
+	conceptListCmd.Flags().Int("limit", 0, "Maximum concepts to return (0 = all)")
+	conceptListCmd.Flags().Int("offset", 0, "Skip this many concepts (ordered by ID)")
 	conceptCmd.AddCommand(conceptListCmd)
 
 	// concept update flags
@@ -46,9 +45,16 @@ func init() {
 
 	// concept papers flags
 	conceptPapersCmd.Flags().StringP("type", "t", "", "Filter by relationship type")
+	conceptPapersCmd.Flags().String("since", "", "Only edges created at or after this time: a duration (7d, 2w, 36h), YYYY-MM-DD, or RFC3339")
+	conceptPapersCmd.Flags().Int("limit", 0, "Maximum papers to return (0 = all; default from search.default_limit)")
+	conceptPapersCmd.Flags().Bool("transitive", false, "Also include papers linked to descendant concepts")
+	conceptPapersCmd.Flags().String("format", "json", "Output format: json or csv")
+	conceptPapersCmd.Flags().String("follow", defaultConceptTreeType, "With --transitive, the concept→concept relationship type that leads to descendants")
 	conceptCmd.AddCommand(conceptPapersCmd)
 
-	// concept merge - no extra flags
+	// concept merge flags
+	conceptMergeCmd.Flags().Bool("dry-run", false, "Show what the merge would change without writing")
+	conceptMergeCmd.Flags().Bool("preview", false, "Alias for --dry-run")
 	conceptCmd.AddCommand(conceptMergeCmd)
 }
 
@@ -114,15 +120,17 @@ func runConceptAdd(cmd *cobra.Command, args []string) error {
 		exitWithError(ExitConceptValidation, "concept with id %q already exists", conceptID)
 	}
 
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexConcepts, conceptsPath)
+
 	// Append to JSONL
 	if err := storage.AppendConcept(conceptsPath, c); err != nil {
 		exitWithError(ExitDataError, "writing concept: %v", err)
 	}
 
 	// Update SQLite index
-	db := mustOpenDatabase(repoRoot)
-	defer db.Close()
-	if _, err := db.RebuildConceptsFromJSONL(conceptsPath); err != nil {
+	if err := db.ApplyIndexUpdate(storage.IndexConcepts, conceptsPath, indexCurrent, func() error { return db.UpsertConcept(c) }); err != nil {
 		exitWithError(ExitDataError, "updating index: %v", err)
 	}
 
@@ -172,50 +180,80 @@ func runConceptGet(cmd *cobra.Command, args []string) error {
 }
 
 // ConceptListResult is the response for the concept list command.
+// Count is the number returned, Total the number in the repository.
 type ConceptListResult struct {
 	Concepts []concept.Concept `json:"concepts"`
 	Count    int               `json:"count"`
+	Total    int               `json:"total"`
 }
 
 var conceptListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all concepts",
-	Long:  `List all concept nodes in the knowledge graph.`,
-	RunE:  runConceptList,
+	Long: `List all concept nodes in the knowledge graph, ordered by ID.
+
+Use --limit and --offset to page through them; the JSON output's count is
+the size of this page and total the number of concepts.
+
+Examples:
+  bip concept list --limit 50
+  bip concept list --limit 50 --offset 50`,
+	RunE: runConceptList,
 }
 
 func runConceptList(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
 
+	limit, _ := cmd.Flags().GetInt("limit")
+	offset, _ := cmd.Flags().GetInt("offset")
+	if limit < 0 || offset < 0 {
+		exitWithError(ExitError, "--limit and --offset must not be negative")
+	}
+
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
 
-	concepts, err := db.GetAllConcepts()
+	total, err := db.CountConcepts()
 	if err != nil {
-		exitWithError(ExitDataError, "querying concepts: %v", err)
+		exitWithError(ExitDataError, "counting concepts: %v", err)
+	}
+	items := listItems[concept.Concept](func(yield func(concept.Concept) error) error {
+		return db.GetConceptsPagedEach(limit, offset, yield)
+	})
+
+	if !humanOutput {
+		err := outputList(items, func(concepts []concept.Concept) any {
+			return ConceptListResult{
+				Concepts: concepts,
+				Count:    len(concepts),
+				Total:    total,
+			}
+		})
+		if err != nil {
+			exitWithError(ExitDataError, "querying concepts: %v", err)
+		}
+		return nil
 	}
 
-	if humanOutput {
-		if len(concepts) == 0 {
-			fmt.Println("No concepts found")
-			return nil
-		}
-		for i, c := range concepts {
-			if i > 0 {
-				fmt.Println()
-			}
-			// Use formatConceptHuman but skip description for list view
-			fmt.Print(formatConceptHuman(c.ID, c.Name, c.Aliases, "", ""))
+	concepts, err := collectItems(items)
+	if err != nil {
+		exitWithError(ExitDataError, "querying concepts: %v", err)
+	}
+	if len(concepts) == 0 {
+		fmt.Println("No concepts found")
+		return nil
+	}
+	for i, c := range concepts {
+		if i > 0 {
+			fmt.Println()
 		}
-		fmt.Printf("Total: %d concepts\n", len(concepts))
+		// Use formatConceptHuman but skip description for list view
+		fmt.Print(formatConceptHuman(c.ID, c.Name, c.Aliases, "", ""))
+	}
+	if len(concepts) < total {
+		fmt.Printf("Showing %d-%d of %d concepts\n", offset+1, offset+len(concepts), total)
 	} else {
-		if concepts == nil {
-			concepts = []concept.Concept{}
-		}
-		outputJSON(ConceptListResult{
-			Concepts: concepts,
-			Count:    len(concepts),
-		})
+		fmt.Printf("Total: %d concepts\n", len(concepts))
 	}
 
 	return nil
@@ -288,15 +326,17 @@ func runConceptUpdate(cmd *cobra.Command, args []string) error {
 
 	concepts[idx] = c
 
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexConcepts, conceptsPath)
+
 	// Write back
 	if err := storage.WriteAllConcepts(conceptsPath, concepts); err != nil {
 		exitWithError(ExitDataError, "writing concepts: %v", err)
 	}
 
 	// Update SQLite index
-	db := mustOpenDatabase(repoRoot)
-	defer db.Close()
-	if _, err := db.RebuildConceptsFromJSONL(conceptsPath); err != nil {
+	if err := db.ApplyIndexUpdate(storage.IndexConcepts, conceptsPath, indexCurrent, func() error { return db.UpsertConcept(c) }); err != nil {
 		exitWithError(ExitDataError, "updating index: %v", err)
 	}
 
@@ -323,6 +363,7 @@ type ConceptDeleteResult struct {
 // ConceptDeleteBlockedResult is the response when delete is blocked by edges.
 type ConceptDeleteBlockedResult struct {
 	Error     string `json:"error"`
+	ErrorCode string `json:"error_code"`
 	EdgeCount int    `json:"edge_count"`
 }
 
@@ -343,10 +384,11 @@ func checkConceptDeleteBlocked(conceptID string, edgeCount int, force bool) {
 		} else {
 			outputJSON(ConceptDeleteBlockedResult{
 				Error:     fmt.Sprintf("concept %q has %d linked edges; use --force to delete anyway", conceptID, edgeCount),
+				ErrorCode: ExitConceptValidation.ErrorCode(),
 				EdgeCount: edgeCount,
 			})
 		}
-		os.Exit(ExitConceptValidation)
+		os.Exit(ExitConceptValidation.Status())
 	}
 }
 
@@ -450,23 +492,98 @@ func runConceptDelete(cmd *cobra.Command, args []string) error {
 
 // ConceptPapersResult is the response for the concept papers command.
 type ConceptPapersResult struct {
-	ConceptID string                     `json:"concept_id"`
-	Papers    []storage.PaperConceptEdge `json:"papers"`
-	Count     int                        `json:"count"`
+	ConceptID string         `json:"concept_id"`
+	Papers    []ConceptPaper `json:"papers"`
+	Count     int            `json:"count"`
+}
+
+// ConceptPaper is a paper linked to the queried concept. With --transitive,
+// ViaConcept is the concept the paper is linked to and ConceptPath the chain
+// from the queried concept down to it.
+type ConceptPaper struct {
+	storage.PaperConceptEdge
+	ViaConcept  string   `json:"via_concept,omitempty"`
+	ConceptPath []string `json:"concept_path,omitempty"`
 }
 
 var conceptPapersCmd = &cobra.Command{
 	Use:   "papers <concept-id>",
 	Short: "List papers linked to a concept",
-	Long:  `Query all papers linked to a specific concept, optionally filtered by relationship type.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runConceptPapers,
+	Long: `Query all papers linked to a specific concept, optionally filtered by relationship type.
+
+Use --since to review only the links created after a cutoff (e.g., after an
+agent session). Edges without a created_at timestamp are excluded when
+--since is set, and results are ordered by creation time.
+
+With --transitive, papers linked to any concept below this one are included
+too, following subconcept-of edges (or the type given by --follow) down the
+hierarchy; cycles are cut. Each paper appears once, through the nearest
+concept it is linked to, with via_concept and concept_path showing how it
+was reached.
+
+With --format csv, the papers are written as CSV with a header row
+(paper_id, via_concept, relationship_type, summary), e.g. to share a
+reading list; via_concept is the queried concept unless --transitive found
+the paper further down.
+
+Examples:
+  bip concept papers somatic-hypermutation
+  bip concept papers somatic-hypermutation --format csv > reading.csv
+  bip concept papers somatic-hypermutation --type introduces
+  bip concept papers machine-learning --transitive --human
+  bip concept papers somatic-hypermutation --since 2026-01-15 --human
+  bip concept papers somatic-hypermutation --since 7d --human`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConceptPapers,
+}
+
+// filterEdgesCreatedSince returns edges created at or after the cutoff, oldest first.
+// Edges without a parseable CreatedAt are dropped since their age is unknown.
+// Edges are ordered by parsed time, since timestamps in different zones do
+// not sort as strings.
+func filterEdgesCreatedSince(edges []storage.PaperConceptEdge, cutoff time.Time) []storage.PaperConceptEdge {
+	type datedEdge struct {
+		edge    storage.PaperConceptEdge
+		created time.Time
+	}
+	var dated []datedEdge
+	for _, e := range edges {
+		created, err := time.Parse(time.RFC3339, e.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if !created.Before(cutoff) {
+			dated = append(dated, datedEdge{e, created})
+		}
+	}
+	sort.SliceStable(dated, func(i, j int) bool { return dated[i].created.Before(dated[j].created) })
+
+	var result []storage.PaperConceptEdge
+	for _, d := range dated {
+		result = append(result, d.edge)
+	}
+	return result
 }
 
 func runConceptPapers(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
 	conceptID := args[0]
 	relType, _ := cmd.Flags().GetString("type")
+	since, _ := cmd.Flags().GetString("since")
+	transitive, _ := cmd.Flags().GetBool("transitive")
+	follow, _ := cmd.Flags().GetString("follow")
+	format, _ := cmd.Flags().GetString("format")
+	validateFormatFlag(ExitError, format)
+	limit := resolveSearchLimit(cmd, mustLoadConfig(repoRoot), 0)
+
+	var cutoff time.Time
+	if since != "" {
+		var err error
+		cutoff, err = flow.ParseSince(since)
+		if err != nil {
+			exitWithError(ExitError, "%v", err)
+		}
+	}
 
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
@@ -480,133 +597,294 @@ func runConceptPapers(cmd *cobra.Command, args []string) error {
 		exitWithError(ExitConceptNotFound, "concept %q not found", conceptID)
 	}
 
-	// Get papers
-	papers, err := db.GetPapersByConcept(conceptID, relType)
+	// Get papers: streamed from the index, unless the transitive walk or
+	// the --since ordering needs them all first
+	var linked listItems[storage.PaperConceptEdge]
+	var paths map[string][]string
+	if transitive {
+		edges, err := storage.ReadAllEdges(config.EdgesPath(repoRoot))
+		if err != nil {
+			exitWithError(ExitDataError, "reading edges: %v", err)
+		}
+		var papers []storage.PaperConceptEdge
+		papers, paths = collectTransitiveConceptPapers(conceptID, edges, follow, relType)
+		linked = sliceItems(papers)
+	} else {
+		linked = func(yield func(storage.PaperConceptEdge) error) error {
+			return db.GetPapersByConceptEach(conceptID, relType, yield)
+		}
+	}
+	if since != "" {
+		papers, err := collectItems(linked)
+		if err != nil {
+			exitWithError(ExitDataError, "querying papers: %v", err)
+		}
+		linked = sliceItems(filterEdgesCreatedSince(papers, cutoff))
+	}
+	linked = limitItems(linked, limit)
+
+	if format == "json" && !humanOutput {
+		results := mapItems(linked, func(e storage.PaperConceptEdge) ConceptPaper {
+			cp := ConceptPaper{PaperConceptEdge: e}
+			if path, ok := paths[e.PaperID]; ok {
+				cp.ViaConcept = path[len(path)-1]
+				cp.ConceptPath = path
+			}
+			return cp
+		})
+		err := outputList(results, func(papers []ConceptPaper) any {
+			return ConceptPapersResult{
+				ConceptID: conceptID,
+				Papers:    papers,
+				Count:     len(papers),
+			}
+		})
+		if err != nil {
+			exitWithError(ExitDataError, "querying papers: %v", err)
+		}
+		return nil
+	}
+
+	papers, err := collectItems(linked)
 	if err != nil {
 		exitWithError(ExitDataError, "querying papers: %v", err)
 	}
 
-	if humanOutput {
-		fmt.Printf("Papers linked to: %s\n", conceptID)
-		if len(papers) == 0 {
-			fmt.Println("\n(no papers)")
-		} else {
-			fmt.Print(formatEdgesGroupedByType(papers, func(e storage.PaperConceptEdge) string {
-				return e.PaperID
-			}))
+	if format == "csv" {
+		rows := make([][]string, len(papers))
+		for i, e := range papers {
+			via := conceptID
+			if path, ok := paths[e.PaperID]; ok {
+				via = path[len(path)-1]
+			}
+			rows[i] = []string{e.PaperID, via, e.RelationshipType, e.Summary}
 		}
-		fmt.Printf("\nTotal: %d papers\n", len(papers))
-	} else {
-		if papers == nil {
-			papers = []storage.PaperConceptEdge{}
+		if err := writeCSV(os.Stdout, paperLinkCSVHeader, rows); err != nil {
+			exitWithError(ExitDataError, "writing CSV: %v", err)
 		}
-		outputJSON(ConceptPapersResult{
-			ConceptID: conceptID,
-			Papers:    papers,
-			Count:     len(papers),
-		})
+		return nil
 	}
 
+	fmt.Printf("Papers linked to: %s\n", conceptID)
+	if since != "" {
+		fmt.Printf("Linked since: %s\n", since)
+	}
+	if len(papers) == 0 {
+		fmt.Println("\n(no papers)")
+	} else if since != "" {
+		fmt.Println()
+		for _, e := range papers {
+			fmt.Printf("  %s  %s [%s]: %s\n", e.CreatedAt, e.PaperID, e.RelationshipType, e.Summary)
+		}
+	} else {
+		fmt.Print(formatEdgesGroupedByType(papers, func(e storage.PaperConceptEdge) string {
+			if path := paths[e.PaperID]; len(path) > 1 {
+				return e.PaperID + " (via " + strings.Join(path, " > ") + ")"
+			}
+			return e.PaperID
+		}))
+	}
+	fmt.Printf("\nTotal: %d papers\n", len(papers))
+
 	return nil
 }
 
+// collectTransitiveConceptPapers returns the papers linked to conceptID or
+// to any concept below it along follow edges, optionally only through
+// relType paper→concept edges. Each paper appears once, through the concept
+// nearest conceptID (ties by concept ID), and the returned paths map each
+// paper ID to the bare concept IDs from conceptID down to that concept.
+// Papers are ordered by relationship type, then paper ID.
+func collectTransitiveConceptPapers(conceptID string, edges []edge.Edge, follow, relType string) ([]storage.PaperConceptEdge, map[string][]string) {
+	conceptPaths := expandConceptPaths([]string{"concept:" + conceptID}, edges, follow, 0)
+
+	paths := make(map[string][]string)
+	byPaper := make(map[string]storage.PaperConceptEdge)
+	for _, e := range edges {
+		path, ok := conceptPaths[e.TargetID]
+		if !ok || strings.Contains(e.SourceID, ":") || (relType != "" && e.RelationshipType != relType) {
+			continue
+		}
+		if prev, seen := paths[e.SourceID]; seen && !conceptPathBefore(path, prev, byPaper[e.SourceID], e) {
+			continue
+		}
+		bare := make([]string, len(path))
+		for i, c := range path {
+			bare[i] = strings.TrimPrefix(c, "concept:")
+		}
+		paths[e.SourceID] = bare
+		byPaper[e.SourceID] = storage.PaperConceptEdge{
+			PaperID:          e.SourceID,
+			RelationshipType: e.RelationshipType,
+			Summary:          e.Summary,
+			Strength:         e.Strength,
+			CreatedAt:        e.CreatedAt,
+		}
+	}
+
+	papers := make([]storage.PaperConceptEdge, 0, len(byPaper))
+	for _, p := range byPaper {
+		papers = append(papers, p)
+	}
+	sort.Slice(papers, func(i, j int) bool {
+		if papers[i].RelationshipType != papers[j].RelationshipType {
+			return papers[i].RelationshipType < papers[j].RelationshipType
+		}
+		return papers[i].PaperID < papers[j].PaperID
+	})
+	return papers, paths
+}
+
+// conceptPathBefore reports whether a paper reached through the prefixed
+// concept path, by edge e, should replace the one kept so far: shorter paths
+// win, then the lower concept ID, then the lower relationship type.
+func conceptPathBefore(path, keptBare []string, kept storage.PaperConceptEdge, e edge.Edge) bool {
+	if len(path) != len(keptBare) {
+		return len(path) < len(keptBare)
+	}
+	if via, keptVia := strings.TrimPrefix(path[len(path)-1], "concept:"), keptBare[len(keptBare)-1]; via != keptVia {
+		return via < keptVia
+	}
+	return e.RelationshipType < kept.RelationshipType
+}
+
 // ConceptMergeResult is the response for the concept merge command.
+// With --dry-run, Status is "dry_run" and nothing is written.
 type ConceptMergeResult struct {
-	Status            string   `json:"status"`
-	SourceID          string   `json:"source_id"`
-	TargetID          string   `json:"target_id"`
-	EdgesUpdated      int      `json:"edges_updated"`
-	AliasesAdded      []string `json:"aliases_added"`
-	DuplicatesRemoved int      `json:"duplicates_removed"`
+	Status            string      `json:"status"`
+	SourceID          string      `json:"source_id"`
+	TargetID          string      `json:"target_id"`
+	EdgesUpdated      int         `json:"edges_updated"`
+	AliasesAdded      []string    `json:"aliases_added"`
+	DuplicatesRemoved int         `json:"duplicates_removed"`
+	RepointedEdges    []edge.Edge `json:"repointed_edges,omitempty"` // As they will read after the merge
+	RemovedEdges      []edge.Edge `json:"removed_edges,omitempty"`   // Duplicates dropped after repointing
 }
 
 var conceptMergeCmd = &cobra.Command{
 	Use:   "merge <source-id> <target-id>",
 	Short: "Merge one concept into another",
-	Long:  `Merge source concept into target concept, transferring all edges.`,
-	Args:  cobra.ExactArgs(2),
-	RunE:  runConceptMerge,
+	Long: `Merge source concept into target concept, transferring all edges.
+
+The source concept's name and aliases become aliases of the target, edges
+pointing at the source are repointed to the target, and any edges that become
+duplicates are removed (keeping the earliest created).
+
+Use --dry-run (or --preview) to list the repointed edges, added aliases, and
+removed duplicates without writing anything.
+
+Examples:
+  bip concept merge vae variational-autoencoder --dry-run --human
+  bip concept merge vae variational-autoencoder`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConceptMerge,
+}
+
+// conceptMergePlan is the computed outcome of merging one concept into another.
+type conceptMergePlan struct {
+	Concepts     []concept.Concept // Concepts after the merge (source removed, target updated)
+	Edges        []edge.Edge       // Edges after repointing and deduplication
+	AliasesAdded []string
+	Repointed    []edge.Edge // Edges whose target changed, after repointing
+	Removed      []edge.Edge // Duplicate edges dropped
+}
+
+// planConceptMerge computes the result of merging sourceID into targetID
+// without writing anything. The concepts and edges slices are not modified.
+func planConceptMerge(concepts []concept.Concept, edges []edge.Edge, sourceID, targetID string) (conceptMergePlan, error) {
+	var plan conceptMergePlan
+
+	sourceIdx, found := storage.FindConceptByID(concepts, sourceID)
+	if !found {
+		return plan, fmt.Errorf("source concept %q not found", sourceID)
+	}
+	targetIdx, found := storage.FindConceptByID(concepts, targetID)
+	if !found {
+		return plan, fmt.Errorf("target concept %q not found", targetID)
+	}
+
+	// Merge aliases into a copy so a preview leaves the input untouched
+	merged := append([]concept.Concept(nil), concepts...)
+	merged[targetIdx].Aliases = append([]string(nil), merged[targetIdx].Aliases...)
+	plan.AliasesAdded = merged[targetIdx].MergeAliases(&concepts[sourceIdx])
+	plan.Concepts, _ = storage.DeleteConceptFromSlice(merged, sourceID)
+
+	repointed := make([]edge.Edge, len(edges))
+	for i, e := range edges {
+		if e.TargetID == sourceID {
+			e.TargetID = targetID
+			plan.Repointed = append(plan.Repointed, e)
+		}
+		repointed[i] = e
+	}
+
+	// Deduplicate edges (same source_id + target_id + relationship_type),
+	// keeping the one with earlier created_at
+	plan.Edges, plan.Removed = edge.DedupeEdges(repointed)
+
+	return plan, nil
 }
 
 func runConceptMerge(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
 	sourceID := args[0]
 	targetID := args[1]
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if preview, _ := cmd.Flags().GetBool("preview"); preview {
+		dryRun = true
+	}
 
 	// Validate not same
 	if sourceID == targetID {
 		exitWithError(ExitConceptValidation, "source and target concepts cannot be the same")
 	}
 
-	// Load concepts
+	// Load concepts and edges
 	conceptsPath := config.ConceptsPath(repoRoot)
 	concepts, err := storage.ReadAllConcepts(conceptsPath)
 	if err != nil {
 		exitWithError(ExitDataError, "reading concepts: %v", err)
 	}
-
-	// Find source
-	sourceIdx, found := storage.FindConceptByID(concepts, sourceID)
-	if !found {
-		exitWithError(ExitConceptNotFound, "source concept %q not found", sourceID)
-	}
-	sourceConcept := concepts[sourceIdx]
-
-	// Find target
-	targetIdx, found := storage.FindConceptByID(concepts, targetID)
-	if !found {
-		exitWithError(ExitConceptNotFound, "target concept %q not found", targetID)
-	}
-	targetConcept := &concepts[targetIdx]
-
-	// Merge aliases
-	aliasesAdded := targetConcept.MergeAliases(&sourceConcept)
-
-	// Delete source concept
-	concepts, _ = storage.DeleteConceptFromSlice(concepts, sourceID)
-
-	// Write concepts
-	if err := storage.WriteAllConcepts(conceptsPath, concepts); err != nil {
-		exitWithError(ExitDataError, "writing concepts: %v", err)
-	}
-
-	// Load edges and update target_id
 	edgesPath := config.EdgesPath(repoRoot)
 	edges, err := storage.ReadAllEdges(edgesPath)
 	if err != nil {
 		exitWithError(ExitDataError, "reading edges: %v", err)
 	}
 
-	edgesUpdated := 0
-	for i := range edges {
-		if edges[i].TargetID == sourceID {
-			edges[i].TargetID = targetID
-			edgesUpdated++
-		}
+	plan, err := planConceptMerge(concepts, edges, sourceID, targetID)
+	if err != nil {
+		exitWithError(ExitConceptNotFound, "%v", err)
 	}
 
-	// Deduplicate edges (same source_id + target_id + relationship_type)
-	// Keep the one with earlier created_at
-	duplicatesRemoved := 0
-	seen := make(map[edge.EdgeKey]int) // key -> index in result
-	var deduped []edge.Edge
+	result := ConceptMergeResult{
+		Status:            "merged",
+		SourceID:          sourceID,
+		TargetID:          targetID,
+		EdgesUpdated:      len(plan.Repointed),
+		AliasesAdded:      plan.AliasesAdded,
+		DuplicatesRemoved: len(plan.Removed),
+	}
+	if result.AliasesAdded == nil {
+		result.AliasesAdded = []string{}
+	}
 
-	for _, e := range edges {
-		key := e.Key()
-		if existingIdx, exists := seen[key]; exists {
-			// Duplicate found - keep the one with earlier created_at
-			if e.CreatedAt < deduped[existingIdx].CreatedAt {
-				deduped[existingIdx] = e
-			}
-			duplicatesRemoved++
+	if dryRun {
+		result.Status = "dry_run"
+		result.RepointedEdges = plan.Repointed
+		result.RemovedEdges = plan.Removed
+		if humanOutput {
+			printConceptMergePreview(result)
 		} else {
-			seen[key] = len(deduped)
-			deduped = append(deduped, e)
+			outputJSON(result)
 		}
+		return nil
 	}
 
-	// Write edges
-	if err := storage.WriteAllEdges(edgesPath, deduped); err != nil {
+	// Write concepts and edges
+	if err := storage.WriteAllConcepts(conceptsPath, plan.Concepts); err != nil {
+		exitWithError(ExitDataError, "writing concepts: %v", err)
+	}
+	if err := storage.WriteAllEdges(edgesPath, plan.Edges); err != nil {
 		exitWithError(ExitDataError, "writing edges: %v", err)
 	}
 
@@ -623,26 +901,36 @@ func runConceptMerge(cmd *cobra.Command, args []string) error {
 	// Output
 	if humanOutput {
 		fmt.Printf("Merged %q into %q\n", sourceID, targetID)
-		fmt.Printf("  Edges updated: %d\n", edgesUpdated)
-		if len(aliasesAdded) > 0 {
-			fmt.Printf("  Aliases added: %s\n", strings.Join(aliasesAdded, ", "))
+		fmt.Printf("  Edges updated: %d\n", result.EdgesUpdated)
+		if len(result.AliasesAdded) > 0 {
+			fmt.Printf("  Aliases added: %s\n", strings.Join(result.AliasesAdded, ", "))
 		}
-		if duplicatesRemoved > 0 {
-			fmt.Printf("  Duplicate edges removed: %d\n", duplicatesRemoved)
+		if result.DuplicatesRemoved > 0 {
+			fmt.Printf("  Duplicate edges removed: %d\n", result.DuplicatesRemoved)
 		}
 	} else {
-		if aliasesAdded == nil {
-			aliasesAdded = []string{}
-		}
-		outputJSON(ConceptMergeResult{
-			Status:            "merged",
-			SourceID:          sourceID,
-			TargetID:          targetID,
-			EdgesUpdated:      edgesUpdated,
-			AliasesAdded:      aliasesAdded,
-			DuplicatesRemoved: duplicatesRemoved,
-		})
+		outputJSON(result)
 	}
 
 	return nil
 }
+
+// printConceptMergePreview prints a --dry-run merge for --human output.
+func printConceptMergePreview(result ConceptMergeResult) {
+	fmt.Printf("Would merge %q into %q (dry run, nothing written)\n", result.SourceID, result.TargetID)
+
+	fmt.Printf("\nEdges repointed (%d):\n", len(result.RepointedEdges))
+	for _, e := range result.RepointedEdges {
+		fmt.Printf("  %s --[%s]--> %s (was %s)\n", e.SourceID, e.RelationshipType, e.TargetID, result.SourceID)
+	}
+
+	fmt.Printf("\nAliases added to %s (%d):\n", result.TargetID, len(result.AliasesAdded))
+	for _, a := range result.AliasesAdded {
+		fmt.Printf("  %s\n", a)
+	}
+
+	fmt.Printf("\nDuplicate edges removed (%d):\n", len(result.RemovedEdges))
+	for _, e := range result.RemovedEdges {
+		fmt.Printf("  %s --[%s]--> %s\n", e.SourceID, e.RelationshipType, e.TargetID)
+	}
+}
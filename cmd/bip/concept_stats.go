@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+// Sort orders for concept stats.
+const (
+	conceptStatsSortID          = "id"
+	conceptStatsSortConnections = "connections"
+)
+
+func init() {
+	conceptStatsCmd.Flags().String("sort", conceptStatsSortID, "Order: id, or connections (most connected first)")
+	conceptStatsCmd.Flags().Int("min", 0, "Only show concepts with fewer than this many connections (0 = all)")
+	conceptCmd.AddCommand(conceptStatsCmd)
+}
+
+var conceptStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Count edges per concept",
+	Long: `Count the edges touching each concept, to find hubs and under-linked
+concepts worth grooming.
+
+For each concept, reports edges linking a paper (paper_count), edges with
+the concept as source (outgoing_count), edges linking a project
+(project_count), and all edges touching it (total). Concepts with no edges
+are included with zero counts.
+
+Examples:
+  bip concept stats --sort connections --human
+  bip concept stats --min 2 --human     # Concepts with 0 or 1 connections`,
+	Args: cobra.NoArgs,
+	RunE: runConceptStats,
+}
+
+// ConceptStatsResult is the response for the concept stats command.
+type ConceptStatsResult struct {
+	Concepts []storage.ConceptEdgeStats `json:"concepts"`
+	Summary  ConceptStatsSummary        `json:"summary"`
+}
+
+// ConceptStatsSummary aggregates concept stats over all concepts, before
+// --min filtering.
+type ConceptStatsSummary struct {
+	Concepts int `json:"concepts"`
+	Unlinked int `json:"unlinked"`      // Concepts with no edges
+	NoPapers int `json:"no_papers"`     // Concepts with no paper edges
+	MaxTotal int `json:"max_total"`     // Connections of the best-linked concept
+	Shown    int `json:"shown"`         // Concepts listed after --min
+	Min      int `json:"min,omitempty"` // The --min threshold, if given
+}
+
+func runConceptStats(cmd *cobra.Command, args []string) error {
+	sortBy, _ := cmd.Flags().GetString("sort")
+	minTotal, _ := cmd.Flags().GetInt("min")
+	if sortBy != conceptStatsSortID && sortBy != conceptStatsSortConnections {
+		exitWithError(ExitError, "invalid --sort %q: must be id or connections", sortBy)
+	}
+	if minTotal < 0 {
+		exitWithError(ExitError, "--min must not be negative")
+	}
+
+	repoRoot := mustFindRepository()
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	stats, err := db.GetConceptEdgeStats()
+	if err != nil {
+		exitWithError(ExitDataError, "counting concept edges: %v", err)
+	}
+
+	result := ConceptStatsResult{
+		Concepts: selectConceptStats(stats, sortBy, minTotal),
+		Summary:  summarizeConceptStats(stats),
+	}
+	result.Summary.Shown = len(result.Concepts)
+	result.Summary.Min = minTotal
+
+	if humanOutput {
+		if len(result.Concepts) == 0 {
+			fmt.Println("No concepts found")
+			return nil
+		}
+		fmt.Printf("%-30s %6s %8s %8s %6s\n", "CONCEPT", "PAPERS", "OUTGOING", "PROJECTS", "TOTAL")
+		for _, s := range result.Concepts {
+			fmt.Printf("%-30s %6d %8d %8d %6d\n", truncateString(s.ConceptID, 30), s.PaperCount, s.OutgoingCount, s.ProjectCount, s.Total)
+		}
+		sum := result.Summary
+		fmt.Printf("\n%d concepts (%d shown): %d unlinked, %d without papers, most connected has %d\n",
+			sum.Concepts, sum.Shown, sum.Unlinked, sum.NoPapers, sum.MaxTotal)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// selectConceptStats returns the stats with total below minTotal (all if
+// minTotal is 0), ordered by ID or, for "connections", by total descending
+// with ties by ID. The input is not modified.
+func selectConceptStats(stats []storage.ConceptEdgeStats, sortBy string, minTotal int) []storage.ConceptEdgeStats {
+	selected := []storage.ConceptEdgeStats{}
+	for _, s := range stats {
+		if minTotal == 0 || s.Total < minTotal {
+			selected = append(selected, s)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		if sortBy == conceptStatsSortConnections && selected[i].Total != selected[j].Total {
+			return selected[i].Total > selected[j].Total
+		}
+		return selected[i].ConceptID < selected[j].ConceptID
+	})
+	return selected
+}
+
+// summarizeConceptStats aggregates stats over all concepts.
+func summarizeConceptStats(stats []storage.ConceptEdgeStats) ConceptStatsSummary {
+	sum := ConceptStatsSummary{Concepts: len(stats)}
+	for _, s := range stats {
+		if s.Total == 0 {
+			sum.Unlinked++
+		}
+		if s.PaperCount == 0 {
+			sum.NoPapers++
+		}
+		if s.Total > sum.MaxTotal {
+			sum.MaxTotal = s.Total
+		}
+	}
+	return sum
+}
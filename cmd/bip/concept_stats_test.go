@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+func TestSelectConceptStats(t *testing.T) {
+	stats := []storage.ConceptEdgeStats{
+		{ConceptID: "a", PaperCount: 1, Total: 1},
+		{ConceptID: "b", PaperCount: 4, Total: 5},
+		{ConceptID: "c"},
+		{ConceptID: "d", PaperCount: 1, Total: 5},
+	}
+
+	ids := func(s []storage.ConceptEdgeStats) []string {
+		var out []string
+		for _, cs := range s {
+			out = append(out, cs.ConceptID)
+		}
+		return out
+	}
+	tests := []struct {
+		sortBy   string
+		minTotal int
+		want     []string
+	}{
+		{conceptStatsSortID, 0, []string{"a", "b", "c", "d"}},
+		{conceptStatsSortConnections, 0, []string{"b", "d", "a", "c"}},
+		{conceptStatsSortID, 2, []string{"a", "c"}},
+		{conceptStatsSortConnections, 1, []string{"c"}},
+	}
+	for _, tt := range tests {
+		got := ids(selectConceptStats(stats, tt.sortBy, tt.minTotal))
+		if len(got) != len(tt.want) {
+			t.Errorf("selectConceptStats(%s, %d) = %v, want %v", tt.sortBy, tt.minTotal, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("selectConceptStats(%s, %d) = %v, want %v", tt.sortBy, tt.minTotal, got, tt.want)
+				break
+			}
+		}
+	}
+	if stats[0].ConceptID != "a" || stats[1].ConceptID != "b" {
+		t.Error("selectConceptStats modified its input")
+	}
+
+	sum := summarizeConceptStats(stats)
+	want := ConceptStatsSummary{Concepts: 4, Unlinked: 1, NoPapers: 1, MaxTotal: 5}
+	if sum != want {
+		t.Errorf("summarizeConceptStats = %+v, want %+v", sum, want)
+	}
+}
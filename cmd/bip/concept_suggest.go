@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	conceptSuggestCmd.Flags().IntP("limit", "l", 10, "Maximum number of suggestions")
+	conceptSuggestCmd.Flags().Float32P("threshold", "t", 0.5, "Minimum similarity threshold (0.0-1.0)")
+	conceptSuggestCmd.Flags().StringP("type", "r", "applies", "Relationship type used in the edge add hints")
+	conceptCmd.AddCommand(conceptSuggestCmd)
+}
+
+var conceptSuggestCmd = &cobra.Command{
+	Use:   "suggest <concept-id>",
+	Short: "Suggest papers to link to a concept",
+	Long: `Suggest papers that may belong to a concept but are not linked to it.
+
+The concept's description is embedded with the configured provider and
+compared with the abstract embeddings in the semantic index, as 'bip
+semantic' does. Papers already linked to the concept by an edge are left
+out; the rest are ranked by cosine similarity, returning the top --limit
+at or above --threshold.
+
+Each suggestion carries a ready-to-run 'bip edge add' command using the
+--type relationship (default applies). Nothing is linked automatically:
+review the paper, fill in the summary, and run the hint yourself.
+
+Requires a concept description (set one with 'bip concept update <id>
+--description') and the semantic index built with 'bip index build'.
+
+Examples:
+  bip concept suggest somatic-hypermutation
+  bip concept suggest somatic-hypermutation --limit 5 --human
+  bip concept suggest variational-inference --type introduces`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConceptSuggest,
+}
+
+// ConceptSuggestResult is the response for the concept suggest command.
+type ConceptSuggestResult struct {
+	ConceptID   string              `json:"concept_id"`
+	Suggestions []ConceptSuggestion `json:"suggestions"`
+	Total       int                 `json:"total"`
+	Linked      int                 `json:"linked"` // Papers already linked, excluded from suggestions
+	Threshold   float32             `json:"threshold"`
+	Model       string              `json:"model"`
+}
+
+// ConceptSuggestion is one candidate paper for a concept.
+type ConceptSuggestion struct {
+	PaperSearchResult
+	EdgeAddHint string `json:"edge_add_hint"`
+}
+
+func runConceptSuggest(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	conceptID := args[0]
+	limit, _ := cmd.Flags().GetInt("limit")
+	threshold, _ := cmd.Flags().GetFloat32("threshold")
+	relType, _ := cmd.Flags().GetString("type")
+	if limit < 0 {
+		exitWithError(ExitError, "--limit must not be negative")
+	}
+	warnNonStandardRelationType(relType)
+
+	repoRoot := mustFindRepository()
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	c, err := db.GetConceptByID(conceptID)
+	if err != nil {
+		exitWithError(ExitDataError, "querying concept: %v", err)
+	}
+	if c == nil {
+		exitWithError(ExitConceptNotFound, "concept %q not found", conceptID)
+	}
+	description := strings.TrimSpace(c.Description)
+	if description == "" {
+		exitWithError(ExitConceptValidation, "concept %q has no description to match papers against\n  Hint: bip concept update %s --description \"...\"", conceptID, conceptID)
+	}
+
+	linkedEdges, err := db.GetPapersByConcept(conceptID, "")
+	if err != nil {
+		exitWithError(ExitDataError, "querying papers: %v", err)
+	}
+	linked := make(map[string]bool, len(linkedEdges))
+	for _, e := range linkedEdges {
+		linked[e.PaperID] = true
+	}
+
+	idx := mustLoadSemanticIndex(repoRoot)
+	provider := mustEmbeddingProvider(ctx, repoRoot, false)
+	mustMatchIndexProvider(idx, provider)
+
+	queryEmb, err := provider.Embed(ctx, description)
+	if err != nil {
+		exitWithError(ExitError, "generating concept embedding: %v", err)
+	}
+	results, err := idx.SearchExcluding(queryEmb.Vector, limit, threshold, linked)
+	if err != nil {
+		exitWithError(ExitError, "searching index: %v", err)
+	}
+
+	papers := buildSearchResults(results, db, false)
+	suggestions := make([]ConceptSuggestion, len(papers))
+	for i, p := range papers {
+		suggestions[i] = ConceptSuggestion{
+			PaperSearchResult: p,
+			EdgeAddHint:       conceptEdgeAddHint(p.ID, conceptID, relType),
+		}
+	}
+
+	if humanOutput {
+		fmt.Printf("Suggested papers for: %s\n", conceptID)
+		fmt.Printf("\"%s\"\n", truncateString(description, DetailTitleMaxLen))
+		fmt.Printf("Found %d unlinked papers (threshold: %.1f, %d already linked)\n\n", len(suggestions), threshold, len(linked))
+		for i, s := range suggestions {
+			fmt.Printf("%d. [%.2f] %s\n", i+1, s.Similarity, s.ID)
+			fmt.Printf("   %s\n", truncateString(s.Title, SearchTitleMaxLen))
+			fmt.Printf("   %s (%d)\n", formatAuthorsShort(s.Authors, 3), s.Year)
+			fmt.Printf("   %s\n\n", s.EdgeAddHint)
+		}
+	} else {
+		outputList(sliceItems(suggestions), func(suggestions []ConceptSuggestion) any {
+			return ConceptSuggestResult{
+				ConceptID:   conceptID,
+				Suggestions: suggestions,
+				Total:       len(suggestions),
+				Linked:      len(linked),
+				Threshold:   threshold,
+				Model:       provider.ModelName(),
+			}
+		})
+	}
+	return nil
+}
+
+// conceptEdgeAddHint returns the bip edge add command linking paperID to
+// conceptID, with a summary placeholder to fill in.
+func conceptEdgeAddHint(paperID, conceptID, relType string) string {
+	return fmt.Sprintf("bip edge add -s %s -t concept:%s -r %s -m \"...\"", paperID, conceptID, relType)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+func TestFilterEdgesCreatedSince(t *testing.T) {
+	edges := []storage.PaperConceptEdge{
+		{PaperID: "Late", CreatedAt: "2026-02-01T00:00:00Z"},
+		{PaperID: "Old", CreatedAt: "2025-12-31T23:59:59Z"},
+		{PaperID: "Undated"},
+		{PaperID: "Boundary", CreatedAt: "2026-01-01T00:00:00Z"},
+		// 2026-01-15T02:00Z: later than Mid as a time, earlier as a string
+		{PaperID: "Offset", CreatedAt: "2026-01-14T18:00:00-08:00"},
+		{PaperID: "Mid", CreatedAt: "2026-01-15T01:00:00Z"},
+	}
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := filterEdgesCreatedSince(edges, cutoff)
+	var ids []string
+	for _, e := range got {
+		ids = append(ids, e.PaperID)
+	}
+	if want := []string{"Boundary", "Mid", "Offset", "Late"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("filterEdgesCreatedSince() = %v, want oldest-first %v", ids, want)
+	}
+}
+
+func TestPlanConceptMerge(t *testing.T) {
+	concepts := []concept.Concept{
+		{ID: "vae", Name: "VAE", Aliases: []string{"var-ae", "shared"}},
+		{ID: "variational-autoencoder", Name: "Variational Autoencoder", Aliases: []string{"shared"}},
+	}
+	edges := []edge.Edge{
+		{SourceID: "Kingma2014-mo", TargetID: "vae", RelationshipType: "introduces", CreatedAt: "2026-01-02T00:00:00Z"},
+		{SourceID: "Kingma2014-mo", TargetID: "variational-autoencoder", RelationshipType: "introduces", CreatedAt: "2026-01-01T00:00:00Z"},
+		{SourceID: "Rezende2014-ab", TargetID: "vae", RelationshipType: "applies"},
+		{SourceID: "Other2020-cd", TargetID: "elsewhere", RelationshipType: "applies"},
+	}
+
+	plan, err := planConceptMerge(concepts, edges, "vae", "variational-autoencoder")
+	if err != nil {
+		t.Fatalf("planConceptMerge() error = %v", err)
+	}
+
+	if len(plan.Repointed) != 2 {
+		t.Errorf("Repointed = %d, want 2", len(plan.Repointed))
+	}
+	for _, e := range plan.Repointed {
+		if e.TargetID != "variational-autoencoder" {
+			t.Errorf("repointed edge target = %s", e.TargetID)
+		}
+	}
+	if len(plan.AliasesAdded) != 2 || plan.AliasesAdded[0] != "var-ae" || plan.AliasesAdded[1] != "VAE" {
+		t.Errorf("AliasesAdded = %v, want [var-ae VAE]", plan.AliasesAdded)
+	}
+	if len(plan.Removed) != 1 || plan.Removed[0].CreatedAt != "2026-01-02T00:00:00Z" {
+		t.Errorf("Removed = %+v, want the later Kingma edge", plan.Removed)
+	}
+	if len(plan.Edges) != 3 {
+		t.Errorf("Edges = %d, want 3", len(plan.Edges))
+	}
+	if len(plan.Concepts) != 1 || plan.Concepts[0].ID != "variational-autoencoder" {
+		t.Errorf("Concepts = %+v, want only the target", plan.Concepts)
+	}
+
+	// A preview must not modify its inputs
+	if edges[0].TargetID != "vae" || len(concepts) != 2 || len(concepts[1].Aliases) != 1 {
+		t.Error("planConceptMerge() modified its inputs")
+	}
+}
+
+func TestPlanConceptMerge_NotFound(t *testing.T) {
+	concepts := []concept.Concept{{ID: "a", Name: "A"}}
+	if _, err := planConceptMerge(concepts, nil, "a", "missing"); err == nil {
+		t.Error("expected error for missing target")
+	}
+	if _, err := planConceptMerge(concepts, nil, "missing", "a"); err == nil {
+		t.Error("expected error for missing source")
+	}
+}
+
+func TestCollectTransitiveConceptPapers(t *testing.T) {
+	edges := []edge.Edge{
+		{SourceID: "concept:vae", TargetID: "concept:ml", RelationshipType: "subconcept-of"},
+		{SourceID: "concept:beta-vae", TargetID: "concept:vae", RelationshipType: "subconcept-of"},
+		{SourceID: "concept:ml", TargetID: "concept:beta-vae", RelationshipType: "subconcept-of"}, // cycle
+		{SourceID: "concept:gan", TargetID: "concept:ml", RelationshipType: "related-to"},
+		{SourceID: "PaperML", TargetID: "concept:ml", RelationshipType: "applies"},
+		{SourceID: "PaperBeta", TargetID: "concept:beta-vae", RelationshipType: "introduces"},
+		{SourceID: "PaperBeta", TargetID: "concept:vae", RelationshipType: "applies"}, // Nearer concept wins
+		{SourceID: "PaperVAE", TargetID: "concept:vae", RelationshipType: "introduces"},
+		{SourceID: "PaperGAN", TargetID: "concept:gan", RelationshipType: "introduces"},
+		{SourceID: "project:p", TargetID: "concept:vae", RelationshipType: "implements"},
+	}
+
+	papers, paths := collectTransitiveConceptPapers("ml", edges, "subconcept-of", "")
+	var got []string
+	for _, p := range papers {
+		got = append(got, p.PaperID+"/"+p.RelationshipType)
+	}
+	want := []string{"PaperBeta/applies", "PaperML/applies", "PaperVAE/introduces"}
+	if len(got) != len(want) {
+		t.Fatalf("papers = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("papers[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+	if p := paths["PaperBeta"]; len(p) != 2 || p[0] != "ml" || p[1] != "vae" {
+		t.Errorf("PaperBeta path = %v, want [ml vae]", p)
+	}
+	if p := paths["PaperML"]; len(p) != 1 || p[0] != "ml" {
+		t.Errorf("PaperML path = %v, want [ml]", p)
+	}
+
+	// A relationship filter applies to the paper edges, not the hierarchy
+	papers, paths = collectTransitiveConceptPapers("ml", edges, "subconcept-of", "introduces")
+	if len(papers) != 2 || papers[0].PaperID != "PaperBeta" || papers[1].PaperID != "PaperVAE" {
+		t.Errorf("introduces papers = %+v, want PaperBeta and PaperVAE", papers)
+	}
+	if p := paths["PaperBeta"]; len(p) != 3 || p[2] != "beta-vae" {
+		t.Errorf("PaperBeta introduces path = %v, want [ml vae beta-vae]", p)
+	}
+
+	// Following another type walks a different hierarchy
+	papers, _ = collectTransitiveConceptPapers("ml", edges, "related-to", "")
+	if len(papers) != 2 || papers[0].PaperID != "PaperML" || papers[1].PaperID != "PaperGAN" {
+		t.Errorf("related-to papers = %+v, want PaperML and PaperGAN", papers)
+	}
+}
+
+func TestConceptEdgeAddHint(t *testing.T) {
+	got := conceptEdgeAddHint("Smith2024-ab", "mcmc", "applies")
+	want := `bip edge add -s Smith2024-ab -t concept:mcmc -r applies -m "..."`
+	if got != want {
+		t.Errorf("conceptEdgeAddHint() = %q, want %q", got, want)
+	}
+}
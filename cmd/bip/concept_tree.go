@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/spf13/cobra"
+)
+
+// defaultConceptTreeType is the concept→concept relationship walked by concept tree.
+const defaultConceptTreeType = "subconcept-of"
+
+func init() {
+	conceptTreeCmd.Flags().StringP("type", "t", defaultConceptTreeType, "Concept→concept relationship type to follow")
+	conceptTreeCmd.Flags().Bool("ancestors", false, "Walk up to broader concepts instead of down to narrower ones")
+	conceptCmd.AddCommand(conceptTreeCmd)
+}
+
+var conceptTreeCmd = &cobra.Command{
+	Use:   "tree <id>",
+	Short: "Show a concept's hierarchy",
+	Long: `Show the concepts below (or above) a concept in its hierarchy.
+
+The hierarchy comes from concept→concept edges of one relationship type
+(default subconcept-of). An edge concept:a --[subconcept-of]--> concept:b
+makes a a child of b. By default the tree shows descendants; --ancestors
+walks the other way.
+
+If the edges form a cycle, the concept that closes it is shown once more
+with "cycle": true and is not expanded again.
+
+Examples:
+  bip concept tree deep-learning --human
+  bip concept tree vae --ancestors --human
+  bip concept tree antibody --type part-of`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConceptTree,
+}
+
+// ConceptTreeNode is one concept in the output of concept tree.
+type ConceptTreeNode struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Cycle    bool              `json:"cycle,omitempty"` // Already on the path from the root; not expanded
+	Children []ConceptTreeNode `json:"children"`
+}
+
+func runConceptTree(cmd *cobra.Command, args []string) error {
+	repoRoot := mustFindRepository()
+	conceptID := args[0]
+	relType, _ := cmd.Flags().GetString("type")
+	ancestors, _ := cmd.Flags().GetBool("ancestors")
+
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	concepts, err := db.GetAllConcepts()
+	if err != nil {
+		exitWithError(ExitDataError, "querying concepts: %v", err)
+	}
+	names := make(map[string]string, len(concepts))
+	for _, c := range concepts {
+		names[c.ID] = c.Name
+	}
+	if _, ok := names[conceptID]; !ok {
+		exitWithError(ExitConceptNotFound, "concept %q not found", conceptID)
+	}
+
+	edges, err := db.GetEdgesByType(relType)
+	if err != nil {
+		exitWithError(ExitDataError, "querying edges: %v", err)
+	}
+
+	tree := buildConceptTree(conceptID, edges, names, ancestors)
+
+	if humanOutput {
+		fmt.Print(formatConceptTree(tree))
+	} else {
+		outputJSON(tree)
+	}
+	return nil
+}
+
+// buildConceptTree walks concept→concept edges from rootID. Children of a
+// concept are the sources of edges targeting it, or the targets of its own
+// edges when ancestors is true. Non-concept endpoints are ignored. A concept
+// already on the current path is emitted with Cycle set and not expanded, so
+// malformed graphs terminate.
+func buildConceptTree(rootID string, edges []edge.Edge, names map[string]string, ancestors bool) ConceptTreeNode {
+	next := make(map[string][]string)
+	for _, e := range edges {
+		srcType, src := parseNodeType(e.SourceID)
+		tgtType, tgt := parseNodeType(e.TargetID)
+		if srcType != "concept" || tgtType != "concept" {
+			continue
+		}
+		if ancestors {
+			next[src] = append(next[src], tgt)
+		} else {
+			next[tgt] = append(next[tgt], src)
+		}
+	}
+	for id := range next {
+		sort.Strings(next[id])
+	}
+
+	onPath := make(map[string]bool)
+	var walk func(id string) ConceptTreeNode
+	walk = func(id string) ConceptTreeNode {
+		node := ConceptTreeNode{ID: id, Name: names[id], Children: []ConceptTreeNode{}}
+		if onPath[id] {
+			node.Cycle = true
+			return node
+		}
+		onPath[id] = true
+		for _, child := range next[id] {
+			node.Children = append(node.Children, walk(child))
+		}
+		onPath[id] = false
+		return node
+	}
+	return walk(rootID)
+}
+
+// formatConceptTree renders a concept tree as indented text, two spaces per level.
+func formatConceptTree(root ConceptTreeNode) string {
+	var b strings.Builder
+	var write func(n ConceptTreeNode, depth int)
+	write = func(n ConceptTreeNode, depth int) {
+		b.WriteString(strings.Repeat("  ", depth))
+		b.WriteString(n.ID)
+		if n.Name != "" {
+			fmt.Fprintf(&b, " (%s)", n.Name)
+		}
+		if n.Cycle {
+			b.WriteString(" [cycle]")
+		}
+		b.WriteString("\n")
+		for _, c := range n.Children {
+			write(c, depth+1)
+		}
+	}
+	write(root, 0)
+	return b.String()
+}
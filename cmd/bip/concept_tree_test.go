@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/matsen/bipartite/internal/edge"
+)
+
+func TestBuildConceptTree(t *testing.T) {
+	names := map[string]string{"ml": "Machine learning", "dl": "Deep learning", "vae": "VAE", "gan": "GAN"}
+	edges := []edge.Edge{
+		{SourceID: "concept:dl", TargetID: "concept:ml", RelationshipType: "subconcept-of"},
+		{SourceID: "concept:vae", TargetID: "concept:dl", RelationshipType: "subconcept-of"},
+		{SourceID: "concept:gan", TargetID: "concept:dl", RelationshipType: "subconcept-of"},
+		{SourceID: "PaperA", TargetID: "concept:dl", RelationshipType: "subconcept-of"}, // not a concept
+	}
+
+	tree := buildConceptTree("ml", edges, names, false)
+	got := formatConceptTree(tree)
+	want := "ml (Machine learning)\n  dl (Deep learning)\n    gan (GAN)\n    vae (VAE)\n"
+	if got != want {
+		t.Errorf("descendants tree:\n%s\nwant:\n%s", got, want)
+	}
+
+	up := buildConceptTree("vae", edges, names, true)
+	got = formatConceptTree(up)
+	want = "vae (VAE)\n  dl (Deep learning)\n    ml (Machine learning)\n"
+	if got != want {
+		t.Errorf("ancestors tree:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestBuildConceptTree_Cycle(t *testing.T) {
+	names := map[string]string{"a": "A", "b": "B"}
+	edges := []edge.Edge{
+		{SourceID: "concept:a", TargetID: "concept:b"},
+		{SourceID: "concept:b", TargetID: "concept:a"},
+	}
+
+	tree := buildConceptTree("a", edges, names, false)
+
+	if len(tree.Children) != 1 || tree.Children[0].ID != "b" {
+		t.Fatalf("children of a = %+v, want [b]", tree.Children)
+	}
+	closing := tree.Children[0].Children
+	if len(closing) != 1 || closing[0].ID != "a" || !closing[0].Cycle || len(closing[0].Children) != 0 {
+		t.Errorf("cycle not broken: %+v", closing)
+	}
+}
@@ -23,6 +23,7 @@ Usage:
   bip config pdf-root /path/to/pdfs   # Set value
   bip config pdf-reader skim          # Set PDF reader
   bip config papers-repo ~/re/bip-papers  # Set papers repository
+  bip config doctor                   # Diagnose configuration problems
 
 Keys:
   pdf-root     Path to PDF folder (e.g., ~/Google Drive/Paperpile)
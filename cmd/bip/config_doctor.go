@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/embedding"
+	"github.com/spf13/cobra"
+)
+
+// Doctor check statuses, in increasing severity.
+const (
+	doctorOK   = "ok"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+)
+
+// ollamaDoctorTimeout bounds the Ollama reachability check.
+const ollamaDoctorTimeout = 3 * time.Second
+
+func init() {
+	configCmd.AddCommand(configDoctorCmd)
+}
+
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose configuration problems",
+	Long: `Check the bip setup and report each problem found.
+
+Checks that the global config (~/.config/bip/config.yml) loads, that
+nexus_path points at a directory containing a .bipartite repository, that
+the repository's JSONL files are present, whether the GitHub, Slack, and
+OpenAI credentials are set (reporting only where they come from, never
+their values), and whether Ollama is reachable for semantic search.
+
+Each check has a status of ok, warn, or fail. Exits with code 2 if any
+check fails.
+
+Examples:
+  bip config doctor --human
+  bip config doctor`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDoctor,
+}
+
+// DoctorCheck is the outcome of one config doctor check.
+type DoctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // ok, warn, or fail
+	Message string `json:"message"`
+}
+
+// ConfigDoctorResult is the response for the config doctor command.
+// Status is the most severe status among the checks.
+type ConfigDoctorResult struct {
+	Status string        `json:"status"`
+	Checks []DoctorCheck `json:"checks"`
+}
+
+func runConfigDoctor(cmd *cobra.Command, args []string) error {
+	var checks []DoctorCheck
+
+	checks = append(checks, checkGlobalConfig(config.GlobalConfigPath()))
+
+	nexusCheck, repoRoot := checkNexusPath(config.GetNexusPath())
+	checks = append(checks, nexusCheck)
+	if repoRoot == "" {
+		// Without nexus_path, bip falls back to the current directory
+		if cwd, err := os.Getwd(); err == nil {
+			repoRoot, _ = config.FindRepository(cwd)
+		}
+	}
+
+	var cfg *config.Config
+	if repoRoot == "" {
+		checks = append(checks, DoctorCheck{Name: "repository", Status: doctorFail,
+			Message: "no .bipartite repository found via nexus_path or the current directory"})
+	} else {
+		var err error
+		cfg, err = config.Load(repoRoot)
+		if err != nil {
+			checks = append(checks, DoctorCheck{Name: "repository", Status: doctorFail,
+				Message: fmt.Sprintf("%s: %v", repoRoot, err)})
+		} else {
+			checks = append(checks, DoctorCheck{Name: "repository", Status: doctorOK, Message: repoRoot})
+		}
+		checks = append(checks, checkJSONLFiles(repoRoot)...)
+	}
+
+	checks = append(checks,
+		checkToken("github_token", config.GitHubTokenSource(), "bip repo and GitHub commands will be unauthenticated or fail"),
+		checkToken("slack_bot_token", config.SlackBotTokenSource(), "bip slack commands will fail"),
+		checkOpenAIKey(cfg),
+		checkOllama(cmd.Context(), cfg),
+	)
+
+	result := ConfigDoctorResult{Status: worstDoctorStatus(checks), Checks: checks}
+
+	if humanOutput {
+		counts := map[string]int{}
+		for _, c := range checks {
+			counts[c.Status]++
+			fmt.Printf("  [%-4s] %-16s %s\n", doctorLabel(c.Status), c.Name, c.Message)
+		}
+		fmt.Printf("\n%d ok, %d warn, %d fail\n", counts[doctorOK], counts[doctorWarn], counts[doctorFail])
+	} else {
+		outputJSON(result)
+	}
+
+	if result.Status == doctorFail {
+		exitWithResultStatus(ExitConfigError)
+	}
+	return nil
+}
+
+// checkGlobalConfig reports whether the global config at path parses.
+// A missing file is fine: every setting then has its default.
+func checkGlobalConfig(path string) DoctorCheck {
+	check := DoctorCheck{Name: "global_config", Status: doctorOK}
+	if _, err := config.LoadGlobalConfig(); err != nil {
+		check.Status, check.Message = doctorFail, fmt.Sprintf("%s: %v", path, err)
+		return check
+	}
+	if _, err := os.Stat(path); err != nil {
+		check.Message = fmt.Sprintf("%s not found (using defaults)", path)
+		return check
+	}
+	check.Message = path
+	return check
+}
+
+// checkNexusPath validates the configured nexus_path. It returns the
+// check and, when nexus_path holds a repository, its root.
+func checkNexusPath(path string) (DoctorCheck, string) {
+	check := DoctorCheck{Name: "nexus_path"}
+	switch {
+	case path == "":
+		check.Status, check.Message = doctorWarn, "not set; bip only finds a repository from the current directory"
+	case !dirExists(path):
+		check.Status, check.Message = doctorFail, fmt.Sprintf("%s does not exist", path)
+	case !config.IsRepository(path):
+		check.Status, check.Message = doctorFail, fmt.Sprintf("%s has no %s directory", path, config.BipartiteDir)
+	default:
+		check.Status, check.Message = doctorOK, path
+		return check, path
+	}
+	return check, ""
+}
+
+// checkJSONLFiles reports which of the repository's JSONL files exist.
+// Only refs.jsonl is required; the others are created on first write.
+func checkJSONLFiles(repoRoot string) []DoctorCheck {
+	files := []struct {
+		path     string
+		required bool
+	}{
+		{config.RefsPath(repoRoot), true},
+		{config.ConceptsPath(repoRoot), false},
+		{config.EdgesPath(repoRoot), false},
+		{config.ProjectsPath(repoRoot), false},
+		{config.ReposPath(repoRoot), false},
+	}
+
+	var checks []DoctorCheck
+	for _, f := range files {
+		check := DoctorCheck{Name: filepath.Base(f.path), Status: doctorOK, Message: "present"}
+		if info, err := os.Stat(f.path); err != nil {
+			check.Status, check.Message = doctorWarn, "missing (created on first write)"
+			if f.required {
+				check.Status, check.Message = doctorFail, "missing"
+			}
+		} else if info.IsDir() {
+			check.Status, check.Message = doctorFail, "is a directory"
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkToken reports whether a credential is set, naming only its source.
+func checkToken(name, source, impact string) DoctorCheck {
+	if source == "" {
+		return DoctorCheck{Name: name, Status: doctorWarn, Message: "not set; " + impact}
+	}
+	if source == "config" {
+		return DoctorCheck{Name: name, Status: doctorOK, Message: "set in " + config.GlobalConfigPath()}
+	}
+	return DoctorCheck{Name: name, Status: doctorOK, Message: "set via $" + source}
+}
+
+// checkOpenAIKey reports whether the OpenAI API key is set. A missing key
+// only matters when the repository uses the OpenAI embedding provider.
+func checkOpenAIKey(cfg *config.Config) DoctorCheck {
+	source := config.OpenAIAPIKeySource()
+	usesOpenAI := cfg != nil && cfg.Embedding.Provider == config.EmbeddingProviderOpenAI
+	if source == "" && !usesOpenAI {
+		return DoctorCheck{Name: "openai_api_key", Status: doctorOK, Message: "not set (not used: embedding.provider is ollama)"}
+	}
+	return checkToken("openai_api_key", source, "semantic search and indexing will fail with embedding.provider openai")
+}
+
+// checkOllama reports whether Ollama is reachable. It is skipped when the
+// repository uses the OpenAI embedding provider.
+func checkOllama(ctx context.Context, cfg *config.Config) DoctorCheck {
+	check := DoctorCheck{Name: "ollama"}
+	if cfg != nil && cfg.Embedding.Provider == config.EmbeddingProviderOpenAI {
+		check.Status, check.Message = doctorOK, "not used (embedding.provider is openai)"
+		return check
+	}
+	p, err := newEmbeddingProvider(cfg)
+	if err != nil {
+		check.Status, check.Message = doctorFail, err.Error()
+		return check
+	}
+	provider, ok := p.(*embedding.OllamaProvider)
+	if !ok {
+		check.Status, check.Message = doctorFail, fmt.Sprintf("unexpected embedding provider %s", p.Name())
+		return check
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, ollamaDoctorTimeout)
+	defer cancel()
+
+	if err := provider.IsAvailable(ctx); err != nil {
+		check.Status, check.Message = doctorWarn, fmt.Sprintf("not reachable at %s; semantic search and indexing will fail", provider.BaseURL())
+		return check
+	}
+	hasModel, err := provider.HasModel(ctx)
+	if err != nil || !hasModel {
+		check.Status, check.Message = doctorWarn, fmt.Sprintf("running, but model %q is not pulled", provider.ModelName())
+		return check
+	}
+	check.Status, check.Message = doctorOK, fmt.Sprintf("running with model %s", provider.ModelName())
+	return check
+}
+
+// worstDoctorStatus returns the most severe status among checks.
+func worstDoctorStatus(checks []DoctorCheck) string {
+	worst := doctorOK
+	for _, c := range checks {
+		if c.Status == doctorFail {
+			return doctorFail
+		}
+		if c.Status == doctorWarn {
+			worst = doctorWarn
+		}
+	}
+	return worst
+}
+
+// doctorLabel is the bracketed tag shown for a status in human output.
+func doctorLabel(status string) string {
+	switch status {
+	case doctorOK:
+		return "OK"
+	case doctorWarn:
+		return "WARN"
+	default:
+		return "FAIL"
+	}
+}
+
+// dirExists reports whether path is an existing directory.
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
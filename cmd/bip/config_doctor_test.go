@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/config"
+)
+
+func TestCheckNexusPath(t *testing.T) {
+	nexus := t.TempDir()
+	if err := os.Mkdir(config.BipartitePath(nexus), 0755); err != nil {
+		t.Fatal(err)
+	}
+	plain := t.TempDir()
+
+	tests := []struct {
+		name     string
+		path     string
+		want     string
+		wantRoot string
+	}{
+		{"unset", "", doctorWarn, ""},
+		{"missing", filepath.Join(plain, "nope"), doctorFail, ""},
+		{"not a nexus", plain, doctorFail, ""},
+		{"valid", nexus, doctorOK, nexus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, root := checkNexusPath(tt.path)
+			if check.Status != tt.want {
+				t.Errorf("status = %q, want %q (%s)", check.Status, tt.want, check.Message)
+			}
+			if root != tt.wantRoot {
+				t.Errorf("root = %q, want %q", root, tt.wantRoot)
+			}
+		})
+	}
+}
+
+func TestCheckJSONLFiles(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.Mkdir(config.BipartitePath(repo), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(config.EdgesPath(repo), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]string{}
+	for _, c := range checkJSONLFiles(repo) {
+		got[c.Name] = c.Status
+	}
+	if got["refs.jsonl"] != doctorFail {
+		t.Errorf("missing refs.jsonl status = %q, want fail", got["refs.jsonl"])
+	}
+	if got["edges.jsonl"] != doctorOK {
+		t.Errorf("present edges.jsonl status = %q, want ok", got["edges.jsonl"])
+	}
+	if got["concepts.jsonl"] != doctorWarn {
+		t.Errorf("missing concepts.jsonl status = %q, want warn", got["concepts.jsonl"])
+	}
+}
+
+func TestCheckToken_NeverShowsValue(t *testing.T) {
+	if c := checkToken("github_token", "", "impact"); c.Status != doctorWarn {
+		t.Errorf("unset token status = %q, want warn", c.Status)
+	}
+	c := checkToken("github_token", "GITHUB_TOKEN", "impact")
+	if c.Status != doctorOK || !strings.Contains(c.Message, "$GITHUB_TOKEN") {
+		t.Errorf("env token check = %+v, want ok naming $GITHUB_TOKEN", c)
+	}
+}
+
+func TestCheckOpenAIKey(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("BIP_OPENAI_API_KEY", "")
+	t.Setenv("OPENAI_API_KEY", "")
+	openai := &config.Config{Embedding: config.EmbeddingSettings{Provider: config.EmbeddingProviderOpenAI}}
+
+	if c := checkOpenAIKey(nil); c.Status != doctorOK {
+		t.Errorf("unset key without openai provider = %+v, want ok", c)
+	}
+	if c := checkOpenAIKey(openai); c.Status != doctorWarn {
+		t.Errorf("unset key with openai provider = %+v, want warn", c)
+	}
+
+	t.Setenv("OPENAI_API_KEY", "sk-secret")
+	c := checkOpenAIKey(openai)
+	if c.Status != doctorOK || !strings.Contains(c.Message, "$OPENAI_API_KEY") || strings.Contains(c.Message, "sk-secret") {
+		t.Errorf("env key check = %+v, want ok naming $OPENAI_API_KEY without the value", c)
+	}
+}
+
+func TestWorstDoctorStatus(t *testing.T) {
+	tests := []struct {
+		statuses []string
+		want     string
+	}{
+		{nil, doctorOK},
+		{[]string{doctorOK, doctorOK}, doctorOK},
+		{[]string{doctorOK, doctorWarn}, doctorWarn},
+		{[]string{doctorFail, doctorWarn}, doctorFail},
+	}
+	for _, tt := range tests {
+		var checks []DoctorCheck
+		for _, s := range tt.statuses {
+			checks = append(checks, DoctorCheck{Status: s})
+		}
+		if got := worstDoctorStatus(checks); got != tt.want {
+			t.Errorf("worstDoctorStatus(%v) = %q, want %q", tt.statuses, got, tt.want)
+		}
+	}
+}
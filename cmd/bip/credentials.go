@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/asta"
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/github"
+	"github.com/matsen/bipartite/internal/s2"
+)
+
+// newS2Client returns a Semantic Scholar client, warning on stderr if no
+// API key is configured and requests will be anonymous.
+func newS2Client() *s2.Client {
+	if config.GetS2APIKey() == "" {
+		warnMissingCredential("Semantic Scholar API key", config.S2APIKeyEnvVars, "s2_api_key",
+			"sending anonymous requests at a lower rate limit")
+	}
+	return s2.NewClient()
+}
+
+// newASTAClient returns an ASTA client, warning on stderr if no API key is
+// configured. The cheap endpoints (paper, citations, ...) accept anonymous
+// calls, so a missing key otherwise stays invisible until search times out.
+func newASTAClient() *asta.Client {
+	if config.GetASTAAPIKey() == "" {
+		warnMissingCredential("ASTA API key", config.ASTAAPIKeyEnvVars, "asta_api_key",
+			"sending anonymous requests (search may time out; keys at https://allenai.org/asta/resources/mcp)")
+	}
+	return asta.NewClient()
+}
+
+// newGitHubClient returns a GitHub client, warning on stderr if no token
+// is configured and requests will be unauthenticated.
+func newGitHubClient(opts ...github.ClientOption) *github.Client {
+	if config.GetGitHubToken() == "" {
+		warnMissingCredential("GitHub token", config.GitHubTokenEnvVars, "github_token",
+			"sending unauthenticated requests (60/hour, public repos only)")
+	}
+	return github.NewClient(opts...)
+}
+
+// githubCacheOption returns the client option that caches repo metadata
+// under .bipartite/cache/github_repos for github.cache_ttl (default 24h).
+// With noCache, or a zero TTL, the cache is neither read nor written.
+func githubCacheOption(repoRoot string, noCache bool) github.ClientOption {
+	if noCache {
+		return github.WithCache("", 0)
+	}
+	ttl, err := mustLoadConfig(repoRoot).GitHubCacheTTL(github.DefaultCacheTTL)
+	if err != nil {
+		exitWithError(ExitConfigError, "%v", err)
+	}
+	return github.WithCache(filepath.Join(config.CachePath(repoRoot), "github_repos"), ttl)
+}
+
+// newGitHubRefreshClient returns a GitHub client for repo refresh: it always
+// fetches from the API and writes the results through to the cache.
+func newGitHubRefreshClient(repoRoot string) *github.Client {
+	return newGitHubClient(githubCacheOption(repoRoot, false), github.WithFreshFetch())
+}
+
+// warnMissingCredential tells the user on stderr that a command is going
+// ahead without a credential, and where it would have been read from.
+func warnMissingCredential(what string, envVars []string, configKey, consequence string) {
+	fmt.Fprintln(os.Stderr, missingCredentialWarning(what, envVars, configKey, consequence))
+}
+
+// missingCredentialWarning formats the warning printed by warnMissingCredential.
+func missingCredentialWarning(what string, envVars []string, configKey, consequence string) string {
+	vars := make([]string, len(envVars))
+	for i, v := range envVars {
+		vars[i] = "$" + v
+	}
+	return fmt.Sprintf("Warning: no %s found (set %s, or %s in %s); %s",
+		what, strings.Join(vars, " or "), configKey, config.GlobalConfigPath(), consequence)
+}
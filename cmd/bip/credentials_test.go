@@ -0,0 +1,15 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMissingCredentialWarning(t *testing.T) {
+	got := missingCredentialWarning("GitHub token", []string{"BIP_GITHUB_TOKEN", "GITHUB_TOKEN"}, "github_token", "sending unauthenticated requests")
+	for _, want := range []string{"Warning: no GitHub token found", "$BIP_GITHUB_TOKEN or $GITHUB_TOKEN", "github_token in ", "sending unauthenticated requests"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("warning %q missing %q", got, want)
+		}
+	}
+}
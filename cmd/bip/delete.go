@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	deleteCmd.Flags().Bool("force", false, "Delete even if edges involve the paper (removes those edges too)")
+	rootCmd.AddCommand(deleteCmd)
+}
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a paper",
+	Long: `Delete a paper from the library.
+
+If any edges have the paper as source or target, the delete is refused
+unless --force is given, in which case those edges are removed as well.
+The paper's semantic index metadata is cleared so it is no longer counted
+as indexed; it drops out of the index vectors at the next 'bip index build'.
+
+Examples:
+  bip delete Smith2024-ab
+  bip delete Smith2024-ab --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDelete,
+}
+
+// DeleteResult is the response for the delete command.
+type DeleteResult struct {
+	Status                   string `json:"status"`
+	ID                       string `json:"id"`
+	EdgesRemoved             int    `json:"edges_removed"`
+	EmbeddingMetadataCleared bool   `json:"embedding_metadata_cleared"`
+}
+
+// DeleteBlockedResult is the response when delete is blocked by edges.
+type DeleteBlockedResult struct {
+	Error     string `json:"error"`
+	ErrorCode string `json:"error_code"`
+	ID        string `json:"id"`
+	EdgeCount int    `json:"edge_count"`
+	Hint      string `json:"hint"`
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	repoRoot := mustFindRepository()
+	paperID := args[0]
+	force, _ := cmd.Flags().GetBool("force")
+
+	refsPath := config.RefsPath(repoRoot)
+	refs, err := storage.ReadAll(refsPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+	if _, found := storage.FindByID(refs, paperID); !found {
+		exitWithError(ExitError, "reference not found: %s", paperID)
+	}
+
+	// Count from edges.jsonl, the file the cascade rewrites, so a stale
+	// index cannot let a paper with edges through
+	edgesPath := config.EdgesPath(repoRoot)
+	edges, err := storage.ReadAllEdges(edgesPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading edges: %v", err)
+	}
+	linked := 0
+	for _, e := range edges {
+		if e.SourceID == paperID || e.TargetID == paperID {
+			linked++
+		}
+	}
+	checkDeleteBlocked(paperID, linked, force)
+
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexRefs, refsPath)
+
+	refs, _ = storage.DeleteByID(refs, paperID)
+	if err := storage.WriteAll(refsPath, refs); err != nil {
+		exitWithError(ExitDataError, "writing refs: %v", err)
+	}
+	if err := db.ApplyIndexUpdate(storage.IndexRefs, refsPath, indexCurrent, func() error { return db.DeleteRef(paperID) }); err != nil {
+		exitWithError(ExitDataError, "updating index: %v", err)
+	}
+
+	edgesRemoved := 0
+	if linked > 0 {
+		edgesRemoved = deletePaperEdges(edgesPath, edges, paperID, db)
+	}
+
+	cleared, err := db.DeleteEmbeddingMetadata(paperID)
+	if err != nil {
+		exitWithError(ExitDataError, "clearing embedding metadata: %v", err)
+	}
+
+	if humanOutput {
+		if edgesRemoved > 0 {
+			fmt.Printf("Deleted paper %q and %d linked edges\n", paperID, edgesRemoved)
+		} else {
+			fmt.Printf("Deleted paper %q\n", paperID)
+		}
+		if cleared {
+			fmt.Println("  Cleared semantic index metadata")
+		}
+	} else {
+		outputJSON(DeleteResult{
+			Status:                   "deleted",
+			ID:                       paperID,
+			EdgesRemoved:             edgesRemoved,
+			EmbeddingMetadataCleared: cleared,
+		})
+	}
+	return nil
+}
+
+// deletePaperEdges rewrites edgesPath without the edges that have the paper
+// as source or target and rebuilds the edges index. Returns the number of
+// edges removed.
+func deletePaperEdges(edgesPath string, edges []edge.Edge, paperID string, db *storage.DB) int {
+	var remaining []edge.Edge
+	edgesRemoved := 0
+	for _, e := range edges {
+		if e.SourceID == paperID || e.TargetID == paperID {
+			edgesRemoved++
+		} else {
+			remaining = append(remaining, e)
+		}
+	}
+
+	if err := storage.WriteAllEdges(edgesPath, remaining); err != nil {
+		exitWithError(ExitDataError, "writing edges: %v", err)
+	}
+	if _, err := db.RebuildEdgesFromJSONL(edgesPath); err != nil {
+		exitWithError(ExitDataError, "rebuilding edges index: %v", err)
+	}
+
+	return edgesRemoved
+}
+
+// checkDeleteBlocked handles the case when delete is blocked by linked edges.
+// It outputs an error message and exits if blocked (force=false and edges exist).
+func checkDeleteBlocked(paperID string, edgeCount int, force bool) {
+	if edgeCount > 0 && !force {
+		msg := fmt.Sprintf("paper %q has %d linked edges; use --force to delete anyway", paperID, edgeCount)
+		hint := fmt.Sprintf("bip delete %s --force", paperID)
+		if humanOutput {
+			fmt.Fprintf(os.Stderr, "error: %s\n  Hint: %s\n", msg, hint)
+		} else {
+			outputJSON(DeleteBlockedResult{
+				Error:     msg,
+				ErrorCode: ErrorCodeValidation,
+				ID:        paperID,
+				EdgeCount: edgeCount,
+				Hint:      hint,
+			})
+		}
+		os.Exit(ExitDataError.Status())
+	}
+}
@@ -18,31 +18,60 @@ var digestCmd = &cobra.Command{
 	Long: `Generate an LLM-summarized digest of GitHub activity for a channel.
 
 Channels are defined in sources.yml via the "channel" field on repos.
-By default, shows a preview only. Use --post to actually send to Slack.`,
+By default, shows a preview only. Use --post to actually send to Slack.
+
+With --by-court, skips the LLM summary and instead lists each repo's
+activity in three sections using the same ball-in-court logic as
+'bip checkin': "Needs your response", "Waiting on others", and "Recently
+resolved", with each item's reason and last actor. "You" is the login
+given by --me, else github.user in ~/.config/bip/config.yml, else the
+account gh is logged in as (cached for 30 days; --refresh-user looks it
+up again).
+
+With --assignee-aware, an open item assigned to someone else is listed
+under "Waiting on others" (reason assigned_elsewhere) whoever acted last,
+unless you are one of its requested reviewers, as in 'bip checkin
+--assignee-aware'. Unassigned items are grouped as usual.
+
+Without --by-court, the digest is summarized per person rather than for
+you, so ball-in-court reasons are shown only with --by-court.
+
+-v/--verbose enables debug logging, as on every command. For
+compatibility with the old digest --verbose, it also implies --details
+(with a deprecation warning) unless --details is given too.`,
 	Run: runDigest,
 }
 
 var (
-	digestChannel string
-	digestSince   string
-	digestPostTo  string
-	digestRepos   string
-	digestExclude string
-	digestPost    bool
-	digestVerbose bool
+	digestChannel     string
+	digestSince       string
+	digestPostTo      string
+	digestRepos       string
+	digestExclude     string
+	digestPost        bool
+	digestDetails     bool
+	digestByCourt     bool
+	digestMe          string
+	digestRefreshUser bool
+	digestAssignee    bool
 )
 
 func init() {
 	rootCmd.AddCommand(digestCmd)
 
 	digestCmd.Flags().StringVar(&digestChannel, "channel", "", "Channel whose repos to scan (required)")
-	digestCmd.Flags().StringVar(&digestSince, "since", "1w", "Time period to summarize (e.g., 1w, 2d, 12h)")
+	digestCmd.Flags().StringVar(&digestSince, "since", "1w", "Time period to summarize (e.g., 1w, 2d, 12h) or start time (YYYY-MM-DD, RFC3339)")
 	digestCmd.Flags().StringVar(&digestPostTo, "post-to", "", "Override destination channel for posting")
 	digestCmd.Flags().StringVar(&digestRepos, "repos", "", "Override repos to scan (comma-separated)")
 	digestCmd.Flags().StringVar(&digestExclude, "exclude", "", "Repos to exclude (comma-separated, matches repo name suffix)")
 	digestCmd.Flags().BoolVar(&digestPost, "post", false, "Actually post to Slack (default: preview only)")
-	digestCmd.Flags().BoolVar(&digestVerbose, "verbose", false, "Fetch PR/issue bodies and include LLM summaries")
+	digestCmd.Flags().BoolVar(&digestDetails, "details", false, "Fetch PR/issue bodies and include LLM summaries")
+	digestCmd.Flags().BoolVar(&digestByCourt, "by-court", false, "Group activity by ball-in-court status instead of summarizing")
+	digestCmd.Flags().StringVar(&digestMe, "me", "", "With --by-court, GitHub login to treat as you (overrides github.user and gh detection)")
+	digestCmd.Flags().BoolVar(&digestRefreshUser, "refresh-user", false, "With --by-court, re-detect the GitHub login instead of using the cached one")
+	digestCmd.Flags().BoolVar(&digestAssignee, "assignee-aware", false, "With --by-court, treat items assigned to someone else as in their court (unless you are a reviewer)")
 	digestCmd.MarkFlagRequired("channel")
+	digestCmd.MarkFlagsMutuallyExclusive("by-court", "post")
 }
 
 func runDigest(cmd *cobra.Command, args []string) {
@@ -113,15 +142,28 @@ func runDigest(cmd *cobra.Command, args []string) {
 	}
 
 	// Determine time range
-	duration, err := flow.ParseDuration(digestSince)
+	since, err := flow.ParseSince(digestSince)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "error: invalid --since value: %v\n", err)
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 	until := time.Now().UTC()
-	since := until.Add(-duration)
+	since = since.UTC()
 	dateRange := flow.FormatDateRange(since, until)
 
+	if digestByCourt {
+		runDigestByCourt(nexusPath, repos, since, dateRange)
+		return
+	}
+
+	// digest's own --verbose used to mean --details; the global debug
+	// logging flag now takes that name, so keep implying --details for
+	// existing scripts until they pass --details themselves
+	if cmd.Flags().Changed("verbose") && !digestDetails {
+		fmt.Fprintln(os.Stderr, "Warning: --verbose implying --details is deprecated; pass --details for PR/issue bodies and summaries (--verbose also enables debug logging)")
+		digestDetails = true
+	}
+
 	fmt.Printf("Generating digest for #%s (%s)...\n", digestChannel, dateRange)
 	if postTo != digestChannel {
 		fmt.Printf("(posting to #%s)\n", postTo)
@@ -130,7 +172,7 @@ func runDigest(cmd *cobra.Command, args []string) {
 	fmt.Printf("Scanning %d repos...\n", len(repos))
 
 	// Fetch digest items from GitHub activity
-	items, err := fetchDigestItems(repos, since, digestVerbose)
+	items, err := fetchDigestItems(repos, since, digestDetails)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: building digest items: %v\n", err)
 		os.Exit(1)
@@ -146,8 +188,8 @@ func runDigest(cmd *cobra.Command, args []string) {
 	fmt.Printf("Found %d items (%d after filtering closed issues)\n", len(items), len(filtered))
 	items = filtered
 
-	// Generate summaries if verbose mode
-	if digestVerbose && len(items) > 0 {
+	// Generate summaries with --details
+	if digestDetails && len(items) > 0 {
 		fmt.Println("Generating summaries with Claude Haiku...")
 		items, err = flow.SummarizeDigestItems(items)
 		if err != nil {
@@ -212,29 +254,17 @@ func fetchDigestItems(repos []string, since time.Time, includeBody bool) ([]flow
 		}
 		successfulFetches++
 
+		commenters, reviewers := fetchDigestParticipants(repo, allItems)
+
 		for _, item := range allItems {
 			// Collect contributors
 			contributors := make(map[string]bool)
 			contributors[item.User.Login] = true
-
-			commenters, err := flow.FetchItemCommenters(repo, item.Number)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to fetch commenters for %s#%d: %v\n", repo, item.Number, err)
-			} else {
-				for _, c := range commenters {
-					contributors[c] = true
-				}
+			for _, c := range commenters[item.Number] {
+				contributors[c] = true
 			}
-
-			if item.IsPR {
-				reviewers, err := flow.FetchPRReviewers(repo, item.Number)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to fetch reviewers for %s#%d: %v\n", repo, item.Number, err)
-				} else {
-					for _, r := range reviewers {
-						contributors[r] = true
-					}
-				}
+			for _, r := range reviewers[item.Number] {
+				contributors[r] = true
 			}
 
 			// Remove "unknown" and sort
@@ -275,3 +305,88 @@ func fetchDigestItems(repos []string, since time.Time, includeBody bool) ([]flow
 
 	return items, nil
 }
+
+// fetchDigestParticipants fetches commenters and PR reviewers for a repo's
+// items with one batched call each, keyed by item number. If the commenter
+// batch fails, it falls back to fetching each item's commenters separately.
+func fetchDigestParticipants(repo string, items []flow.GitHubItem) (map[int][]string, map[int][]string) {
+	var numbers, prNumbers []int
+	for _, item := range items {
+		numbers = append(numbers, item.Number)
+		if item.IsPR {
+			prNumbers = append(prNumbers, item.Number)
+		}
+	}
+
+	commenters, err := flow.FetchItemsCommenters(repo, numbers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; falling back to per-item fetching\n", err)
+		commenters = make(map[int][]string)
+		for _, n := range numbers {
+			logins, err := flow.FetchItemCommenters(repo, n)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to fetch commenters for %s#%d: %v\n", repo, n, err)
+				continue
+			}
+			commenters[n] = logins
+		}
+	}
+
+	return commenters, flow.FetchPRsReviewers(repo, prNumbers)
+}
+
+// runDigestByCourt prints each repo's activity since the given time grouped
+// by ball-in-court status for the authenticated GitHub user.
+func runDigestByCourt(nexusPath string, repos []string, since time.Time, dateRange string) {
+	githubUser, err := flow.ResolveGitHubUser(nexusPath, digestMe, digestRefreshUser)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Activity for #%s by ball-in-court status (%s)\n", digestChannel, dateRange)
+
+	var successfulFetches int
+	for _, repo := range repos {
+		items, err := flow.FetchIssues(repo, since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to fetch %s: %v\n", repo, err)
+			continue
+		}
+		successfulFetches++
+		if len(items) == 0 {
+			continue
+		}
+
+		if digestAssignee {
+			// Requested reviewers feed the reviewer exception
+			items = enrichPRsWithRequestedReviewers(repo, items)
+		}
+		actions := flow.CourtActions(repo, items, flow.FetchCourtActivity(repo, items, since))
+		summary := flow.PartitionByCourt(items, actions, githubUser, digestAssignee)
+		fmt.Printf("\n## %s\n", repo)
+		printCourtSection("Needs your response", summary.NeedsResponse)
+		printCourtSection("Waiting on others", summary.WaitingOnOthers)
+		printCourtSection("Recently resolved", summary.Resolved)
+	}
+
+	if successfulFetches == 0 {
+		fmt.Fprintf(os.Stderr, "error: failed to fetch activity from all %d repos\n", len(repos))
+		os.Exit(1)
+	}
+}
+
+// printCourtSection prints one ball-in-court section, omitting empty ones.
+func printCourtSection(label string, entries []flow.CourtEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Printf("\n### %s (%d)\n", label, len(entries))
+	for _, e := range entries {
+		lastActor := "no activity"
+		if e.LastActor != "" {
+			lastActor = "last: @" + e.LastActor
+		}
+		fmt.Printf("  %s - %s — %s (%s)\n", e.Item.HTMLURL, e.Item.Title, e.Reason, lastActor)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/joho/godotenv"
+	"github.com/matsen/bipartite/internal/config"
+)
+
+// dotEnvFile is the name of the env file bip loads at startup.
+const dotEnvFile = ".env"
+
+// loadDotEnv loads, best-effort, the nearest .env file at or above the
+// current directory and the nearest at or above the configured nexus, so
+// API keys kept beside a nexus are found wherever bip is run from. Values
+// never override variables already set in the environment, and the
+// current directory's file wins over the nexus's where both set a key.
+func loadDotEnv() {
+	var starts []string
+	if cwd, err := os.Getwd(); err == nil {
+		starts = append(starts, cwd)
+	}
+	if nexus := config.GetNexusPath(); nexus != "" {
+		starts = append(starts, nexus)
+	}
+	loadDotEnvFrom(starts...)
+}
+
+// loadDotEnvFrom loads the nearest .env at or above each start directory,
+// skipping files already loaded. A missing or unreadable file is ignored.
+// Returns the paths loaded.
+func loadDotEnvFrom(starts ...string) []string {
+	var loaded []string
+	seen := make(map[string]bool)
+	for _, start := range starts {
+		path := findDotEnv(start)
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		if err := godotenv.Load(path); err == nil {
+			loaded = append(loaded, path)
+		}
+	}
+	return loaded
+}
+
+// findDotEnv walks up from start and returns the first .env file found,
+// or "" if there is none up to the filesystem root.
+func findDotEnv(start string) string {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, dotEnvFile)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/config"
+)
+
+// writeDotEnvRepo creates a nexus with a .env file and a nested directory,
+// returning the nexus root and the nested directory.
+func writeDotEnvRepo(t *testing.T, content string) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	nested := filepath.Join(root, ".bipartite", "cache")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".env"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root, nested
+}
+
+// unsetEnvForTest unsets name for the duration of the test.
+func unsetEnvForTest(t *testing.T, name string) {
+	t.Helper()
+	t.Setenv(name, "") // Registers restoration of the original value
+	os.Unsetenv(name)
+}
+
+func TestLoadDotEnvFrom_FindsFileAboveStart(t *testing.T) {
+	root, nested := writeDotEnvRepo(t, "BIP_S2_API_KEY=from-dotenv\n")
+	unsetEnvForTest(t, "BIP_S2_API_KEY")
+	unsetEnvForTest(t, "S2_API_KEY")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	config.ResetGlobalConfigCache()
+	defer config.ResetGlobalConfigCache()
+
+	loaded := loadDotEnvFrom(nested)
+	if len(loaded) != 1 || loaded[0] != filepath.Join(root, ".env") {
+		t.Fatalf("loaded = %v, want [%s]", loaded, filepath.Join(root, ".env"))
+	}
+	if got := config.GetS2APIKey(); got != "from-dotenv" {
+		t.Errorf("GetS2APIKey() = %q, want from-dotenv", got)
+	}
+}
+
+func TestLoadDotEnvFrom_ShellEnvWins(t *testing.T) {
+	_, nested := writeDotEnvRepo(t, "BIP_DOTENV_TEST=from-dotenv\n")
+	t.Setenv("BIP_DOTENV_TEST", "from-shell")
+
+	loadDotEnvFrom(nested)
+	if got := os.Getenv("BIP_DOTENV_TEST"); got != "from-shell" {
+		t.Errorf("BIP_DOTENV_TEST = %q, want from-shell", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -13,13 +14,6 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// Exit codes specific to edge commands (per CLI contract)
-const (
-	ExitEdgeSourceNotFound = 1 // Source paper not found
-	ExitEdgeTargetNotFound = 2 // Target paper not found
-	ExitEdgeInvalidArgs    = 3 // Invalid arguments
-)
-
 func init() {
 	rootCmd.AddCommand(edgeCmd)
 
@@ -28,6 +22,8 @@ func init() {
 	edgeAddCmd.Flags().StringP("target", "t", "", "Target paper ID (required)")
 	edgeAddCmd.Flags().StringP("type", "r", "", "Relationship type (required)")
 	edgeAddCmd.Flags().StringP("summary", "m", "", "Relational summary text (required)")
+	edgeAddCmd.Flags().String("at", "", "Creation time to record, RFC3339 (default: now)")
+	edgeAddCmd.Flags().Float64("strength", 0, "Relationship strength from 0 (tangential) to 1 (foundational) (default: unset)")
 	edgeAddCmd.MarkFlagRequired("source")
 	edgeAddCmd.MarkFlagRequired("target")
 	edgeAddCmd.MarkFlagRequired("type")
@@ -43,15 +39,20 @@ func init() {
 	edgeListCmd.Flags().StringP("paper", "p", "", "Filter edges by paper ID")
 	edgeListCmd.Flags().StringP("concept", "c", "", "Filter edges by concept ID")
 	edgeListCmd.Flags().StringP("project", "P", "", "Filter edges by project ID")
+	edgeListCmd.Flags().StringP("type", "t", "", "With a paper ID, only show edges of this relationship type")
 	edgeCmd.AddCommand(edgeListCmd)
 
 	// bp edge search flags
 	edgeSearchCmd.Flags().StringP("type", "r", "", "Relationship type to filter by (required)")
 	edgeSearchCmd.MarkFlagRequired("type")
+	edgeSearchCmd.Flags().Int("limit", 0, "Maximum edges to return (0 = all; default from search.default_limit)")
 	edgeCmd.AddCommand(edgeSearchCmd)
 
 	// bp edge export flags
 	edgeExportCmd.Flags().StringP("paper", "p", "", "Only export edges involving this paper")
+	edgeExportCmd.Flags().Int("limit", 0, "Maximum edges to export (0 = all)")
+	edgeExportCmd.Flags().Int("offset", 0, "Skip this many edges before exporting")
+	edgeExportCmd.Flags().String("format", "jsonl", "Output format: jsonl or csv")
 	edgeCmd.AddCommand(edgeExportCmd)
 }
 
@@ -255,8 +256,15 @@ type EdgeAddResult struct {
 var edgeAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add an edge to the knowledge graph",
-	Long:  `Add a directed relationship between two papers.`,
-	RunE:  runEdgeAdd,
+	Long: `Add a directed relationship between two papers.
+
+Use --at to backdate the edge when importing historical relationships, so
+--since queries and timelines reflect when the link was actually made.
+
+Use --strength to weight the relationship from 0 (tangential) to 1
+(foundational); the visualization draws stronger edges wider. Updating an
+existing edge without --strength keeps its current strength.`,
+	RunE: runEdgeAdd,
 }
 
 func runEdgeAdd(cmd *cobra.Command, args []string) error {
@@ -266,6 +274,7 @@ func runEdgeAdd(cmd *cobra.Command, args []string) error {
 	targetID, _ := cmd.Flags().GetString("target")
 	relType, _ := cmd.Flags().GetString("type")
 	summary, _ := cmd.Flags().GetString("summary")
+	at, _ := cmd.Flags().GetString("at")
 
 	// Create edge
 	e := edge.Edge{
@@ -274,6 +283,17 @@ func runEdgeAdd(cmd *cobra.Command, args []string) error {
 		RelationshipType: relType,
 		Summary:          summary,
 	}
+	if at != "" {
+		createdAt, err := edge.NormalizeTimestamp(at)
+		if err != nil {
+			exitWithError(ExitEdgeInvalidArgs, "--at: %v", err)
+		}
+		e.CreatedAt = createdAt
+	}
+	if cmd.Flags().Changed("strength") {
+		strength, _ := cmd.Flags().GetFloat64("strength")
+		e.Strength = &strength
+	}
 
 	// Validate edge structure
 	if err := e.ValidateForCreate(); err != nil {
@@ -296,8 +316,17 @@ func runEdgeAdd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Warn if using non-standard relationship type
-	if (sourceType == "paper" && targetType == "concept") || (sourceType == "concept" && targetType == "paper") {
+	// Enforce the relationship-type vocabulary if the nexus has one;
+	// otherwise just warn about non-standard types
+	vocab, err := edge.LoadVocabulary(config.EdgeTypesPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitConfigError, "%v", err)
+	}
+	if vocab != nil {
+		if !vocab.Allowed(sourceType, targetType, relType) {
+			exitWithError(ExitEdgeInvalidArgs, "%s", disallowedRelTypeMessage(vocab, sourceType, targetType, relType))
+		}
+	} else if (sourceType == "paper" && targetType == "concept") || (sourceType == "concept" && targetType == "paper") {
 		warnNonStandardRelationType(relType)
 	} else if (sourceType == "concept" && targetType == "project") || (sourceType == "project" && targetType == "concept") {
 		warnNonStandardConceptProjectRelType(relType)
@@ -310,15 +339,14 @@ func runEdgeAdd(cmd *cobra.Command, args []string) error {
 		exitWithError(ExitDataError, "reading edges: %v", err)
 	}
 
-	// Upsert edge
+	// Upsert edge, then reread it to pick up the CreatedAt it was given
 	edges, updated := storage.UpsertEdge(edges, e)
-	e = edges[len(edges)-1] // Get the edge with CreatedAt set
+	idx, _ := storage.FindEdgeByKey(edges, e.Key())
+	e = edges[idx]
 
-	if updated {
-		// Find the updated edge
-		idx, _ := storage.FindEdgeByKey(edges, e.Key())
-		e = edges[idx]
-	}
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexEdges, edgesPath)
 
 	// Write back to JSONL
 	if err := storage.WriteAllEdges(edgesPath, edges); err != nil {
@@ -326,9 +354,7 @@ func runEdgeAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Update SQLite index
-	db := mustOpenDatabase(repoRoot)
-	defer db.Close()
-	if err := db.InsertEdge(e); err != nil {
+	if err := db.ApplyIndexUpdate(storage.IndexEdges, edgesPath, indexCurrent, func() error { return db.InsertEdge(e) }); err != nil {
 		exitWithError(ExitDataError, "updating index: %v", err)
 	}
 
@@ -407,7 +433,7 @@ func runEdgeImport(cmd *cobra.Command, args []string) error {
 	// Check if all edges were invalid
 	if result.Added == 0 && result.Updated == 0 && result.Skipped > 0 {
 		outputImportFailure(result)
-		os.Exit(ExitEdgeInvalidArgs)
+		os.Exit(ExitEdgeInvalidArgs.Status())
 	}
 
 	// Write back to JSONL
@@ -439,6 +465,13 @@ func runEdgeImport(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// importEdgeRecord is one line of an edge import file: an edge plus an
+// optional "at" timestamp that overrides created_at, as edge add --at does.
+type importEdgeRecord struct {
+	edge.Edge
+	At string `json:"at,omitempty"`
+}
+
 // processImportFile reads edges from a file and validates/upserts them.
 // Returns the updated edges slice and any file reading error.
 func processImportFile(f *os.File, edges []edge.Edge, ids nodeIDSets, result *EdgeImportResult) ([]edge.Edge, error) {
@@ -454,8 +487,8 @@ func processImportFile(f *os.File, edges []edge.Edge, ids nodeIDSets, result *Ed
 			continue
 		}
 
-		var e edge.Edge
-		if err := json.Unmarshal(line, &e); err != nil {
+		var rec importEdgeRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
 			result.Errors = append(result.Errors, EdgeImportError{
 				Line:  lineNum,
 				Error: fmt.Sprintf("invalid JSON: %v", err),
@@ -463,6 +496,19 @@ func processImportFile(f *os.File, edges []edge.Edge, ids nodeIDSets, result *Ed
 			result.Skipped++
 			continue
 		}
+		e := rec.Edge
+		if rec.At != "" {
+			createdAt, err := edge.NormalizeTimestamp(rec.At)
+			if err != nil {
+				result.Errors = append(result.Errors, EdgeImportError{
+					Line:  lineNum,
+					Error: fmt.Sprintf("at: %v", err),
+				})
+				result.Skipped++
+				continue
+			}
+			e.CreatedAt = createdAt
+		}
 
 		// Validate edge structure
 		if err := e.ValidateForCreate(); err != nil {
@@ -528,6 +574,25 @@ type EdgeListResult struct {
 	Incoming []edge.Edge `json:"incoming,omitempty"`
 }
 
+// EdgeListLine is one --json-lines record of edge list for a paper. The
+// direction says which side of the edge the paper is on.
+type EdgeListLine struct {
+	edge.Edge
+	Direction string `json:"direction"` // "outgoing" or "incoming"
+}
+
+// edgeListLines flattens a paper's edges for --json-lines, outgoing first.
+func edgeListLines(result EdgeListResult) []EdgeListLine {
+	lines := make([]EdgeListLine, 0, len(result.Outgoing)+len(result.Incoming))
+	for _, e := range result.Outgoing {
+		lines = append(lines, EdgeListLine{Edge: e, Direction: "outgoing"})
+	}
+	for _, e := range result.Incoming {
+		lines = append(lines, EdgeListLine{Edge: e, Direction: "incoming"})
+	}
+	return lines
+}
+
 // EdgeListAllResult is the response for listing all edges.
 type EdgeListAllResult struct {
 	Edges []edge.Edge `json:"edges"`
@@ -540,12 +605,14 @@ var edgeListCmd = &cobra.Command{
 	Long: `List edges in the knowledge graph.
 
 Without arguments, lists all edges. With a paper ID argument or --paper flag,
-lists edges for that specific paper. Use --concept to filter by concept.
+lists edges for that specific paper, optionally narrowed to one relationship
+type with --type. Use --concept to filter by concept.
 
 Examples:
   bip edge list                    # List all edges
   bip edge list Smith2026          # Edges for paper Smith2026
   bip edge list --paper Smith2026  # Same as above
+  bip edge list Smith2026 --all --type cites  # Only cites edges, both directions
   bip edge list --concept mcmc     # Edges involving concept "mcmc"`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runEdgeList,
@@ -559,6 +626,7 @@ func runEdgeList(cmd *cobra.Command, args []string) error {
 	projectFlag, _ := cmd.Flags().GetString("project")
 	incoming, _ := cmd.Flags().GetBool("incoming")
 	all, _ := cmd.Flags().GetBool("all")
+	relType, _ := cmd.Flags().GetString("type")
 
 	// Determine paper ID from args or flag
 	var paperID string
@@ -567,6 +635,9 @@ func runEdgeList(cmd *cobra.Command, args []string) error {
 	} else if paperFlag != "" {
 		paperID = paperFlag
 	}
+	if relType != "" && (paperID == "" || projectFlag != "" || conceptFlag != "") {
+		exitWithError(ExitError, "--type requires a paper ID (use 'bip edge search --type' across all edges)")
+	}
 
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
@@ -596,7 +667,7 @@ func runEdgeList(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			exitWithError(ExitDataError, "querying outgoing edges: %v", err)
 		}
-		result.Outgoing = outgoing
+		result.Outgoing = filterEdgesByType(outgoing, relType)
 	}
 
 	// Get incoming edges (paper is target)
@@ -605,7 +676,7 @@ func runEdgeList(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			exitWithError(ExitDataError, "querying incoming edges: %v", err)
 		}
-		result.Incoming = incomingEdges
+		result.Incoming = filterEdgesByType(incomingEdges, relType)
 	}
 
 	// Output results
@@ -641,38 +712,49 @@ func runEdgeList(cmd *cobra.Command, args []string) error {
 		if result.Incoming == nil {
 			result.Incoming = []edge.Edge{}
 		}
-		outputJSON(result)
+		outputList(sliceItems(edgeListLines(result)), func([]EdgeListLine) any { return result })
 	}
 
 	return nil
 }
 
+// filterEdgesByType returns the edges with relationship type relType, or
+// all edges if relType is empty.
+func filterEdgesByType(edges []edge.Edge, relType string) []edge.Edge {
+	if relType == "" {
+		return edges
+	}
+	var filtered []edge.Edge
+	for _, e := range edges {
+		if e.RelationshipType == relType {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
 // runEdgeListAll outputs all edges in the graph.
 func runEdgeListAll(db *storage.DB) error {
-	edges, err := db.GetAllEdges()
+	items := listItems[edge.Edge](func(yield func(edge.Edge) error) error {
+		return db.GetAllEdgesEach(yield)
+	})
+	if !humanOutput {
+		return outputEdgeList(items)
+	}
+
+	edges, err := collectItems(items)
 	if err != nil {
 		exitWithError(ExitDataError, "querying edges: %v", err)
 	}
+	if len(edges) == 0 {
+		fmt.Println("No edges in knowledge graph.")
+		return nil
+	}
 
-	if humanOutput {
-		if len(edges) == 0 {
-			fmt.Println("No edges in knowledge graph.")
-			return nil
-		}
-
-		fmt.Printf("All edges (%d total):\n", len(edges))
-		for _, e := range edges {
-			fmt.Printf("  %s --[%s]--> %s\n", e.SourceID, e.RelationshipType, e.TargetID)
-			fmt.Printf("    %q\n", e.Summary)
-		}
-	} else {
-		if edges == nil {
-			edges = []edge.Edge{}
-		}
-		outputJSON(EdgeListAllResult{
-			Edges: edges,
-			Count: len(edges),
-		})
+	fmt.Printf("All edges (%d total):\n", len(edges))
+	for _, e := range edges {
+		fmt.Printf("  %s --[%s]--> %s\n", e.SourceID, e.RelationshipType, e.TargetID)
+		fmt.Printf("    %q\n", e.Summary)
 	}
 
 	return nil
@@ -680,30 +762,26 @@ func runEdgeListAll(db *storage.DB) error {
 
 // runEdgeListByProject outputs edges involving a specific project.
 func runEdgeListByProject(db *storage.DB, projectID string) error {
-	edges, err := db.GetEdgesByProject(projectID)
+	items := listItems[edge.Edge](func(yield func(edge.Edge) error) error {
+		return db.GetEdgesByProjectEach(projectID, yield)
+	})
+	if !humanOutput {
+		return outputEdgeList(items)
+	}
+
+	edges, err := collectItems(items)
 	if err != nil {
 		exitWithError(ExitDataError, "querying edges: %v", err)
 	}
+	if len(edges) == 0 {
+		fmt.Printf("No edges found for project %s\n", projectID)
+		return nil
+	}
 
-	if humanOutput {
-		if len(edges) == 0 {
-			fmt.Printf("No edges found for project %s\n", projectID)
-			return nil
-		}
-
-		fmt.Printf("Edges for project %s (%d total):\n", projectID, len(edges))
-		for _, e := range edges {
-			fmt.Printf("  %s --[%s]--> %s\n", e.SourceID, e.RelationshipType, e.TargetID)
-			fmt.Printf("    %q\n", e.Summary)
-		}
-	} else {
-		if edges == nil {
-			edges = []edge.Edge{}
-		}
-		outputJSON(EdgeListAllResult{
-			Edges: edges,
-			Count: len(edges),
-		})
+	fmt.Printf("Edges for project %s (%d total):\n", projectID, len(edges))
+	for _, e := range edges {
+		fmt.Printf("  %s --[%s]--> %s\n", e.SourceID, e.RelationshipType, e.TargetID)
+		fmt.Printf("    %q\n", e.Summary)
 	}
 
 	return nil
@@ -711,32 +789,43 @@ func runEdgeListByProject(db *storage.DB, projectID string) error {
 
 // runEdgeListByConcept outputs edges involving a specific concept.
 func runEdgeListByConcept(db *storage.DB, conceptID string) error {
-	edges, err := db.GetEdgesByTarget("concept:" + conceptID)
+	items := listItems[edge.Edge](func(yield func(edge.Edge) error) error {
+		return db.GetEdgesByTargetEach("concept:"+conceptID, yield)
+	})
+	if !humanOutput {
+		return outputEdgeList(items)
+	}
+
+	edges, err := collectItems(items)
 	if err != nil {
 		exitWithError(ExitDataError, "querying edges: %v", err)
 	}
+	if len(edges) == 0 {
+		fmt.Printf("No edges found for concept %s\n", conceptID)
+		return nil
+	}
 
-	if humanOutput {
-		if len(edges) == 0 {
-			fmt.Printf("No edges found for concept %s\n", conceptID)
-			return nil
-		}
+	fmt.Printf("Edges to concept %s (%d total):\n", conceptID, len(edges))
+	for _, e := range edges {
+		fmt.Printf("  %s --[%s]--> %s\n", e.SourceID, e.RelationshipType, e.TargetID)
+		fmt.Printf("    %q\n", e.Summary)
+	}
 
-		fmt.Printf("Edges to concept %s (%d total):\n", conceptID, len(edges))
-		for _, e := range edges {
-			fmt.Printf("  %s --[%s]--> %s\n", e.SourceID, e.RelationshipType, e.TargetID)
-			fmt.Printf("    %q\n", e.Summary)
-		}
-	} else {
-		if edges == nil {
-			edges = []edge.Edge{}
-		}
-		outputJSON(EdgeListAllResult{
+	return nil
+}
+
+// outputEdgeList writes edges as an EdgeListAllResult JSON object, or one
+// edge per line with --json-lines.
+func outputEdgeList(items listItems[edge.Edge]) error {
+	err := outputList(items, func(edges []edge.Edge) any {
+		return EdgeListAllResult{
 			Edges: edges,
 			Count: len(edges),
-		})
+		}
+	})
+	if err != nil {
+		exitWithError(ExitDataError, "querying edges: %v", err)
 	}
-
 	return nil
 }
 
@@ -756,35 +845,41 @@ var edgeSearchCmd = &cobra.Command{
 func runEdgeSearch(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
 	relType, _ := cmd.Flags().GetString("type")
+	limit := resolveSearchLimit(cmd, mustLoadConfig(repoRoot), 0)
 
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
 
-	edges, err := db.GetEdgesByType(relType)
+	items := limitItems(func(yield func(edge.Edge) error) error {
+		return db.GetEdgesByTypeEach(relType, yield)
+	}, limit)
+
+	if !humanOutput {
+		err := outputList(items, func(edges []edge.Edge) any {
+			return EdgeSearchResult{
+				RelationshipType: relType,
+				Edges:            edges,
+			}
+		})
+		if err != nil {
+			exitWithError(ExitDataError, "searching edges: %v", err)
+		}
+		return nil
+	}
+
+	edges, err := collectItems(items)
 	if err != nil {
 		exitWithError(ExitDataError, "searching edges: %v", err)
 	}
+	if len(edges) == 0 {
+		fmt.Printf("No edges found with type %q\n", relType)
+		return nil
+	}
 
-	// Output results
-	if humanOutput {
-		if len(edges) == 0 {
-			fmt.Printf("No edges found with type %q\n", relType)
-			return nil
-		}
-
-		fmt.Printf("Edges with type %q:\n", relType)
-		for _, e := range edges {
-			fmt.Printf("  %s --[%s]--> %s\n", e.SourceID, e.RelationshipType, e.TargetID)
-			fmt.Printf("    %q\n", e.Summary)
-		}
-	} else {
-		if edges == nil {
-			edges = []edge.Edge{}
-		}
-		outputJSON(EdgeSearchResult{
-			RelationshipType: relType,
-			Edges:            edges,
-		})
+	fmt.Printf("Edges with type %q:\n", relType)
+	for _, e := range edges {
+		fmt.Printf("  %s --[%s]--> %s\n", e.SourceID, e.RelationshipType, e.TargetID)
+		fmt.Printf("    %q\n", e.Summary)
 	}
 
 	return nil
@@ -793,13 +888,32 @@ func runEdgeSearch(cmd *cobra.Command, args []string) error {
 var edgeExportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export edges to JSONL format",
-	Long:  `Export edges to JSONL format, writing to stdout.`,
-	RunE:  runEdgeExport,
+	Long: `Export edges to JSONL format, writing to stdout.
+
+Use --format csv for a spreadsheet-friendly CSV with a header row
+(source_id,target_id,relationship_type,summary,created_at).
+
+Edges are always written sorted by source_id, target_id, and
+relationship_type, so --offset and --limit page through a stable order:
+
+  bip edge export --limit 1000 --offset 0 > part1.jsonl
+  bip edge export --limit 1000 --offset 1000 > part2.jsonl
+  bip edge export --format csv > edges.csv`,
+	RunE: runEdgeExport,
 }
 
 func runEdgeExport(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
 	paperID, _ := cmd.Flags().GetString("paper")
+	limit, _ := cmd.Flags().GetInt("limit")
+	offset, _ := cmd.Flags().GetInt("offset")
+	format, _ := cmd.Flags().GetString("format")
+	if limit < 0 || offset < 0 {
+		exitWithError(ExitEdgeInvalidArgs, "--limit and --offset must not be negative")
+	}
+	if format != "jsonl" && format != "csv" {
+		exitWithError(ExitEdgeInvalidArgs, "invalid --format %q: must be jsonl or csv", format)
+	}
 
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
@@ -819,6 +933,15 @@ func runEdgeExport(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	edges = paginateEdges(edges, offset, limit)
+
+	if format == "csv" {
+		if err := writeEdgesCSV(os.Stdout, edges); err != nil {
+			exitWithError(ExitDataError, "writing CSV: %v", err)
+		}
+		return nil
+	}
+
 	// Output as JSONL (one JSON object per line)
 	for _, e := range edges {
 		outputJSONCompact(e)
@@ -826,3 +949,29 @@ func runEdgeExport(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// edgeCSVHeader is the header row written by edge export --format csv.
+var edgeCSVHeader = []string{"source_id", "target_id", "relationship_type", "summary", "created_at"}
+
+// writeEdgesCSV writes edges as CSV with a header row.
+func writeEdgesCSV(out io.Writer, edges []edge.Edge) error {
+	rows := make([][]string, len(edges))
+	for i, e := range edges {
+		rows[i] = []string{e.SourceID, e.TargetID, e.RelationshipType, e.Summary, e.CreatedAt}
+	}
+	return writeCSV(out, edgeCSVHeader, rows)
+}
+
+// paginateEdges returns the page of edges starting at offset with at most
+// limit entries (0 = no limit). The queries feeding it sort by edge key, so
+// pages are stable across calls.
+func paginateEdges(edges []edge.Edge, offset, limit int) []edge.Edge {
+	if offset >= len(edges) {
+		return nil
+	}
+	edges = edges[offset:]
+	if limit > 0 && limit < len(edges) {
+		edges = edges[:limit]
+	}
+	return edges
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	edgeStatsCmd.Flags().String("node-type", "", "Only count edges with an endpoint of this type: paper, concept, or project")
+	edgeCmd.AddCommand(edgeStatsCmd)
+}
+
+var edgeStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Count edges per relationship type",
+	Long: `Count the edges of each relationship type, most common first, e.g. to
+see which types are in use before writing an edge-type vocabulary.
+
+With --node-type, only edges with at least one endpoint of that type are
+counted, so --node-type project shows the types used for concept↔project
+links.
+
+Examples:
+  bip edge stats --human
+  bip edge stats --node-type project --human`,
+	Args: cobra.NoArgs,
+	RunE: runEdgeStats,
+}
+
+// EdgeStatsResult is the response for the edge stats command.
+type EdgeStatsResult struct {
+	Types    []storage.RelationshipTypeCount `json:"types"`
+	Total    int                             `json:"total"`               // Edges counted
+	NodeType string                          `json:"node_type,omitempty"` // The --node-type filter, if given
+}
+
+func runEdgeStats(cmd *cobra.Command, args []string) error {
+	nodeType, _ := cmd.Flags().GetString("node-type")
+
+	repoRoot := mustFindRepository()
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	counts, err := db.GetRelationshipTypeCounts(nodeType)
+	if err != nil {
+		exitWithError(ExitEdgeInvalidArgs, "%v", err)
+	}
+
+	result := EdgeStatsResult{Types: counts, NodeType: nodeType}
+	if result.Types == nil {
+		result.Types = []storage.RelationshipTypeCount{}
+	}
+	for _, c := range counts {
+		result.Total += c.Count
+	}
+
+	if humanOutput {
+		if len(result.Types) == 0 {
+			fmt.Println("No edges found")
+			return nil
+		}
+		fmt.Printf("%-30s %6s\n", "TYPE", "COUNT")
+		for _, c := range result.Types {
+			fmt.Printf("%-30s %6d\n", truncateString(c.RelationshipType, 30), c.Count)
+		}
+		fmt.Printf("\n%d edges, %d relationship types\n", result.Total, len(result.Types))
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/s2"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+// CitesRelationshipType is the relationship type for paper-to-paper citation edges.
+const CitesRelationshipType = "cites"
+
+// citationSuggestionSummary is the summary recorded on edges created by --apply.
+const citationSuggestionSummary = "Citation reported by Semantic Scholar"
+
+func init() {
+	edgeSuggestCitationsCmd.Flags().Bool("apply", false, "Create the suggested cites edges")
+	edgeSuggestCitationsCmd.Flags().Int("batch-size", 100, fmt.Sprintf("Papers per Semantic Scholar batch request (max %d)", s2.MaxBatchSize))
+	edgeCmd.AddCommand(edgeSuggestCitationsCmd)
+}
+
+var edgeSuggestCitationsCmd = &cobra.Command{
+	Use:   "suggest-citations",
+	Short: "Suggest cites edges between papers already in the library",
+	Long: `Fetch reference lists from Semantic Scholar and report citations between
+two papers that are both in the library but have no "cites" edge yet.
+
+Papers are looked up by DOI (or S2 paper ID) in batches, so a library of a few
+hundred papers takes only a handful of requests. Papers without a DOI or S2 ID
+are skipped. If the API rate limit is hit, the suggestions found so far are
+reported and the result is marked incomplete.
+
+Examples:
+  bip edge suggest-citations --human
+  bip edge suggest-citations --apply`,
+	Args: cobra.NoArgs,
+	RunE: runEdgeSuggestCitations,
+}
+
+// CitationSuggestion is a cites edge missing from the local graph.
+type CitationSuggestion struct {
+	SourceID string `json:"source_id"` // Citing paper
+	TargetID string `json:"target_id"` // Cited paper
+}
+
+// EdgeSuggestCitationsResult is the response for the edge suggest-citations command.
+type EdgeSuggestCitationsResult struct {
+	Suggestions    []CitationSuggestion `json:"suggestions"`
+	Checked        int                  `json:"checked"`    // Library papers whose references were fetched
+	NotFound       int                  `json:"not_found"`  // Library papers unknown to Semantic Scholar
+	Unresolved     int                  `json:"unresolved"` // Library papers with no DOI or S2 ID
+	Applied        int                  `json:"applied,omitempty"`
+	Incomplete     bool                 `json:"incomplete,omitempty"` // Stopped early due to rate limiting
+	IncompleteNote string               `json:"incomplete_note,omitempty"`
+}
+
+func runEdgeSuggestCitations(cmd *cobra.Command, args []string) error {
+	apply, _ := cmd.Flags().GetBool("apply")
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+	if batchSize < 1 || batchSize > s2.MaxBatchSize {
+		exitWithError(ExitEdgeInvalidArgs, "--batch-size must be between 1 and %d", s2.MaxBatchSize)
+	}
+
+	ctx := context.Background()
+	repoRoot := mustFindRepository()
+
+	refs, err := storage.ReadAll(config.RefsPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+	edgesPath := config.EdgesPath(repoRoot)
+	edges, err := storage.ReadAllEdges(edgesPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading edges: %v", err)
+	}
+
+	resolver := s2.NewLocalResolverFromRefs(refs)
+	client := newS2Client()
+
+	// Map each resolvable library paper to its S2 lookup ID
+	var localIDs, s2IDs []string
+	result := EdgeSuggestCitationsResult{}
+	for _, ref := range refs {
+		s2ID, _, err := resolver.ResolveToS2ID(ref.ID)
+		if err != nil {
+			result.Unresolved++
+			continue
+		}
+		localIDs = append(localIDs, ref.ID)
+		s2IDs = append(s2IDs, s2ID)
+	}
+
+	existing := make(map[edge.EdgeKey]bool, len(edges))
+	for _, e := range edges {
+		existing[e.Key()] = true
+	}
+
+	seen := make(map[edge.EdgeKey]bool)
+	for start := 0; start < len(s2IDs); start += batchSize {
+		end := min(start+batchSize, len(s2IDs))
+		if humanOutput {
+			fmt.Fprintf(os.Stderr, "Fetching references for papers %d-%d of %d...\n", start+1, end, len(s2IDs))
+		}
+
+		papers, err := client.GetReferencesBatch(ctx, s2IDs[start:end])
+		if err != nil {
+			if s2.IsRateLimited(err) {
+				result.Incomplete = true
+				result.IncompleteNote = fmt.Sprintf("rate limited after %d of %d papers; rerun later to check the rest", start, len(s2IDs))
+				break
+			}
+			exitWithError(ExitError, "fetching references: %v", err)
+		}
+
+		for i, p := range papers {
+			if p == nil {
+				result.NotFound++
+				continue
+			}
+			result.Checked++
+			for _, s := range missingCitations(localIDs[start+i], p.References, resolver, existing) {
+				key := edge.EdgeKey{SourceID: s.SourceID, TargetID: s.TargetID, RelationshipType: CitesRelationshipType}
+				if !seen[key] {
+					seen[key] = true
+					result.Suggestions = append(result.Suggestions, s)
+				}
+			}
+		}
+	}
+
+	sort.Slice(result.Suggestions, func(i, j int) bool {
+		a, b := result.Suggestions[i], result.Suggestions[j]
+		if a.SourceID != b.SourceID {
+			return a.SourceID < b.SourceID
+		}
+		return a.TargetID < b.TargetID
+	})
+	if result.Suggestions == nil {
+		result.Suggestions = []CitationSuggestion{}
+	}
+
+	if apply && len(result.Suggestions) > 0 {
+		result.Applied = applyCitationSuggestions(repoRoot, edgesPath, edges, result.Suggestions)
+	}
+
+	if humanOutput {
+		printCitationSuggestions(result, apply)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// missingCitations returns a suggestion for each reference of sourceID that
+// resolves to a different library paper and has no cites edge yet.
+func missingCitations(sourceID string, references []s2.S2Paper, resolver *s2.LocalResolver, existing map[edge.EdgeKey]bool) []CitationSuggestion {
+	var suggestions []CitationSuggestion
+	for _, cited := range references {
+		ref, ok := resolver.ExistsLocally(cited)
+		if !ok || ref.ID == sourceID {
+			continue
+		}
+		key := edge.EdgeKey{SourceID: sourceID, TargetID: ref.ID, RelationshipType: CitesRelationshipType}
+		if existing[key] {
+			continue
+		}
+		suggestions = append(suggestions, CitationSuggestion{SourceID: sourceID, TargetID: ref.ID})
+	}
+	return suggestions
+}
+
+// applyCitationSuggestions writes the suggested edges to JSONL and the SQLite index.
+// Returns the number of edges created.
+func applyCitationSuggestions(repoRoot, edgesPath string, edges []edge.Edge, suggestions []CitationSuggestion) int {
+	var added []edge.Edge
+	for _, s := range suggestions {
+		e := edge.Edge{
+			SourceID:         s.SourceID,
+			TargetID:         s.TargetID,
+			RelationshipType: CitesRelationshipType,
+			Summary:          citationSuggestionSummary,
+		}
+		var updated bool
+		edges, updated = storage.UpsertEdgeInSlice(edges, e)
+		if !updated {
+			added = append(added, edges[len(edges)-1])
+		}
+	}
+
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexEdges, edgesPath)
+
+	if err := storage.WriteAllEdges(edgesPath, edges); err != nil {
+		exitWithError(ExitDataError, "writing edges: %v", err)
+	}
+
+	err := db.ApplyIndexUpdate(storage.IndexEdges, edgesPath, indexCurrent, func() error {
+		for _, e := range added {
+			if err := db.InsertEdge(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		exitWithError(ExitDataError, "updating index: %v", err)
+	}
+
+	return len(added)
+}
+
+// printCitationSuggestions prints suggest-citations results for --human output.
+func printCitationSuggestions(result EdgeSuggestCitationsResult, apply bool) {
+	if len(result.Suggestions) == 0 {
+		fmt.Println("No missing citation edges found.")
+	} else {
+		verb := "Suggested"
+		if apply {
+			verb = "Added"
+		}
+		for _, s := range result.Suggestions {
+			fmt.Printf("%s: %s --[%s]--> %s\n", verb, s.SourceID, CitesRelationshipType, s.TargetID)
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("Checked %d papers (%d not in Semantic Scholar, %d without DOI or S2 ID).\n",
+		result.Checked, result.NotFound, result.Unresolved)
+	if len(result.Suggestions) > 0 && !apply {
+		fmt.Println("Run with --apply to create these edges.")
+	}
+	if result.Incomplete {
+		fmt.Printf("Incomplete: %s\n", result.IncompleteNote)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/s2"
+)
+
+func TestMissingCitations(t *testing.T) {
+	resolver := s2.NewLocalResolverFromRefs([]reference.Reference{
+		{ID: "Alpha2020", DOI: "10.1/alpha"},
+		{ID: "Beta2021", DOI: "10.1/beta"},
+		{ID: "Gamma2022", Source: reference.ImportSource{Type: "s2", ID: "gamma-s2"}},
+	})
+	existing := map[edge.EdgeKey]bool{
+		{SourceID: "Alpha2020", TargetID: "Beta2021", RelationshipType: CitesRelationshipType}: true,
+	}
+
+	refs := []s2.S2Paper{
+		{PaperID: "x", ExternalIDs: s2.ExternalIDs{DOI: "10.1/BETA"}}, // Already linked
+		{PaperID: "gamma-s2"}, // Matched by S2 ID
+		{PaperID: "y", ExternalIDs: s2.ExternalIDs{DOI: "10.1/alpha"}}, // Self-citation
+		{PaperID: "z", ExternalIDs: s2.ExternalIDs{DOI: "10.1/other"}}, // Not in library
+	}
+
+	got := missingCitations("Alpha2020", refs, resolver, existing)
+	want := []CitationSuggestion{{SourceID: "Alpha2020", TargetID: "Gamma2022"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("missingCitations() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	edgeCmd.AddCommand(edgeTypesCmd)
+}
+
+var edgeTypesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "List permitted relationship types",
+	Long: `List relationship types by node-type pair.
+
+If .bipartite/edge-types.yml exists, it is the vocabulary that 'edge add'
+enforces, and this command lists it:
+
+  paper-concept: [introduces, applies, models]
+  paper-paper: [cites, extends]
+  concept-concept: [subconcept-of]
+
+Pairs are unordered, so paper-concept also covers concept→paper edges.
+Without the file any type is accepted, and this command lists the distinct
+types currently used in the edges table instead.
+
+Examples:
+  bip edge types --human`,
+	Args: cobra.NoArgs,
+	RunE: runEdgeTypes,
+}
+
+// EdgeTypesResult is the response for the edge types command.
+type EdgeTypesResult struct {
+	Source string              `json:"source"` // "vocabulary" or "edges"
+	Pairs  map[string][]string `json:"pairs"`
+}
+
+func runEdgeTypes(cmd *cobra.Command, args []string) error {
+	repoRoot := mustFindRepository()
+
+	vocab, err := edge.LoadVocabulary(config.EdgeTypesPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitConfigError, "%v", err)
+	}
+
+	result := EdgeTypesResult{Source: "vocabulary", Pairs: vocab}
+	if vocab == nil {
+		db := mustOpenDatabase(repoRoot)
+		defer db.Close()
+
+		edges, err := db.GetAllEdges()
+		if err != nil {
+			exitWithError(ExitDataError, "querying edges: %v", err)
+		}
+		result = EdgeTypesResult{Source: "edges", Pairs: relTypesInUse(edges)}
+	}
+
+	if humanOutput {
+		if result.Source == "vocabulary" {
+			fmt.Printf("Relationship types from %s:\n", config.EdgeTypesFile)
+		} else {
+			fmt.Printf("No %s; relationship types in use:\n", config.EdgeTypesFile)
+		}
+		pairs := make([]string, 0, len(result.Pairs))
+		for pair := range result.Pairs {
+			pairs = append(pairs, pair)
+		}
+		sort.Strings(pairs)
+		for _, pair := range pairs {
+			fmt.Printf("  %s: %s\n", pair, strings.Join(result.Pairs[pair], ", "))
+		}
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// relTypesInUse groups the distinct relationship types of edges by
+// node-type pair, in the same shape as an edge.Vocabulary.
+func relTypesInUse(edges []edge.Edge) map[string][]string {
+	seen := make(map[string]map[string]bool)
+	for _, e := range edges {
+		srcType, _ := parseNodeType(e.SourceID)
+		tgtType, _ := parseNodeType(e.TargetID)
+		pair := edge.PairKey(srcType, tgtType)
+		if seen[pair] == nil {
+			seen[pair] = make(map[string]bool)
+		}
+		seen[pair][e.RelationshipType] = true
+	}
+
+	pairs := make(map[string][]string, len(seen))
+	for pair, types := range seen {
+		for t := range types {
+			pairs[pair] = append(pairs[pair], t)
+		}
+		sort.Strings(pairs[pair])
+	}
+	return pairs
+}
+
+// disallowedRelTypeMessage explains why edge add rejected relType.
+func disallowedRelTypeMessage(vocab edge.Vocabulary, sourceType, targetType, relType string) string {
+	pair := edge.PairKey(sourceType, targetType)
+	allowed := "none"
+	if len(vocab[pair]) > 0 {
+		allowed = strings.Join(vocab[pair], ", ")
+	}
+	return fmt.Sprintf("relationship type %q is not allowed for %s edges (allowed: %s)\n\nAdd it to .bipartite/%s to permit it.",
+		relType, pair, allowed, config.EdgeTypesFile)
+}
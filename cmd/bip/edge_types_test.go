@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/edge"
+)
+
+func TestRelTypesInUse(t *testing.T) {
+	edges := []edge.Edge{
+		{SourceID: "PaperA", TargetID: "PaperB", RelationshipType: "cites"},
+		{SourceID: "PaperB", TargetID: "PaperC", RelationshipType: "cites"},
+		{SourceID: "PaperA", TargetID: "concept:vae", RelationshipType: "introduces"},
+		{SourceID: "concept:vae", TargetID: "PaperC", RelationshipType: "applies"},
+		{SourceID: "concept:vae", TargetID: "concept:ml", RelationshipType: "subconcept-of"},
+	}
+
+	got := relTypesInUse(edges)
+	want := map[string][]string{
+		"paper-paper":     {"cites"},
+		"paper-concept":   {"applies", "introduces"},
+		"concept-concept": {"subconcept-of"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("relTypesInUse() = %v, want %v", got, want)
+	}
+}
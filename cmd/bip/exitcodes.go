@@ -1,17 +1,135 @@
 package main
 
+// ExitCode identifies why bip exited. Command groups reuse the same process
+// exit statuses with different meanings (2 is a config error for most
+// commands but "not found" for project commands), so each condition is its
+// own value, and exitCodeTable maps it to its status and error_code.
+type ExitCode int
+
 // Exit codes as defined in contracts/cli.md
 const (
-	ExitSuccess       = 0 // Success
-	ExitError         = 1 // General error (invalid arguments, runtime failure)
-	ExitConfigError   = 2 // Configuration error (missing config, invalid paths) / Index not found (Phase II)
-	ExitDataError     = 3 // Data error (malformed input, validation failure) / Ollama not available (Phase II)
-	ExitNoAbstract    = 4 // Paper has no abstract (Phase II)
-	ExitModelNotFound = 5 // Embedding model not found (Phase II)
-	ExitIndexStale    = 6 // Semantic index is stale (Phase II)
+	ExitSuccess       ExitCode = iota // Success
+	ExitError                         // General error (invalid arguments, runtime failure)
+	ExitConfigError                   // Configuration error (missing config, invalid paths) / Index not found (Phase II)
+	ExitDataError                     // Data error (malformed input, validation failure) / Ollama not available (Phase II)
+	ExitNoAbstract                    // Paper has no abstract (Phase II)
+	ExitModelNotFound                 // Embedding model not found (Phase II)
+	ExitIndexStale                    // Semantic index is stale (Phase II)
 
 	// ASTA exit codes (from contracts/cli.md)
-	ExitASTANotFound  = 1 // Resource not found in ASTA
-	ExitASTAAuthError = 2 // Missing or invalid ASTA_API_KEY
-	ExitASTAAPIError  = 3 // API error (rate limit, network)
+	ExitASTANotFound  // Resource not found in ASTA
+	ExitASTAAuthError // Missing or invalid ASTA_API_KEY
+	ExitASTAAPIError  // API error (rate limit, network)
+
+	// Exit codes specific to s2 commands (from contracts/cli.md)
+	ExitS2NotFound  // Paper not found in Semantic Scholar
+	ExitS2Duplicate // Paper already exists (without --update)
+	ExitS2APIError  // API error (rate limit, network)
+
+	// Exit codes specific to slack commands (from spec.md)
+	ExitSlackMissingToken    // SLACK_BOT_TOKEN not set
+	ExitSlackChannelNotFound // Channel not in configuration
+	ExitSlackNotMember       // Bot not member of channel
+
+	// Exit codes specific to edge commands (per CLI contract)
+	ExitEdgeSourceNotFound // Source paper not found
+	ExitEdgeTargetNotFound // Target paper not found
+	ExitEdgeInvalidArgs    // Invalid arguments
+
+	// Exit codes for paper, project, concept, and repo commands (per CLI contract)
+	ExitPaperNotFound     // Paper not found
+	ExitProjectNotFound   // Project not found
+	ExitProjectValidation // Validation error (invalid ID, duplicate, has edges)
+	ExitConceptNotFound   // Concept not found
+	ExitConceptValidation // Validation error (invalid ID, duplicate, has edges)
+	ExitRepoNotFound      // Repo not found
+	ExitRepoValidation    // Validation error
+	ExitRepoDataError     // Data error
+	ExitRepoGitHubError   // GitHub API error
+
+	// Exit codes specific to check, so automation can branch on the outcome
+	ExitCheckIssues // Issues found (read-only, or left after --fix)
+	ExitCheckFixed  // Issues found and all of them fixed by --fix
+
+	numExitCodes // Number of exit codes; keep last
+)
+
+// Error classes for ErrorResponse.ErrorCode. Every ExitCode maps to one of
+// these; exitWithErrorCode can report a more specific code below instead.
+const (
+	ErrorCodeGeneral     = "general"      // Invalid arguments or an unexpected runtime failure
+	ErrorCodeConfig      = "config"       // Missing or invalid configuration, or no index
+	ErrorCodeData        = "data"         // Malformed input or unreadable/unwritable data files
+	ErrorCodeNotFound    = "not_found"    // The requested paper, concept, project, repo, or model does not exist
+	ErrorCodeValidation  = "validation"   // The request is well-formed but not allowed
+	ErrorCodeDuplicate   = "duplicate"    // The item already exists
+	ErrorCodeAuth        = "auth"         // A credential is missing or rejected
+	ErrorCodePermission  = "permission"   // The credential lacks access, e.g. the Slack bot is not in the channel
+	ErrorCodeAPI         = "api"          // An external API failed or rate-limited the request
+	ErrorCodeGitHub      = "github"       // The GitHub API failed or rate-limited the request
+	ErrorCodeNoAbstract  = "no_abstract"  // The paper has no abstract to embed
+	ErrorCodeIndexStale  = "index_stale"  // The semantic index needs rebuilding
+	ErrorCodeCheckIssues = "check_issues" // bip check found issues
+	ErrorCodeCheckFixed  = "check_fixed"  // bip check --fix repaired every issue
 )
+
+// exitCodeInfo is the process exit status and error_code of an ExitCode.
+type exitCodeInfo struct {
+	status    int
+	errorCode string
+}
+
+// exitCodeTable is the single lookup from each ExitCode to its exit status
+// and error class.
+var exitCodeTable = map[ExitCode]exitCodeInfo{
+	ExitSuccess:       {0, ""},
+	ExitError:         {1, ErrorCodeGeneral},
+	ExitConfigError:   {2, ErrorCodeConfig},
+	ExitDataError:     {3, ErrorCodeData},
+	ExitNoAbstract:    {4, ErrorCodeNoAbstract},
+	ExitModelNotFound: {5, ErrorCodeNotFound},
+	ExitIndexStale:    {6, ErrorCodeIndexStale},
+
+	ExitASTANotFound:  {1, ErrorCodeNotFound},
+	ExitASTAAuthError: {2, ErrorCodeAuth},
+	ExitASTAAPIError:  {3, ErrorCodeAPI},
+
+	ExitS2NotFound:  {1, ErrorCodeNotFound},
+	ExitS2Duplicate: {2, ErrorCodeDuplicate},
+	ExitS2APIError:  {3, ErrorCodeAPI},
+
+	ExitSlackMissingToken:    {1, ErrorCodeAuth},
+	ExitSlackChannelNotFound: {2, ErrorCodeNotFound},
+	ExitSlackNotMember:       {3, ErrorCodePermission},
+
+	ExitEdgeSourceNotFound: {1, ErrorCodeNotFound},
+	ExitEdgeTargetNotFound: {2, ErrorCodeNotFound},
+	ExitEdgeInvalidArgs:    {3, ErrorCodeValidation},
+
+	ExitPaperNotFound:     {2, ErrorCodeNotFound},
+	ExitProjectNotFound:   {2, ErrorCodeNotFound},
+	ExitProjectValidation: {3, ErrorCodeValidation},
+	ExitConceptNotFound:   {2, ErrorCodeNotFound},
+	ExitConceptValidation: {3, ErrorCodeValidation},
+	ExitRepoNotFound:      {2, ErrorCodeNotFound},
+	ExitRepoValidation:    {3, ErrorCodeValidation},
+	ExitRepoDataError:     {4, ErrorCodeData},
+	ExitRepoGitHubError:   {5, ErrorCodeGitHub},
+
+	ExitCheckIssues: {7, ErrorCodeCheckIssues},
+	ExitCheckFixed:  {8, ErrorCodeCheckFixed},
+}
+
+// Status returns the process exit status for c.
+func (c ExitCode) Status() int {
+	info, ok := exitCodeTable[c]
+	if !ok {
+		return exitCodeTable[ExitError].status
+	}
+	return info.status
+}
+
+// ErrorCode returns the error_code reported in JSON errors for c.
+func (c ExitCode) ErrorCode() string {
+	return exitCodeTable[c].errorCode
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/asta"
+)
+
+// errorCodeConsts is the enumerated set of error_code values, keyed by
+// constant name.
+var errorCodeConsts = map[string]string{
+	"ErrorCodeGeneral":             ErrorCodeGeneral,
+	"ErrorCodeConfig":              ErrorCodeConfig,
+	"ErrorCodeData":                ErrorCodeData,
+	"ErrorCodeNotFound":            ErrorCodeNotFound,
+	"ErrorCodeValidation":          ErrorCodeValidation,
+	"ErrorCodeDuplicate":           ErrorCodeDuplicate,
+	"ErrorCodeAuth":                ErrorCodeAuth,
+	"ErrorCodePermission":          ErrorCodePermission,
+	"ErrorCodeAPI":                 ErrorCodeAPI,
+	"ErrorCodeGitHub":              ErrorCodeGitHub,
+	"ErrorCodeNoAbstract":          ErrorCodeNoAbstract,
+	"ErrorCodeIndexStale":          ErrorCodeIndexStale,
+	"ErrorCodeCheckIssues":         ErrorCodeCheckIssues,
+	"ErrorCodeCheckFixed":          ErrorCodeCheckFixed,
+	"ErrorCodeOllamaUnavailable":   ErrorCodeOllamaUnavailable,
+	"ErrorCodeOpenAIUnavailable":   ErrorCodeOpenAIUnavailable,
+	"ErrorCodeDOINotFound":         ErrorCodeDOINotFound,
+	"ErrorCodeCrossrefUnavailable": ErrorCodeCrossrefUnavailable,
+	"ErrorCodeArXivNotFound":       ErrorCodeArXivNotFound,
+	"ErrorCodeArXivUnavailable":    ErrorCodeArXivUnavailable,
+}
+
+// isKnownErrorCode reports whether code is in errorCodeConsts.
+func isKnownErrorCode(code string) bool {
+	for _, known := range errorCodeConsts {
+		if code == known {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExitCodeTable(t *testing.T) {
+	if len(exitCodeTable) != int(numExitCodes) {
+		t.Errorf("exitCodeTable has %d entries, want %d", len(exitCodeTable), numExitCodes)
+	}
+	for c := ExitSuccess + 1; c < numExitCodes; c++ {
+		info, ok := exitCodeTable[c]
+		if !ok {
+			t.Errorf("ExitCode %d has no exitCodeTable entry", c)
+			continue
+		}
+		if info.status == 0 {
+			t.Errorf("ExitCode %d exits with status 0", c)
+		}
+		if !isKnownErrorCode(info.errorCode) {
+			t.Errorf("ExitCode %d has unknown error code %q", c, info.errorCode)
+		}
+	}
+
+	// The numeric statuses are part of the CLI contract
+	statuses := map[ExitCode]int{
+		ExitSuccess: 0, ExitError: 1, ExitConfigError: 2, ExitDataError: 3,
+		ExitProjectNotFound: 2, ExitConceptValidation: 3, ExitRepoGitHubError: 5,
+		ExitS2Duplicate: 2, ExitCheckIssues: 7, ExitCheckFixed: 8,
+	}
+	for c, want := range statuses {
+		if got := c.Status(); got != want {
+			t.Errorf("ExitCode %d Status() = %d, want %d", c, got, want)
+		}
+	}
+	if got := numExitCodes.Status(); got != 1 {
+		t.Errorf("unknown ExitCode Status() = %d, want 1", got)
+	}
+}
+
+// TestErrorPathsUseKnownCodes checks every exitWithErrorCode call in the
+// package passes one of the enumerated error codes. exitWithError always
+// reports its ExitCode's class, which TestExitCodeTable covers.
+func TestErrorPathsUseKnownCodes(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	calls := 0
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if fn, ok := call.Fun.(*ast.Ident); !ok || fn.Name != "exitWithErrorCode" || len(call.Args) < 2 {
+				return true
+			}
+			if path == "output.go" {
+				return true // exitWithError forwards its ExitCode's class
+			}
+			pos := fset.Position(call.Pos())
+			calls++
+			ident, ok := call.Args[1].(*ast.Ident)
+			if !ok {
+				t.Errorf("%s: error code is not a named ErrorCode constant", pos)
+				return true
+			}
+			if _, ok := errorCodeConsts[ident.Name]; !ok {
+				t.Errorf("%s: unknown error code %s", pos, ident.Name)
+			}
+			return true
+		})
+	}
+	if calls == 0 {
+		t.Error("found no exitWithErrorCode calls to check")
+	}
+}
+
+// TestJSONErrorsCarryErrorCode checks the error results that bypass
+// exitWithErrorCode still report a known error_code.
+func TestJSONErrorsCarryErrorCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		result any
+	}{
+		{"DeleteBlockedResult", DeleteBlockedResult{ErrorCode: ErrorCodeValidation}},
+		{"ConceptDeleteBlockedResult", ConceptDeleteBlockedResult{ErrorCode: ExitConceptValidation.ErrorCode()}},
+		{"ProjectDeleteBlockedResult", ProjectDeleteBlockedResult{ErrorCode: ExitProjectValidation.ErrorCode()}},
+		{"S2ErrorResult", S2ErrorResult{Code: "rate_limited", ErrorCode: ExitS2APIError.ErrorCode()}},
+		{"SlackErrorResult", SlackErrorResult{Error: "not_member", ErrorCode: ExitSlackNotMember.ErrorCode()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields map[string]any
+			if err := json.Unmarshal(data, &fields); err != nil {
+				t.Fatal(err)
+			}
+			code, _ := fields["error_code"].(string)
+			if !isKnownErrorCode(code) {
+				t.Errorf("error_code = %q, want a known code (JSON %s)", code, data)
+			}
+		})
+	}
+
+	// ASTA errors take error_code from the classified exit code
+	for _, err := range []error{asta.ErrNotFound, asta.ErrAuthError, asta.ErrRateLimited, errors.New("boom")} {
+		if code := classifyError(err).exitCode.ErrorCode(); !isKnownErrorCode(code) {
+			t.Errorf("classifyError(%v) error_code = %q, want a known code", err, code)
+		}
+	}
+}
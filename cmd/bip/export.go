@@ -2,50 +2,134 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/matsen/bipartite/internal/export"
+	"github.com/matsen/bipartite/internal/git"
 	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
 	"github.com/spf13/cobra"
 )
 
 var (
-	exportBibtex bool
-	exportKeys   string
-	exportAppend string
+	exportBibtex  bool
+	exportRIS     bool
+	exportBundle  bool
+	exportKeys    string
+	exportAppend  string
+	exportSchema  string
+	exportSkipBad bool
+	exportSince   string
+	exportVault   string
+	exportOut     string
 )
 
 func init() {
 	exportCmd.Flags().BoolVar(&exportBibtex, "bibtex", false, "Export to BibTeX format")
+	exportCmd.Flags().BoolVar(&exportRIS, "ris", false, "Export to RIS format (Zotero, EndNote)")
+	exportCmd.Flags().BoolVar(&exportBundle, "bundle", false, "Export the whole nexus as one JSON bundle")
 	exportCmd.Flags().StringVar(&exportKeys, "keys", "", "Export only specified IDs (comma-separated) [deprecated: use positional args]")
 	exportCmd.Flags().StringVar(&exportAppend, "append", "", "Append to existing .bib file (with deduplication)")
+	exportCmd.Flags().StringVar(&exportSchema, "validate-against", "", "Validate each reference's JSON record against this JSON Schema file")
+	exportCmd.Flags().BoolVar(&exportSkipBad, "skip-invalid", false, "With --validate-against, drop non-conforming references instead of failing")
+	exportCmd.Flags().StringVar(&exportSince, "since-commit", "", "Export only references added or modified since this git commit")
+	exportCmd.Flags().StringVar(&exportVault, "obsidian", "", "Write linked markdown notes for papers and concepts into this Obsidian vault directory")
+	exportCmd.Flags().StringVar(&exportOut, "out", "", "Write the JSON bundle, BibTeX, or RIS to this file instead of stdout")
 	rootCmd.AddCommand(exportCmd)
 }
 
 var exportCmd = &cobra.Command{
 	Use:   "export [<id>...] [flags]",
-	Short: "Export references to BibTeX format",
-	Long: `Export references to BibTeX format.
+	Short: "Export the nexus as a JSON bundle, or references to BibTeX, RIS, NDJSON, or Obsidian notes",
+	Long: `Export references to BibTeX or RIS format, or the whole nexus as one JSON file.
+
+One of --bibtex, --ris, --bundle, --since-commit, or --obsidian picks what
+is exported.
+
+--bundle writes a single JSON bundle of every ref, concept, project, repo,
+and edge, as read from the JSONL files, with a format version and an
+exported_at timestamp. This is a snapshot for backup or for handing to an
+agent. Use --out to write it to a file instead of stdout.
 
 Without IDs, exports all papers. With IDs, exports only specified papers.
-Use --append to add to an existing .bib file with automatic deduplication.
+Use --append to add to an existing .bib file with automatic deduplication,
+or --out to write a new one. --ris writes RIS records instead, for
+Zotero, EndNote, and other managers that import RIS. To export a subset
+selected by year, venue, or author, use 'bip list --format bibtex' (or
+--format ris) with its filters.
+
+Use --since-commit to export only references added or modified in
+refs.jsonl since a git commit (working tree vs. that commit), for
+incremental syncs. The records are written as NDJSON, one refs.jsonl record
+per line, or as BibTeX with --bibtex or RIS with --ris. Removed references are not exported.
+
+Use --obsidian <vault-dir> to write one markdown note per paper into
+<vault-dir>/papers/ and one per concept into <vault-dir>/concepts/. Paper
+notes carry the metadata as frontmatter, the abstract, and a [[wikilink]]
+to each linked concept; concept notes link back to their papers. Rerunning
+updates the generated part of each note and keeps everything below the
+"your notes below are kept" marker, so notes can be annotated in Obsidian.
+With IDs, only those papers and the concepts they link to are written.
+
+Use --validate-against to check each reference's JSON record (as stored in
+refs.jsonl) against a JSON Schema before exporting. Any violation fails the
+export with exit code 3; add --skip-invalid to export only the conforming
+references and report the rest on stderr.
 
 Examples:
+  bip export --bundle > nexus.json
+  bip export --bundle --out nexus.json
   bip export --bibtex
   bip export --bibtex Smith2024-ab Lee2024-cd
   bip export --bibtex --keys Ahn2026-rs,Gao2026-gi  # deprecated
   bip export --bibtex > refs.bib
-  bip export --bibtex --append refs.bib Smith2024-ab`,
+  bip export --bibtex --out refs.bib
+  bip export --bibtex --append refs.bib Smith2024-ab
+  bip export --ris --out library.ris
+  bip export --bibtex --validate-against schema.json --skip-invalid
+  bip export --since-commit HEAD~5 > changed.jsonl
+  bip export --since-commit v1.0 --bibtex
+  bip export --obsidian ~/vault/bipartite`,
 	RunE: runExport,
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
-	if !exportBibtex {
-		exitWithError(ExitError, "--bibtex flag is required")
+	if exportBundle {
+		if exportBibtex || exportRIS || exportSince != "" || exportVault != "" {
+			exitWithError(ExitError, "--bundle cannot be combined with --bibtex, --ris, --since-commit, or --obsidian")
+		}
+		if len(args) > 0 || exportKeys != "" || exportAppend != "" || exportSchema != "" {
+			exitWithError(ExitError, "--bundle exports the whole nexus; it takes no reference IDs, --append, or --validate-against")
+		}
+		return runExportBundle(mustFindRepository(), exportOut)
+	}
+	if !exportBibtex && !exportRIS && exportSince == "" && exportVault == "" {
+		exitWithError(ExitError, "one of --bibtex, --ris, --bundle, --since-commit, or --obsidian is required")
+	}
+	if exportBibtex && exportRIS {
+		exitWithError(ExitError, "--bibtex and --ris cannot be combined")
+	}
+	if exportAppend != "" && !exportBibtex {
+		exitWithError(ExitError, "--append requires --bibtex")
+	}
+	if exportOut != "" && ((!exportBibtex && !exportRIS) || exportSince != "" || exportAppend != "") {
+		exitWithError(ExitError, "--out only applies to --bundle or a plain --bibtex or --ris export")
+	}
+	if exportVault != "" && (exportBibtex || exportRIS || exportSince != "" || exportAppend != "") {
+		exitWithError(ExitError, "--obsidian cannot be combined with --bibtex, --ris, --since-commit, or --append")
+	}
+	if exportSkipBad && exportSchema == "" {
+		exitWithError(ExitError, "--skip-invalid requires --validate-against")
 	}
 
 	repoRoot := mustFindRepository()
+
+	if exportSince != "" {
+		return runExportSinceCommit(repoRoot, exportSince, len(args) > 0 || exportKeys != "")
+	}
+
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
 
@@ -82,24 +166,77 @@ func runExport(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		// Export all
-		refs, err = db.ListAll(0)
+		refs, err = db.ListAll(0, storage.SortDefault)
 		if err != nil {
 			exitWithError(ExitError, "listing references: %v", err)
 		}
 	}
 
+	if exportSchema != "" {
+		refs = validateExportRefs(refs, exportSchema, exportSkipBad)
+	}
+
+	if exportVault != "" {
+		return runExportObsidian(repoRoot, exportVault, db, refs, len(ids) == 0)
+	}
+
 	// Handle --append mode
 	if exportAppend != "" {
 		return runExportAppend(refs, exportAppend)
 	}
 
+	if exportOut != "" {
+		return runExportOut(refs, exportOut)
+	}
+
 	// Standard export to stdout (no JSON wrapper)
-	bibtex := export.ToBibTeXList(refs)
-	fmt.Print(bibtex)
+	fmt.Print(formatExportRefs(refs))
 
 	return nil
 }
 
+// formatExportRefs renders refs as RIS with --ris, else as BibTeX.
+func formatExportRefs(refs []reference.Reference) string {
+	if exportRIS {
+		return export.ToRISList(refs)
+	}
+	return export.ToBibTeXList(refs)
+}
+
+// runExportSinceCommit exports references added or modified since commitRef.
+func runExportSinceCommit(repoRoot, commitRef string, hasIDs bool) error {
+	if hasIDs {
+		exitWithError(ExitError, "--since-commit cannot be combined with reference IDs")
+	}
+
+	gitRoot := mustFindGitRepo(repoRoot)
+	mustCheckGitTracking(gitRoot)
+	mustValidateCommit(gitRoot, commitRef)
+
+	diff, err := git.DiffSince(gitRoot, commitRef)
+	if err != nil {
+		exitWithError(ExitError, "getting diff: %v", err)
+	}
+	refs := append(diff.Added, diff.Modified...)
+	git.SortRefsAlphabetically(refs)
+
+	if exportSchema != "" {
+		refs = validateExportRefs(refs, exportSchema, exportSkipBad)
+	}
+
+	if !exportBibtex && !exportRIS {
+		for _, ref := range refs {
+			outputJSONCompact(ref)
+		}
+		return nil
+	}
+	if exportAppend != "" {
+		return runExportAppend(refs, exportAppend)
+	}
+	fmt.Print(formatExportRefs(refs))
+	return nil
+}
+
 func runExportAppend(refs []reference.Reference, outputPath string) error {
 	// Make path absolute for output
 	absPath, err := filepath.Abs(outputPath)
@@ -151,3 +288,53 @@ func runExportAppend(refs []reference.Reference, outputPath string) error {
 
 	return nil
 }
+
+// runExportOut writes refs as BibTeX or RIS to outputPath, replacing any
+// existing file.
+func runExportOut(refs []reference.Reference, outputPath string) error {
+	absPath, err := filepath.Abs(outputPath)
+	if err != nil {
+		exitWithError(ExitError, "resolving path: %v", err)
+	}
+	if err := os.WriteFile(absPath, []byte(formatExportRefs(refs)), 0644); err != nil {
+		exitWithError(ExitError, "cannot write to file: %s\n  Hint: Check file has write permissions", outputPath)
+	}
+
+	result := ExportResult{Exported: len(refs), OutputPath: absPath}
+	if humanOutput {
+		fmt.Printf("Exported %d entries to %s\n", result.Exported, result.OutputPath)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// validateExportRefs checks refs against the JSON Schema at schemaPath.
+// Violations are reported on stderr; without skipInvalid any violation is
+// fatal, with it the non-conforming references are dropped.
+func validateExportRefs(refs []reference.Reference, schemaPath string, skipInvalid bool) []reference.Reference {
+	validator, err := export.LoadSchemaValidator(schemaPath)
+	if err != nil {
+		exitWithError(ExitConfigError, "%v", err)
+	}
+
+	valid, violations, err := validator.ValidateAll(refs)
+	if err != nil {
+		exitWithError(ExitError, "%v", err)
+	}
+	if len(violations) == 0 {
+		return refs
+	}
+
+	for _, v := range violations {
+		for _, msg := range v.Errors {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", v.ID, msg)
+		}
+	}
+	if !skipInvalid {
+		exitWithError(ExitDataError, "%d of %d references do not conform to %s\n  Hint: Use --skip-invalid to export only conforming references",
+			len(violations), len(refs), schemaPath)
+	}
+	fmt.Fprintf(os.Stderr, "warning: skipped %d of %d references that do not conform to %s\n", len(violations), len(refs), schemaPath)
+	return valid
+}
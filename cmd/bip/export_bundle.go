@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/export"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+// BundleExportResult is the response for export --out.
+type BundleExportResult struct {
+	Path     string `json:"path"`
+	Refs     int    `json:"refs"`
+	Concepts int    `json:"concepts"`
+	Projects int    `json:"projects"`
+	Repos    int    `json:"repos"`
+	Edges    int    `json:"edges"`
+}
+
+// runExportBundle writes every JSONL source in repoRoot as one JSON bundle,
+// to outPath if set or else to stdout.
+func runExportBundle(repoRoot, outPath string) error {
+	refs, err := storage.ReadAll(config.RefsPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+	concepts, err := storage.ReadAllConcepts(config.ConceptsPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading concepts: %v", err)
+	}
+	projects, err := storage.ReadAllProjects(config.ProjectsPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading projects: %v", err)
+	}
+	repos, err := storage.ReadAllRepos(config.ReposPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading repos: %v", err)
+	}
+	edges, err := storage.ReadAllEdges(config.EdgesPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading edges: %v", err)
+	}
+
+	bundle := export.BuildBundle(refs, concepts, projects, repos, edges, time.Now())
+
+	// Standard export to stdout is the bundle itself (no JSON wrapper)
+	if outPath == "" {
+		outputJSON(bundle)
+		return nil
+	}
+
+	absPath, err := filepath.Abs(outPath)
+	if err != nil {
+		exitWithError(ExitError, "resolving path: %v", err)
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		exitWithError(ExitError, "encoding bundle: %v", err)
+	}
+	if err := os.WriteFile(absPath, append(data, '\n'), 0644); err != nil {
+		exitWithError(ExitError, "writing %s: %v", absPath, err)
+	}
+
+	result := BundleExportResult{
+		Path:     absPath,
+		Refs:     len(bundle.Refs),
+		Concepts: len(bundle.Concepts),
+		Projects: len(bundle.Projects),
+		Repos:    len(bundle.Repos),
+		Edges:    len(bundle.Edges),
+	}
+	if humanOutput {
+		fmt.Printf("Exported %d refs, %d concepts, %d projects, %d repos, %d edges to %s\n",
+			result.Refs, result.Concepts, result.Projects, result.Repos, result.Edges, result.Path)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/export"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+// ObsidianExportResult is the response for export --obsidian.
+type ObsidianExportResult struct {
+	Vault    string `json:"vault"`
+	Papers   int    `json:"papers"`
+	Concepts int    `json:"concepts"`
+	Created  int    `json:"created"`
+	Updated  int    `json:"updated"`
+}
+
+// runExportObsidian writes a markdown note per paper in refs and per concept
+// into vaultDir. With allConcepts, every concept gets a note; otherwise only
+// concepts linked to one of refs.
+func runExportObsidian(repoRoot, vaultDir string, db *storage.DB, refs []reference.Reference, allConcepts bool) error {
+	absVault, err := filepath.Abs(vaultDir)
+	if err != nil {
+		exitWithError(ExitError, "resolving path: %v", err)
+	}
+
+	concepts, err := storage.ReadAllConcepts(config.ConceptsPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading concepts: %v", err)
+	}
+	edges, err := db.GetAllEdges()
+	if err != nil {
+		exitWithError(ExitDataError, "querying edges: %v", err)
+	}
+
+	paperLinks, conceptLinks := obsidianLinks(refs, concepts, edges)
+
+	result := ObsidianExportResult{Vault: absVault}
+	for _, dir := range []string{export.ObsidianPapersDir, export.ObsidianConceptsDir} {
+		if err := os.MkdirAll(filepath.Join(absVault, dir), 0755); err != nil {
+			exitWithError(ExitError, "creating vault directory: %v", err)
+		}
+	}
+
+	for _, ref := range refs {
+		note := export.ObsidianPaperNote(ref, paperLinks[ref.ID])
+		writeObsidianNote(filepath.Join(absVault, export.ObsidianPapersDir, export.ObsidianNoteName(ref.ID)+".md"), note, &result)
+		result.Papers++
+	}
+	for _, c := range concepts {
+		links := conceptLinks[c.ID]
+		if !allConcepts && len(links) == 0 {
+			continue
+		}
+		note := export.ObsidianConceptNote(c, links)
+		writeObsidianNote(filepath.Join(absVault, export.ObsidianConceptsDir, export.ObsidianNoteName(c.ID)+".md"), note, &result)
+		result.Concepts++
+	}
+
+	if humanOutput {
+		fmt.Printf("Wrote %d paper and %d concept notes to %s (%d created, %d updated)\n",
+			result.Papers, result.Concepts, result.Vault, result.Created, result.Updated)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// obsidianLinks collects the paper–concept edges among refs and concepts,
+// in either direction, as links for each paper's note (keyed by paper ID)
+// and each concept's note (keyed by bare concept ID). Links are sorted by
+// target note.
+func obsidianLinks(refs []reference.Reference, concepts []concept.Concept, edges []edge.Edge) (map[string][]export.ObsidianLink, map[string][]export.ObsidianLink) {
+	refsByID := make(map[string]reference.Reference, len(refs))
+	for _, r := range refs {
+		refsByID[r.ID] = r
+	}
+	conceptsByID := make(map[string]concept.Concept, len(concepts))
+	for _, c := range concepts {
+		conceptsByID[c.ID] = c
+	}
+
+	paperLinks := make(map[string][]export.ObsidianLink)
+	conceptLinks := make(map[string][]export.ObsidianLink)
+	for _, e := range edges {
+		paperID, conceptID := e.SourceID, e.TargetID
+		if strings.HasPrefix(paperID, "concept:") {
+			paperID, conceptID = conceptID, paperID
+		}
+		conceptID, isConcept := strings.CutPrefix(conceptID, "concept:")
+		if !isConcept {
+			continue
+		}
+		ref, okRef := refsByID[paperID]
+		c, okConcept := conceptsByID[conceptID]
+		if !okRef || !okConcept {
+			continue
+		}
+
+		label := c.Name
+		if label == "" {
+			label = c.ID
+		}
+		paperLinks[ref.ID] = append(paperLinks[ref.ID], export.ObsidianLink{
+			Note:             export.ObsidianConceptsDir + "/" + export.ObsidianNoteName(c.ID),
+			Label:            label,
+			RelationshipType: e.RelationshipType,
+			Summary:          e.Summary,
+		})
+		conceptLinks[c.ID] = append(conceptLinks[c.ID], export.ObsidianLink{
+			Note:             export.ObsidianPapersDir + "/" + export.ObsidianNoteName(ref.ID),
+			Label:            ref.Title,
+			RelationshipType: e.RelationshipType,
+			Summary:          e.Summary,
+		})
+	}
+
+	for _, m := range []map[string][]export.ObsidianLink{paperLinks, conceptLinks} {
+		for _, links := range m {
+			sort.SliceStable(links, func(i, j int) bool { return links[i].Note < links[j].Note })
+		}
+	}
+	return paperLinks, conceptLinks
+}
+
+// writeObsidianNote writes note to path, keeping the user section of any
+// existing note, and counts it as created or updated.
+func writeObsidianNote(path, note string, result *ObsidianExportResult) {
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		result.Updated++
+	case os.IsNotExist(err):
+		result.Created++
+	default:
+		exitWithError(ExitError, "reading %s: %v", path, err)
+	}
+
+	if err := os.WriteFile(path, []byte(export.MergeObsidianNote(string(existing), note)), 0644); err != nil {
+		exitWithError(ExitError, "writing %s: %v", path, err)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+func TestObsidianLinks(t *testing.T) {
+	refs := []reference.Reference{{ID: "PaperA", Title: "Paper A"}, {ID: "PaperB", Title: "Paper B"}}
+	concepts := []concept.Concept{{ID: "vae", Name: "VAE"}, {ID: "ml"}}
+	edges := []edge.Edge{
+		{SourceID: "PaperA", TargetID: "concept:vae", RelationshipType: "introduces"},
+		{SourceID: "concept:ml", TargetID: "PaperA", RelationshipType: "applies"},
+		{SourceID: "PaperA", TargetID: "PaperB", RelationshipType: "cites"},
+		{SourceID: "PaperC", TargetID: "concept:vae", RelationshipType: "applies"}, // not exported
+		{SourceID: "concept:vae", TargetID: "concept:ml", RelationshipType: "subconcept-of"},
+	}
+
+	paperLinks, conceptLinks := obsidianLinks(refs, concepts, edges)
+
+	a := paperLinks["PaperA"]
+	if len(a) != 2 || a[0].Note != "concepts/ml" || a[0].Label != "ml" || a[1].Note != "concepts/vae" || a[1].Label != "VAE" {
+		t.Errorf("PaperA links = %+v", a)
+	}
+	if len(paperLinks["PaperB"]) != 0 {
+		t.Errorf("PaperB links = %+v, want none", paperLinks["PaperB"])
+	}
+	vae := conceptLinks["vae"]
+	if len(vae) != 1 || vae[0].Note != "papers/PaperA" || vae[0].Label != "Paper A" || vae[0].RelationshipType != "introduces" {
+		t.Errorf("vae links = %+v", vae)
+	}
+}
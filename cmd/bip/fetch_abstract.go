@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/arxiv"
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/crossref"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/semantic"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fetchAbstractAll   bool
+	fetchAbstractForce bool
+	fetchAbstractEmail string
+)
+
+func init() {
+	fetchAbstractCmd.Flags().BoolVar(&fetchAbstractAll, "all", false, "Backfill every reference missing an abstract")
+	fetchAbstractCmd.Flags().BoolVar(&fetchAbstractForce, "force", false, "Replace existing abstracts")
+	fetchAbstractCmd.Flags().StringVar(&fetchAbstractEmail, "email", "", "Contact email sent to Crossref and NCBI (recommended)")
+	rootCmd.AddCommand(fetchAbstractCmd)
+}
+
+var fetchAbstractCmd = &cobra.Command{
+	Use:   "fetch-abstract <id> | --all",
+	Short: "Fetch missing abstracts from Crossref, arXiv, or PubMed",
+	Long: `Fetch a paper's abstract by its external IDs and write it into the reference.
+
+Sources are tried in order: Crossref by DOI, arXiv by arXiv ID, then PubMed
+by PMID; the first one that returns an abstract wins. A paper counts as
+missing its abstract when it has none or one shorter than the semantic
+index minimum, as reported by 'bip check'. Longer abstracts are only
+replaced with --force.
+
+With --all, every reference missing an abstract that has a DOI, arXiv ID,
+or PMID is backfilled (with --force, every reference with such an ID). A
+paper that fails is reported in the results instead of stopping the run.
+
+A new abstract leaves an already embedded paper stale in the semantic
+index; run 'bip index build' afterwards to re-embed.
+
+Examples:
+  bip fetch-abstract Smith2024-ab
+  bip fetch-abstract --all --human
+  bip fetch-abstract Smith2024-ab --force --email you@example.com`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFetchAbstract,
+}
+
+// FetchAbstractResult is the response for the fetch-abstract command.
+type FetchAbstractResult struct {
+	Updated      int                   `json:"updated"`
+	Skipped      int                   `json:"skipped"`
+	Failed       int                   `json:"failed"`
+	NoExternalID int                   `json:"no_external_id,omitempty"` // --all: refs missing an abstract with no ID to look up
+	Details      []FetchAbstractAction `json:"details"`
+}
+
+// FetchAbstractAction describes what happened to one paper.
+type FetchAbstractAction struct {
+	ID          string `json:"id"`
+	Action      string `json:"action"`           // "updated", "skipped", or "failed"
+	Source      string `json:"source,omitempty"` // "crossref", "arxiv", or "pubmed"
+	Length      int    `json:"length,omitempty"` // Characters in the new abstract
+	Reason      string `json:"reason,omitempty"`
+	IndexStatus string `json:"index_status,omitempty"` // Semantic index status after the update
+}
+
+// abstractSource fetches abstracts by one kind of external ID. id returns
+// the reference's ID for this source, or "" if it has none.
+type abstractSource struct {
+	name  string
+	id    func(reference.Reference) string
+	fetch func(ctx context.Context, id string) (string, error)
+}
+
+// errNoAbstractSource is returned for a paper with no ID any source accepts.
+var errNoAbstractSource = errors.New("no DOI, arXiv ID, or PMID to look up")
+
+// newAbstractSources returns the Crossref, arXiv, and PubMed sources, in the
+// order they are tried, using the same clients as 'bip add'.
+func newAbstractSources(email string) []abstractSource {
+	var crossrefOpts []crossref.ClientOption
+	if email != "" {
+		crossrefOpts = append(crossrefOpts, crossref.WithMailto(email))
+	}
+	crossrefClient := crossref.NewClient(crossrefOpts...)
+	arxivClient := arxiv.NewClient()
+	ncbiClient := newNCBIClient(email)
+
+	return []abstractSource{
+		{
+			name: "crossref",
+			id:   func(r reference.Reference) string { return r.DOI },
+			fetch: func(ctx context.Context, doi string) (string, error) {
+				ref, err := crossrefClient.FetchByDOI(ctx, doi)
+				if err != nil {
+					return "", err
+				}
+				return ref.Abstract, nil
+			},
+		},
+		{
+			name: "arxiv",
+			id:   func(r reference.Reference) string { return r.ArXivID },
+			fetch: func(ctx context.Context, id string) (string, error) {
+				ref, err := arxivClient.FetchByID(ctx, id)
+				if err != nil {
+					return "", err
+				}
+				return ref.Abstract, nil
+			},
+		},
+		{
+			name:  "pubmed",
+			id:    func(r reference.Reference) string { return r.PMID },
+			fetch: ncbiClient.FetchAbstract,
+		},
+	}
+}
+
+// hasAbstractSource reports whether any source can look up ref.
+func hasAbstractSource(ref reference.Reference, sources []abstractSource) bool {
+	for _, s := range sources {
+		if s.id(ref) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// missingAbstract reports whether ref's abstract is too short to index.
+func missingAbstract(ref reference.Reference) bool {
+	return semantic.AbstractTooShort(ref.Abstract)
+}
+
+// fetchAbstract tries each source ref has an ID for and returns the first
+// non-empty abstract with the source's name. If none returns one, the error
+// lists what each source said.
+func fetchAbstract(ctx context.Context, ref reference.Reference, sources []abstractSource) (string, string, error) {
+	var failures []string
+	for _, s := range sources {
+		id := s.id(ref)
+		if id == "" {
+			continue
+		}
+		abstract, err := s.fetch(ctx, id)
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("%s: %v", s.name, err))
+		case abstract == "":
+			failures = append(failures, s.name+": no abstract")
+		default:
+			return abstract, s.name, nil
+		}
+	}
+	if len(failures) == 0 {
+		return "", "", errNoAbstractSource
+	}
+	return "", "", errors.New(strings.Join(failures, "; "))
+}
+
+// backfillAbstracts fetches abstracts for refs[i], i in targets, updating
+// refs in place. Papers with an abstract are skipped unless force.
+func backfillAbstracts(ctx context.Context, refs []reference.Reference, targets []int, sources []abstractSource, force bool) FetchAbstractResult {
+	result := FetchAbstractResult{Details: []FetchAbstractAction{}}
+	for _, i := range targets {
+		ref := &refs[i]
+		action := FetchAbstractAction{ID: ref.ID}
+
+		if !force && !missingAbstract(*ref) {
+			action.Action = "skipped"
+			action.Reason = "already has an abstract (use --force to replace it)"
+			result.Skipped++
+		} else if abstract, source, err := fetchAbstract(ctx, *ref, sources); err != nil {
+			action.Action = "failed"
+			action.Reason = err.Error()
+			result.Failed++
+		} else {
+			ref.Abstract = abstract
+			action.Action = "updated"
+			action.Source = source
+			action.Length = len(abstract)
+			result.Updated++
+		}
+
+		result.Details = append(result.Details, action)
+	}
+	return result
+}
+
+func runFetchAbstract(cmd *cobra.Command, args []string) error {
+	if fetchAbstractAll && len(args) > 0 {
+		exitWithError(ExitError, "cannot combine --all with a paper ID")
+	}
+	if !fetchAbstractAll && len(args) == 0 {
+		exitWithError(ExitError, "paper ID required (or use --all)")
+	}
+
+	repoRoot := mustFindRepository()
+	refsPath := config.RefsPath(repoRoot)
+	refs, err := storage.ReadAll(refsPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+
+	sources := newAbstractSources(fetchAbstractEmail)
+	var targets []int
+	noExternalID := 0
+	if fetchAbstractAll {
+		for i, ref := range refs {
+			if !fetchAbstractForce && !missingAbstract(ref) {
+				continue
+			}
+			if !hasAbstractSource(ref, sources) {
+				if missingAbstract(ref) {
+					noExternalID++
+				}
+				continue
+			}
+			targets = append(targets, i)
+		}
+	} else {
+		idx, found := storage.FindByID(refs, args[0])
+		if !found {
+			exitWithError(ExitDataError, "paper %s not found", args[0])
+		}
+		targets = []int{idx}
+	}
+
+	result := backfillAbstracts(context.Background(), refs, targets, sources, fetchAbstractForce)
+	result.NoExternalID = noExternalID
+
+	// A single paper that could not be updated is an error
+	if !fetchAbstractAll && result.Updated == 0 {
+		detail := result.Details[0]
+		if detail.Action == "skipped" {
+			exitWithError(ExitDataError, "paper %s %s", detail.ID, detail.Reason)
+		}
+		exitWithError(ExitError, "fetching abstract for %s: %s", detail.ID, detail.Reason)
+	}
+
+	if result.Updated > 0 {
+		db := mustOpenDatabase(repoRoot)
+		defer db.Close()
+		indexCurrent := db.IndexIsCurrent(storage.IndexRefs, refsPath)
+
+		if err := storage.WriteAll(refsPath, refs); err != nil {
+			exitWithError(ExitDataError, "writing refs: %v", err)
+		}
+		if err := db.ApplyIndexUpdate(storage.IndexRefs, refsPath, indexCurrent, func() error {
+			for _, detail := range result.Details {
+				if detail.Action != "updated" {
+					continue
+				}
+				idx, _ := storage.FindByID(refs, detail.ID)
+				if err := db.UpsertRef(refs[idx]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			exitWithError(ExitDataError, "updating index: %v", err)
+		}
+
+		// Embeddings record a hash of the abstract they were built from, so
+		// a changed abstract shows up as stale until the next index build
+		for i, detail := range result.Details {
+			if detail.Action != "updated" {
+				continue
+			}
+			meta, err := db.GetEmbeddingMetadata(detail.ID)
+			if err != nil {
+				exitWithError(ExitDataError, "reading embedding metadata: %v", err)
+			}
+			idx, _ := storage.FindByID(refs, detail.ID)
+			result.Details[i].IndexStatus = semantic.PaperIndexStatus(refs[idx].Abstract, meta != nil, meta)
+		}
+	}
+
+	if humanOutput {
+		printFetchAbstractResult(result)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// printFetchAbstractResult prints fetch-abstract results, one line per paper.
+func printFetchAbstractResult(result FetchAbstractResult) {
+	for _, d := range result.Details {
+		switch d.Action {
+		case "updated":
+			fmt.Printf("  updated  %s (%s, %d chars)\n", d.ID, d.Source, d.Length)
+		default:
+			fmt.Printf("  %-8s %s: %s\n", d.Action, d.ID, d.Reason)
+		}
+	}
+	if len(result.Details) > 0 {
+		fmt.Println()
+	}
+	fmt.Printf("Updated %d, skipped %d, failed %d\n", result.Updated, result.Skipped, result.Failed)
+	if result.NoExternalID > 0 {
+		fmt.Printf("%d references missing an abstract have no DOI, arXiv ID, or PMID\n", result.NoExternalID)
+	}
+	if result.Updated > 0 {
+		fmt.Println("Run 'bip index build' to embed the new abstracts")
+	}
+}
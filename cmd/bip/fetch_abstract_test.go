@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+// fakeAbstractSources returns crossref and pubmed sources that serve
+// abstracts from maps keyed by ID, erroring for unknown IDs, and count calls.
+func fakeAbstractSources(byDOI, byPMID map[string]string, calls *int) []abstractSource {
+	lookup := func(m map[string]string) func(context.Context, string) (string, error) {
+		return func(_ context.Context, id string) (string, error) {
+			*calls++
+			abstract, ok := m[id]
+			if !ok {
+				return "", errors.New("not found")
+			}
+			return abstract, nil
+		}
+	}
+	return []abstractSource{
+		{name: "crossref", id: func(r reference.Reference) string { return r.DOI }, fetch: lookup(byDOI)},
+		{name: "pubmed", id: func(r reference.Reference) string { return r.PMID }, fetch: lookup(byPMID)},
+	}
+}
+
+func TestBackfillAbstracts(t *testing.T) {
+	long := strings.Repeat("An abstract. ", 10)
+	refs := []reference.Reference{
+		{ID: "FromCrossref", DOI: "10.1/a"},
+		{ID: "FallsBack", DOI: "10.1/empty", PMID: "42"},
+		{ID: "HasAbstract", DOI: "10.1/a", Abstract: long},
+		{ID: "Short", PMID: "42", Abstract: "Too short."},
+		{ID: "Unknown", DOI: "10.1/missing"},
+		{ID: "NoIDs"},
+	}
+	var calls int
+	sources := fakeAbstractSources(
+		map[string]string{"10.1/a": "crossref abstract", "10.1/empty": ""},
+		map[string]string{"42": "pubmed abstract"},
+		&calls,
+	)
+
+	result := backfillAbstracts(context.Background(), refs, []int{0, 1, 2, 3, 4, 5}, sources, false)
+	if result.Updated != 3 || result.Skipped != 1 || result.Failed != 2 {
+		t.Fatalf("result = %+v, want 3 updated, 1 skipped, 2 failed", result)
+	}
+
+	want := []struct{ action, source, abstract string }{
+		{"updated", "crossref", "crossref abstract"},
+		{"updated", "pubmed", "pubmed abstract"},
+		{"skipped", "", long},
+		{"updated", "pubmed", "pubmed abstract"},
+		{"failed", "", ""},
+		{"failed", "", ""},
+	}
+	for i, w := range want {
+		d := result.Details[i]
+		if d.Action != w.action || d.Source != w.source || refs[i].Abstract != w.abstract {
+			t.Errorf("%s: action=%q source=%q abstract=%q, want %q %q %q", refs[i].ID, d.Action, d.Source, refs[i].Abstract, w.action, w.source, w.abstract)
+		}
+	}
+	if r := result.Details[1]; r.Length != len("pubmed abstract") {
+		t.Errorf("Length = %d, want %d", r.Length, len("pubmed abstract"))
+	}
+	if r := result.Details[4].Reason; !strings.Contains(r, "crossref: not found") {
+		t.Errorf("failure reason = %q, want the crossref error", r)
+	}
+	if r := result.Details[5].Reason; r != errNoAbstractSource.Error() {
+		t.Errorf("no-ID reason = %q, want %q", r, errNoAbstractSource)
+	}
+	// The skipped paper and the paper without IDs make no requests
+	if calls != 5 {
+		t.Errorf("fetch calls = %d, want 5", calls)
+	}
+
+	// --force replaces an existing abstract
+	result = backfillAbstracts(context.Background(), refs, []int{2}, sources, true)
+	if result.Updated != 1 || refs[2].Abstract != "crossref abstract" {
+		t.Errorf("force: result = %+v, abstract = %q", result, refs[2].Abstract)
+	}
+}
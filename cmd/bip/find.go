@@ -0,0 +1,76 @@
+package main
+
+import (
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+// findFlags maps each find flag to the external ID kind it looks up.
+var findFlags = []struct {
+	flag, kind, usage string
+}{
+	{"doi", storage.ExternalIDDOI, "DOI (e.g. 10.1234/abc)"},
+	{"pmid", storage.ExternalIDPMID, "PubMed ID"},
+	{"pmcid", storage.ExternalIDPMCID, "PubMed Central ID (e.g. PMC1234567)"},
+	{"arxiv", storage.ExternalIDArXiv, "arXiv ID (e.g. 2401.12345)"},
+	{"s2", storage.ExternalIDS2, "Semantic Scholar paper ID"},
+}
+
+func init() {
+	for _, f := range findFlags {
+		findCmd.Flags().String(f.flag, "", f.usage)
+	}
+	rootCmd.AddCommand(findCmd)
+}
+
+var findCmd = &cobra.Command{
+	Use:   "find",
+	Short: "Find a reference by external ID",
+	Long: `Find the reference with an exact match for an external identifier,
+e.g. to check whether a paper is already in the library before importing it.
+
+Give exactly one of --doi, --pmid, --pmcid, --arxiv, or --s2. Exits with an
+error if no reference matches.
+
+Examples:
+  bip find --pmid 12345678
+  bip find --arxiv 2401.12345 --human`,
+	Args: cobra.NoArgs,
+	RunE: runFind,
+}
+
+func runFind(cmd *cobra.Command, args []string) error {
+	var flag, kind, value string
+	for _, f := range findFlags {
+		if !cmd.Flags().Changed(f.flag) {
+			continue
+		}
+		if flag != "" {
+			exitWithError(ExitError, "give only one of --%s and --%s", flag, f.flag)
+		}
+		flag, kind = f.flag, f.kind
+		value, _ = cmd.Flags().GetString(f.flag)
+	}
+	if flag == "" {
+		exitWithError(ExitError, "must specify one of --doi, --pmid, --pmcid, --arxiv, or --s2")
+	}
+
+	repoRoot := mustFindRepository()
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	ref, err := db.GetByExternalID(kind, value)
+	if err != nil {
+		exitWithError(ExitError, "finding reference: %v", err)
+	}
+	if ref == nil {
+		exitWithError(ExitError, "reference not found: %s %s", flag, value)
+	}
+
+	if humanOutput {
+		printRefSummary(1, *ref)
+	} else {
+		outputJSON(ref)
+	}
+	return nil
+}
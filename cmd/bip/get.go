@@ -4,11 +4,35 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/matsen/bipartite/internal/export"
 	"github.com/matsen/bipartite/internal/reference"
 	"github.com/spf13/cobra"
 )
 
+// Output formats for reference records (bip get, bip list).
+const (
+	refFormatJSON   = "json"
+	refFormatRIS    = "ris"
+	refFormatBibTeX = "bibtex"
+)
+
+// validateRefFormat checks a --format value for reference output.
+func validateRefFormat(format string) error {
+	if format != refFormatJSON && format != refFormatRIS && format != refFormatBibTeX {
+		return fmt.Errorf("invalid --format %q: must be json, ris, or bibtex", format)
+	}
+	return nil
+}
+
+// RefDetail is the JSON output of bip get: the reference plus the ID of the
+// paper that supersedes it, if any.
+type RefDetail struct {
+	reference.Reference
+	SupersededBy string `json:"superseded_by,omitempty"`
+}
+
 func init() {
+	getCmd.Flags().String("format", refFormatJSON, "Output format: json, ris, or bibtex")
 	rootCmd.AddCommand(getCmd)
 }
 
@@ -17,13 +41,20 @@ var getCmd = &cobra.Command{
 	Short: "Get a single reference by ID",
 	Long: `Get a single reference by its ID.
 
-Example:
-  bip get Ahn2026-rs`,
+Examples:
+  bip get Ahn2026-rs
+  bip get Ahn2026-rs --format ris
+  bip get Ahn2026-rs --format bibtex`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGet,
 }
 
 func runGet(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if err := validateRefFormat(format); err != nil {
+		exitWithError(ExitError, "%v", err)
+	}
+
 	repoRoot := mustFindRepository()
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
@@ -38,16 +69,32 @@ func runGet(cmd *cobra.Command, args []string) error {
 		exitWithError(ExitError, "reference not found: %s", id)
 	}
 
+	if format == refFormatRIS {
+		fmt.Print(export.ToRIS(*ref))
+		return nil
+	}
+	if format == refFormatBibTeX {
+		fmt.Print(export.ToBibTeX(*ref))
+		return nil
+	}
+
+	supersededBy, err := db.GetSupersededBy(*ref)
+	if err != nil {
+		exitWithError(ExitDataError, "%v", err)
+	}
+	detail := RefDetail{Reference: *ref, SupersededBy: supersededBy}
+
 	if humanOutput {
-		printRefDetail(*ref)
+		printRefDetail(detail)
 	} else {
-		outputJSON(ref)
+		outputJSON(detail)
 	}
 
 	return nil
 }
 
-func printRefDetail(ref reference.Reference) {
+func printRefDetail(detail RefDetail) {
+	ref := detail.Reference
 	fmt.Println(ref.ID)
 	fmt.Println(strings.Repeat("═", DetailTitleMaxLen))
 	fmt.Println()
@@ -84,6 +131,14 @@ func printRefDetail(ref reference.Reference) {
 		fmt.Printf("Tags:     %s\n", strings.Join(ref.Tags, ", "))
 	}
 
+	// Versions
+	if ref.Supersedes != "" {
+		fmt.Printf("Supersedes:    %s\n", ref.Supersedes)
+	}
+	if detail.SupersededBy != "" {
+		fmt.Printf("Superseded by: %s\n", detail.SupersededBy)
+	}
+
 	// Notes
 	if ref.Note != "" {
 		fmt.Println()
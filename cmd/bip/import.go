@@ -12,30 +12,43 @@ import (
 )
 
 var (
-	importFormat string
-	importDryRun bool
-	importStrict bool
+	importFormat  string
+	importDryRun  bool
+	importStrict  bool
+	importMerge   bool
+	importReplace bool
 )
 
 func init() {
-	importCmd.Flags().StringVar(&importFormat, "format", "", "Import format (paperpile)")
+	importCmd.Flags().StringVar(&importFormat, "format", "", "Import format (paperpile); omit to restore a 'bip export' bundle")
 	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without writing")
 	importCmd.Flags().BoolVar(&importStrict, "strict", false, "Drop entries with missing required fields (title, author, year) instead of filling sentinels")
-	importCmd.MarkFlagRequired("format")
+	importCmd.Flags().BoolVar(&importMerge, "merge", false, "Bundle import: add records to the existing data, skipping IDs already present")
+	importCmd.Flags().BoolVar(&importReplace, "replace", false, "Bundle import: overwrite the existing data with the bundle")
 	rootCmd.AddCommand(importCmd)
 }
 
 var importCmd = &cobra.Command{
 	Use:   "import <file>",
-	Short: "Import references from an external format",
-	Long: `Import references from an external format.
+	Short: "Import references from an external format, or restore a nexus bundle",
+	Long: `Import references from an external format, or restore a nexus bundle.
 
 Usage:
   bip import --format paperpile export.json
   bip import --format paperpile export.json --dry-run
+  bip import nexus.json --replace
+  bip import nexus.json --merge
 
 Supported formats:
-  paperpile  - Paperpile JSON export`,
+  paperpile  - Paperpile JSON export
+
+Without --format, the file is a bundle written by 'bip export', and its
+refs, concepts, projects, repos, and edges are written to the JSONL files
+and all indexes rebuilt. --replace overwrites the existing data; --merge
+adds the bundle's records, skipping refs, concepts, projects, and repos
+whose ID is already present and edges that already exist. One of the two
+is required unless the nexus is empty. Each record is validated; invalid
+records are reported and skipped without aborting the import.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runImport,
 }
@@ -77,6 +90,16 @@ type importStats struct {
 func runImport(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
 
+	if importFormat == "" {
+		if importDryRun || importStrict {
+			exitWithError(ExitError, "--dry-run and --strict require --format")
+		}
+		return runImportBundle(repoRoot, args[0], importMerge, importReplace)
+	}
+	if importMerge || importReplace {
+		exitWithError(ExitError, "--merge and --replace only apply to bundle imports (without --format)")
+	}
+
 	// Validate format
 	if importFormat != "paperpile" {
 		exitWithError(ExitError, "unknown format: %s", importFormat)
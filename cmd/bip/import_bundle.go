@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/export"
+	"github.com/matsen/bipartite/internal/project"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/repo"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+// BundleImportResult is the response for a bundle import.
+type BundleImportResult struct {
+	Status   string             `json:"status"`
+	Mode     string             `json:"mode"` // "replace" or "merge"
+	Refs     BundleImportCounts `json:"refs"`
+	Concepts BundleImportCounts `json:"concepts"`
+	Projects BundleImportCounts `json:"projects"`
+	Repos    BundleImportCounts `json:"repos"`
+	Edges    BundleImportCounts `json:"edges"`
+	Errors   []string           `json:"errors"`
+}
+
+// BundleImportCounts tallies the records of one type in a bundle import.
+// Skipped records duplicate one already present; failed ones are invalid.
+type BundleImportCounts struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+	Failed   int `json:"failed"`
+}
+
+// runImportBundle restores the bundle at path into repoRoot.
+func runImportBundle(repoRoot, path string, merge, replace bool) error {
+	if merge && replace {
+		exitWithError(ExitError, "--merge and --replace cannot be combined")
+	}
+
+	bundle, err := export.ReadBundle(path)
+	if err != nil {
+		exitWithError(ExitDataError, "%v", err)
+	}
+
+	existing := readNexusBundle(repoRoot)
+	if !merge && !replace && !bundleEmpty(existing) {
+		exitWithError(ExitError, "nexus is not empty: choose --merge to add to it or --replace to overwrite it")
+	}
+
+	base := &export.Bundle{}
+	if merge {
+		base = existing
+	}
+	result, merged := planBundleImport(base, bundle)
+	result.Status = "completed"
+	result.Mode = "replace"
+	if merge {
+		result.Mode = "merge"
+	}
+
+	if err := storage.WriteAll(config.RefsPath(repoRoot), merged.Refs); err != nil {
+		exitWithError(ExitDataError, "writing refs: %v", err)
+	}
+	if err := storage.WriteAllConcepts(config.ConceptsPath(repoRoot), merged.Concepts); err != nil {
+		exitWithError(ExitDataError, "writing concepts: %v", err)
+	}
+	if err := storage.WriteAllProjects(config.ProjectsPath(repoRoot), merged.Projects); err != nil {
+		exitWithError(ExitDataError, "writing projects: %v", err)
+	}
+	if err := storage.WriteAllRepos(config.ReposPath(repoRoot), merged.Repos); err != nil {
+		exitWithError(ExitDataError, "writing repos: %v", err)
+	}
+	if err := storage.WriteAllEdges(config.EdgesPath(repoRoot), merged.Edges); err != nil {
+		exitWithError(ExitDataError, "writing edges: %v", err)
+	}
+
+	if err := os.MkdirAll(config.CachePath(repoRoot), 0755); err != nil {
+		exitWithError(ExitError, "creating cache directory: %v", err)
+	}
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	if _, err := db.RebuildFromJSONL(config.RefsPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "rebuilding refs database: %v", err)
+	}
+	if _, err := db.RebuildEdgesFromJSONL(config.EdgesPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "rebuilding edges database: %v", err)
+	}
+	if _, err := db.RebuildConceptsFromJSONL(config.ConceptsPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "rebuilding concepts database: %v", err)
+	}
+	if _, err := db.RebuildProjectsFromJSONL(config.ProjectsPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "rebuilding projects database: %v", err)
+	}
+	if _, err := db.RebuildReposFromJSONL(config.ReposPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "rebuilding repos database: %v", err)
+	}
+
+	if humanOutput {
+		fmt.Printf("Imported bundle (%s):\n", result.Mode)
+		printBundleImportCounts("Refs", result.Refs)
+		printBundleImportCounts("Concepts", result.Concepts)
+		printBundleImportCounts("Projects", result.Projects)
+		printBundleImportCounts("Repos", result.Repos)
+		printBundleImportCounts("Edges", result.Edges)
+		if len(result.Errors) > 0 {
+			fmt.Println("\nErrors:")
+			for _, e := range result.Errors {
+				fmt.Printf("  - %s\n", e)
+			}
+		}
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// readNexusBundle reads the current JSONL data of repoRoot as a bundle.
+func readNexusBundle(repoRoot string) *export.Bundle {
+	var b export.Bundle
+	var err error
+	if b.Refs, err = storage.ReadAll(config.RefsPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+	if b.Concepts, err = storage.ReadAllConcepts(config.ConceptsPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "reading concepts: %v", err)
+	}
+	if b.Projects, err = storage.ReadAllProjects(config.ProjectsPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "reading projects: %v", err)
+	}
+	if b.Repos, err = storage.ReadAllRepos(config.ReposPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "reading repos: %v", err)
+	}
+	if b.Edges, err = storage.ReadAllEdges(config.EdgesPath(repoRoot)); err != nil {
+		exitWithError(ExitDataError, "reading edges: %v", err)
+	}
+	return &b
+}
+
+// bundleEmpty reports whether b holds no records of any type.
+func bundleEmpty(b *export.Bundle) bool {
+	return len(b.Refs) == 0 && len(b.Concepts) == 0 && len(b.Projects) == 0 &&
+		len(b.Repos) == 0 && len(b.Edges) == 0
+}
+
+// planBundleImport adds the records of incoming to those of base, skipping
+// invalid records and any whose ID (edge key, for edges) is already present.
+// Neither bundle is modified.
+func planBundleImport(base, incoming *export.Bundle) (BundleImportResult, *export.Bundle) {
+	result := BundleImportResult{Errors: []string{}}
+	merged := &export.Bundle{}
+
+	merged.Refs = importRecords(base.Refs, incoming.Refs, "ref", &result.Refs, &result.Errors,
+		func(r reference.Reference) string { return r.ID },
+		func(r reference.Reference) string { return r.ID },
+		func(r reference.Reference) error { return r.Validate() })
+	merged.Concepts = importRecords(base.Concepts, incoming.Concepts, "concept", &result.Concepts, &result.Errors,
+		func(c concept.Concept) string { return c.ID },
+		func(c concept.Concept) string { return c.ID },
+		func(c concept.Concept) error { return c.ValidateForCreate() })
+	merged.Projects = importRecords(base.Projects, incoming.Projects, "project", &result.Projects, &result.Errors,
+		func(p project.Project) string { return p.ID },
+		func(p project.Project) string { return p.ID },
+		func(p project.Project) error { return p.ValidateForCreate() })
+	merged.Repos = importRecords(base.Repos, incoming.Repos, "repo", &result.Repos, &result.Errors,
+		func(r repo.Repo) string { return r.ID },
+		func(r repo.Repo) string { return r.ID },
+		func(r repo.Repo) error { return r.ValidateForCreate() })
+	merged.Edges = importRecords(base.Edges, incoming.Edges, "edge", &result.Edges, &result.Errors,
+		func(e edge.Edge) edge.EdgeKey { return e.Key() },
+		func(e edge.Edge) string {
+			return fmt.Sprintf("%s --[%s]--> %s", e.SourceID, e.RelationshipType, e.TargetID)
+		},
+		func(e edge.Edge) error { return e.ValidateForCreate() })
+
+	return result, merged
+}
+
+// importRecords appends each valid record of incoming whose key is not yet
+// present to a copy of existing, tallying the outcome in counts and
+// recording each validation failure in errs, labeled with kind and label.
+func importRecords[T any, K comparable](existing, incoming []T, kind string, counts *BundleImportCounts, errs *[]string,
+	key func(T) K, label func(T) string, validate func(T) error) []T {
+
+	result := append([]T{}, existing...)
+	seen := make(map[K]bool, len(existing)+len(incoming))
+	for _, r := range existing {
+		seen[key(r)] = true
+	}
+	for _, r := range incoming {
+		if err := validate(r); err != nil {
+			counts.Failed++
+			*errs = append(*errs, fmt.Sprintf("%s %s: %v", kind, label(r), err))
+			continue
+		}
+		if seen[key(r)] {
+			counts.Skipped++
+			continue
+		}
+		seen[key(r)] = true
+		result = append(result, r)
+		counts.Imported++
+	}
+	return result
+}
+
+// printBundleImportCounts prints one line of the human bundle import summary.
+func printBundleImportCounts(name string, c BundleImportCounts) {
+	fmt.Printf("  %-9s %d imported, %d skipped, %d failed\n", name+":", c.Imported, c.Skipped, c.Failed)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/export"
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+func TestPlanBundleImport(t *testing.T) {
+	base := &export.Bundle{
+		Refs:     []reference.Reference{{ID: "Smith2024-ab", Title: "Existing"}},
+		Concepts: []concept.Concept{{ID: "mcmc", Name: "MCMC"}},
+		Edges:    []edge.Edge{{SourceID: "Smith2024-ab", TargetID: "concept:mcmc", RelationshipType: "introduces", Summary: "s"}},
+	}
+	incoming := &export.Bundle{
+		Refs: []reference.Reference{
+			{ID: "Smith2024-ab", Title: "Incoming copy"},
+			{ID: "Lee2024-cd", Title: "New"},
+			{ID: "Bad2024-ef"}, // No title
+			{ID: "Lee2024-cd", Title: "Duplicate within bundle"},
+		},
+		Concepts: []concept.Concept{{ID: "vae", Name: "VAE"}},
+		Edges: []edge.Edge{
+			{SourceID: "Smith2024-ab", TargetID: "concept:mcmc", RelationshipType: "introduces", Summary: "again"},
+			{SourceID: "Lee2024-cd", TargetID: "concept:vae", RelationshipType: "applies", Summary: "s"},
+		},
+	}
+
+	result, merged := planBundleImport(base, incoming)
+
+	if result.Refs != (BundleImportCounts{Imported: 1, Skipped: 2, Failed: 1}) {
+		t.Errorf("Refs counts = %+v", result.Refs)
+	}
+	if len(merged.Refs) != 2 || merged.Refs[0].Title != "Existing" || merged.Refs[1].ID != "Lee2024-cd" {
+		t.Errorf("merged refs = %+v", merged.Refs)
+	}
+	if result.Concepts != (BundleImportCounts{Imported: 1}) || len(merged.Concepts) != 2 {
+		t.Errorf("Concepts counts = %+v, merged = %d", result.Concepts, len(merged.Concepts))
+	}
+	if result.Edges != (BundleImportCounts{Imported: 1, Skipped: 1}) || len(merged.Edges) != 2 {
+		t.Errorf("Edges counts = %+v, merged = %d", result.Edges, len(merged.Edges))
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Errors = %v, want one for Bad2024-ef", result.Errors)
+	}
+	if len(base.Refs) != 1 {
+		t.Errorf("base bundle was modified: %d refs", len(base.Refs))
+	}
+}
+
+func TestPlanBundleImport_Replace(t *testing.T) {
+	incoming := &export.Bundle{Refs: []reference.Reference{{ID: "Lee2024-cd", Title: "New"}}}
+
+	result, merged := planBundleImport(&export.Bundle{}, incoming)
+
+	if result.Refs.Imported != 1 || len(merged.Refs) != 1 {
+		t.Errorf("Refs = %+v, merged = %d", result.Refs, len(merged.Refs))
+	}
+	if merged.Edges == nil {
+		t.Error("merged.Edges is nil, want empty slice")
+	}
+}
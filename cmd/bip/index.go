@@ -14,7 +14,8 @@ import (
 )
 
 var (
-	noProgress bool
+	noProgress     bool
+	indexBuildFull bool
 )
 
 func init() {
@@ -23,6 +24,7 @@ func init() {
 	indexCmd.AddCommand(indexCheckCmd)
 
 	indexBuildCmd.Flags().BoolVar(&noProgress, "no-progress", false, "Suppress progress output")
+	indexBuildCmd.Flags().BoolVar(&indexBuildFull, "force", false, "Re-embed every paper, not just new or changed ones")
 }
 
 var indexCmd = &cobra.Command{
@@ -37,7 +39,12 @@ type IndexBuildResult struct {
 	PapersIndexed   int     `json:"papers_indexed"`
 	PapersSkipped   int     `json:"papers_skipped"`
 	SkippedReason   string  `json:"skipped_reason"`
+	Embedded        int     `json:"embedded"` // Papers embedded in this build
+	Skipped         int     `json:"skipped"`  // Unchanged papers whose previous vector was reused
+	Removed         int     `json:"removed"`  // Papers dropped from the index (deleted, or abstract removed)
+	FullRebuild     bool    `json:"full_rebuild"`
 	DurationSeconds float64 `json:"duration_seconds"`
+	Provider        string  `json:"provider"`
 	Model           string  `json:"model"`
 	IndexSizeBytes  int64   `json:"index_size_bytes"`
 }
@@ -45,29 +52,43 @@ type IndexBuildResult struct {
 var indexBuildCmd = &cobra.Command{
 	Use:   "build",
 	Short: "Build or rebuild the semantic index",
-	Long: `Build or rebuild the semantic index from paper abstracts.
-
-Requires Ollama to be running with the embedding model available.
-Run 'ollama pull all-minilm:l6-v2' to download the model.`,
+	Long: `Build or update the semantic index from paper abstracts.
+
+Builds are incremental: a paper is only embedded if it is new, its abstract
+changed since it was embedded, or it was embedded with a different model.
+Papers that were deleted or lost their abstract are dropped. If the index
+was built with a different provider or model, everything is re-embedded;
+--force does the same unconditionally.
+
+Embeddings come from Ollama by default, which must be running with the
+embedding model available (run 'ollama pull nomic-embed-text'). Set
+embedding.provider: openai in .bipartite/config.yml to use OpenAI instead,
+with the API key in OPENAI_API_KEY.`,
 	RunE: runIndexBuild,
 }
 
 // outputBuildResults outputs the build statistics in the appropriate format.
-func outputBuildResults(provider *embedding.OllamaProvider, stats *semantic.BuildStats) {
+func outputBuildResults(provider embedding.Provider, stats *semantic.BuildStats) {
 	if humanOutput {
 		fmt.Printf("\nBuild complete:\n")
 		fmt.Printf("  Papers indexed: %d\n", stats.PapersIndexed)
+		fmt.Printf("  Embedded: %d, unchanged: %d, removed: %d\n", stats.Embedded, stats.Unchanged, stats.Removed)
 		fmt.Printf("  Papers skipped: %d (no abstract)\n", stats.PapersSkipped)
 		fmt.Printf("  Time elapsed: %s\n", formatDuration(stats.Duration))
 		fmt.Printf("  Index size: %s\n", formatBytes(stats.IndexSizeBytes))
-		fmt.Printf("  Model: %s\n", provider.ModelName())
+		fmt.Printf("  Model: %s (%s)\n", provider.ModelName(), provider.Name())
 	} else {
 		outputJSON(IndexBuildResult{
 			Status:          "complete",
 			PapersIndexed:   stats.PapersIndexed,
 			PapersSkipped:   stats.PapersSkipped,
 			SkippedReason:   stats.SkippedReason,
+			Embedded:        stats.Embedded,
+			Skipped:         stats.Unchanged,
+			Removed:         stats.Removed,
+			FullRebuild:     stats.FullRebuild,
 			DurationSeconds: stats.Duration.Seconds(),
+			Provider:        provider.Name(),
 			Model:           provider.ModelName(),
 			IndexSizeBytes:  stats.IndexSizeBytes,
 		})
@@ -78,27 +99,36 @@ func runIndexBuild(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	repoRoot := mustFindRepository()
 
-	// Validate Ollama setup (check both availability and model)
-	provider := embedding.NewOllamaProvider()
-	mustValidateOllama(ctx, provider, true)
+	// Validate the configured provider (for Ollama, availability and model)
+	provider := mustEmbeddingProvider(ctx, repoRoot, true)
 
 	// Open database and get references
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
 
-	refs, err := db.ListAll(0)
+	refs, err := db.ListAll(0, storage.SortDefault)
 	if err != nil {
 		exitWithError(ExitError, "listing references: %v", err)
 	}
 
+	// Previous index supplies reusable vectors; an unreadable one is rebuilt
+	prev, err := semantic.Load(repoRoot)
+	if err != nil {
+		if err != semantic.ErrIndexNotFound {
+			fmt.Fprintf(os.Stderr, "Warning: rebuilding from scratch, could not load existing index: %v\n", err)
+		}
+		prev = nil
+	}
+
 	// Build index with progress reporting
 	builder := semantic.NewBuilder(provider, db)
+	builder.SetForceRebuild(indexBuildFull)
 	if !noProgress && humanOutput {
 		builder.SetProgressReporter(semantic.ProgressFunc(printProgress))
 		fmt.Fprintf(os.Stderr, "Building semantic index...\n")
 	}
 
-	idx, stats, err := builder.Build(ctx, refs)
+	idx, stats, err := builder.Update(ctx, refs, prev)
 	if err != nil {
 		exitWithError(ExitError, "building index: %v", err)
 	}
@@ -163,7 +193,7 @@ func findUnindexedPapersWithAbstracts(db *storage.DB, idx *semantic.SemanticInde
 }
 
 // outputCheckResults outputs the index check results in the appropriate format.
-func outputCheckResults(result IndexCheckResult, exitCode int) {
+func outputCheckResults(result IndexCheckResult, exitCode ExitCode) {
 	if humanOutput {
 		fmt.Printf("Semantic Index Status: %s\n\n", result.Status)
 		fmt.Printf("Papers:\n")
@@ -183,7 +213,7 @@ func outputCheckResults(result IndexCheckResult, exitCode int) {
 	}
 
 	if exitCode != ExitSuccess {
-		os.Exit(exitCode)
+		os.Exit(exitCode.Status())
 	}
 }
 
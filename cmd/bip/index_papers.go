@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/semantic"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var indexPapersUnindexed bool
+
+func init() {
+	indexCmd.AddCommand(indexPapersCmd)
+	indexPapersCmd.Flags().BoolVar(&indexPapersUnindexed, "unindexed", false, "Only show papers that are not searchable under their current abstract")
+}
+
+var indexPapersCmd = &cobra.Command{
+	Use:   "papers [<id>...]",
+	Short: "Explain which papers are in the semantic index and why",
+	Long: `Report, per paper, whether it is in the semantic index and, if not, why.
+
+Statuses:
+  indexed             In the index under its current abstract
+  no_abstract         No abstract to embed
+  abstract_too_short  Abstract shorter than the minimum indexed length
+  not_indexed         Eligible, but added (or given an abstract) after the last build
+  stale               In the index, but the abstract changed since it was embedded
+
+With no IDs, every paper is reported. If no index has been built yet,
+eligible papers are reported as not_indexed. Run 'bip index build' to
+fix not_indexed and stale papers.
+
+Examples:
+  bip index papers --unindexed --human
+  bip index papers Smith2024-ab`,
+	RunE: runIndexPapers,
+}
+
+// PaperIndexEntry is one paper in the output of index papers.
+type PaperIndexEntry struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	AbstractLength int    `json:"abstract_length"`
+}
+
+// IndexPapersResult is the response for the index papers command.
+type IndexPapersResult struct {
+	Papers []PaperIndexEntry `json:"papers"`
+	Counts map[string]int    `json:"counts"`
+}
+
+func runIndexPapers(cmd *cobra.Command, args []string) error {
+	repoRoot := mustFindRepository()
+
+	idx, err := semantic.Load(repoRoot)
+	if err == semantic.ErrIndexNotFound {
+		idx = semantic.NewSemanticIndex("", 0)
+	} else if err != nil {
+		exitWithError(ExitError, "loading index: %v", err)
+	}
+
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	var refs []reference.Reference
+	if len(args) == 0 {
+		refs, err = db.ListAll(0, storage.SortDefault)
+		if err != nil {
+			exitWithError(ExitError, "listing references: %v", err)
+		}
+	} else {
+		for _, id := range args {
+			ref, err := db.GetByID(id)
+			if err != nil {
+				exitWithError(ExitError, "getting reference: %v", err)
+			}
+			if ref == nil {
+				exitWithError(ExitError, "reference not found: %s", id)
+			}
+			refs = append(refs, *ref)
+		}
+	}
+
+	metas, err := db.GetAllEmbeddingMetadata()
+	if err != nil {
+		exitWithError(ExitError, "reading embedding metadata: %v", err)
+	}
+
+	result := IndexPapersResult{Papers: []PaperIndexEntry{}, Counts: map[string]int{}}
+	for _, ref := range refs {
+		var meta *storage.EmbeddingMetadata
+		if m, ok := metas[ref.ID]; ok {
+			meta = &m
+		}
+		status := semantic.PaperIndexStatus(ref.Abstract, idx.HasPaper(ref.ID), meta)
+		result.Counts[status]++
+		if indexPapersUnindexed && status == semantic.IndexStatusIndexed {
+			continue
+		}
+		result.Papers = append(result.Papers, PaperIndexEntry{
+			ID:             ref.ID,
+			Status:         status,
+			AbstractLength: len(ref.Abstract),
+		})
+	}
+
+	if humanOutput {
+		for _, p := range result.Papers {
+			fmt.Printf("%-30s %s\n", p.ID, p.Status)
+		}
+		if len(result.Papers) > 0 {
+			fmt.Println()
+		}
+		for _, s := range []string{
+			semantic.IndexStatusIndexed,
+			semantic.IndexStatusStale,
+			semantic.IndexStatusNotIndexed,
+			semantic.IndexStatusTooShort,
+			semantic.IndexStatusNoAbstract,
+		} {
+			fmt.Printf("  %-20s %d\n", s+":", result.Counts[s])
+		}
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/matsen/bipartite/internal/semantic"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var indexRehashConfirmed bool
+
+func init() {
+	indexCmd.AddCommand(indexRehashCmd)
+	indexRehashCmd.Flags().BoolVar(&indexRehashConfirmed, "i-know-what-im-doing", false, "Confirm that indexed embeddings are up to date")
+}
+
+var indexRehashCmd = &cobra.Command{
+	Use:   "recompute-hashes",
+	Short: "Re-baseline staleness detection without re-embedding",
+	Long: `Recompute the abstract hash stored for every indexed paper, using the
+current hashing scheme, without re-embedding anything.
+
+This is for the case where the hashing scheme itself changed (e.g. abstract
+normalization was added) and every paper now looks stale in
+'bip index papers' even though the embeddings are fine. Afterwards each
+indexed paper counts as current for its present abstract.
+
+It also hides genuinely stale embeddings: a paper whose abstract really
+changed since it was embedded will no longer be reported. Run
+'bip index build' instead unless you are sure the vectors are current,
+and pass --i-know-what-im-doing to confirm.`,
+	Args: cobra.NoArgs,
+	RunE: runIndexRehash,
+}
+
+// IndexRehashResult is the response for the index recompute-hashes command.
+type IndexRehashResult struct {
+	Updated     int `json:"updated"`
+	Unchanged   int `json:"unchanged"`
+	NotIndexed  int `json:"not_indexed"`
+	PapersTotal int `json:"papers_total"`
+}
+
+func runIndexRehash(cmd *cobra.Command, args []string) error {
+	if !indexRehashConfirmed {
+		exitWithError(ExitError, "recompute-hashes can mask genuinely stale embeddings\n\nRun 'bip index build' to re-embed, or pass --i-know-what-im-doing if the vectors are current.")
+	}
+
+	repoRoot := mustFindRepository()
+	idx := mustLoadSemanticIndex(repoRoot)
+
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	refs, err := db.ListAll(0, storage.SortDefault)
+	if err != nil {
+		exitWithError(ExitError, "listing references: %v", err)
+	}
+	metas, err := db.GetAllEmbeddingMetadata()
+	if err != nil {
+		exitWithError(ExitError, "reading embedding metadata: %v", err)
+	}
+
+	rehash := semantic.RecomputeHashes(refs, idx, metas)
+	for _, meta := range rehash.Updated {
+		if err := db.SaveEmbeddingMetadata(meta); err != nil {
+			exitWithError(ExitError, "saving metadata for %s: %v", meta.PaperID, err)
+		}
+	}
+
+	result := IndexRehashResult{
+		Updated:     len(rehash.Updated),
+		Unchanged:   rehash.Unchanged,
+		NotIndexed:  rehash.NotIndexed,
+		PapersTotal: rehash.PapersTotal,
+	}
+	if humanOutput {
+		fmt.Printf("Recomputed abstract hashes for %d papers\n", result.Updated)
+		fmt.Printf("  Already current: %d\n", result.Unchanged)
+		fmt.Printf("  Not in index: %d\n", result.NotIndexed)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
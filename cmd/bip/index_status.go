@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/matsen/bipartite/internal/semantic"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	indexCmd.AddCommand(indexStatusCmd)
+}
+
+var indexStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show how current the semantic index is",
+	Long: `Report semantic index staleness from the recorded embedding metadata.
+
+Shows how many papers have an abstract long enough to index, how many have
+been embedded, which embedded papers have had their abstract changed since
+(stale), and the embedding model used. Run 'bip index build' if stale_ids
+is non-empty or indexed is short of total_indexable.
+
+Examples:
+  bip index status --human`,
+	Args: cobra.NoArgs,
+	RunE: runIndexStatus,
+}
+
+// IndexStatusResult is the response for the index status command.
+type IndexStatusResult struct {
+	Indexed        int      `json:"indexed"`
+	TotalIndexable int      `json:"total_indexable"`
+	StaleIDs       []string `json:"stale_ids"`
+	ModelName      string   `json:"model_name"`
+}
+
+func runIndexStatus(cmd *cobra.Command, args []string) error {
+	repoRoot := mustFindRepository()
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	indexable, err := db.CountPapersWithAbstract(semantic.MinAbstractLength)
+	if err != nil {
+		exitWithError(ExitError, "counting abstracts: %v", err)
+	}
+	indexed, err := db.CountEmbeddingMetadata()
+	if err != nil {
+		exitWithError(ExitError, "counting embedding metadata: %v", err)
+	}
+	metas, err := db.GetAllEmbeddingMetadata()
+	if err != nil {
+		exitWithError(ExitError, "reading embedding metadata: %v", err)
+	}
+	refs, err := db.ListAll(0, storage.SortDefault)
+	if err != nil {
+		exitWithError(ExitError, "listing references: %v", err)
+	}
+
+	result := IndexStatusResult{
+		Indexed:        indexed,
+		TotalIndexable: indexable,
+		StaleIDs:       semantic.StaleIDs(refs, metas),
+		ModelName:      semantic.PrimaryModel(metas),
+	}
+	if result.StaleIDs == nil {
+		result.StaleIDs = []string{}
+	}
+
+	if humanOutput {
+		fmt.Printf("Indexed: %d of %d indexable papers\n", result.Indexed, result.TotalIndexable)
+		if result.ModelName != "" {
+			fmt.Printf("Model:   %s\n", result.ModelName)
+		}
+		fmt.Printf("Stale:   %d\n", len(result.StaleIDs))
+		for _, id := range result.StaleIDs {
+			fmt.Printf("  %s\n", id)
+		}
+		if len(result.StaleIDs) > 0 || result.Indexed < result.TotalIndexable {
+			fmt.Println("\nRun 'bip index build' to update the index")
+		}
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
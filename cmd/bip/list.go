@@ -1,16 +1,48 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/matsen/bipartite/internal/export"
+	"github.com/matsen/bipartite/internal/graph"
 	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
 	"github.com/spf13/cobra"
 )
 
-var listLimit int
+var (
+	listLimit          int
+	listExportManifest bool
+	listRandomWalk     string
+	listWalkSteps      int
+	listWalkRestart    float64
+	listWalkSeed       int64
+	listFormat         string
+	listYearFrom       int
+	listYearTo         int
+	listVenue          string
+	listAuthors        []string
+	listExactAuthor    bool
+	listSort           string
+)
 
 func init() {
 	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Maximum results to return (0 = all)")
+	listCmd.Flags().BoolVar(&listExportManifest, "export-manifest", false, "Emit a reproducibility manifest (IDs, DOIs, content hashes) instead of records")
+	listCmd.Flags().StringVar(&listRandomWalk, "random-walk", "", "Rank papers by visits in a random walk over edges from this paper")
+	listCmd.Flags().IntVar(&listWalkSteps, "steps", 10000, "Random walk: number of steps")
+	listCmd.Flags().Float64Var(&listWalkRestart, "restart", 0.15, "Random walk: probability of restarting at the start paper each step")
+	listCmd.Flags().Int64Var(&listWalkSeed, "seed", 0, "Random walk: RNG seed (same seed gives the same result)")
+	listCmd.Flags().StringVar(&listFormat, "format", "json", "Output format: json, ris, or bibtex")
+	listCmd.Flags().IntVar(&listYearFrom, "year-from", 0, "Only papers published in or after this year")
+	listCmd.Flags().IntVar(&listYearTo, "year-to", 0, "Only papers published in or before this year")
+	listCmd.Flags().StringVar(&listVenue, "venue", "", "Only papers whose venue contains this (case-insensitive)")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "Sort by year, -year (newest first), title, or id")
+	listCmd.Flags().StringArrayVarP(&listAuthors, "author", "a", nil, "Only papers by this author (can be repeated, uses AND logic)")
+	listCmd.Flags().BoolVar(&listExactAuthor, "exact-author", false, "Match --author first names in full instead of by prefix")
 	rootCmd.AddCommand(listCmd)
 }
 
@@ -19,9 +51,40 @@ var listCmd = &cobra.Command{
 	Short: "List all references",
 	Long: `List all references in the repository.
 
+With --export-manifest, emits a JSON manifest of the listed references:
+each paper's ID, DOI, title, and a content hash of its record, plus a
+snapshot timestamp and a combined checksum. Check it later with
+'bip verify-manifest'.
+
+With --random-walk, walks the edge graph (papers ↔ concepts ↔ papers) from
+a starting paper, restarting with probability --restart, and lists the
+papers visited most often with the shortest path connecting each one.
+This surfaces related papers two or three hops away.
+
+With --year-from, --year-to, --venue, or --author, lists only the matching
+papers, newest first, as {"count": N, "references": [...]}. Unlike
+'bip search' there is no keyword: this is for browsing. Author matching
+works as in 'bip search --author'.
+
+--sort orders the list by year, -year (newest first), title, or id. The
+default is by id, or newest first when filtering.
+
+With --format ris, prints the references as RIS records for reference
+managers that import RIS rather than BibTeX. With --format bibtex, prints
+them as BibTeX entries keyed by ID, e.g. to build a .bib file from a
+filtered subset.
+
 Examples:
   bip list
-  bip list --limit 100`,
+  bip list --limit 100
+  bip list --year-from 2020 --venue Nature --human
+  bip list -a "Matsen" --year-to 2019
+  bip list --sort title --human
+  bip list --format ris > library.ris
+  bip list --year-from 2020 -a "Matsen" --format bibtex > refs.bib
+  bip list --export-manifest > manifest.json
+  bip list --random-walk Zhang2018-vi --limit 10 --human
+  bip list --random-walk Zhang2018-vi --steps 50000 --seed 7`,
 	RunE: runList,
 }
 
@@ -30,34 +93,206 @@ func runList(cmd *cobra.Command, args []string) error {
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
 
-	refs, err := db.ListAll(listLimit)
+	if err := validateRefFormat(listFormat); err != nil {
+		exitWithError(ExitError, "%v", err)
+	}
+	order, err := storage.ParseSortOrder(listSort)
+	if err != nil {
+		exitWithError(ExitError, "%v", err)
+	}
+	if listFormat != refFormatJSON && (listRandomWalk != "" || listExportManifest) {
+		exitWithError(ExitError, "--format %s cannot be combined with --random-walk or --export-manifest", listFormat)
+	}
+
+	filters := storage.SearchFilters{
+		Authors:      listAuthors,
+		ExactAuthors: listExactAuthor,
+		YearFrom:     listYearFrom,
+		YearTo:       listYearTo,
+		Venue:        listVenue,
+	}
+	filtered := len(filters.Authors) > 0 || filters.YearFrom > 0 || filters.YearTo > 0 || filters.Venue != ""
+
+	if listRandomWalk != "" {
+		if filtered || order != storage.SortDefault {
+			exitWithError(ExitError, "--random-walk cannot be combined with --year-from, --year-to, --venue, --author, or --sort")
+		}
+		return runListRandomWalk(db, listRandomWalk)
+	}
+
+	var items listItems[reference.Reference]
+	if filtered {
+		if order == storage.SortDefault {
+			order = storage.SortYearDesc
+		}
+		// Limit after the author post-filter, which SQL LIMIT would precede
+		items = limitItems(func(yield func(reference.Reference) error) error {
+			return db.SearchWithFiltersEach(filters, 0, order, yield)
+		}, listLimit)
+	} else {
+		items = func(yield func(reference.Reference) error) error {
+			return db.ListAllEach(listLimit, order, yield)
+		}
+	}
+
+	if listFormat == refFormatJSON && !listExportManifest && !humanOutput {
+		err := outputList(items, func(refs []reference.Reference) any {
+			if filtered {
+				return ListFilteredResult{Count: len(refs), References: refs}
+			}
+			return refs
+		})
+		if err != nil {
+			exitWithError(ExitError, "listing references: %v", err)
+		}
+		return nil
+	}
+
+	refs, err := collectItems(items)
 	if err != nil {
 		exitWithError(ExitError, "listing references: %v", err)
 	}
 
-	// Get total count for human output
-	total, _ := db.Count()
+	if listFormat == refFormatRIS {
+		fmt.Print(export.ToRISList(refs))
+		return nil
+	}
+	if listFormat == refFormatBibTeX {
+		fmt.Print(export.ToBibTeXList(refs))
+		return nil
+	}
 
-	if humanOutput {
+	if listExportManifest {
+		manifest, err := export.BuildManifest(refs, time.Now())
+		if err != nil {
+			exitWithError(ExitError, "building manifest: %v", err)
+		}
+		outputJSON(manifest)
+		return nil
+	}
+
+	if filtered {
 		if len(refs) == 0 {
-			fmt.Println("No references in repository")
+			fmt.Println("No matching references")
 		} else {
-			if listLimit > 0 && listLimit < total {
-				fmt.Printf("%d references (showing first %d):\n\n", total, len(refs))
-			} else {
-				fmt.Printf("%d references in repository:\n\n", len(refs))
-			}
+			fmt.Printf("%d matching references:\n\n", len(refs))
 			for _, ref := range refs {
-				title := truncateString(ref.Title, ListTitleMaxLen)
-				fmt.Printf("  %-16s %s\n", ref.ID, title)
+				fmt.Printf("  %-16s %4d  %s\n", ref.ID, ref.Published.Year, truncateString(ref.Title, ListTitleMaxLen))
 			}
 		}
+		return nil
+	}
+
+	// Get total count for human output
+	total, _ := db.Count()
+
+	if len(refs) == 0 {
+		fmt.Println("No references in repository")
 	} else {
-		if refs == nil {
-			refs = []reference.Reference{}
+		if listLimit > 0 && listLimit < total {
+			fmt.Printf("%d references (showing first %d):\n\n", total, len(refs))
+		} else {
+			fmt.Printf("%d references in repository:\n\n", len(refs))
+		}
+		for _, ref := range refs {
+			title := truncateString(ref.Title, ListTitleMaxLen)
+			fmt.Printf("  %-16s %s\n", ref.ID, title)
 		}
-		outputJSON(refs)
 	}
 
 	return nil
 }
+
+// ListFilteredResult is the response for bip list with filters.
+type ListFilteredResult struct {
+	Count      int                   `json:"count"`
+	References []reference.Reference `json:"references"`
+}
+
+// RandomWalkResult is the response for bip list --random-walk.
+type RandomWalkResult struct {
+	Start  string      `json:"start"`
+	Steps  int         `json:"steps"`
+	Seed   int64       `json:"seed"`
+	Papers []WalkPaper `json:"papers"`
+}
+
+// WalkPaper is a paper reached by a random walk, with its visit count.
+type WalkPaper struct {
+	ID     string   `json:"id"`
+	Title  string   `json:"title"`
+	Year   int      `json:"year"`
+	Visits int      `json:"visits"`
+	Path   []string `json:"path"`
+}
+
+func runListRandomWalk(db *storage.DB, startID string) error {
+	start, err := db.GetByID(startID)
+	if err != nil {
+		exitWithError(ExitError, "getting reference: %v", err)
+	}
+	if start == nil {
+		exitWithError(ExitError, "reference not found: %s", startID)
+	}
+
+	edges, err := db.GetAllEdges()
+	if err != nil {
+		exitWithError(ExitError, "loading edges: %v", err)
+	}
+
+	isPaper := func(id string) bool {
+		nodeType, _ := parseNodeType(id)
+		return nodeType == "paper"
+	}
+	visits, err := graph.New(edges).RandomWalk(startID, graph.WalkOptions{
+		Steps:       listWalkSteps,
+		RestartProb: listWalkRestart,
+		Seed:        listWalkSeed,
+		Include:     isPaper,
+	})
+	if err != nil {
+		if errors.Is(err, graph.ErrNodeNotFound) {
+			exitWithError(ExitDataError, "paper %s has no edges to walk from\n  Hint: Link it with 'bip edge add'", startID)
+		}
+		exitWithError(ExitError, "random walk: %v", err)
+	}
+
+	papers := make([]WalkPaper, 0, len(visits))
+	for _, v := range visits {
+		ref, err := db.GetByID(v.ID)
+		if err != nil || ref == nil {
+			continue // Skip orphaned edge endpoints
+		}
+		papers = append(papers, WalkPaper{
+			ID:     ref.ID,
+			Title:  ref.Title,
+			Year:   ref.Published.Year,
+			Visits: v.Count,
+			Path:   v.Path,
+		})
+		if listLimit > 0 && len(papers) >= listLimit {
+			break
+		}
+	}
+
+	if humanOutput {
+		if len(papers) == 0 {
+			fmt.Printf("No papers reached from %s\n", startID)
+			return nil
+		}
+		fmt.Printf("Papers reached from %s (%d steps, seed %d):\n\n", startID, listWalkSteps, listWalkSeed)
+		for i, p := range papers {
+			fmt.Printf("%d. [%d] %s\n", i+1, p.Visits, p.ID)
+			fmt.Printf("   %s (%d)\n", truncateString(p.Title, SearchTitleMaxLen), p.Year)
+			fmt.Printf("   via %s\n\n", strings.Join(p.Path, " → "))
+		}
+	} else {
+		outputJSON(RandomWalkResult{
+			Start:  startID,
+			Steps:  listWalkSteps,
+			Seed:   listWalkSeed,
+			Papers: papers,
+		})
+	}
+	return nil
+}
@@ -4,10 +4,13 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
+	"time"
 
 	"github.com/matsen/bipartite/internal/config"
 	"github.com/matsen/bipartite/internal/embedding"
+	"github.com/matsen/bipartite/internal/logging"
 	"github.com/matsen/bipartite/internal/semantic"
 	"github.com/matsen/bipartite/internal/storage"
 	"github.com/spf13/cobra"
@@ -19,12 +22,22 @@ var Version = "dev"
 // humanOutput controls whether to use human-readable output
 var humanOutput bool
 
+// jsonLines makes list commands write one JSON object per line
+var jsonLines bool
+
+// prettyOutput indents JSON output; by default it is compact
+var prettyOutput bool
+
+// verbose enables debug logging of external calls and index rebuilds to stderr
+var verbose bool
+
 func main() {
+	loadDotEnv()
 	if err := rootCmd.Execute(); err != nil {
 		// Print the error since we have SilenceErrors: true
 		// This ensures Cobra errors (like missing required flags) are visible
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		os.Exit(ExitError)
+		os.Exit(ExitError.Status())
 	}
 }
 
@@ -44,19 +57,25 @@ Data is stored in git-versionable JSONL with ephemeral SQLite for queries.
 All commands output JSON by default for AI agent integration.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		logging.Setup(os.Stderr, verbose)
+	},
 }
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&humanOutput, "human", false, "Use human-readable output instead of JSON")
+	rootCmd.PersistentFlags().BoolVar(&jsonLines, "json-lines", false, "Write list output as one JSON object per line")
+	rootCmd.PersistentFlags().BoolVar(&prettyOutput, "pretty", false, "Indent JSON output for reading")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Log external API calls and index rebuild timings to stderr")
 	rootCmd.Version = Version
 }
 
 // getStartingDirectory returns the directory to start searching for a repository.
 // Prefers nexus_path from global config, falls back to current working directory.
-func getStartingDirectory() (string, int) {
+func getStartingDirectory() (string, ExitCode) {
 	// Try global config first
 	if root := config.GetNexusPath(); root != "" {
-		return root, 0
+		return root, ExitSuccess
 	}
 
 	// Fall back to current working directory
@@ -65,22 +84,22 @@ func getStartingDirectory() (string, int) {
 		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
 		return "", ExitError
 	}
-	return cwd, 0
+	return cwd, ExitSuccess
 }
 
 // mustFindRepository finds and validates the repository, exits on error.
 // Returns the repository root path.
 func mustFindRepository() string {
 	start, exitCode := getStartingDirectory()
-	if exitCode != 0 {
-		os.Exit(exitCode)
+	if exitCode != ExitSuccess {
+		os.Exit(exitCode.Status())
 	}
 
 	repoRoot, err := config.FindRepository(start)
 	if err != nil {
 		// Show helpful message with tip about global config
 		fmt.Fprintln(os.Stderr, config.HelpfulConfigMessage())
-		os.Exit(ExitConfigError)
+		os.Exit(ExitConfigError.Status())
 	}
 	return repoRoot
 }
@@ -89,10 +108,12 @@ func mustFindRepository() string {
 // The caller is responsible for calling Close() on the returned DB.
 func mustOpenDatabase(repoRoot string) *storage.DB {
 	dbPath := config.DBPath(repoRoot)
+	start := time.Now()
 	db, err := storage.OpenDB(dbPath)
 	if err != nil {
 		exitWithError(ExitError, "opening database: %v", err)
 	}
+	slog.Debug("sqlite open", "path", dbPath, "duration", time.Since(start).Round(time.Millisecond))
 	return db
 }
 
@@ -121,7 +142,7 @@ func mustLoadSemanticIndex(repoRoot string) *semantic.SemanticIndex {
 // If checkModel is true, also verifies the required embedding model is available.
 func mustValidateOllama(ctx context.Context, provider *embedding.OllamaProvider, checkModel bool) {
 	if err := provider.IsAvailable(ctx); err != nil {
-		exitWithError(ExitDataError, "Ollama is not running\n\nStart Ollama with 'ollama serve' or install from https://ollama.ai")
+		exitWithErrorCode(ExitDataError, ErrorCodeOllamaUnavailable, "Ollama is not running\n\nStart Ollama with 'ollama serve' or install from https://ollama.ai")
 	}
 
 	if checkModel {
@@ -134,3 +155,67 @@ func mustValidateOllama(ctx context.Context, provider *embedding.OllamaProvider,
 		}
 	}
 }
+
+// mustEmbeddingProvider returns the embedding provider selected by the
+// repository's embedding config (Ollama by default), exiting if it cannot
+// be used. For Ollama, checkModel also verifies the model is pulled.
+func mustEmbeddingProvider(ctx context.Context, repoRoot string, checkModel bool) embedding.Provider {
+	provider, err := newEmbeddingProvider(mustLoadConfig(repoRoot))
+	if err != nil {
+		exitWithError(ExitConfigError, "%v", err)
+	}
+	if ollama, ok := provider.(*embedding.OllamaProvider); ok {
+		mustValidateOllama(ctx, ollama, checkModel)
+		return provider
+	}
+	if err := provider.IsAvailable(ctx); err != nil {
+		exitWithErrorCode(ExitDataError, ErrorCodeOpenAIUnavailable, "OpenAI embeddings are not available: %v\n\nCheck OPENAI_API_KEY (or openai_api_key in ~/.config/bip/config.yml) and the configured model.", err)
+	}
+	return provider
+}
+
+// newEmbeddingProvider builds the embedding provider selected by cfg
+// (Ollama if cfg is nil or names none). The vector size is
+// embedding.dimensions if set, else the known size of the configured model,
+// else the provider's default (so an unknown model of another size needs
+// embedding.dimensions).
+func newEmbeddingProvider(cfg *config.Config) (embedding.Provider, error) {
+	var settings config.EmbeddingSettings
+	if cfg != nil {
+		settings = cfg.Embedding
+	}
+	if err := config.ValidateEmbeddingProvider(settings.Provider); err != nil {
+		return nil, err
+	}
+	if settings.Dimensions < 0 {
+		return nil, fmt.Errorf("invalid embedding.dimensions: %d (must be positive)", settings.Dimensions)
+	}
+	dims := settings.Dimensions
+	if dims == 0 && settings.Model != "" {
+		dims = embedding.ModelDimensions(settings.Model)
+	}
+
+	if settings.Provider == config.EmbeddingProviderOpenAI {
+		apiKey := config.GetOpenAIAPIKey()
+		if apiKey == "" {
+			return nil, fmt.Errorf("OpenAI API key not set\n\nSet OPENAI_API_KEY or openai_api_key in ~/.config/bip/config.yml")
+		}
+		model := settings.Model
+		if model == "" {
+			model = embedding.DefaultOpenAIModel
+		}
+		if dims == 0 {
+			dims = embedding.DefaultOpenAIDimensions
+		}
+		return embedding.NewOpenAIProvider(apiKey, embedding.WithOpenAIModel(model, dims)), nil
+	}
+
+	var opts []embedding.OllamaOption
+	if settings.Model != "" {
+		opts = append(opts, embedding.WithModel(settings.Model))
+	}
+	if dims != 0 {
+		opts = append(opts, embedding.WithDimensions(dims))
+	}
+	return embedding.NewOllamaProvider(opts...), nil
+}
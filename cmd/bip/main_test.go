@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/embedding"
+)
+
+func TestNewEmbeddingProvider(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test")
+
+	tests := []struct {
+		name     string
+		settings config.EmbeddingSettings
+		provider string
+		model    string
+		dims     int
+	}{
+		{"default", config.EmbeddingSettings{}, embedding.ProviderOllama, embedding.DefaultModel, embedding.DefaultDimensions},
+		{"known ollama model", config.EmbeddingSettings{Model: "mxbai-embed-large"}, embedding.ProviderOllama, "mxbai-embed-large", 1024},
+		{"ollama tag", config.EmbeddingSettings{Model: "all-minilm:latest"}, embedding.ProviderOllama, "all-minilm:latest", 384},
+		{"explicit dimensions", config.EmbeddingSettings{Model: "custom-embed", Dimensions: 512}, embedding.ProviderOllama, "custom-embed", 512},
+		{"openai default", config.EmbeddingSettings{Provider: "openai"}, embedding.ProviderOpenAI, embedding.DefaultOpenAIModel, embedding.DefaultOpenAIDimensions},
+		{"known openai model", config.EmbeddingSettings{Provider: "openai", Model: "text-embedding-3-large"}, embedding.ProviderOpenAI, "text-embedding-3-large", 3072},
+		{"openai reduced dimensions", config.EmbeddingSettings{Provider: "openai", Model: "text-embedding-3-large", Dimensions: 256}, embedding.ProviderOpenAI, "text-embedding-3-large", 256},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newEmbeddingProvider(&config.Config{Embedding: tt.settings})
+			if err != nil {
+				t.Fatalf("newEmbeddingProvider() error = %v", err)
+			}
+			if p.Name() != tt.provider || p.ModelName() != tt.model || p.Dimensions() != tt.dims {
+				t.Errorf("got %s/%s/%d, want %s/%s/%d", p.Name(), p.ModelName(), p.Dimensions(), tt.provider, tt.model, tt.dims)
+			}
+		})
+	}
+
+	if p, err := newEmbeddingProvider(nil); err != nil || p.Name() != embedding.ProviderOllama {
+		t.Errorf("newEmbeddingProvider(nil) = %v, %v; want the Ollama default", p, err)
+	}
+	for _, bad := range []config.EmbeddingSettings{{Provider: "cohere"}, {Dimensions: -1}} {
+		if _, err := newEmbeddingProvider(&config.Config{Embedding: bad}); err == nil {
+			t.Errorf("newEmbeddingProvider(%+v) should fail", bad)
+		}
+	}
+}
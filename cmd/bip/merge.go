@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	mergeCmd.Flags().Bool("dry-run", false, "Show what the merge would change without writing")
+	rootCmd.AddCommand(mergeCmd)
+}
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <from-id> <to-id>",
+	Short: "Merge a duplicate paper into another",
+	Long: `Merge the paper <from-id> into <to-id>, e.g. two imports of the same
+paper that 'bip check' reports as a duplicate_doi.
+
+Edges of the from paper, as source or target, are moved to the to paper.
+Edges that then duplicate one another are collapsed to the copy with the
+earliest created_at, and edges linking the to paper to itself are dropped.
+Fields the to paper lacks (DOI, abstract, PMID, ...) are filled from the
+from paper, and the from paper is deleted. The old ID stays discoverable
+as the to paper's supersedes field, unless that already names another paper.
+Other papers that superseded the from paper are pointed at the to paper.
+
+Like 'bip delete', the from paper's semantic index metadata is cleared.
+
+Examples:
+  bip merge Smith2024-ab-2 Smith2024-ab --dry-run --human
+  bip merge Smith2024-ab-2 Smith2024-ab`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMerge,
+}
+
+// MergeResult is the response for the merge command.
+// With --dry-run, Status is "dry_run" and nothing is written.
+type MergeResult struct {
+	Status                   string `json:"status"`
+	FromID                   string `json:"from_id"`
+	ToID                     string `json:"to_id"`
+	EdgesUpdated             int    `json:"edges_updated"`
+	DuplicatesRemoved        int    `json:"duplicates_removed"`
+	SelfLinksRemoved         int    `json:"self_links_removed"`
+	SupersedesUpdated        int    `json:"supersedes_updated"`   // Other papers' supersedes moved to the to paper
+	Supersedes               string `json:"supersedes,omitempty"` // The to paper's supersedes after the merge
+	EmbeddingMetadataCleared bool   `json:"embedding_metadata_cleared"`
+}
+
+// paperMergePlan is the computed outcome of merging one paper into another.
+type paperMergePlan struct {
+	Refs        []reference.Reference // References after the merge (from removed, to updated)
+	Changed     []string              // IDs of remaining references whose records changed
+	Edges       []edge.Edge           // Edges after repointing and deduplication
+	Repointed   int                   // Edges with an endpoint changed
+	Removed     []edge.Edge           // Duplicate edges dropped
+	SelfLinks   int                   // Edges dropped for linking to to itself
+	Superseders int                   // Other papers whose supersedes moved from from to to
+}
+
+// planPaperMerge computes the result of merging fromID into toID without
+// writing anything. The refs and edges slices are not modified.
+func planPaperMerge(refs []reference.Reference, edges []edge.Edge, fromID, toID string) (paperMergePlan, error) {
+	var plan paperMergePlan
+
+	if fromID == toID {
+		return plan, fmt.Errorf("cannot merge a paper into itself")
+	}
+	fromIdx, found := storage.FindByID(refs, fromID)
+	if !found {
+		return plan, fmt.Errorf("reference not found: %s", fromID)
+	}
+	toIdx, found := storage.FindByID(refs, toID)
+	if !found {
+		return plan, fmt.Errorf("reference not found: %s", toID)
+	}
+
+	merged := reference.MergeUpdate(refs[fromIdx], refs[toIdx])
+	if refs[toIdx].Supersedes == "" || reference.ResolveSupersedes(refs, refs[toIdx].Supersedes) == fromIdx {
+		merged.Supersedes = fromID
+	} else {
+		merged.Supersedes = refs[toIdx].Supersedes
+	}
+	updated := append([]reference.Reference(nil), refs...)
+	updated[toIdx] = merged
+	plan.Changed = append(plan.Changed, toID)
+	for i, r := range refs {
+		if i == fromIdx || i == toIdx {
+			continue
+		}
+		if reference.ResolveSupersedes(refs, r.Supersedes) == fromIdx {
+			updated[i].Supersedes = toID
+			plan.Changed = append(plan.Changed, r.ID)
+			plan.Superseders++
+		}
+	}
+	plan.Refs, _ = storage.DeleteByID(updated, fromID)
+
+	var repointed []edge.Edge
+	for _, e := range edges {
+		if e.SourceID != fromID && e.TargetID != fromID {
+			repointed = append(repointed, e)
+			continue
+		}
+		if e.SourceID == fromID {
+			e.SourceID = toID
+		}
+		if e.TargetID == fromID {
+			e.TargetID = toID
+		}
+		if e.SourceID == e.TargetID {
+			plan.SelfLinks++
+			continue
+		}
+		plan.Repointed++
+		repointed = append(repointed, e)
+	}
+
+	// Deduplicate edges (same source_id + target_id + relationship_type),
+	// keeping the one with earlier created_at
+	plan.Edges, plan.Removed = edge.DedupeEdges(repointed)
+
+	return plan, nil
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	fromID, toID := args[0], args[1]
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	repoRoot := mustFindRepository()
+	refsPath := config.RefsPath(repoRoot)
+	refs, err := storage.ReadAll(refsPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+	edgesPath := config.EdgesPath(repoRoot)
+	edges, err := storage.ReadAllEdges(edgesPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading edges: %v", err)
+	}
+
+	plan, err := planPaperMerge(refs, edges, fromID, toID)
+	if err != nil {
+		exitWithError(ExitError, "%v", err)
+	}
+
+	toIdx, _ := storage.FindByID(plan.Refs, toID)
+	result := MergeResult{
+		Status:            "merged",
+		FromID:            fromID,
+		ToID:              toID,
+		EdgesUpdated:      plan.Repointed,
+		DuplicatesRemoved: len(plan.Removed),
+		SelfLinksRemoved:  plan.SelfLinks,
+		SupersedesUpdated: plan.Superseders,
+		Supersedes:        plan.Refs[toIdx].Supersedes,
+	}
+
+	if dryRun {
+		result.Status = "dry_run"
+	} else {
+		db := mustOpenDatabase(repoRoot)
+		defer db.Close()
+		indexCurrent := db.IndexIsCurrent(storage.IndexRefs, refsPath)
+
+		if err := storage.WriteAll(refsPath, plan.Refs); err != nil {
+			exitWithError(ExitDataError, "writing refs: %v", err)
+		}
+		if err := storage.WriteAllEdges(edgesPath, plan.Edges); err != nil {
+			exitWithError(ExitDataError, "writing edges: %v", err)
+		}
+
+		if err := db.ApplyIndexUpdate(storage.IndexRefs, refsPath, indexCurrent, func() error {
+			return applyMergedRefs(db, plan, fromID)
+		}); err != nil {
+			exitWithError(ExitDataError, "updating index: %v", err)
+		}
+		if _, err := db.RebuildEdgesFromJSONL(edgesPath); err != nil {
+			exitWithError(ExitDataError, "rebuilding edges index: %v", err)
+		}
+		result.EmbeddingMetadataCleared, err = db.DeleteEmbeddingMetadata(fromID)
+		if err != nil {
+			exitWithError(ExitDataError, "clearing embedding metadata: %v", err)
+		}
+	}
+
+	if humanOutput {
+		verb := "Merged"
+		if dryRun {
+			verb = "Would merge"
+		}
+		fmt.Printf("%s %q into %q\n", verb, fromID, toID)
+		fmt.Printf("  Edges updated: %d\n", result.EdgesUpdated)
+		if result.DuplicatesRemoved > 0 || result.SelfLinksRemoved > 0 {
+			fmt.Printf("  Edges removed: %d duplicates, %d self-links\n", result.DuplicatesRemoved, result.SelfLinksRemoved)
+		}
+		if result.Supersedes == fromID {
+			fmt.Printf("  %s now supersedes %s\n", toID, fromID)
+		}
+		if result.SupersedesUpdated > 0 {
+			fmt.Printf("  Supersedes links moved to %s: %d\n", toID, result.SupersedesUpdated)
+		}
+		if result.EmbeddingMetadataCleared {
+			fmt.Println("  Cleared semantic index metadata")
+		}
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// applyMergedRefs re-indexes the references a merge changed and drops the
+// merged-away paper.
+func applyMergedRefs(db *storage.DB, plan paperMergePlan, fromID string) error {
+	if err := db.DeleteRef(fromID); err != nil {
+		return err
+	}
+	for _, id := range plan.Changed {
+		idx, _ := storage.FindByID(plan.Refs, id)
+		if err := db.UpsertRef(plan.Refs[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+func TestPlanPaperMerge(t *testing.T) {
+	refs := []reference.Reference{
+		{ID: "Dup", DOI: "10.1000/xyz", Abstract: "From the duplicate", PMID: "123"},
+		{ID: "Keep", DOI: "10.1000/xyz", Title: "Kept"},
+		{ID: "Other"},
+	}
+	edges := []edge.Edge{
+		{SourceID: "Dup", TargetID: "concept:vae", RelationshipType: "introduces", CreatedAt: "2024-01-01"},
+		{SourceID: "Keep", TargetID: "concept:vae", RelationshipType: "introduces", CreatedAt: "2024-06-01"},
+		{SourceID: "Other", TargetID: "Dup", RelationshipType: "cites"},
+		{SourceID: "Dup", TargetID: "Keep", RelationshipType: "cites"}, // becomes a self-link
+		{SourceID: "Other", TargetID: "Keep", RelationshipType: "extends"},
+	}
+
+	plan, err := planPaperMerge(refs, edges, "Dup", "Keep")
+	if err != nil {
+		t.Fatalf("planPaperMerge: %v", err)
+	}
+
+	if len(plan.Refs) != 2 || plan.Refs[0].ID != "Keep" || plan.Refs[1].ID != "Other" {
+		t.Fatalf("refs after merge = %+v, want Keep and Other", plan.Refs)
+	}
+	keep := plan.Refs[0]
+	if keep.Title != "Kept" || keep.Abstract != "From the duplicate" || keep.PMID != "123" {
+		t.Errorf("merged ref = %+v, want Keep's title with gaps filled from Dup", keep)
+	}
+	if keep.Supersedes != "Dup" {
+		t.Errorf("Supersedes = %q, want Dup", keep.Supersedes)
+	}
+	if refs[1].Supersedes != "" || len(refs) != 3 {
+		t.Error("input refs were modified")
+	}
+
+	if plan.Repointed != 2 || plan.SelfLinks != 1 || len(plan.Removed) != 1 {
+		t.Errorf("repointed, self-links, removed = %d, %d, %d; want 2, 1, 1",
+			plan.Repointed, plan.SelfLinks, len(plan.Removed))
+	}
+	want := []edge.EdgeKey{
+		{SourceID: "Keep", TargetID: "concept:vae", RelationshipType: "introduces"},
+		{SourceID: "Other", TargetID: "Keep", RelationshipType: "cites"},
+		{SourceID: "Other", TargetID: "Keep", RelationshipType: "extends"},
+	}
+	if len(plan.Edges) != len(want) {
+		t.Fatalf("got %d edges, want %d: %+v", len(plan.Edges), len(want), plan.Edges)
+	}
+	for i := range want {
+		if plan.Edges[i].Key() != want[i] {
+			t.Errorf("edge %d = %+v, want %+v", i, plan.Edges[i].Key(), want[i])
+		}
+	}
+	if plan.Edges[0].CreatedAt != "2024-01-01" {
+		t.Errorf("kept duplicate created_at = %q, want the earliest", plan.Edges[0].CreatedAt)
+	}
+}
+
+func TestPlanPaperMerge_Errors(t *testing.T) {
+	refs := []reference.Reference{{ID: "A"}, {ID: "B", Supersedes: "Z"}, {ID: "Z"}}
+
+	if _, err := planPaperMerge(refs, nil, "A", "A"); err == nil {
+		t.Error("expected error merging a paper into itself")
+	}
+	if _, err := planPaperMerge(refs, nil, "missing", "A"); err == nil {
+		t.Error("expected error for missing from paper")
+	}
+
+	// An existing supersedes link on the to paper is kept
+	plan, err := planPaperMerge(refs, nil, "A", "B")
+	if err != nil {
+		t.Fatalf("planPaperMerge: %v", err)
+	}
+	if plan.Refs[0].Supersedes != "Z" {
+		t.Errorf("Supersedes = %q, want Z kept", plan.Refs[0].Supersedes)
+	}
+}
+
+func TestPlanPaperMerge_RepointsSuperseders(t *testing.T) {
+	refs := []reference.Reference{
+		{ID: "Dup", DOI: "10.1000/dup"},
+		{ID: "Keep"},
+		{ID: "ByID", Supersedes: "Dup"},
+		{ID: "ByDOI", Supersedes: "10.1000/dup"},
+		{ID: "Unrelated", Supersedes: "Keep"},
+	}
+
+	plan, err := planPaperMerge(refs, nil, "Dup", "Keep")
+	if err != nil {
+		t.Fatalf("planPaperMerge: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, r := range plan.Refs {
+		got[r.ID] = r.Supersedes
+	}
+	want := map[string]string{"Keep": "Dup", "ByID": "Keep", "ByDOI": "Keep", "Unrelated": "Keep"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("supersedes after merge = %v, want %v", got, want)
+	}
+	if plan.Superseders != 2 {
+		t.Errorf("Superseders = %d, want 2", plan.Superseders)
+	}
+	if refs[2].Supersedes != "Dup" {
+		t.Error("input refs were modified")
+	}
+}
@@ -196,13 +196,13 @@ func runOpen(cmd *cobra.Command, args []string) error {
 
 // exitErrorSilent returns an error that signals the exit code without printing a message.
 type silentExitError struct {
-	code int
+	code ExitCode
 }
 
 func (e silentExitError) Error() string {
 	return ""
 }
 
-func exitErrorSilent(code int) error {
+func exitErrorSilent(code ExitCode) error {
 	return silentExitError{code: code}
 }
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -28,39 +31,192 @@ const (
 	DetailTextWrapWidth = 68 // Wider wrap for detail views
 )
 
-// outputJSON writes a value as formatted JSON to stdout.
+// outputJSON writes a value as JSON to stdout, indented with --pretty.
 func outputJSON(v interface{}) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
+	return writeJSON(os.Stdout, v)
+}
+
+// writeJSON writes a value as one line of compact JSON, or as indented JSON
+// with --pretty.
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	if prettyOutput {
+		enc.SetIndent("", "  ")
+	}
 	return enc.Encode(v)
 }
 
-// outputJSONCompact writes a value as compact JSON to stdout.
+// outputJSONCompact writes a value as compact JSON to stdout, even with
+// --pretty, for output that is one JSON object per line.
 func outputJSONCompact(v interface{}) error {
 	enc := json.NewEncoder(os.Stdout)
 	return enc.Encode(v)
 }
 
+// listItems produces the items of a list command in order, passing each to
+// yield as soon as it is available and stopping at the first error yield
+// returns. DB-backed lists wrap a storage Each query, so rows are handed on
+// as they are read; lists that are ranked or merged in memory use
+// sliceItems.
+type listItems[T any] func(yield func(T) error) error
+
+// sliceItems returns the listItems of a slice already in memory.
+func sliceItems[T any](items []T) listItems[T] {
+	return func(yield func(T) error) error {
+		for _, item := range items {
+			if err := yield(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// errListLimit stops a listItems once limitItems has passed on enough items.
+var errListLimit = errors.New("list limit reached")
+
+// limitItems passes on the first limit items (0 = no limit) and then stops
+// items without reading further.
+func limitItems[T any](items listItems[T], limit int) listItems[T] {
+	if limit <= 0 {
+		return items
+	}
+	return func(yield func(T) error) error {
+		n := 0
+		err := items(func(item T) error {
+			if err := yield(item); err != nil {
+				return err
+			}
+			n++
+			if n == limit {
+				return errListLimit
+			}
+			return nil
+		})
+		if errors.Is(err, errListLimit) {
+			return nil
+		}
+		return err
+	}
+}
+
+// filterItems passes on the items keep reports true for.
+func filterItems[T any](items listItems[T], keep func(T) bool) listItems[T] {
+	return func(yield func(T) error) error {
+		return items(func(item T) error {
+			if !keep(item) {
+				return nil
+			}
+			return yield(item)
+		})
+	}
+}
+
+// mapItems passes on each of items converted by f.
+func mapItems[T, U any](items listItems[T], f func(T) U) listItems[U] {
+	return func(yield func(U) error) error {
+		return items(func(item T) error {
+			return yield(f(item))
+		})
+	}
+}
+
+// collectItems returns all of items as a slice, empty rather than nil.
+func collectItems[T any](items listItems[T]) ([]T, error) {
+	all := []T{}
+	err := items(func(item T) error {
+		all = append(all, item)
+		return nil
+	})
+	return all, err
+}
+
+// outputList writes the JSON output of a list command. With --json-lines,
+// each item is written as its own compact JSON line as soon as items yields
+// it, for line-oriented tools like jq -c or grep; otherwise the items are
+// collected and full builds the result object written by outputJSON.
+func outputList[T any](items listItems[T], full func([]T) any) error {
+	if jsonLines {
+		return writeJSONLines(os.Stdout, items)
+	}
+	all, err := collectItems(items)
+	if err != nil {
+		return err
+	}
+	return outputJSON(full(all))
+}
+
+// writeJSONLines writes each item as one compact JSON object per line, as
+// items yields it.
+func writeJSONLines[T any](w io.Writer, items listItems[T]) error {
+	enc := json.NewEncoder(w)
+	return items(func(item T) error {
+		return enc.Encode(item)
+	})
+}
+
+// writeCSV writes a header row and then rows as CSV. Fields containing
+// commas, quotes, or newlines are quoted by encoding/csv; with no rows only
+// the header is written.
+func writeCSV(out io.Writer, header []string, rows [][]string) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// paperLinkCSVHeader is the header row written by concept papers and
+// project papers with --format csv.
+var paperLinkCSVHeader = []string{"paper_id", "via_concept", "relationship_type", "summary"}
+
+// validateFormatFlag exits with code unless a --format value is "json" or "csv".
+func validateFormatFlag(code ExitCode, format string) {
+	if format != "json" && format != "csv" {
+		exitWithError(code, "invalid --format %q: must be json or csv", format)
+	}
+}
+
 // outputHuman writes a human-readable string to stdout.
 func outputHuman(format string, args ...interface{}) {
 	fmt.Printf(format, args...)
 }
 
-// outputError writes an error message to stderr and returns the exit code.
-func outputError(code int, format string, args ...interface{}) int {
+// outputError writes an error message to stderr and returns the exit status.
+func outputError(code ExitCode, format string, args ...interface{}) int {
 	fmt.Fprintf(os.Stderr, "error: "+format+"\n", args...)
-	return code
+	return code.Status()
 }
 
 // exitWithError outputs an error in the appropriate format (human or JSON) and exits.
-func exitWithError(code int, format string, args ...interface{}) {
+// The JSON error_code is the error class of code.
+func exitWithError(code ExitCode, format string, args ...interface{}) {
+	exitWithErrorCode(code, code.ErrorCode(), format, args...)
+}
+
+// exitWithErrorCode is exitWithError with a more specific machine-readable
+// error code in the JSON output, so agents can tell this failure apart from
+// others in the same class.
+func exitWithErrorCode(code ExitCode, errorCode, format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 	if humanOutput {
 		fmt.Fprintf(os.Stderr, "error: %s\n", msg)
 	} else {
-		outputJSON(ErrorResponse{Error: msg})
+		outputJSON(ErrorResponse{Error: msg, ErrorCode: errorCode})
+	}
+	os.Exit(code.Status())
+}
+
+// exitWithResultStatus exits with code's status once a command has already
+// written its result, for outcomes such as failed checks that are reported
+// in the result itself rather than as an error message. ExitSuccess returns.
+func exitWithResultStatus(code ExitCode) {
+	if code != ExitSuccess {
+		os.Exit(code.Status())
 	}
-	os.Exit(code)
 }
 
 // StatusResponse is a generic response for commands that return status.
@@ -85,9 +241,21 @@ type UpdateResponse struct {
 
 // ErrorResponse is a JSON error response.
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error     string `json:"error"`
+	ErrorCode string `json:"error_code"` // Machine-readable cause: an ErrorCode* class or specific code
 }
 
+// Specific error codes for ErrorResponse.ErrorCode, reported by
+// exitWithErrorCode in place of the error class.
+const (
+	ErrorCodeOllamaUnavailable   = "ollama_unavailable"
+	ErrorCodeOpenAIUnavailable   = "openai_unavailable"
+	ErrorCodeDOINotFound         = "doi_not_found"
+	ErrorCodeCrossrefUnavailable = "crossref_unavailable"
+	ErrorCodeArXivNotFound       = "arxiv_not_found"
+	ErrorCodeArXivUnavailable    = "arxiv_unavailable"
+)
+
 // PaperSearchResult represents a paper in search results (semantic search and similar papers).
 type PaperSearchResult struct {
 	ID         string             `json:"id"`
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/edge"
+)
+
+func TestWriteJSON(t *testing.T) {
+	v := map[string]any{"id": "a", "tags": []string{"x", "y"}}
+	defer func(old bool) { prettyOutput = old }(prettyOutput)
+
+	var buf bytes.Buffer
+	prettyOutput = false
+	if err := writeJSON(&buf, v); err != nil {
+		t.Fatalf("writeJSON: %v", err)
+	}
+	compact := strings.TrimSuffix(buf.String(), "\n")
+	if strings.Contains(compact, "\n") {
+		t.Errorf("compact output has a newline: %q", buf.String())
+	}
+	if want := `{"id":"a","tags":["x","y"]}`; compact != want {
+		t.Errorf("compact = %q, want %q", compact, want)
+	}
+
+	buf.Reset()
+	prettyOutput = true
+	if err := writeJSON(&buf, v); err != nil {
+		t.Fatalf("writeJSON: %v", err)
+	}
+	pretty := strings.TrimSuffix(buf.String(), "\n")
+	if !strings.Contains(pretty, "\n  \"id\": \"a\"") {
+		t.Errorf("pretty output not indented: %q", buf.String())
+	}
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	type item struct {
+		ID   string `json:"id"`
+		Year int    `json:"year"`
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSONLines(&buf, sliceItems([]item{{"a", 2020}, {"b", 2021}})); err != nil {
+		t.Fatalf("writeJSONLines: %v", err)
+	}
+	want := "{\"id\":\"a\",\"year\":2020}\n{\"id\":\"b\",\"year\":2021}\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := writeJSONLines(&buf, sliceItems([]item(nil))); err != nil {
+		t.Fatalf("writeJSONLines: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("empty input wrote %q", buf.String())
+	}
+}
+
+func TestLimitAndFilterItems(t *testing.T) {
+	read := 0
+	items := listItems[int](func(yield func(int) error) error {
+		for i := 1; i <= 10; i++ {
+			read++
+			if err := yield(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	even := filterItems(items, func(n int) bool { return n%2 == 0 })
+	got, err := collectItems(limitItems(even, 2))
+	if err != nil {
+		t.Fatalf("collectItems: %v", err)
+	}
+	if len(got) != 2 || got[0] != 2 || got[1] != 4 {
+		t.Errorf("got %v, want [2 4]", got)
+	}
+	if read != 4 {
+		t.Errorf("read %d items, want 4 (no reading past the limit)", read)
+	}
+
+	got, err = collectItems(limitItems(items, 0))
+	if err != nil || len(got) != 10 {
+		t.Errorf("limit 0 = %v, %v; want all 10 items", got, err)
+	}
+	got, err = collectItems(limitItems(items, 20))
+	if err != nil || len(got) != 10 {
+		t.Errorf("limit 20 = %v, %v; want all 10 items", got, err)
+	}
+}
+
+func TestEdgeListLines_KeepDirection(t *testing.T) {
+	result := EdgeListResult{
+		PaperID:  "A",
+		Outgoing: []edge.Edge{{SourceID: "A", TargetID: "B", RelationshipType: "cites"}},
+		Incoming: []edge.Edge{{SourceID: "C", TargetID: "A", RelationshipType: "extends"}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSONLines(&buf, sliceItems(edgeListLines(result))); err != nil {
+		t.Fatalf("writeJSONLines: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"target_id":"B"`) || !strings.Contains(lines[0], `"direction":"outgoing"`) {
+		t.Errorf("first line = %s, want the outgoing edge", lines[0])
+	}
+	if !strings.Contains(lines[1], `"source_id":"C"`) || !strings.Contains(lines[1], `"direction":"incoming"`) {
+		t.Errorf("second line = %s, want the incoming edge", lines[1])
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	header := []string{"paper_id", "summary"}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, header, nil); err != nil {
+		t.Fatalf("writeCSV: %v", err)
+	}
+	if want := "paper_id,summary\n"; buf.String() != want {
+		t.Errorf("empty rows = %q, want only the header %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	rows := [][]string{{"Smith2024-ab", `uses "VI", twice`}, {"Lee2023-cd", "line one\nline two"}}
+	if err := writeCSV(&buf, header, rows); err != nil {
+		t.Fatalf("writeCSV: %v", err)
+	}
+	want := "paper_id,summary\nSmith2024-ab,\"uses \"\"VI\"\", twice\"\nLee2023-cd,\"line one\nline two\"\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
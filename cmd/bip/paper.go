@@ -7,11 +7,6 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// Exit codes for paper commands
-const (
-	ExitPaperNotFound = 2 // Paper not found
-)
-
 func init() {
 	rootCmd.AddCommand(paperCmd)
 
@@ -59,31 +54,36 @@ func runPaperConcepts(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get concepts
-	concepts, err := db.GetConceptsByPaper(paperID, relType)
+	items := listItems[storage.PaperConceptEdge](func(yield func(storage.PaperConceptEdge) error) error {
+		return db.GetConceptsByPaperEach(paperID, relType, yield)
+	})
+	if !humanOutput {
+		err := outputList(items, func(concepts []storage.PaperConceptEdge) any {
+			return PaperConceptsResult{
+				PaperID:  paperID,
+				Concepts: concepts,
+				Count:    len(concepts),
+			}
+		})
+		if err != nil {
+			exitWithError(ExitDataError, "querying concepts: %v", err)
+		}
+		return nil
+	}
+
+	concepts, err := collectItems(items)
 	if err != nil {
 		exitWithError(ExitDataError, "querying concepts: %v", err)
 	}
-
-	if humanOutput {
-		fmt.Printf("Concepts for paper: %s\n", paperID)
-		if len(concepts) == 0 {
-			fmt.Println("\n(no concepts)")
-		} else {
-			fmt.Print(formatEdgesGroupedByType(concepts, func(e storage.PaperConceptEdge) string {
-				return e.ConceptID
-			}))
-		}
-		fmt.Printf("\nTotal: %d concepts\n", len(concepts))
+	fmt.Printf("Concepts for paper: %s\n", paperID)
+	if len(concepts) == 0 {
+		fmt.Println("\n(no concepts)")
 	} else {
-		if concepts == nil {
-			concepts = []storage.PaperConceptEdge{}
-		}
-		outputJSON(PaperConceptsResult{
-			PaperID:  paperID,
-			Concepts: concepts,
-			Count:    len(concepts),
-		})
+		fmt.Print(formatEdgesGroupedByType(concepts, func(e storage.PaperConceptEdge) string {
+			return e.ConceptID
+		}))
 	}
+	fmt.Printf("\nTotal: %d concepts\n", len(concepts))
 
 	return nil
 }
@@ -14,12 +14,6 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// Exit codes for project commands (per CLI contract)
-const (
-	ExitProjectNotFound   = 2 // Project not found
-	ExitProjectValidation = 3 // Validation error (invalid ID, duplicate, has edges)
-)
-
 func init() {
 	rootCmd.AddCommand(projectCmd)
 
@@ -32,7 +26,9 @@ func init() {
 	// project get - no extra flags
 	projectCmd.AddCommand(projectGetCmd)
 
-	// project list - no extra flags
+	// project list flags
+	projectListCmd.Flags().Int("limit", 0, "Maximum projects to return (0 = all)")
+	projectListCmd.Flags().Int("offset", 0, "Skip this many projects (ordered by ID)")
 	projectCmd.AddCommand(projectListCmd)
 
 	// project update flags
@@ -51,7 +47,9 @@ func init() {
 	projectConceptsCmd.Flags().StringP("type", "t", "", "Filter by relationship type")
 	projectCmd.AddCommand(projectConceptsCmd)
 
-	// project papers - no extra flags
+	// project papers flags
+	projectPapersCmd.Flags().String("format", "json", "Output format: json or csv")
+	projectPapersCmd.Flags().Int("depth", 1, "Concept levels to include: 1 = the project's concepts, 2 = also their subconcepts, ...")
 	projectCmd.AddCommand(projectPapersCmd)
 }
 
@@ -115,15 +113,17 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 		exitWithError(ExitProjectValidation, "project with id %q already exists", projectID)
 	}
 
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexProjects, projectsPath)
+
 	// Append to JSONL
 	if err := storage.AppendProject(projectsPath, p); err != nil {
 		exitWithError(ExitDataError, "writing project: %v", err)
 	}
 
 	// Update SQLite index
-	db := mustOpenDatabase(repoRoot)
-	defer db.Close()
-	if _, err := db.RebuildProjectsFromJSONL(projectsPath); err != nil {
+	if err := db.ApplyIndexUpdate(storage.IndexProjects, projectsPath, indexCurrent, func() error { return db.UpsertProject(p) }); err != nil {
 		exitWithError(ExitDataError, "updating index: %v", err)
 	}
 
@@ -209,53 +209,82 @@ func runProjectGet(cmd *cobra.Command, args []string) error {
 }
 
 // ProjectListResult is the response for the project list command.
+// Count is the number returned, Total the number in the repository.
 type ProjectListResult struct {
 	Projects []project.Project `json:"projects"`
 	Count    int               `json:"count"`
+	Total    int               `json:"total"`
 }
 
 var projectListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all projects",
-	Long:  `List all project nodes in the knowledge graph.`,
-	RunE:  runProjectList,
+	Long: `List all project nodes in the knowledge graph, ordered by ID.
+
+Use --limit and --offset to page through them; the JSON output's count is
+the size of this page and total the number of projects.
+
+Examples:
+  bip project list --limit 20 --offset 20`,
+	RunE: runProjectList,
 }
 
 func runProjectList(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
 
+	limit, _ := cmd.Flags().GetInt("limit")
+	offset, _ := cmd.Flags().GetInt("offset")
+	if limit < 0 || offset < 0 {
+		exitWithError(ExitError, "--limit and --offset must not be negative")
+	}
+
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
 
-	projects, err := db.GetAllProjects()
+	total, err := db.CountProjects()
 	if err != nil {
-		exitWithError(ExitDataError, "querying projects: %v", err)
+		exitWithError(ExitDataError, "counting projects: %v", err)
+	}
+	items := listItems[project.Project](func(yield func(project.Project) error) error {
+		return db.GetProjectsPagedEach(limit, offset, yield)
+	})
+
+	if !humanOutput {
+		err := outputList(items, func(projects []project.Project) any {
+			return ProjectListResult{
+				Projects: projects,
+				Count:    len(projects),
+				Total:    total,
+			}
+		})
+		if err != nil {
+			exitWithError(ExitDataError, "querying projects: %v", err)
+		}
+		return nil
 	}
 
-	if humanOutput {
-		if len(projects) == 0 {
-			fmt.Println("No projects found")
-			return nil
+	projects, err := collectItems(items)
+	if err != nil {
+		exitWithError(ExitDataError, "querying projects: %v", err)
+	}
+	if len(projects) == 0 {
+		fmt.Println("No projects found")
+		return nil
+	}
+	for i, p := range projects {
+		if i > 0 {
+			fmt.Println()
 		}
-		for i, p := range projects {
-			if i > 0 {
-				fmt.Println()
-			}
-			fmt.Printf("Project: %s\n", p.ID)
-			fmt.Printf("Name:    %s\n", p.Name)
-			if p.Description != "" {
-				fmt.Printf("Desc:    %s\n", p.Description)
-			}
+		fmt.Printf("Project: %s\n", p.ID)
+		fmt.Printf("Name:    %s\n", p.Name)
+		if p.Description != "" {
+			fmt.Printf("Desc:    %s\n", p.Description)
 		}
-		fmt.Printf("\nTotal: %d projects\n", len(projects))
+	}
+	if len(projects) < total {
+		fmt.Printf("\nShowing %d-%d of %d projects\n", offset+1, offset+len(projects), total)
 	} else {
-		if projects == nil {
-			projects = []project.Project{}
-		}
-		outputJSON(ProjectListResult{
-			Projects: projects,
-			Count:    len(projects),
-		})
+		fmt.Printf("\nTotal: %d projects\n", len(projects))
 	}
 
 	return nil
@@ -316,15 +345,17 @@ func runProjectUpdate(cmd *cobra.Command, args []string) error {
 
 	projects[idx] = p
 
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexProjects, projectsPath)
+
 	// Write back
 	if err := storage.WriteAllProjects(projectsPath, projects); err != nil {
 		exitWithError(ExitDataError, "writing projects: %v", err)
 	}
 
 	// Update SQLite index
-	db := mustOpenDatabase(repoRoot)
-	defer db.Close()
-	if _, err := db.RebuildProjectsFromJSONL(projectsPath); err != nil {
+	if err := db.ApplyIndexUpdate(storage.IndexProjects, projectsPath, indexCurrent, func() error { return db.UpsertProject(p) }); err != nil {
 		exitWithError(ExitDataError, "updating index: %v", err)
 	}
 
@@ -356,6 +387,7 @@ type ProjectDeleteResult struct {
 // ProjectDeleteBlockedResult is the response when delete is blocked by edges or repos.
 type ProjectDeleteBlockedResult struct {
 	Error     string `json:"error"`
+	ErrorCode string `json:"error_code"`
 	EdgeCount int    `json:"edge_count"`
 	RepoCount int    `json:"repo_count"`
 }
@@ -379,7 +411,7 @@ func runProjectDelete(cmd *cobra.Command, args []string) error {
 	// Block if not force and has dependencies
 	if !force && (repoCount > 0 || edgeCount > 0) {
 		outputDeleteBlocked(projectID, repoCount, edgeCount)
-		os.Exit(ExitProjectValidation)
+		os.Exit(ExitProjectValidation.Status())
 	}
 
 	// Perform cascade delete
@@ -420,6 +452,7 @@ func outputDeleteBlocked(projectID string, repoCount, edgeCount int) {
 	} else {
 		outputJSON(ProjectDeleteBlockedResult{
 			Error:     fmt.Sprintf("project %q has %d repos and %d linked edges; use --force to delete anyway", projectID, repoCount, edgeCount),
+			ErrorCode: ExitProjectValidation.ErrorCode(),
 			EdgeCount: edgeCount,
 			RepoCount: repoCount,
 		})
@@ -587,45 +620,50 @@ func runProjectRepos(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get repos
-	repos, err := db.GetReposByProject(projectID)
+	items := listItems[repo.Repo](func(yield func(repo.Repo) error) error {
+		return db.GetReposByProjectEach(projectID, yield)
+	})
+	if !humanOutput {
+		err := outputList(items, func(repos []repo.Repo) any {
+			return ProjectReposResult{
+				ProjectID: projectID,
+				Repos:     repos,
+				Count:     len(repos),
+			}
+		})
+		if err != nil {
+			exitWithError(ExitDataError, "querying repos: %v", err)
+		}
+		return nil
+	}
+
+	repos, err := collectItems(items)
 	if err != nil {
 		exitWithError(ExitDataError, "querying repos: %v", err)
 	}
-
-	if humanOutput {
-		fmt.Printf("Repos for project: %s\n", projectID)
-		if len(repos) == 0 {
-			fmt.Println("\n(no repos)")
-		} else {
-			fmt.Println()
-			for _, r := range repos {
-				fmt.Printf("  %s (%s)\n", r.ID, r.Type)
-				if r.GitHubURL != "" {
-					fmt.Printf("    %s\n", r.GitHubURL)
+	fmt.Printf("Repos for project: %s\n", projectID)
+	if len(repos) == 0 {
+		fmt.Println("\n(no repos)")
+	} else {
+		fmt.Println()
+		for _, r := range repos {
+			fmt.Printf("  %s (%s)\n", r.ID, r.Type)
+			if r.GitHubURL != "" {
+				fmt.Printf("    %s\n", r.GitHubURL)
+			}
+			if r.Language != "" || len(r.Topics) > 0 {
+				parts := []string{}
+				if r.Language != "" {
+					parts = append(parts, r.Language)
 				}
-				if r.Language != "" || len(r.Topics) > 0 {
-					parts := []string{}
-					if r.Language != "" {
-						parts = append(parts, r.Language)
-					}
-					if len(r.Topics) > 0 {
-						parts = append(parts, strings.Join(r.Topics, ", "))
-					}
-					fmt.Printf("    %s\n", strings.Join(parts, " · "))
+				if len(r.Topics) > 0 {
+					parts = append(parts, strings.Join(r.Topics, ", "))
 				}
+				fmt.Printf("    %s\n", strings.Join(parts, " · "))
 			}
 		}
-		fmt.Printf("\nTotal: %d repos\n", len(repos))
-	} else {
-		if repos == nil {
-			repos = []repo.Repo{}
-		}
-		outputJSON(ProjectReposResult{
-			ProjectID: projectID,
-			Repos:     repos,
-			Count:     len(repos),
-		})
 	}
+	fmt.Printf("\nTotal: %d repos\n", len(repos))
 
 	return nil
 }
@@ -691,10 +729,12 @@ func runProjectConcepts(cmd *cobra.Command, args []string) error {
 		if concepts == nil {
 			concepts = []ProjectConceptEdge{}
 		}
-		outputJSON(ProjectConceptsResult{
-			ProjectID: projectID,
-			Concepts:  concepts,
-			Count:     len(concepts),
+		outputList(sliceItems(concepts), func(concepts []ProjectConceptEdge) any {
+			return ProjectConceptsResult{
+				ProjectID: projectID,
+				Concepts:  concepts,
+				Count:     len(concepts),
+			}
 		})
 	}
 
@@ -750,23 +790,44 @@ type ProjectPapersResult struct {
 
 // ProjectPaperEdge represents a paper linked to a project via a concept.
 type ProjectPaperEdge struct {
-	PaperID          string `json:"paper_id"`
-	ViaConcept       string `json:"via_concept"`
-	RelationshipType string `json:"relationship_type"`
-	Summary          string `json:"summary"`
+	PaperID          string   `json:"paper_id"`
+	ViaConcept       string   `json:"via_concept"`
+	ConceptPath      []string `json:"concept_path"` // From the project's concept down to ViaConcept
+	RelationshipType string   `json:"relationship_type"`
+	Summary          string   `json:"summary"`
 }
 
 var projectPapersCmd = &cobra.Command{
 	Use:   "papers <id>",
 	Short: "List papers relevant to a project (via concepts)",
-	Long:  `Query all papers linked to concepts that are linked to the project.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runProjectPapers,
+	Long: `Query all papers linked to concepts that are linked to the project.
+
+With --depth N > 1, the project's concepts are first expanded to narrower
+concepts up to N-1 subconcept-of hops below them (see 'bip concept tree'),
+and papers are gathered across the expanded set. Each paper's concept_path
+shows the chain from the project's concept to the one it is linked to.
+
+With --format csv, the papers are written as CSV with a header row
+(paper_id, via_concept, relationship_type, summary), e.g. to share a
+reading list with collaborators.
+
+Examples:
+  bip project papers dasm2
+  bip project papers dasm2 --format csv > reading.csv
+  bip project papers dasm2 --depth 3 --human`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectPapers,
 }
 
 func runProjectPapers(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
 	projectID := args[0]
+	depth, _ := cmd.Flags().GetInt("depth")
+	if depth < 1 {
+		exitWithError(ExitProjectValidation, "--depth must be at least 1")
+	}
+	format, _ := cmd.Flags().GetString("format")
+	validateFormatFlag(ExitProjectValidation, format)
 
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
@@ -781,11 +842,22 @@ func runProjectPapers(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get papers transitively via concepts
-	papers, err := getPapersForProjectTransitive(repoRoot, projectID)
+	papers, err := getPapersForProjectTransitive(repoRoot, projectID, depth)
 	if err != nil {
 		exitWithError(ExitDataError, "querying papers: %v", err)
 	}
 
+	if format == "csv" {
+		rows := make([][]string, len(papers))
+		for i, pe := range papers {
+			rows[i] = []string{pe.PaperID, pe.ViaConcept, pe.RelationshipType, pe.Summary}
+		}
+		if err := writeCSV(os.Stdout, paperLinkCSVHeader, rows); err != nil {
+			exitWithError(ExitDataError, "writing CSV: %v", err)
+		}
+		return nil
+	}
+
 	if humanOutput {
 		fmt.Printf("Papers relevant to project: %s (via concepts)\n", projectID)
 		if len(papers) == 0 {
@@ -794,7 +866,11 @@ func runProjectPapers(cmd *cobra.Command, args []string) error {
 			fmt.Println()
 			for _, pe := range papers {
 				fmt.Printf("  %s\n", pe.PaperID)
-				fmt.Printf("    via %s --[%s]--> paper\n", pe.ViaConcept, pe.RelationshipType)
+				if len(pe.ConceptPath) > 1 {
+					fmt.Printf("    via %s --[%s]--> paper\n", strings.Join(pe.ConceptPath, " > "), pe.RelationshipType)
+				} else {
+					fmt.Printf("    via %s --[%s]--> paper\n", pe.ViaConcept, pe.RelationshipType)
+				}
 				fmt.Printf("    %q\n", pe.Summary)
 			}
 		}
@@ -803,18 +879,22 @@ func runProjectPapers(cmd *cobra.Command, args []string) error {
 		if papers == nil {
 			papers = []ProjectPaperEdge{}
 		}
-		outputJSON(ProjectPapersResult{
-			ProjectID: projectID,
-			Papers:    papers,
-			Count:     len(papers),
+		outputList(sliceItems(papers), func(papers []ProjectPaperEdge) any {
+			return ProjectPapersResult{
+				ProjectID: projectID,
+				Papers:    papers,
+				Count:     len(papers),
+			}
 		})
 	}
 
 	return nil
 }
 
-// getPapersForProjectTransitive finds papers via: project ← concepts ← papers
-func getPapersForProjectTransitive(repoRoot, projectID string) ([]ProjectPaperEdge, error) {
+// getPapersForProjectTransitive finds papers via: project ← concepts ← papers.
+// With depth > 1, the project's concepts are first expanded to their
+// narrower concepts up to depth-1 subconcept-of hops.
+func getPapersForProjectTransitive(repoRoot, projectID string, depth int) ([]ProjectPaperEdge, error) {
 	// Step 1: Find all concepts linked to this project
 	concepts, err := getConceptsForProject(repoRoot, projectID, "")
 	if err != nil {
@@ -825,11 +905,11 @@ func getPapersForProjectTransitive(repoRoot, projectID string) ([]ProjectPaperEd
 		return nil, nil
 	}
 
-	// Build set of concept IDs (keeping concept: prefix for edge lookup)
-	// Since paper→concept edges now use prefixed targets like "concept:vi"
-	conceptIDs := make(map[string]bool)
+	// Concept IDs keep the concept: prefix for edge lookup,
+	// since paper→concept edges use prefixed targets like "concept:vi"
+	var roots []string
 	for _, c := range concepts {
-		conceptIDs[c.ConceptID] = true
+		roots = append(roots, c.ConceptID)
 	}
 
 	// Step 2: Find all papers linked to those concepts
@@ -839,28 +919,77 @@ func getPapersForProjectTransitive(repoRoot, projectID string) ([]ProjectPaperEd
 		return nil, err
 	}
 
+	return collectProjectPapers(roots, edges, depth), nil
+}
+
+// expandConceptPaths walks down relType concept→concept edges (child →
+// parent, like subconcept-of) from the root concepts (prefixed IDs) for up
+// to depth-1 hops, breadth-first; depth 0 means no limit. It returns the
+// shortest concept path from a root to every concept reached; roots map to
+// themselves. Concepts already reached are not revisited, so cycles terminate.
+func expandConceptPaths(roots []string, edges []edge.Edge, relType string, depth int) map[string][]string {
+	children := make(map[string][]string)
+	for _, e := range edges {
+		if e.RelationshipType == relType &&
+			strings.HasPrefix(e.SourceID, "concept:") && strings.HasPrefix(e.TargetID, "concept:") {
+			children[e.TargetID] = append(children[e.TargetID], e.SourceID)
+		}
+	}
+
+	paths := make(map[string][]string)
+	frontier := []string{}
+	for _, r := range roots {
+		if _, ok := paths[r]; !ok {
+			paths[r] = []string{r}
+			frontier = append(frontier, r)
+		}
+	}
+	for hop := 1; (depth == 0 || hop < depth) && len(frontier) > 0; hop++ {
+		var next []string
+		for _, c := range frontier {
+			for _, child := range children[c] {
+				if _, ok := paths[child]; ok {
+					continue
+				}
+				paths[child] = append(append([]string(nil), paths[c]...), child)
+				next = append(next, child)
+			}
+		}
+		frontier = next
+	}
+	return paths
+}
+
+// collectProjectPapers returns the papers linked to any concept within depth
+// of the root concepts, one entry per paper+concept pair, in edge order.
+func collectProjectPapers(roots []string, edges []edge.Edge, depth int) []ProjectPaperEdge {
+	paths := expandConceptPaths(roots, edges, defaultConceptTreeType, depth)
+
 	var results []ProjectPaperEdge
 	seen := make(map[string]bool) // Deduplicate paper+concept combinations
 
 	for _, e := range edges {
 		// Paper→concept edges have unprefixed paper ID as source and "concept:X" as target
-		// Check if target is one of our concepts
-		if conceptIDs[e.TargetID] {
-			// Source should be a paper (not prefixed with concept: or project:)
-			if !strings.Contains(e.SourceID, ":") {
-				key := e.SourceID + "|" + e.TargetID
-				if !seen[key] {
-					seen[key] = true
-					results = append(results, ProjectPaperEdge{
-						PaperID:          e.SourceID,
-						ViaConcept:       e.TargetID, // Already prefixed with "concept:"
-						RelationshipType: e.RelationshipType,
-						Summary:          e.Summary,
-					})
-				}
-			}
+		path, ok := paths[e.TargetID]
+		if !ok {
+			continue
+		}
+		// Source should be a paper (not prefixed with concept: or project:)
+		if strings.Contains(e.SourceID, ":") {
+			continue
+		}
+		key := e.SourceID + "|" + e.TargetID
+		if !seen[key] {
+			seen[key] = true
+			results = append(results, ProjectPaperEdge{
+				PaperID:          e.SourceID,
+				ViaConcept:       e.TargetID, // Already prefixed with "concept:"
+				ConceptPath:      path,
+				RelationshipType: e.RelationshipType,
+				Summary:          e.Summary,
+			})
 		}
 	}
 
-	return results, nil
+	return results
 }
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/github"
+	"github.com/matsen/bipartite/internal/project"
+	"github.com/matsen/bipartite/internal/repo"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	projectExportCmd.Flags().String("out", "", "Write the config to this file instead of stdout")
+	projectCmd.AddCommand(projectExportCmd)
+}
+
+var projectExportCmd = &cobra.Command{
+	Use:   "export [ids...]",
+	Short: "Export projects as a project import config",
+	Long: `Write projects in the YAML config format read by 'bip project import',
+e.g. to keep the project layout under version control or share it.
+
+Each project gets its name, its GitHub repos as org/repo, and the concepts
+linked to it by applied-in edges (the edges 'bip project import
+--link-concepts' creates). Manual repos and other concept→project
+relationship types have no place in the format and are left out, with a
+note on stderr.
+
+Importing the output into the same repository with --dry-run reports
+every project, repo, and edge as skipped.
+
+Give project IDs to export only those; by default all are exported.
+
+Examples:
+  bip project export > projects.yml
+  bip project export dasm2 --out dasm2.yml
+  bip project import projects.yml --link-concepts --dry-run`,
+	RunE: runProjectExport,
+}
+
+func runProjectExport(cmd *cobra.Command, args []string) error {
+	repoRoot := mustFindRepository()
+	outPath, _ := cmd.Flags().GetString("out")
+
+	projects, err := storage.ReadAllProjects(config.ProjectsPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading projects: %v", err)
+	}
+	repos, err := storage.ReadAllRepos(config.ReposPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading repos: %v", err)
+	}
+	edges, err := storage.ReadAllEdges(config.EdgesPath(repoRoot))
+	if err != nil {
+		exitWithError(ExitDataError, "reading edges: %v", err)
+	}
+
+	configs, notes, err := buildProjectConfigs(projects, repos, edges, args)
+	if err != nil {
+		exitWithError(ExitProjectNotFound, "%v", err)
+	}
+	for _, note := range notes {
+		fmt.Fprintf(os.Stderr, "note: %s\n", note)
+	}
+
+	data, err := encodeProjectConfigs(configs)
+	if err != nil {
+		exitWithError(ExitError, "encoding config: %v", err)
+	}
+
+	if outPath == "" {
+		os.Stdout.Write(data)
+		return nil
+	}
+
+	absPath, err := filepath.Abs(outPath)
+	if err != nil {
+		exitWithError(ExitError, "resolving path: %v", err)
+	}
+	if err := os.WriteFile(absPath, data, 0644); err != nil {
+		exitWithError(ExitError, "cannot write to file: %s\n  Hint: Check file has write permissions", outPath)
+	}
+	result := ExportResult{Exported: len(configs), OutputPath: absPath}
+	if humanOutput {
+		fmt.Printf("Exported %d projects to %s\n", result.Exported, result.OutputPath)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// encodeProjectConfigs writes configs as YAML with the two-space indent
+// used in project import examples.
+func encodeProjectConfigs(configs map[string]ProjectConfig) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(configs); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// buildProjectConfigs converts projects, with their GitHub repos and
+// applied-in concept links, into the project import config format. With
+// ids, only those projects are included; an unknown ID is an error. The
+// returned notes describe data the format cannot represent.
+func buildProjectConfigs(projects []project.Project, repos []repo.Repo, edges []edge.Edge, ids []string) (map[string]ProjectConfig, []string, error) {
+	selected := make(map[string]bool)
+	for _, p := range projects {
+		selected[p.ID] = len(ids) == 0
+	}
+	for _, id := range ids {
+		if _, ok := selected[id]; !ok {
+			return nil, nil, fmt.Errorf("project %q not found", id)
+		}
+		selected[id] = true
+	}
+
+	configs := make(map[string]ProjectConfig)
+	for _, p := range projects {
+		if selected[p.ID] {
+			configs[p.ID] = ProjectConfig{Name: p.Name}
+		}
+	}
+
+	var notes []string
+	for _, r := range repos {
+		cfg, ok := configs[r.Project]
+		if !ok {
+			continue
+		}
+		owner, name, err := github.ParseGitHubURL(r.GitHubURL)
+		if r.Type != repo.TypeGitHub || err != nil {
+			notes = append(notes, fmt.Sprintf("repo %q in project %q is not a GitHub repo; not exported", r.ID, r.Project))
+			continue
+		}
+		cfg.Repos = append(cfg.Repos, owner+"/"+name)
+		configs[r.Project] = cfg
+	}
+
+	for _, e := range edges {
+		if !strings.HasPrefix(e.SourceID, "concept:") || !strings.HasPrefix(e.TargetID, "project:") {
+			continue
+		}
+		projectID := strings.TrimPrefix(e.TargetID, "project:")
+		cfg, ok := configs[projectID]
+		if !ok {
+			continue
+		}
+		conceptID := strings.TrimPrefix(e.SourceID, "concept:")
+		if e.RelationshipType != projectImportEdgeType {
+			notes = append(notes, fmt.Sprintf("%s edge from concept %q to project %q is not exported", e.RelationshipType, conceptID, projectID))
+			continue
+		}
+		cfg.Concepts = append(cfg.Concepts, conceptID)
+		configs[projectID] = cfg
+	}
+	for id, cfg := range configs {
+		sort.Strings(cfg.Concepts)
+		configs[id] = cfg
+	}
+
+	return configs, notes, nil
+}
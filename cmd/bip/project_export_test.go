@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/project"
+	"github.com/matsen/bipartite/internal/repo"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildProjectConfigs(t *testing.T) {
+	projects := []project.Project{
+		{ID: "dasm", Name: "DASM"},
+		{ID: "other", Name: "Other"},
+	}
+	repos := []repo.Repo{
+		{ID: "netam", Project: "dasm", Type: repo.TypeGitHub, GitHubURL: "https://github.com/matsengrp/netam"},
+		{ID: "tools", Project: "dasm", Type: repo.TypeManual, Name: "Tools"},
+		{ID: "bipartite", Project: "other", Type: repo.TypeGitHub, GitHubURL: "https://github.com/matsen/bipartite"},
+	}
+	edges := []edge.Edge{
+		{SourceID: "concept:shm", TargetID: "project:dasm", RelationshipType: "applied-in"},
+		{SourceID: "concept:fitness", TargetID: "project:dasm", RelationshipType: "applied-in"},
+		{SourceID: "concept:bcr", TargetID: "project:dasm", RelationshipType: "studied-by"},
+		{SourceID: "Smith2024-ab", TargetID: "concept:shm", RelationshipType: "introduces"},
+	}
+
+	configs, notes, err := buildProjectConfigs(projects, repos, edges, []string{"dasm"})
+	if err != nil {
+		t.Fatalf("buildProjectConfigs() error = %v", err)
+	}
+	want := map[string]ProjectConfig{
+		"dasm": {
+			Name:     "DASM",
+			Repos:    []string{"matsengrp/netam"},
+			Concepts: []string{"fitness", "shm"},
+		},
+	}
+	if !reflect.DeepEqual(configs, want) {
+		t.Errorf("configs = %+v, want %+v", configs, want)
+	}
+	if len(notes) != 2 {
+		t.Errorf("notes = %v, want one for the manual repo and one for the studied-by edge", notes)
+	}
+
+	// The encoded config parses back as a project import config
+	data, err := encodeProjectConfigs(configs)
+	if err != nil {
+		t.Fatalf("encodeProjectConfigs() error = %v", err)
+	}
+	var parsed map[string]ProjectConfig
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("parsing exported config: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, want) {
+		t.Errorf("round trip = %+v, want %+v", parsed, want)
+	}
+
+	if _, _, err := buildProjectConfigs(projects, repos, edges, []string{"missing"}); err == nil {
+		t.Error("buildProjectConfigs() with unknown ID: expected error")
+	}
+	all, _, _ := buildProjectConfigs(projects, repos, edges, nil)
+	if len(all) != 2 {
+		t.Errorf("without ids got %d projects, want 2", len(all))
+	}
+}
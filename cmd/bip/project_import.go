@@ -4,24 +4,28 @@ import (
 	"fmt"
 	"os"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/matsen/bipartite/internal/config"
 	"github.com/matsen/bipartite/internal/edge"
 	"github.com/matsen/bipartite/internal/github"
 	"github.com/matsen/bipartite/internal/project"
-	"github.com/matsen/bipartite/internal/repo"
 	"github.com/matsen/bipartite/internal/storage"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
+// projectImportEdgeType is the concept→project relationship that
+// --link-concepts creates, and so the one 'bip project export' writes
+// back as a project's concepts.
+const projectImportEdgeType = "applied-in"
+
 func init() {
 	// project import flags
 	projectImportCmd.Flags().Bool("link-concepts", false, "Create concept↔project edges for listed concepts")
 	projectImportCmd.Flags().Bool("dry-run", false, "Show what would be created without making changes")
 	projectImportCmd.Flags().Bool("no-fetch", false, "Skip GitHub metadata fetch (create repos with minimal data)")
+	projectImportCmd.Flags().Bool("no-cache", false, "Fetch GitHub metadata fresh instead of from the local cache")
 	projectCmd.AddCommand(projectImportCmd)
 }
 
@@ -110,6 +114,7 @@ func runProjectImport(cmd *cobra.Command, args []string) error {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	linkConcepts, _ := cmd.Flags().GetBool("link-concepts")
 	noFetch, _ := cmd.Flags().GetBool("no-fetch")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
 
 	// Read and parse config file
 	data, err := os.ReadFile(configPath)
@@ -158,15 +163,6 @@ func runProjectImport(cmd *cobra.Command, args []string) error {
 		existingProjectIDs[p.ID] = true
 	}
 
-	existingRepoIDs := make(map[string]bool)
-	existingRepoURLs := make(map[string]bool)
-	for _, r := range existingRepos {
-		existingRepoIDs[r.ID] = true
-		if r.GitHubURL != "" {
-			existingRepoURLs[r.GitHubURL] = true
-		}
-	}
-
 	existingEdgeKeys := make(map[edge.EdgeKey]bool)
 	for _, e := range existingEdges {
 		existingEdgeKeys[e.Key()] = true
@@ -183,11 +179,14 @@ func runProjectImport(cmd *cobra.Command, args []string) error {
 	}
 
 	var newProjects []project.Project
-	var newRepos []repo.Repo
 	var newEdges []edge.Edge
 
 	now := time.Now().UTC().Format(time.RFC3339)
-	ghClient := github.NewClient()
+	var ghClient *github.Client
+	if !noFetch {
+		ghClient = newGitHubClient(githubCacheOption(repoRoot, noCache))
+	}
+	repos := newRepoBatch(existingRepos, ghClient, noFetch, now)
 
 	// Sort project IDs for deterministic output
 	projectIDs := make([]string, 0, len(projectConfigs))
@@ -238,9 +237,7 @@ func runProjectImport(cmd *cobra.Command, args []string) error {
 
 		// Process repos
 		for _, repoSpec := range cfg.Repos {
-			repoAction := processRepoImport(repoSpec, projectID, now, ghClient, noFetch,
-				existingRepoIDs, existingRepoURLs, &newRepos, result)
-			result.Details.Repos = append(result.Details.Repos, repoAction)
+			result.Details.Repos = append(result.Details.Repos, repos.add(repoSpec, projectID))
 		}
 
 		// Process concept edges if requested
@@ -253,6 +250,10 @@ func runProjectImport(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	newRepos := repos.New
+	result.ReposCreated, result.ReposSkipped, result.ReposFailed = repos.Created, repos.Skipped, repos.Failed
+	result.Warnings = append(result.Warnings, repos.Warnings...)
+
 	// Handle dry run
 	if dryRun {
 		result.Status = "dry_run"
@@ -312,158 +313,6 @@ func runProjectImport(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// processRepoImport handles importing a single repo.
-func processRepoImport(repoSpec, projectID, now string, ghClient *github.Client, noFetch bool,
-	existingRepoIDs, existingRepoURLs map[string]bool, newRepos *[]repo.Repo, result *ProjectImportResult) RepoImportAction {
-
-	// Normalize GitHub URL
-	normalizedURL, err := github.NormalizeGitHubURL(repoSpec)
-	if err != nil {
-		result.ReposFailed++
-		return RepoImportAction{
-			ID:        repoSpec,
-			ProjectID: projectID,
-			Action:    "failed",
-			Reason:    fmt.Sprintf("invalid GitHub URL: %v", err),
-		}
-	}
-
-	// Derive repo ID
-	repoID, err := github.DeriveRepoID(repoSpec)
-	if err != nil {
-		result.ReposFailed++
-		return RepoImportAction{
-			ID:        repoSpec,
-			ProjectID: projectID,
-			GitHubURL: normalizedURL,
-			Action:    "failed",
-			Reason:    fmt.Sprintf("cannot derive repo ID: %v", err),
-		}
-	}
-
-	// Check if repo URL already exists
-	if existingRepoURLs[normalizedURL] {
-		result.ReposSkipped++
-		return RepoImportAction{
-			ID:        repoID,
-			ProjectID: projectID,
-			GitHubURL: normalizedURL,
-			Action:    "skipped",
-			Reason:    "GitHub URL already exists",
-		}
-	}
-
-	// Handle ID collision - append project ID to make unique
-	originalID := repoID
-	if existingRepoIDs[repoID] {
-		repoID = fmt.Sprintf("%s-%s", projectID, repoID)
-		// If still collision, use full org-repo format
-		if existingRepoIDs[repoID] {
-			// ParseGitHubURL won't fail here since NormalizeGitHubURL already succeeded
-			owner, repoName, _ := github.ParseGitHubURL(repoSpec)
-			if owner != "" && repoName != "" {
-				repoID = strings.ToLower(fmt.Sprintf("%s-%s", owner, repoName))
-			}
-		}
-	}
-
-	var r repo.Repo
-
-	if noFetch {
-		// Create minimal repo without GitHub metadata
-		r = repo.Repo{
-			ID:        repoID,
-			Project:   projectID,
-			Type:      repo.TypeGitHub,
-			Name:      originalID, // Use repo name as display name
-			GitHubURL: normalizedURL,
-			CreatedAt: now,
-			UpdatedAt: now,
-		}
-	} else {
-		// Fetch metadata from GitHub
-		meta, err := ghClient.FetchRepoMetadata(repoSpec)
-		if err != nil {
-			switch err {
-			case github.ErrRepoNotFound:
-				result.ReposFailed++
-				return RepoImportAction{
-					ID:        repoID,
-					ProjectID: projectID,
-					GitHubURL: normalizedURL,
-					Action:    "failed",
-					Reason:    "GitHub repository not found",
-				}
-			case github.ErrRateLimited:
-				result.ReposFailed++
-				result.Warnings = append(result.Warnings, "GitHub rate limit exceeded; try --no-fetch or set BIP_GITHUB_TOKEN (or GITHUB_TOKEN / GH_TOKEN)")
-				return RepoImportAction{
-					ID:        repoID,
-					ProjectID: projectID,
-					GitHubURL: normalizedURL,
-					Action:    "failed",
-					Reason:    "GitHub rate limit exceeded",
-				}
-			case github.ErrUnauthorized:
-				result.ReposFailed++
-				return RepoImportAction{
-					ID:        repoID,
-					ProjectID: projectID,
-					GitHubURL: normalizedURL,
-					Action:    "failed",
-					Reason:    "GitHub authentication failed",
-				}
-			default:
-				result.ReposFailed++
-				return RepoImportAction{
-					ID:        repoID,
-					ProjectID: projectID,
-					GitHubURL: normalizedURL,
-					Action:    "failed",
-					Reason:    fmt.Sprintf("GitHub API error: %v", err),
-				}
-			}
-		}
-
-		r = repo.Repo{
-			ID:          repoID,
-			Project:     projectID,
-			Type:        repo.TypeGitHub,
-			Name:        meta.Name,
-			GitHubURL:   normalizedURL,
-			Description: meta.Description,
-			Topics:      meta.Topics,
-			Language:    meta.Language,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
-	}
-
-	// Validate repo
-	if err := r.ValidateForCreate(); err != nil {
-		result.ReposFailed++
-		return RepoImportAction{
-			ID:        repoID,
-			ProjectID: projectID,
-			GitHubURL: normalizedURL,
-			Action:    "failed",
-			Reason:    fmt.Sprintf("invalid repo: %v", err),
-		}
-	}
-
-	*newRepos = append(*newRepos, r)
-	existingRepoIDs[repoID] = true
-	existingRepoURLs[normalizedURL] = true
-	result.ReposCreated++
-
-	return RepoImportAction{
-		ID:        repoID,
-		ProjectID: projectID,
-		GitHubURL: normalizedURL,
-		Action:    "created",
-	}
-}
-
 // processEdgeImport handles creating a concept↔project edge.
 func processEdgeImport(conceptID, projectID, now string,
 	conceptIDs map[string]bool, existingEdgeKeys map[edge.EdgeKey]bool, newEdges *[]edge.Edge, result *ProjectImportResult) EdgeImportAction {
@@ -483,7 +332,7 @@ func processEdgeImport(conceptID, projectID, now string,
 	e := edge.Edge{
 		SourceID:         "concept:" + conceptID,
 		TargetID:         "project:" + projectID,
-		RelationshipType: "applied-in",
+		RelationshipType: projectImportEdgeType,
 		Summary:          fmt.Sprintf("Concept %s is applied in project %s", conceptID, projectID),
 		CreatedAt:        now,
 	}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/edge"
+)
+
+func TestCollectProjectPapers(t *testing.T) {
+	edges := []edge.Edge{
+		{SourceID: "concept:vae", TargetID: "concept:ml", RelationshipType: "subconcept-of"},
+		{SourceID: "concept:beta-vae", TargetID: "concept:vae", RelationshipType: "subconcept-of"},
+		{SourceID: "concept:ml", TargetID: "concept:beta-vae", RelationshipType: "subconcept-of"}, // cycle
+		{SourceID: "concept:gan", TargetID: "concept:ml", RelationshipType: "related-to"},
+		{SourceID: "PaperML", TargetID: "concept:ml", RelationshipType: "applies"},
+		{SourceID: "PaperVAE", TargetID: "concept:vae", RelationshipType: "introduces"},
+		{SourceID: "PaperBeta", TargetID: "concept:beta-vae", RelationshipType: "introduces"},
+		{SourceID: "PaperGAN", TargetID: "concept:gan", RelationshipType: "introduces"},
+		{SourceID: "project:p", TargetID: "concept:vae", RelationshipType: "implements"},
+	}
+
+	tests := []struct {
+		depth int
+		want  map[string][]string // paper ID -> concept path
+	}{
+		{1, map[string][]string{
+			"PaperML": {"concept:ml"},
+		}},
+		{2, map[string][]string{
+			"PaperML":  {"concept:ml"},
+			"PaperVAE": {"concept:ml", "concept:vae"},
+		}},
+		{5, map[string][]string{
+			"PaperML":   {"concept:ml"},
+			"PaperVAE":  {"concept:ml", "concept:vae"},
+			"PaperBeta": {"concept:ml", "concept:vae", "concept:beta-vae"},
+		}},
+	}
+
+	for _, tt := range tests {
+		got := make(map[string][]string)
+		for _, p := range collectProjectPapers([]string{"concept:ml"}, edges, tt.depth) {
+			if p.ViaConcept != p.ConceptPath[len(p.ConceptPath)-1] {
+				t.Errorf("depth %d: %s via %s but path ends at %v", tt.depth, p.PaperID, p.ViaConcept, p.ConceptPath)
+			}
+			got[p.PaperID] = p.ConceptPath
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("depth %d: got %v, want %v", tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestCollectProjectPapersShortestPath(t *testing.T) {
+	// beta-vae is reachable from root "ml" via vae, but is also a root itself
+	edges := []edge.Edge{
+		{SourceID: "concept:vae", TargetID: "concept:ml", RelationshipType: "subconcept-of"},
+		{SourceID: "concept:beta-vae", TargetID: "concept:vae", RelationshipType: "subconcept-of"},
+		{SourceID: "PaperBeta", TargetID: "concept:beta-vae", RelationshipType: "introduces"},
+	}
+
+	got := collectProjectPapers([]string{"concept:ml", "concept:beta-vae"}, edges, 3)
+	if len(got) != 1 {
+		t.Fatalf("got %d papers, want 1", len(got))
+	}
+	if want := []string{"concept:beta-vae"}; !reflect.DeepEqual(got[0].ConceptPath, want) {
+		t.Errorf("ConceptPath = %v, want %v", got[0].ConceptPath, want)
+	}
+}
+
+func TestCountDistinctPapers(t *testing.T) {
+	edges := []ProjectPaperEdge{
+		{PaperID: "A", ViaConcept: "concept:x"},
+		{PaperID: "A", ViaConcept: "concept:y"},
+		{PaperID: "B", ViaConcept: "concept:x"},
+	}
+	if got := countDistinctPapers(edges); got != 2 {
+		t.Errorf("countDistinctPapers = %d, want 2", got)
+	}
+	if got := countDistinctPapers(nil); got != 0 {
+		t.Errorf("countDistinctPapers(nil) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/project"
+	"github.com/matsen/bipartite/internal/repo"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	projectSummaryCmd.Flags().Int("depth", 1, "Concept levels for the paper count, as in 'project papers --depth'")
+	projectCmd.AddCommand(projectSummaryCmd)
+}
+
+var projectSummaryCmd = &cobra.Command{
+	Use:   "summary <id>",
+	Short: "Show everything about a project at once",
+	Long: `Summarize a project in one response: its metadata, its repos, the
+concepts linked to it directly, and how many papers are reachable through
+those concepts (see 'bip project papers').
+
+Examples:
+  bip project summary dasm2 --human
+  bip project summary dasm2 --depth 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectSummary,
+}
+
+// ProjectSummaryResult is the response for the project summary command.
+type ProjectSummaryResult struct {
+	Project      project.Project      `json:"project"`
+	RepoCount    int                  `json:"repo_count"`
+	Repos        []repo.Repo          `json:"repos"`
+	ConceptCount int                  `json:"concept_count"`
+	Concepts     []ProjectConceptEdge `json:"concepts"`
+	PaperCount   int                  `json:"paper_count"` // Distinct papers reachable via concepts
+	Depth        int                  `json:"depth"`
+}
+
+func runProjectSummary(cmd *cobra.Command, args []string) error {
+	repoRoot := mustFindRepository()
+	projectID := args[0]
+	depth, _ := cmd.Flags().GetInt("depth")
+	if depth < 1 {
+		exitWithError(ExitProjectValidation, "--depth must be at least 1")
+	}
+
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	p, err := db.GetProjectByID(projectID)
+	if err != nil {
+		exitWithError(ExitDataError, "querying project: %v", err)
+	}
+	if p == nil {
+		exitWithError(ExitProjectNotFound, "project %q not found", projectID)
+	}
+
+	repos, err := db.GetReposByProject(projectID)
+	if err != nil {
+		exitWithError(ExitDataError, "querying repos: %v", err)
+	}
+	concepts, err := getConceptsForProject(repoRoot, projectID, "")
+	if err != nil {
+		exitWithError(ExitDataError, "querying concepts: %v", err)
+	}
+	papers, err := getPapersForProjectTransitive(repoRoot, projectID, depth)
+	if err != nil {
+		exitWithError(ExitDataError, "querying papers: %v", err)
+	}
+
+	if repos == nil {
+		repos = []repo.Repo{}
+	}
+	if concepts == nil {
+		concepts = []ProjectConceptEdge{}
+	}
+	result := ProjectSummaryResult{
+		Project:      *p,
+		RepoCount:    len(repos),
+		Repos:        repos,
+		ConceptCount: len(concepts),
+		Concepts:     concepts,
+		PaperCount:   countDistinctPapers(papers),
+		Depth:        depth,
+	}
+
+	if humanOutput {
+		printProjectSummary(result)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// countDistinctPapers counts the papers in edges, which list a paper once
+// per concept it is reached through.
+func countDistinctPapers(edges []ProjectPaperEdge) int {
+	seen := make(map[string]bool)
+	for _, e := range edges {
+		seen[e.PaperID] = true
+	}
+	return len(seen)
+}
+
+func printProjectSummary(result ProjectSummaryResult) {
+	p := result.Project
+	fmt.Printf("Project: %s (%s)\n", p.ID, p.Name)
+	if p.Description != "" {
+		fmt.Printf("  %s\n", p.Description)
+	}
+
+	fmt.Printf("\nRepos (%d):\n", result.RepoCount)
+	if result.RepoCount == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, r := range result.Repos {
+		if r.GitHubURL != "" {
+			fmt.Printf("  %s  %s\n", r.ID, r.GitHubURL)
+		} else {
+			fmt.Printf("  %s\n", r.ID)
+		}
+	}
+
+	fmt.Printf("\nConcepts (%d):\n", result.ConceptCount)
+	if result.ConceptCount == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, c := range result.Concepts {
+		fmt.Printf("  %s [%s]\n", strings.TrimPrefix(c.ConceptID, "concept:"), c.RelationshipType)
+	}
+
+	via := "via its concepts"
+	if result.Depth > 1 {
+		via = fmt.Sprintf("via its concepts, %d levels deep", result.Depth)
+	}
+	fmt.Printf("\nPapers: %d %s (see 'bip project papers %s')\n", result.PaperCount, via, p.ID)
+}
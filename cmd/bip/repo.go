@@ -12,14 +12,6 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// Exit codes for repo commands (per CLI contract)
-const (
-	ExitRepoNotFound    = 2 // Repo not found
-	ExitRepoValidation  = 3 // Validation error
-	ExitRepoDataError   = 4 // Data error
-	ExitRepoGitHubError = 5 // GitHub API error
-)
-
 func init() {
 	rootCmd.AddCommand(repoCmd)
 
@@ -30,6 +22,9 @@ func init() {
 	repoAddCmd.Flags().StringP("name", "n", "", "Display name (required for manual)")
 	repoAddCmd.Flags().StringP("description", "d", "", "Description (manual only)")
 	repoAddCmd.Flags().String("topics", "", "Comma-separated topics (manual only)")
+	repoAddCmd.Flags().String("batch", "", "Add every org/repo or GitHub URL listed in this file, one per line")
+	repoAddCmd.Flags().Bool("no-fetch", false, "With --batch, skip GitHub metadata fetch (create repos with minimal data)")
+	repoAddCmd.Flags().Bool("no-cache", false, "Fetch GitHub metadata fresh instead of from the local cache")
 	repoAddCmd.MarkFlagRequired("project")
 	repoCmd.AddCommand(repoAddCmd)
 
@@ -40,6 +35,11 @@ func init() {
 	repoListCmd.Flags().StringP("project", "p", "", "Filter by project")
 	repoCmd.AddCommand(repoListCmd)
 
+	// repo search flags
+	repoSearchCmd.Flags().StringP("language", "l", "", "Filter by primary language")
+	repoSearchCmd.Flags().StringArrayP("topic", "t", nil, "Filter by topic (repeatable; all must match)")
+	repoCmd.AddCommand(repoSearchCmd)
+
 	// repo update flags
 	repoUpdateCmd.Flags().StringP("name", "n", "", "New display name")
 	repoUpdateCmd.Flags().StringP("description", "d", "", "New description")
@@ -49,7 +49,8 @@ func init() {
 	// repo delete - no extra flags
 	repoCmd.AddCommand(repoDeleteCmd)
 
-	// repo refresh - no extra flags
+	// repo refresh flags
+	repoRefreshCmd.Flags().Bool("all", false, "Refresh every GitHub repo")
 	repoCmd.AddCommand(repoRefreshCmd)
 }
 
@@ -70,10 +71,17 @@ var repoAddCmd = &cobra.Command{
 	Short: "Add a repo to a project",
 	Long: `Add a GitHub repository to a project.
 
+With --batch, add every repo listed in a file, one org/repo or GitHub URL
+per line; blank lines and lines starting with # are ignored. Repos whose
+URL is already present are skipped, and derived IDs that collide are made
+unique as in 'bip project import'. The result reports created, skipped,
+and failed counts.
+
 Examples:
   bip repo add https://github.com/matsen/bipartite --project bipartite
   bip repo add matsen/bipartite --project bipartite
-  bip repo add --manual --project dasm2 --id internal-tools --name "Internal Tools"`,
+  bip repo add --manual --project dasm2 --id internal-tools --name "Internal Tools"
+  bip repo add --batch repos.txt --project dasm2 --no-fetch`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runRepoAdd,
 }
@@ -87,6 +95,16 @@ func runRepoAdd(cmd *cobra.Command, args []string) error {
 	name, _ := cmd.Flags().GetString("name")
 	description, _ := cmd.Flags().GetString("description")
 	topicsStr, _ := cmd.Flags().GetString("topics")
+	batchPath, _ := cmd.Flags().GetString("batch")
+	noFetch, _ := cmd.Flags().GetBool("no-fetch")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+
+	if batchPath == "" && noFetch {
+		exitWithError(ExitRepoValidation, "--no-fetch requires --batch")
+	}
+	if batchPath != "" && (len(args) > 0 || isManual || repoID != "") {
+		exitWithError(ExitRepoValidation, "--batch cannot be combined with a repo argument, --manual, or --id")
+	}
 
 	// Verify project exists
 	projectsPath := config.ProjectsPath(repoRoot)
@@ -99,6 +117,11 @@ func runRepoAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	now := time.Now().UTC().Format(time.RFC3339)
+	if batchPath != "" {
+		runRepoAddBatch(repoRoot, batchPath, projectID, noFetch, noCache, now)
+		return nil
+	}
+
 	var r repo.Repo
 
 	if isManual {
@@ -158,7 +181,7 @@ func runRepoAdd(cmd *cobra.Command, args []string) error {
 		}
 
 		// Fetch metadata from GitHub
-		client := github.NewClient()
+		client := newGitHubClient(githubCacheOption(repoRoot, noCache))
 		meta, err := client.FetchRepoMetadata(githubInput)
 		if err != nil {
 			switch err {
@@ -174,16 +197,18 @@ func runRepoAdd(cmd *cobra.Command, args []string) error {
 		}
 
 		r = repo.Repo{
-			ID:          repoID,
-			Project:     projectID,
-			Type:        repo.TypeGitHub,
-			Name:        meta.Name,
-			GitHubURL:   normalizedURL,
-			Description: meta.Description,
-			Topics:      meta.Topics,
-			Language:    meta.Language,
-			CreatedAt:   now,
-			UpdatedAt:   now,
+			ID:           repoID,
+			Project:      projectID,
+			Type:         repo.TypeGitHub,
+			Name:         meta.Name,
+			GitHubURL:    normalizedURL,
+			Description:  meta.Description,
+			Topics:       meta.Topics,
+			Language:     meta.Language,
+			Stars:        &meta.Stars,
+			LastPushedAt: meta.PushedAt,
+			CreatedAt:    now,
+			UpdatedAt:    now,
 		}
 	}
 
@@ -202,15 +227,17 @@ func runRepoAdd(cmd *cobra.Command, args []string) error {
 		exitWithError(ExitRepoValidation, "repo with id %q already exists", r.ID)
 	}
 
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexRepos, reposPath)
+
 	// Append to JSONL
 	if err := storage.AppendRepo(reposPath, r); err != nil {
 		exitWithError(ExitRepoDataError, "writing repo: %v", err)
 	}
 
 	// Update SQLite index
-	db := mustOpenDatabase(repoRoot)
-	defer db.Close()
-	if _, err := db.RebuildReposFromJSONL(reposPath); err != nil {
+	if err := db.ApplyIndexUpdate(storage.IndexRepos, reposPath, indexCurrent, func() error { return db.UpsertRepo(r) }); err != nil {
 		exitWithError(ExitRepoDataError, "updating index: %v", err)
 	}
 
@@ -232,6 +259,13 @@ func runRepoAdd(cmd *cobra.Command, args []string) error {
 		if len(r.Topics) > 0 {
 			fmt.Printf("  Topics:   %s\n", strings.Join(r.Topics, ", "))
 		}
+		// Both are unset on records never fetched from GitHub
+		if r.Stars != nil {
+			fmt.Printf("  Stars:    %d\n", *r.Stars)
+		}
+		if r.LastPushedAt != "" {
+			fmt.Printf("  Pushed:   %s\n", r.LastPushedAt)
+		}
 	} else {
 		outputJSON(RepoAddResult{
 			Status: "created",
@@ -295,6 +329,12 @@ func runRepoGet(cmd *cobra.Command, args []string) error {
 		if len(r.Topics) > 0 {
 			fmt.Printf("Topics:   %s\n", strings.Join(r.Topics, ", "))
 		}
+		if r.Stars != nil {
+			fmt.Printf("Stars:    %d\n", *r.Stars)
+		}
+		if r.LastPushedAt != "" {
+			fmt.Printf("Pushed:   %s\n", r.LastPushedAt)
+		}
 		fmt.Printf("Created:  %s\n", r.CreatedAt)
 	} else {
 		outputJSON(r)
@@ -323,49 +363,124 @@ func runRepoList(cmd *cobra.Command, args []string) error {
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
 
-	var repos []repo.Repo
-	var err error
-
+	items := listItems[repo.Repo](db.GetAllReposEach)
 	if projectFilter != "" {
-		repos, err = db.GetReposByProject(projectFilter)
-	} else {
-		repos, err = db.GetAllRepos()
+		items = func(yield func(repo.Repo) error) error {
+			return db.GetReposByProjectEach(projectFilter, yield)
+		}
+	}
+	if !humanOutput {
+		outputRepoList(items, "querying repos")
+		return nil
 	}
+
+	repos, err := collectItems(items)
 	if err != nil {
 		exitWithError(ExitRepoDataError, "querying repos: %v", err)
 	}
+	if len(repos) == 0 {
+		if projectFilter != "" {
+			fmt.Printf("No repos found for project %q\n", projectFilter)
+		} else {
+			fmt.Println("No repos found")
+		}
+		return nil
+	}
+	printReposHuman(repos, false)
 
-	if humanOutput {
-		if len(repos) == 0 {
-			if projectFilter != "" {
-				fmt.Printf("No repos found for project %q\n", projectFilter)
-			} else {
-				fmt.Println("No repos found")
-			}
-			return nil
+	return nil
+}
+
+// printReposHuman prints repos as blocks followed by a total, for --human
+// output. With showMatched, each block also shows the language and topics
+// that repo search filters on.
+func printReposHuman(repos []repo.Repo, showMatched bool) {
+	for i, r := range repos {
+		if i > 0 {
+			fmt.Println()
 		}
-		for i, r := range repos {
-			if i > 0 {
-				fmt.Println()
-			}
-			fmt.Printf("Repo:    %s\n", r.ID)
-			fmt.Printf("Project: %s\n", r.Project)
-			fmt.Printf("Type:    %s\n", r.Type)
-			fmt.Printf("Name:    %s\n", r.Name)
-			if r.GitHubURL != "" {
-				fmt.Printf("URL:     %s\n", r.GitHubURL)
-			}
+		fmt.Printf("Repo:    %s\n", r.ID)
+		fmt.Printf("Project: %s\n", r.Project)
+		fmt.Printf("Type:    %s\n", r.Type)
+		fmt.Printf("Name:    %s\n", r.Name)
+		if showMatched && r.Language != "" {
+			fmt.Printf("Lang:    %s\n", r.Language)
 		}
-		fmt.Printf("\nTotal: %d repos\n", len(repos))
-	} else {
-		if repos == nil {
-			repos = []repo.Repo{}
+		if showMatched && len(r.Topics) > 0 {
+			fmt.Printf("Topics:  %s\n", strings.Join(r.Topics, ", "))
+		}
+		if r.Stars != nil {
+			fmt.Printf("Stars:   %d\n", *r.Stars)
+		}
+		if r.LastPushedAt != "" {
+			fmt.Printf("Pushed:  %s\n", r.LastPushedAt)
+		}
+		if r.GitHubURL != "" {
+			fmt.Printf("URL:     %s\n", r.GitHubURL)
 		}
-		outputJSON(RepoListResult{
+	}
+	fmt.Printf("\nTotal: %d repos\n", len(repos))
+}
+
+// outputRepoList writes repos as a RepoListResult JSON object, or one repo
+// per line with --json-lines. A query error exits with errContext.
+func outputRepoList(items listItems[repo.Repo], errContext string) {
+	err := outputList(items, func(repos []repo.Repo) any {
+		return RepoListResult{
 			Repos: repos,
 			Count: len(repos),
-		})
+		}
+	})
+	if err != nil {
+		exitWithError(ExitRepoDataError, "%s: %v", errContext, err)
 	}
+}
+
+var repoSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search repos by language and topic",
+	Long: `Search repository nodes across all projects by language and topic.
+
+Filters combine with AND: every --topic must be present, and --language must
+match if given. Matching is case-insensitive.
+
+Examples:
+  bip repo search --language Go
+  bip repo search --topic antibodies
+  bip repo search --language Python --topic antibodies --topic ml`,
+	Args: cobra.NoArgs,
+	RunE: runRepoSearch,
+}
+
+func runRepoSearch(cmd *cobra.Command, args []string) error {
+	repoRoot := mustFindRepository()
+	language, _ := cmd.Flags().GetString("language")
+	topics, _ := cmd.Flags().GetStringArray("topic")
+
+	if language == "" && len(topics) == 0 {
+		exitWithError(ExitRepoValidation, "at least one of --language or --topic is required")
+	}
+
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	items := listItems[repo.Repo](func(yield func(repo.Repo) error) error {
+		return db.SearchReposEach(language, topics, yield)
+	})
+	if !humanOutput {
+		outputRepoList(items, "searching repos")
+		return nil
+	}
+
+	repos, err := collectItems(items)
+	if err != nil {
+		exitWithError(ExitRepoDataError, "searching repos: %v", err)
+	}
+	if len(repos) == 0 {
+		fmt.Println("No matching repos found")
+		return nil
+	}
+	printReposHuman(repos, true)
 
 	return nil
 }
@@ -434,15 +549,17 @@ func runRepoUpdate(cmd *cobra.Command, args []string) error {
 
 	repos[idx] = r
 
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexRepos, reposPath)
+
 	// Write back
 	if err := storage.WriteAllRepos(reposPath, repos); err != nil {
 		exitWithError(ExitRepoDataError, "writing repos: %v", err)
 	}
 
 	// Update SQLite index
-	db := mustOpenDatabase(repoRoot)
-	defer db.Close()
-	if _, err := db.RebuildReposFromJSONL(reposPath); err != nil {
+	if err := db.ApplyIndexUpdate(storage.IndexRepos, reposPath, indexCurrent, func() error { return db.UpsertRepo(r) }); err != nil {
 		exitWithError(ExitRepoDataError, "updating index: %v", err)
 	}
 
@@ -529,14 +646,34 @@ type RepoRefreshResult struct {
 }
 
 var repoRefreshCmd = &cobra.Command{
-	Use:   "refresh <id>",
+	Use:   "refresh <id> | --all",
 	Short: "Refresh GitHub metadata for a repo",
-	Long:  `Re-fetch metadata from GitHub for a repository.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  runRepoRefresh,
+	Long: `Re-fetch metadata from GitHub for a repository. Refresh always asks the API,
+ignoring cached metadata, and updates the cache with the result.
+
+With --all, refreshes every GitHub repo in one pass. Manual repos are skipped,
+and a repo that fails (not found, rate limited) is reported in the results
+instead of stopping the run.
+
+Examples:
+  bip repo refresh bipartite
+  bip repo refresh --all --human`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRepoRefresh,
 }
 
 func runRepoRefresh(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	if all && len(args) > 0 {
+		exitWithError(ExitRepoValidation, "cannot combine --all with a repo ID")
+	}
+	if all {
+		return runRepoRefreshAll()
+	}
+	if len(args) == 0 {
+		exitWithError(ExitRepoValidation, "repo ID required (or use --all)")
+	}
+
 	repoRoot := mustFindRepository()
 	repoID := args[0]
 
@@ -561,7 +698,7 @@ func runRepoRefresh(cmd *cobra.Command, args []string) error {
 	}
 
 	// Fetch updated metadata from GitHub
-	client := github.NewClient()
+	client := newGitHubRefreshClient(repoRoot)
 	meta, err := client.FetchRepoMetadata(r.GitHubURL)
 	if err != nil {
 		switch err {
@@ -576,13 +713,7 @@ func runRepoRefresh(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Update metadata
-	r.Name = meta.Name
-	r.Description = meta.Description
-	r.Topics = meta.Topics
-	r.Language = meta.Language
-	r.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
-
+	applyRepoMetadata(&r, meta, time.Now().UTC().Format(time.RFC3339))
 	repos[idx] = r
 
 	// Write back
@@ -610,6 +741,12 @@ func runRepoRefresh(cmd *cobra.Command, args []string) error {
 		if len(r.Topics) > 0 {
 			fmt.Printf("  Topics:   %s\n", strings.Join(r.Topics, ", "))
 		}
+		if r.Stars != nil {
+			fmt.Printf("  Stars:    %d\n", *r.Stars)
+		}
+		if r.LastPushedAt != "" {
+			fmt.Printf("  Pushed:   %s\n", r.LastPushedAt)
+		}
 	} else {
 		outputJSON(RepoRefreshResult{
 			Status: "refreshed",
@@ -619,3 +756,14 @@ func runRepoRefresh(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// applyRepoMetadata copies refreshed GitHub metadata onto r and stamps UpdatedAt.
+func applyRepoMetadata(r *repo.Repo, meta *github.RepoMetadata, now string) {
+	r.Name = meta.Name
+	r.Description = meta.Description
+	r.Topics = meta.Topics
+	r.Language = meta.Language
+	r.Stars = &meta.Stars
+	r.LastPushedAt = meta.PushedAt
+	r.UpdatedAt = now
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/github"
+	"github.com/matsen/bipartite/internal/repo"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+// repoBatch creates GitHub repos in bulk, shared by 'bip project import'
+// and 'bip repo add --batch'. It tracks the IDs and URLs already taken,
+// including by repos it has created, so later specs see earlier ones.
+type repoBatch struct {
+	client  *github.Client
+	noFetch bool
+	now     string
+	ids     map[string]bool
+	urls    map[string]bool
+
+	rateLimited bool        // Rate-limit warning already recorded
+	New         []repo.Repo // Repos created, in order
+	Created     int
+	Skipped     int
+	Failed      int
+	Warnings    []string
+}
+
+// newRepoBatch returns a repoBatch that avoids the IDs and GitHub URLs of
+// existing. With noFetch, repos are created without GitHub metadata and
+// client may be nil.
+func newRepoBatch(existing []repo.Repo, client *github.Client, noFetch bool, now string) *repoBatch {
+	b := &repoBatch{
+		client:  client,
+		noFetch: noFetch,
+		now:     now,
+		ids:     make(map[string]bool),
+		urls:    make(map[string]bool),
+	}
+	for _, r := range existing {
+		b.ids[r.ID] = true
+		if r.GitHubURL != "" {
+			b.urls[r.GitHubURL] = true
+		}
+	}
+	return b
+}
+
+// add creates the repo for one org/repo or GitHub URL in projectID.
+// A URL already present is skipped. A derived ID already taken is
+// prefixed with the project ID, then replaced by org-repo if still taken.
+func (b *repoBatch) add(repoSpec, projectID string) RepoImportAction {
+	return b.addWithMetadata(repoSpec, projectID, nil)
+}
+
+// addWithMetadata is add for a repo whose GitHub metadata is already
+// known, e.g. from an org listing; a nil meta is fetched unless noFetch.
+func (b *repoBatch) addWithMetadata(repoSpec, projectID string, meta *github.RepoMetadata) RepoImportAction {
+	action := RepoImportAction{ID: repoSpec, ProjectID: projectID}
+	fail := func(reason string) RepoImportAction {
+		b.Failed++
+		action.Action, action.Reason = "failed", reason
+		return action
+	}
+
+	// Normalize GitHub URL
+	normalizedURL, err := github.NormalizeGitHubURL(repoSpec)
+	if err != nil {
+		return fail(fmt.Sprintf("invalid GitHub URL: %v", err))
+	}
+	action.GitHubURL = normalizedURL
+
+	// Derive repo ID
+	repoID, err := github.DeriveRepoID(repoSpec)
+	if err != nil {
+		return fail(fmt.Sprintf("cannot derive repo ID: %v", err))
+	}
+	action.ID = repoID
+
+	// Check if repo URL already exists
+	if b.urls[normalizedURL] {
+		b.Skipped++
+		action.Action, action.Reason = "skipped", "GitHub URL already exists"
+		return action
+	}
+
+	// Handle ID collision - append project ID to make unique
+	originalID := repoID
+	if b.ids[repoID] {
+		repoID = fmt.Sprintf("%s-%s", projectID, repoID)
+		// If still collision, use full org-repo format
+		if b.ids[repoID] {
+			// ParseGitHubURL won't fail here since NormalizeGitHubURL already succeeded
+			owner, repoName, _ := github.ParseGitHubURL(repoSpec)
+			if owner != "" && repoName != "" {
+				repoID = strings.ToLower(fmt.Sprintf("%s-%s", owner, repoName))
+			}
+		}
+	}
+	action.ID = repoID
+
+	r := repo.Repo{
+		ID:        repoID,
+		Project:   projectID,
+		Type:      repo.TypeGitHub,
+		Name:      originalID, // Use repo name as display name
+		GitHubURL: normalizedURL,
+		CreatedAt: b.now,
+		UpdatedAt: b.now,
+	}
+
+	if meta == nil && !b.noFetch {
+		// Fetch metadata from GitHub
+		meta, err = b.client.FetchRepoMetadata(repoSpec)
+		if err != nil {
+			switch err {
+			case github.ErrRepoNotFound:
+				return fail("GitHub repository not found")
+			case github.ErrRateLimited:
+				if !b.rateLimited {
+					b.rateLimited = true
+					b.Warnings = append(b.Warnings, "GitHub rate limit exceeded; try --no-fetch or set BIP_GITHUB_TOKEN (or GITHUB_TOKEN / GH_TOKEN)")
+				}
+				return fail("GitHub rate limit exceeded")
+			case github.ErrUnauthorized:
+				return fail("GitHub authentication failed")
+			default:
+				return fail(fmt.Sprintf("GitHub API error: %v", err))
+			}
+		}
+	}
+	if meta != nil {
+		r.Name = meta.Name
+		r.Description = meta.Description
+		r.Topics = meta.Topics
+		r.Language = meta.Language
+		r.Stars = &meta.Stars
+		r.LastPushedAt = meta.PushedAt
+	}
+
+	// Validate repo
+	if err := r.ValidateForCreate(); err != nil {
+		return fail(fmt.Sprintf("invalid repo: %v", err))
+	}
+
+	b.New = append(b.New, r)
+	b.ids[repoID] = true
+	b.urls[normalizedURL] = true
+	b.Created++
+
+	action.Action = "created"
+	return action
+}
+
+// readRepoBatchFile reads one org/repo or GitHub URL per line from path.
+// Blank lines and lines starting with # are ignored, as is anything after
+// whitespace following the spec.
+func readRepoBatchFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var specs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		specs = append(specs, strings.Fields(line)[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+// RepoBatchResult is the response for repo add --batch and repo discover --add.
+type RepoBatchResult struct {
+	Status   string             `json:"status"`
+	Created  int                `json:"created"`
+	Skipped  int                `json:"skipped"`
+	Failed   int                `json:"failed"`
+	Warnings []string           `json:"warnings,omitempty"`
+	Repos    []RepoImportAction `json:"repos"`
+}
+
+// runRepoAddBatch adds every repo listed in batchPath to projectID.
+func runRepoAddBatch(repoRoot, batchPath, projectID string, noFetch, noCache bool, now string) {
+	specs, err := readRepoBatchFile(batchPath)
+	if err != nil {
+		exitWithError(ExitRepoDataError, "reading batch file: %v", err)
+	}
+	if len(specs) == 0 {
+		exitWithError(ExitRepoValidation, "batch file %s lists no repos", batchPath)
+	}
+
+	reposPath := config.ReposPath(repoRoot)
+	existing, err := storage.ReadAllRepos(reposPath)
+	if err != nil {
+		exitWithError(ExitRepoDataError, "reading repos: %v", err)
+	}
+
+	var client *github.Client
+	if !noFetch {
+		client = newGitHubClient(githubCacheOption(repoRoot, noCache))
+	}
+	batch := newRepoBatch(existing, client, noFetch, now)
+	var actions []RepoImportAction
+	for _, spec := range specs {
+		actions = append(actions, batch.add(spec, projectID))
+	}
+	finishRepoBatch(repoRoot, batch, actions)
+}
+
+// finishRepoBatch writes the repos a batch created, updates the index,
+// and outputs a RepoBatchResult with the per-repo actions.
+func finishRepoBatch(repoRoot string, batch *repoBatch, actions []RepoImportAction) {
+	reposPath := config.ReposPath(repoRoot)
+	result := RepoBatchResult{Status: "completed", Repos: actions}
+	if result.Repos == nil {
+		result.Repos = []RepoImportAction{}
+	}
+	result.Created, result.Skipped, result.Failed = batch.Created, batch.Skipped, batch.Failed
+	result.Warnings = batch.Warnings
+
+	if len(batch.New) > 0 {
+		for _, r := range batch.New {
+			if err := storage.AppendRepo(reposPath, r); err != nil {
+				exitWithError(ExitRepoDataError, "writing repo: %v", err)
+			}
+		}
+		db := mustOpenDatabase(repoRoot)
+		defer db.Close()
+		if _, err := db.RebuildReposFromJSONL(reposPath); err != nil {
+			exitWithError(ExitRepoDataError, "updating repos index: %v", err)
+		}
+	}
+
+	if humanOutput {
+		for _, a := range result.Repos {
+			line := fmt.Sprintf("  %-8s %s", a.Action, a.ID)
+			if a.Reason != "" {
+				line += " (" + a.Reason + ")"
+			}
+			fmt.Println(line)
+		}
+		fmt.Printf("\nRepos: %d created, %d skipped, %d failed\n", result.Created, result.Skipped, result.Failed)
+		for _, w := range result.Warnings {
+			fmt.Printf("Warning: %s\n", w)
+		}
+	} else {
+		outputJSON(result)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/repo"
+)
+
+func TestReadRepoBatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repos.txt")
+	content := `# DASM repos
+matsengrp/netam
+
+https://github.com/matsengrp/dasm2-experiments  # experiments
+  # indented comment
+github.com/matsen/bipartite
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readRepoBatchFile(path)
+	if err != nil {
+		t.Fatalf("readRepoBatchFile() error = %v", err)
+	}
+	want := []string{"matsengrp/netam", "https://github.com/matsengrp/dasm2-experiments", "github.com/matsen/bipartite"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readRepoBatchFile() = %v, want %v", got, want)
+	}
+}
+
+func TestRepoBatchAdd(t *testing.T) {
+	existing := []repo.Repo{
+		{ID: "utils", Project: "other", Type: repo.TypeGitHub, GitHubURL: "https://github.com/org0/utils"},
+	}
+	batch := newRepoBatch(existing, nil, true, "2026-01-01T00:00:00Z")
+
+	actions := []RepoImportAction{
+		batch.add("org0/utils", "dasm"),      // URL already present
+		batch.add("org1/utils", "dasm"),      // ID taken: project prefix
+		batch.add("org2/utils", "dasm"),      // Prefixed ID also taken: org-repo
+		batch.add("org1/utils", "dasm"),      // Duplicate within the batch
+		batch.add("not a repo spec", "dasm"), // Invalid
+	}
+
+	wantActions := []string{"skipped", "created", "created", "skipped", "failed"}
+	wantIDs := []string{"utils", "dasm-utils", "org2-utils", "utils", ""}
+	for i, a := range actions {
+		if a.Action != wantActions[i] {
+			t.Errorf("action %d = %q (%s), want %q", i, a.Action, a.Reason, wantActions[i])
+		}
+		if wantIDs[i] != "" && a.ID != wantIDs[i] {
+			t.Errorf("action %d ID = %q, want %q", i, a.ID, wantIDs[i])
+		}
+	}
+	if batch.Created != 2 || batch.Skipped != 2 || batch.Failed != 1 {
+		t.Errorf("counts = %d created, %d skipped, %d failed; want 2, 2, 1", batch.Created, batch.Skipped, batch.Failed)
+	}
+	if len(batch.New) != 2 || batch.New[0].Project != "dasm" {
+		t.Errorf("New = %+v, want 2 repos in project dasm", batch.New)
+	}
+}
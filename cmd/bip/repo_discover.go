@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/github"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	repoDiscoverCmd.Flags().StringP("topic", "t", "", "Only repos with this topic")
+	repoDiscoverCmd.Flags().StringP("language", "l", "", "Only repos with this primary language")
+	repoDiscoverCmd.Flags().Bool("exclude-archived", false, "Skip archived repos")
+	repoDiscoverCmd.Flags().Bool("exclude-fork", false, "Skip forks")
+	repoDiscoverCmd.Flags().Bool("add", false, "Add the discovered repos to --project")
+	repoDiscoverCmd.Flags().StringP("project", "p", "", "Project to add repos to (with --add)")
+	repoCmd.AddCommand(repoDiscoverCmd)
+}
+
+var repoDiscoverCmd = &cobra.Command{
+	Use:   "discover <org>",
+	Short: "List a GitHub organization's repos, optionally adding them",
+	Long: `List the repositories of a GitHub organization (or user), filtered by
+topic, language, archived state, and fork state.
+
+Without --add this is read-only: the matching repos are printed with their
+GitHub metadata, so the list can be previewed before adding. With --add
+--project, every match is added to the project as by 'bip repo add --batch':
+repos already present are skipped and colliding IDs are made unique.
+
+Listing an organization makes one API call per 100 repos; set a GitHub
+token for private repos and the higher rate limit.
+
+Examples:
+  bip repo discover matsengrp --topic antibodies --exclude-archived --human
+  bip repo discover matsengrp --language Python --exclude-fork
+  bip repo discover matsengrp --topic antibodies --add --project dasm2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRepoDiscover,
+}
+
+// RepoDiscoverResult is the response for repo discover without --add.
+type RepoDiscoverResult struct {
+	Org   string                `json:"org"`
+	Repos []github.RepoMetadata `json:"repos"`
+	Count int                   `json:"count"`
+}
+
+func runRepoDiscover(cmd *cobra.Command, args []string) error {
+	org := args[0]
+	topic, _ := cmd.Flags().GetString("topic")
+	language, _ := cmd.Flags().GetString("language")
+	excludeArchived, _ := cmd.Flags().GetBool("exclude-archived")
+	excludeFork, _ := cmd.Flags().GetBool("exclude-fork")
+	add, _ := cmd.Flags().GetBool("add")
+	projectID, _ := cmd.Flags().GetString("project")
+
+	if add && projectID == "" {
+		exitWithError(ExitRepoValidation, "--add requires --project")
+	}
+	if !add && projectID != "" {
+		exitWithError(ExitRepoValidation, "--project requires --add")
+	}
+
+	repoRoot := mustFindRepository()
+	if add {
+		projectIDs, err := storage.LoadProjectIDSet(config.ProjectsPath(repoRoot))
+		if err != nil {
+			exitWithError(ExitRepoDataError, "reading projects: %v", err)
+		}
+		if !projectIDs[projectID] {
+			exitWithError(ExitRepoValidation, "project %q not found", projectID)
+		}
+	}
+
+	client := newGitHubClient()
+	repos, err := client.ListOrgRepos(org, github.ListOrgReposOptions{
+		Topic:           topic,
+		Language:        language,
+		ExcludeArchived: excludeArchived,
+		ExcludeForks:    excludeFork,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, github.ErrOrgNotFound):
+			exitWithError(ExitRepoNotFound, "GitHub organization or user not found: %s", org)
+		case errors.Is(err, github.ErrInvalidURL):
+			exitWithError(ExitRepoValidation, "%v", err)
+		case errors.Is(err, github.ErrRateLimited):
+			exitWithError(ExitRepoGitHubError, "GitHub API rate limit exceeded; try again later or set BIP_GITHUB_TOKEN (or GITHUB_TOKEN / GH_TOKEN)")
+		case errors.Is(err, github.ErrUnauthorized):
+			exitWithError(ExitRepoGitHubError, "GitHub API authentication failed; check BIP_GITHUB_TOKEN (or GITHUB_TOKEN / GH_TOKEN, or github_token in ~/.config/bip/config.yml)")
+		default:
+			exitWithError(ExitRepoGitHubError, "%v", err)
+		}
+	}
+
+	if add {
+		existing, err := storage.ReadAllRepos(config.ReposPath(repoRoot))
+		if err != nil {
+			exitWithError(ExitRepoDataError, "reading repos: %v", err)
+		}
+		batch := newRepoBatch(existing, client, false, time.Now().UTC().Format(time.RFC3339))
+		var actions []RepoImportAction
+		for i := range repos {
+			actions = append(actions, batch.addWithMetadata(repos[i].FullName, projectID, &repos[i]))
+		}
+		finishRepoBatch(repoRoot, batch, actions)
+		return nil
+	}
+
+	if repos == nil {
+		repos = []github.RepoMetadata{}
+	}
+	if humanOutput {
+		if len(repos) == 0 {
+			fmt.Printf("No matching repos in %s\n", org)
+			return nil
+		}
+		for _, r := range repos {
+			var tags []string
+			if r.Archived {
+				tags = append(tags, "archived")
+			}
+			if r.Fork {
+				tags = append(tags, "fork")
+			}
+			line := fmt.Sprintf("%-40s %-12s %5d★", r.FullName, r.Language, r.Stars)
+			if len(tags) > 0 {
+				line += " [" + strings.Join(tags, ", ") + "]"
+			}
+			fmt.Println(line)
+			if len(r.Topics) > 0 {
+				fmt.Printf("    topics: %s\n", strings.Join(r.Topics, ", "))
+			}
+		}
+		fmt.Printf("\n%d repos\n", len(repos))
+	} else {
+		outputList(sliceItems(repos), func(repos []github.RepoMetadata) any {
+			return RepoDiscoverResult{Org: org, Repos: repos, Count: len(repos)}
+		})
+	}
+	return nil
+}
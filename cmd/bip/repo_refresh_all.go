@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/github"
+	"github.com/matsen/bipartite/internal/repo"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+// RepoRefreshAllResult is the response for repo refresh --all.
+type RepoRefreshAllResult struct {
+	Status    string              `json:"status"`
+	Refreshed int                 `json:"refreshed"`
+	Skipped   int                 `json:"skipped"`
+	Failed    int                 `json:"failed"`
+	Warnings  []string            `json:"warnings,omitempty"`
+	Details   []RepoRefreshAction `json:"details"`
+}
+
+// RepoRefreshAction describes what happened to one repo during refresh --all.
+type RepoRefreshAction struct {
+	ID        string `json:"id"`
+	GitHubURL string `json:"github_url,omitempty"`
+	Action    string `json:"action"` // "refreshed", "skipped", or "failed"
+	Reason    string `json:"reason,omitempty"`
+}
+
+// runRepoRefreshAll re-fetches metadata for every GitHub repo, then writes
+// repos.jsonl and rebuilds the index once.
+func runRepoRefreshAll() error {
+	repoRoot := mustFindRepository()
+	reposPath := config.ReposPath(repoRoot)
+	repos, err := storage.ReadAllRepos(reposPath)
+	if err != nil {
+		exitWithError(ExitRepoDataError, "reading repos: %v", err)
+	}
+
+	client := newGitHubRefreshClient(repoRoot)
+	result := refreshAllRepos(repos, client.FetchRepoMetadata, time.Now().UTC().Format(time.RFC3339))
+
+	if result.Refreshed > 0 {
+		if err := storage.WriteAllRepos(reposPath, repos); err != nil {
+			exitWithError(ExitRepoDataError, "writing repos: %v", err)
+		}
+
+		db := mustOpenDatabase(repoRoot)
+		defer db.Close()
+		if _, err := db.RebuildReposFromJSONL(reposPath); err != nil {
+			exitWithError(ExitRepoDataError, "updating index: %v", err)
+		}
+	}
+
+	if humanOutput {
+		printRepoRefreshAll(result)
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// refreshAllRepos updates each GitHub repo in place using fetch. Manual repos
+// are skipped, and per-repo failures are recorded rather than returned. Once
+// the API reports a rate limit, the remaining repos are marked failed without
+// further requests.
+func refreshAllRepos(repos []repo.Repo, fetch func(string) (*github.RepoMetadata, error), now string) RepoRefreshAllResult {
+	result := RepoRefreshAllResult{Status: "refreshed", Details: []RepoRefreshAction{}}
+	rateLimited := false
+
+	for i := range repos {
+		r := &repos[i]
+		action := RepoRefreshAction{ID: r.ID, GitHubURL: r.GitHubURL}
+
+		switch {
+		case r.Type != repo.TypeGitHub:
+			action.Action = "skipped"
+			action.Reason = "manual repo"
+			result.Skipped++
+		case rateLimited:
+			action.Action = "failed"
+			action.Reason = "GitHub rate limit exceeded"
+			result.Failed++
+		default:
+			meta, err := fetch(r.GitHubURL)
+			if err != nil {
+				action.Action = "failed"
+				action.Reason = repoRefreshFailureReason(err)
+				result.Failed++
+				if errors.Is(err, github.ErrRateLimited) {
+					rateLimited = true
+					result.Warnings = append(result.Warnings, "GitHub rate limit exceeded; try again later or set BIP_GITHUB_TOKEN (or GITHUB_TOKEN / GH_TOKEN)")
+				}
+			} else {
+				applyRepoMetadata(r, meta, now)
+				action.Action = "refreshed"
+				result.Refreshed++
+			}
+		}
+
+		result.Details = append(result.Details, action)
+	}
+
+	return result
+}
+
+// repoRefreshFailureReason returns a short per-repo reason for a fetch error.
+func repoRefreshFailureReason(err error) string {
+	switch {
+	case errors.Is(err, github.ErrRepoNotFound):
+		return "GitHub repository not found (may have been deleted or made private)"
+	case errors.Is(err, github.ErrRateLimited):
+		return "GitHub rate limit exceeded"
+	case errors.Is(err, github.ErrUnauthorized):
+		return "GitHub API authentication failed"
+	default:
+		return err.Error()
+	}
+}
+
+// printRepoRefreshAll prints refresh --all results for --human output.
+func printRepoRefreshAll(result RepoRefreshAllResult) {
+	for _, a := range result.Details {
+		switch a.Action {
+		case "refreshed":
+			fmt.Printf("Refreshed: %s\n", a.ID)
+		case "failed":
+			fmt.Printf("Failed:    %s (%s)\n", a.ID, a.Reason)
+		}
+	}
+	for _, w := range result.Warnings {
+		fmt.Printf("Warning: %s\n", w)
+	}
+	fmt.Printf("\n%d refreshed, %d skipped, %d failed\n", result.Refreshed, result.Skipped, result.Failed)
+}
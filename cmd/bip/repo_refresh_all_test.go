@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/matsen/bipartite/internal/github"
+	"github.com/matsen/bipartite/internal/repo"
+)
+
+func TestRefreshAllRepos(t *testing.T) {
+	repos := []repo.Repo{
+		{ID: "alpha", Type: repo.TypeGitHub, GitHubURL: "https://github.com/o/alpha", Name: "alpha"},
+		{ID: "tools", Type: repo.TypeManual, Name: "Tools"},
+		{ID: "gone", Type: repo.TypeGitHub, GitHubURL: "https://github.com/o/gone", Name: "gone"},
+		{ID: "beta", Type: repo.TypeGitHub, GitHubURL: "https://github.com/o/beta", Name: "beta"},
+	}
+	fetch := func(url string) (*github.RepoMetadata, error) {
+		if url == "https://github.com/o/gone" {
+			return nil, github.ErrRepoNotFound
+		}
+		return &github.RepoMetadata{Name: "fetched", Stars: 7, PushedAt: "2026-01-01T00:00:00Z"}, nil
+	}
+
+	result := refreshAllRepos(repos, fetch, "2026-02-01T00:00:00Z")
+
+	if result.Refreshed != 2 || result.Skipped != 1 || result.Failed != 1 {
+		t.Errorf("refreshed/skipped/failed = %d/%d/%d, want 2/1/1", result.Refreshed, result.Skipped, result.Failed)
+	}
+	if len(result.Details) != 4 || result.Details[2].Action != "failed" {
+		t.Errorf("Details = %+v", result.Details)
+	}
+	if repos[0].Stars == nil || *repos[0].Stars != 7 || repos[0].UpdatedAt != "2026-02-01T00:00:00Z" {
+		t.Errorf("alpha not updated in place: %+v", repos[0])
+	}
+	if repos[2].Name != "gone" || repos[2].UpdatedAt != "" {
+		t.Errorf("failed repo was modified: %+v", repos[2])
+	}
+}
+
+func TestRefreshAllRepos_StopsFetchingAfterRateLimit(t *testing.T) {
+	repos := []repo.Repo{
+		{ID: "a", Type: repo.TypeGitHub, GitHubURL: "https://github.com/o/a"},
+		{ID: "b", Type: repo.TypeGitHub, GitHubURL: "https://github.com/o/b"},
+		{ID: "c", Type: repo.TypeGitHub, GitHubURL: "https://github.com/o/c"},
+	}
+	calls := 0
+	fetch := func(url string) (*github.RepoMetadata, error) {
+		calls++
+		return nil, github.ErrRateLimited
+	}
+
+	result := refreshAllRepos(repos, fetch, "2026-02-01T00:00:00Z")
+
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want 1", calls)
+	}
+	if result.Failed != 3 || len(result.Warnings) != 1 {
+		t.Errorf("Failed = %d, Warnings = %v; want 3 failures and one warning", result.Failed, result.Warnings)
+	}
+}
@@ -111,7 +111,7 @@ func runResolve(cmd *cobra.Command, args []string) error {
 		} else {
 			outputJSON(result)
 		}
-		os.Exit(ExitError)
+		os.Exit(ExitError.Status())
 	}
 
 	// Write resolved file
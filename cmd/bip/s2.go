@@ -1,16 +1,6 @@
 package main
 
-import (
-	"github.com/joho/godotenv"
-	"github.com/spf13/cobra"
-)
-
-// Exit codes specific to s2 commands (from contracts/cli.md)
-const (
-	ExitS2NotFound  = 1 // Paper not found in Semantic Scholar
-	ExitS2Duplicate = 2 // Paper already exists (without --update)
-	ExitS2APIError  = 3 // API error (rate limit, network)
-)
+import "github.com/spf13/cobra"
 
 var s2Cmd = &cobra.Command{
 	Use:   "s2",
@@ -25,8 +15,5 @@ Use --human flag for human-readable output.`,
 }
 
 func init() {
-	// Load .env file if present (for S2_API_KEY)
-	_ = godotenv.Load()
-
 	rootCmd.AddCommand(s2Cmd)
 }
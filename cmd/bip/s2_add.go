@@ -61,9 +61,11 @@ type S2AddPaperSummary struct {
 	Venue   string   `json:"venue,omitempty"`
 }
 
-// S2ErrorResult is the JSON output for errors.
+// S2ErrorResult is the JSON output for errors. Code is the command's own
+// cause; ErrorCode is the exit code's class, as in ErrorResponse.
 type S2ErrorResult struct {
 	Code       string `json:"error"`
+	ErrorCode  string `json:"error_code"`
 	Message    string `json:"message"`
 	Suggestion string `json:"suggestion,omitempty"`
 	RetryAfter int    `json:"retry_after,omitempty"`
@@ -86,7 +88,7 @@ func runS2Add(cmd *cobra.Command, args []string) error {
 
 	// Create resolver and client
 	resolver := s2.NewLocalResolverFromRefs(refs)
-	client := s2.NewClient()
+	client := newS2Client()
 
 	// Parse and resolve paper ID
 	parsed := s2.ParsePaperID(paperID)
@@ -215,6 +217,7 @@ func outputS2Duplicate(existingID, doi string) error {
 		Action: "skipped",
 		Error: &S2ErrorResult{
 			Code:       "duplicate",
+			ErrorCode:  ExitS2Duplicate.ErrorCode(),
 			Message:    "Paper already exists in collection",
 			PaperID:    existingID,
 			Suggestion: "Use --update flag to refresh metadata",
@@ -230,7 +233,7 @@ func outputS2Duplicate(existingID, doi string) error {
 	} else {
 		outputJSON(result)
 	}
-	os.Exit(ExitS2Duplicate)
+	os.Exit(ExitS2Duplicate.Status())
 	return nil
 }
 
@@ -238,6 +241,6 @@ func outputS2RateLimited(err error) error {
 	return outputGenericRateLimited(err)
 }
 
-func outputS2Error(exitCode int, context string, err error) error {
+func outputS2Error(exitCode ExitCode, context string, err error) error {
 	return outputGenericError(exitCode, "api_error", context, err)
 }
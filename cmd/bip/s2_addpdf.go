@@ -75,7 +75,7 @@ func runS2AddPdf(cmd *cobra.Command, args []string) error {
 
 	// Create resolver and client
 	resolver := s2.NewLocalResolverFromRefs(refs)
-	client := s2.NewClient()
+	client := newS2Client()
 
 	// Try to extract DOI from PDF
 	doi, err := pdf.ExtractDOI(absPath)
@@ -212,6 +212,7 @@ func outputAddPdfDuplicate(existingID, doi string) error {
 		Action: "skipped",
 		Error: &S2ErrorResult{
 			Code:       "duplicate",
+			ErrorCode:  ExitS2Duplicate.ErrorCode(),
 			Message:    "Paper already exists in collection",
 			PaperID:    existingID,
 			Suggestion: "Use 'bip s2 add --update' to refresh metadata",
@@ -226,7 +227,7 @@ func outputAddPdfDuplicate(existingID, doi string) error {
 	} else {
 		outputJSON(result)
 	}
-	os.Exit(ExitS2Duplicate)
+	os.Exit(ExitS2Duplicate.Status())
 	return nil
 }
 
@@ -245,6 +246,7 @@ func outputAddPdfMultipleMatches(papers []s2.S2Paper) error {
 	result := map[string]interface{}{
 		"error": map[string]interface{}{
 			"code":       "multiple_matches",
+			"error_code": ExitS2Duplicate.ErrorCode(),
 			"message":    "Multiple papers match the extracted title",
 			"suggestion": "Use 'bip s2 add DOI:...' with the correct DOI",
 			"matches":    matches,
@@ -264,10 +266,10 @@ func outputAddPdfMultipleMatches(papers []s2.S2Paper) error {
 	} else {
 		outputJSON(result)
 	}
-	os.Exit(ExitS2Duplicate) // Exit code 2 for multiple matches
+	os.Exit(ExitS2Duplicate.Status()) // Exit code 2 for multiple matches
 	return nil
 }
 
-func outputAddPdfError(exitCode int, context string, err error) error {
+func outputAddPdfError(exitCode ExitCode, context string, err error) error {
 	return outputGenericError(exitCode, "error", context, err)
 }
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/pdf"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/s2"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+// PDFImportResult is the outcome for one file in a directory import.
+type PDFImportResult struct {
+	File      string `json:"file"`
+	Action    string `json:"action"`               // added, skipped, manual, error
+	ID        string `json:"id,omitempty"`         // New or existing reference ID
+	DOI       string `json:"doi,omitempty"`        // DOI found in the PDF, if any
+	DOISource string `json:"doi_source,omitempty"` // metadata, extracted, title_search
+	Title     string `json:"title,omitempty"`      // Title from the PDF, for manual follow-up
+	Author    string `json:"author,omitempty"`     // Author from the PDF, for manual follow-up
+	Reason    string `json:"reason,omitempty"`     // Why the file was skipped or needs manual handling
+}
+
+// S2AddPdfDirResult is the JSON output for bip add --from-pdfs.
+type S2AddPdfDirResult struct {
+	Added   int               `json:"added"`
+	Skipped int               `json:"skipped"`
+	Manual  int               `json:"manual"`
+	Errors  int               `json:"errors"`
+	Results []PDFImportResult `json:"results"`
+}
+
+// runS2AddPdfDir adds every PDF in dir, reporting files that need manual handling
+// instead of stopping at the first one.
+func runS2AddPdfDir(dir string) error {
+	ctx := context.Background()
+
+	repoRoot := mustFindRepository()
+	cfg := mustLoadConfig(repoRoot)
+	refsPath := config.RefsPath(repoRoot)
+
+	files, err := listPDFs(dir)
+	if err != nil {
+		exitWithError(ExitError, "reading directory: %v", err)
+	}
+
+	refs, err := storage.ReadAll(refsPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+
+	resolver := s2.NewLocalResolverFromRefs(refs)
+	client := newS2Client()
+	pdfRoot := config.ExpandTilde(cfg.PDFRoot)
+
+	result := S2AddPdfDirResult{Results: []PDFImportResult{}}
+	for _, file := range files {
+		r, ref, rateLimited := importPDF(ctx, client, resolver, file)
+
+		if ref != nil {
+			ref.PDFPath = pdfPathForRef(file, pdfRoot)
+			ref.ID = storage.GenerateUniqueID(refs, ref.ID)
+			if err := storage.Append(refsPath, *ref); err != nil {
+				exitWithError(ExitDataError, "saving reference: %v", err)
+			}
+			refs = append(refs, *ref)
+			resolver = s2.NewLocalResolverFromRefs(refs)
+			r.ID = ref.ID
+		}
+
+		result.Results = append(result.Results, r)
+		switch r.Action {
+		case "added":
+			result.Added++
+		case "skipped":
+			result.Skipped++
+		case "manual":
+			result.Manual++
+		default:
+			result.Errors++
+		}
+
+		if humanOutput {
+			printPDFImportResult(r)
+		}
+
+		// Remaining files would hit the same limit; report what was done.
+		if rateLimited {
+			break
+		}
+	}
+
+	if humanOutput {
+		fmt.Printf("\n%d added, %d skipped, %d need manual handling, %d errors (%d PDFs found)\n",
+			result.Added, result.Skipped, result.Manual, result.Errors, len(files))
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// importPDF resolves one PDF to a new reference via its DOI or, failing that,
+// an unambiguous title match. Returns a nil reference when nothing should be
+// appended; rateLimited is set when the S2 API refused the request.
+func importPDF(ctx context.Context, client *s2.Client, resolver *s2.LocalResolver, file string) (PDFImportResult, *reference.Reference, bool) {
+	r := PDFImportResult{File: file}
+
+	info, err := pdf.ExtractInfo(file)
+	if err != nil {
+		r.Action = "error"
+		r.Reason = fmt.Sprintf("reading PDF: %v", err)
+		return r, nil, false
+	}
+	r.Author = info.Author
+
+	// Prefer a DOI in the metadata, then one printed on the first pages
+	doi, source := info.DOI(), "metadata"
+	if doi == "" {
+		doi, _ = pdf.ExtractDOI(file)
+		source = "extracted"
+	}
+
+	var paper *s2.S2Paper
+	if doi != "" {
+		r.DOI = doi
+		r.DOISource = source
+		if existing, found := resolver.FindByDOI(doi); found {
+			r.Action = "skipped"
+			r.ID = existing.ID
+			r.Reason = "already in collection"
+			return r, nil, false
+		}
+
+		paper, err = client.GetPaper(ctx, "DOI:"+doi)
+		if err != nil {
+			if s2.IsRateLimited(err) {
+				r.Action = "error"
+				r.Reason = "S2 rate limit reached; rerun to continue"
+				return r, nil, true
+			}
+			if !s2.IsNotFound(err) {
+				r.Action = "error"
+				r.Reason = fmt.Sprintf("fetching paper: %v", err)
+				return r, nil, false
+			}
+			paper = nil // Unknown to S2; fall back to the title
+		}
+	}
+
+	if paper == nil {
+		title := info.UsableTitle()
+		if title == "" {
+			title, _ = pdf.ExtractTitle(file)
+		}
+		r.Title = title
+		if title == "" {
+			r.Action = "manual"
+			r.Reason = "no DOI or title found"
+			return r, nil, false
+		}
+
+		searchResp, err := client.SearchByTitle(ctx, title, PDFSearchLimit)
+		if err != nil {
+			if s2.IsRateLimited(err) {
+				r.Action = "error"
+				r.Reason = "S2 rate limit reached; rerun to continue"
+				return r, nil, true
+			}
+			r.Action = "error"
+			r.Reason = fmt.Sprintf("searching by title: %v", err)
+			return r, nil, false
+		}
+
+		// Unattended bulk import only accepts a single exact title match;
+		// anything looser is left for a person to confirm.
+		var matches []s2.S2Paper
+		for _, p := range searchResp.Data {
+			if titlesMatchStrict(title, p.Title) {
+				matches = append(matches, p)
+			}
+		}
+		if len(matches) != 1 {
+			r.Action = "manual"
+			if doi != "" {
+				r.Reason = "DOI not found in Semantic Scholar and no unambiguous title match"
+			} else {
+				r.Reason = fmt.Sprintf("no DOI found; %d papers match the title", len(matches))
+			}
+			return r, nil, false
+		}
+		paper = &matches[0]
+		r.DOISource = "title_search"
+	}
+
+	if paper.ExternalIDs.DOI != "" {
+		if existing, found := resolver.FindByDOI(paper.ExternalIDs.DOI); found {
+			r.Action = "skipped"
+			r.ID = existing.ID
+			r.Reason = "already in collection"
+			return r, nil, false
+		}
+	}
+	if existing, found := resolver.FindByS2ID(paper.PaperID); found {
+		r.Action = "skipped"
+		r.ID = existing.ID
+		r.Reason = "already in collection"
+		return r, nil, false
+	}
+
+	ref := s2.MapS2ToReference(*paper)
+	r.Action = "added"
+	r.DOI = ref.DOI
+	r.Title = ref.Title
+	return r, &ref, false
+}
+
+// listPDFs returns the absolute paths of the PDF files directly inside dir, sorted.
+func listPDFs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".pdf") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// pdfPathForRef returns the pdf_path to store for a file: relative to pdfRoot
+// when the file lives under it (so bip open can resolve it), otherwise absolute.
+func pdfPathForRef(file, pdfRoot string) string {
+	if pdfRoot == "" {
+		return file
+	}
+	rel, err := filepath.Rel(pdfRoot, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return file
+	}
+	return rel
+}
+
+// printPDFImportResult prints one line per file for --human output.
+func printPDFImportResult(r PDFImportResult) {
+	name := filepath.Base(r.File)
+	switch r.Action {
+	case "added":
+		fmt.Printf("Added:   %s -> %s (%s)\n", name, r.ID, r.DOISource)
+	case "skipped":
+		fmt.Printf("Skipped: %s (%s: %s)\n", name, r.Reason, r.ID)
+	case "manual":
+		fmt.Printf("Manual:  %s (%s)\n", name, r.Reason)
+		if r.Title != "" {
+			fmt.Printf("         Title: %s\n", r.Title)
+		}
+		if r.Author != "" {
+			fmt.Printf("         Author: %s\n", r.Author)
+		}
+	default:
+		fmt.Printf("Error:   %s (%s)\n", name, r.Reason)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPDFPathForRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		pdfRoot string
+		want    string
+	}{
+		{"under pdf root", "/papers/2024/smith.pdf", "/papers", "2024/smith.pdf"},
+		{"outside pdf root", "/downloads/smith.pdf", "/papers", "/downloads/smith.pdf"},
+		{"sibling with shared prefix", "/papers-old/smith.pdf", "/papers", "/papers-old/smith.pdf"},
+		{"no pdf root", "/downloads/smith.pdf", "", "/downloads/smith.pdf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pdfPathForRef(tt.file, tt.pdfRoot); got != tt.want {
+				t.Errorf("pdfPathForRef(%q, %q) = %q, want %q", tt.file, tt.pdfRoot, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPDFs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.pdf", "a.PDF", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub.pdf"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := listPDFs(dir)
+	if err != nil {
+		t.Fatalf("listPDFs() error = %v", err)
+	}
+	want := []string{filepath.Join(dir, "a.PDF"), filepath.Join(dir, "b.pdf")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listPDFs() = %v, want %v", got, want)
+	}
+}
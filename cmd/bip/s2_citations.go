@@ -71,7 +71,7 @@ func runS2Citations(cmd *cobra.Command, args []string) error {
 
 	// Create resolver and client
 	resolver := s2.NewLocalResolverFromRefs(refs)
-	client := s2.NewClient()
+	client := newS2Client()
 
 	// Resolve paper ID
 	parsed := s2.ParsePaperID(paperID)
@@ -181,6 +181,6 @@ func outputCitationsNotFound(paperID string) error {
 	return outputGenericNotFound(paperID, "Paper not found")
 }
 
-func outputCitationsError(exitCode int, context string, err error) error {
+func outputCitationsError(exitCode ExitCode, context string, err error) error {
 	return outputGenericError(exitCode, "api_error", context, err)
 }
@@ -77,7 +77,7 @@ func runS2Gaps(cmd *cobra.Command, args []string) error {
 
 	// Create resolver and client
 	resolver := s2.NewLocalResolverFromRefs(refs)
-	client := s2.NewClient()
+	client := newS2Client()
 
 	// Get papers with DOIs
 	refsWithDOI := resolver.RefsWithDOI()
@@ -219,6 +219,6 @@ func outputGapsHuman(result S2GapsResult) {
 	fmt.Printf("Found %d gaps after analyzing %d papers.\n", result.Total, result.AnalyzedPapers)
 }
 
-func outputGapsError(exitCode int, context string, err error) error {
+func outputGapsError(exitCode ExitCode, context string, err error) error {
 	return outputGenericError(exitCode, "api_error", context, err)
 }
@@ -88,7 +88,7 @@ type GenericErrorResult struct {
 }
 
 // outputGenericError outputs an error in both human and JSON format and exits.
-func outputGenericError(exitCode int, errCode, context string, err error) error {
+func outputGenericError(exitCode ExitCode, errCode, context string, err error) error {
 	msg := context
 	if err != nil {
 		msg = fmt.Sprintf("%s: %v", context, err)
@@ -96,8 +96,9 @@ func outputGenericError(exitCode int, errCode, context string, err error) error
 
 	result := GenericErrorResult{
 		Error: &S2ErrorResult{
-			Code:    errCode,
-			Message: msg,
+			Code:      errCode,
+			ErrorCode: exitCode.ErrorCode(),
+			Message:   msg,
 		},
 	}
 
@@ -106,7 +107,7 @@ func outputGenericError(exitCode int, errCode, context string, err error) error
 	} else {
 		outputJSON(result)
 	}
-	os.Exit(exitCode)
+	os.Exit(exitCode.Status())
 	return nil
 }
 
@@ -115,6 +116,7 @@ func outputGenericNotFound(paperID, message string) error {
 	result := GenericErrorResult{
 		Error: &S2ErrorResult{
 			Code:       "not_found",
+			ErrorCode:  ExitS2NotFound.ErrorCode(),
 			Message:    message,
 			PaperID:    paperID,
 			Suggestion: "Verify the paper ID is correct",
@@ -127,7 +129,7 @@ func outputGenericNotFound(paperID, message string) error {
 	} else {
 		outputJSON(result)
 	}
-	os.Exit(ExitS2NotFound)
+	os.Exit(ExitS2NotFound.Status())
 	return nil
 }
 
@@ -142,6 +144,7 @@ func outputGenericRateLimited(err error) error {
 	result := GenericErrorResult{
 		Error: &S2ErrorResult{
 			Code:       "rate_limited",
+			ErrorCode:  ExitS2APIError.ErrorCode(),
 			Message:    "Semantic Scholar rate limit exceeded",
 			Suggestion: fmt.Sprintf("Wait %d seconds or add s2_api_key to ~/.config/bip/config.yml", retryAfter),
 			RetryAfter: retryAfter,
@@ -154,7 +157,7 @@ func outputGenericRateLimited(err error) error {
 	} else {
 		outputJSON(result)
 	}
-	os.Exit(ExitS2APIError)
+	os.Exit(ExitS2APIError.Status())
 	return nil
 }
 
@@ -67,7 +67,7 @@ func runS2LinkPub(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client
-	client := s2.NewClient()
+	client := newS2Client()
 
 	// Find preprints
 	preprints := findPreprints(refs)
@@ -236,6 +236,6 @@ func outputLinkPubResult(result S2LinkPubResult) error {
 	return nil
 }
 
-func outputLinkPubError(exitCode int, context string, err error) error {
+func outputLinkPubError(exitCode ExitCode, context string, err error) error {
 	return outputGenericError(exitCode, "api_error", context, err)
 }
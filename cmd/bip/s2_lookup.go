@@ -73,7 +73,7 @@ func runS2Lookup(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
 	// Create client
-	client := s2.NewClient()
+	client := newS2Client()
 
 	// Parse paper ID
 	parsed := s2.ParsePaperID(paperID)
@@ -251,6 +251,6 @@ func outputLookupNotFound(paperID string) error {
 	return outputGenericNotFound(paperID, "Paper not found in Semantic Scholar")
 }
 
-func outputLookupError(exitCode int, context string, err error) error {
+func outputLookupError(exitCode ExitCode, context string, err error) error {
 	return outputGenericError(exitCode, "api_error", context, err)
 }
@@ -61,7 +61,7 @@ func runS2References(cmd *cobra.Command, args []string) error {
 
 	// Create resolver and client
 	resolver := s2.NewLocalResolverFromRefs(refs)
-	client := s2.NewClient()
+	client := newS2Client()
 
 	// Resolve paper ID
 	parsed := s2.ParsePaperID(paperID)
@@ -179,6 +179,6 @@ func outputReferencesNotFound(paperID string) error {
 	return outputGenericNotFound(paperID, "Paper not found")
 }
 
-func outputReferencesError(exitCode int, context string, err error) error {
+func outputReferencesError(exitCode ExitCode, context string, err error) error {
 	return outputGenericError(exitCode, "api_error", context, err)
 }
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/matsen/bipartite/internal/config"
 	"github.com/matsen/bipartite/internal/reference"
 	"github.com/matsen/bipartite/internal/storage"
 	"github.com/spf13/cobra"
@@ -13,11 +14,13 @@ import (
 var (
 	searchLimit   int
 	searchAuthors []string
+	searchExact   bool
 	searchYear    string
 	searchTitle   string
 	searchVenue   string
 	searchDOI     string
 	searchTag     string
+	searchSort    string
 )
 
 // hasAnyFilterFlags returns true if any field-specific search flags were provided.
@@ -32,13 +35,15 @@ func hasAnyFilterFlags() bool {
 }
 
 func init() {
-	searchCmd.Flags().IntVar(&searchLimit, "limit", DefaultSearchLimit, "Maximum results to return")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", DefaultSearchLimit, "Maximum results to return (0 = all; default from search.default_limit)")
 	searchCmd.Flags().StringArrayVarP(&searchAuthors, "author", "a", nil, "Search by author name (can be repeated, uses AND logic)")
+	searchCmd.Flags().BoolVar(&searchExact, "exact-author", false, "Match --author first names in full instead of by prefix")
 	searchCmd.Flags().StringVar(&searchYear, "year", "", "Filter by year: exact (2024), range (2020:2024), or open (2020: or :2024)")
 	searchCmd.Flags().StringVarP(&searchTitle, "title", "t", "", "Search in title only")
 	searchCmd.Flags().StringVar(&searchVenue, "venue", "", "Filter by venue/journal (partial match)")
 	searchCmd.Flags().StringVar(&searchDOI, "doi", "", "Lookup by exact DOI")
 	searchCmd.Flags().StringVar(&searchTag, "tag", "", "Filter by tag/label (partial match)")
+	searchCmd.Flags().StringVar(&searchSort, "sort", "", "Sort by year, -year (newest first), title, or id (default: unordered)")
 	rootCmd.AddCommand(searchCmd)
 }
 
@@ -54,16 +59,19 @@ Query Syntax (positional argument):
 
 Flags:
   --author, -a   - Search by author (repeatable, AND logic, exact last name)
+  --exact-author - With --author, match first names in full, not by prefix
   --title, -t    - Search in title only
   --year         - Filter by year (exact, range, or open-ended)
   --venue        - Filter by venue/journal (partial match)
   --doi          - Lookup by exact DOI
   --tag          - Filter by tag/label (partial match)
+  --sort         - Sort by year, -year (newest first), title, or id
 
 Author matching uses exact last name matching to avoid false positives:
   -a "Yu"           - Matches last name "Yu" exactly (not "Yujia")
   -a "Timothy Yu"   - Last name "Yu" + first name starts with "Timothy"
   -a "Yu, Timothy"  - Same as above (Last, First format)
+  -a "Tim Yu" --exact-author - First name exactly "Tim" (not "Timothy")
 
 When multiple authors are specified, all must match (AND logic).
 
@@ -79,29 +87,49 @@ Examples:
   bip search -a "Yu" -a "Bloom" --year 2022:
   bip search --title "SARS-CoV-2" --venue Nature
   bip search --doi "10.1126/science.abf4063"
-  bip search --tag "antibody"`,
+  bip search --tag "antibody"
+  bip search "phylogenetics" --sort -year`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runSearch,
 }
 
+// resolveSearchLimit returns the --limit flag value if given, otherwise the
+// configured search.default_limit, otherwise fallback. Exits on a negative limit.
+func resolveSearchLimit(cmd *cobra.Command, cfg *config.Config, fallback int) int {
+	limit := cfg.SearchLimit(fallback)
+	if cmd.Flags().Changed("limit") {
+		limit, _ = cmd.Flags().GetInt("limit")
+	}
+	if limit < 0 {
+		exitWithError(ExitError, "--limit must not be negative")
+	}
+	return limit
+}
+
 func runSearch(cmd *cobra.Command, args []string) error {
 	repoRoot := mustFindRepository()
+	limit := resolveSearchLimit(cmd, mustLoadConfig(repoRoot), DefaultSearchLimit)
 	db := mustOpenDatabase(repoRoot)
 	defer db.Close()
 
-	var refs []reference.Reference
-	var err error
+	order, err := storage.ParseSortOrder(searchSort)
+	if err != nil {
+		exitWithError(ExitError, "%v", err)
+	}
+
+	var items listItems[reference.Reference]
 
 	// Check if using flag-based search
 	hasFilterFlags := hasAnyFilterFlags()
 
 	if hasFilterFlags {
 		filters := storage.SearchFilters{
-			Authors: searchAuthors,
-			Title:   searchTitle,
-			Venue:   searchVenue,
-			DOI:     searchDOI,
-			Tag:     searchTag,
+			Authors:      searchAuthors,
+			ExactAuthors: searchExact,
+			Title:        searchTitle,
+			Venue:        searchVenue,
+			DOI:          searchDOI,
+			Tag:          searchTag,
 		}
 
 		if len(args) > 0 {
@@ -117,7 +145,9 @@ func runSearch(cmd *cobra.Command, args []string) error {
 			filters.YearTo = to
 		}
 
-		refs, err = db.SearchWithFilters(filters, searchLimit)
+		items = func(yield func(reference.Reference) error) error {
+			return db.SearchWithFiltersEach(filters, limit, order, yield)
+		}
 	} else if len(args) > 0 {
 		// Legacy behavior: positional query argument
 		query := args[0]
@@ -125,37 +155,43 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		// Check for field-specific searches (legacy syntax)
 		if strings.HasPrefix(query, "author:") {
 			value := strings.TrimPrefix(query, "author:")
-			refs, err = db.SearchField("author", value, searchLimit)
+			items = func(yield func(reference.Reference) error) error {
+				return db.SearchFieldEach("author", value, limit, order, yield)
+			}
 		} else if strings.HasPrefix(query, "title:") {
 			value := strings.TrimPrefix(query, "title:")
-			refs, err = db.SearchField("title", value, searchLimit)
+			items = func(yield func(reference.Reference) error) error {
+				return db.SearchFieldEach("title", value, limit, order, yield)
+			}
 		} else {
-			refs, err = db.Search(query, searchLimit)
+			items = func(yield func(reference.Reference) error) error {
+				return db.SearchEach(query, limit, order, yield)
+			}
 		}
 	} else {
 		exitWithError(ExitError, "must specify a query or at least one filter (--author, --year)")
 	}
 
-	if err != nil {
-		exitWithError(ExitError, "searching: %v", err)
+	if !humanOutput {
+		// Empty result is not an error
+		err := outputList(items, func(refs []reference.Reference) any { return refs })
+		if err != nil {
+			exitWithError(ExitError, "searching: %v", err)
+		}
+		return nil
 	}
 
-	// Empty result is not an error
-	if refs == nil {
-		refs = []reference.Reference{}
+	refs, err := collectItems(items)
+	if err != nil {
+		exitWithError(ExitError, "searching: %v", err)
 	}
-
-	if humanOutput {
-		if len(refs) == 0 {
-			fmt.Println("No references found")
-		} else {
-			fmt.Printf("Found %d references:\n\n", len(refs))
-			for i, ref := range refs {
-				printRefSummary(i+1, ref)
-			}
-		}
+	if len(refs) == 0 {
+		fmt.Println("No references found")
 	} else {
-		outputJSON(refs)
+		fmt.Printf("Found %d references:\n\n", len(refs))
+		for i, ref := range refs {
+			printRefSummary(i+1, ref)
+		}
 	}
 
 	return nil
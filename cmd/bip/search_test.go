@@ -1,6 +1,11 @@
 package main
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/spf13/cobra"
+)
 
 func TestParseYearRange(t *testing.T) {
 	tests := []struct {
@@ -54,3 +59,32 @@ func TestParseYearRange(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveSearchLimit(t *testing.T) {
+	configured := 200
+	unlimited := 0
+	tests := []struct {
+		name string
+		cfg  config.Config
+		args []string
+		want int
+	}{
+		{"unset uses fallback", config.Config{}, nil, 50},
+		{"configured default", config.Config{Search: config.SearchSettings{DefaultLimit: &configured}}, nil, 200},
+		{"configured unlimited", config.Config{Search: config.SearchSettings{DefaultLimit: &unlimited}}, nil, 0},
+		{"flag overrides config", config.Config{Search: config.SearchSettings{DefaultLimit: &configured}}, []string{"--limit", "5"}, 5},
+		{"explicit flag equal to default", config.Config{Search: config.SearchSettings{DefaultLimit: &configured}}, []string{"--limit", "50"}, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Int("limit", 50, "")
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := resolveSearchLimit(cmd, &tt.cfg, 50); got != tt.want {
+				t.Errorf("resolveSearchLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/matsen/bipartite/internal/embedding"
+	"github.com/matsen/bipartite/internal/semantic"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +29,7 @@ type SemanticResponse struct {
 	Total     int                 `json:"total"`
 	Threshold float32             `json:"threshold"`
 	Model     string              `json:"model"`
+	ByPaper   bool                `json:"by_paper,omitempty"` // Query was an indexed paper ID; its stored embedding was used
 }
 
 var semanticCmd = &cobra.Command{
@@ -38,7 +40,16 @@ var semanticCmd = &cobra.Command{
 Unlike keyword search, semantic search understands the meaning of your query
 and finds papers with related concepts, even without exact word matches.
 
-Requires the semantic index to be built first with 'bip index build'.`,
+If the query is the ID of an indexed paper, its stored embedding is used as
+the query vector and the paper itself is left out of the results. This
+needs no running Ollama. Free-text queries are embedded with Ollama; if it
+is not running, the JSON error carries "error_code": "ollama_unavailable".
+
+Requires the semantic index to be built first with 'bip index build'.
+
+Examples:
+  bip semantic "antibody language models"
+  bip semantic Smith2024-ab --limit 5`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSemantic,
 }
@@ -57,18 +68,26 @@ func runSemantic(cmd *cobra.Command, args []string) error {
 	// Load index
 	idx := mustLoadSemanticIndex(repoRoot)
 
-	// Check Ollama availability (no model check needed for query-only operations)
-	provider := embedding.NewOllamaProvider()
-	mustValidateOllama(ctx, provider, false)
-
-	// Generate query embedding
-	queryEmb, err := provider.Embed(ctx, query)
-	if err != nil {
-		exitWithError(ExitError, "generating query embedding: %v", err)
+	var results []semantic.SearchResult
+	var err error
+	byPaper := idx.HasPaper(query)
+	model := idx.ModelName
+	if byPaper {
+		// Reuse the paper's stored embedding; Ollama is not needed
+		results, err = idx.SearchByPaper(query, semanticLimit, semanticThreshold)
+	} else {
+		// No model check needed for query-only operations
+		provider := mustEmbeddingProvider(ctx, repoRoot, false)
+		mustMatchIndexProvider(idx, provider)
+		model = provider.ModelName()
+
+		// Generate query embedding
+		queryEmb, embedErr := provider.Embed(ctx, query)
+		if embedErr != nil {
+			exitWithError(ExitError, "generating query embedding: %v", embedErr)
+		}
+		results, err = idx.Search(queryEmb.Vector, semanticLimit, semanticThreshold)
 	}
-
-	// Search
-	results, err := idx.Search(queryEmb.Vector, semanticLimit, semanticThreshold)
 	if err != nil {
 		exitWithError(ExitError, "searching index: %v", err)
 	}
@@ -91,9 +110,20 @@ func runSemantic(cmd *cobra.Command, args []string) error {
 			Results:   semanticResults,
 			Total:     len(semanticResults),
 			Threshold: semanticThreshold,
-			Model:     provider.ModelName(),
+			Model:     model,
+			ByPaper:   byPaper,
 		})
 	}
 
 	return nil
 }
+
+// mustMatchIndexProvider exits if the index was built with a different
+// embedding provider or model than the one configured, since query vectors
+// from another model are not comparable with the indexed ones.
+func mustMatchIndexProvider(idx *semantic.SemanticIndex, provider embedding.Provider) {
+	if idx.ProviderName() != provider.Name() || idx.ModelName != provider.ModelName() {
+		exitWithError(ExitIndexStale, "semantic index was built with %s/%s but %s/%s is configured\n\nRun 'bip index build --force' to rebuild it.",
+			idx.ProviderName(), idx.ModelName, provider.Name(), provider.ModelName())
+	}
+}
@@ -36,10 +36,16 @@ var similarCmd = &cobra.Command{
 	Short: "Find papers similar to a specific paper",
 	Long: `Find papers that are semantically similar to a given paper.
 
-This uses the paper's abstract to find other papers with related content.
-The source paper is excluded from results.
+Ranks every other indexed paper by the cosine similarity of its stored
+abstract embedding to the given paper's, returning the top --limit with
+their similarity scores. The source paper is excluded from results.
 
-Requires the semantic index to be built first with 'bip index build'.`,
+This compares stored vectors only, so Ollama is not needed. Requires the
+semantic index to be built first with 'bip index build'.
+
+Examples:
+  bip similar Zhang2018-vi
+  bip similar Zhang2018-vi --limit 5 --human`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSimilar,
 }
@@ -1,23 +1,16 @@
 package main
 
-import (
-	"github.com/joho/godotenv"
-	"github.com/spf13/cobra"
-)
-
-// Exit codes specific to slack commands (from spec.md)
-const (
-	ExitSlackMissingToken    = 1 // SLACK_BOT_TOKEN not set
-	ExitSlackChannelNotFound = 2 // Channel not in configuration
-	ExitSlackNotMember       = 3 // Bot not member of channel
-)
+import "github.com/spf13/cobra"
 
 var slackCmd = &cobra.Command{
 	Use:   "slack",
 	Short: "Slack channel integration commands",
-	Long: `Commands for reading from Slack channels.
+	Long: `Commands for reading from and posting to Slack channels.
 
-Fetch message history, list configured channels, and analyze team activity.
+Fetch message history, list configured channels, inspect or rebuild the
+cached user names, and analyze team activity.
+The 'post' subcommand sends a message through a channel's webhook instead,
+and 'digest' summarizes a channel's history and can post the summary.
 Requires a Slack bot token with channels:history, channels:read, and
 users:read scopes. Sourced from BIP_SLACK_TOKEN (recommended) or
 SLACK_BOT_TOKEN, falling back to slack_bot_token in ~/.config/bip/config.yml.
@@ -31,8 +24,5 @@ Use --human flag for human-readable output.`,
 }
 
 func init() {
-	// Load .env file if present (for SLACK_BOT_TOKEN)
-	_ = godotenv.Load()
-
 	rootCmd.AddCommand(slackCmd)
 }
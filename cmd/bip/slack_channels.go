@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -64,9 +63,7 @@ func runSlackChannels(cmd *cobra.Command, args []string) error {
 }
 
 func outputSlackChannelsJSON(response flow.ChannelsResponse) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(response)
+	return outputJSON(response)
 }
 
 func outputSlackChannelsHuman(response flow.ChannelsResponse) error {
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/flow"
+	"github.com/spf13/cobra"
+)
+
+var (
+	slackDigestChannel string
+	slackDigestSince   string
+	slackDigestLimit   int
+	slackDigestThreads bool
+	slackDigestPostTo  string
+)
+
+var slackDigestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Summarize a Slack channel's recent activity",
+	Long: `Summarize a configured channel's messages over a period.
+
+History is fetched as 'bip slack history' does, then counted by day and
+author. The summary is a short markdown message with the channel's
+purpose from sources.yml, the message total, the most active
+participants, and one line per day. With --threads, thread replies are
+fetched and counted too.
+
+By default the digest is printed: JSON with the counts and the rendered
+summary in "markdown", or just the summary with --human. With --post-to,
+the summary is posted through that channel's incoming webhook
+(SLACK_WEBHOOK_<CHANNEL>, or slack_webhooks in ~/.config/bip/config.yml).
+
+Examples:
+  bip slack digest --channel fortnight-goals
+  bip slack digest --channel fortnight-goals --since 14d --human
+  bip slack digest --channel fortnight-goals --since 2026-01-01 --threads
+  bip slack digest --channel fortnight-goals --post-to eng`,
+	Args: cobra.NoArgs,
+	RunE: runSlackDigest,
+}
+
+func init() {
+	slackCmd.AddCommand(slackDigestCmd)
+	slackDigestCmd.Flags().StringVar(&slackDigestChannel, "channel", "", "Channel to summarize (required)")
+	slackDigestCmd.Flags().StringVar(&slackDigestSince, "since", "7d", "Start of the period: a duration (7d, 2w, 36h) or a date (YYYY-MM-DD)")
+	slackDigestCmd.Flags().IntVar(&slackDigestLimit, "limit", 1000, "Maximum messages to fetch")
+	slackDigestCmd.Flags().BoolVar(&slackDigestThreads, "threads", false, "Also fetch and count thread replies")
+	slackDigestCmd.Flags().StringVar(&slackDigestPostTo, "post-to", "", "Post the summary to this channel's webhook instead of printing it")
+	slackDigestCmd.MarkFlagRequired("channel")
+}
+
+func runSlackDigest(cmd *cobra.Command, args []string) error {
+	nexusPath := config.MustGetNexusPath()
+	channelName := slackDigestChannel
+
+	since, err := flow.ParseSince(slackDigestSince)
+	if err != nil {
+		return outputSlackError(ExitError, "invalid_date", err.Error())
+	}
+
+	// Check the destination before spending API calls on the history
+	var webhookURL string
+	if slackDigestPostTo != "" {
+		webhookURL = flow.GetWebhookURL(slackDigestPostTo)
+		if webhookURL == "" {
+			return outputSlackError(ExitConfigError, "missing_webhook",
+				fmt.Sprintf("no webhook configured for channel '%s'; set %s", slackDigestPostTo, flow.WebhookEnvVar(slackDigestPostTo)))
+		}
+	}
+
+	channelConfig, err := flow.GetSlackChannel(nexusPath, channelName)
+	if err != nil {
+		return outputSlackError(ExitSlackChannelNotFound, "channel_not_found", err.Error())
+	}
+
+	client, err := flow.NewSlackClient()
+	if err != nil {
+		return outputSlackError(ExitSlackMissingToken, "missing_token", err.Error())
+	}
+	if _, err := client.GetUsers(); err != nil {
+		// Non-fatal: authors are counted under their user IDs
+		fmt.Fprintf(os.Stderr, "Warning: could not load users: %v\n", err)
+	}
+
+	messages, err := client.GetChannelHistory(channelConfig.ID, since, slackDigestLimit)
+	if err != nil {
+		if errors.Is(err, flow.ErrSlackNotInChannel) {
+			return outputSlackError(ExitSlackNotMember, "not_member",
+				fmt.Sprintf("Bot is not a member of channel '%s'. Invite the bot with /invite @bot-name", channelName))
+		}
+		return outputSlackError(ExitError, "api_error", err.Error())
+	}
+	if slackDigestThreads {
+		if err := client.GetThreadReplies(channelConfig.ID, messages, since); err != nil {
+			return outputSlackError(ExitError, "api_error", err.Error())
+		}
+	}
+
+	period := flow.Period{
+		Start: since.Format("2006-01-02"),
+		End:   time.Now().Format("2006-01-02"),
+	}
+	digest := flow.BuildChannelDigest(channelName, channelConfig.ID, channelConfig.Purpose, period, messages)
+
+	if slackDigestPostTo != "" {
+		if err := flow.PostToSlack(webhookURL, digest.Markdown); err != nil {
+			return outputSlackError(ExitError, "api_error", err.Error())
+		}
+		if humanOutput {
+			fmt.Printf("Posted #%s digest (%d messages) to #%s\n", channelName, digest.MessageCount, slackDigestPostTo)
+			return nil
+		}
+		return outputJSON(SlackPostResult{Status: "sent", Channel: slackDigestPostTo})
+	}
+
+	if humanOutput {
+		fmt.Print(digest.Markdown)
+		return nil
+	}
+	return outputJSON(digest)
+}
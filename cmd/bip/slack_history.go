@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -14,9 +13,11 @@ import (
 )
 
 var (
-	slackHistoryDays  int
-	slackHistorySince string
-	slackHistoryLimit int
+	slackHistoryDays    int
+	slackHistorySince   string
+	slackHistoryLimit   int
+	slackHistoryThreads bool
+	slackHistoryNoCache bool
 )
 
 var slackHistoryCmd = &cobra.Command{
@@ -27,12 +28,25 @@ var slackHistoryCmd = &cobra.Command{
 The channel must be configured in sources.yml under slack.channels.
 The bot must be a member of the channel to read messages.
 
+Only top-level messages are fetched by default. With --threads, replies to
+each thread are fetched too (one extra API call per thread) and nested
+under their parent message.
+
+Mention markup in message text is rewritten for reading: <@U...> becomes
+@name from the user cache, <#C...> becomes #channel from sources.yml, and
+<url|text> links become "text (url)". Unknown IDs keep their raw token.
+
+Messages for past days are cached in .bipartite/cache/slack_history/ so
+repeated runs only fetch today's messages live. Use --no-cache to fetch
+the whole window from Slack.
+
 Examples:
   bip slack history fortnight-goals
   bip slack history fortnight-goals --days 7
   bip slack history fortnight-goals --since 2025-01-13
   bip slack history fortnight-goals --human
-  bip slack history fortnight-goals --limit 50`,
+  bip slack history fortnight-goals --limit 50
+  bip slack history fortnight-goals --threads`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSlackHistory,
 }
@@ -42,6 +56,8 @@ func init() {
 	slackHistoryCmd.Flags().IntVar(&slackHistoryDays, "days", 14, "Number of days to fetch")
 	slackHistoryCmd.Flags().StringVar(&slackHistorySince, "since", "", "Start date (YYYY-MM-DD), overrides --days")
 	slackHistoryCmd.Flags().IntVar(&slackHistoryLimit, "limit", 100, "Maximum messages to return")
+	slackHistoryCmd.Flags().BoolVar(&slackHistoryNoCache, "no-cache", false, "Fetch live, ignoring cached past days")
+	slackHistoryCmd.Flags().BoolVar(&slackHistoryThreads, "threads", false, "Also fetch thread replies, nested under their parent")
 }
 
 func runSlackHistory(cmd *cobra.Command, args []string) error {
@@ -59,6 +75,13 @@ func runSlackHistory(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return outputSlackError(ExitSlackMissingToken, "missing_token", err.Error())
 	}
+	client.SetHistoryCache(!slackHistoryNoCache)
+
+	// Channel names for rewriting <#C...> mentions; without them mentions
+	// fall back to the alias in the markup
+	if idToName, err := flow.LoadChannelIDMap(nexusPath); err == nil {
+		client.SetChannelNames(idToName)
+	}
 
 	// Load user cache first (or fetch if empty)
 	if _, err := client.GetUsers(); err != nil {
@@ -82,6 +105,12 @@ func runSlackHistory(cmd *cobra.Command, args []string) error {
 		return outputSlackError(1, "api_error", err.Error())
 	}
 
+	if slackHistoryThreads {
+		if err := client.GetThreadReplies(channelConfig.ID, messages, timeRange.Oldest); err != nil {
+			return outputSlackError(1, "api_error", err.Error())
+		}
+	}
+
 	// Build response
 	response := flow.HistoryResponse{
 		Channel:   channelName,
@@ -101,9 +130,7 @@ func runSlackHistory(cmd *cobra.Command, args []string) error {
 }
 
 func outputSlackHistoryJSON(response flow.HistoryResponse) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(response)
+	return outputJSON(response)
 }
 
 func outputSlackHistoryHuman(response flow.HistoryResponse) error {
@@ -131,12 +158,10 @@ func outputSlackHistoryHuman(response flow.HistoryResponse) error {
 	for _, date := range dates {
 		fmt.Printf("## %s\n\n", date)
 		for _, msg := range byDate[date] {
-			// Truncate long messages for display
-			text := msg.Text
-			if len(text) > 200 {
-				text = text[:200] + "..."
+			fmt.Printf("**%s**: %s\n\n", msg.UserName, truncateSlackText(msg.Text))
+			for _, reply := range msg.Replies {
+				fmt.Printf("  ↳ **%s**: %s\n\n", reply.UserName, truncateSlackText(reply.Text))
 			}
-			fmt.Printf("**%s**: %s\n\n", msg.UserName, text)
 		}
 	}
 
@@ -144,14 +169,24 @@ func outputSlackHistoryHuman(response flow.HistoryResponse) error {
 	return nil
 }
 
-// SlackErrorResult is the JSON output for Slack errors.
+// truncateSlackText truncates long messages for display.
+func truncateSlackText(text string) string {
+	if len(text) > 200 {
+		return text[:200] + "..."
+	}
+	return text
+}
+
+// SlackErrorResult is the JSON output for Slack errors. Error is the
+// Slack-specific cause; ErrorCode is the exit code's class.
 type SlackErrorResult struct {
 	Error      string `json:"error"`
+	ErrorCode  string `json:"error_code"`
 	Message    string `json:"message"`
 	Suggestion string `json:"suggestion,omitempty"`
 }
 
-func outputSlackError(exitCode int, errorCode, message string) error {
+func outputSlackError(exitCode ExitCode, errorCode, message string) error {
 	suggestion := ""
 	switch errorCode {
 	case "missing_token":
@@ -160,10 +195,13 @@ func outputSlackError(exitCode int, errorCode, message string) error {
 		suggestion = "Check that the channel is configured in sources.yml under slack.channels"
 	case "not_member":
 		suggestion = "Invite the bot to the channel with /invite @bot-name"
+	case "missing_webhook":
+		suggestion = "Set the channel's webhook environment variable, or add it to slack_webhooks in ~/.config/bip/config.yml"
 	}
 
 	result := SlackErrorResult{
 		Error:      errorCode,
+		ErrorCode:  exitCode.ErrorCode(),
 		Message:    message,
 		Suggestion: suggestion,
 	}
@@ -174,11 +212,9 @@ func outputSlackError(exitCode int, errorCode, message string) error {
 			fmt.Fprintf(os.Stderr, "Suggestion: %s\n", suggestion)
 		}
 	} else {
-		enc := json.NewEncoder(os.Stderr)
-		enc.SetIndent("", "  ")
-		enc.Encode(result)
+		writeJSON(os.Stderr, result)
 	}
 
-	os.Exit(exitCode)
+	os.Exit(exitCode.Status())
 	return nil
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/flow"
+	"github.com/spf13/cobra"
+)
+
+var (
+	slackPostChannel string
+	slackPostMessage string
+	slackPostDryRun  bool
+)
+
+var slackPostCmd = &cobra.Command{
+	Use:   "post",
+	Short: "Post a message to a Slack channel via its webhook",
+	Long: `Post a message to a channel using its incoming webhook.
+
+The webhook comes from the SLACK_WEBHOOK_<CHANNEL> environment variable,
+falling back to slack_webhooks in ~/.config/bip/config.yml. The message is
+taken from --message, or read from stdin if --message is not given.
+
+With --dry-run, the payload is printed and nothing is sent.
+
+Examples:
+  bip slack post --channel eng --message "Deploy finished"
+  echo "Nightly build failed" | bip slack post --channel eng
+  bip slack post --channel eng --message "test" --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runSlackPost,
+}
+
+func init() {
+	slackCmd.AddCommand(slackPostCmd)
+	slackPostCmd.Flags().StringVar(&slackPostChannel, "channel", "", "Channel to post to (required)")
+	slackPostCmd.Flags().StringVar(&slackPostMessage, "message", "", "Message text (default: read from stdin)")
+	slackPostCmd.Flags().BoolVar(&slackPostDryRun, "dry-run", false, "Print the payload without sending")
+	slackPostCmd.MarkFlagRequired("channel")
+}
+
+// SlackPostResult is the JSON output for bip slack post.
+type SlackPostResult struct {
+	Status            string            `json:"status"` // "sent" or "dry_run"
+	Channel           string            `json:"channel"`
+	Payload           map[string]string `json:"payload,omitempty"`            // Dry run only
+	WebhookConfigured *bool             `json:"webhook_configured,omitempty"` // Dry run only
+}
+
+func runSlackPost(cmd *cobra.Command, args []string) error {
+	message := slackPostMessage
+	if !cmd.Flags().Changed("message") {
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return outputSlackError(1, "io_error", err.Error())
+		}
+		message = string(input)
+	}
+	message = strings.TrimRight(message, "\n")
+	if strings.TrimSpace(message) == "" {
+		return outputSlackError(1, "empty_message", "message is empty; pass --message or pipe text on stdin")
+	}
+
+	webhookURL := flow.GetWebhookURL(slackPostChannel)
+
+	if slackPostDryRun {
+		configured := webhookURL != ""
+		result := SlackPostResult{
+			Status:            "dry_run",
+			Channel:           slackPostChannel,
+			Payload:           flow.WebhookPayload(message),
+			WebhookConfigured: &configured,
+		}
+		if humanOutput {
+			fmt.Printf("Would post to #%s:\n", slackPostChannel)
+			data, _ := json.MarshalIndent(result.Payload, "", "  ")
+			fmt.Println(string(data))
+			if !configured {
+				fmt.Printf("Warning: no webhook configured; set %s\n", flow.WebhookEnvVar(slackPostChannel))
+			}
+			return nil
+		}
+		outputJSON(result)
+		return nil
+	}
+
+	if webhookURL == "" {
+		return outputSlackError(1, "missing_webhook",
+			fmt.Sprintf("no webhook configured for channel '%s'; set %s", slackPostChannel, flow.WebhookEnvVar(slackPostChannel)))
+	}
+	if err := flow.PostToSlack(webhookURL, message); err != nil {
+		return outputSlackError(1, "api_error", err.Error())
+	}
+
+	if humanOutput {
+		fmt.Printf("Posted to #%s\n", slackPostChannel)
+		return nil
+	}
+	outputJSON(SlackPostResult{Status: "sent", Channel: slackPostChannel})
+	return nil
+}
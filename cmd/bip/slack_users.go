@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/matsen/bipartite/internal/flow"
+	"github.com/spf13/cobra"
+)
+
+var slackUsersRefresh bool
+
+var slackUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "List or rebuild the cached Slack user names",
+	Long: `List the cached mapping from Slack user IDs to display names.
+
+Commands like 'bip slack history' resolve message authors through a cache
+in .bipartite/cache/slack_users.json. New users are added to it as they
+are seen, but a renamed user keeps their old name there. A message that
+shows a raw user ID (U...) instead of a name usually means that user is
+missing from the cache.
+
+Listing reads the cache only and needs no Slack token. With --refresh,
+the full workspace user list is fetched from Slack and replaces the
+cache, dropping stale names and users who have left.
+
+Examples:
+  bip slack users --human
+  bip slack users --refresh
+  bip slack users --refresh --human`,
+	Args: cobra.NoArgs,
+	RunE: runSlackUsers,
+}
+
+func init() {
+	slackCmd.AddCommand(slackUsersCmd)
+	slackUsersCmd.Flags().BoolVar(&slackUsersRefresh, "refresh", false, "Re-fetch all workspace users from Slack and overwrite the cache")
+}
+
+func runSlackUsers(cmd *cobra.Command, args []string) error {
+	var users map[string]string
+	if slackUsersRefresh {
+		client, err := flow.NewSlackClient()
+		if err != nil {
+			return outputSlackError(ExitSlackMissingToken, "missing_token", err.Error())
+		}
+		users, err = client.RefreshUsers()
+		if err != nil {
+			return outputSlackError(ExitError, "api_error", err.Error())
+		}
+	} else {
+		var err error
+		users, err = flow.ReadSlackUserCache()
+		if err != nil {
+			return outputSlackError(ExitDataError, "cache_error", err.Error())
+		}
+	}
+
+	response := flow.UsersResponse{
+		Users:     sortedSlackUsers(users),
+		Count:     len(users),
+		Refreshed: slackUsersRefresh,
+		CachePath: flow.SlackUserCachePath(),
+	}
+
+	if humanOutput {
+		return outputSlackUsersHuman(response)
+	}
+	return outputJSON(response)
+}
+
+// sortedSlackUsers returns the cache entries sorted by name
+// (case-insensitively), then ID.
+func sortedSlackUsers(users map[string]string) []flow.UserInfo {
+	infos := make([]flow.UserInfo, 0, len(users))
+	for id, name := range users {
+		infos = append(infos, flow.UserInfo{ID: id, Name: name})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		a, b := strings.ToLower(infos[i].Name), strings.ToLower(infos[j].Name)
+		if a != b {
+			return a < b
+		}
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+func outputSlackUsersHuman(response flow.UsersResponse) error {
+	fmt.Println("# Cached Slack Users")
+	fmt.Println()
+
+	if response.Count == 0 {
+		fmt.Printf("No users cached in %s.\n", response.CachePath)
+		fmt.Println("Run 'bip slack users --refresh' to fetch them from Slack.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME")
+	fmt.Fprintln(w, "--\t----")
+	for _, u := range response.Users {
+		fmt.Fprintf(w, "%s\t%s\n", u.ID, u.Name)
+	}
+	w.Flush()
+
+	if response.Refreshed {
+		fmt.Printf("\nCached %d users in %s\n", response.Count, response.CachePath)
+	} else {
+		fmt.Printf("\nTotal: %d users\n", response.Count)
+	}
+	return nil
+}
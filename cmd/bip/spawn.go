@@ -1,13 +1,16 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/matsen/bipartite/internal/config"
@@ -30,6 +33,21 @@ The ref can be:
 Or use --prompt without a ref for adhoc sessions:
   - bip spawn --prompt "Explore the clamping question"
 
+Use --list to show the windows spawned in the current tmux session, i.e.
+those named repo#N or adhoc-<timestamp> (custom --name windows are not
+recognized). Output is JSON unless --human is given.
+
+Output is a JSON result (window name, repo, number, URL, work dir, context
+file, and status) unless --human is given.
+
+Use --dry-run to report the full prompt (after fetching the issue or PR and
+adding project context) without touching tmux or creating a worktree; with
+--human the prompt alone goes to stdout. It works outside tmux.
+
+Use --kill <name> to close one window, or --kill-all to close every window
+--list shows except the one bip runs in; windows with other names are never
+touched. --kill-all asks for confirmation unless --force is given.
+
 Requires:
   - Running inside tmux
   - Repository defined in sources.yml (unless using --prompt alone)
@@ -42,6 +60,11 @@ var spawnPrompt string
 var spawnPromptFile string
 var spawnDir string
 var spawnName string
+var spawnList bool
+var spawnKill string
+var spawnKillAll bool
+var spawnForce bool
+var spawnDryRun bool
 
 func init() {
 	rootCmd.AddCommand(spawnCmd)
@@ -49,29 +72,116 @@ func init() {
 	spawnCmd.Flags().StringVar(&spawnPromptFile, "prompt-file", "", "Read prompt from file (avoids shell expansion issues)")
 	spawnCmd.Flags().StringVar(&spawnDir, "dir", "", "Working directory override (default: from sources.yml)")
 	spawnCmd.Flags().StringVar(&spawnName, "name", "", "Tmux window name override (default: repo#N)")
+	spawnCmd.Flags().BoolVar(&spawnList, "list", false, "List spawned windows in the current tmux session")
+	spawnCmd.Flags().StringVar(&spawnKill, "kill", "", "Kill the tmux window with this name")
+	spawnCmd.Flags().BoolVar(&spawnKillAll, "kill-all", false, "Kill all spawned windows in the current tmux session")
+	spawnCmd.Flags().BoolVar(&spawnForce, "force", false, "With --kill-all, skip the confirmation prompt")
+	spawnCmd.Flags().BoolVar(&spawnDryRun, "dry-run", false, "Print the prompt that would be sent instead of creating a window")
+	spawnCmd.MarkFlagsMutuallyExclusive("dry-run", "list", "kill", "kill-all")
 }
 
 func resolvePrompt() string {
 	if spawnPromptFile != "" {
 		data, err := os.ReadFile(spawnPromptFile)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Could not read prompt file: %v\n", err)
-			os.Exit(1)
+			spawnError("Could not read prompt file: %v", err)
 		}
 		return string(data)
 	}
 	return spawnPrompt
 }
 
+// SpawnResult is the response for spawn.
+type SpawnResult struct {
+	WindowName  string `json:"window_name"`
+	Repo        string `json:"repo,omitempty"`      // org/repo; empty for adhoc sessions
+	Number      int    `json:"number,omitempty"`    // Issue or PR number
+	ItemType    string `json:"item_type,omitempty"` // "issue" or "pr"
+	URL         string `json:"url,omitempty"`
+	WorkDir     string `json:"work_dir"`
+	ContextPath string `json:"context_path,omitempty"` // Project context file prepended to the prompt
+	Status      string `json:"status"`                 // "created", "exists", or "dry_run"
+	Prompt      string `json:"prompt,omitempty"`       // With --dry-run, the prompt that would be sent
+}
+
+// spawnError reports an error and exits: as "Error: ..." on stderr with
+// --human, as the shared JSON error otherwise.
+func spawnError(format string, args ...interface{}) {
+	if humanOutput {
+		fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
+		os.Exit(ExitError.Status())
+	}
+	exitWithError(ExitError, format, args...)
+}
+
+// spawnStatusOut is where spawn prints human progress messages: stdout
+// normally, stderr with --dry-run so stdout holds only the prompt.
+func spawnStatusOut() io.Writer {
+	if spawnDryRun {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// finishSpawn creates the window for result, or with --dry-run only
+// reports it, and writes the outcome. Under --human the caller has already
+// printed the "Spawning" line.
+func finishSpawn(result SpawnResult, prompt string) {
+	if spawnDryRun {
+		result.Status = "dry_run"
+		if humanOutput {
+			fmt.Fprintf(os.Stderr, "Dry run: would spawn tmux window %s in %s\n", result.WindowName, result.WorkDir)
+			fmt.Println(prompt)
+			return
+		}
+		result.Prompt = prompt
+		outputJSON(result)
+		return
+	}
+
+	result.Status = "exists"
+	if spawnWindow(result.WindowName, result.WorkDir, prompt, result.URL) {
+		result.Status = "created"
+	}
+	if !humanOutput {
+		outputJSON(result)
+		return
+	}
+	if result.Status == "exists" {
+		fmt.Printf("Window %s already exists, skipping\n", result.WindowName)
+	}
+	// Print URL as last line for easy clicking
+	if result.URL != "" {
+		fmt.Println(result.URL)
+	}
+}
+
 func runSpawn(cmd *cobra.Command, args []string) {
+	if spawnList || spawnKill != "" || spawnKillAll {
+		if len(args) > 0 {
+			exitWithError(ExitError, "cannot combine --list, --kill, or --kill-all with a reference")
+		}
+		if !spawn.IsInTmux() {
+			exitWithError(ExitError, "must be running inside tmux")
+		}
+		switch {
+		case spawnList:
+			runSpawnList()
+		case spawnKill != "":
+			runSpawnKill(spawnKill)
+		default:
+			runSpawnKillAll()
+		}
+		return
+	}
+
 	// Resolve prompt from --prompt or --prompt-file
 	spawnPrompt = resolvePrompt()
 
 	// Handle adhoc mode (--prompt without ref) - doesn't need nexus directory
 	if len(args) == 0 {
 		if spawnPrompt == "" {
-			fmt.Fprintf(os.Stderr, "Error: Either provide a GitHub reference, --prompt, or --prompt-file for adhoc sessions\n")
-			os.Exit(1)
+			spawnError("Either provide a GitHub reference, --prompt, or --prompt-file for adhoc sessions")
 		}
 		runAdhocSpawn()
 		return
@@ -83,8 +193,7 @@ func runSpawn(cmd *cobra.Command, args []string) {
 	// Parse GitHub reference
 	ref := flow.ParseGitHubRef(args[0])
 	if ref == nil {
-		fmt.Fprintf(os.Stderr, "Error: Invalid format. Expected org/repo#number or GitHub URL\n")
-		os.Exit(1)
+		spawnError("Invalid format. Expected org/repo#number or GitHub URL")
 	}
 
 	// Resolve working directory. Three paths:
@@ -103,24 +212,33 @@ func runSpawn(cmd *cobra.Command, args []string) {
 		earlyResolve, err := flow.ResolveRepoPath(nexusPath, ref.Repo, flow.ResolveContext{})
 		if err != nil {
 			if errors.Is(err, flow.ErrRepoNotInSources) {
-				fmt.Fprintf(os.Stderr, "Error: Repo %s not found in sources.yml\n", ref.Repo)
-				fmt.Fprintf(os.Stderr, "Add it to sources.yml under 'code' or 'writing' category\n")
-				os.Exit(1)
+				spawnError("Repo %s not found in sources.yml\nAdd it to sources.yml under 'code' or 'writing' category", ref.Repo)
 			}
-			fmt.Fprintf(os.Stderr, "Error: resolving repo path: %v\n", err)
-			os.Exit(1)
+			spawnError("resolving repo path: %v", err)
 		}
 		// earlyResolve.Path is always the canonical clone (worktree mode
 		// with an empty context falls back to canonical).
 		canonicalClone = earlyResolve.Path
 		if _, err := os.Stat(canonicalClone); os.IsNotExist(err) {
-			fmt.Fprintf(os.Stderr, "Error: Local clone not found at %s\n", canonicalClone)
-			fmt.Fprintf(os.Stderr, "Clone it with: git clone git@github.com:%s.git %s\n", ref.Repo, canonicalClone)
-			os.Exit(1)
+			spawnError("Local clone not found at %s\nClone it with: git clone git@github.com:%s.git %s", canonicalClone, ref.Repo, canonicalClone)
 		}
 		// repoPath is filled in below once we know the issue/PR context.
 	}
 
+	// Load the repo's prompt template, if any, before fetching anything so
+	// a malformed template fails fast
+	var taskTemplate *template.Template
+	if spawnPrompt == "" {
+		if path := flow.GetRepoPromptTemplatePath(nexusPath, ref.Repo); path != "" {
+			var err error
+			taskTemplate, err = loadPromptTemplate(path)
+			if err != nil {
+				spawnError("%v", err)
+			}
+			fmt.Fprintf(os.Stderr, "Using prompt template %s\n", path)
+		}
+	}
+
 	// Detect item type if not known from URL
 	itemType := ref.ItemType
 	if itemType == "" {
@@ -128,8 +246,7 @@ func runSpawn(cmd *cobra.Command, args []string) {
 		var err error
 		itemType, err = flow.DetectItemType(ref.Repo, ref.Number)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Could not find issue or PR #%d: %v\n", ref.Number, err)
-			os.Exit(1)
+			spawnError("Could not find issue or PR #%d: %v", ref.Number, err)
 		}
 		fmt.Fprintf(os.Stderr, "  → %s\n", itemType)
 	}
@@ -143,8 +260,7 @@ func runSpawn(cmd *cobra.Command, args []string) {
 		data, err = fetchIssueData(ref.Repo, ref.Number)
 	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		spawnError("%v", err)
 	}
 
 	// Final path resolution: now we have a title (and so a slug) plus a
@@ -160,27 +276,25 @@ func runSpawn(cmd *cobra.Command, args []string) {
 		}
 		resolved, err := flow.ResolveRepoPath(nexusPath, ref.Repo, rctx)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: resolving repo path: %v\n", err)
-			os.Exit(1)
+			spawnError("resolving repo path: %v", err)
 		}
 		if resolved.FellBack {
 			fmt.Fprintf(os.Stderr, "Note: worktree mode is configured but this spawn has no issue/PR context; using canonical clone %s\n", resolved.Path)
 		}
-		if resolved.Mode == config.LayoutModeWorktree && resolved.IsNew {
+		if resolved.Mode == config.LayoutModeWorktree && resolved.IsNew && spawnDryRun {
+			fmt.Fprintf(os.Stderr, "Dry run: would create worktree at %s on branch %s\n", resolved.Path, resolved.Branch)
+		} else if resolved.Mode == config.LayoutModeWorktree && resolved.IsNew {
 			// IsNew is filesystem-based (the directory is absent). If git
 			// still has it registered as a worktree — e.g. the directory was
 			// deleted by hand instead of via `bip worktree remove` — then
 			// `git worktree add` would fail with an arcane message. Detect
 			// that and point the user at the fix.
 			if registered, _ := gitx.WorktreeExists(canonicalClone, resolved.Path); registered {
-				fmt.Fprintf(os.Stderr, "Error: %s is registered as a worktree but its directory is missing.\n", resolved.Path)
-				fmt.Fprintf(os.Stderr, "Run `git -C %s worktree prune` (or `bip worktree remove %s`) and retry.\n", canonicalClone, resolved.Path)
-				os.Exit(1)
+				spawnError("%s is registered as a worktree but its directory is missing.\nRun `git -C %s worktree prune` (or `bip worktree remove %s`) and retry.", resolved.Path, canonicalClone, resolved.Path)
 			}
 			fmt.Fprintf(os.Stderr, "Creating worktree at %s on branch %s\n", resolved.Path, resolved.Branch)
 			if err := gitx.AddWorktree(canonicalClone, resolved.Path, resolved.Branch); err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				spawnError("%v", err)
 			}
 		}
 		repoPath = resolved.Path
@@ -195,40 +309,54 @@ func runSpawn(cmd *cobra.Command, args []string) {
 		windowName = fmt.Sprintf("%s#%d", repoName, ref.Number)
 	}
 
-	// Print spawning message first
-	fmt.Printf("Spawning tmux window %s...\n", windowName)
+	if humanOutput && !spawnDryRun {
+		fmt.Printf("Spawning tmux window %s...\n", windowName)
+	}
 
 	// Build prompt
+	var taskSection string
+	if taskTemplate != nil {
+		var err error
+		taskSection, err = renderPromptTemplate(taskTemplate, ref.Repo, ref.Number, itemType, data)
+		if err != nil {
+			spawnError("%v", err)
+		}
+	}
 	var prompt string
 	if spawnPrompt != "" {
 		prompt = buildCustomPrompt(ref.Repo, ref.Number, itemType, spawnPrompt)
 	} else if itemType == "pr" {
-		prompt = buildPRPrompt(ref.Repo, ref.Number, data)
+		prompt = buildPRPrompt(ref.Repo, ref.Number, data, taskSection)
 	} else {
-		prompt = buildIssuePrompt(ref.Repo, ref.Number, data)
+		prompt = buildIssuePrompt(ref.Repo, ref.Number, data, taskSection)
 	}
 
 	// Add project context if available
 	contextPath := flow.GetRepoContextPath(nexusPath, ref.Repo)
 	if contextPath != "" {
-		fmt.Printf("Context: %s\n", contextPath)
+		if humanOutput {
+			fmt.Fprintf(spawnStatusOut(), "Context: %s\n", contextPath)
+		}
 		if contextData, err := os.ReadFile(contextPath); err == nil {
 			prompt = fmt.Sprintf("## Project Context\n\n%s\n\n---\n\n%s", string(contextData), prompt)
 		}
 	}
 
-	// Create tmux window
-	url := flow.GitHubURL(ref.Repo, ref.Number, itemType)
-	spawnWindow(windowName, repoPath, prompt, url)
-
-	// Print URL as last line for easy clicking
-	fmt.Println(url)
+	finishSpawn(SpawnResult{
+		WindowName:  windowName,
+		Repo:        ref.Repo,
+		Number:      ref.Number,
+		ItemType:    itemType,
+		URL:         flow.GitHubURL(ref.Repo, ref.Number, itemType),
+		WorkDir:     repoPath,
+		ContextPath: contextPath,
+	}, prompt)
 }
 
 func runAdhocSpawn() {
 	windowName := spawnName
 	if windowName == "" {
-		windowName = fmt.Sprintf("adhoc-%s", time.Now().Format("2006-01-02-150405"))
+		windowName = spawn.BuildAdhocWindowName(time.Now())
 	}
 	var workDir string
 	if spawnDir != "" {
@@ -237,48 +365,151 @@ func runAdhocSpawn() {
 		var err error
 		workDir, err = os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Could not get working directory: %v\n", err)
-			os.Exit(1)
+			spawnError("Could not get working directory: %v", err)
 		}
 	}
-	fmt.Printf("Spawning tmux window %s...\n", windowName)
-	spawnWindow(windowName, workDir, spawnPrompt, "")
+	if humanOutput && !spawnDryRun {
+		fmt.Printf("Spawning tmux window %s...\n", windowName)
+	}
+	finishSpawn(SpawnResult{WindowName: windowName, WorkDir: workDir}, spawnPrompt)
+}
+
+// SpawnListResult is the response for spawn --list.
+type SpawnListResult struct {
+	Windows []spawn.Window `json:"windows"`
+	Count   int            `json:"count"`
+}
+
+func runSpawnList() {
+	windows, err := spawn.ListSpawnedWindows()
+	if err != nil {
+		exitWithError(ExitError, "%v", err)
+	}
+	if windows == nil {
+		windows = []spawn.Window{}
+	}
+
+	if !humanOutput {
+		outputList(sliceItems(windows), func(windows []spawn.Window) any {
+			return SpawnListResult{Windows: windows, Count: len(windows)}
+		})
+		return
+	}
+	if len(windows) == 0 {
+		fmt.Println("No spawned windows")
+		return
+	}
+	for _, w := range windows {
+		marker := " "
+		if w.Current {
+			marker = "*"
+		}
+		if w.Kind == "item" {
+			fmt.Printf("%s %-30s %s #%d\n", marker, w.Name, w.Repo, w.Number)
+		} else {
+			fmt.Printf("%s %-30s adhoc\n", marker, w.Name)
+		}
+	}
+}
+
+// SpawnKillResult is the response for spawn --kill and --kill-all.
+type SpawnKillResult struct {
+	Killed []string `json:"killed"` // Names of the killed windows
+	Count  int      `json:"count"`
+}
+
+func runSpawnKill(name string) {
+	if err := spawn.KillWindow(name); err != nil {
+		exitWithError(ExitError, "%v", err)
+	}
+	outputSpawnKillResult([]string{name})
+}
+
+func runSpawnKillAll() {
+	if !spawnForce {
+		windows, err := spawn.ListSpawnedWindows()
+		if err != nil {
+			exitWithError(ExitError, "%v", err)
+		}
+		var names []string
+		for _, w := range windows {
+			if !w.Current {
+				names = append(names, w.Name)
+			}
+		}
+		if len(names) == 0 {
+			outputSpawnKillResult(nil)
+			return
+		}
+
+		// Prompt on stderr so JSON output on stdout stays parseable
+		fmt.Fprintf(os.Stderr, "Windows to kill:\n  %s\n", strings.Join(names, "\n  "))
+		fmt.Fprintf(os.Stderr, "Kill %d windows? [y/N] ", len(names))
+		input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(input)) != "y" {
+			exitWithError(ExitError, "no windows killed (use --force to skip confirmation)")
+		}
+	}
+
+	killed, err := spawn.KillSpawnedWindows()
+	names := make([]string, len(killed))
+	for i, w := range killed {
+		names[i] = w.Name
+	}
+	if err != nil {
+		exitWithError(ExitError, "%v (killed %d windows first: %s)", err, len(names), strings.Join(names, ", "))
+	}
+	outputSpawnKillResult(names)
+}
+
+// outputSpawnKillResult reports the killed windows.
+func outputSpawnKillResult(names []string) {
+	if names == nil {
+		names = []string{}
+	}
+	if !humanOutput {
+		outputJSON(SpawnKillResult{Killed: names, Count: len(names)})
+		return
+	}
+	if len(names) == 0 {
+		fmt.Println("No spawned windows to kill")
+		return
+	}
+	for _, name := range names {
+		fmt.Printf("  killed %s\n", name)
+	}
 }
 
 func mustValidateDir(dir string) string {
 	abs, err := filepath.Abs(dir)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Could not resolve directory path: %s\n", dir)
-		os.Exit(1)
+		spawnError("Could not resolve directory path: %s", dir)
 	}
 	info, err := os.Stat(abs)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Directory does not exist: %s\n", abs)
-		os.Exit(1)
+		spawnError("Directory does not exist: %s", abs)
 	}
 	if !info.IsDir() {
-		fmt.Fprintf(os.Stderr, "Error: Path is not a directory: %s\n", abs)
-		os.Exit(1)
+		spawnError("Path is not a directory: %s", abs)
 	}
 	return abs
 }
 
 // spawnWindow validates tmux, checks for duplicates, and creates the window.
-func spawnWindow(windowName, workDir, prompt, url string) {
+// It returns false if a window with the name already exists.
+func spawnWindow(windowName, workDir, prompt, url string) bool {
 	if !spawn.IsInTmux() {
-		fmt.Fprintf(os.Stderr, "Error: Must be running inside tmux\n")
-		os.Exit(1)
+		spawnError("Must be running inside tmux")
 	}
 
 	if spawn.WindowExists(windowName) {
-		fmt.Printf("Window %s already exists, skipping\n", windowName)
-		return
+		return false
 	}
 
 	if err := spawn.CreateWindow(windowName, workDir, prompt, url); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		spawnError("%v", err)
 	}
+	return true
 }
 
 // ItemData contains fetched issue/PR data.
@@ -456,7 +687,28 @@ func fetchPRData(repo string, number int) (*ItemData, error) {
 	return data, nil
 }
 
-func buildIssuePrompt(repo string, number int, data *ItemData) string {
+// issueTaskSection returns the built-in instructions for an issue prompt.
+func issueTaskSection(data *ItemData) string {
+	if len(data.Comments) > 0 {
+		return `Your task:
+1. Read the issue and all comments carefully
+2. Prepare the user to respond to the latest comment
+3. If anything is unclear, explore the codebase to understand it
+4. Summarize the discussion and suggest a response
+
+Do NOT make changes, close, or comment on the issue. Analysis only.`
+	}
+	return `Your task:
+1. Read the issue carefully
+2. Summarize what the issue is asking for
+3. If anything is unclear from the issue itself, explore the codebase to understand it
+
+Do NOT make changes, close, or comment on the issue. Analysis only.`
+}
+
+// buildIssuePrompt builds the prompt for an issue, ending with taskSection,
+// or with the built-in instructions if taskSection is empty.
+func buildIssuePrompt(repo string, number int, data *ItemData, taskSection string) string {
 	body := data.Body
 	if body == "" {
 		body = "(No description)"
@@ -469,24 +721,8 @@ func buildIssuePrompt(repo string, number int, data *ItemData) string {
 
 	relativeCreated := flow.FormatRelativeTime(data.CreatedAt)
 	commentsSection := formatComments(data.Comments)
-
-	hasComments := len(data.Comments) > 0
-	var taskSection string
-	if hasComments {
-		taskSection = `Your task:
-1. Read the issue and all comments carefully
-2. Prepare the user to respond to the latest comment
-3. If anything is unclear, explore the codebase to understand it
-4. Summarize the discussion and suggest a response
-
-Do NOT make changes, close, or comment on the issue. Analysis only.`
-	} else {
-		taskSection = `Your task:
-1. Read the issue carefully
-2. Summarize what the issue is asking for
-3. If anything is unclear from the issue itself, explore the codebase to understand it
-
-Do NOT make changes, close, or comment on the issue. Analysis only.`
+	if taskSection == "" {
+		taskSection = issueTaskSection(data)
 	}
 
 	return fmt.Sprintf(`GitHub issue: %s
@@ -507,29 +743,12 @@ Created: %s
 %s`, data.Title, repo, repo, number, data.State, data.Author, labelsStr, relativeCreated, body, commentsSection, taskSection)
 }
 
-func buildPRPrompt(repo string, number int, data *ItemData) string {
-	body := data.Body
-	if body == "" {
-		body = "(No description)"
-	}
-
-	labelsStr := "(none)"
-	if len(data.Labels) > 0 {
-		labelsStr = strings.Join(data.Labels, ", ")
-	}
-
-	relativeCreated := flow.FormatRelativeTime(data.CreatedAt)
-	commentsSection := formatComments(data.Comments)
-	filesSection := formatFiles(data.Files)
-	reviewsSection := formatReviews(data.Reviews)
-
-	// Determine if user has engaged
-	githubUser, _ := flow.GetGitHubUser()
-	engaged := userHasEngaged(data, githubUser)
-
-	var taskSection string
-	if engaged {
-		taskSection = `Your task:
+// prTaskSection returns the built-in instructions for a PR prompt, which
+// depend on whether the GitHub user has already engaged with the PR.
+func prTaskSection(data *ItemData) string {
+	githubUser, _ := flow.ResolveGitHubUser(config.GetNexusPath(), "", false)
+	if userHasEngaged(data, githubUser) {
+		return `Your task:
 1. Read the PR and all comments/reviews carefully
 2. Start by summarizing the PR description — surface any results, benchmarks,
    or data the author included. Do not skip over this content.
@@ -541,8 +760,8 @@ func buildPRPrompt(repo string, number int, data *ItemData) string {
    the actual code. Fix any errors it finds before presenting to the user.
 
 Do NOT approve, merge, comment, or make changes. Analysis only.`
-	} else {
-		taskSection = `Your task:
+	}
+	return `Your task:
 1. Check @CLAUDE.md in this repo for PR review guidelines and follow them
 2. Start by summarizing the PR description — surface any results, benchmarks,
    or data the author included. Do not skip over this content.
@@ -554,6 +773,27 @@ Do NOT approve, merge, comment, or make changes. Analysis only.`
    the actual code. Fix any errors it finds before presenting to the user.
 
 Do NOT approve, merge, comment, or make changes. Analysis only.`
+}
+
+// buildPRPrompt builds the prompt for a PR, ending with taskSection, or
+// with the built-in instructions if taskSection is empty.
+func buildPRPrompt(repo string, number int, data *ItemData, taskSection string) string {
+	body := data.Body
+	if body == "" {
+		body = "(No description)"
+	}
+
+	labelsStr := "(none)"
+	if len(data.Labels) > 0 {
+		labelsStr = strings.Join(data.Labels, ", ")
+	}
+
+	relativeCreated := flow.FormatRelativeTime(data.CreatedAt)
+	commentsSection := formatComments(data.Comments)
+	filesSection := formatFiles(data.Files)
+	reviewsSection := formatReviews(data.Reviews)
+	if taskSection == "" {
+		taskSection = prTaskSection(data)
 	}
 
 	return fmt.Sprintf(`GitHub PR: %s
@@ -580,6 +820,73 @@ Stats: +%d/-%d in %d commit(s)
 		data.Additions, data.Deletions, data.Commits, body, filesSection, reviewsSection, commentsSection, taskSection)
 }
 
+// promptTemplateData is what a sources.yml prompt_template can reference.
+// Comments, Files, and Reviews are the same formatted sections the built-in
+// prompt includes; Files and Reviews are empty for issues.
+type promptTemplateData struct {
+	Repo      string // org/repo
+	Number    int
+	Type      string // "issue" or "pr"
+	URL       string
+	Title     string
+	Body      string
+	State     string
+	Author    string
+	Labels    string // Comma-separated
+	Comments  string
+	Files     string
+	Reviews   string
+	Additions int
+	Deletions int
+	Commits   int
+}
+
+// loadPromptTemplate reads and parses the prompt template at path. It also
+// renders it once with empty data, so a reference to a field that doesn't
+// exist fails here rather than after the issue or PR is fetched.
+func loadPromptTemplate(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading prompt template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing prompt template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, promptTemplateData{}); err != nil {
+		return nil, fmt.Errorf("checking prompt template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renderPromptTemplate fills tmpl with the item's data, for use as the
+// prompt's task section.
+func renderPromptTemplate(tmpl *template.Template, repo string, number int, itemType string, data *ItemData) (string, error) {
+	td := promptTemplateData{
+		Repo:     repo,
+		Number:   number,
+		Type:     itemType,
+		URL:      flow.GitHubURL(repo, number, itemType),
+		Title:    data.Title,
+		Body:     data.Body,
+		State:    data.State,
+		Author:   data.Author,
+		Labels:   strings.Join(data.Labels, ", "),
+		Comments: formatComments(data.Comments),
+	}
+	if itemType == "pr" {
+		td.Files = formatFiles(data.Files)
+		td.Reviews = formatReviews(data.Reviews)
+		td.Additions, td.Deletions, td.Commits = data.Additions, data.Deletions, data.Commits
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, td); err != nil {
+		return "", fmt.Errorf("rendering prompt template: %w", err)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
 func buildCustomPrompt(repo string, number int, itemType, customPrompt string) string {
 	url := flow.GitHubURL(repo, number, itemType)
 	itemLabel := "Issue"
@@ -119,3 +119,73 @@ func mustRun(t *testing.T, dir, name string, args ...string) {
 		t.Fatalf("%s %s: %v\n%s", name, strings.Join(args, " "), err, out)
 	}
 }
+
+func TestPromptTemplate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pr-review.md")
+	tmplText := `Review {{.Repo}}#{{.Number}} ({{.Type}}): {{.Title}}
+{{if eq .Type "pr"}}+{{.Additions}}/-{{.Deletions}}
+{{.Files}}{{end}}
+Checklist: tests, docs, changelog.
+`
+	if err := os.WriteFile(path, []byte(tmplText), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := loadPromptTemplate(path)
+	if err != nil {
+		t.Fatalf("loadPromptTemplate() error = %v", err)
+	}
+
+	data := &ItemData{
+		Title:     "Add clamping",
+		Additions: 10,
+		Deletions: 2,
+		Files:     []FileData{{Path: "netam/clamp.py", Additions: 10, Deletions: 2}},
+	}
+	task, err := renderPromptTemplate(tmpl, "matsengrp/netam", 42, "pr", data)
+	if err != nil {
+		t.Fatalf("renderPromptTemplate() error = %v", err)
+	}
+	for _, want := range []string{"Review matsengrp/netam#42 (pr): Add clamping", "+10/-2", "netam/clamp.py (+10/-2)", "Checklist: tests, docs, changelog."} {
+		if !strings.Contains(task, want) {
+			t.Errorf("rendered task missing %q:\n%s", want, task)
+		}
+	}
+
+	// The template replaces the built-in task section, keeping the header
+	prompt := buildIssuePrompt("matsengrp/netam", 42, data, task)
+	if !strings.Contains(prompt, "GitHub issue: Add clamping") || !strings.HasSuffix(prompt, "Checklist: tests, docs, changelog.") {
+		t.Errorf("prompt does not end with the template:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "Your task:") {
+		t.Errorf("prompt still has the built-in task section:\n%s", prompt)
+	}
+	if builtin := buildIssuePrompt("matsengrp/netam", 42, data, ""); !strings.Contains(builtin, "Your task:") {
+		t.Errorf("prompt without a template lacks the built-in task section:\n%s", builtin)
+	}
+}
+
+func TestPromptTemplate_Malformed(t *testing.T) {
+	dir := t.TempDir()
+
+	bad := filepath.Join(dir, "bad.md")
+	if err := os.WriteFile(bad, []byte("Review {{.Title"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadPromptTemplate(bad); err == nil || !strings.Contains(err.Error(), "parsing prompt template") {
+		t.Errorf("loadPromptTemplate(malformed) error = %v, want parse error", err)
+	}
+
+	if _, err := loadPromptTemplate(filepath.Join(dir, "missing.md")); err == nil {
+		t.Error("loadPromptTemplate(missing file) succeeded, want error")
+	}
+
+	// An unknown field is caught at load time, before anything is fetched
+	unknown := filepath.Join(dir, "unknown.md")
+	if err := os.WriteFile(unknown, []byte("{{.Milestone}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadPromptTemplate(unknown); err == nil || !strings.Contains(err.Error(), "checking prompt template") {
+		t.Errorf("loadPromptTemplate(unknown field) error = %v, want check error", err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+
+	"github.com/matsen/bipartite/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var storeExportWhere string
+
+func init() {
+	storeCmd.AddCommand(storeExportCmd)
+	storeExportCmd.Flags().StringVarP(&storeExportWhere, "where", "w", "", "SQL WHERE clause selecting records to export")
+}
+
+var storeExportCmd = &cobra.Command{
+	Use:   "export <name>",
+	Short: "Export store records as JSONL",
+	Long: `Write a store's records to stdout as JSONL.
+
+Lines are copied from the store's JSONL file exactly as stored, not
+rebuilt from SQLite, so field values keep their original types. With
+--where, the clause selects matching primary keys in the SQLite index
+(synced first if needed), and only those records are written.
+
+The --where clause is passed to SQLite as-is, exactly as for
+'bip store delete --where'. Only use clauses you wrote yourself; never
+pass through text from an untrusted source.
+
+Examples:
+  bip store export gh_activity > backup.jsonl
+  bip store export gh_activity --where "date >= '2026-01-01'"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStoreExport,
+}
+
+func runStoreExport(cmd *cobra.Command, args []string) error {
+	storeName := args[0]
+	repoRoot := mustFindRepository()
+
+	s, err := store.OpenStore(repoRoot, storeName)
+	if err != nil {
+		exitWithError(ExitError, "store %q not found", storeName)
+	}
+
+	if _, err := s.Export(os.Stdout, storeExportWhere); err != nil {
+		exitWithError(ExitError, "exporting: %v", err)
+	}
+	return nil
+}
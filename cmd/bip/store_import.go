@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/matsen/bipartite/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var storeImportDryRun bool
+
+// StoreImportResult is the response for store import command.
+type StoreImportResult struct {
+	Store  string `json:"store"`
+	DryRun bool   `json:"dry_run,omitempty"`
+	store.ImportResult
+}
+
+func init() {
+	storeCmd.AddCommand(storeImportCmd)
+	storeImportCmd.Flags().BoolVar(&storeImportDryRun, "dry-run", false, "Validate records without writing")
+}
+
+var storeImportCmd = &cobra.Command{
+	Use:   "import <name> <file.jsonl>",
+	Short: "Bulk import records from a JSONL file",
+	Long: `Bulk import records from a JSONL file into a store.
+
+Each line is validated against the store's schema, and its primary key is
+checked against existing records and earlier lines in the file. Invalid
+lines are skipped and reported by line number; the valid records are
+appended and the SQLite index is synced once at the end.
+
+With --dry-run, the file is validated and the same report is produced,
+but nothing is written.
+
+Examples:
+  bip store import gh_activity dump.jsonl
+  bip store import gh_activity dump.jsonl --dry-run --human`,
+	Args: cobra.ExactArgs(2),
+	RunE: runStoreImport,
+}
+
+func runStoreImport(cmd *cobra.Command, args []string) error {
+	storeName, importPath := args[0], args[1]
+	repoRoot := mustFindRepository()
+
+	s, err := store.OpenStore(repoRoot, storeName)
+	if err != nil {
+		exitWithError(ExitError, "store %q not found", storeName)
+	}
+	if _, err := os.Stat(importPath); err != nil {
+		exitWithError(ExitError, "file not found: %q", importPath)
+	}
+
+	imported, err := s.Import(importPath, storeImportDryRun)
+	if err != nil {
+		exitWithError(ExitDataError, "%v", err)
+	}
+	result := StoreImportResult{Store: storeName, DryRun: storeImportDryRun, ImportResult: *imported}
+
+	if humanOutput {
+		verb := "Imported"
+		if storeImportDryRun {
+			verb = "Would import"
+		}
+		fmt.Printf("%s %d records into '%s' (%d skipped)\n", verb, result.Added, storeName, result.Skipped)
+		if len(result.Errors) > 0 {
+			fmt.Println("Skipped:")
+			for _, e := range result.Errors {
+				fmt.Printf("  Line %d: %s\n", e.Line, e.Error)
+			}
+		}
+	} else {
+		outputJSON(result)
+	}
+
+	// Every line invalid: report it, but fail like edge import does
+	if result.Added == 0 && result.Skipped > 0 {
+		os.Exit(ExitError.Status())
+	}
+	return nil
+}
@@ -15,6 +15,8 @@ var storeQueryJSON bool
 var storeQueryCSV bool
 var storeQueryJSONL bool
 var storeQueryCross bool
+var storeQuerySearch string
+var storeQueryLimit int
 
 func init() {
 	storeCmd.AddCommand(storeQueryCmd)
@@ -22,21 +24,27 @@ func init() {
 	storeQueryCmd.Flags().BoolVar(&storeQueryCSV, "csv", false, "Output CSV")
 	storeQueryCmd.Flags().BoolVar(&storeQueryJSONL, "jsonl", false, "Output JSONL")
 	storeQueryCmd.Flags().BoolVarP(&storeQueryCross, "cross", "x", false, "Enable cross-store query")
+	storeQueryCmd.Flags().StringVar(&storeQuerySearch, "search", "", "Full-text search the store's FTS fields instead of running SQL")
+	storeQueryCmd.Flags().IntVar(&storeQueryLimit, "limit", 0, "Maximum results for --search (0 for all)")
 }
 
 var storeQueryCmd = &cobra.Command{
-	Use:   "query <name> <sql>",
+	Use:   "query <name> [<sql>]",
 	Short: "Query a store using SQL",
 	Long: `Execute a SQL query against a store's SQLite index.
 
 For cross-store queries (JOINs across multiple stores), use --cross flag.
 The store name can be omitted with --cross since tables are referenced in SQL.
 
+With --search, the SQL is omitted and the store's FTS fields are searched
+instead, best matches first.
+
 Examples:
   # Basic query
   bip store query gh_activity "SELECT * FROM gh_activity WHERE type = 'pr'"
 
   # Full-text search
+  bip store query gh_activity --search store --limit 10
   bip store query gh_activity "SELECT * FROM gh_activity WHERE id IN (SELECT id FROM gh_activity_fts WHERE gh_activity_fts MATCH 'store')"
 
   # Cross-store query
@@ -63,6 +71,11 @@ func runStoreQuery(cmd *cobra.Command, args []string) error {
 			// Allow "store query --cross storename sql" for consistency
 			sql = args[1]
 		}
+	} else if storeQuerySearch != "" {
+		if len(args) != 1 {
+			exitWithError(ExitError, "usage: bip store query <name> --search <text>")
+		}
+		storeName = args[0]
 	} else {
 		if len(args) < 2 {
 			exitWithError(ExitError, "usage: bip store query <name> <sql>")
@@ -70,11 +83,21 @@ func runStoreQuery(cmd *cobra.Command, args []string) error {
 		storeName = args[0]
 		sql = args[1]
 	}
+	if storeQueryCross && storeQuerySearch != "" {
+		exitWithError(ExitError, "--search cannot be combined with --cross")
+	}
+
+	autoSync := mustLoadConfig(repoRoot).Store.AutoSync
 
 	var records []store.Record
 	var err error
 
 	if storeQueryCross {
+		if autoSync {
+			if err := syncStaleStores(repoRoot); err != nil {
+				exitWithError(ExitDataError, "auto-sync: %v", err)
+			}
+		}
 		records, err = store.QueryCross(repoRoot, sql)
 		if err != nil {
 			exitWithError(ExitError, "SQL error: %v", err)
@@ -86,19 +109,28 @@ func runStoreQuery(cmd *cobra.Command, args []string) error {
 			exitWithError(ExitError, "store %q not found", storeName)
 		}
 
-		// Check if synced
-		needsSync, err := s.NeedsSync()
-		if err != nil {
-			exitWithError(ExitError, "checking sync status: %v", err)
-		}
-		if needsSync {
-			exitWithError(ExitError, "store %q not synced, run 'bip store sync %s' first", storeName, storeName)
+		// Check if synced, unless Query and SearchFTS will sync on their own
+		s.AutoSync = autoSync
+		if !autoSync {
+			needsSync, err := s.NeedsSync()
+			if err != nil {
+				exitWithError(ExitError, "checking sync status: %v", err)
+			}
+			if needsSync {
+				exitWithError(ExitError, "store %q not synced, run 'bip store sync %s' first (or set store.auto_sync: true in .bipartite/config.yml)", storeName, storeName)
+			}
 		}
 
-		// Execute query
-		records, err = s.Query(sql)
-		if err != nil {
-			exitWithError(ExitError, "SQL error: %v", err)
+		if storeQuerySearch != "" {
+			records, err = s.SearchFTS(storeQuerySearch, storeQueryLimit)
+			if err != nil {
+				exitWithError(ExitError, "search error: %v", err)
+			}
+		} else {
+			records, err = s.Query(sql)
+			if err != nil {
+				exitWithError(ExitError, "SQL error: %v", err)
+			}
 		}
 	}
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/matsen/bipartite/internal/store"
 	"github.com/spf13/cobra"
@@ -13,7 +14,8 @@ var storeSyncAll bool
 type StoreSyncResult struct {
 	Store   string `json:"store"`
 	Records int    `json:"records"`
-	Action  string `json:"action"` // "rebuilt" or "skipped"
+	Action  string `json:"action"`          // "rebuilt", "skipped", or "error"
+	Error   string `json:"error,omitempty"` // Set when Action is "error"
 }
 
 // StoreSyncAllResult is the response for store sync --all command.
@@ -32,6 +34,12 @@ var storeSyncCmd = &cobra.Command{
 	Long: `Rebuild the SQLite query index from the JSONL source of truth.
 
 Use this after manually editing JSONL files or after pulling changes from git.
+With --all, every registered store whose JSONL changed is rebuilt; stores
+already in sync are skipped.
+
+To sync automatically before every query, set in .bipartite/config.yml:
+  store:
+    auto_sync: true
 
 Example:
   bip store sync gh_activity    # Sync single store
@@ -111,58 +119,84 @@ func runStoreSyncAll(repoRoot string) error {
 		return nil
 	}
 
-	var results []StoreSyncResult
-
+	names := make([]string, 0, len(registry.Stores))
 	for name := range registry.Stores {
-		s, err := store.OpenStore(repoRoot, name)
-		if err != nil {
-			if humanOutput {
-				fmt.Printf("Error opening '%s': %v\n", name, err)
-			}
-			continue
-		}
-
-		needsSync, err := s.NeedsSync()
-		if err != nil {
-			if humanOutput {
-				fmt.Printf("Error checking '%s': %v\n", name, err)
-			}
-			continue
-		}
-
-		var result StoreSyncResult
-		result.Store = name
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-		if !needsSync {
-			count, _ := s.Count()
-			result.Records = count
-			result.Action = "skipped"
-		} else {
-			count, err := s.Sync()
-			if err != nil {
-				if humanOutput {
-					fmt.Printf("Error syncing '%s': %v\n", name, err)
-				}
-				continue
-			}
-			result.Records = count
-			result.Action = "rebuilt"
-		}
+	results := []StoreSyncResult{}
+	failed := 0
 
+	for _, name := range names {
+		result := syncStore(repoRoot, name)
 		results = append(results, result)
 
 		if humanOutput {
-			if result.Action == "skipped" {
+			switch result.Action {
+			case "skipped":
 				fmt.Printf("'%s' already in sync (skipped)\n", name)
-			} else {
+			case "rebuilt":
 				fmt.Printf("Synced '%s': %d records (rebuilt)\n", name, result.Records)
+			default:
+				fmt.Printf("Error syncing '%s': %s\n", name, result.Error)
 			}
 		}
+		if result.Action == "error" {
+			failed++
+		}
 	}
 
 	if !humanOutput {
 		outputJSON(StoreSyncAllResult{Results: results})
 	}
 
+	if failed > 0 {
+		exitWithError(ExitDataError, "%d of %d stores failed to sync", failed, len(names))
+	}
+
+	return nil
+}
+
+// syncStore syncs a single store if it is stale, recording failures in the
+// result instead of exiting so --all can continue past a broken store.
+func syncStore(repoRoot, name string) StoreSyncResult {
+	result := StoreSyncResult{Store: name}
+
+	s, err := store.OpenStore(repoRoot, name)
+	if err != nil {
+		result.Action = "error"
+		result.Error = err.Error()
+		return result
+	}
+
+	synced, err := s.SyncIfNeeded()
+	if err != nil {
+		result.Action = "error"
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Records, _ = s.Count()
+	if synced {
+		result.Action = "rebuilt"
+	} else {
+		result.Action = "skipped"
+	}
+	return result
+}
+
+// syncStaleStores syncs every registered store whose JSONL has changed.
+// Used by auto-sync before cross-store queries.
+func syncStaleStores(repoRoot string) error {
+	registry, err := store.LoadRegistry(repoRoot)
+	if err != nil {
+		return err
+	}
+	for name := range registry.Stores {
+		if result := syncStore(repoRoot, name); result.Action == "error" {
+			return fmt.Errorf("store %q: %s", name, result.Error)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	supersedeCmd.Flags().Bool("migrate-edges", false, "Move edges from the old paper to the new one")
+	rootCmd.AddCommand(supersedeCmd)
+	rootCmd.AddCommand(supersededByCmd)
+}
+
+var supersedeCmd = &cobra.Command{
+	Use:   "supersede <old-id> <new-id>",
+	Short: "Record that one paper replaces another",
+	Long: `Record that <new-id> supersedes <old-id>, e.g. a journal version
+replacing its preprint, by setting the new paper's supersedes field.
+
+Each paper supersedes at most one other and is superseded by at most one,
+so the versions form a chain; links that would close a cycle are refused.
+
+With --migrate-edges, edges involving the old paper are moved to the new
+one. Edges that would then duplicate an existing edge, or link the new
+paper to itself, are dropped.
+
+Examples:
+  bip supersede Smith2023-pr Smith2024-ab
+  bip supersede Smith2023-pr Smith2024-ab --migrate-edges`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSupersede,
+}
+
+var supersededByCmd = &cobra.Command{
+	Use:   "superseded-by <id>",
+	Short: "Show the newer versions of a paper",
+	Long: `Walk the supersedes chain forward from a paper, listing each paper
+that replaces it in turn, ending with the latest version.
+
+Examples:
+  bip superseded-by Smith2023-pr --human`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSupersededBy,
+}
+
+// SupersedeResult is the response for the supersede command.
+type SupersedeResult struct {
+	Old           string `json:"old"`
+	New           string `json:"new"`
+	EdgesMigrated int    `json:"edges_migrated"`
+	EdgesDropped  int    `json:"edges_dropped"`
+}
+
+// SupersededByResult is the response for the superseded-by command.
+type SupersededByResult struct {
+	ID     string   `json:"id"`
+	Chain  []string `json:"chain"`
+	Latest string   `json:"latest"`
+}
+
+func runSupersede(cmd *cobra.Command, args []string) error {
+	oldID, newID := args[0], args[1]
+	migrate, _ := cmd.Flags().GetBool("migrate-edges")
+
+	repoRoot := mustFindRepository()
+	refsPath := config.RefsPath(repoRoot)
+	refs, err := storage.ReadAll(refsPath)
+	if err != nil {
+		exitWithError(ExitDataError, "reading refs: %v", err)
+	}
+
+	oldIdx, found := storage.FindByID(refs, oldID)
+	if !found {
+		exitWithError(ExitError, "reference not found: %s", oldID)
+	}
+	newIdx, found := storage.FindByID(refs, newID)
+	if !found {
+		exitWithError(ExitError, "reference not found: %s", newID)
+	}
+	if err := reference.CheckSupersede(refs, oldIdx, newIdx); err != nil {
+		exitWithError(ExitDataError, "%v", err)
+	}
+
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+	indexCurrent := db.IndexIsCurrent(storage.IndexRefs, refsPath)
+
+	refs[newIdx].Supersedes = oldID
+	if err := storage.WriteAll(refsPath, refs); err != nil {
+		exitWithError(ExitDataError, "writing refs: %v", err)
+	}
+	if err := db.ApplyIndexUpdate(storage.IndexRefs, refsPath, indexCurrent, func() error { return db.UpsertRef(refs[newIdx]) }); err != nil {
+		exitWithError(ExitDataError, "updating index: %v", err)
+	}
+
+	result := SupersedeResult{Old: oldID, New: newID}
+	if migrate {
+		edgesPath := config.EdgesPath(repoRoot)
+		edges, err := storage.ReadAllEdges(edgesPath)
+		if err != nil {
+			exitWithError(ExitDataError, "reading edges: %v", err)
+		}
+		var kept []edge.Edge
+		kept, result.EdgesMigrated, result.EdgesDropped = migratePaperEdges(edges, oldID, newID)
+		if result.EdgesMigrated > 0 || result.EdgesDropped > 0 {
+			if err := storage.WriteAllEdges(edgesPath, kept); err != nil {
+				exitWithError(ExitDataError, "writing edges: %v", err)
+			}
+			if _, err := db.RebuildEdgesFromJSONL(edgesPath); err != nil {
+				exitWithError(ExitDataError, "rebuilding edges index: %v", err)
+			}
+		}
+	}
+
+	if humanOutput {
+		fmt.Printf("%s now supersedes %s\n", newID, oldID)
+		if migrate {
+			fmt.Printf("  Migrated %d edges (%d dropped as duplicates or self-links)\n", result.EdgesMigrated, result.EdgesDropped)
+		}
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
+
+// migratePaperEdges repoints edges from oldID to newID. A repointed edge
+// is dropped if it would link newID to itself or duplicate an edge already
+// present. Returns the resulting edges and the numbers migrated and dropped.
+func migratePaperEdges(edges []edge.Edge, oldID, newID string) ([]edge.Edge, int, int) {
+	existing := make(map[edge.EdgeKey]bool)
+	for _, e := range edges {
+		if e.SourceID != oldID && e.TargetID != oldID {
+			existing[e.Key()] = true
+		}
+	}
+
+	var result []edge.Edge
+	migrated, dropped := 0, 0
+	for _, e := range edges {
+		if e.SourceID != oldID && e.TargetID != oldID {
+			result = append(result, e)
+			continue
+		}
+		if e.SourceID == oldID {
+			e.SourceID = newID
+		}
+		if e.TargetID == oldID {
+			e.TargetID = newID
+		}
+		if e.SourceID == e.TargetID || existing[e.Key()] {
+			dropped++
+			continue
+		}
+		existing[e.Key()] = true
+		result = append(result, e)
+		migrated++
+	}
+	return result, migrated, dropped
+}
+
+func runSupersededBy(cmd *cobra.Command, args []string) error {
+	id := args[0]
+
+	repoRoot := mustFindRepository()
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	refs, err := db.ListAll(0, storage.SortDefault)
+	if err != nil {
+		exitWithError(ExitError, "listing references: %v", err)
+	}
+	idx, found := storage.FindByID(refs, id)
+	if !found {
+		exitWithError(ExitError, "reference not found: %s", id)
+	}
+
+	result := SupersededByResult{ID: id, Chain: reference.SupersedesChain(refs, idx), Latest: id}
+	if result.Chain == nil {
+		result.Chain = []string{}
+	}
+	if len(result.Chain) > 0 {
+		result.Latest = result.Chain[len(result.Chain)-1]
+	}
+
+	if humanOutput {
+		if len(result.Chain) == 0 {
+			fmt.Printf("%s is not superseded\n", id)
+		} else {
+			fmt.Println(strings.Join(append([]string{id}, result.Chain...), " → "))
+		}
+	} else {
+		outputJSON(result)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/matsen/bipartite/internal/edge"
+)
+
+func TestMigratePaperEdges(t *testing.T) {
+	edges := []edge.Edge{
+		{SourceID: "Old", TargetID: "PaperX", RelationshipType: "cites"},
+		{SourceID: "PaperY", TargetID: "Old", RelationshipType: "extends"},
+		{SourceID: "New", TargetID: "PaperX", RelationshipType: "cites"}, // Old's copy becomes a duplicate
+		{SourceID: "Old", TargetID: "New", RelationshipType: "cites"},    // becomes a self-link
+		{SourceID: "Old", TargetID: "concept:vae", RelationshipType: "introduces"},
+		{SourceID: "PaperX", TargetID: "PaperY", RelationshipType: "cites"},
+	}
+
+	got, migrated, dropped := migratePaperEdges(edges, "Old", "New")
+	if migrated != 2 || dropped != 2 {
+		t.Errorf("migrated, dropped = %d, %d; want 2, 2", migrated, dropped)
+	}
+
+	want := []edge.EdgeKey{
+		{SourceID: "PaperY", TargetID: "New", RelationshipType: "extends"},
+		{SourceID: "New", TargetID: "PaperX", RelationshipType: "cites"},
+		{SourceID: "New", TargetID: "concept:vae", RelationshipType: "introduces"},
+		{SourceID: "PaperX", TargetID: "PaperY", RelationshipType: "cites"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d edges, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Key() != want[i] {
+			t.Errorf("edge %d = %+v, want %+v", i, got[i].Key(), want[i])
+		}
+	}
+}
@@ -53,7 +53,7 @@ type ExportResult struct {
 	Exported   int      `json:"exported"`              // Number of entries written
 	Skipped    int      `json:"skipped"`               // Number of duplicates skipped
 	SkippedIDs []string `json:"skipped_ids,omitempty"` // IDs that were duplicates
-	OutputPath string   `json:"output_path,omitempty"` // When --append used
+	OutputPath string   `json:"output_path,omitempty"` // When --append or --out used
 }
 
 // ResolveResult is the JSON output of the bip resolve command.
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/matsen/bipartite/internal/export"
+	"github.com/matsen/bipartite/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(verifyManifestCmd)
+}
+
+var verifyManifestCmd = &cobra.Command{
+	Use:   "verify-manifest <file>",
+	Short: "Check whether the library still matches a manifest",
+	Long: `Check whether the current library still matches a manifest written by
+'bip list --export-manifest'.
+
+Every reference in the manifest must still exist with the same content hash.
+References added since the snapshot are ignored. Exits with code 3 if any
+reference is missing or changed, or if the manifest checksum is invalid.
+
+Examples:
+  bip verify-manifest manifest.json
+  bip verify-manifest manifest.json --human`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVerifyManifest,
+}
+
+func runVerifyManifest(cmd *cobra.Command, args []string) error {
+	manifest, err := export.ReadManifest(args[0])
+	if err != nil {
+		exitWithError(ExitDataError, "%v", err)
+	}
+
+	repoRoot := mustFindRepository()
+	db := mustOpenDatabase(repoRoot)
+	defer db.Close()
+
+	refs, err := db.ListAll(0, storage.SortDefault)
+	if err != nil {
+		exitWithError(ExitError, "listing references: %v", err)
+	}
+
+	result, err := export.VerifyManifest(manifest, refs)
+	if err != nil {
+		exitWithError(ExitError, "verifying manifest: %v", err)
+	}
+
+	if humanOutput {
+		if result.Match {
+			fmt.Printf("Manifest matches: %d references unchanged since %s\n", result.Checked, manifest.CreatedAt)
+		} else {
+			fmt.Printf("Manifest does not match (%d references checked, snapshot %s)\n", result.Checked, manifest.CreatedAt)
+			if !result.ChecksumValid {
+				fmt.Println("  Manifest checksum does not match its entries")
+			}
+			if len(result.Missing) > 0 {
+				fmt.Printf("  Missing: %s\n", formatIDList(result.Missing))
+			}
+			if len(result.Changed) > 0 {
+				fmt.Printf("  Changed: %s\n", formatIDList(result.Changed))
+			}
+		}
+	} else {
+		outputJSON(result)
+	}
+
+	if !result.Match {
+		os.Exit(ExitDataError.Status())
+	}
+	return nil
+}
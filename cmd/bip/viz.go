@@ -3,19 +3,33 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/matsen/bipartite/internal/config"
 	"github.com/matsen/bipartite/internal/viz"
 	"github.com/spf13/cobra"
 )
 
 var vizOutput string
+var vizOpen bool
 var vizLayout string
 var vizOffline bool
+var vizProjects []string
+var vizConcepts []string
+var vizGroupBy string
 
 func init() {
-	vizCmd.Flags().StringVarP(&vizOutput, "output", "o", "", "Output file path (default: stdout)")
-	vizCmd.Flags().StringVar(&vizLayout, "layout", "force", "Layout algorithm: force, circle, or grid")
+	vizCmd.Flags().StringVarP(&vizOutput, "out", "o", "", "Output file path, or - for stdout (default: .bipartite/cache/graph.html)")
+	vizCmd.Flags().StringVar(&vizOutput, "output", "", "Output file path")
+	vizCmd.Flags().MarkDeprecated("output", "use --out instead")
+	vizCmd.Flags().BoolVar(&vizOpen, "open", false, "Open the written file in the default browser")
+	vizCmd.Flags().StringVar(&vizLayout, "layout", "force", "Layout algorithm: force, circle, grid, or hierarchical")
 	vizCmd.Flags().BoolVar(&vizOffline, "offline", false, "Bundle Cytoscape.js inline for offline use")
+	vizCmd.Flags().StringArrayVar(&vizProjects, "project", nil, "Only show this project and its neighbors (repeatable)")
+	vizCmd.Flags().StringArrayVar(&vizConcepts, "concept", nil, "Only show this concept and its neighbors (repeatable)")
+	vizCmd.Flags().StringVar(&vizGroupBy, "group-by", "", "Cluster nodes into containers: "+strings.Join(viz.ValidGroupBy, ", "))
 	rootCmd.AddCommand(vizCmd)
 }
 
@@ -31,22 +45,66 @@ connected by relationship edges. Edge colors indicate relationship types:
   - purple: models
   - gray: other
 
+The HTML is written to .bipartite/cache/graph.html, or to --out (use
+--out - for stdout). With --open, the written file is opened in the system
+browser (open on macOS, xdg-open on Linux, start on Windows). A graph with
+no nodes still writes a page explaining how to add some.
+
 Examples:
-  # Generate HTML to stdout
-  bip viz > graph.html
+  # Write to .bipartite/cache/graph.html and open it
+  bip viz --open
 
   # Generate to file
-  bip viz --output graph.html
+  bip viz --out graph.html
+
+  # Generate HTML to stdout
+  bip viz --out - > graph.html
 
   # Use circular layout
-  bip viz --layout circle --output graph.html
+  bip viz --layout circle --out graph.html
+
+  # Layered view: projects on top, concepts below, papers at the bottom
+  bip viz --layout hierarchical --out graph.html
 
   # Generate offline-capable HTML
-  bip viz --offline --output graph.html`,
+  bip viz --offline --out graph.html
+
+  # Show only the subgraph around two projects
+  bip viz --project dasm2 --project netam --out graph.html
+
+  # Show only a concept and the papers/projects linked to it
+  bip viz --concept somatic-hypermutation --out graph.html
+
+  # Cluster concepts and their papers inside the project they relate to
+  bip viz --group-by project --out graph.html
+
+With --group-by project, each concept is placed in the project it has the
+most edges to, and each paper follows the project holding most of its
+concepts. Ties go to the alphabetically first project ID. Nodes with no
+project connection stay outside any group, and a graph without projects is
+drawn flat.`,
 	RunE: runViz,
 }
 
+// vizDefaultFile is the file written under the cache directory when no
+// --out is given.
+const vizDefaultFile = "graph.html"
+
+// VizResult is the response for the viz command when writing a file.
+type VizResult struct {
+	Output string `json:"output"`          // Path the HTML was written to
+	Opened bool   `json:"opened"`          // Whether --open launched the browser
+	Empty  bool   `json:"empty,omitempty"` // The graph had no nodes; the page explains how to add some
+}
+
 func runViz(cmd *cobra.Command, args []string) error {
+	if vizOpen && vizOutput == "-" {
+		exitWithError(ExitError, "--open needs a file; it cannot be combined with --out -")
+	}
+	if vizGroupBy != "" && !slices.Contains(viz.ValidGroupBy, vizGroupBy) {
+		exitWithError(ExitError, "invalid --group-by %q: must be one of %s", vizGroupBy, strings.Join(viz.ValidGroupBy, ", "))
+	}
+
 	// Find repository and open database
 	repoRoot := mustFindRepository()
 	db := mustOpenDatabase(repoRoot)
@@ -58,29 +116,69 @@ func runViz(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("building graph data: %w", err)
 	}
 
+	// Restrict to the subgraph around requested nodes before serialization
+	graph, missing := graph.FilterSubgraph(viz.GraphFilter{
+		Projects: vizProjects,
+		Concepts: vizConcepts,
+	})
+	var emptyMessage string
+	if len(missing) > 0 {
+		emptyMessage = fmt.Sprintf("No node found for %s.", strings.Join(missing, ", "))
+		if !graph.IsEmpty() {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", emptyMessage)
+		}
+	}
+
+	graph, err = graph.ApplyGrouping(vizGroupBy)
+	if err != nil {
+		return err
+	}
+
 	// Generate HTML (validates options internally)
 	opts := viz.HTMLOptions{
-		Layout:  vizLayout,
-		Offline: vizOffline,
+		Layout:       vizLayout,
+		Offline:      vizOffline,
+		EmptyMessage: emptyMessage,
 	}
 	html, err := viz.GenerateHTML(graph, opts)
 	if err != nil {
 		return fmt.Errorf("generating HTML: %w", err)
 	}
 
-	// Output
-	if vizOutput == "" {
+	if vizOutput == "-" {
 		fmt.Print(html)
-	} else {
-		if err := os.WriteFile(vizOutput, []byte(html), 0644); err != nil {
-			return fmt.Errorf("writing output file: %w", err)
+		return nil
+	}
+
+	outPath := vizOutput
+	if outPath == "" {
+		outPath = filepath.Join(config.CachePath(repoRoot), vizDefaultFile)
+		if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+			return fmt.Errorf("creating cache directory: %w", err)
 		}
-		if !humanOutput {
-			fmt.Printf("{\"output\":\"%s\"}\n", vizOutput)
-		} else {
-			fmt.Printf("Visualization written to %s\n", vizOutput)
+	}
+	if err := os.WriteFile(outPath, []byte(html), 0644); err != nil {
+		return fmt.Errorf("writing output file: %w", err)
+	}
+
+	if vizOpen {
+		absPath, err := filepath.Abs(outPath)
+		if err != nil {
+			return fmt.Errorf("resolving output path: %w", err)
+		}
+		if err := viz.OpenInBrowser(absPath); err != nil {
+			return fmt.Errorf("visualization written to %s but not opened: %w", outPath, err)
 		}
 	}
 
+	if humanOutput {
+		fmt.Printf("Visualization written to %s\n", outPath)
+		if vizOpen {
+			fmt.Println("Opened in browser")
+		}
+	} else {
+		outputJSON(VizResult{Output: outPath, Opened: vizOpen, Empty: graph.IsEmpty()})
+	}
+
 	return nil
 }
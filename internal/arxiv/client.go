@@ -0,0 +1,97 @@
+// Package arxiv fetches preprint metadata from the arXiv Atom query API.
+package arxiv
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/matsen/bipartite/internal/httpapi"
+	"github.com/matsen/bipartite/internal/logging"
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+const (
+	// BaseURL is the arXiv query API endpoint.
+	BaseURL = "https://export.arxiv.org/api/query"
+
+	// DefaultTimeout is the default HTTP request timeout.
+	DefaultTimeout = 30 * time.Second
+)
+
+// Client is an HTTP client for the arXiv query API.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithBaseURL sets a custom base URL (for testing).
+func WithBaseURL(u string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = u
+	}
+}
+
+// NewClient creates a new arXiv client.
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		httpClient: logging.NewHTTPClient("arxiv", DefaultTimeout),
+		baseURL:    BaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// FetchByID fetches the paper with the given arXiv ID and maps it to a
+// Reference with an empty ID. A versioned ID fetches that version; the
+// stored ArXivID is always unversioned. Returns ErrInvalidID for a malformed
+// ID and ErrNotFound for one arXiv does not know.
+func (c *Client) FetchByID(ctx context.Context, arxivID string) (*reference.Reference, error) {
+	id, err := ParseID(arxivID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := c.baseURL + "?" + url.Values{"id_list": {id.String()}}.Encode()
+	headers := map[string]string{"Accept": "application/atom+xml"}
+
+	status, body, err := httpapi.Get(ctx, c.httpClient, endpoint, headers, ErrNetworkError)
+	if err != nil {
+		return nil, err
+	}
+	if status >= 400 {
+		return nil, &APIError{StatusCode: status, Message: httpapi.ErrorMessage(body)}
+	}
+
+	var feed Feed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	// Unknown IDs come back as an empty feed, or as a single entry whose
+	// id points at the API's error documentation
+	if len(feed.Entries) == 0 || strings.Contains(feed.Entries[0].ID, "/api/errors") {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	ref := MapEntryToReference(feed.Entries[0], id)
+	return &ref, nil
+}
@@ -0,0 +1,156 @@
+package arxiv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+// loadFixture reads a fixture file from testdata/.
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading fixture %s: %v", name, err)
+	}
+	return data
+}
+
+// newFixtureServer returns an httptest server that responds to every
+// request with statusCode and body, recording the last id_list parameter.
+func newFixtureServer(t *testing.T, statusCode int, body []byte, idList *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if idList != nil {
+			*idList = r.URL.Query().Get("id_list")
+		}
+		w.Header().Set("Content-Type", "application/atom+xml")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ID
+		wantErr bool
+	}{
+		{"2401.12345", ID{Base: "2401.12345"}, false},
+		{"2401.12345v2", ID{Base: "2401.12345", Version: "v2"}, false},
+		{"arXiv:2401.12345v3", ID{Base: "2401.12345", Version: "v3"}, false},
+		{"https://arxiv.org/abs/2401.12345v2", ID{Base: "2401.12345", Version: "v2"}, false},
+		{"https://arxiv.org/pdf/2401.12345.pdf", ID{Base: "2401.12345"}, false},
+		{"0704.0001", ID{Base: "0704.0001"}, false},
+		{"hep-th/9901001v1", ID{Base: "hep-th/9901001", Version: "v1"}, false},
+		{"math.GT/0309136", ID{Base: "math.GT/0309136"}, false},
+		{"10.1234/abc", ID{}, true},
+		{"", ID{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseID(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidID) {
+					t.Errorf("ParseID(%q) error = %v, want ErrInvalidID", tt.input, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseID(%q) = %+v, %v; want %+v", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeID(t *testing.T) {
+	for input, want := range map[string]string{
+		"2401.12345":         "2401.12345",
+		"arXiv:2401.12345v2": "2401.12345",
+		"ARXIV:2401.12345":   "2401.12345",
+		" hep-th/9901001v1 ": "hep-th/9901001",
+		"not-an-arxiv-id ":   "not-an-arxiv-id",
+	} {
+		if got := NormalizeID(input); got != want {
+			t.Errorf("NormalizeID(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestFetchByID_Found(t *testing.T) {
+	var idList string
+	server := newFixtureServer(t, 200, loadFixture(t, "entry_found.xml"), &idList)
+	client := NewClient(WithBaseURL(server.URL))
+
+	ref, err := client.FetchByID(context.Background(), "arXiv:2401.12345v2")
+	if err != nil {
+		t.Fatalf("FetchByID: %v", err)
+	}
+
+	if idList != "2401.12345v2" {
+		t.Errorf("id_list = %q, want the versioned ID", idList)
+	}
+	if ref.ArXivID != "2401.12345" {
+		t.Errorf("ArXivID = %q, want unversioned", ref.ArXivID)
+	}
+	if ref.Source != (reference.ImportSource{Type: "arxiv", ID: "2401.12345v2"}) {
+		t.Errorf("Source = %+v", ref.Source)
+	}
+	if ref.Title != "Variational Inference for Phylogenetics with Normalizing Flows" {
+		t.Errorf("Title = %q", ref.Title)
+	}
+	if ref.Abstract != "We develop a method for variational phylogenetic inference. It scales to large trees." {
+		t.Errorf("Abstract = %q", ref.Abstract)
+	}
+	want := []reference.Author{{First: "Jane Q.", Last: "Smith"}, {First: "Frederick A.", Last: "Matsen"}}
+	if len(ref.Authors) != 2 || ref.Authors[0] != want[0] || ref.Authors[1] != want[1] {
+		t.Errorf("Authors = %+v", ref.Authors)
+	}
+	if ref.Published != (reference.PublicationDate{Year: 2024, Month: 1, Day: 22}) {
+		t.Errorf("Published = %+v", ref.Published)
+	}
+	if ref.DOI != "10.48550/arXiv.2401.12345" || ref.Venue != "arXiv" {
+		t.Errorf("DOI = %q, Venue = %q", ref.DOI, ref.Venue)
+	}
+}
+
+func TestFetchByID_NotFound(t *testing.T) {
+	for _, fixture := range []string{"entry_error.xml", ""} {
+		body := []byte(`<feed xmlns="http://www.w3.org/2005/Atom"></feed>`)
+		if fixture != "" {
+			body = loadFixture(t, fixture)
+		}
+		server := newFixtureServer(t, 200, body, nil)
+		client := NewClient(WithBaseURL(server.URL))
+
+		if _, err := client.FetchByID(context.Background(), "9999.99999"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("fixture %q: err = %v, want ErrNotFound", fixture, err)
+		}
+	}
+}
+
+func TestFetchByID_Errors(t *testing.T) {
+	client := NewClient(WithBaseURL("http://unused.invalid"))
+	if _, err := client.FetchByID(context.Background(), "not-an-id"); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("err = %v, want ErrInvalidID", err)
+	}
+
+	server := newFixtureServer(t, 503, []byte("down"), nil)
+	var apiErr *APIError
+	if _, err := NewClient(WithBaseURL(server.URL)).FetchByID(context.Background(), "2401.12345"); !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Errorf("err = %v, want 503 APIError", err)
+	}
+
+	server.Close()
+	if _, err := NewClient(WithBaseURL(server.URL)).FetchByID(context.Background(), "2401.12345"); !errors.Is(err, ErrNetworkError) {
+		t.Errorf("err = %v, want ErrNetworkError", err)
+	}
+}
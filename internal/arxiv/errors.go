@@ -0,0 +1,31 @@
+package arxiv
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Common errors returned by the arXiv client.
+var (
+	// ErrInvalidID indicates a string that is not an arXiv identifier.
+	ErrInvalidID = errors.New("invalid arXiv ID")
+
+	// ErrNotFound indicates arXiv has no paper with the ID.
+	ErrNotFound = errors.New("arXiv ID not found")
+
+	// ErrNetworkError indicates a network connectivity issue.
+	ErrNetworkError = errors.New("network error communicating with arXiv")
+
+	// ErrInvalidResponse indicates a malformed or unexpected response shape.
+	ErrInvalidResponse = errors.New("invalid response from arXiv")
+)
+
+// APIError represents an HTTP error status returned by the arXiv API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("arXiv API error (status %d): %s", e.StatusCode, e.Message)
+}
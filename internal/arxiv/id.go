@@ -0,0 +1,60 @@
+package arxiv
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// newStyleID matches identifiers since April 2007, e.g. 2401.12345v2.
+	newStyleID = regexp.MustCompile(`^(\d{4}\.\d{4,5})(v\d+)?$`)
+	// oldStyleID matches earlier archive-based identifiers, e.g. hep-th/9901001v1.
+	oldStyleID = regexp.MustCompile(`^([a-z-]+(?:\.[A-Z]{2})?/\d{7})(v\d+)?$`)
+)
+
+// ID is a parsed arXiv identifier.
+type ID struct {
+	Base    string // Identifier without version, as stored in ArXivID (e.g. 2401.12345)
+	Version string // Version suffix if one was given (e.g. v2), else ""
+}
+
+// String returns the identifier with its version, if any.
+func (id ID) String() string {
+	return id.Base + id.Version
+}
+
+// ParseID parses an arXiv identifier, accepting the bare form, an
+// "arXiv:" prefix, or an arxiv.org abs/pdf URL, with or without a version.
+func ParseID(s string) (ID, error) {
+	v := strings.TrimSpace(s)
+	for _, prefix := range []string{"https://", "http://"} {
+		v = strings.TrimPrefix(v, prefix)
+	}
+	for _, prefix := range []string{"arxiv.org/abs/", "arxiv.org/pdf/", "www.arxiv.org/abs/", "www.arxiv.org/pdf/"} {
+		v = strings.TrimPrefix(v, prefix)
+	}
+	v = strings.TrimSuffix(v, ".pdf")
+	if len(v) > 6 && strings.EqualFold(v[:6], "arxiv:") {
+		v = v[6:]
+	}
+
+	for _, re := range []*regexp.Regexp{newStyleID, oldStyleID} {
+		if m := re.FindStringSubmatch(v); m != nil {
+			return ID{Base: m[1], Version: m[2]}, nil
+		}
+	}
+	return ID{}, fmt.Errorf("%w: %q", ErrInvalidID, s)
+}
+
+// NormalizeID returns the unversioned identifier of s (e.g. "2401.12345"
+// for "arXiv:2401.12345v2"), so IDs stored in different forms compare
+// equal. Strings that are not arXiv IDs are returned trimmed but otherwise
+// unchanged.
+func NormalizeID(s string) string {
+	id, err := ParseID(s)
+	if err != nil {
+		return strings.TrimSpace(s)
+	}
+	return id.Base
+}
@@ -0,0 +1,52 @@
+package arxiv
+
+import (
+	"strings"
+	"time"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+// MapEntryToReference converts an arXiv entry to a Reference. The ID is left
+// empty for the caller to generate; ArXivID is the identifier without version.
+func MapEntryToReference(e Entry, id ID) reference.Reference {
+	ref := reference.Reference{
+		DOI:      strings.TrimSpace(e.DOI),
+		Title:    cleanText(e.Title),
+		Abstract: cleanText(e.Summary),
+		Venue:    "arXiv",
+		ArXivID:  id.Base,
+		Source: reference.ImportSource{
+			Type: "arxiv",
+			ID:   id.String(),
+		},
+	}
+
+	for _, a := range e.Authors {
+		if first, last := splitName(a.Name); last != "" {
+			ref.Authors = append(ref.Authors, reference.Author{First: first, Last: last})
+		}
+	}
+
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(e.Published)); err == nil {
+		ref.Published = reference.PublicationDate{Year: t.Year(), Month: int(t.Month()), Day: t.Day()}
+	}
+
+	return ref
+}
+
+// splitName splits "First Middle Last" at the last space. arXiv names carry
+// no structure, so compound last names end up partly in First.
+func splitName(name string) (first, last string) {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return "", ""
+	}
+	return strings.Join(parts[:len(parts)-1], " "), parts[len(parts)-1]
+}
+
+// cleanText collapses the line breaks and indentation arXiv puts in titles
+// and abstracts.
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,22 @@
+package arxiv
+
+// Feed is the Atom feed returned by the arXiv query API.
+type Feed struct {
+	TotalResults int     `xml:"http://a9.com/-/spec/opensearch/1.1/ totalResults"`
+	Entries      []Entry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+// Entry is one paper in an arXiv Atom feed.
+type Entry struct {
+	ID        string   `xml:"http://www.w3.org/2005/Atom id"` // e.g. http://arxiv.org/abs/2401.12345v2
+	Title     string   `xml:"http://www.w3.org/2005/Atom title"`
+	Summary   string   `xml:"http://www.w3.org/2005/Atom summary"`
+	Published string   `xml:"http://www.w3.org/2005/Atom published"` // RFC3339, first version
+	Authors   []Author `xml:"http://www.w3.org/2005/Atom author"`
+	DOI       string   `xml:"http://arxiv.org/schemas/atom doi"`
+}
+
+// Author is an entry author; arXiv gives only the full name.
+type Author struct {
+	Name string `xml:"http://www.w3.org/2005/Atom name"`
+}
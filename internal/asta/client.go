@@ -8,13 +8,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
-	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/logging"
 	"golang.org/x/time/rate"
 )
 
@@ -81,7 +80,7 @@ func NewClient(opts ...ClientOption) *Client {
 	// Use a longer timeout for SSE streaming - the server sends pings every 15s
 	// and may take a while to process requests
 	c := &Client{
-		httpClient: &http.Client{Timeout: 3 * time.Minute},
+		httpClient: logging.NewHTTPClient("asta", 3*time.Minute),
 		limiter:    rate.NewLimiter(rate.Limit(RateLimit), 1),
 		baseURL:    BaseURL,
 	}
@@ -96,28 +95,9 @@ func NewClient(opts ...ClientOption) *Client {
 		opt(c)
 	}
 
-	// Warn once per process if we are operating without an API key. The
-	// cheap endpoints (paper, citations, ...) accept anonymous calls, so a
-	// missing key stays invisible until `search` trips the SSE timeout.
-	if c.apiKey == "" {
-		warnNoAPIKey()
-	}
-
 	return c
 }
 
-// anonymousWarnOnce ensures the no-key warning is emitted at most once per
-// process, regardless of how many clients are constructed.
-var anonymousWarnOnce sync.Once
-
-func warnNoAPIKey() {
-	anonymousWarnOnce.Do(func() {
-		fmt.Fprintln(os.Stderr, "bip: ASTA API key not configured; falling back to anonymous access "+
-			"(the search endpoint may time out). Set BIP_ASTA_API_KEY or see "+
-			"https://allenai.org/asta/resources/mcp")
-	})
-}
-
 // parseSSEResponse extracts text content from an SSE/MCP response stream.
 func parseSSEResponse(body io.Reader) ([]string, error) {
 	scanner := bufio.NewScanner(body)
@@ -11,6 +11,7 @@ import (
 type Query struct {
 	First string // First name (may be empty for last-name-only queries)
 	Last  string // Last name (required)
+	Exact bool   // Require the whole first name to match, not just a prefix
 }
 
 // ParseQuery parses an author search string into a structured Query.
@@ -67,6 +68,11 @@ func (q Query) Matches(a reference.Author) bool {
 		return true
 	}
 
+	// Exact queries compare the whole first name (case-insensitive)
+	if q.Exact {
+		return strings.EqualFold(q.First, a.First)
+	}
+
 	// First name uses prefix matching (case-insensitive)
 	// "Tim" matches "Timothy", "Timothy C", etc.
 	return strings.HasPrefix(
@@ -125,6 +125,18 @@ func TestQueryMatches(t *testing.T) {
 			author: reference.Author{First: "Timothy C", Last: "Yu"},
 			want:   true,
 		},
+		{
+			name:   "exact first name rejects prefix",
+			query:  Query{First: "Tim", Last: "Yu", Exact: true},
+			author: reference.Author{First: "Timothy", Last: "Yu"},
+			want:   false,
+		},
+		{
+			name:   "exact first name case insensitive",
+			query:  Query{First: "timothy c", Last: "Yu", Exact: true},
+			author: reference.Author{First: "Timothy C", Last: "Yu"},
+			want:   true,
+		},
 	}
 
 	for _, tt := range tests {
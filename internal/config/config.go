@@ -5,27 +5,95 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents repository configuration stored in .bipartite/config.yml.
 type Config struct {
-	PDFRoot    string `yaml:"pdf_root"`              // Absolute path to PDF folder
-	PDFReader  string `yaml:"pdf_reader"`            // Reader preference: system, skim, zathura, etc.
-	PapersRepo string `yaml:"papers_repo,omitempty"` // Path to bip-papers repository
+	PDFRoot    string            `yaml:"pdf_root"`              // Absolute path to PDF folder
+	PDFReader  string            `yaml:"pdf_reader"`            // Reader preference: system, skim, zathura, etc.
+	PapersRepo string            `yaml:"papers_repo,omitempty"` // Path to bip-papers repository
+	Store      StoreSettings     `yaml:"store,omitempty"`       // Generic store settings
+	Embedding  EmbeddingSettings `yaml:"embedding,omitempty"`   // Semantic index embedding provider
+	Search     SearchSettings    `yaml:"search,omitempty"`      // Search command defaults
+	GitHub     GitHubSettings    `yaml:"github,omitempty"`      // GitHub API client settings
+}
+
+// StoreSettings holds settings for generic stores (bip store).
+type StoreSettings struct {
+	AutoSync bool `yaml:"auto_sync,omitempty"` // Sync stale stores before queries
+}
+
+// SearchSettings holds defaults for search commands.
+type SearchSettings struct {
+	DefaultLimit *int `yaml:"default_limit,omitempty"` // --limit when the flag is omitted; 0 = no limit
+}
+
+// SearchLimit returns the configured search.default_limit, or fallback if
+// the key is unset.
+func (c *Config) SearchLimit(fallback int) int {
+	if c.Search.DefaultLimit == nil {
+		return fallback
+	}
+	return *c.Search.DefaultLimit
+}
+
+// GitHubSettings holds settings for GitHub API access.
+type GitHubSettings struct {
+	CacheTTL string `yaml:"cache_ttl,omitempty"` // Repo metadata cache lifetime, e.g. 12h; 0 disables
+}
+
+// GitHubCacheTTL returns the configured github.cache_ttl, or fallback if
+// the key is unset. A zero duration means caching is disabled.
+func (c *Config) GitHubCacheTTL(fallback time.Duration) (time.Duration, error) {
+	if c.GitHub.CacheTTL == "" {
+		return fallback, nil
+	}
+	if c.GitHub.CacheTTL == "0" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(c.GitHub.CacheTTL)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid github.cache_ttl %q: want a duration such as 24h or 30m", c.GitHub.CacheTTL)
+	}
+	return ttl, nil
+}
+
+// EmbeddingSettings selects the embedding provider for the semantic index.
+type EmbeddingSettings struct {
+	Provider   string `yaml:"provider,omitempty"`   // ollama (default) or openai
+	Model      string `yaml:"model,omitempty"`      // Provider's default model if empty
+	Dimensions int    `yaml:"dimensions,omitempty"` // Vector size; looked up for known models if 0
+}
+
+// Embedding provider names for EmbeddingSettings.Provider.
+const (
+	EmbeddingProviderOllama = "ollama"
+	EmbeddingProviderOpenAI = "openai"
+)
+
+// ValidateEmbeddingProvider checks that the provider value is valid.
+func ValidateEmbeddingProvider(provider string) error {
+	switch provider {
+	case "", EmbeddingProviderOllama, EmbeddingProviderOpenAI:
+		return nil // Empty defaults to ollama
+	}
+	return fmt.Errorf("invalid embedding.provider: %s (valid: %s, %s)", provider, EmbeddingProviderOllama, EmbeddingProviderOpenAI)
 }
 
 const (
-	BipartiteDir = ".bipartite"
-	ConfigFile   = "config.yml"
-	RefsFile     = "refs.jsonl"
-	EdgesFile    = "edges.jsonl"
-	ConceptsFile = "concepts.jsonl"
-	ProjectsFile = "projects.jsonl"
-	ReposFile    = "repos.jsonl"
-	CacheDir     = "cache"
-	DBFile       = "refs.db"
+	BipartiteDir  = ".bipartite"
+	ConfigFile    = "config.yml"
+	RefsFile      = "refs.jsonl"
+	EdgesFile     = "edges.jsonl"
+	ConceptsFile  = "concepts.jsonl"
+	ProjectsFile  = "projects.jsonl"
+	ReposFile     = "repos.jsonl"
+	EdgeTypesFile = "edge-types.yml"
+	CacheDir      = "cache"
+	DBFile        = "refs.db"
 )
 
 // ValidReaders lists the supported PDF reader values.
@@ -66,6 +134,11 @@ func ReposPath(root string) string {
 	return filepath.Join(root, BipartiteDir, ReposFile)
 }
 
+// EdgeTypesPath returns the path to the optional edge-types.yml vocabulary from a root path.
+func EdgeTypesPath(root string) string {
+	return filepath.Join(root, BipartiteDir, EdgeTypesFile)
+}
+
 // CachePath returns the path to the cache directory from a root path.
 func CachePath(root string) string {
 	return filepath.Join(root, BipartiteDir, CacheDir)
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestPathFunctions(t *testing.T) {
@@ -139,6 +140,67 @@ func TestConfig_SaveAndLoad(t *testing.T) {
 	}
 }
 
+func TestConfig_SearchLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	bpDir := filepath.Join(tmpDir, BipartiteDir)
+	if err := os.Mkdir(bpDir, 0755); err != nil {
+		t.Fatalf("Failed to create .bipartite: %v", err)
+	}
+
+	unset, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := unset.SearchLimit(50); got != 50 {
+		t.Errorf("SearchLimit() without key = %d, want fallback 50", got)
+	}
+
+	for _, tt := range []struct {
+		yaml string
+		want int
+	}{
+		{"search:\n  default_limit: 200\n", 200},
+		{"search:\n  default_limit: 0\n", 0}, // Unlimited, not unset
+	} {
+		if err := os.WriteFile(ConfigPath(tmpDir), []byte(tt.yaml), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := Load(tmpDir)
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if got := cfg.SearchLimit(50); got != tt.want {
+			t.Errorf("SearchLimit() with %q = %d, want %d", tt.yaml, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_GitHubCacheTTL(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 24 * time.Hour, false}, // Unset: fallback
+		{"12h", 12 * time.Hour, false},
+		{"0", 0, false},
+		{"0s", 0, false},
+		{"tomorrow", 0, true},
+		{"-1h", 0, true},
+	}
+	for _, tt := range tests {
+		cfg := &Config{GitHub: GitHubSettings{CacheTTL: tt.value}}
+		got, err := cfg.GitHubCacheTTL(24 * time.Hour)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("GitHubCacheTTL(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("GitHubCacheTTL(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
 func TestLoad_NotFound(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -253,6 +315,17 @@ func TestValidatePDFReader(t *testing.T) {
 	}
 }
 
+func TestValidateEmbeddingProvider(t *testing.T) {
+	for _, p := range []string{"", EmbeddingProviderOllama, EmbeddingProviderOpenAI} {
+		if err := ValidateEmbeddingProvider(p); err != nil {
+			t.Errorf("ValidateEmbeddingProvider(%q) = %v, want nil", p, err)
+		}
+	}
+	if err := ValidateEmbeddingProvider("cohere"); err == nil {
+		t.Error("ValidateEmbeddingProvider(cohere) = nil, want error")
+	}
+}
+
 func TestValidReaders(t *testing.T) {
 	// Ensure ValidReaders contains expected values
 	expected := []string{"system", "skim", "zathura", "evince", "okular"}
@@ -17,14 +17,23 @@ type GlobalConfig struct {
 	ASTAAPIKey    string            `yaml:"asta_api_key,omitempty"`
 	SlackBotToken string            `yaml:"slack_bot_token,omitempty"`
 	GitHubToken   string            `yaml:"github_token,omitempty"`
+	OpenAIAPIKey  string            `yaml:"openai_api_key,omitempty"`
 	SlackWebhooks map[string]string `yaml:"slack_webhooks,omitempty"`
 
+	// GitHub overrides the GitHub identity bip detects via gh.
+	GitHub GitHubIdentity `yaml:"github,omitempty"`
+
 	// Layout, when set, is the per-machine default for repo working-directory
 	// resolution. Read by flow.ResolveRepoPath. Optional; an absent block
 	// leaves bip in its pre-issue-149 clone-mode behavior.
 	Layout *LayoutConfig `yaml:"layout,omitempty"`
 }
 
+// GitHubIdentity holds the github block of the global config.
+type GitHubIdentity struct {
+	User string `yaml:"user,omitempty"` // Login treated as "me" by ball-in-court; overrides gh api user
+}
+
 const (
 	// GlobalConfigDir is the directory name under XDG_CONFIG_HOME.
 	GlobalConfigDir = "bip"
@@ -89,24 +98,66 @@ func ResetGlobalConfigCache() {
 	globalConfigCache = nil
 }
 
-// firstEnvOrConfig returns the first non-empty environment variable named in
-// names, falling back to configValue. Empty env vars are treated as unset.
-func firstEnvOrConfig(names []string, configValue string) string {
-	for _, name := range names {
+// resolveSecret returns a credential: the first non-empty environment
+// variable named in envVars, falling back to the field of the global config
+// selected by configField. Empty env vars are treated as unset, and an
+// unreadable global config counts as an empty one.
+//
+// Every credential getter goes through resolveSecret so the precedence is
+// the same for all of them. Values from a local .env file (loaded by some
+// commands at startup) arrive as environment variables, but never override
+// variables already set in the real environment.
+func resolveSecret(envVars []string, configField func(*GlobalConfig) string) string {
+	value, _ := resolveSecretWithSource(envVars, configField)
+	return value
+}
+
+// resolveSecretWithSource is resolveSecret that also reports where the
+// value came from: the environment variable name, "config", or "" if unset.
+func resolveSecretWithSource(envVars []string, configField func(*GlobalConfig) string) (string, string) {
+	for _, name := range envVars {
 		if v := os.Getenv(name); v != "" {
-			return v
+			return v, name
+		}
+	}
+	if cfg, err := LoadGlobalConfig(); err == nil && cfg != nil {
+		if v := configField(cfg); v != "" {
+			return v, "config"
 		}
 	}
-	return configValue
+	return "", ""
+}
+
+// GitHubTokenSource reports where GetGitHubToken finds the token (an
+// environment variable name, "config", or "" if unset) without revealing it.
+func GitHubTokenSource() string {
+	_, source := resolveSecretWithSource(GitHubTokenEnvVars, func(c *GlobalConfig) string { return c.GitHubToken })
+	return source
 }
 
-// GetS2APIKey returns the Semantic Scholar API key from global config.
+// SlackBotTokenSource reports where GetSlackBotToken finds the token (an
+// environment variable name, "config", or "" if unset) without revealing it.
+func SlackBotTokenSource() string {
+	_, source := resolveSecretWithSource(SlackBotTokenEnvVars, func(c *GlobalConfig) string { return c.SlackBotToken })
+	return source
+}
+
+// S2APIKeyEnvVars lists the environment variables consulted by
+// GetS2APIKey, in precedence order. BIP_S2_API_KEY is the recommended
+// bip-specific name; S2_API_KEY is the conventional fallback, also loaded
+// from a local .env file by cmd/bip/s2.go.
+var S2APIKeyEnvVars = []string{"BIP_S2_API_KEY", "S2_API_KEY"}
+
+// GetS2APIKey returns the Semantic Scholar API key.
+//
+// Precedence:
+//  1. $BIP_S2_API_KEY
+//  2. $S2_API_KEY
+//  3. s2_api_key in ~/.config/bip/config.yml
+//
+// Empty env vars are treated as unset.
 func GetS2APIKey() string {
-	cfg, err := LoadGlobalConfig()
-	if err != nil || cfg == nil {
-		return ""
-	}
-	return cfg.S2APIKey
+	return resolveSecret(S2APIKeyEnvVars, func(c *GlobalConfig) string { return c.S2APIKey })
 }
 
 // ASTAAPIKeyEnvVars lists the environment variables consulted by
@@ -125,12 +176,7 @@ var ASTAAPIKeyEnvVars = []string{"BIP_ASTA_API_KEY", "ASTA_API_KEY"}
 //
 // Empty env vars are treated as unset.
 func GetASTAAPIKey() string {
-	cfg, _ := LoadGlobalConfig()
-	configValue := ""
-	if cfg != nil {
-		configValue = cfg.ASTAAPIKey
-	}
-	return firstEnvOrConfig(ASTAAPIKeyEnvVars, configValue)
+	return resolveSecret(ASTAAPIKeyEnvVars, func(c *GlobalConfig) string { return c.ASTAAPIKey })
 }
 
 // GitHubTokenEnvVars lists the environment variables consulted by
@@ -154,12 +200,33 @@ var SlackBotTokenEnvVars = []string{"BIP_SLACK_TOKEN", "SLACK_BOT_TOKEN"}
 //
 // Empty env vars are treated as unset.
 func GetSlackBotToken() string {
-	cfg, _ := LoadGlobalConfig()
-	configValue := ""
-	if cfg != nil {
-		configValue = cfg.SlackBotToken
-	}
-	return firstEnvOrConfig(SlackBotTokenEnvVars, configValue)
+	return resolveSecret(SlackBotTokenEnvVars, func(c *GlobalConfig) string { return c.SlackBotToken })
+}
+
+// OpenAIAPIKeyEnvVars lists the environment variables consulted by
+// GetOpenAIAPIKey, in precedence order. BIP_OPENAI_API_KEY is the
+// recommended bip-specific name; OPENAI_API_KEY is the conventional
+// fallback used by OpenAI's own tools.
+var OpenAIAPIKeyEnvVars = []string{"BIP_OPENAI_API_KEY", "OPENAI_API_KEY"}
+
+// GetOpenAIAPIKey returns the OpenAI API key, used by the openai
+// embedding provider.
+//
+// Precedence:
+//  1. $BIP_OPENAI_API_KEY
+//  2. $OPENAI_API_KEY
+//  3. openai_api_key in ~/.config/bip/config.yml
+//
+// Empty env vars are treated as unset.
+func GetOpenAIAPIKey() string {
+	return resolveSecret(OpenAIAPIKeyEnvVars, func(c *GlobalConfig) string { return c.OpenAIAPIKey })
+}
+
+// OpenAIAPIKeySource reports where GetOpenAIAPIKey finds the key (an
+// environment variable name, "config", or "" if unset) without revealing it.
+func OpenAIAPIKeySource() string {
+	_, source := resolveSecretWithSource(OpenAIAPIKeyEnvVars, func(c *GlobalConfig) string { return c.OpenAIAPIKey })
+	return source
 }
 
 // GetGitHubToken returns the GitHub token.
@@ -172,12 +239,7 @@ func GetSlackBotToken() string {
 //
 // Empty env vars are treated as unset.
 func GetGitHubToken() string {
-	cfg, _ := LoadGlobalConfig()
-	configValue := ""
-	if cfg != nil {
-		configValue = cfg.GitHubToken
-	}
-	return firstEnvOrConfig(GitHubTokenEnvVars, configValue)
+	return resolveSecret(GitHubTokenEnvVars, func(c *GlobalConfig) string { return c.GitHubToken })
 }
 
 // GetSlackWebhook returns the Slack webhook URL for a channel from global config.
@@ -189,6 +251,15 @@ func GetSlackWebhook(channel string) string {
 	return ""
 }
 
+// GetGitHubUser returns github.user from the global config, or "" if unset.
+func GetGitHubUser() string {
+	cfg, _ := LoadGlobalConfig()
+	if cfg == nil {
+		return ""
+	}
+	return cfg.GitHub.User
+}
+
 // GetNexusPath returns the configured nexus path from global config.
 func GetNexusPath() string {
 	cfg, _ := LoadGlobalConfig()
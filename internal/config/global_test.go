@@ -151,6 +151,7 @@ func TestLoadGlobalConfig_InvalidYAML(t *testing.T) {
 }
 
 func TestGetS2APIKey(t *testing.T) {
+	clearTokenEnv(t)
 	ResetGlobalConfigCache()
 	defer ResetGlobalConfigCache()
 
@@ -182,6 +183,114 @@ func TestGetS2APIKey(t *testing.T) {
 	}
 }
 
+func TestGetS2APIKey_EnvPrecedence(t *testing.T) {
+	cases := []struct {
+		name      string
+		envs      map[string]string
+		configKey string
+		want      string
+	}{
+		{
+			name: "BIP_S2_API_KEY wins over S2_API_KEY",
+			envs: map[string]string{
+				"BIP_S2_API_KEY": "from-bip",
+				"S2_API_KEY":     "from-s2",
+			},
+			configKey: "from-config",
+			want:      "from-bip",
+		},
+		{
+			name:      "S2_API_KEY wins over config",
+			envs:      map[string]string{"S2_API_KEY": "from-s2"},
+			configKey: "from-config",
+			want:      "from-s2",
+		},
+		{
+			name:      "empty env vars treated as unset",
+			envs:      map[string]string{"BIP_S2_API_KEY": "", "S2_API_KEY": ""},
+			configKey: "from-config",
+			want:      "from-config",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearTokenEnv(t)
+			writeGlobalConfig(t, GlobalConfig{S2APIKey: tc.configKey})
+			for k, v := range tc.envs {
+				t.Setenv(k, v)
+			}
+			if got := GetS2APIKey(); got != tc.want {
+				t.Errorf("GetS2APIKey() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetters_EnvWinsOverMalformedConfig(t *testing.T) {
+	writeRawConfig(t, "asta_api_key: [unterminated\n")
+	getters := map[string]struct {
+		envVar string
+		get    func() string
+	}{
+		"GetS2APIKey":      {"S2_API_KEY", GetS2APIKey},
+		"GetASTAAPIKey":    {"ASTA_API_KEY", GetASTAAPIKey},
+		"GetGitHubToken":   {"GITHUB_TOKEN", GetGitHubToken},
+		"GetSlackBotToken": {"SLACK_BOT_TOKEN", GetSlackBotToken},
+		"GetOpenAIAPIKey":  {"OPENAI_API_KEY", GetOpenAIAPIKey},
+	}
+	for name, g := range getters {
+		t.Run(name, func(t *testing.T) {
+			clearTokenEnv(t)
+			t.Setenv(g.envVar, "from-env")
+			if got := g.get(); got != "from-env" {
+				t.Errorf("%s() = %q, want from-env", name, got)
+			}
+		})
+	}
+}
+
+// TestGetters_EnvWinsOverValidConfig checks every credential getter, for
+// each of its env vars, prefers the env var over a config value that is
+// set, and falls back to the config value when no env var is.
+func TestGetters_EnvWinsOverValidConfig(t *testing.T) {
+	writeGlobalConfig(t, GlobalConfig{
+		S2APIKey:      "config-s2",
+		ASTAAPIKey:    "config-asta",
+		GitHubToken:   "config-github",
+		SlackBotToken: "config-slack",
+		OpenAIAPIKey:  "config-openai",
+	})
+	getters := []struct {
+		name       string
+		envVars    []string
+		get        func() string
+		fromConfig string
+	}{
+		{"GetS2APIKey", S2APIKeyEnvVars, GetS2APIKey, "config-s2"},
+		{"GetASTAAPIKey", ASTAAPIKeyEnvVars, GetASTAAPIKey, "config-asta"},
+		{"GetGitHubToken", GitHubTokenEnvVars, GetGitHubToken, "config-github"},
+		{"GetSlackBotToken", SlackBotTokenEnvVars, GetSlackBotToken, "config-slack"},
+		{"GetOpenAIAPIKey", OpenAIAPIKeyEnvVars, GetOpenAIAPIKey, "config-openai"},
+	}
+	for _, g := range getters {
+		t.Run(g.name+"/config", func(t *testing.T) {
+			clearTokenEnv(t)
+			if got := g.get(); got != g.fromConfig {
+				t.Errorf("%s() = %q, want %q", g.name, got, g.fromConfig)
+			}
+		})
+		for _, envVar := range g.envVars {
+			t.Run(g.name+"/"+envVar, func(t *testing.T) {
+				clearTokenEnv(t)
+				t.Setenv(envVar, "from-env")
+				if got := g.get(); got != "from-env" {
+					t.Errorf("%s() with $%s set = %q, want from-env", g.name, envVar, got)
+				}
+			})
+		}
+	}
+}
+
 func TestGetSlackWebhook(t *testing.T) {
 	ResetGlobalConfigCache()
 	defer ResetGlobalConfigCache()
@@ -216,6 +325,18 @@ func TestGetSlackWebhook(t *testing.T) {
 	}
 }
 
+func TestGetGitHubUser(t *testing.T) {
+	writeGlobalConfig(t, GlobalConfig{})
+	if got := GetGitHubUser(); got != "" {
+		t.Errorf("GetGitHubUser() = %q, want empty", got)
+	}
+
+	writeGlobalConfig(t, GlobalConfig{GitHub: GitHubIdentity{User: "octocat"}})
+	if got := GetGitHubUser(); got != "octocat" {
+		t.Errorf("GetGitHubUser() = %q, want octocat", got)
+	}
+}
+
 func TestHelpfulConfigMessage(t *testing.T) {
 	msg := HelpfulConfigMessage()
 	if msg == "" {
@@ -330,6 +451,8 @@ func clearTokenEnv(t *testing.T) {
 	names := append([]string{}, GitHubTokenEnvVars...)
 	names = append(names, SlackBotTokenEnvVars...)
 	names = append(names, ASTAAPIKeyEnvVars...)
+	names = append(names, OpenAIAPIKeyEnvVars...)
+	names = append(names, S2APIKeyEnvVars...)
 	for _, name := range names {
 		t.Setenv(name, "")
 	}
@@ -582,6 +705,74 @@ func TestGetSlackBotToken_EnvPrecedence(t *testing.T) {
 	}
 }
 
+func TestGitHubTokenSource(t *testing.T) {
+	cases := []struct {
+		name        string
+		envs        map[string]string
+		configToken string
+		want        string
+	}{
+		{"env var named", map[string]string{"GH_TOKEN": "from-gh"}, "from-config", "GH_TOKEN"},
+		{"first env var wins", map[string]string{"GITHUB_TOKEN": "a", "GH_TOKEN": "b"}, "", "GITHUB_TOKEN"},
+		{"config when no env", nil, "from-config", "config"},
+		{"unset", map[string]string{"GITHUB_TOKEN": ""}, "", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearTokenEnv(t)
+			writeGlobalConfig(t, GlobalConfig{GitHubToken: tc.configToken})
+			for k, v := range tc.envs {
+				t.Setenv(k, v)
+			}
+			if got := GitHubTokenSource(); got != tc.want {
+				t.Errorf("GitHubTokenSource() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetOpenAIAPIKey_EnvPrecedence(t *testing.T) {
+	cases := []struct {
+		name      string
+		envs      map[string]string
+		configKey string
+		want      string
+	}{
+		{
+			name: "BIP_OPENAI_API_KEY wins over OPENAI_API_KEY",
+			envs: map[string]string{
+				"BIP_OPENAI_API_KEY": "from-bip",
+				"OPENAI_API_KEY":     "from-openai",
+			},
+			configKey: "from-config",
+			want:      "from-bip",
+		},
+		{
+			name:      "OPENAI_API_KEY wins when BIP_OPENAI_API_KEY unset",
+			envs:      map[string]string{"OPENAI_API_KEY": "from-openai"},
+			configKey: "from-config",
+			want:      "from-openai",
+		},
+		{
+			name:      "config used when no env vars set",
+			configKey: "from-config",
+			want:      "from-config",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearTokenEnv(t)
+			writeGlobalConfig(t, GlobalConfig{OpenAIAPIKey: tc.configKey})
+			for k, v := range tc.envs {
+				t.Setenv(k, v)
+			}
+			if got := GetOpenAIAPIKey(); got != tc.want {
+				t.Errorf("GetOpenAIAPIKey() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestValidateNexusPath_Valid(t *testing.T) {
 	ResetGlobalConfigCache()
 	defer ResetGlobalConfigCache()
@@ -0,0 +1,125 @@
+// Package crossref fetches reference metadata by DOI from the Crossref REST API.
+package crossref
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/matsen/bipartite/internal/httpapi"
+	"github.com/matsen/bipartite/internal/logging"
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+const (
+	// BaseURL is the Crossref REST API base URL.
+	BaseURL = "https://api.crossref.org"
+
+	// DefaultTimeout is the default HTTP request timeout.
+	DefaultTimeout = 30 * time.Second
+
+	// UserAgent identifies the client, as Crossref asks of API users.
+	UserAgent = "bipartite (https://github.com/matsen/bipartite)"
+)
+
+// Client is an HTTP client for the Crossref works API.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	mailto     string
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithBaseURL sets a custom base URL (for testing).
+func WithBaseURL(u string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = u
+	}
+}
+
+// WithMailto sets the contact email sent as the `mailto` parameter, which
+// routes requests to Crossref's more reliable "polite" pool.
+func WithMailto(email string) ClientOption {
+	return func(c *Client) {
+		c.mailto = email
+	}
+}
+
+// NewClient creates a new Crossref client.
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		httpClient: logging.NewHTTPClient("crossref", DefaultTimeout),
+		baseURL:    BaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// FetchByDOI fetches the work with the given DOI and maps it to a Reference
+// with an empty ID. Returns ErrNotFound for a DOI Crossref does not know.
+func (c *Client) FetchByDOI(ctx context.Context, doi string) (*reference.Reference, error) {
+	doi = normalizeDOI(doi)
+	if doi == "" {
+		return nil, fmt.Errorf("empty DOI")
+	}
+
+	endpoint := fmt.Sprintf("%s/works/%s", c.baseURL, url.PathEscape(doi))
+	if c.mailto != "" {
+		endpoint += "?" + url.Values{"mailto": {c.mailto}}.Encode()
+	}
+	headers := map[string]string{"Accept": "application/json", "User-Agent": UserAgent}
+
+	status, body, err := httpapi.Get(ctx, c.httpClient, endpoint, headers, ErrNetworkError)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case status == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, doi)
+	case status == http.StatusTooManyRequests:
+		return nil, ErrRateLimited
+	case status >= 400:
+		return nil, &APIError{StatusCode: status, Message: httpapi.ErrorMessage(body)}
+	}
+
+	var parsed Response
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if parsed.Status != "ok" {
+		return nil, fmt.Errorf("%w: status %q", ErrInvalidResponse, parsed.Status)
+	}
+
+	ref := MapWorkToReference(parsed.Message)
+	if ref.DOI == "" {
+		ref.DOI = doi
+	}
+	return &ref, nil
+}
+
+// normalizeDOI strips URL and "doi:" prefixes and surrounding whitespace.
+func normalizeDOI(doi string) string {
+	doi = strings.TrimSpace(doi)
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "doi.org/", "DOI:", "doi:"} {
+		doi = strings.TrimPrefix(doi, prefix)
+	}
+	return doi
+}
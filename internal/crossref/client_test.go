@@ -0,0 +1,132 @@
+package crossref
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+// newFixtureServer returns an httptest server that responds to every
+// request with statusCode and body, recording the last request path.
+func newFixtureServer(t *testing.T, statusCode int, body []byte, lastPath *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lastPath != nil {
+			*lastPath = r.URL.EscapedPath() + "?" + r.URL.RawQuery
+		}
+		w.WriteHeader(statusCode)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchByDOI_Found(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "work_found.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var path string
+	server := newFixtureServer(t, 200, data, &path)
+	client := NewClient(WithBaseURL(server.URL), WithMailto("me@example.org"))
+
+	ref, err := client.FetchByDOI(context.Background(), "https://doi.org/10.1371/journal.pcbi.1013758")
+	if err != nil {
+		t.Fatalf("FetchByDOI: %v", err)
+	}
+
+	if path != "/works/10.1371%2Fjournal.pcbi.1013758?mailto=me%40example.org" {
+		t.Errorf("request path = %s", path)
+	}
+	if ref.ID != "" {
+		t.Errorf("ID = %q, want empty for the caller to generate", ref.ID)
+	}
+	if ref.DOI != "10.1371/journal.pcbi.1013758" {
+		t.Errorf("DOI = %q", ref.DOI)
+	}
+	if ref.Title != "Nucleotide context models outperform protein language models for predicting antibody affinity maturation" {
+		t.Errorf("Title = %q", ref.Title)
+	}
+	wantAuthors := []reference.Author{
+		{First: "Mackenzie M.", Last: "Johnson"},
+		{First: "Frederick A.", Last: "Matsen"},
+		{Last: "EPAM Consortium"},
+	}
+	if len(ref.Authors) != len(wantAuthors) {
+		t.Fatalf("Authors = %+v", ref.Authors)
+	}
+	for i, a := range wantAuthors {
+		if ref.Authors[i] != a {
+			t.Errorf("Authors[%d] = %+v, want %+v", i, ref.Authors[i], a)
+		}
+	}
+	if ref.Venue != "PLOS Computational Biology" {
+		t.Errorf("Venue = %q", ref.Venue)
+	}
+	if ref.Published != (reference.PublicationDate{Year: 2025, Month: 12}) {
+		t.Errorf("Published = %+v", ref.Published)
+	}
+	if ref.Abstract != "Antibodies play a crucial role & more in adaptive immunity." {
+		t.Errorf("Abstract = %q", ref.Abstract)
+	}
+	if ref.Source.Type != "crossref" {
+		t.Errorf("Source.Type = %q", ref.Source.Type)
+	}
+}
+
+func TestFetchByDOI_NotFound(t *testing.T) {
+	server := newFixtureServer(t, 404, []byte("Resource not found."), nil)
+	client := NewClient(WithBaseURL(server.URL))
+
+	_, err := client.FetchByDOI(context.Background(), "10.1234/missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFetchByDOI_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		check  func(error) bool
+	}{
+		{"rate limited", 429, "", func(err error) bool { return errors.Is(err, ErrRateLimited) }},
+		{"server error", 503, "down", func(err error) bool {
+			var apiErr *APIError
+			return errors.As(err, &apiErr) && apiErr.StatusCode == 503
+		}},
+		{"invalid json", 200, "not json", func(err error) bool { return errors.Is(err, ErrInvalidResponse) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFixtureServer(t, tt.status, []byte(tt.body), nil)
+			client := NewClient(WithBaseURL(server.URL))
+			if _, err := client.FetchByDOI(context.Background(), "10.1234/x"); !tt.check(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFetchByDOI_NetworkError(t *testing.T) {
+	server := newFixtureServer(t, 200, nil, nil)
+	server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+
+	if _, err := client.FetchByDOI(context.Background(), "10.1234/x"); !errors.Is(err, ErrNetworkError) {
+		t.Errorf("err = %v, want ErrNetworkError", err)
+	}
+}
+
+func TestFetchByDOI_EmptyDOI(t *testing.T) {
+	if _, err := NewClient().FetchByDOI(context.Background(), "  "); err == nil {
+		t.Error("expected error for empty DOI")
+	}
+}
@@ -0,0 +1,32 @@
+package crossref
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Common errors returned by the Crossref client.
+var (
+	// ErrNotFound indicates Crossref has no record for the DOI.
+	ErrNotFound = errors.New("DOI not found in Crossref")
+
+	// ErrRateLimited indicates Crossref has throttled the client.
+	ErrRateLimited = errors.New("Crossref rate limit exceeded")
+
+	// ErrNetworkError indicates a network connectivity issue.
+	ErrNetworkError = errors.New("network error communicating with Crossref")
+
+	// ErrInvalidResponse indicates a malformed or unexpected response shape.
+	ErrInvalidResponse = errors.New("invalid response from Crossref")
+)
+
+// APIError represents an HTTP error status returned by Crossref other than
+// 404 (ErrNotFound) and 429 (ErrRateLimited).
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Crossref API error (status %d): %s", e.StatusCode, e.Message)
+}
@@ -0,0 +1,79 @@
+package crossref
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+// jatsTag matches the XML tags Crossref embeds in abstracts.
+var jatsTag = regexp.MustCompile(`<[^>]+>`)
+
+// MapWorkToReference converts a Crossref work to a Reference. The ID is
+// left empty for the caller to generate, since it depends on the library.
+func MapWorkToReference(w Work) reference.Reference {
+	ref := reference.Reference{
+		DOI:      w.DOI,
+		Title:    cleanText(first(w.Title)),
+		Authors:  mapAuthors(w.Author),
+		Venue:    cleanText(first(w.ContainerTitle)),
+		Abstract: cleanText(jatsTag.ReplaceAllString(w.Abstract, " ")),
+		Source: reference.ImportSource{
+			Type: "crossref",
+			ID:   w.DOI,
+		},
+	}
+
+	// Prefer the earliest publication date, falling back to the issue date
+	date := w.Published
+	if len(date.DateParts) == 0 || len(date.DateParts[0]) == 0 {
+		date = w.Issued
+	}
+	if len(date.DateParts) > 0 {
+		parts := date.DateParts[0]
+		if len(parts) > 0 {
+			ref.Published.Year = parts[0]
+		}
+		if len(parts) > 1 {
+			ref.Published.Month = parts[1]
+		}
+		if len(parts) > 2 {
+			ref.Published.Day = parts[2]
+		}
+	}
+
+	return ref
+}
+
+// mapAuthors converts Crossref authors, using Name as the last name for
+// organizational authors without a family name.
+func mapAuthors(authors []Author) []reference.Author {
+	result := make([]reference.Author, 0, len(authors))
+	for _, a := range authors {
+		last := strings.TrimSpace(a.Family)
+		first := strings.TrimSpace(a.Given)
+		if last == "" {
+			last, first = strings.TrimSpace(a.Name), ""
+		}
+		if last == "" {
+			continue
+		}
+		result = append(result, reference.Author{First: first, Last: last})
+	}
+	return result
+}
+
+// cleanText unescapes HTML entities and collapses whitespace.
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+// first returns the first element of values, or "".
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
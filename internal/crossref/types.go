@@ -0,0 +1,32 @@
+package crossref
+
+// Response is the envelope of a Crossref /works/{doi} response.
+type Response struct {
+	Status  string `json:"status"`
+	Message Work   `json:"message"`
+}
+
+// Work is the subset of a Crossref work record that maps to a reference.
+type Work struct {
+	DOI            string   `json:"DOI"`
+	Type           string   `json:"type"`
+	Title          []string `json:"title"`
+	Author         []Author `json:"author"`
+	ContainerTitle []string `json:"container-title"`
+	Abstract       string   `json:"abstract"` // JATS XML, e.g. "<jats:p>...</jats:p>"
+	Published      DateInfo `json:"published"`
+	Issued         DateInfo `json:"issued"`
+}
+
+// Author is a Crossref contributor. Organizations have only Name.
+type Author struct {
+	Given  string `json:"given"`
+	Family string `json:"family"`
+	Name   string `json:"name"`
+}
+
+// DateInfo holds a Crossref partial date as [[year, month, day]], where
+// month and day may be missing.
+type DateInfo struct {
+	DateParts [][]int `json:"date-parts"`
+}
@@ -3,6 +3,8 @@ package edge
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -14,8 +16,9 @@ type Edge struct {
 	RelationshipType string `json:"relationship_type"`
 
 	// Metadata
-	Summary   string `json:"summary"`
-	CreatedAt string `json:"created_at,omitempty"`
+	Summary   string   `json:"summary"`
+	Strength  *float64 `json:"strength,omitempty"` // Optional weight in [0, 1]; nil if unset
+	CreatedAt string   `json:"created_at,omitempty"`
 }
 
 // Validation errors.
@@ -25,6 +28,7 @@ var (
 	ErrEmptyRelationshipType = errors.New("relationship_type is required")
 	ErrEmptySummary          = errors.New("summary is required")
 	ErrSelfEdge              = errors.New("source_id and target_id cannot be the same")
+	ErrInvalidStrength       = errors.New("strength must be between 0 and 1")
 )
 
 // ValidateForCreate validates an edge for creation.
@@ -45,6 +49,9 @@ func (e *Edge) ValidateForCreate() error {
 	if e.SourceID == e.TargetID {
 		return ErrSelfEdge
 	}
+	if e.Strength != nil && (*e.Strength < 0 || *e.Strength > 1) {
+		return ErrInvalidStrength
+	}
 	return nil
 }
 
@@ -55,6 +62,17 @@ func (e *Edge) SetCreatedAt() {
 	}
 }
 
+// NormalizeTimestamp parses an RFC3339 timestamp and returns it in UTC in the
+// format used for CreatedAt, so explicit (backdated) timestamps sort and
+// compare like generated ones.
+func NormalizeTimestamp(ts string) (string, error) {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return "", fmt.Errorf("invalid timestamp %q (want RFC3339, e.g. 2024-03-15T00:00:00Z)", ts)
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
 // MergeCreatedAt preserves the existing CreatedAt timestamp if the new one is empty.
 func (e *Edge) MergeCreatedAt(existing Edge) {
 	if e.CreatedAt == "" && existing.CreatedAt != "" {
@@ -62,6 +80,14 @@ func (e *Edge) MergeCreatedAt(existing Edge) {
 	}
 }
 
+// MergeStrength preserves the existing Strength if the new one is unset.
+func (e *Edge) MergeStrength(existing Edge) {
+	if e.Strength == nil && existing.Strength != nil {
+		s := *existing.Strength
+		e.Strength = &s
+	}
+}
+
 // Key returns the unique identity tuple for this edge.
 func (e *Edge) Key() EdgeKey {
 	return EdgeKey{
@@ -86,12 +112,28 @@ type OrphanedEdgeInfo struct {
 	Reason           string `json:"reason"` // "missing_source", "missing_target", or "missing_both"
 }
 
+// nodePrefixes are the ID prefixes of non-paper edge endpoints.
+var nodePrefixes = []string{"concept:", "project:", "repo:"}
+
+// isPaperID reports whether an edge endpoint refers to a paper, i.e. has no
+// node-type prefix.
+func isPaperID(id string) bool {
+	for _, p := range nodePrefixes {
+		if strings.HasPrefix(id, p) {
+			return false
+		}
+	}
+	return true
+}
+
 // DetectOrphanedEdges finds edges that reference papers not in the valid ID set.
+// Only paper endpoints are checked; concept, project, and repo endpoints
+// (with a "concept:", "project:", or "repo:" prefix) are left to callers.
 // Returns orphaned edges with their reasons and the list of valid edges.
 func DetectOrphanedEdges(edges []Edge, validIDs map[string]bool) (orphaned []OrphanedEdgeInfo, valid []Edge) {
 	for _, e := range edges {
-		sourceOK := validIDs[e.SourceID]
-		targetOK := validIDs[e.TargetID]
+		sourceOK := !isPaperID(e.SourceID) || validIDs[e.SourceID]
+		targetOK := !isPaperID(e.TargetID) || validIDs[e.TargetID]
 
 		if !sourceOK || !targetOK {
 			info := OrphanedEdgeInfo{
@@ -131,3 +173,25 @@ func FindDuplicateEdges(edges []Edge) map[EdgeKey]int {
 	}
 	return duplicates
 }
+
+// DedupeEdges collapses edges sharing an EdgeKey, keeping the one with the
+// earliest CreatedAt. Returns the kept edges in first-seen key order and the
+// dropped duplicates.
+func DedupeEdges(edges []Edge) (kept, removed []Edge) {
+	seen := make(map[EdgeKey]int) // key -> index in kept
+	for _, e := range edges {
+		key := e.Key()
+		if existingIdx, exists := seen[key]; exists {
+			if e.CreatedAt < kept[existingIdx].CreatedAt {
+				removed = append(removed, kept[existingIdx])
+				kept[existingIdx] = e
+			} else {
+				removed = append(removed, e)
+			}
+		} else {
+			seen[key] = len(kept)
+			kept = append(kept, e)
+		}
+	}
+	return kept, removed
+}
@@ -68,6 +68,21 @@ func TestEdge_ValidateForCreate(t *testing.T) {
 			},
 			wantErr: ErrSelfEdge,
 		},
+		{
+			name:    "strength at bounds",
+			edge:    Edge{SourceID: "Smith2024", TargetID: "Jones2023", RelationshipType: "cites", Summary: "s", Strength: ptrFloat(1)},
+			wantErr: nil,
+		},
+		{
+			name:    "strength below zero",
+			edge:    Edge{SourceID: "Smith2024", TargetID: "Jones2023", RelationshipType: "cites", Summary: "s", Strength: ptrFloat(-0.1)},
+			wantErr: ErrInvalidStrength,
+		},
+		{
+			name:    "strength above one",
+			edge:    Edge{SourceID: "Smith2024", TargetID: "Jones2023", RelationshipType: "cites", Summary: "s", Strength: ptrFloat(1.5)},
+			wantErr: ErrInvalidStrength,
+		},
 	}
 
 	for _, tt := range tests {
@@ -80,6 +95,8 @@ func TestEdge_ValidateForCreate(t *testing.T) {
 	}
 }
 
+func ptrFloat(f float64) *float64 { return &f }
+
 func TestEdge_SetCreatedAt(t *testing.T) {
 	t.Run("sets timestamp when empty", func(t *testing.T) {
 		e := Edge{
@@ -190,6 +207,29 @@ func TestEdge_Key(t *testing.T) {
 	}
 }
 
+func TestNormalizeTimestamp(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"2024-03-15T00:00:00Z", "2024-03-15T00:00:00Z", false},
+		{"2024-03-15T09:30:00+02:00", "2024-03-15T07:30:00Z", false},
+		{"2024-03-15", "", true},
+		{"yesterday", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeTimestamp(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeTimestamp(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeTimestamp(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
 func TestDetectOrphanedEdges(t *testing.T) {
 	edges := []Edge{
 		{SourceID: "A", TargetID: "B", RelationshipType: "cites", Summary: "s1"},
@@ -241,6 +281,24 @@ func TestDetectOrphanedEdges_NoOrphans(t *testing.T) {
 	}
 }
 
+func TestDetectOrphanedEdges_NonPaperEndpoints(t *testing.T) {
+	edges := []Edge{
+		{SourceID: "A", TargetID: "concept:vae", RelationshipType: "introduces", Summary: "s1"},
+		{SourceID: "concept:vae", TargetID: "project:dasm2", RelationshipType: "applied-in", Summary: "s2"},
+		{SourceID: "X", TargetID: "concept:vae", RelationshipType: "applies", Summary: "s3"}, // X missing
+	}
+	validIDs := map[string]bool{"A": true}
+
+	orphaned, valid := DetectOrphanedEdges(edges, validIDs)
+
+	if len(valid) != 2 {
+		t.Errorf("expected 2 valid edges, got %d", len(valid))
+	}
+	if len(orphaned) != 1 || orphaned[0].SourceID != "X" || orphaned[0].Reason != "missing_source" {
+		t.Errorf("expected only X's edge orphaned (missing_source), got %+v", orphaned)
+	}
+}
+
 func TestFindDuplicateEdges(t *testing.T) {
 	edges := []Edge{
 		{SourceID: "A", TargetID: "B", RelationshipType: "cites", Summary: "s1"},
@@ -277,3 +335,26 @@ func TestFindDuplicateEdges_NoDuplicates(t *testing.T) {
 		t.Errorf("expected 0 duplicates, got %d", len(duplicates))
 	}
 }
+
+func TestDedupeEdges(t *testing.T) {
+	edges := []Edge{
+		{SourceID: "A", TargetID: "B", RelationshipType: "cites", Summary: "later", CreatedAt: "2024-02-01T00:00:00Z"},
+		{SourceID: "A", TargetID: "C", RelationshipType: "cites", Summary: "unique", CreatedAt: "2024-01-15T00:00:00Z"},
+		{SourceID: "A", TargetID: "B", RelationshipType: "cites", Summary: "earliest", CreatedAt: "2024-01-01T00:00:00Z"},
+		{SourceID: "A", TargetID: "B", RelationshipType: "cites", Summary: "latest", CreatedAt: "2024-03-01T00:00:00Z"},
+	}
+
+	kept, removed := DedupeEdges(edges)
+
+	if len(kept) != 2 || len(removed) != 2 {
+		t.Fatalf("expected 2 kept and 2 removed, got %d and %d", len(kept), len(removed))
+	}
+	if kept[0].Summary != "earliest" || kept[1].Summary != "unique" {
+		t.Errorf("kept = %+v, want earliest A->B then A->C", kept)
+	}
+	for _, e := range removed {
+		if e.Summary == "earliest" {
+			t.Errorf("earliest edge should not be removed")
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package edge
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// nodeTypeRank orders the node types that may appear in a vocabulary pair
+// key, from papers out to repos. PairKey puts the lower-ranked type first.
+var nodeTypeRank = map[string]int{"paper": 0, "concept": 1, "project": 2, "repo": 3}
+
+// Vocabulary lists the relationship types permitted for each pair of node
+// types. Keys are PairKey values; pairs are unordered, so "paper-concept"
+// covers edges in both directions.
+type Vocabulary map[string][]string
+
+// PairKey returns the vocabulary key for an edge between nodes of types a
+// and b, independent of direction, in the paper, concept, project, repo
+// order used in edge-types.yml (e.g. "paper-concept", "concept-project").
+func PairKey(a, b string) string {
+	ra, rb := pairRank(a), pairRank(b)
+	if rb < ra || (rb == ra && b < a) {
+		a, b = b, a
+	}
+	return a + "-" + b
+}
+
+// pairRank returns t's position in the pair-key order; unknown types sort
+// after all known ones.
+func pairRank(t string) int {
+	if r, ok := nodeTypeRank[t]; ok {
+		return r
+	}
+	return len(nodeTypeRank)
+}
+
+// isNodeType reports whether t may appear in a vocabulary pair key.
+func isNodeType(t string) bool {
+	_, ok := nodeTypeRank[t]
+	return ok
+}
+
+// LoadVocabulary reads a relationship-type vocabulary from a YAML file
+// mapping node-type pairs to type lists:
+//
+//	paper-concept: [introduces, applies]
+//	paper-paper: [cites, extends]
+//
+// Returns nil (not an error) if the file doesn't exist, meaning any type is
+// allowed.
+func LoadVocabulary(path string) (Vocabulary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading edge types: %w", err)
+	}
+
+	var raw map[string][]string
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing edge types: %w", err)
+	}
+
+	vocab := make(Vocabulary, len(raw))
+	for key, types := range raw {
+		a, b, ok := strings.Cut(key, "-")
+		if !ok || !isNodeType(a) || !isNodeType(b) {
+			return nil, fmt.Errorf("parsing edge types: invalid node-type pair %q (want e.g. paper-concept)", key)
+		}
+		pair := PairKey(a, b)
+		vocab[pair] = append(vocab[pair], types...)
+	}
+	for pair, types := range vocab {
+		vocab[pair] = sortedUnique(types)
+	}
+	return vocab, nil
+}
+
+// Allowed reports whether relType is permitted between nodes of the given types.
+// A pair missing from the vocabulary permits no types.
+func (v Vocabulary) Allowed(sourceType, targetType, relType string) bool {
+	for _, t := range v[PairKey(sourceType, targetType)] {
+		if t == relType {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedUnique returns the distinct strings in s, sorted.
+func sortedUnique(s []string) []string {
+	seen := make(map[string]bool, len(s))
+	out := []string{}
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
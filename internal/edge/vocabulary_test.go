@@ -0,0 +1,93 @@
+package edge
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeVocabulary(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "edge-types.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("writing vocabulary: %v", err)
+	}
+	return path
+}
+
+func TestPairKey(t *testing.T) {
+	tests := []struct{ a, b, want string }{
+		{"paper", "concept", "paper-concept"},
+		{"concept", "paper", "paper-concept"},
+		{"project", "concept", "concept-project"},
+		{"repo", "project", "project-repo"},
+		{"paper", "paper", "paper-paper"},
+	}
+	for _, tt := range tests {
+		if got := PairKey(tt.a, tt.b); got != tt.want {
+			t.Errorf("PairKey(%s, %s) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if PairKey("paper", "concept") != PairKey("concept", "paper") {
+		t.Error("PairKey should not depend on direction")
+	}
+}
+
+func TestLoadVocabulary(t *testing.T) {
+	path := writeVocabulary(t, `
+paper-concept: [introduces, applies]
+concept-paper: [applies, models]
+paper-paper: [cites]
+`)
+
+	vocab, err := LoadVocabulary(path)
+	if err != nil {
+		t.Fatalf("LoadVocabulary() error = %v", err)
+	}
+
+	want := Vocabulary{
+		"paper-concept": {"applies", "introduces", "models"},
+		"paper-paper":   {"cites"},
+	}
+	if !reflect.DeepEqual(vocab, want) {
+		t.Errorf("LoadVocabulary() = %v, want %v", vocab, want)
+	}
+}
+
+func TestLoadVocabulary_Missing(t *testing.T) {
+	vocab, err := LoadVocabulary(filepath.Join(t.TempDir(), "edge-types.yml"))
+	if err != nil {
+		t.Fatalf("LoadVocabulary() error = %v", err)
+	}
+	if vocab != nil {
+		t.Errorf("LoadVocabulary() = %v, want nil for missing file", vocab)
+	}
+}
+
+func TestLoadVocabulary_InvalidPair(t *testing.T) {
+	for _, content := range []string{"paper: [cites]\n", "paper-author: [wrote]\n", "not a map\n"} {
+		if _, err := LoadVocabulary(writeVocabulary(t, content)); err == nil {
+			t.Errorf("LoadVocabulary(%q) succeeded, want error", content)
+		}
+	}
+}
+
+func TestVocabulary_Allowed(t *testing.T) {
+	vocab := Vocabulary{"paper-concept": {"applies", "introduces"}}
+
+	tests := []struct {
+		source, target, relType string
+		want                    bool
+	}{
+		{"paper", "concept", "introduces", true},
+		{"concept", "paper", "applies", true},
+		{"paper", "concept", "introduce", false},
+		{"paper", "paper", "cites", false}, // pair not listed
+	}
+	for _, tt := range tests {
+		if got := vocab.Allowed(tt.source, tt.target, tt.relType); got != tt.want {
+			t.Errorf("Allowed(%s, %s, %s) = %v, want %v", tt.source, tt.target, tt.relType, got, tt.want)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/matsen/bipartite/internal/logging"
 )
 
 const (
@@ -76,7 +78,7 @@ func NewOllamaProvider(opts ...OllamaOption) *OllamaProvider {
 		baseURL:    DefaultOllamaURL,
 		model:      DefaultModel,
 		dimensions: DefaultDimensions,
-		client:     &http.Client{Timeout: DefaultTimeout},
+		client:     logging.NewHTTPClient("ollama", DefaultTimeout),
 	}
 	for _, opt := range opts {
 		opt(p)
@@ -154,6 +156,11 @@ func (p *OllamaProvider) Embed(ctx context.Context, text string) (Embedding, err
 	return Embedding{Vector: result.Embedding}, nil
 }
 
+// Name returns the provider name.
+func (p *OllamaProvider) Name() string {
+	return ProviderOllama
+}
+
 // ModelName returns the name of the embedding model.
 func (p *OllamaProvider) ModelName() string {
 	return p.model
@@ -164,6 +171,11 @@ func (p *OllamaProvider) Dimensions() int {
 	return p.dimensions
 }
 
+// BaseURL returns the Ollama API base URL requests are sent to.
+func (p *OllamaProvider) BaseURL() string {
+	return p.baseURL
+}
+
 // IsAvailable checks if Ollama is running and accessible.
 func (p *OllamaProvider) IsAvailable(ctx context.Context) error {
 	resp, err := p.doGet(ctx, apiPathTags)
@@ -0,0 +1,195 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/logging"
+)
+
+const (
+	// DefaultOpenAIURL is the default OpenAI API endpoint.
+	DefaultOpenAIURL = "https://api.openai.com/v1"
+
+	// DefaultOpenAIModel is the default OpenAI embedding model.
+	DefaultOpenAIModel = "text-embedding-3-small"
+
+	// DefaultOpenAIDimensions is the output dimensions for text-embedding-3-small.
+	DefaultOpenAIDimensions = 1536
+
+	// openAIPathEmbeddings is the OpenAI API endpoint for generating embeddings.
+	openAIPathEmbeddings = "/embeddings"
+
+	// openAIPathModels is the OpenAI API endpoint for retrieving a model.
+	openAIPathModels = "/models/"
+
+	// openAIDimensionsPrefix marks the models that accept the dimensions
+	// request parameter; older models such as text-embedding-ada-002
+	// reject it.
+	openAIDimensionsPrefix = "text-embedding-3"
+)
+
+// Provider names recorded in the semantic index.
+const (
+	ProviderOllama = "ollama"
+	ProviderOpenAI = "openai"
+)
+
+// ErrMissingAPIKey is returned when the OpenAI provider has no API key.
+var ErrMissingAPIKey = errors.New("OpenAI API key not set")
+
+// OpenAIProvider generates embeddings using the OpenAI API.
+type OpenAIProvider struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	dimensions int
+	client     *http.Client
+}
+
+// OpenAIOption configures an OpenAIProvider.
+type OpenAIOption func(*OpenAIProvider)
+
+// WithOpenAIBaseURL sets the OpenAI API base URL.
+func WithOpenAIBaseURL(url string) OpenAIOption {
+	return func(p *OpenAIProvider) {
+		p.baseURL = url
+	}
+}
+
+// WithOpenAIModel sets the embedding model and its vector dimensions.
+func WithOpenAIModel(model string, dims int) OpenAIOption {
+	return func(p *OpenAIProvider) {
+		p.model = model
+		p.dimensions = dims
+	}
+}
+
+// NewOpenAIProvider creates a new OpenAI embedding provider.
+func NewOpenAIProvider(apiKey string, opts ...OpenAIOption) *OpenAIProvider {
+	p := &OpenAIProvider{
+		baseURL:    DefaultOpenAIURL,
+		apiKey:     apiKey,
+		model:      DefaultOpenAIModel,
+		dimensions: DefaultOpenAIDimensions,
+		client:     logging.NewHTTPClient("openai", DefaultTimeout),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Embed generates an embedding for the given text.
+func (p *OpenAIProvider) Embed(ctx context.Context, text string) (Embedding, error) {
+	if p.apiKey == "" {
+		return Embedding{}, ErrMissingAPIKey
+	}
+
+	body, err := json.Marshal(openAIEmbedRequest{
+		Model:      p.model,
+		Input:      text,
+		Dimensions: p.requestDimensions(),
+	})
+	if err != nil {
+		return Embedding{}, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+openAIPathEmbeddings, bytes.NewReader(body))
+	if err != nil {
+		return Embedding{}, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Embedding{}, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Embedding{}, fmt.Errorf("openai returned status %d: %s", resp.StatusCode, formatErrorBody(resp.Body))
+	}
+
+	var result openAIEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Embedding{}, fmt.Errorf("decoding response: %w", err)
+	}
+	if len(result.Data) == 0 {
+		return Embedding{}, fmt.Errorf("openai returned no embeddings")
+	}
+
+	vector := result.Data[0].Embedding
+	if len(vector) != p.dimensions {
+		return Embedding{}, fmt.Errorf("unexpected embedding dimensions: got %d, want %d", len(vector), p.dimensions)
+	}
+
+	return Embedding{Vector: vector}, nil
+}
+
+// IsAvailable checks that the API accepts the key and serves the model.
+func (p *OpenAIProvider) IsAvailable(ctx context.Context) error {
+	if p.apiKey == "" {
+		return ErrMissingAPIKey
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+openAIPathModels+p.model, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("openai is not reachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("openai returned status %d for model %s: %s", resp.StatusCode, p.model, formatErrorBody(resp.Body))
+	}
+	return nil
+}
+
+// Name returns the provider name.
+func (p *OpenAIProvider) Name() string {
+	return ProviderOpenAI
+}
+
+// ModelName returns the name of the embedding model.
+func (p *OpenAIProvider) ModelName() string {
+	return p.model
+}
+
+// Dimensions returns the expected vector dimensions.
+func (p *OpenAIProvider) Dimensions() int {
+	return p.dimensions
+}
+
+// requestDimensions returns the dimensions to send with an embed request,
+// or 0 to leave it out for models that do not accept it.
+func (p *OpenAIProvider) requestDimensions() int {
+	if strings.HasPrefix(p.model, openAIDimensionsPrefix) {
+		return p.dimensions
+	}
+	return 0
+}
+
+// openAIEmbedRequest is the request body for the OpenAI embeddings API.
+type openAIEmbedRequest struct {
+	Model      string `json:"model"`
+	Input      string `json:"input"`
+	Dimensions int    `json:"dimensions,omitempty"`
+}
+
+// openAIEmbedResponse is the response from the OpenAI embeddings API.
+type openAIEmbedResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
@@ -0,0 +1,117 @@
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenAIProvider_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != openAIPathEmbeddings {
+			t.Errorf("path = %s, want %s", r.URL.Path, openAIPathEmbeddings)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer sk-test" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer sk-test")
+		}
+		var req openAIEmbedRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decoding request: %v", err)
+		}
+		if req.Model != "text-embedding-3-small" || req.Input != "hello" || req.Dimensions != 3 {
+			t.Errorf("request = %+v", req)
+		}
+		w.Write([]byte(`{"data":[{"embedding":[0.1,0.2,0.3]}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider("sk-test", WithOpenAIBaseURL(server.URL), WithOpenAIModel("text-embedding-3-small", 3))
+	emb, err := provider.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(emb.Vector) != 3 || emb.Vector[2] != 0.3 {
+		t.Errorf("Vector = %v, want [0.1 0.2 0.3]", emb.Vector)
+	}
+}
+
+func TestOpenAIProvider_EmbedOmitsDimensions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decoding request: %v", err)
+		}
+		if _, ok := req["dimensions"]; ok {
+			t.Errorf("request for %v should not send dimensions: %v", req["model"], req)
+		}
+		w.Write([]byte(`{"data":[{"embedding":[0.1,0.2,0.3]}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider("sk-test", WithOpenAIBaseURL(server.URL), WithOpenAIModel("text-embedding-ada-002", 3))
+	if _, err := provider.Embed(context.Background(), "hello"); err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+}
+
+func TestOpenAIProvider_EmbedErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"message":"bad key"}}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider("sk-bad", WithOpenAIBaseURL(server.URL))
+	if _, err := provider.Embed(context.Background(), "hello"); err == nil {
+		t.Error("Embed() with rejected key should fail")
+	}
+
+	noKey := NewOpenAIProvider("", WithOpenAIBaseURL(server.URL))
+	if _, err := noKey.Embed(context.Background(), "hello"); !errors.Is(err, ErrMissingAPIKey) {
+		t.Errorf("Embed() without key error = %v, want ErrMissingAPIKey", err)
+	}
+}
+
+func TestOpenAIProvider_Defaults(t *testing.T) {
+	provider := NewOpenAIProvider("sk-test")
+	if provider.Name() != ProviderOpenAI {
+		t.Errorf("Name() = %s, want %s", provider.Name(), ProviderOpenAI)
+	}
+	if provider.ModelName() != DefaultOpenAIModel {
+		t.Errorf("ModelName() = %s, want %s", provider.ModelName(), DefaultOpenAIModel)
+	}
+	if provider.Dimensions() != DefaultOpenAIDimensions {
+		t.Errorf("Dimensions() = %d, want %d", provider.Dimensions(), DefaultOpenAIDimensions)
+	}
+}
+
+func TestOpenAIProvider_IsAvailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk-good" {
+			http.Error(w, `{"error":{"message":"bad key"}}`, http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/models/"+DefaultOpenAIModel {
+			http.Error(w, `{"error":{"message":"no such model"}}`, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":"text-embedding-3-small"}`))
+	}))
+	defer server.Close()
+
+	if err := NewOpenAIProvider("sk-good", WithOpenAIBaseURL(server.URL)).IsAvailable(context.Background()); err != nil {
+		t.Errorf("IsAvailable() error = %v", err)
+	}
+	if err := NewOpenAIProvider("sk-bad", WithOpenAIBaseURL(server.URL)).IsAvailable(context.Background()); err == nil {
+		t.Error("IsAvailable() with rejected key should fail")
+	}
+	missing := NewOpenAIProvider("sk-good", WithOpenAIBaseURL(server.URL), WithOpenAIModel("nope", 10))
+	if err := missing.IsAvailable(context.Background()); err == nil {
+		t.Error("IsAvailable() for an unknown model should fail")
+	}
+	if err := NewOpenAIProvider("").IsAvailable(context.Background()); !errors.Is(err, ErrMissingAPIKey) {
+		t.Errorf("IsAvailable() without key error = %v, want ErrMissingAPIKey", err)
+	}
+}
@@ -7,9 +7,44 @@ type Provider interface {
 	// Embed generates an embedding for the given text.
 	Embed(ctx context.Context, text string) (Embedding, error)
 
+	// Name returns the provider name, e.g. "ollama" or "openai".
+	Name() string
+
 	// ModelName returns the name of the embedding model.
 	ModelName() string
 
 	// Dimensions returns the expected vector dimensions.
 	Dimensions() int
+
+	// IsAvailable checks that the provider can be reached and will accept
+	// requests, returning an error describing why not.
+	IsAvailable(ctx context.Context) error
+}
+
+// knownModelDimensions maps embedding models to their output dimensions,
+// so configuring a model by name is enough for the common ones.
+var knownModelDimensions = map[string]int{
+	"nomic-embed-text":       768,
+	"mxbai-embed-large":      1024,
+	"all-minilm":             384,
+	"snowflake-arctic-embed": 1024,
+	"bge-m3":                 1024,
+	"text-embedding-3-small": 1536,
+	"text-embedding-3-large": 3072,
+	"text-embedding-ada-002": 1536,
+}
+
+// ModelDimensions returns the output dimensions of a known embedding model,
+// or 0 if the model is not known. An Ollama tag suffix (":latest") is
+// ignored.
+func ModelDimensions(model string) int {
+	if dims, ok := knownModelDimensions[model]; ok {
+		return dims
+	}
+	for i := len(model) - 1; i >= 0; i-- {
+		if model[i] == ':' {
+			return knownModelDimensions[model[:i]]
+		}
+	}
+	return 0
 }
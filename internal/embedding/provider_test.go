@@ -0,0 +1,22 @@
+package embedding
+
+import "testing"
+
+func TestModelDimensions(t *testing.T) {
+	tests := []struct {
+		model string
+		want  int
+	}{
+		{"nomic-embed-text", 768},
+		{"nomic-embed-text:latest", 768},
+		{"mxbai-embed-large:335m", 1024},
+		{"text-embedding-3-large", 3072},
+		{"unknown-model", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := ModelDimensions(tt.model); got != tt.want {
+			t.Errorf("ModelDimensions(%q) = %d, want %d", tt.model, got, tt.want)
+		}
+	}
+}
@@ -65,8 +65,12 @@ func ToBibTeXList(refs []reference.Reference) string {
 }
 
 // determineEntryType returns the BibTeX entry type for a reference.
+// References without a venue are @misc, since @article requires a journal.
 func determineEntryType(ref reference.Reference) string {
 	venue := strings.ToLower(ref.Venue)
+	if venue == "" {
+		return "misc"
+	}
 
 	// Preprints
 	if strings.Contains(venue, "arxiv") ||
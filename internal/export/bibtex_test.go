@@ -1,6 +1,8 @@
 package export
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -105,7 +107,8 @@ func TestDetermineEntryType(t *testing.T) {
 		{"International Conference on Machine Learning", "inproceedings"},
 		{"Workshop on AI Safety", "inproceedings"},
 		{"Symposium on Theory of Computing", "inproceedings"},
-		{"", "article"}, // Default
+		{"Cell", "article"}, // Default
+		{"", "misc"},
 	}
 
 	for _, tt := range tests {
@@ -254,12 +257,14 @@ func TestToBibTeXList(t *testing.T) {
 		{
 			ID:        "First2026",
 			Title:     "First Paper",
+			Venue:     "Nature",
 			Authors:   []reference.Author{{First: "A", Last: "B"}},
 			Published: reference.PublicationDate{Year: 2026},
 		},
 		{
 			ID:        "Second2026",
 			Title:     "Second Paper",
+			Venue:     "Science",
 			Authors:   []reference.Author{{First: "C", Last: "D"}},
 			Published: reference.PublicationDate{Year: 2025},
 		},
@@ -312,3 +317,49 @@ func TestToBibTeX_NoAuthors(t *testing.T) {
 		t.Errorf("ToBibTeX() should still include year, got:\n%s", got)
 	}
 }
+
+func TestToBibTeX_MiscWithoutVenue(t *testing.T) {
+	ref := reference.Reference{
+		ID:        "Report2026",
+		Title:     "A Technical Report",
+		Published: reference.PublicationDate{Year: 2026},
+	}
+
+	got := ToBibTeX(ref)
+
+	if !strings.HasPrefix(got, "@misc{Report2026,") {
+		t.Errorf("ToBibTeX() without venue should be @misc, got:\n%s", got)
+	}
+	if strings.Contains(got, "journal = ") {
+		t.Errorf("ToBibTeX() @misc should not have a journal, got:\n%s", got)
+	}
+}
+
+func TestToBibTeXList_RoundTrip(t *testing.T) {
+	refs := []reference.Reference{
+		{ID: "Smith2024-ab", DOI: "10.1234/abc", Title: "Trees & Forests", Venue: "Nature", Published: reference.PublicationDate{Year: 2024, Month: 3}},
+		{ID: "Lee2023-cd", Title: "Notes on 50% of MCMC", Published: reference.PublicationDate{Year: 2023}},
+		{ID: "Kim2022-ef", DOI: "10.5678/DEF", Title: "Proofs", Venue: "Proceedings of ICML", Published: reference.PublicationDate{Year: 2022}},
+	}
+
+	path := filepath.Join(t.TempDir(), "refs.bib")
+	if err := os.WriteFile(path, []byte(ToBibTeXList(refs)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := ParseBibTeXFile(path)
+	if err != nil {
+		t.Fatalf("ParseBibTeXFile() error = %v", err)
+	}
+
+	if len(idx.Keys) != len(refs) {
+		t.Errorf("parsed %d keys, want %d", len(idx.Keys), len(refs))
+	}
+	for _, ref := range refs {
+		if !idx.Keys[ref.ID] {
+			t.Errorf("citekey %s not found after round trip", ref.ID)
+		}
+		if ref.DOI != "" && !idx.HasEntry("", ref.DOI) {
+			t.Errorf("DOI %s not found after round trip", ref.DOI)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/project"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/repo"
+)
+
+// BundleVersion is the format version written into every bundle.
+const BundleVersion = 1
+
+// Bundle is a single-document snapshot of a whole nexus: every reference,
+// concept, project, repo, and edge, as stored in the JSONL files.
+type Bundle struct {
+	Version    int                   `json:"version"`
+	ExportedAt string                `json:"exported_at"` // RFC3339 snapshot timestamp
+	Refs       []reference.Reference `json:"refs"`
+	Concepts   []concept.Concept     `json:"concepts"`
+	Projects   []project.Project     `json:"projects"`
+	Repos      []repo.Repo           `json:"repos"`
+	Edges      []edge.Edge           `json:"edges"`
+}
+
+// BuildBundle assembles a bundle stamped with now. Records keep their
+// JSONL order, and nil slices become empty so every array is present.
+func BuildBundle(refs []reference.Reference, concepts []concept.Concept, projects []project.Project, repos []repo.Repo, edges []edge.Edge, now time.Time) *Bundle {
+	b := &Bundle{
+		Version:    BundleVersion,
+		ExportedAt: now.UTC().Format(time.RFC3339),
+		Refs:       refs,
+		Concepts:   concepts,
+		Projects:   projects,
+		Repos:      repos,
+		Edges:      edges,
+	}
+	if b.Refs == nil {
+		b.Refs = []reference.Reference{}
+	}
+	if b.Concepts == nil {
+		b.Concepts = []concept.Concept{}
+	}
+	if b.Projects == nil {
+		b.Projects = []project.Project{}
+	}
+	if b.Repos == nil {
+		b.Repos = []repo.Repo{}
+	}
+	if b.Edges == nil {
+		b.Edges = []edge.Edge{}
+	}
+	return b
+}
+
+// ReadBundle reads a bundle from a JSON file, rejecting any format version
+// other than BundleVersion.
+func ReadBundle(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundle: %w", err)
+	}
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing bundle: %w", err)
+	}
+	if b.Version != BundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d (want %d)", b.Version, BundleVersion)
+	}
+	return &b, nil
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/edge"
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+func TestBuildBundle(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("PST", -8*3600))
+	refs := []reference.Reference{{ID: "Smith2024-ab", Title: "A"}}
+	concepts := []concept.Concept{{ID: "mcmc", Name: "MCMC"}}
+	edges := []edge.Edge{{SourceID: "Smith2024-ab", TargetID: "concept:mcmc", RelationshipType: "introduces"}}
+
+	b := BuildBundle(refs, concepts, nil, nil, edges, now)
+
+	if b.Version != BundleVersion {
+		t.Errorf("Version = %d, want %d", b.Version, BundleVersion)
+	}
+	if b.ExportedAt != "2026-01-02T11:04:05Z" {
+		t.Errorf("ExportedAt = %s, want UTC timestamp", b.ExportedAt)
+	}
+	if len(b.Refs) != 1 || len(b.Concepts) != 1 || len(b.Edges) != 1 {
+		t.Errorf("got %d refs, %d concepts, %d edges, want 1 each", len(b.Refs), len(b.Concepts), len(b.Edges))
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, want := range []string{`"projects":[]`, `"repos":[]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("bundle JSON missing %s: %s", want, data)
+		}
+	}
+}
+
+func TestReadBundle(t *testing.T) {
+	dir := t.TempDir()
+	b := BuildBundle([]reference.Reference{{ID: "Smith2024-ab", Title: "A"}}, nil, nil, nil, nil, time.Now())
+	data, _ := json.Marshal(b)
+	path := filepath.Join(dir, "nexus.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadBundle(path)
+	if err != nil {
+		t.Fatalf("ReadBundle() error = %v", err)
+	}
+	if len(got.Refs) != 1 || got.Refs[0].ID != "Smith2024-ab" {
+		t.Errorf("Refs = %+v", got.Refs)
+	}
+
+	badPath := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(badPath, []byte(`{"version": 99}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadBundle(badPath); err == nil {
+		t.Error("ReadBundle() accepted an unsupported version")
+	}
+}
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+// ManifestVersion is the format version written into every manifest.
+const ManifestVersion = 1
+
+// Manifest is a reproducible snapshot of a set of references.
+// Each entry carries a content hash of its canonicalized record, and the
+// manifest carries a combined checksum over all entries so a single value
+// identifies the exact library state that was used.
+type Manifest struct {
+	Version   int             `json:"version"`
+	CreatedAt string          `json:"created_at"` // RFC3339 snapshot timestamp
+	Count     int             `json:"count"`
+	Checksum  string          `json:"checksum"` // SHA256 over sorted entry IDs and hashes
+	Entries   []ManifestEntry `json:"entries"`
+}
+
+// ManifestEntry identifies one reference in a manifest.
+type ManifestEntry struct {
+	ID    string `json:"id"`
+	DOI   string `json:"doi,omitempty"`
+	Title string `json:"title"`
+	Hash  string `json:"hash"` // SHA256 of the canonicalized record
+}
+
+// ContentHash returns the SHA256 hex digest of a reference's canonical JSON form.
+// The canonical form is the reference marshaled with its fixed struct field
+// order, so it is stable across runs and independent of JSONL line order.
+func ContentHash(ref reference.Reference) (string, error) {
+	data, err := json.Marshal(ref)
+	if err != nil {
+		return "", fmt.Errorf("encoding reference %s: %w", ref.ID, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// BuildManifest creates a manifest for the given references.
+// Entries are sorted by ID so the output is deterministic.
+func BuildManifest(refs []reference.Reference, now time.Time) (*Manifest, error) {
+	entries := make([]ManifestEntry, 0, len(refs))
+	for _, ref := range refs {
+		hash, err := ContentHash(ref)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, ManifestEntry{
+			ID:    ref.ID,
+			DOI:   ref.DOI,
+			Title: ref.Title,
+			Hash:  hash,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	return &Manifest{
+		Version:   ManifestVersion,
+		CreatedAt: now.UTC().Format(time.RFC3339),
+		Count:     len(entries),
+		Checksum:  combinedChecksum(entries),
+		Entries:   entries,
+	}, nil
+}
+
+// combinedChecksum hashes "id:hash\n" for each entry. Entries must be sorted by ID.
+func combinedChecksum(entries []ManifestEntry) string {
+	h := sha256.New()
+	for _, e := range entries {
+		fmt.Fprintf(h, "%s:%s\n", e.ID, e.Hash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ReadManifest reads a manifest from a JSON file.
+func ReadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// ManifestVerification is the result of checking a manifest against current references.
+type ManifestVerification struct {
+	Match         bool     `json:"match"`
+	Checked       int      `json:"checked"`
+	ChecksumValid bool     `json:"checksum_valid"`    // Manifest checksum agrees with its own entries
+	Missing       []string `json:"missing,omitempty"` // IDs in the manifest but not in the library
+	Changed       []string `json:"changed,omitempty"` // IDs whose content hash differs
+}
+
+// VerifyManifest checks whether every manifest entry still exists with the same
+// content hash. References added to the library since the snapshot are ignored:
+// the manifest pins what was used, not what else exists.
+func VerifyManifest(m *Manifest, refs []reference.Reference) (*ManifestVerification, error) {
+	byID := make(map[string]reference.Reference, len(refs))
+	for _, ref := range refs {
+		byID[ref.ID] = ref
+	}
+
+	result := &ManifestVerification{Checked: len(m.Entries)}
+	for _, e := range m.Entries {
+		ref, ok := byID[e.ID]
+		if !ok {
+			result.Missing = append(result.Missing, e.ID)
+			continue
+		}
+		hash, err := ContentHash(ref)
+		if err != nil {
+			return nil, err
+		}
+		if hash != e.Hash {
+			result.Changed = append(result.Changed, e.ID)
+		}
+	}
+
+	// A manifest whose checksum doesn't match its own entries has been edited by hand.
+	sorted := append([]ManifestEntry(nil), m.Entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	result.ChecksumValid = combinedChecksum(sorted) == m.Checksum
+
+	result.Match = result.ChecksumValid && len(result.Missing) == 0 && len(result.Changed) == 0
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+func manifestTestRefs() []reference.Reference {
+	return []reference.Reference{
+		{ID: "Zhang2020-ab", DOI: "10.1/z", Title: "Zeta", Published: reference.PublicationDate{Year: 2020}},
+		{ID: "Adams2019-cd", DOI: "10.1/a", Title: "Alpha", Published: reference.PublicationDate{Year: 2019}},
+	}
+}
+
+func TestBuildManifest_SortedAndDeterministic(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m1, err := BuildManifest(manifestTestRefs(), now)
+	if err != nil {
+		t.Fatalf("BuildManifest() error = %v", err)
+	}
+
+	if m1.Count != 2 || len(m1.Entries) != 2 {
+		t.Fatalf("Count = %d, entries = %d, want 2", m1.Count, len(m1.Entries))
+	}
+	if m1.Entries[0].ID != "Adams2019-cd" {
+		t.Errorf("entries not sorted by ID: first = %s", m1.Entries[0].ID)
+	}
+	if m1.CreatedAt != "2026-01-02T03:04:05Z" {
+		t.Errorf("CreatedAt = %s", m1.CreatedAt)
+	}
+
+	// Input order must not affect the checksum.
+	refs := manifestTestRefs()
+	refs[0], refs[1] = refs[1], refs[0]
+	m2, err := BuildManifest(refs, now)
+	if err != nil {
+		t.Fatalf("BuildManifest() error = %v", err)
+	}
+	if m1.Checksum != m2.Checksum {
+		t.Errorf("checksum depends on input order: %s vs %s", m1.Checksum, m2.Checksum)
+	}
+}
+
+func TestVerifyManifest(t *testing.T) {
+	m, err := BuildManifest(manifestTestRefs(), time.Now())
+	if err != nil {
+		t.Fatalf("BuildManifest() error = %v", err)
+	}
+
+	t.Run("unchanged library matches", func(t *testing.T) {
+		refs := append(manifestTestRefs(), reference.Reference{ID: "New2026-ef", Title: "Added later"})
+		got, err := VerifyManifest(m, refs)
+		if err != nil {
+			t.Fatalf("VerifyManifest() error = %v", err)
+		}
+		if !got.Match || !got.ChecksumValid {
+			t.Errorf("expected match, got %+v", got)
+		}
+	})
+
+	t.Run("changed and missing references", func(t *testing.T) {
+		refs := manifestTestRefs()[:1]
+		refs[0].Title = "Zeta (revised)"
+		got, err := VerifyManifest(m, refs)
+		if err != nil {
+			t.Fatalf("VerifyManifest() error = %v", err)
+		}
+		if got.Match {
+			t.Error("expected mismatch")
+		}
+		if len(got.Changed) != 1 || got.Changed[0] != "Zhang2020-ab" {
+			t.Errorf("Changed = %v", got.Changed)
+		}
+		if len(got.Missing) != 1 || got.Missing[0] != "Adams2019-cd" {
+			t.Errorf("Missing = %v", got.Missing)
+		}
+	})
+
+	t.Run("tampered checksum", func(t *testing.T) {
+		tampered := *m
+		tampered.Checksum = "deadbeef"
+		got, err := VerifyManifest(&tampered, manifestTestRefs())
+		if err != nil {
+			t.Fatalf("VerifyManifest() error = %v", err)
+		}
+		if got.Match || got.ChecksumValid {
+			t.Errorf("expected invalid checksum, got %+v", got)
+		}
+	})
+}
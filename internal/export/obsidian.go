@@ -0,0 +1,144 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/reference"
+	"gopkg.in/yaml.v3"
+)
+
+// Obsidian vault layout: one note per paper and per concept, in these
+// subdirectories of the vault.
+const (
+	ObsidianPapersDir   = "papers"
+	ObsidianConceptsDir = "concepts"
+)
+
+// ObsidianUserMarker separates generated note content from the user's own.
+// Everything below it is kept when a note is regenerated.
+const ObsidianUserMarker = "<!-- bip: generated above this line; your notes below are kept -->"
+
+// ObsidianLink is a knowledge-graph edge between a paper and a concept, as
+// listed in either end's note.
+type ObsidianLink struct {
+	Note             string // Vault-relative note path without .md, e.g. "concepts/vae"
+	Label            string // Display text
+	RelationshipType string
+	Summary          string
+}
+
+// ObsidianNoteName returns a file name (without .md) for a node ID, replacing
+// characters Obsidian does not allow in note names.
+func ObsidianNoteName(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '#', '^', '[', ']', '|':
+			return '-'
+		}
+		return r
+	}, id)
+}
+
+type obsidianPaperFrontmatter struct {
+	BipID   string   `yaml:"bip_id"`
+	Title   string   `yaml:"title"`
+	Authors []string `yaml:"authors,omitempty"`
+	Year    int      `yaml:"year,omitempty"`
+	DOI     string   `yaml:"doi,omitempty"`
+	Venue   string   `yaml:"venue,omitempty"`
+	Tags    []string `yaml:"tags,omitempty"`
+}
+
+type obsidianConceptFrontmatter struct {
+	BipID   string   `yaml:"bip_id"`
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// ObsidianPaperNote renders the generated part of a paper's note: metadata
+// frontmatter, the abstract, and a wikilink to each linked concept.
+func ObsidianPaperNote(ref reference.Reference, concepts []ObsidianLink) string {
+	fm := obsidianPaperFrontmatter{
+		BipID: ref.ID,
+		Title: ref.Title,
+		Year:  ref.Published.Year,
+		DOI:   ref.DOI,
+		Venue: ref.Venue,
+		Tags:  ref.Tags,
+	}
+	for _, a := range ref.Authors {
+		fm.Authors = append(fm.Authors, strings.TrimSpace(a.First+" "+a.Last))
+	}
+
+	var b strings.Builder
+	writeFrontmatter(&b, fm)
+	fmt.Fprintf(&b, "# %s\n", ref.Title)
+	if ref.Abstract != "" {
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(ref.Abstract))
+	}
+	writeLinkSection(&b, "Concepts", concepts)
+	fmt.Fprintf(&b, "\n%s\n", ObsidianUserMarker)
+	return b.String()
+}
+
+// ObsidianConceptNote renders the generated part of a concept's note: its
+// aliases (so Obsidian resolves links by name), description, and a wikilink
+// to each linked paper.
+func ObsidianConceptNote(c concept.Concept, papers []ObsidianLink) string {
+	fm := obsidianConceptFrontmatter{BipID: c.ID}
+	if c.Name != "" {
+		fm.Aliases = append(fm.Aliases, c.Name)
+	}
+	fm.Aliases = append(fm.Aliases, c.Aliases...)
+
+	var b strings.Builder
+	writeFrontmatter(&b, fm)
+	name := c.Name
+	if name == "" {
+		name = c.ID
+	}
+	fmt.Fprintf(&b, "# %s\n", name)
+	if c.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(c.Description))
+	}
+	writeLinkSection(&b, "Papers", papers)
+	fmt.Fprintf(&b, "\n%s\n", ObsidianUserMarker)
+	return b.String()
+}
+
+// MergeObsidianNote combines freshly generated note content with an existing
+// note. Text below ObsidianUserMarker in the existing note is kept; an
+// existing note without the marker is kept whole below it, so nothing the
+// user wrote is lost.
+func MergeObsidianNote(existing, generated string) string {
+	if existing == "" {
+		return generated
+	}
+	user := existing
+	if _, after, found := strings.Cut(existing, ObsidianUserMarker); found {
+		user = strings.TrimPrefix(after, "\n")
+	}
+	return generated + user
+}
+
+func writeFrontmatter(b *strings.Builder, fm interface{}) {
+	// Plain structs of strings and ints always marshal
+	out, _ := yaml.Marshal(fm)
+	fmt.Fprintf(b, "---\n%s---\n\n", out)
+}
+
+func writeLinkSection(b *strings.Builder, heading string, links []ObsidianLink) {
+	if len(links) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", heading)
+	for _, l := range links {
+		label := strings.NewReplacer("|", "-", "]]", "] ]", "\n", " ").Replace(l.Label)
+		fmt.Fprintf(b, "- %s: [[%s|%s]]", l.RelationshipType, l.Note, label)
+		if l.Summary != "" {
+			fmt.Fprintf(b, " — %s", strings.Join(strings.Fields(l.Summary), " "))
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+func TestObsidianPaperNote(t *testing.T) {
+	ref := reference.Reference{
+		ID:        "Smith2026-ab",
+		DOI:       "10.1234/test",
+		Title:     "Test: A Paper",
+		Authors:   []reference.Author{{First: "John", Last: "Smith"}, {Last: "Consortium"}},
+		Abstract:  "An abstract.\n",
+		Venue:     "Nature",
+		Published: reference.PublicationDate{Year: 2026},
+	}
+	links := []ObsidianLink{
+		{Note: "concepts/vae", Label: "Variational Autoencoder", RelationshipType: "introduces", Summary: "Defines\nthe VAE"},
+	}
+
+	got := ObsidianPaperNote(ref, links)
+	want := `---
+bip_id: Smith2026-ab
+title: 'Test: A Paper'
+authors:
+    - John Smith
+    - Consortium
+year: 2026
+doi: 10.1234/test
+venue: Nature
+---
+
+# Test: A Paper
+
+An abstract.
+
+## Concepts
+
+- introduces: [[concepts/vae|Variational Autoencoder]] — Defines the VAE
+
+` + ObsidianUserMarker + "\n"
+	if got != want {
+		t.Errorf("ObsidianPaperNote() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestObsidianConceptNote(t *testing.T) {
+	c := concept.Concept{ID: "vae", Name: "Variational Autoencoder", Aliases: []string{"VAE"}}
+	links := []ObsidianLink{{Note: "papers/Smith2026-ab", Label: "A | B", RelationshipType: "introduces"}}
+
+	got := ObsidianConceptNote(c, links)
+	for _, want := range []string{
+		"aliases:\n    - Variational Autoencoder\n    - VAE\n",
+		"# Variational Autoencoder\n",
+		"- introduces: [[papers/Smith2026-ab|A - B]]\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ObsidianConceptNote() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestMergeObsidianNote(t *testing.T) {
+	generated := "# New\n" + ObsidianUserMarker + "\n"
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{"new note", "", generated},
+		{"keeps user section", "# Old\n" + ObsidianUserMarker + "\nMy notes\n", generated + "My notes\n"},
+		{"no marker keeps whole note", "Hand-written\n", generated + "Hand-written\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeObsidianNote(tt.existing, generated); got != tt.want {
+				t.Errorf("MergeObsidianNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObsidianNoteName(t *testing.T) {
+	if got := ObsidianNoteName("arXiv:2401/1234"); got != "arXiv-2401-1234" {
+		t.Errorf("ObsidianNoteName() = %q", got)
+	}
+}
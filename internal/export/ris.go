@@ -0,0 +1,77 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+// ToRIS converts a reference to an RIS record, terminated by "ER  - ".
+// Multi-line values (e.g. abstracts) are folded onto one line, since RIS
+// readers treat each line as a separate tag.
+func ToRIS(ref reference.Reference) string {
+	var b strings.Builder
+	tag := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s  - %s\n", name, risValue(value))
+		}
+	}
+
+	risType := "JOUR"
+	venueTag := "JO"
+	if determineEntryType(ref) == "inproceedings" {
+		risType, venueTag = "CPAPER", "T2"
+	}
+	tag("TY", risType)
+	tag("ID", ref.ID)
+	for _, a := range ref.Authors {
+		name := a.Last
+		if a.First != "" {
+			name = a.Last + ", " + a.First
+		}
+		tag("AU", name)
+	}
+	tag("TI", ref.Title)
+	tag(venueTag, ref.Venue)
+	if ref.Published.Year > 0 {
+		tag("PY", fmt.Sprintf("%d", ref.Published.Year))
+		tag("DA", risDate(ref.Published))
+	}
+	tag("DO", ref.DOI)
+	tag("AB", ref.Abstract)
+	for _, t := range ref.Tags {
+		tag("KW", t)
+	}
+	tag("N1", ref.Note)
+	b.WriteString("ER  - \n")
+
+	return b.String()
+}
+
+// ToRISList converts multiple references to RIS format, one record per reference.
+func ToRISList(refs []reference.Reference) string {
+	var entries []string
+	for _, ref := range refs {
+		entries = append(entries, ToRIS(ref))
+	}
+	return strings.Join(entries, "\n")
+}
+
+// risDate formats a publication date as RIS DA ("YYYY/MM/DD/"), leaving
+// unknown parts empty.
+func risDate(d reference.PublicationDate) string {
+	month, day := "", ""
+	if d.Month > 0 {
+		month = fmt.Sprintf("%02d", d.Month)
+	}
+	if d.Day > 0 {
+		day = fmt.Sprintf("%02d", d.Day)
+	}
+	return fmt.Sprintf("%d/%s/%s/", d.Year, month, day)
+}
+
+// risValue collapses runs of whitespace, including newlines, to single spaces.
+func risValue(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
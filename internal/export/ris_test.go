@@ -0,0 +1,74 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+func TestToRIS_Article(t *testing.T) {
+	ref := reference.Reference{
+		ID:    "Smith2026-ab",
+		DOI:   "10.1234/test",
+		Title: "Test Paper Title",
+		Authors: []reference.Author{
+			{First: "John", Last: "Smith"},
+			{Last: "Consortium"},
+		},
+		Abstract:  "First line\nsecond   line",
+		Venue:     "Nature",
+		Published: reference.PublicationDate{Year: 2026, Month: 3},
+		Tags:      []string{"antibody"},
+	}
+
+	got := ToRIS(ref)
+	want := `TY  - JOUR
+ID  - Smith2026-ab
+AU  - Smith, John
+AU  - Consortium
+TI  - Test Paper Title
+JO  - Nature
+PY  - 2026
+DA  - 2026/03//
+DO  - 10.1234/test
+AB  - First line second line
+KW  - antibody
+ER  - 
+`
+	if got != want {
+		t.Errorf("ToRIS() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestToRIS_ConferencePaper(t *testing.T) {
+	ref := reference.Reference{
+		ID:        "Lee2025-cd",
+		Title:     "A Method",
+		Venue:     "Proceedings of ICML",
+		Published: reference.PublicationDate{Year: 2025},
+	}
+
+	got := ToRIS(ref)
+	if !strings.HasPrefix(got, "TY  - CPAPER\n") {
+		t.Errorf("expected CPAPER type, got:\n%s", got)
+	}
+	if !strings.Contains(got, "T2  - Proceedings of ICML\n") {
+		t.Errorf("expected venue as T2, got:\n%s", got)
+	}
+	if strings.Contains(got, "DO  -") || strings.Contains(got, "AB  -") {
+		t.Errorf("empty fields should be omitted, got:\n%s", got)
+	}
+}
+
+func TestToRISList(t *testing.T) {
+	refs := []reference.Reference{
+		{ID: "A", Title: "First", Published: reference.PublicationDate{Year: 2020}},
+		{ID: "B", Title: "Second", Published: reference.PublicationDate{Year: 2021}},
+	}
+
+	got := ToRISList(refs)
+	if strings.Count(got, "TY  - ") != 2 || strings.Count(got, "ER  - ") != 2 {
+		t.Errorf("expected two records, got:\n%s", got)
+	}
+}
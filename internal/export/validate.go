@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// SchemaValidator checks references against a user-supplied JSON Schema.
+// Each reference is validated in its serialized JSON form (the same shape
+// written to refs.jsonl), so a schema written for that format applies as-is.
+type SchemaValidator struct {
+	schema *jsonschema.Schema
+}
+
+// SchemaViolation records why one reference failed validation.
+type SchemaViolation struct {
+	ID     string   `json:"id"`
+	Errors []string `json:"errors"` // One message per failing location, e.g. "/doi: missing"
+}
+
+// LoadSchemaValidator compiles the JSON Schema at path.
+func LoadSchemaValidator(path string) (*SchemaValidator, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving schema path: %w", err)
+	}
+
+	schema, err := jsonschema.NewCompiler().Compile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("compiling schema %s: %w", path, err)
+	}
+	return &SchemaValidator{schema: schema}, nil
+}
+
+// Validate checks a single reference. Returns nil if it conforms.
+func (v *SchemaValidator) Validate(ref reference.Reference) (*SchemaViolation, error) {
+	data, err := json.Marshal(ref)
+	if err != nil {
+		return nil, fmt.Errorf("serializing %s: %w", ref.ID, err)
+	}
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", ref.ID, err)
+	}
+
+	err = v.schema.Validate(inst)
+	if err == nil {
+		return nil, nil
+	}
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return nil, fmt.Errorf("validating %s: %w", ref.ID, err)
+	}
+	return &SchemaViolation{ID: ref.ID, Errors: violationMessages(verr)}, nil
+}
+
+// ValidateAll splits refs into those that conform and a violation for each
+// that doesn't, preserving input order.
+func (v *SchemaValidator) ValidateAll(refs []reference.Reference) ([]reference.Reference, []SchemaViolation, error) {
+	var valid []reference.Reference
+	var violations []SchemaViolation
+	for _, ref := range refs {
+		violation, err := v.Validate(ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		if violation != nil {
+			violations = append(violations, *violation)
+		} else {
+			valid = append(valid, ref)
+		}
+	}
+	return valid, violations, nil
+}
+
+// violationMessages flattens a validation error into one "location: message"
+// string per leaf failure, sorted for stable output.
+func violationMessages(verr *jsonschema.ValidationError) []string {
+	seen := make(map[string]bool)
+	var msgs []string
+	for _, unit := range verr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		loc := unit.InstanceLocation
+		if loc == "" {
+			loc = "/"
+		}
+		msg := fmt.Sprintf("%s: %s", loc, unit.Error.String())
+		if !seen[msg] {
+			seen[msg] = true
+			msgs = append(msgs, msg)
+		}
+	}
+	if len(msgs) == 0 {
+		msgs = []string{verr.Error()}
+	}
+	sort.Strings(msgs)
+	return msgs
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/reference"
+)
+
+// writeSchema writes a JSON Schema to a temp file and returns its path.
+func writeSchema(t *testing.T, schema string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(path, []byte(schema), 0644); err != nil {
+		t.Fatalf("writing schema: %v", err)
+	}
+	return path
+}
+
+const testRefSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["id", "doi", "title"],
+  "properties": {
+    "doi": {"type": "string", "minLength": 1},
+    "published": {
+      "type": "object",
+      "properties": {"year": {"type": "integer", "minimum": 1900}}
+    }
+  }
+}`
+
+func TestSchemaValidator_ValidateAll(t *testing.T) {
+	v, err := LoadSchemaValidator(writeSchema(t, testRefSchema))
+	if err != nil {
+		t.Fatalf("LoadSchemaValidator() error = %v", err)
+	}
+
+	refs := []reference.Reference{
+		{ID: "Good2020-ab", DOI: "10.1/good", Title: "Good", Published: reference.PublicationDate{Year: 2020}},
+		{ID: "NoDOI2021-cd", Title: "Missing DOI", Published: reference.PublicationDate{Year: 2021}},
+		{ID: "Old1850-ef", DOI: "10.1/old", Title: "Old", Published: reference.PublicationDate{Year: 1850}},
+	}
+
+	valid, violations, err := v.ValidateAll(refs)
+	if err != nil {
+		t.Fatalf("ValidateAll() error = %v", err)
+	}
+	if len(valid) != 1 || valid[0].ID != "Good2020-ab" {
+		t.Errorf("valid = %v, want only Good2020-ab", valid)
+	}
+	if len(violations) != 2 {
+		t.Fatalf("violations = %d, want 2", len(violations))
+	}
+
+	if violations[0].ID != "NoDOI2021-cd" {
+		t.Errorf("violations[0].ID = %s", violations[0].ID)
+	}
+	if !containsSubstring(violations[0].Errors, "/doi") {
+		t.Errorf("NoDOI errors = %v, want a /doi location", violations[0].Errors)
+	}
+	if violations[1].ID != "Old1850-ef" {
+		t.Errorf("violations[1].ID = %s", violations[1].ID)
+	}
+	if !containsSubstring(violations[1].Errors, "/published/year") {
+		t.Errorf("Old errors = %v, want a /published/year location", violations[1].Errors)
+	}
+}
+
+func TestLoadSchemaValidator_Errors(t *testing.T) {
+	if _, err := LoadSchemaValidator(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing schema file")
+	}
+	if _, err := LoadSchemaValidator(writeSchema(t, `{"type": 42}`)); err == nil {
+		t.Error("expected error for invalid schema")
+	}
+}
+
+func containsSubstring(msgs []string, sub string) bool {
+	for _, m := range msgs {
+		if strings.Contains(m, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // CommentsToActions converts GitHubComments to ItemActions.
@@ -37,6 +38,71 @@ func CommentsToActions(comments []GitHubComment) []ItemAction {
 	return actions
 }
 
+// BuildActionTimeline assembles the chronological action timeline for items
+// from every source: issue comments, events such as closes and merges, and
+// PR reviews as returned by FetchPRReviewsAsComments. Actions on items not in
+// items are dropped (pass nil to keep all), and identical actions — same
+// actor, item, and timestamp, e.g. a review fetched both in and outside the
+// since window — are kept once.
+func BuildActionTimeline(items []GitHubItem, comments []GitHubComment, events []ItemAction, reviews []GitHubComment) []ItemAction {
+	var wanted map[int]bool
+	if items != nil {
+		wanted = make(map[int]bool, len(items))
+		for _, item := range items {
+			wanted[item.Number] = true
+		}
+	}
+
+	type actionKey struct {
+		item  int
+		actor string
+		ts    int64
+	}
+	seen := make(map[actionKey]bool)
+
+	all := CommentsToActions(comments)
+	all = append(all, events...)
+	all = append(all, CommentsToActions(reviews)...)
+
+	timeline := make([]ItemAction, 0, len(all))
+	for _, a := range all {
+		if wanted != nil && !wanted[a.ItemNumber] {
+			continue
+		}
+		key := actionKey{a.ItemNumber, a.Actor, a.Timestamp.UnixNano()}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		timeline = append(timeline, a)
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Timestamp.Before(timeline[j].Timestamp)
+	})
+	return timeline
+}
+
+// Ball-in-court reasons returned by BallInMyCourtReason and its
+// assignee-aware and strict variants.
+const (
+	CourtNeedsReview   = "needs_review"    // Their item, no actions yet
+	CourtTheyReplied   = "they_replied"    // Someone else acted last
+	CourtWaitingOnThem = "waiting_on_them" // My item, no actions yet
+	CourtIActedLast    = "i_acted_last"    // I acted last
+
+	// CourtAssignedElsewhere is given by PartitionByCourt in assignee-aware
+	// mode, and by BallInMyCourtAssigneeAwareReason, for items
+	// AssignedElsewhere.
+	CourtAssignedElsewhere = "assigned_elsewhere"
+
+	// CourtInvolved and CourtNotInvolved are given by
+	// BallInMyCourtStrictReason for their items with no actions, depending
+	// on HasInvolvement.
+	CourtInvolved    = "involved"
+	CourtNotInvolved = "not_involved"
+)
+
 // BallInMyCourt determines if the user needs to act on an item.
 //
 // Truth table:
@@ -50,6 +116,15 @@ func CommentsToActions(comments []GitHubComment) []ItemAction {
 //
 // Actions include comments, close events, and merge events.
 func BallInMyCourt(item GitHubItem, actions []ItemAction, githubUser string) bool {
+	mine, _ := BallInMyCourtReason(item, actions, githubUser)
+	return mine
+}
+
+// BallInMyCourtReason is BallInMyCourt plus the reason for the decision,
+// one of the Court* constants: CourtNeedsReview and CourtTheyReplied when
+// the ball is in the user's court, CourtWaitingOnThem and CourtIActedLast
+// when it is not.
+func BallInMyCourtReason(item GitHubItem, actions []ItemAction, githubUser string) (bool, string) {
 	author := item.User.Login
 	isMyItem := author == githubUser
 
@@ -58,14 +133,75 @@ func BallInMyCourt(item GitHubItem, actions []ItemAction, githubUser string) boo
 
 	if len(itemActions) == 0 {
 		// No actions: show their items (need review), hide mine (waiting for feedback)
-		return !isMyItem
+		if isMyItem {
+			return false, CourtWaitingOnThem
+		}
+		return true, CourtNeedsReview
 	}
 
 	// Has actions: show if last actor is not me (they're waiting for my response)
 	sortActionsByTime(itemActions)
 	lastActor := itemActions[len(itemActions)-1].Actor
 
-	return lastActor != "" && lastActor != githubUser
+	switch lastActor {
+	case githubUser:
+		return false, CourtIActedLast
+	case "":
+		return false, CourtWaitingOnThem // No attributable last action
+	}
+	return true, CourtTheyReplied
+}
+
+// AssignedElsewhere reports whether item is assigned, but not to user, and
+// user is not a requested reviewer on it. Under assignee-aware ball-in-court
+// such items are in the assignee's court regardless of who acted last.
+// Unassigned items are never assigned elsewhere.
+func AssignedElsewhere(item GitHubItem, user string) bool {
+	if len(item.Assignees) == 0 {
+		return false
+	}
+	for _, a := range item.Assignees {
+		if a.Login == user {
+			return false
+		}
+	}
+	for _, r := range item.RequestedReviewers {
+		if r.Login == user {
+			return false
+		}
+	}
+	return true
+}
+
+// BallInMyCourtAssigneeAware is like BallInMyCourt but first hands items
+// assigned to someone else to their assignee (see AssignedElsewhere).
+// Unassigned items behave exactly as in BallInMyCourt.
+func BallInMyCourtAssigneeAware(item GitHubItem, actions []ItemAction, githubUser string) bool {
+	mine, _ := BallInMyCourtAssigneeAwareReason(item, actions, githubUser)
+	return mine
+}
+
+// BallInMyCourtAssigneeAwareReason is BallInMyCourtAssigneeAware plus the
+// reason: CourtAssignedElsewhere for items AssignedElsewhere, else the
+// reason from BallInMyCourtReason.
+func BallInMyCourtAssigneeAwareReason(item GitHubItem, actions []ItemAction, githubUser string) (bool, string) {
+	if AssignedElsewhere(item, githubUser) {
+		return false, CourtAssignedElsewhere
+	}
+	return BallInMyCourtReason(item, actions, githubUser)
+}
+
+// FilterAssignedElsewhere drops items for which AssignedElsewhere is true.
+// Apply it before FilterByBallInCourt or FilterByBallInCourtStrict to make
+// either filter assignee-aware.
+func FilterAssignedElsewhere(items []GitHubItem, githubUser string) []GitHubItem {
+	var filtered []GitHubItem
+	for _, item := range items {
+		if !AssignedElsewhere(item, githubUser) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
 }
 
 // Involvement captures all the signals that indicate a user has some connection
@@ -142,33 +278,44 @@ func bodyMentionsUser(body, user string) bool {
 // window, the last actor decides; if it's my item with no actions, it's not
 // ball-in-court.
 func BallInMyCourtStrict(item GitHubItem, actions []ItemAction, githubUser string, inv Involvement) bool {
-	author := item.User.Login
-	isMyItem := author == githubUser
-
-	itemActions := filterActionsForItem(actions, item.Number)
+	mine, _ := BallInMyCourtStrictReason(item, actions, githubUser, inv)
+	return mine
+}
 
-	if len(itemActions) == 0 {
-		if isMyItem {
-			return false
+// BallInMyCourtStrictReason is BallInMyCourtStrict plus the reason. Their
+// items with no actions get CourtInvolved or CourtNotInvolved; every other
+// case gets the reason BallInMyCourtReason gives.
+func BallInMyCourtStrictReason(item GitHubItem, actions []ItemAction, githubUser string, inv Involvement) (bool, string) {
+	if item.User.Login != githubUser && len(filterActionsForItem(actions, item.Number)) == 0 {
+		if HasInvolvement(item, githubUser, inv) {
+			return true, CourtInvolved
 		}
-		return HasInvolvement(item, githubUser, inv)
+		return false, CourtNotInvolved
 	}
-
-	sortActionsByTime(itemActions)
-	lastActor := itemActions[len(itemActions)-1].Actor
-
-	return lastActor != "" && lastActor != githubUser
+	return BallInMyCourtReason(item, actions, githubUser)
 }
 
 // FilterByBallInCourtStrict applies BallInMyCourtStrict to each item.
 func FilterByBallInCourtStrict(items []GitHubItem, actions []ItemAction, githubUser string, inv Involvement) []GitHubItem {
+	filtered, _ := FilterByCourtWithReasons(items, func(item GitHubItem) (bool, string) {
+		return BallInMyCourtStrictReason(item, actions, githubUser, inv)
+	})
+	return filtered
+}
+
+// FilterByCourtWithReasons keeps the items court puts in the user's court
+// and maps each kept item's number to the reason court gave for it. Pass
+// one of the *Reason functions, bound to its actions and user, as court.
+func FilterByCourtWithReasons(items []GitHubItem, court func(GitHubItem) (bool, string)) ([]GitHubItem, map[int]string) {
 	var filtered []GitHubItem
+	reasons := make(map[int]string)
 	for _, item := range items {
-		if BallInMyCourtStrict(item, actions, githubUser, inv) {
+		if mine, reason := court(item); mine {
 			filtered = append(filtered, item)
+			reasons[item.Number] = reason
 		}
 	}
-	return filtered
+	return filtered, reasons
 }
 
 // filterActionsForItem returns actions that belong to the given item number.
@@ -220,6 +367,88 @@ func FilterByBallInCourt(items []GitHubItem, actions []ItemAction, githubUser st
 	return filtered
 }
 
+// FilterByLabels keeps items carrying at least one of includeLabels (any
+// item if includeLabels is empty) and none of excludeLabels. Exclusion wins
+// when an item matches both. Labels compare case-insensitively, as on GitHub.
+func FilterByLabels(items []GitHubItem, includeLabels, excludeLabels []string) []GitHubItem {
+	if len(includeLabels) == 0 && len(excludeLabels) == 0 {
+		return items
+	}
+	var filtered []GitHubItem
+	for _, item := range items {
+		if hasAnyLabel(item, excludeLabels) {
+			continue
+		}
+		if len(includeLabels) > 0 && !hasAnyLabel(item, includeLabels) {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
+// FilterByBallInCourtWithLabels applies FilterByLabels on top of
+// FilterByBallInCourt.
+func FilterByBallInCourtWithLabels(items []GitHubItem, actions []ItemAction, githubUser string, includeLabels, excludeLabels []string) []GitHubItem {
+	return FilterByBallInCourt(FilterByLabels(items, includeLabels, excludeLabels), actions, githubUser)
+}
+
+// CourtEntry is one item of a CourtSummary, with why it landed in its bucket.
+type CourtEntry struct {
+	Item      GitHubItem
+	Reason    string // One of the Court* constants from BallInMyCourtReason
+	LastActor string // Who acted last on the item, or "" if no actions
+}
+
+// CourtSummary groups items by ball-in-court status.
+type CourtSummary struct {
+	NeedsResponse   []CourtEntry // Open items in the user's court
+	WaitingOnOthers []CourtEntry // Open items waiting on someone else
+	Resolved        []CourtEntry // Closed issues and closed or merged PRs
+}
+
+// PartitionByCourt sorts items into CourtSummary buckets using
+// BallInMyCourtReason. Closed items go to Resolved whoever acted last; open
+// items go to NeedsResponse or WaitingOnOthers. With assigneeAware, items
+// AssignedElsewhere wait on their assignee, with reason
+// CourtAssignedElsewhere. Items keep their input order within each bucket.
+func PartitionByCourt(items []GitHubItem, actions []ItemAction, githubUser string, assigneeAware bool) CourtSummary {
+	var summary CourtSummary
+	for _, item := range items {
+		mine, reason := BallInMyCourtReason(item, actions, githubUser)
+		if assigneeAware && AssignedElsewhere(item, githubUser) {
+			mine, reason = false, CourtAssignedElsewhere
+		}
+		entry := CourtEntry{Item: item, Reason: reason}
+		if itemActions := filterActionsForItem(actions, item.Number); len(itemActions) > 0 {
+			sortActionsByTime(itemActions)
+			entry.LastActor = itemActions[len(itemActions)-1].Actor
+		}
+
+		switch {
+		case item.State == "closed":
+			summary.Resolved = append(summary.Resolved, entry)
+		case mine:
+			summary.NeedsResponse = append(summary.NeedsResponse, entry)
+		default:
+			summary.WaitingOnOthers = append(summary.WaitingOnOthers, entry)
+		}
+	}
+	return summary
+}
+
+// hasAnyLabel reports whether item carries any of labels.
+func hasAnyLabel(item GitHubItem, labels []string) bool {
+	for _, l := range item.Labels {
+		for _, want := range labels {
+			if strings.EqualFold(l.Name, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // EnrichActionsWithLastComments fetches the last issue-thread comment for items
 // that have no actions in the current window. This prevents ball-in-court from
 // falling through to the author-based default when the user's last comment
@@ -269,6 +498,56 @@ func EnrichActionsWithLastComments(repo string, items []GitHubItem, existingActi
 	return enriched
 }
 
+// CourtActivity is the comment and review activity on a repo's items that
+// ball-in-court classification reads.
+type CourtActivity struct {
+	Comments []GitHubComment // Issue and PR comments, and PR reviews, updated since the window start
+	Reviews  []GitHubComment // Every review on the items' PRs, however old
+}
+
+// FetchCourtActivity fetches a repo's issue and PR comments updated since
+// since, and every review on the PRs among items. Reviews are fetched without
+// a time filter because a review predating the window still decides whose
+// move it is; those inside the window are also added to Comments for display.
+// A failed source is logged to stderr and left out.
+func FetchCourtActivity(repo string, items []GitHubItem, since time.Time) CourtActivity {
+	var activity CourtActivity
+	issueComments, err := FetchIssueComments(repo, since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to fetch issue comments for %s: %v\n", repo, err)
+	}
+	prComments, err := FetchPRComments(repo, since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to fetch PR comments for %s: %v\n", repo, err)
+	}
+	activity.Comments = append(issueComments, prComments...)
+
+	var prNumbers []int
+	for _, item := range items {
+		if item.IsPR {
+			prNumbers = append(prNumbers, item.Number)
+		}
+	}
+	if len(prNumbers) > 0 {
+		activity.Reviews = FetchPRReviewsAsComments(repo, prNumbers, time.Time{})
+		for _, rc := range activity.Reviews {
+			if !rc.UpdatedAt.Before(since) {
+				activity.Comments = append(activity.Comments, rc)
+			}
+		}
+	}
+	return activity
+}
+
+// CourtActions builds the ball-in-court action timeline for items from
+// activity. Only comments and reviews count; close and merge events are
+// administrative, not conversational. Items with no actions get their last
+// comment fetched, so a reply that predates the window still counts.
+func CourtActions(repo string, items []GitHubItem, activity CourtActivity) []ItemAction {
+	actions := BuildActionTimeline(items, activity.Comments, nil, activity.Reviews)
+	return append(actions, EnrichActionsWithLastComments(repo, items, actions)...)
+}
+
 // FilterCommentsByItems returns comments that belong to the given items.
 func FilterCommentsByItems(comments []GitHubComment, items []GitHubItem) []GitHubComment {
 	itemNumbers := make(map[int]bool)
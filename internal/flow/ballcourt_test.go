@@ -1,6 +1,9 @@
 package flow
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -29,11 +32,14 @@ func TestBallInMyCourt(t *testing.T) {
 	}
 
 	tests := []struct {
-		name       string
-		itemAuthor string
-		actions    []ItemAction
-		expected   bool
-		reason     string
+		name          string
+		itemAuthor    string
+		assignees     []string
+		reviewers     []string
+		assigneeAware bool
+		actions       []ItemAction
+		expected      bool
+		reason        string
 	}{
 		{
 			name:       "their item, no actions",
@@ -77,12 +83,83 @@ func TestBallInMyCourt(t *testing.T) {
 			expected:   false,
 			reason:     "waiting for their reply",
 		},
+		{
+			name:       "assigned to someone else, they acted last, not assignee-aware",
+			itemAuthor: them,
+			assignees:  []string{"other"},
+			actions:    []ItemAction{makeAction(them, 1)},
+			expected:   true,
+			reason:     "assignment ignored by default",
+		},
+		{
+			name:          "assigned to someone else, they acted last",
+			itemAuthor:    them,
+			assignees:     []string{"other"},
+			assigneeAware: true,
+			actions:       []ItemAction{makeAction(them, 1)},
+			expected:      false,
+			reason:        "ball is in the assignee's court",
+		},
+		{
+			name:          "my item assigned to someone else, they replied",
+			itemAuthor:    me,
+			assignees:     []string{"other"},
+			assigneeAware: true,
+			actions:       []ItemAction{makeAction(them, 1)},
+			expected:      false,
+			reason:        "assignee owns it even on my item",
+		},
+		{
+			name:          "assigned to someone else, I'm a requested reviewer",
+			itemAuthor:    them,
+			assignees:     []string{"other"},
+			reviewers:     []string{me},
+			assigneeAware: true,
+			actions:       []ItemAction{makeAction(them, 1)},
+			expected:      true,
+			reason:        "reviewers keep the ball",
+		},
+		{
+			name:          "assigned to me and someone else, they acted last",
+			itemAuthor:    them,
+			assignees:     []string{"other", me},
+			assigneeAware: true,
+			actions:       []ItemAction{makeAction(them, 1)},
+			expected:      true,
+			reason:        "I'm an assignee",
+		},
+		{
+			name:          "assigned to me, I acted last",
+			itemAuthor:    them,
+			assignees:     []string{me},
+			assigneeAware: true,
+			actions:       []ItemAction{makeAction(me, 1)},
+			expected:      false,
+			reason:        "last-actor rule still applies",
+		},
+		{
+			name:          "unassigned, no actions, assignee-aware",
+			itemAuthor:    them,
+			assigneeAware: true,
+			actions:       nil,
+			expected:      true,
+			reason:        "unassigned items keep current behavior",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			item := makeItem(tt.itemAuthor, 1)
+			for _, a := range tt.assignees {
+				item.Assignees = append(item.Assignees, GitHubUser{Login: a})
+			}
+			for _, r := range tt.reviewers {
+				item.RequestedReviewers = append(item.RequestedReviewers, GitHubUser{Login: r})
+			}
 			got := BallInMyCourt(item, tt.actions, me)
+			if tt.assigneeAware {
+				got = BallInMyCourtAssigneeAware(item, tt.actions, me)
+			}
 			if got != tt.expected {
 				t.Errorf("BallInMyCourt() = %v, want %v (%s)", got, tt.expected, tt.reason)
 			}
@@ -451,6 +528,72 @@ func TestCommentsToActions(t *testing.T) {
 	})
 }
 
+func TestBuildActionTimeline(t *testing.T) {
+	now := time.Now()
+	comment := func(login string, item int, when time.Time) GitHubComment {
+		return GitHubComment{
+			User:      GitHubUser{Login: login},
+			IssueURL:  fmt.Sprintf("https://api.github.com/repos/org/repo/issues/%d", item),
+			UpdatedAt: when,
+		}
+	}
+
+	t.Run("merges sources in time order", func(t *testing.T) {
+		comments := []GitHubComment{comment("alice", 1, now)}
+		events := []ItemAction{{ItemNumber: 1, Actor: "carol", Timestamp: now.Add(time.Hour)}}
+		reviews := []GitHubComment{comment("bob", 1, now.Add(-time.Hour))}
+
+		timeline := BuildActionTimeline(nil, comments, events, reviews)
+
+		var actors []string
+		for _, a := range timeline {
+			actors = append(actors, a.Actor)
+		}
+		if got := strings.Join(actors, ","); got != "bob,alice,carol" {
+			t.Errorf("timeline actors = %s, want bob,alice,carol", got)
+		}
+	})
+
+	t.Run("dedups identical actions", func(t *testing.T) {
+		// A since-window review appears both in comments and in reviews
+		review := comment("bob", 1, now)
+		comments := []GitHubComment{review, comment("bob", 1, now.Add(time.Minute))}
+		reviews := []GitHubComment{review}
+		events := []ItemAction{{ItemNumber: 1, Actor: "bob", Timestamp: now}}
+
+		timeline := BuildActionTimeline(nil, comments, events, reviews)
+
+		if len(timeline) != 2 {
+			t.Fatalf("Expected 2 actions after dedup, got %d: %+v", len(timeline), timeline)
+		}
+		if !timeline[0].Timestamp.Equal(now) || !timeline[1].Timestamp.Equal(now.Add(time.Minute)) {
+			t.Errorf("unexpected timeline: %+v", timeline)
+		}
+	})
+
+	t.Run("same time, different actor or item is kept", func(t *testing.T) {
+		comments := []GitHubComment{comment("alice", 1, now), comment("bob", 1, now), comment("alice", 2, now)}
+
+		if timeline := BuildActionTimeline(nil, comments, nil, nil); len(timeline) != 3 {
+			t.Errorf("Expected 3 actions, got %d", len(timeline))
+		}
+	})
+
+	t.Run("restricts to items", func(t *testing.T) {
+		comments := []GitHubComment{comment("alice", 1, now), comment("bob", 2, now)}
+		items := []GitHubItem{{Number: 2}}
+
+		timeline := BuildActionTimeline(items, comments, nil, nil)
+
+		if len(timeline) != 1 || timeline[0].ItemNumber != 2 {
+			t.Errorf("Expected only item 2, got %+v", timeline)
+		}
+		if timeline := BuildActionTimeline([]GitHubItem{}, comments, nil, nil); len(timeline) != 0 {
+			t.Errorf("Expected empty timeline for no items, got %+v", timeline)
+		}
+	})
+}
+
 func TestBallInMyCourtStrict(t *testing.T) {
 	me := "jgallowa07"
 	now := time.Now()
@@ -716,3 +859,238 @@ func TestFilterByBallInCourt(t *testing.T) {
 		t.Errorf("Expected item #1, got #%d", filtered[0].Number)
 	}
 }
+
+func TestBallInMyCourtReason(t *testing.T) {
+	now := time.Now()
+	act := func(actor string, offset time.Duration) ItemAction {
+		return ItemAction{ItemNumber: 1, Actor: actor, Timestamp: now.Add(offset)}
+	}
+
+	tests := []struct {
+		name       string
+		itemAuthor string
+		actions    []ItemAction
+		wantMine   bool
+		wantReason string
+	}{
+		{"their item, no actions", "them", nil, true, CourtNeedsReview},
+		{"their item, they acted last", "them", []ItemAction{act("me", 0), act("them", time.Minute)}, true, CourtTheyReplied},
+		{"their item, I acted last", "them", []ItemAction{act("me", 0)}, false, CourtIActedLast},
+		{"my item, no actions", "me", nil, false, CourtWaitingOnThem},
+		{"my item, they acted last", "me", []ItemAction{act("them", 0)}, true, CourtTheyReplied},
+		{"my item, I acted last", "me", []ItemAction{act("them", 0), act("me", time.Minute)}, false, CourtIActedLast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := GitHubItem{Number: 1, User: GitHubUser{Login: tt.itemAuthor}}
+			mine, reason := BallInMyCourtReason(item, tt.actions, "me")
+			if mine != tt.wantMine || reason != tt.wantReason {
+				t.Errorf("BallInMyCourtReason() = (%v, %q), want (%v, %q)", mine, reason, tt.wantMine, tt.wantReason)
+			}
+			if got := BallInMyCourt(item, tt.actions, "me"); got != mine {
+				t.Errorf("BallInMyCourt() = %v, disagrees with BallInMyCourtReason() = %v", got, mine)
+			}
+		})
+	}
+}
+
+func TestBallInMyCourtVariantReasons(t *testing.T) {
+	now := time.Now()
+	involved := GitHubItem{Number: 1, User: GitHubUser{Login: "them"}, Assignees: []GitHubUser{{Login: "me"}}}
+	uninvolved := GitHubItem{Number: 2, User: GitHubUser{Login: "them"}}
+	elsewhere := GitHubItem{Number: 3, User: GitHubUser{Login: "them"}, Assignees: []GitHubUser{{Login: "other"}}}
+	replied := []ItemAction{{ItemNumber: 2, Actor: "them", Timestamp: now}}
+
+	tests := []struct {
+		name       string
+		court      func() (bool, string)
+		wantMine   bool
+		wantReason string
+	}{
+		{"strict, involved", func() (bool, string) { return BallInMyCourtStrictReason(involved, nil, "me", Involvement{}) }, true, CourtInvolved},
+		{"strict, not involved", func() (bool, string) { return BallInMyCourtStrictReason(uninvolved, nil, "me", Involvement{}) }, false, CourtNotInvolved},
+		{"strict, they replied", func() (bool, string) { return BallInMyCourtStrictReason(uninvolved, replied, "me", Involvement{}) }, true, CourtTheyReplied},
+		{"assignee-aware, assigned elsewhere", func() (bool, string) { return BallInMyCourtAssigneeAwareReason(elsewhere, nil, "me") }, false, CourtAssignedElsewhere},
+		{"assignee-aware, unassigned", func() (bool, string) { return BallInMyCourtAssigneeAwareReason(uninvolved, nil, "me") }, true, CourtNeedsReview},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mine, reason := tt.court()
+			if mine != tt.wantMine || reason != tt.wantReason {
+				t.Errorf("got (%v, %q), want (%v, %q)", mine, reason, tt.wantMine, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestFilterByCourtWithReasons(t *testing.T) {
+	items := []GitHubItem{
+		{Number: 1, User: GitHubUser{Login: "them"}, Assignees: []GitHubUser{{Login: "me"}}}, // Keep: involved
+		{Number: 2, User: GitHubUser{Login: "them"}},                                         // Drop: not involved
+	}
+
+	filtered, reasons := FilterByCourtWithReasons(items, func(item GitHubItem) (bool, string) {
+		return BallInMyCourtStrictReason(item, nil, "me", Involvement{})
+	})
+
+	if len(filtered) != 1 || filtered[0].Number != 1 {
+		t.Fatalf("FilterByCourtWithReasons() kept %v, want only #1", filtered)
+	}
+	// The reason comes from the strict filter, not plain BallInMyCourtReason
+	if reasons[1] != CourtInvolved {
+		t.Errorf("reasons[1] = %q, want %q", reasons[1], CourtInvolved)
+	}
+	if _, ok := reasons[2]; ok {
+		t.Errorf("reasons has an entry for dropped item #2: %q", reasons[2])
+	}
+}
+
+func TestFilterByBallInCourtWithLabels(t *testing.T) {
+	labeled := func(number int, labels ...string) GitHubItem {
+		item := GitHubItem{Number: number, User: GitHubUser{Login: "them"}}
+		for _, l := range labels {
+			item.Labels = append(item.Labels, GitHubLabel{Name: l})
+		}
+		return item
+	}
+	items := []GitHubItem{
+		labeled(1),
+		labeled(2, "needs-review"),
+		labeled(3, "needs-review", "blocked"),
+		labeled(4, "wontfix"),
+		labeled(5, "Needs-Review"),
+	}
+	// Item 6 carries the include label but I acted last
+	items = append(items, labeled(6, "needs-review"))
+	actions := []ItemAction{{Actor: "me", ItemNumber: 6, Timestamp: time.Now()}}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []int
+	}{
+		{"no label filters", nil, nil, []int{1, 2, 3, 4, 5}},
+		{"include only", []string{"needs-review"}, nil, []int{2, 3, 5}},
+		{"exclude only", nil, []string{"wontfix", "blocked"}, []int{1, 2, 5}},
+		{"exclude wins over include", []string{"needs-review"}, []string{"blocked"}, []int{2, 5}},
+		{"same label included and excluded", []string{"wontfix"}, []string{"wontfix"}, nil},
+		{"include matches any of several", []string{"wontfix", "needs-review"}, []string{"blocked"}, []int{2, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := FilterByBallInCourtWithLabels(items, actions, "me", tt.include, tt.exclude)
+			var got []int
+			for _, item := range filtered {
+				got = append(got, item.Number)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got items %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got items %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPartitionByCourt(t *testing.T) {
+	me := "me"
+	now := time.Now()
+
+	items := []GitHubItem{
+		{Number: 1, User: GitHubUser{Login: "them"}, State: "open"}, // no actions: needs review
+		{Number: 2, User: GitHubUser{Login: me}, State: "open"},     // they replied
+		{Number: 3, User: GitHubUser{Login: "them"}, State: "open"}, // I acted last
+		{Number: 4, User: GitHubUser{Login: me}, State: "open"},     // no actions: waiting
+		{Number: 5, User: GitHubUser{Login: "them"}, State: "closed", IsPR: true},
+	}
+	actions := []ItemAction{
+		{ItemNumber: 2, Actor: me, Timestamp: now.Add(-2 * time.Hour)},
+		{ItemNumber: 2, Actor: "reviewer", Timestamp: now.Add(-time.Hour)},
+		{ItemNumber: 3, Actor: me, Timestamp: now},
+		{ItemNumber: 3, Actor: "them", Timestamp: now.Add(-time.Hour)}, // out of order
+		{ItemNumber: 5, Actor: "them", Timestamp: now},
+	}
+
+	summary := PartitionByCourt(items, actions, me, false)
+
+	check := func(name string, got []CourtEntry, want ...CourtEntry) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %d entries, want %d: %+v", name, len(got), len(want), got)
+		}
+		for i := range want {
+			if got[i].Item.Number != want[i].Item.Number || got[i].Reason != want[i].Reason || got[i].LastActor != want[i].LastActor {
+				t.Errorf("%s[%d] = #%d %s %q, want #%d %s %q", name, i,
+					got[i].Item.Number, got[i].Reason, got[i].LastActor,
+					want[i].Item.Number, want[i].Reason, want[i].LastActor)
+			}
+		}
+	}
+	entry := func(n int, reason, lastActor string) CourtEntry {
+		return CourtEntry{Item: GitHubItem{Number: n}, Reason: reason, LastActor: lastActor}
+	}
+
+	check("NeedsResponse", summary.NeedsResponse,
+		entry(1, CourtNeedsReview, ""),
+		entry(2, CourtTheyReplied, "reviewer"))
+	check("WaitingOnOthers", summary.WaitingOnOthers,
+		entry(3, CourtIActedLast, me),
+		entry(4, CourtWaitingOnThem, ""))
+	check("Resolved", summary.Resolved,
+		entry(5, CourtTheyReplied, "them"))
+}
+
+func TestPartitionByCourt_AssigneeAware(t *testing.T) {
+	me := "me"
+	now := time.Now()
+
+	items := []GitHubItem{
+		// They replied, but it's assigned to a teammate
+		{Number: 1, User: GitHubUser{Login: me}, State: "open", Assignees: []GitHubUser{{Login: "alice"}}},
+		// Assigned to a teammate, but I'm a requested reviewer
+		{Number: 2, User: GitHubUser{Login: "alice"}, State: "open", IsPR: true,
+			Assignees: []GitHubUser{{Login: "alice"}}, RequestedReviewers: []GitHubUser{{Login: me}}},
+		// Assigned to me
+		{Number: 3, User: GitHubUser{Login: "them"}, State: "open", Assignees: []GitHubUser{{Login: me}}},
+		// Unassigned, no actions
+		{Number: 4, User: GitHubUser{Login: "them"}, State: "open"},
+		// Closed items stay resolved
+		{Number: 5, User: GitHubUser{Login: "them"}, State: "closed", Assignees: []GitHubUser{{Login: "alice"}}},
+	}
+	actions := []ItemAction{
+		{ItemNumber: 1, Actor: "reviewer", Timestamp: now},
+	}
+
+	reasons := func(entries []CourtEntry) map[int]string {
+		m := make(map[int]string)
+		for _, e := range entries {
+			m[e.Item.Number] = e.Reason
+		}
+		return m
+	}
+
+	off := PartitionByCourt(items, actions, me, false)
+	if got := reasons(off.NeedsResponse); len(got) != 4 || got[1] != CourtTheyReplied {
+		t.Errorf("assignee-unaware NeedsResponse = %v, want #1-#4 with #1 they_replied", got)
+	}
+
+	on := PartitionByCourt(items, actions, me, true)
+	wantMine := map[int]string{2: CourtNeedsReview, 3: CourtNeedsReview, 4: CourtNeedsReview}
+	if got := reasons(on.NeedsResponse); !reflect.DeepEqual(got, wantMine) {
+		t.Errorf("NeedsResponse = %v, want %v", got, wantMine)
+	}
+	wantWaiting := map[int]string{1: CourtAssignedElsewhere}
+	if got := reasons(on.WaitingOnOthers); !reflect.DeepEqual(got, wantWaiting) {
+		t.Errorf("WaitingOnOthers = %v, want %v", got, wantWaiting)
+	}
+	if got := reasons(on.Resolved); len(got) != 1 || got[5] == "" {
+		t.Errorf("Resolved = %v, want #5", got)
+	}
+}
@@ -132,6 +132,9 @@ func parseRepoEntriesYAML(items []interface{}) ([]RepoEntry, error) {
 			if channel, ok := v["channel"].(string); ok {
 				entry.Channel = channel
 			}
+			if tmpl, ok := v["prompt_template"].(string); ok {
+				entry.PromptTemplate = tmpl
+			}
 			if raw, ok := v["layout"]; ok {
 				layout, err := parseLayoutMap(raw)
 				if err != nil {
@@ -439,6 +442,31 @@ func GetRepoContextPath(nexusPath, orgRepo string) string {
 	return ""
 }
 
+// GetRepoPromptTemplatePath returns the bip spawn prompt template path for a
+// repo if its sources.yml entry sets prompt_template. Relative paths are
+// resolved against the nexus directory. Returns empty string if no template
+// is set for the repo or if sources.yml cannot be loaded.
+func GetRepoPromptTemplatePath(nexusPath, orgRepo string) string {
+	sources, err := LoadSources(nexusPath)
+	if err != nil {
+		return ""
+	}
+
+	for _, entries := range [][]RepoEntry{sources.Writing, sources.Code} {
+		for _, entry := range entries {
+			if entry.Repo != orgRepo || entry.PromptTemplate == "" {
+				continue
+			}
+			path := config.ExpandTilde(entry.PromptTemplate)
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(nexusPath, path)
+			}
+			return path
+		}
+	}
+	return ""
+}
+
 // RepoInCategory checks if a repo is in a specific category (code or writing).
 // Returns false if the repo is not in the category, if the category is invalid,
 // or if sources.yml cannot be loaded.
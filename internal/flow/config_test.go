@@ -139,6 +139,36 @@ writing:
 	}
 }
 
+func TestGetRepoPromptTemplatePath(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcesContent := `code:
+  - matsengrp/repo1
+  - repo: matsengrp/repo2
+    prompt_template: templates/pr-review.md
+writing:
+  - repo: matsengrp/paper1
+    prompt_template: /abs/paper-review.md
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "sources.yml"), []byte(sourcesContent), 0644); err != nil {
+		t.Fatalf("Failed to write test sources.yml: %v", err)
+	}
+
+	tests := []struct {
+		repo string
+		want string
+	}{
+		{"matsengrp/repo2", filepath.Join(tmpDir, "templates/pr-review.md")},
+		{"matsengrp/paper1", "/abs/paper-review.md"},
+		{"matsengrp/repo1", ""},
+		{"matsengrp/unknown", ""},
+	}
+	for _, tt := range tests {
+		if got := GetRepoPromptTemplatePath(tmpDir, tt.repo); got != tt.want {
+			t.Errorf("GetRepoPromptTemplatePath(%q) = %q, want %q", tt.repo, got, tt.want)
+		}
+	}
+}
+
 func TestLoadReposByChannel(t *testing.T) {
 	// Create a temp directory with a test sources.yml
 	tmpDir := t.TempDir()
@@ -57,6 +57,30 @@ func ParseDuration(s string) (time.Duration, error) {
 	}
 }
 
+// ErrInvalidSince is returned by ParseSince for unrecognized input.
+var ErrInvalidSince = errors.New("invalid --since value; use a duration like 7d, 2w, 36h, a date (YYYY-MM-DD), or an RFC3339 time")
+
+// ParseSince parses a --since value into the start of a time window. It
+// accepts a duration back from now in ParseDuration's format ("7d", "2w",
+// "36h"), an RFC3339 timestamp, or a YYYY-MM-DD date (midnight UTC).
+func ParseSince(s string) (time.Time, error) {
+	return parseSinceAt(s, time.Now())
+}
+
+// parseSinceAt is ParseSince with relative durations measured back from now.
+func parseSinceAt(s string, now time.Time) (time.Time, error) {
+	if d, err := ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidSince, s)
+}
+
 // FormatDateRange formats a date range for display (e.g., "Jan 12-18").
 func FormatDateRange(since, until time.Time) string {
 	if since.Month() == until.Month() {
@@ -1,6 +1,7 @@
 package flow
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -74,3 +75,39 @@ func TestFormatDateRange(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"7d", now.AddDate(0, 0, -7)},
+		{"2w", now.AddDate(0, 0, -14)},
+		{"36h", now.Add(-36 * time.Hour)},
+		{"2026-03-01T09:30:00Z", time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseSinceAt(tt.input, now)
+			if err != nil {
+				t.Fatalf("parseSinceAt(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseSinceAt(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSince_Invalid(t *testing.T) {
+	for _, input := range []string{"", "7", "3y", "-2d", "yesterday", "2026-13-01"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseSince(input); !errors.Is(err, ErrInvalidSince) {
+				t.Errorf("ParseSince(%q) error = %v, want ErrInvalidSince", input, err)
+			}
+		})
+	}
+}
@@ -2,11 +2,16 @@ package flow
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/matsen/bipartite/internal/config"
 )
 
 // githubAPIPageSize is the default page size for GitHub API requests.
@@ -15,8 +20,7 @@ const githubAPIPageSize = 100
 // GHAPI calls the GitHub API via the gh CLI.
 // Returns the parsed JSON response.
 func GHAPI(endpoint string) (json.RawMessage, error) {
-	cmd := exec.Command("gh", "api", endpoint, "--paginate")
-	output, err := cmd.Output()
+	output, err := runGH(endpoint, "api", endpoint, "--paginate")
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return nil, fmt.Errorf("gh api %s: %s", endpoint, string(exitErr.Stderr))
@@ -34,6 +38,88 @@ func GHAPI(endpoint string) (json.RawMessage, error) {
 	return output, nil
 }
 
+// GHRetryConfig controls how gh calls retry transient failures.
+type GHRetryConfig struct {
+	MaxAttempts int           // Total attempts including the first; values below 1 mean 1
+	BaseDelay   time.Duration // Wait before the first retry; doubles on each later retry
+}
+
+// GHRetry is the retry policy for every gh call in this package (GHAPI,
+// GHGraphQL, and the helpers built on them). Transient failures — rate
+// limits, 5xx gateway errors, timeouts — are retried with exponential
+// backoff; anything else, such as auth or not-found errors, fails at once.
+var GHRetry = GHRetryConfig{MaxAttempts: 3, BaseDelay: time.Second}
+
+// ghCommand runs gh with args and returns its stdout. On failure the error
+// is an *exec.ExitError carrying gh's stderr. Tests replace it.
+var ghCommand = func(args ...string) ([]byte, error) {
+	return exec.Command("gh", args...).Output()
+}
+
+// ghSleep waits between retries. Tests replace it.
+var ghSleep = time.Sleep
+
+// runGH runs gh with args, retrying transient failures per GHRetry. The
+// error from the last attempt is returned unchanged, so callers still see
+// gh's original stderr. endpoint labels the call in debug logs.
+func runGH(endpoint string, args ...string) ([]byte, error) {
+	attempts := max(GHRetry.MaxAttempts, 1)
+	delay := GHRetry.BaseDelay
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		output, err := ghCommand(args...)
+		logGHCall(endpoint, start, err)
+		if err == nil || attempt >= attempts || !isTransientGHError(err) {
+			return output, err
+		}
+		slog.Debug("gh api retrying", "service", "gh", "endpoint", endpoint, "attempt", attempt, "delay", delay)
+		ghSleep(delay)
+		delay *= 2
+	}
+}
+
+// nonRetryableGHErrors mark gh failures that retrying cannot fix. They are
+// checked before transientGHErrors, e.g. so a 404 whose body happens to
+// mention a timeout still fails fast.
+var nonRetryableGHErrors = []string{"401", "bad credentials", "gh auth login", "404", "not found"}
+
+// transientGHErrors mark gh failures that often succeed on retry, including
+// GitHub's secondary rate limits (a 403 whose message says "rate limit").
+var transientGHErrors = []string{"rate limit", "502", "503", "504", "timeout", "timed out", "connection reset"}
+
+// isTransientGHError reports whether err is a gh failure worth retrying,
+// judged from gh's stderr. Errors that never reached gh's exit, such as gh
+// not being installed, are not retried.
+func isTransientGHError(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	stderr := strings.ToLower(string(exitErr.Stderr))
+	for _, marker := range nonRetryableGHErrors {
+		if strings.Contains(stderr, marker) {
+			return false
+		}
+	}
+	for _, marker := range transientGHErrors {
+		if strings.Contains(stderr, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// logGHCall logs a gh api call to endpoint at debug level. gh reads its
+// own credentials, so there is no token to leak.
+func logGHCall(endpoint string, start time.Time, err error) {
+	attrs := []any{"service", "gh", "endpoint", endpoint, "duration", time.Since(start).Round(time.Millisecond)}
+	if err != nil {
+		slog.Debug("gh api failed", append(attrs, "error", err)...)
+		return
+	}
+	slog.Debug("gh api", attrs...)
+}
+
 // normalizeJSONLines handles paginated gh api output.
 // The --paginate flag can output multiple JSON arrays on separate lines.
 func normalizeJSONLines(data []byte) json.RawMessage {
@@ -83,8 +169,7 @@ func GHGraphQL(query string, variables map[string]interface{}) (json.RawMessage,
 		}
 	}
 
-	cmd := exec.Command("gh", args...)
-	output, err := cmd.Output()
+	output, err := runGH("graphql", args...)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return nil, fmt.Errorf("gh graphql: %s", string(exitErr.Stderr))
@@ -97,14 +182,116 @@ func GHGraphQL(query string, variables map[string]interface{}) (json.RawMessage,
 
 // GetGitHubUser returns the current authenticated GitHub user's login.
 func GetGitHubUser() (string, error) {
-	cmd := exec.Command("gh", "api", "user", "--jq", ".login")
-	output, err := cmd.Output()
+	output, err := runGH("user", "api", "user", "--jq", ".login")
 	if err != nil {
 		return "", fmt.Errorf("getting GitHub user: %w", err)
 	}
 	return strings.TrimSpace(string(output)), nil
 }
 
+// detectGitHubUser looks up the authenticated login; tests replace it.
+var detectGitHubUser = GetGitHubUser
+
+// ResolveGitHubUser returns the login ball-in-court treats as the user:
+// override (from --me) if set, else github.user from the global config, else
+// the login gh is authenticated as. In CI or with several gh accounts
+// detection can fail or pick the wrong account, so the error says how to
+// set the user explicitly.
+//
+// The detected login is cached under nexusPath's .bipartite/cache for
+// githubUserCacheTTL, since it rarely changes and each lookup is a network
+// round-trip; refresh skips the cache and re-detects. An empty nexusPath
+// disables the cache.
+func ResolveGitHubUser(nexusPath, override string, refresh bool) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	if user := config.GetGitHubUser(); user != "" {
+		return user, nil
+	}
+
+	var cachePath string
+	if nexusPath != "" {
+		cachePath = githubUserCachePath(nexusPath)
+		if !refresh {
+			if user, ok := loadGitHubUserCache(cachePath, time.Now()); ok {
+				return user, nil
+			}
+		}
+	}
+
+	user, err := detectGitHubUser()
+	if err == nil && user == "" {
+		err = fmt.Errorf("getting GitHub user: gh returned an empty login")
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w; pass --me <login> or set github.user in %s", err, config.GlobalConfigPath())
+	}
+
+	if cachePath != "" {
+		if err := saveGitHubUserCache(cachePath, user, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save GitHub user cache: %v\n", err)
+		}
+	}
+	return user, nil
+}
+
+// githubUserCacheTTL is how long a detected GitHub login is reused.
+const githubUserCacheTTL = 30 * 24 * time.Hour
+
+// githubUserCacheVersion is the format version of the GitHub user cache
+// file; files with a different version are ignored.
+const githubUserCacheVersion = 1
+
+// githubUserCacheFile is the contents of github_user.json.
+type githubUserCacheFile struct {
+	Version   int       `json:"version"`
+	Login     string    `json:"login"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// githubUserCachePath returns the GitHub user cache file for a nexus.
+func githubUserCachePath(nexusPath string) string {
+	return filepath.Join(config.CachePath(nexusPath), "github_user.json")
+}
+
+// loadGitHubUserCache returns the cached login, reporting false if the
+// cache is missing, unreadable, from another version, or older than
+// githubUserCacheTTL at now.
+func loadGitHubUserCache(path string, now time.Time) (string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	var file githubUserCacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != githubUserCacheVersion || file.Login == "" {
+		return "", false
+	}
+	if now.Sub(file.FetchedAt) > githubUserCacheTTL {
+		return "", false
+	}
+	return file.Login, true
+}
+
+// saveGitHubUserCache writes login to the cache, stamped with now.
+func saveGitHubUserCache(path, login string, now time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	data, err := json.MarshalIndent(githubUserCacheFile{
+		Version:   githubUserCacheVersion,
+		Login:     login,
+		FetchedAt: now.UTC(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling GitHub user cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing GitHub user cache: %w", err)
+	}
+	return nil
+}
+
 // FetchIssues fetches issues updated since the given time.
 func FetchIssues(repo string, since time.Time) ([]GitHubItem, error) {
 	sinceStr := since.UTC().Format(time.RFC3339)
@@ -326,27 +513,6 @@ func fetchPRReviews(repo string, number int) ([]rawPRReview, error) {
 	return reviews, nil
 }
 
-// FetchPRReviewers fetches deduplicated reviewer logins for a PR.
-func FetchPRReviewers(repo string, number int) ([]string, error) {
-	reviews, err := fetchPRReviews(repo, number)
-	if err != nil {
-		return nil, err
-	}
-
-	reviewerSet := make(map[string]bool)
-	for _, r := range reviews {
-		if r.User.Login != "" {
-			reviewerSet[r.User.Login] = true
-		}
-	}
-
-	var reviewers []string
-	for login := range reviewerSet {
-		reviewers = append(reviewers, login)
-	}
-	return reviewers, nil
-}
-
 // fetchPRReviewsBatch fetches reviews for multiple PRs in a single GraphQL call.
 // Returns a map from PR number to its reviews. PRs that fail to fetch are omitted.
 func fetchPRReviewsBatch(repo string, prNumbers []int) (map[int][]rawPRReview, error) {
@@ -441,6 +607,57 @@ func fetchPRReviewsBatch(repo string, prNumbers []int) (map[int][]rawPRReview, e
 	return result, nil
 }
 
+// fetchPRReviewsWithFallback fetches reviews for prNumbers with one batched
+// GraphQL call, falling back to per-PR REST calls if the batch fails. PRs
+// whose reviews could not be fetched are omitted; errors go to stderr.
+func fetchPRReviewsWithFallback(repo string, prNumbers []int) map[int][]rawPRReview {
+	reviewsByPR, err := fetchPRReviewsBatch(repo, prNumbers)
+	if err == nil {
+		return reviewsByPR
+	}
+
+	// Fall back to sequential REST calls.
+	fmt.Fprintf(os.Stderr, "Warning: %v; falling back to per-PR fetching\n", err)
+	reviewsByPR = make(map[int][]rawPRReview)
+	var failures int
+	for _, number := range prNumbers {
+		reviews, err := fetchPRReviews(repo, number)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			failures++
+			continue
+		}
+		reviewsByPR[number] = reviews
+	}
+	if failures == len(prNumbers) {
+		fmt.Fprintf(os.Stderr, "Warning: all %d per-PR review fetches failed for %s; review data unavailable\n", failures, repo)
+	}
+	return reviewsByPR
+}
+
+// FetchPRsReviewers fetches unique reviewer logins for a batch of PRs with a
+// single GraphQL call, or per-PR REST calls if that call fails.
+// Returns a map from PR number to reviewer logins; PRs that fail are omitted.
+func FetchPRsReviewers(repo string, prNumbers []int) map[int][]string {
+	result := make(map[int][]string)
+	if len(prNumbers) == 0 {
+		return result
+	}
+
+	for number, reviews := range fetchPRReviewsWithFallback(repo, prNumbers) {
+		seen := make(map[string]bool)
+		var reviewers []string
+		for _, r := range reviews {
+			if r.User.Login != "" && !seen[r.User.Login] {
+				seen[r.User.Login] = true
+				reviewers = append(reviewers, r.User.Login)
+			}
+		}
+		result[number] = reviewers
+	}
+	return result
+}
+
 // FetchPRReviewsAsComments fetches PR reviews for a set of PRs and returns
 // them as GitHubComment entries so they participate in ball-in-court filtering.
 // Only reviews submitted at or after since are included; pass time.Time{} to
@@ -454,25 +671,7 @@ func fetchPRReviewsBatch(repo string, prNumbers []int) (map[int][]rawPRReview, e
 //   - PENDING: draft reviews not yet submitted (only visible to the author)
 //   - DISMISSED: reviews invalidated by the PR author or admin
 func FetchPRReviewsAsComments(repo string, prNumbers []int, since time.Time) []GitHubComment {
-	reviewsByPR, err := fetchPRReviewsBatch(repo, prNumbers)
-	if err != nil {
-		// Fall back to sequential REST calls.
-		fmt.Fprintf(os.Stderr, "Warning: %v; falling back to per-PR fetching\n", err)
-		reviewsByPR = make(map[int][]rawPRReview)
-		var failures int
-		for _, number := range prNumbers {
-			reviews, err := fetchPRReviews(repo, number)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
-				failures++
-				continue
-			}
-			reviewsByPR[number] = reviews
-		}
-		if failures == len(prNumbers) {
-			fmt.Fprintf(os.Stderr, "Warning: all %d per-PR review fetches failed for %s; review data unavailable\n", failures, repo)
-		}
-	}
+	reviewsByPR := fetchPRReviewsWithFallback(repo, prNumbers)
 
 	var comments []GitHubComment
 	for _, number := range prNumbers {
@@ -688,14 +887,193 @@ func FetchItemsCommenters(repo string, itemNumbers []int) (map[int][]string, err
 	return result, nil
 }
 
+// maxBatchComments is the most comments GraphQL returns per connection.
+const maxBatchComments = 100
+
+// FetchItemDetailsBatch fetches the title, body, state, author, and most
+// recent comments (up to commentLimit) of each issue/PR number in a single
+// batched GraphQL call. Returns a map from item number to its details.
+//
+// If the batched query fails, it falls back to per-item REST calls
+// (FetchIssue and FetchItemComments), so a GraphQL failure costs API calls
+// rather than data. Items that fail to fetch are omitted; errors go to stderr.
+func FetchItemDetailsBatch(repo string, itemNumbers []int, commentLimit int) map[int]ItemDetails {
+	if len(itemNumbers) == 0 {
+		return map[int]ItemDetails{}
+	}
+
+	details, err := fetchItemDetailsGraphQL(repo, itemNumbers, commentLimit)
+	if err == nil {
+		return details
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: %v; falling back to per-item fetching\n", err)
+	details = make(map[int]ItemDetails)
+	var failures int
+	for _, n := range itemNumbers {
+		item, err := FetchIssue(repo, n)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: fetching %s#%d: %v\n", repo, n, err)
+			failures++
+			continue
+		}
+		comments, err := FetchItemComments(repo, n, commentLimit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: fetching comments for %s#%d: %v\n", repo, n, err)
+		}
+		details[n] = ItemDetails{
+			Ref:      fmt.Sprintf("%s#%d", repo, n),
+			Title:    item.Title,
+			Author:   item.User.Login,
+			Body:     item.Body,
+			IsPR:     item.IsPR,
+			State:    item.State,
+			Comments: comments,
+		}
+	}
+	if failures == len(itemNumbers) {
+		fmt.Fprintf(os.Stderr, "Warning: all %d per-item fetches failed for %s; item details unavailable\n", failures, repo)
+	}
+	return details
+}
+
+// fetchItemDetailsGraphQL runs the batched query behind FetchItemDetailsBatch.
+func fetchItemDetailsGraphQL(repo string, itemNumbers []int, commentLimit int) (map[int]ItemDetails, error) {
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repo format %q, expected owner/name", repo)
+	}
+	owner, name := parts[0], parts[1]
+
+	if len(itemNumbers) > 50 {
+		fmt.Fprintf(os.Stderr, "Warning: batching %d items in a single GraphQL query; may hit node limits\n", len(itemNumbers))
+	}
+
+	data, err := GHGraphQL(buildItemDetailsQuery(itemNumbers, commentLimit), map[string]interface{}{
+		"owner": owner,
+		"name":  name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("batch fetching item details for %s: %w", repo, err)
+	}
+
+	return parseItemDetailsResponse(repo, itemNumbers, data)
+}
+
+// buildItemDetailsQuery builds an aliased query with one issueOrPullRequest
+// per item number, so issues and PRs can be fetched together.
+func buildItemDetailsQuery(itemNumbers []int, commentLimit int) string {
+	if commentLimit < 0 {
+		commentLimit = 0
+	}
+	if commentLimit > maxBatchComments {
+		commentLimit = maxBatchComments
+	}
+	fields := fmt.Sprintf(`title body state author { login }
+        comments(last: %d) { nodes { author { login } body createdAt } }`, commentLimit)
+
+	var fragments []string
+	for _, n := range itemNumbers {
+		fragments = append(fragments, fmt.Sprintf(`item_%d: issueOrPullRequest(number: %d) {
+      __typename
+      ... on Issue { %s }
+      ... on PullRequest { %s }
+    }`, n, n, fields, fields))
+	}
+
+	return fmt.Sprintf(`query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    %s
+  }
+}`, strings.Join(fragments, "\n    "))
+}
+
+// parseItemDetailsResponse parses the response to buildItemDetailsQuery.
+// Items missing from the response or null (e.g. deleted) are omitted.
+func parseItemDetailsResponse(repo string, itemNumbers []int, data []byte) (map[int]ItemDetails, error) {
+	var top struct {
+		Data struct {
+			Repository json.RawMessage `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil, fmt.Errorf("parsing item details response: %w", err)
+	}
+
+	var itemMap map[string]json.RawMessage
+	if err := json.Unmarshal(top.Data.Repository, &itemMap); err != nil {
+		return nil, fmt.Errorf("parsing repository aliases: %w", err)
+	}
+
+	result := make(map[int]ItemDetails)
+	for _, n := range itemNumbers {
+		raw, ok := itemMap[fmt.Sprintf("item_%d", n)]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+
+		var itemData struct {
+			Typename string `json:"__typename"`
+			Title    string `json:"title"`
+			Body     string `json:"body"`
+			State    string `json:"state"`
+			Author   struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			Comments struct {
+				Nodes []struct {
+					Author struct {
+						Login string `json:"login"`
+					} `json:"author"`
+					Body      string    `json:"body"`
+					CreatedAt time.Time `json:"createdAt"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		}
+		if err := json.Unmarshal(raw, &itemData); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: parsing details for %s#%d: %v\n", repo, n, err)
+			continue
+		}
+
+		var comments []CommentSummary
+		for _, node := range itemData.Comments.Nodes {
+			comments = append(comments, CommentSummary{
+				Author:    node.Author.Login,
+				Body:      node.Body,
+				CreatedAt: node.CreatedAt,
+			})
+		}
+
+		result[n] = ItemDetails{
+			Ref:      fmt.Sprintf("%s#%d", repo, n),
+			Title:    itemData.Title,
+			Author:   itemData.Author.Login,
+			Body:     itemData.Body,
+			IsPR:     itemData.Typename == "PullRequest",
+			State:    restItemState(itemData.State),
+			Comments: comments,
+		}
+	}
+
+	return result, nil
+}
+
+// restItemState converts a GraphQL issue/PR state (OPEN, CLOSED, MERGED) to
+// the REST API's open/closed, which reports merged PRs as closed.
+func restItemState(state string) string {
+	if state == "OPEN" {
+		return "open"
+	}
+	return "closed"
+}
+
 // FetchLastItemComment fetches the single most recent comment on an issue/PR.
 // Returns nil if the item has no comments.
 func FetchLastItemComment(repo string, number int) (*GitHubComment, error) {
 	endpoint := fmt.Sprintf("/repos/%s/issues/%d/comments?per_page=1&direction=desc", repo, number)
 
 	// Use gh api without --paginate since we only want 1 result
-	cmd := exec.Command("gh", "api", endpoint)
-	output, err := cmd.Output()
+	output, err := runGH(endpoint, "api", endpoint)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return nil, fmt.Errorf("fetching last comment for %s#%d: %s", repo, number, string(exitErr.Stderr))
@@ -0,0 +1,335 @@
+package flow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matsen/bipartite/internal/config"
+)
+
+func TestBuildItemDetailsQuery(t *testing.T) {
+	query := buildItemDetailsQuery([]int{7, 42}, 10)
+
+	for _, want := range []string{
+		"item_7: issueOrPullRequest(number: 7)",
+		"item_42: issueOrPullRequest(number: 42)",
+		"... on Issue",
+		"... on PullRequest",
+		"comments(last: 10)",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query missing %q:\n%s", want, query)
+		}
+	}
+
+	if got := buildItemDetailsQuery([]int{1}, 500); !strings.Contains(got, "comments(last: 100)") {
+		t.Errorf("comment limit not clamped to %d:\n%s", maxBatchComments, got)
+	}
+}
+
+func TestParseItemDetailsResponse(t *testing.T) {
+	data := []byte(`{"data": {"repository": {
+		"item_7": {
+			"__typename": "Issue",
+			"title": "Crash on empty input",
+			"body": "Steps to reproduce",
+			"state": "OPEN",
+			"author": {"login": "alice"},
+			"comments": {"nodes": [
+				{"author": {"login": "bob"}, "body": "Confirmed", "createdAt": "2026-01-02T03:04:05Z"}
+			]}
+		},
+		"item_8": {
+			"__typename": "PullRequest",
+			"title": "Fix crash",
+			"body": "",
+			"state": "MERGED",
+			"author": {"login": "bob"},
+			"comments": {"nodes": []}
+		},
+		"item_9": null
+	}}}`)
+
+	got, err := parseItemDetailsResponse("org/repo", []int{7, 8, 9, 10}, data)
+	if err != nil {
+		t.Fatalf("parseItemDetailsResponse: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d items, want 2 (null and missing aliases omitted): %+v", len(got), got)
+	}
+
+	issue := got[7]
+	if issue.Ref != "org/repo#7" || issue.Title != "Crash on empty input" || issue.Author != "alice" || issue.IsPR || issue.State != "open" {
+		t.Errorf("issue = %+v", issue)
+	}
+	if len(issue.Comments) != 1 || issue.Comments[0].Author != "bob" || issue.Comments[0].Body != "Confirmed" || issue.Comments[0].CreatedAt.IsZero() {
+		t.Errorf("issue comments = %+v", issue.Comments)
+	}
+
+	pr := got[8]
+	if !pr.IsPR || pr.State != "closed" || len(pr.Comments) != 0 {
+		t.Errorf("PR = %+v, want merged PR reported as closed", pr)
+	}
+}
+
+func TestParseItemDetailsResponse_Malformed(t *testing.T) {
+	if _, err := parseItemDetailsResponse("org/repo", []int{1}, []byte(`not json`)); err == nil {
+		t.Error("expected error for malformed response")
+	}
+}
+
+func TestResolveGitHubUser(t *testing.T) {
+	// useGlobalConfig points the global config at a file with the given
+	// contents (no file if empty)
+	useGlobalConfig := func(t *testing.T, contents string) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		if contents != "" {
+			configDir := filepath.Join(tmpDir, config.GlobalConfigDir)
+			if err := os.MkdirAll(configDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(configDir, config.GlobalConfigFile), []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		t.Setenv("XDG_CONFIG_HOME", tmpDir)
+		config.ResetGlobalConfigCache()
+		t.Cleanup(config.ResetGlobalConfigCache)
+	}
+	stubDetect := func(t *testing.T, login string, err error) {
+		t.Helper()
+		orig := detectGitHubUser
+		detectGitHubUser = func() (string, error) { return login, err }
+		t.Cleanup(func() { detectGitHubUser = orig })
+	}
+
+	t.Run("override wins over config and detection", func(t *testing.T) {
+		useGlobalConfig(t, "github:\n  user: from-config\n")
+		stubDetect(t, "from-gh", nil)
+
+		got, err := ResolveGitHubUser("", "from-flag", false)
+		if err != nil || got != "from-flag" {
+			t.Errorf("ResolveGitHubUser() = %q, %v; want from-flag", got, err)
+		}
+	})
+
+	t.Run("override wins when detection fails", func(t *testing.T) {
+		useGlobalConfig(t, "")
+		stubDetect(t, "", errors.New("gh: not logged in"))
+
+		got, err := ResolveGitHubUser("", "from-flag", false)
+		if err != nil || got != "from-flag" {
+			t.Errorf("ResolveGitHubUser() = %q, %v; want from-flag", got, err)
+		}
+	})
+
+	t.Run("config wins over detection", func(t *testing.T) {
+		useGlobalConfig(t, "github:\n  user: from-config\n")
+		stubDetect(t, "from-gh", nil)
+
+		got, err := ResolveGitHubUser("", "", false)
+		if err != nil || got != "from-config" {
+			t.Errorf("ResolveGitHubUser() = %q, %v; want from-config", got, err)
+		}
+	})
+
+	t.Run("falls back to detection", func(t *testing.T) {
+		useGlobalConfig(t, "")
+		stubDetect(t, "from-gh", nil)
+
+		got, err := ResolveGitHubUser("", "", false)
+		if err != nil || got != "from-gh" {
+			t.Errorf("ResolveGitHubUser() = %q, %v; want from-gh", got, err)
+		}
+	})
+
+	t.Run("detection failure is an error naming the overrides", func(t *testing.T) {
+		useGlobalConfig(t, "")
+		for _, tc := range []struct {
+			login string
+			err   error
+		}{
+			{"", errors.New("gh: not logged in")},
+			{"", nil}, // empty login
+		} {
+			stubDetect(t, tc.login, tc.err)
+			_, err := ResolveGitHubUser("", "", false)
+			if err == nil {
+				t.Fatal("expected error when detection fails")
+			}
+			if !strings.Contains(err.Error(), "--me") || !strings.Contains(err.Error(), "github.user") {
+				t.Errorf("error %q should mention --me and github.user", err)
+			}
+		}
+	})
+	t.Run("cache", func(t *testing.T) {
+		useGlobalConfig(t, "")
+		nexus := t.TempDir()
+
+		stubDetect(t, "from-gh", nil)
+		if got, err := ResolveGitHubUser(nexus, "", false); err != nil || got != "from-gh" {
+			t.Fatalf("first lookup = %q, %v; want from-gh", got, err)
+		}
+
+		// A cached login is used without calling gh
+		stubDetect(t, "", errors.New("gh must not be called"))
+		if got, err := ResolveGitHubUser(nexus, "", false); err != nil || got != "from-gh" {
+			t.Errorf("cached lookup = %q, %v; want from-gh", got, err)
+		}
+
+		// --me still wins over the cache
+		if got, _ := ResolveGitHubUser(nexus, "from-flag", false); got != "from-flag" {
+			t.Errorf("override with cache = %q, want from-flag", got)
+		}
+
+		// refresh re-detects and rewrites the cache
+		stubDetect(t, "switched", nil)
+		if got, err := ResolveGitHubUser(nexus, "", true); err != nil || got != "switched" {
+			t.Errorf("refreshed lookup = %q, %v; want switched", got, err)
+		}
+		stubDetect(t, "", errors.New("gh must not be called"))
+		if got, _ := ResolveGitHubUser(nexus, "", false); got != "switched" {
+			t.Errorf("lookup after refresh = %q, want switched", got)
+		}
+	})
+}
+
+func TestLoadGitHubUserCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "github_user.json")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, ok := loadGitHubUserCache(path, now); ok {
+		t.Error("missing cache file should be a miss")
+	}
+
+	if err := saveGitHubUserCache(path, "octocat", now); err != nil {
+		t.Fatalf("saveGitHubUserCache: %v", err)
+	}
+	if got, ok := loadGitHubUserCache(path, now.Add(githubUserCacheTTL)); !ok || got != "octocat" {
+		t.Errorf("loadGitHubUserCache at TTL = %q, %v; want octocat, true", got, ok)
+	}
+	if _, ok := loadGitHubUserCache(path, now.Add(githubUserCacheTTL+time.Second)); ok {
+		t.Error("expired cache should be a miss")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 99, "login": "octocat"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loadGitHubUserCache(path, now); ok {
+		t.Error("cache from another version should be a miss")
+	}
+}
+
+// fakeGH replaces ghCommand with one that fails with each stderr in
+// failures in turn, then succeeds with output. It also makes retries
+// instant. It returns pointers to the call count and the recorded sleeps.
+func fakeGH(t *testing.T, output string, failures ...string) (*int, *[]time.Duration) {
+	t.Helper()
+	calls := 0
+	var sleeps []time.Duration
+
+	origCommand, origSleep, origRetry := ghCommand, ghSleep, GHRetry
+	t.Cleanup(func() { ghCommand, ghSleep, GHRetry = origCommand, origSleep, origRetry })
+
+	ghCommand = func(args ...string) ([]byte, error) {
+		calls++
+		if calls <= len(failures) {
+			return nil, &exec.ExitError{Stderr: []byte(failures[calls-1])}
+		}
+		return []byte(output), nil
+	}
+	ghSleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	GHRetry = GHRetryConfig{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond}
+	return &calls, &sleeps
+}
+
+func TestGHAPI_RetriesTransientErrors(t *testing.T) {
+	calls, sleeps := fakeGH(t, `{"ok": true}`,
+		"gh: HTTP 502: Bad Gateway",
+		"gh: You have exceeded a secondary rate limit (HTTP 403)")
+
+	data, err := GHAPI("/repos/org/repo")
+	if err != nil {
+		t.Fatalf("GHAPI: %v", err)
+	}
+	if string(data) != `{"ok": true}` {
+		t.Errorf("GHAPI = %s", data)
+	}
+	if *calls != 3 {
+		t.Errorf("gh called %d times, want 3", *calls)
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+	if len(*sleeps) != len(want) || (*sleeps)[0] != want[0] || (*sleeps)[1] != want[1] {
+		t.Errorf("backoff delays = %v, want %v", *sleeps, want)
+	}
+}
+
+func TestGHAPI_GivesUpAfterMaxAttempts(t *testing.T) {
+	calls, _ := fakeGH(t, `{}`, "timeout", "timeout", "gh: HTTP 504: Gateway Timeout")
+
+	_, err := GHAPI("/repos/org/repo")
+	if err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if *calls != 3 {
+		t.Errorf("gh called %d times, want 3", *calls)
+	}
+	if !strings.Contains(err.Error(), "504: Gateway Timeout") {
+		t.Errorf("error %q should carry the last attempt's stderr", err)
+	}
+}
+
+func TestGHGraphQL_FailsFastOnPermanentErrors(t *testing.T) {
+	for _, stderr := range []string{
+		"gh: HTTP 401: Bad credentials",
+		"gh: Not Found (HTTP 404)",
+		"To get started with GitHub CLI, please run:  gh auth login",
+		"unknown flag: --bogus",
+	} {
+		t.Run(stderr, func(t *testing.T) {
+			calls, sleeps := fakeGH(t, `{}`, stderr)
+
+			_, err := GHGraphQL("query { viewer { login } }", nil)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if *calls != 1 || len(*sleeps) != 0 {
+				t.Errorf("gh called %d times with %d sleeps, want 1 and 0", *calls, len(*sleeps))
+			}
+			if !strings.Contains(err.Error(), stderr) {
+				t.Errorf("error %q should carry the original message", err)
+			}
+		})
+	}
+}
+
+func TestRunGH_SingleAttempt(t *testing.T) {
+	calls, _ := fakeGH(t, `{}`, "HTTP 502")
+	GHRetry = GHRetryConfig{MaxAttempts: 0}
+
+	if _, err := runGH("test", "api", "/x"); err == nil {
+		t.Fatal("expected error with retries disabled")
+	}
+	if *calls != 1 {
+		t.Errorf("gh called %d times, want 1", *calls)
+	}
+}
+
+func TestIsTransientGHError(t *testing.T) {
+	if isTransientGHError(errors.New("exec: \"gh\": executable file not found in $PATH")) {
+		t.Error("a missing gh binary should not be retried")
+	}
+	if !isTransientGHError(&exec.ExitError{Stderr: []byte("API rate limit exceeded for user")}) {
+		t.Error("a rate limit should be retried")
+	}
+	if !isTransientGHError(fmt.Errorf("wrapped: %w", &exec.ExitError{Stderr: []byte("connection reset by peer")})) {
+		t.Error("a wrapped connection reset should be retried")
+	}
+}
@@ -9,11 +9,13 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/logging"
 )
 
 // ErrSlackNotInChannel is returned when the bot is not a member of the channel.
@@ -22,16 +24,22 @@ var ErrSlackNotInChannel = errors.New("bot is not a member of this channel")
 // slackAPITimeout is the HTTP client timeout for Slack API calls.
 const slackAPITimeout = 30 * time.Second
 
+// slackAPIBaseURL is the Slack Web API endpoint. A variable so tests can
+// point the client at a local server.
+var slackAPIBaseURL = "https://slack.com/api"
+
 // newSlackHTTPClient creates an HTTP client configured for Slack API calls.
 func newSlackHTTPClient() *http.Client {
-	return &http.Client{Timeout: slackAPITimeout}
+	return logging.NewHTTPClient("slack", slackAPITimeout)
 }
 
 // SlackClient provides read access to Slack channels via the API.
 type SlackClient struct {
-	token      string
-	httpClient *http.Client
-	userCache  map[string]string
+	token        string
+	httpClient   *http.Client
+	userCache    map[string]string
+	channelNames map[string]string // Channel ID -> name for mention cleanup
+	historyCache bool
 }
 
 // NewSlackClient creates a new SlackClient. The token is sourced from
@@ -44,19 +52,35 @@ func NewSlackClient() (*SlackClient, error) {
 	}
 
 	return &SlackClient{
-		token:      token,
-		httpClient: newSlackHTTPClient(),
-		userCache:  make(map[string]string),
+		token:        token,
+		httpClient:   newSlackHTTPClient(),
+		userCache:    make(map[string]string),
+		historyCache: true,
 	}, nil
 }
 
+// SetHistoryCache turns the on-disk channel history cache on or off. It is
+// on by default; turning it off makes GetChannelHistory always fetch live.
+func (c *SlackClient) SetHistoryCache(enabled bool) {
+	c.historyCache = enabled
+}
+
+// SetChannelNames sets the channel ID to name map (see LoadChannelIDMap)
+// used to rewrite channel mentions in message text. Without it, channel
+// mentions fall back to the alias embedded in the markup.
+func (c *SlackClient) SetChannelNames(idToName map[string]string) {
+	c.channelNames = idToName
+}
+
 // Message represents a Slack message from history.
 type Message struct {
-	Timestamp string `json:"ts"`
-	UserID    string `json:"user_id"`
-	UserName  string `json:"user_name"`
-	Date      string `json:"date"`
-	Text      string `json:"text"`
+	Timestamp string    `json:"ts"`
+	UserID    string    `json:"user_id"`
+	UserName  string    `json:"user_name"`
+	Date      string    `json:"date"`
+	Text      string    `json:"text"`
+	ThreadTS  string    `json:"thread_ts,omitempty"` // Set on thread parents and replies
+	Replies   []Message `json:"replies,omitempty"`   // Filled by GetThreadReplies
 }
 
 // HistoryResponse is the JSON output for bip slack history.
@@ -73,11 +97,51 @@ type Period struct {
 	End   string `json:"end"`
 }
 
+// ChannelDigest is the JSON output for bip slack digest: a channel's
+// activity over a period, counted by day and author.
+type ChannelDigest struct {
+	Channel      string        `json:"channel"`
+	ChannelID    string        `json:"channel_id"`
+	Purpose      string        `json:"purpose,omitempty"` // From sources.yml
+	Period       Period        `json:"period"`
+	MessageCount int           `json:"message_count"` // Includes fetched thread replies
+	Participants []AuthorCount `json:"participants"`  // Most active first
+	Days         []DigestDay   `json:"days"`          // Oldest first
+	Markdown     string        `json:"markdown"`      // The summary as posted by --post-to
+}
+
+// AuthorCount is the number of messages one author posted.
+type AuthorCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// DigestDay is a channel's activity on one day.
+type DigestDay struct {
+	Date    string        `json:"date"`
+	Count   int           `json:"count"`
+	Authors []AuthorCount `json:"authors"` // Most active first
+}
+
 // ChannelsResponse is the JSON output for bip slack channels.
 type ChannelsResponse struct {
 	Channels []ChannelInfo `json:"channels"`
 }
 
+// UsersResponse is the JSON output for bip slack users.
+type UsersResponse struct {
+	Users     []UserInfo `json:"users"`
+	Count     int        `json:"count"`
+	Refreshed bool       `json:"refreshed"` // Whether the cache was just rebuilt from Slack
+	CachePath string     `json:"cache_path"`
+}
+
+// UserInfo is one entry of the Slack user cache.
+type UserInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // ChannelInfo contains information about a configured channel.
 type ChannelInfo struct {
 	Name    string `json:"name"`
@@ -85,21 +149,28 @@ type ChannelInfo struct {
 	Purpose string `json:"purpose"`
 }
 
-// webhookEnvVar returns the environment variable name for a channel's webhook.
-func webhookEnvVar(channel string) string {
+// WebhookEnvVar returns the environment variable name for a channel's webhook.
+func WebhookEnvVar(channel string) string {
 	return "SLACK_WEBHOOK_" + strings.ToUpper(channel)
 }
 
 // GetWebhookURL returns the Slack webhook URL for a channel.
 // Checks SLACK_WEBHOOK_<CHANNEL> environment variable first, then global config.
 func GetWebhookURL(channel string) string {
+	if url := os.Getenv(WebhookEnvVar(channel)); url != "" {
+		return url
+	}
 	return config.GetSlackWebhook(channel)
 }
 
+// WebhookPayload returns the JSON body PostToSlack sends for a message.
+func WebhookPayload(message string) map[string]string {
+	return map[string]string{"text": message}
+}
+
 // PostToSlack posts a message to Slack via webhook.
 func PostToSlack(webhookURL, message string) error {
-	payload := map[string]string{"text": message}
-	data, err := json.Marshal(payload)
+	data, err := json.Marshal(WebhookPayload(message))
 	if err != nil {
 		return fmt.Errorf("marshaling payload: %w", err)
 	}
@@ -128,19 +199,20 @@ func PostToSlack(webhookURL, message string) error {
 func SendDigest(channel, message string) error {
 	webhookURL := GetWebhookURL(channel)
 	if webhookURL == "" {
-		return fmt.Errorf("no webhook configured for channel '%s'; set %s", channel, webhookEnvVar(channel))
+		return fmt.Errorf("no webhook configured for channel '%s'; set %s", channel, WebhookEnvVar(channel))
 	}
 	return PostToSlack(webhookURL, message)
 }
 
-// userCachePath returns the path to the Slack user cache file.
-func userCachePath() string {
+// SlackUserCachePath returns the path to the Slack user cache file, which
+// maps user IDs to display names. It is relative to the working directory.
+func SlackUserCachePath() string {
 	return filepath.Join(".bipartite", "cache", "slack_users.json")
 }
 
 // loadUserCache loads the user ID to name mapping from disk.
 func (c *SlackClient) loadUserCache() error {
-	path := userCachePath()
+	path := SlackUserCachePath()
 	data, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
 		return nil // No cache yet, not an error
@@ -155,9 +227,19 @@ func (c *SlackClient) loadUserCache() error {
 	return nil
 }
 
+// ReadSlackUserCache returns the cached user ID to name mapping without
+// contacting Slack. A missing cache file yields an empty map.
+func ReadSlackUserCache() (map[string]string, error) {
+	c := &SlackClient{userCache: make(map[string]string)}
+	if err := c.loadUserCache(); err != nil {
+		return nil, err
+	}
+	return c.userCache, nil
+}
+
 // saveUserCache saves the user ID to name mapping to disk.
 func (c *SlackClient) saveUserCache() error {
-	path := userCachePath()
+	path := SlackUserCachePath()
 
 	// Ensure directory exists
 	dir := filepath.Dir(path)
@@ -220,7 +302,9 @@ func (u slackUser) displayNameWithFallback() string {
 }
 
 // GetUsers fetches all users from the Slack workspace and updates the cache.
-// Handles pagination to ensure all users are fetched.
+// Handles pagination to ensure all users are fetched. Fetched users are
+// merged into the existing cache, so entries for users no longer listed
+// are kept; use RefreshUsers to rebuild the cache from scratch.
 func (c *SlackClient) GetUsers() (map[string]string, error) {
 	// Try to load existing cache first
 	if err := c.loadUserCache(); err != nil {
@@ -228,9 +312,45 @@ func (c *SlackClient) GetUsers() (map[string]string, error) {
 		fmt.Fprintf(os.Stderr, "Warning: could not load user cache: %v\n", err)
 	}
 
+	users, err := c.fetchAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	for id, name := range users {
+		c.userCache[id] = name
+	}
+
+	// Save updated cache
+	if err := c.saveUserCache(); err != nil {
+		// Log but don't fail
+		fmt.Fprintf(os.Stderr, "Warning: could not save user cache: %v\n", err)
+	}
+
+	return c.userCache, nil
+}
+
+// RefreshUsers re-fetches the full workspace user list and overwrites the
+// cache with it, dropping stale names and users no longer in the workspace.
+// Unlike GetUsers, failing to write the cache is an error.
+func (c *SlackClient) RefreshUsers() (map[string]string, error) {
+	users, err := c.fetchAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	c.userCache = users
+	if err := c.saveUserCache(); err != nil {
+		return nil, err
+	}
+	return c.userCache, nil
+}
+
+// fetchAllUsers fetches every user in the workspace via users.list,
+// following pagination cursors. Returns a map from user ID to display name.
+func (c *SlackClient) fetchAllUsers() (map[string]string, error) {
+	users := make(map[string]string)
 	cursor := ""
 	for {
-		url := "https://slack.com/api/users.list?limit=200"
+		url := slackAPIBaseURL + "/users.list?limit=200"
 		if cursor != "" {
 			url += "&cursor=" + cursor
 		}
@@ -260,9 +380,8 @@ func (c *SlackClient) GetUsers() (map[string]string, error) {
 			return nil, fmt.Errorf("Slack API error: %s", result.Error)
 		}
 
-		// Update cache with users from this page
 		for _, user := range result.Members {
-			c.userCache[user.ID] = user.displayNameWithFallback()
+			users[user.ID] = user.displayNameWithFallback()
 		}
 
 		// Check for more pages
@@ -271,14 +390,7 @@ func (c *SlackClient) GetUsers() (map[string]string, error) {
 		}
 		cursor = result.ResponseMetadata.NextCursor
 	}
-
-	// Save updated cache
-	if err := c.saveUserCache(); err != nil {
-		// Log but don't fail
-		fmt.Fprintf(os.Stderr, "Warning: could not save user cache: %v\n", err)
-	}
-
-	return c.userCache, nil
+	return users, nil
 }
 
 // slackHistoryResponse is the response from conversations.history API.
@@ -290,11 +402,12 @@ type slackHistoryResponse struct {
 
 // slackMessage represents a message from the Slack API.
 type slackMessage struct {
-	Type    string `json:"type"`
-	User    string `json:"user"`
-	Text    string `json:"text"`
-	TS      string `json:"ts"`
-	SubType string `json:"subtype,omitempty"`
+	Type     string `json:"type"`
+	User     string `json:"user"`
+	Text     string `json:"text"`
+	TS       string `json:"ts"`
+	ThreadTS string `json:"thread_ts,omitempty"`
+	SubType  string `json:"subtype,omitempty"`
 }
 
 // GetChannelHistory fetches messages from a Slack channel.
@@ -312,8 +425,14 @@ func (c *SlackClient) GetChannelHistory(channelID string, oldest time.Time, limi
 		fmt.Fprintf(os.Stderr, "Warning: could not load user cache: %v\n", err)
 	}
 
-	// Fetch messages from Slack API
-	slackMessages, err := c.fetchChannelMessages(channelID, oldest, limit)
+	// Fetch messages from Slack API, or the cache for past days
+	var slackMessages []slackMessage
+	var err error
+	if c.historyCache {
+		slackMessages, err = c.fetchChannelMessagesCached(channelID, oldest, limit)
+	} else {
+		slackMessages, err = c.fetchChannelMessages(channelID, oldest, limit)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -324,8 +443,8 @@ func (c *SlackClient) GetChannelHistory(channelID string, oldest time.Time, limi
 
 // fetchChannelMessages calls the Slack conversations.history API.
 func (c *SlackClient) fetchChannelMessages(channelID string, oldest time.Time, limit int) ([]slackMessage, error) {
-	url := fmt.Sprintf("https://slack.com/api/conversations.history?channel=%s&oldest=%d&limit=%d",
-		channelID, oldest.Unix(), limit)
+	url := fmt.Sprintf("%s/conversations.history?channel=%s&oldest=%d&limit=%d",
+		slackAPIBaseURL, channelID, oldest.Unix(), limit)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -346,15 +465,232 @@ func (c *SlackClient) fetchChannelMessages(channelID string, oldest time.Time, l
 	}
 
 	if !result.OK {
-		if result.Error == "channel_not_found" || result.Error == "not_in_channel" {
-			return nil, fmt.Errorf("%w: invite the bot with /invite @bot-name", ErrSlackNotInChannel)
-		}
-		return nil, fmt.Errorf("Slack API error: %s", result.Error)
+		return nil, channelAPIError(result.Error)
 	}
 
 	return result.Messages, nil
 }
 
+// channelAPIError converts a Slack API error code from a channel read into
+// an error, wrapping ErrSlackNotInChannel when the bot cannot see the channel.
+func channelAPIError(code string) error {
+	if code == "channel_not_found" || code == "not_in_channel" {
+		return fmt.Errorf("%w: invite the bot with /invite @bot-name", ErrSlackNotInChannel)
+	}
+	return fmt.Errorf("Slack API error: %s", code)
+}
+
+// slackRepliesResponse is the response from conversations.replies API.
+type slackRepliesResponse struct {
+	slackAPIResponse
+	Messages         []slackMessage        `json:"messages"`
+	ResponseMetadata slackResponseMetadata `json:"response_metadata"`
+}
+
+// GetThreadReplies fetches the replies to each thread parent in messages
+// and nests them under it as Replies. Replies older than oldest are left
+// out, matching the history window. This costs one API call per thread.
+func (c *SlackClient) GetThreadReplies(channelID string, messages []Message, oldest time.Time) error {
+	for i, m := range messages {
+		if m.ThreadTS == "" || m.ThreadTS != m.Timestamp {
+			continue
+		}
+		replies, err := c.fetchThreadReplies(channelID, m.ThreadTS, oldest)
+		if err != nil {
+			return err
+		}
+		converted, err := c.convertSlackMessages(replies)
+		if err != nil {
+			return err
+		}
+		messages[i].Replies = converted
+	}
+	return nil
+}
+
+// fetchThreadReplies calls the Slack conversations.replies API, following
+// pagination. The parent message, which Slack returns first, is dropped.
+func (c *SlackClient) fetchThreadReplies(channelID, threadTS string, oldest time.Time) ([]slackMessage, error) {
+	var replies []slackMessage
+	cursor := ""
+	for {
+		url := fmt.Sprintf("%s/conversations.replies?channel=%s&ts=%s&oldest=%d&limit=200",
+			slackAPIBaseURL, channelID, threadTS, oldest.Unix())
+		if cursor != "" {
+			url += "&cursor=" + cursor
+		}
+
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetching thread replies: %w", err)
+		}
+
+		var result slackRepliesResponse
+		err = func() error {
+			defer resp.Body.Close()
+			return json.NewDecoder(resp.Body).Decode(&result)
+		}()
+		if err != nil {
+			return nil, fmt.Errorf("parsing replies response: %w", err)
+		}
+
+		if !result.OK {
+			return nil, channelAPIError(result.Error)
+		}
+
+		for _, m := range result.Messages {
+			if m.TS != threadTS {
+				replies = append(replies, m)
+			}
+		}
+
+		if result.ResponseMetadata.NextCursor == "" {
+			return replies, nil
+		}
+		cursor = result.ResponseMetadata.NextCursor
+	}
+}
+
+// slackHistoryCacheVersion is the format version of history cache files.
+// Increment it when slackMessage or the file layout changes; files with a
+// different version are ignored and refetched.
+const slackHistoryCacheVersion = 1
+
+// slackHistoryCacheFile holds one channel's messages for one local day.
+type slackHistoryCacheFile struct {
+	Version   int            `json:"version"`
+	ChannelID string         `json:"channel_id"`
+	Date      string         `json:"date"`
+	Messages  []slackMessage `json:"messages"` // Newest first, as from the API
+}
+
+// historyCachePath returns the cache file path for a channel's day.
+func historyCachePath(channelID string, day time.Time) string {
+	return filepath.Join(".bipartite", "cache", "slack_history", channelID, day.Format("2006-01-02")+".json")
+}
+
+// loadHistoryDay reads a cached day, reporting false if it is missing,
+// unreadable, or from another cache version.
+func loadHistoryDay(channelID string, day time.Time) ([]slackMessage, bool) {
+	data, err := os.ReadFile(historyCachePath(channelID, day))
+	if err != nil {
+		return nil, false
+	}
+	var file slackHistoryCacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != slackHistoryCacheVersion {
+		return nil, false
+	}
+	return file.Messages, true
+}
+
+// saveHistoryDay writes a day's messages to the cache.
+func saveHistoryDay(channelID string, day time.Time, messages []slackMessage) error {
+	path := historyCachePath(channelID, day)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	data, err := json.MarshalIndent(slackHistoryCacheFile{
+		Version:   slackHistoryCacheVersion,
+		ChannelID: channelID,
+		Date:      day.Format("2006-01-02"),
+		Messages:  messages,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling history cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing history cache: %w", err)
+	}
+	return nil
+}
+
+// startOfDay returns local midnight at the start of t's day.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// fetchChannelMessagesCached is fetchChannelMessages backed by a per-day
+// cache. Days before today are served from the cache up to the first one
+// that is missing; from there on everything is fetched live, starting at
+// that day's midnight so it can be cached whole. Today is always fetched.
+func (c *SlackClient) fetchChannelMessagesCached(channelID string, oldest time.Time, limit int) ([]slackMessage, error) {
+	oldest = oldest.Local()
+	today := startOfDay(time.Now())
+
+	var cached [][]slackMessage // Oldest day first
+	liveFrom := today
+	for day := startOfDay(oldest); day.Before(today); day = day.AddDate(0, 0, 1) {
+		msgs, ok := loadHistoryDay(channelID, day)
+		if !ok {
+			liveFrom = day
+			break
+		}
+		cached = append(cached, msgs)
+	}
+
+	live, err := c.fetchChannelMessages(channelID, liveFrom, limit)
+	if err != nil {
+		return nil, err
+	}
+	cacheCompleteDays(channelID, live, liveFrom, today, len(live) >= limit)
+
+	// Live messages are newer than every cached day; both are newest first
+	all := live
+	for i := len(cached) - 1; i >= 0; i-- {
+		all = append(all, cached[i]...)
+	}
+	var result []slackMessage
+	for _, m := range all {
+		ts, err := parseSlackTimestamp(m.TS)
+		if err != nil || ts.Before(oldest.Truncate(time.Second)) {
+			continue
+		}
+		result = append(result, m)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// cacheCompleteDays writes each day from `from` up to (not including) today
+// to the history cache, grouping messages fetched live since `from`. When
+// the fetch hit its limit, the oldest day reached may be incomplete, so it
+// and any earlier days are not cached.
+func cacheCompleteDays(channelID string, messages []slackMessage, from, today time.Time, truncated bool) {
+	byDay := make(map[time.Time][]slackMessage)
+	var earliest time.Time
+	for _, m := range messages {
+		ts, err := parseSlackTimestamp(m.TS)
+		if err != nil {
+			return // Malformed data; leave the cache alone
+		}
+		day := startOfDay(ts)
+		byDay[day] = append(byDay[day], m)
+		if earliest.IsZero() || day.Before(earliest) {
+			earliest = day
+		}
+	}
+
+	for day := from; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if truncated && !day.After(earliest) {
+			continue
+		}
+		if err := saveHistoryDay(channelID, day, byDay[day]); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not save history cache: %v\n", err)
+			return
+		}
+	}
+}
+
 // convertSlackMessages transforms Slack API messages to our Message format.
 func (c *SlackClient) convertSlackMessages(slackMessages []slackMessage) ([]Message, error) {
 	var messages []Message
@@ -378,7 +714,8 @@ func (c *SlackClient) convertSlackMessages(slackMessages []slackMessage) ([]Mess
 			UserID:    m.User,
 			UserName:  userName,
 			Date:      ts.Format("2006-01-02"),
-			Text:      m.Text,
+			Text:      CleanMessageText(m.Text, c.userCache, c.channelNames),
+			ThreadTS:  m.ThreadTS,
 		})
 	}
 	return messages, nil
@@ -407,7 +744,7 @@ type slackUserInfoResponse struct {
 // lookupUser fetches a single user by ID via users.info API.
 // Updates the cache if successful. Returns an error on failure.
 func (c *SlackClient) lookupUser(userID string) (string, error) {
-	url := fmt.Sprintf("https://slack.com/api/users.info?user=%s", userID)
+	url := fmt.Sprintf("%s/users.info?user=%s", slackAPIBaseURL, userID)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -568,3 +905,178 @@ func ResolveChannelMentions(text string, idToName map[string]string) string {
 		return match
 	})
 }
+
+// userMentionRe matches Slack user-mention markup: `<@U…>` or `<@W…>`
+// (Enterprise Grid), with an optional `|alias`.
+var userMentionRe = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|([^>]*))?>`)
+
+// specialMentionRe matches Slack's @here, @channel, and @everyone markup.
+var specialMentionRe = regexp.MustCompile(`<!(here|channel|everyone)(?:\|[^>]*)?>`)
+
+// linkRe matches Slack link markup, `<url>` or `<url|text>`. It runs after
+// the mention patterns, which are the only other `<…>` forms rewritten.
+var linkRe = regexp.MustCompile(`<((?:https?|mailto):[^|>]+)(?:\|([^>]*))?>`)
+
+// slackEntityReplacer undoes the HTML escaping Slack applies to message
+// text. &amp; comes last so "&amp;lt;" decodes to "&lt;", not "<".
+var slackEntityReplacer = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+
+// CleanMessageText rewrites the markup in Slack message text into readable
+// form:
+//
+//   - `<@U123>` becomes `@name` via userNames (user ID -> display name)
+//   - `<#C123|general>` becomes `#name` via channelNames, as in
+//     ResolveChannelMentions
+//   - `<!here>` becomes `@here` (likewise @channel and @everyone)
+//   - `<https://example.com|text>` becomes `text (https://example.com)`, and
+//     a bare `<https://example.com>`, or a link whose text is its URL,
+//     becomes the URL; `mailto:` links become the address
+//
+// Mentions whose ID is unknown use the alias in the markup if there is one,
+// else stay as the raw token. Other markup is left as is. Finally the
+// &lt; &gt; &amp; escapes Slack applies are decoded.
+func CleanMessageText(text string, userNames, channelNames map[string]string) string {
+	text = userMentionRe.ReplaceAllStringFunc(text, func(match string) string {
+		m := userMentionRe.FindStringSubmatch(match)
+		id, alias := m[1], m[2]
+		if name, ok := userNames[id]; ok && name != "" {
+			return "@" + name
+		}
+		if alias != "" {
+			return "@" + strings.TrimPrefix(alias, "@")
+		}
+		return match
+	})
+	text = ResolveChannelMentions(text, channelNames)
+	text = specialMentionRe.ReplaceAllString(text, "@$1")
+	text = linkRe.ReplaceAllStringFunc(text, func(match string) string {
+		m := linkRe.FindStringSubmatch(match)
+		url, label := m[1], m[2]
+		if address, ok := strings.CutPrefix(url, "mailto:"); ok {
+			return address
+		}
+		if label == "" || label == url {
+			return url
+		}
+		return label + " (" + url + ")"
+	})
+	return slackEntityReplacer.Replace(text)
+}
+
+// digestTopParticipants is how many of the most active authors a channel
+// digest's summary line names.
+const digestTopParticipants = 5
+
+// BuildChannelDigest counts messages (and any fetched thread replies) by day
+// and author, and renders the summary with FormatChannelDigest. Authors
+// without a resolved name are counted under their user ID.
+func BuildChannelDigest(channel, channelID, purpose string, period Period, messages []Message) ChannelDigest {
+	total := make(map[string]int)
+	byDay := make(map[string]map[string]int)
+
+	var count func(msgs []Message)
+	count = func(msgs []Message) {
+		for _, m := range msgs {
+			author := m.UserName
+			if author == "" {
+				author = m.UserID
+			}
+			total[author]++
+			if byDay[m.Date] == nil {
+				byDay[m.Date] = make(map[string]int)
+			}
+			byDay[m.Date][author]++
+			count(m.Replies)
+		}
+	}
+	count(messages)
+
+	digest := ChannelDigest{
+		Channel:      channel,
+		ChannelID:    channelID,
+		Purpose:      purpose,
+		Period:       period,
+		Participants: sortedAuthorCounts(total),
+		Days:         []DigestDay{},
+	}
+	for _, a := range digest.Participants {
+		digest.MessageCount += a.Count
+	}
+
+	dates := make([]string, 0, len(byDay))
+	for date := range byDay {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	for _, date := range dates {
+		day := DigestDay{Date: date, Authors: sortedAuthorCounts(byDay[date])}
+		for _, a := range day.Authors {
+			day.Count += a.Count
+		}
+		digest.Days = append(digest.Days, day)
+	}
+
+	digest.Markdown = FormatChannelDigest(digest)
+	return digest
+}
+
+// sortedAuthorCounts returns counts most active first, ties by name.
+func sortedAuthorCounts(counts map[string]int) []AuthorCount {
+	authors := make([]AuthorCount, 0, len(counts))
+	for name, n := range counts {
+		authors = append(authors, AuthorCount{Name: name, Count: n})
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Count != authors[j].Count {
+			return authors[i].Count > authors[j].Count
+		}
+		return authors[i].Name < authors[j].Name
+	})
+	return authors
+}
+
+// FormatChannelDigest renders a channel digest as a compact Slack-flavored
+// markdown summary: the channel's purpose, totals, the most active
+// participants, and one line per day.
+func FormatChannelDigest(d ChannelDigest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*#%s digest* (%s to %s)\n", d.Channel, d.Period.Start, d.Period.End)
+	if d.Purpose != "" {
+		fmt.Fprintf(&b, "_%s_\n", d.Purpose)
+	}
+	b.WriteString("\n")
+
+	if d.MessageCount == 0 {
+		b.WriteString("No messages in this period.\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "%s from %s\n", pluralize(d.MessageCount, "message", "messages"), pluralize(len(d.Participants), "person", "people"))
+	top := d.Participants
+	if len(top) > digestTopParticipants {
+		top = top[:digestTopParticipants]
+	}
+	fmt.Fprintf(&b, "Most active: %s\n\n", formatAuthorCounts(top))
+
+	for _, day := range d.Days {
+		fmt.Fprintf(&b, "• %s: %s (%s)\n", day.Date, pluralize(day.Count, "message", "messages"), formatAuthorCounts(day.Authors))
+	}
+	return b.String()
+}
+
+// formatAuthorCounts formats authors as "alice 3, bob 1".
+func formatAuthorCounts(authors []AuthorCount) string {
+	parts := make([]string, len(authors))
+	for i, a := range authors {
+		parts[i] = fmt.Sprintf("%s %d", a.Name, a.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pluralize formats n with singular, or with plural for n != 1.
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -2,9 +2,14 @@ package flow
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/matsen/bipartite/internal/config"
 	"gopkg.in/yaml.v3"
@@ -64,6 +69,15 @@ func TestGetWebhookURL(t *testing.T) {
 			t.Errorf("GetWebhookURL() = %q, want %q", url, "https://hooks.slack.com/test")
 		}
 	})
+
+	t.Run("env var overrides config", func(t *testing.T) {
+		config.ResetGlobalConfigCache()
+		t.Setenv("SLACK_WEBHOOK_DASM2", "https://hooks.slack.com/from-env")
+		url := GetWebhookURL("dasm2")
+		if url != "https://hooks.slack.com/from-env" {
+			t.Errorf("GetWebhookURL() = %q, want %q", url, "https://hooks.slack.com/from-env")
+		}
+	})
 }
 
 func TestSendDigestError(t *testing.T) {
@@ -190,6 +204,155 @@ func TestUserCache_LoadNonExistent(t *testing.T) {
 	}
 }
 
+// newUsersListServer serves users.list in two pages: U123 renamed to
+// "alice-new" on the first, U456 on the second.
+func newUsersListServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users.list" {
+			t.Errorf("unexpected API call: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"ok":true,"members":[
+				{"id":"U123","name":"alice","profile":{"display_name":"alice-new"}}
+			],"response_metadata":{"next_cursor":"page2"}}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"members":[
+			{"id":"U456","name":"bob","profile":{"real_name":"Bob B"}}
+		]}`))
+	}))
+	oldBase := slackAPIBaseURL
+	slackAPIBaseURL = server.URL
+	t.Cleanup(func() {
+		slackAPIBaseURL = oldBase
+		server.Close()
+	})
+	return server
+}
+
+func TestRefreshUsers(t *testing.T) {
+	cleanup := withTempWorkDir(t)
+	defer cleanup()
+	server := newUsersListServer(t)
+
+	// A stale cache: an old name for U123 and a user who has left
+	stale := &SlackClient{userCache: map[string]string{"U123": "alice-old", "U999": "gone"}}
+	if err := stale.saveUserCache(); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &SlackClient{httpClient: server.Client(), userCache: make(map[string]string)}
+	users, err := client.RefreshUsers()
+	if err != nil {
+		t.Fatalf("RefreshUsers() error = %v", err)
+	}
+	want := map[string]string{"U123": "alice-new", "U456": "Bob B"}
+	if len(users) != len(want) || users["U123"] != want["U123"] || users["U456"] != want["U456"] {
+		t.Errorf("RefreshUsers() = %v, want %v", users, want)
+	}
+
+	cached, err := ReadSlackUserCache()
+	if err != nil {
+		t.Fatalf("ReadSlackUserCache() error = %v", err)
+	}
+	if len(cached) != 2 || cached["U123"] != "alice-new" || cached["U999"] != "" {
+		t.Errorf("cache after refresh = %v, want it overwritten with %v", cached, want)
+	}
+}
+
+func TestGetUsers_MergesIntoCache(t *testing.T) {
+	cleanup := withTempWorkDir(t)
+	defer cleanup()
+	server := newUsersListServer(t)
+
+	stale := &SlackClient{userCache: map[string]string{"U123": "alice-old", "U999": "gone"}}
+	if err := stale.saveUserCache(); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &SlackClient{httpClient: server.Client(), userCache: make(map[string]string)}
+	users, err := client.GetUsers()
+	if err != nil {
+		t.Fatalf("GetUsers() error = %v", err)
+	}
+	if users["U123"] != "alice-new" || users["U456"] != "Bob B" || users["U999"] != "gone" {
+		t.Errorf("GetUsers() = %v, want fetched names merged over the cache", users)
+	}
+}
+
+func TestReadSlackUserCache_Missing(t *testing.T) {
+	cleanup := withTempWorkDir(t)
+	defer cleanup()
+
+	users, err := ReadSlackUserCache()
+	if err != nil {
+		t.Fatalf("ReadSlackUserCache() error = %v", err)
+	}
+	if len(users) != 0 {
+		t.Errorf("ReadSlackUserCache() = %v, want empty", users)
+	}
+}
+
+func TestCleanMessageText(t *testing.T) {
+	users := map[string]string{"U123": "alice", "W456": "bob"}
+	channels := map[string]string{"C111": "general"}
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"user mention", "thanks <@U123>!", "thanks @alice!"},
+		{"enterprise user mention", "cc <@W456>", "cc @bob"},
+		{"user mention with alias", "<@U123|old-name> said", "@alice said"},
+		{"unknown user keeps raw token", "ping <@U999>", "ping <@U999>"},
+		{"unknown user uses alias", "ping <@U999|carol>", "ping @carol"},
+		{"channel mention", "see <#C111>", "see #general"},
+		{"channel mention with alias", "see <#C111|old>", "see #general"},
+		{"unknown channel uses alias", "see <#C222|random>", "see #random"},
+		{"unknown channel keeps raw token", "see <#C222>", "see <#C222>"},
+		{"link with text", "read <https://example.com/doc|the doc>", "read the doc (https://example.com/doc)"},
+		{"bare link", "at <https://example.com>", "at https://example.com"},
+		{"link text same as URL", "<http://x.org|http://x.org>", "http://x.org"},
+		{"mailto link", "mail <mailto:a@b.org|a@b.org>", "mail a@b.org"},
+		{"special mention", "<!here> standup", "@here standup"},
+		{"special mention with label", "<!channel|channel> heads up", "@channel heads up"},
+		{"entities decoded", "a &lt; b &amp;&amp; c &gt; d", "a < b && c > d"},
+		{"escaped markup is not rewritten", "literal &lt;@U123&gt;", "literal <@U123>"},
+		{"several tokens", "<@U123> moved it to <#C111>: <https://x.org|PR>",
+			"@alice moved it to #general: PR (https://x.org)"},
+		{"plain text", "nothing to do", "nothing to do"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanMessageText(tt.in, users, channels); got != tt.want {
+				t.Errorf("CleanMessageText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	if got := CleanMessageText("<@U123> in <#C111|general>", nil, nil); got != "<@U123> in #general" {
+		t.Errorf("with no maps = %q", got)
+	}
+}
+
+func TestConvertSlackMessages_CleansText(t *testing.T) {
+	client := &SlackClient{
+		userCache:    map[string]string{"U1": "alice", "U2": "bob"},
+		channelNames: map[string]string{"C1": "general"},
+	}
+	messages, err := client.convertSlackMessages([]slackMessage{
+		{User: "U1", Text: "<@U2> see <#C1>", TS: "100.000100"},
+	})
+	if err != nil {
+		t.Fatalf("convertSlackMessages() error = %v", err)
+	}
+	if len(messages) != 1 || messages[0].Text != "@bob see #general" {
+		t.Errorf("messages = %+v", messages)
+	}
+}
+
 func TestParseSlackTimestamp(t *testing.T) {
 	tests := []struct {
 		ts       string
@@ -456,3 +619,209 @@ func TestMessage_JSONFields(t *testing.T) {
 		}
 	}
 }
+
+func TestGetThreadReplies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conversations.replies" {
+			t.Errorf("unexpected API call: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("ts"); got != "100.000100" {
+			t.Errorf("ts = %q, want parent ts", got)
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"ok":true,"messages":[
+				{"user":"U1","text":"parent","ts":"100.000100","thread_ts":"100.000100"},
+				{"user":"U2","text":"first","ts":"101.000100","thread_ts":"100.000100"}
+			],"response_metadata":{"next_cursor":"page2"}}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"messages":[
+			{"user":"U1","text":"second","ts":"102.000100","thread_ts":"100.000100"}
+		]}`))
+	}))
+	defer server.Close()
+	oldBase := slackAPIBaseURL
+	slackAPIBaseURL = server.URL
+	defer func() { slackAPIBaseURL = oldBase }()
+
+	client := &SlackClient{
+		httpClient: server.Client(),
+		userCache:  map[string]string{"U1": "alice", "U2": "bob"},
+	}
+	messages := []Message{
+		{Timestamp: "100.000100", ThreadTS: "100.000100", Text: "parent"},
+		{Timestamp: "99.000100", Text: "unthreaded"},
+	}
+	if err := client.GetThreadReplies("C1", messages, time.Unix(0, 0)); err != nil {
+		t.Fatalf("GetThreadReplies() error = %v", err)
+	}
+
+	replies := messages[0].Replies
+	if len(replies) != 2 {
+		t.Fatalf("got %d replies, want 2 (parent excluded): %+v", len(replies), replies)
+	}
+	if replies[0].Text != "first" || replies[0].UserName != "bob" || replies[1].UserName != "alice" {
+		t.Errorf("replies = %+v", replies)
+	}
+	if messages[1].Replies != nil {
+		t.Errorf("unthreaded message got replies: %+v", messages[1].Replies)
+	}
+}
+
+func TestGetChannelHistory_Cache(t *testing.T) {
+	cleanup := withTempWorkDir(t)
+	defer cleanup()
+
+	now := time.Now()
+	today := startOfDay(now)
+	msgTS := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) + ".000100" }
+	all := []slackMessage{ // Newest first
+		{User: "U1", Text: "today", TS: msgTS(now)},
+		{User: "U1", Text: "yesterday", TS: msgTS(today.AddDate(0, 0, -1).Add(12 * time.Hour))},
+		{User: "U1", Text: "two days ago", TS: msgTS(today.AddDate(0, 0, -2).Add(12 * time.Hour))},
+	}
+
+	var requestedOldest []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		oldestParam := r.URL.Query().Get("oldest")
+		requestedOldest = append(requestedOldest, oldestParam)
+		oldest, _ := strconv.ParseInt(oldestParam, 10, 64)
+		var msgs []slackMessage
+		for _, m := range all {
+			if ts, _ := parseSlackTimestamp(m.TS); ts.Unix() >= oldest {
+				msgs = append(msgs, m)
+			}
+		}
+		json.NewEncoder(w).Encode(slackHistoryResponse{slackAPIResponse: slackAPIResponse{OK: true}, Messages: msgs})
+	}))
+	defer server.Close()
+	oldBase := slackAPIBaseURL
+	slackAPIBaseURL = server.URL
+	defer func() { slackAPIBaseURL = oldBase }()
+
+	client := &SlackClient{
+		httpClient:   server.Client(),
+		userCache:    map[string]string{"U1": "alice"},
+		historyCache: true,
+	}
+	oldest := today.AddDate(0, 0, -3)
+
+	for run := 1; run <= 2; run++ {
+		messages, err := client.GetChannelHistory("C1", oldest, 100)
+		if err != nil {
+			t.Fatalf("run %d: GetChannelHistory() error = %v", run, err)
+		}
+		if len(messages) != 3 || messages[0].Text != "today" || messages[2].Text != "two days ago" {
+			t.Errorf("run %d: messages = %+v", run, messages)
+		}
+	}
+
+	want := []string{strconv.FormatInt(oldest.Unix(), 10), strconv.FormatInt(today.Unix(), 10)}
+	if len(requestedOldest) != 2 || requestedOldest[0] != want[0] || requestedOldest[1] != want[1] {
+		t.Errorf("requested oldest = %v, want %v (second run serves past days from cache)", requestedOldest, want)
+	}
+
+	t.Run("old cache version is refetched", func(t *testing.T) {
+		day := today.AddDate(0, 0, -3)
+		data, _ := json.Marshal(slackHistoryCacheFile{Version: slackHistoryCacheVersion + 1, ChannelID: "C1"})
+		if err := os.WriteFile(historyCachePath("C1", day), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := loadHistoryDay("C1", day); ok {
+			t.Error("loadHistoryDay() accepted a file with another version")
+		}
+	})
+
+	t.Run("disabled cache fetches live", func(t *testing.T) {
+		requestedOldest = nil
+		client.SetHistoryCache(false)
+		if _, err := client.GetChannelHistory("C1", oldest, 100); err != nil {
+			t.Fatalf("GetChannelHistory() error = %v", err)
+		}
+		if len(requestedOldest) != 1 || requestedOldest[0] != want[0] {
+			t.Errorf("requested oldest = %v, want [%s]", requestedOldest, want[0])
+		}
+	})
+}
+
+func TestBuildChannelDigest(t *testing.T) {
+	messages := []Message{
+		{UserName: "bob", Date: "2026-10-14"},
+		{UserName: "alice", Date: "2026-10-14", Replies: []Message{
+			{UserName: "bob", Date: "2026-10-14"},
+			{UserName: "carol", Date: "2026-10-15"},
+		}},
+		{UserID: "U999", Date: "2026-10-13"},
+		{UserName: "alice", Date: "2026-10-13"},
+	}
+	period := Period{Start: "2026-10-08", End: "2026-10-15"}
+
+	d := BuildChannelDigest("eng", "C123", "Engineering chat", period, messages)
+
+	if d.MessageCount != 6 {
+		t.Errorf("MessageCount = %d, want 6", d.MessageCount)
+	}
+	wantParticipants := []AuthorCount{{"alice", 2}, {"bob", 2}, {"U999", 1}, {"carol", 1}}
+	if len(d.Participants) != len(wantParticipants) {
+		t.Fatalf("Participants = %v, want %v", d.Participants, wantParticipants)
+	}
+	for i, want := range wantParticipants {
+		if d.Participants[i] != want {
+			t.Errorf("Participants[%d] = %v, want %v", i, d.Participants[i], want)
+		}
+	}
+
+	wantDays := []struct {
+		date  string
+		count int
+	}{{"2026-10-13", 2}, {"2026-10-14", 3}, {"2026-10-15", 1}}
+	if len(d.Days) != len(wantDays) {
+		t.Fatalf("Days = %v, want %d days", d.Days, len(wantDays))
+	}
+	for i, want := range wantDays {
+		if d.Days[i].Date != want.date || d.Days[i].Count != want.count {
+			t.Errorf("Days[%d] = %s/%d, want %s/%d", i, d.Days[i].Date, d.Days[i].Count, want.date, want.count)
+		}
+	}
+	if got := d.Days[1].Authors[0]; got != (AuthorCount{"bob", 2}) {
+		t.Errorf("top author on 2026-10-14 = %v, want bob 2", got)
+	}
+
+	want := "*#eng digest* (2026-10-08 to 2026-10-15)\n" +
+		"_Engineering chat_\n\n" +
+		"6 messages from 4 people\n" +
+		"Most active: alice 2, bob 2, U999 1, carol 1\n\n" +
+		"• 2026-10-13: 2 messages (U999 1, alice 1)\n" +
+		"• 2026-10-14: 3 messages (bob 2, alice 1)\n" +
+		"• 2026-10-15: 1 message (carol 1)\n"
+	if d.Markdown != want {
+		t.Errorf("Markdown =\n%s\nwant\n%s", d.Markdown, want)
+	}
+}
+
+func TestBuildChannelDigest_Empty(t *testing.T) {
+	d := BuildChannelDigest("eng", "C123", "", Period{Start: "2026-10-08", End: "2026-10-15"}, nil)
+
+	if d.MessageCount != 0 || len(d.Participants) != 0 || len(d.Days) != 0 {
+		t.Errorf("digest of no messages = %+v, want no counts", d)
+	}
+	if d.Days == nil {
+		t.Error("Days is nil, want an empty list in JSON")
+	}
+	want := "*#eng digest* (2026-10-08 to 2026-10-15)\n\nNo messages in this period.\n"
+	if d.Markdown != want {
+		t.Errorf("Markdown = %q, want %q", d.Markdown, want)
+	}
+}
+
+func TestFormatChannelDigest_TopParticipants(t *testing.T) {
+	var messages []Message
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		messages = append(messages, Message{UserName: name, Date: "2026-10-14"})
+	}
+	d := BuildChannelDigest("eng", "C123", "", Period{}, messages)
+
+	if !strings.Contains(d.Markdown, "Most active: a 1, b 1, c 1, d 1, e 1\n") {
+		t.Errorf("Markdown should name only the top %d participants:\n%s", digestTopParticipants, d.Markdown)
+	}
+}
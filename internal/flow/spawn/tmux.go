@@ -2,35 +2,218 @@
 package spawn
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// adhocPrefix and adhocTimeLayout make up adhoc window names.
+const (
+	adhocPrefix     = "adhoc-"
+	adhocTimeLayout = "2006-01-02-150405"
+)
+
+// itemWindowPattern matches issue/PR window names like "netam#123".
+var itemWindowPattern = regexp.MustCompile(`^([A-Za-z0-9._-]+)#([0-9]+)$`)
+
+// Window is a tmux window named the way spawn names them.
+type Window struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`              // "item" for repo#N windows, "adhoc" for adhoc sessions
+	Repo    string `json:"repo,omitempty"`    // Repo name (without org) for item windows
+	Number  int    `json:"number,omitempty"`  // Issue or PR number for item windows
+	Current bool   `json:"current,omitempty"` // The window this process runs in
+}
+
 // IsInTmux checks if we're running inside a tmux session.
 func IsInTmux() bool {
 	return os.Getenv("TMUX") != ""
 }
 
+// Runner runs tmux commands, so tests can check the arguments built
+// without a live tmux.
+type Runner interface {
+	// Run executes tmux with args and returns its stdout.
+	Run(args ...string) ([]byte, error)
+}
+
+// ExecRunner implements Runner by running the tmux binary.
+type ExecRunner struct{}
+
+// Run executes tmux with args. A failed command returns an *exec.ExitError
+// carrying tmux's stderr.
+func (ExecRunner) Run(args ...string) ([]byte, error) {
+	return exec.Command("tmux", args...).Output()
+}
+
+// runner is the Runner every tmux call in this package goes through.
+var runner Runner = ExecRunner{}
+
+// tmuxError wraps a failed tmux call, using tmux's stderr as the message
+// when there is one.
+func tmuxError(action string, err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%s: %s", action, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// ErrWindowNotFound is returned by KillWindow when no window has the name.
+var ErrWindowNotFound = errors.New("window not found")
+
+// tmuxWindow is a window in the current tmux session.
+type tmuxWindow struct {
+	id   string // Stable window ID like "@3", safe as a target whatever the name
+	name string
+}
+
+// listTmuxWindows returns the windows in the current tmux session.
+func listTmuxWindows() ([]tmuxWindow, error) {
+	output, err := runner.Run("list-windows", "-F", "#{window_id}\t#W")
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []tmuxWindow
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		id, name, ok := strings.Cut(line, "\t")
+		if ok {
+			windows = append(windows, tmuxWindow{id: id, name: name})
+		}
+	}
+	return windows, nil
+}
+
+// currentWindowID returns the ID of the window this process runs in, or ""
+// if it can't be determined.
+func currentWindowID() string {
+	pane := os.Getenv("TMUX_PANE")
+	if pane == "" {
+		return ""
+	}
+	output, err := runner.Run("display-message", "-p", "-t", pane, "#{window_id}")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// killTmuxWindow kills the window with the given ID.
+func killTmuxWindow(id string) error {
+	if _, err := runner.Run("kill-window", "-t", id); err != nil {
+		return tmuxError("killing window", err)
+	}
+	return nil
+}
+
 // WindowExists checks if a tmux window with the given name exists.
 func WindowExists(windowName string) bool {
-	cmd := exec.Command("tmux", "list-windows", "-F", "#W")
-	output, err := cmd.Output()
+	windows, err := listTmuxWindows()
 	if err != nil {
 		return false
 	}
 
-	windows := strings.Split(strings.TrimSpace(string(output)), "\n")
 	for _, w := range windows {
-		if w == windowName {
+		if w.name == windowName {
 			return true
 		}
 	}
 	return false
 }
 
+// ListSpawnedWindows returns the windows in the current tmux session whose
+// names follow spawn's naming patterns, in tmux order. Windows spawned with
+// a custom --name are not recognized.
+func ListSpawnedWindows() ([]Window, error) {
+	all, err := listTmuxWindows()
+	if err != nil {
+		return nil, fmt.Errorf("listing tmux windows: %w", err)
+	}
+
+	current := currentWindowID()
+	var windows []Window
+	for _, tw := range all {
+		if w, ok := ParseWindowName(tw.name); ok {
+			w.Current = tw.id == current
+			windows = append(windows, w)
+		}
+	}
+	return windows, nil
+}
+
+// KillWindow kills every window in the current tmux session named name,
+// returning ErrWindowNotFound if there is none.
+func KillWindow(name string) error {
+	windows, err := listTmuxWindows()
+	if err != nil {
+		return fmt.Errorf("listing tmux windows: %w", err)
+	}
+
+	found := false
+	for _, w := range windows {
+		if w.name != name {
+			continue
+		}
+		found = true
+		if err := killTmuxWindow(w.id); err != nil {
+			return err
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", ErrWindowNotFound, name)
+	}
+	return nil
+}
+
+// KillSpawnedWindows kills the windows ListSpawnedWindows reports, except
+// the one this process runs in, and returns those it killed. Windows with
+// other names are never touched.
+func KillSpawnedWindows() ([]Window, error) {
+	all, err := listTmuxWindows()
+	if err != nil {
+		return nil, fmt.Errorf("listing tmux windows: %w", err)
+	}
+
+	current := currentWindowID()
+	var killed []Window
+	for _, tw := range all {
+		w, ok := ParseWindowName(tw.name)
+		if !ok || tw.id == current {
+			continue
+		}
+		if err := killTmuxWindow(tw.id); err != nil {
+			return killed, err
+		}
+		killed = append(killed, w)
+	}
+	return killed, nil
+}
+
+// ParseWindowName parses a window name built by BuildWindowName or
+// BuildAdhocWindowName, reporting false for any other name.
+func ParseWindowName(name string) (Window, bool) {
+	if m := itemWindowPattern.FindStringSubmatch(name); m != nil {
+		number, err := strconv.Atoi(m[2])
+		if err != nil {
+			return Window{}, false
+		}
+		return Window{Name: name, Kind: "item", Repo: m[1], Number: number}, true
+	}
+	if stamp, ok := strings.CutPrefix(name, adhocPrefix); ok {
+		if _, err := time.Parse(adhocTimeLayout, stamp); err == nil {
+			return Window{Name: name, Kind: "adhoc"}, true
+		}
+	}
+	return Window{}, false
+}
+
 // CreateWindow creates a tmux window and runs Claude Code with the given prompt.
 func CreateWindow(windowName, repoPath, prompt, url string) error {
 	// Write prompt to temp file
@@ -82,24 +265,15 @@ claude --dangerously-skip-permissions "$prompt"
 		return fmt.Errorf("chmod launcher: %w", err)
 	}
 
-	// Create tmux window
-	cmd := exec.Command("tmux", "new-window", "-n", windowName, "-c", repoPath, "-P")
-	output, err := cmd.Output()
-	if err != nil {
+	// Create tmux window. The output (the new window's target) isn't needed.
+	if _, err := runner.Run("new-window", "-n", windowName, "-c", repoPath, "-P"); err != nil {
 		os.Remove(promptPath)
 		os.Remove(launcherPath)
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return fmt.Errorf("creating window: %s", string(exitErr.Stderr))
-		}
-		return fmt.Errorf("creating window: %w", err)
+		return tmuxError("creating window", err)
 	}
 
-	// Window created successfully
-	_ = output // Contains the window target, not needed
-
 	// Run the launcher script — bash reads the file safely
-	cmd = exec.Command("tmux", "send-keys", "-t", windowName, launcherPath, "Enter")
-	if err := cmd.Run(); err != nil {
+	if _, err := runner.Run("send-keys", "-t", windowName, launcherPath, "Enter"); err != nil {
 		return fmt.Errorf("sending command to window: %w", err)
 	}
 
@@ -111,3 +285,8 @@ func BuildWindowName(repoPath string, number int) string {
 	repoName := filepath.Base(repoPath)
 	return fmt.Sprintf("%s#%d", repoName, number)
 }
+
+// BuildAdhocWindowName creates a window name for an adhoc session started at t.
+func BuildAdhocWindowName(t time.Time) string {
+	return adhocPrefix + t.Format(adhocTimeLayout)
+}
@@ -1,6 +1,81 @@
 package spawn
 
-import "testing"
+import (
+	"errors"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRunner is a Runner standing in for tmux. It holds windows ("id\tname"
+// lines), in which this process runs in window current, records every call,
+// and drops killed windows from later listings. A call whose subcommand is
+// failOn returns an error.
+type fakeRunner struct {
+	t       *testing.T
+	windows []string
+	current string
+	failOn  string
+	calls   [][]string
+}
+
+func (f *fakeRunner) Run(args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	if args[0] == f.failOn {
+		return nil, errors.New("no server running")
+	}
+	switch args[0] {
+	case "list-windows":
+		var lines []string
+		for _, w := range f.windows {
+			id, _, _ := strings.Cut(w, "\t")
+			if !slices.Contains(f.killed(), id) {
+				lines = append(lines, w)
+			}
+		}
+		return []byte(strings.Join(lines, "\n") + "\n"), nil
+	case "display-message":
+		return []byte(f.current + "\n"), nil
+	case "kill-window", "new-window", "send-keys":
+		return nil, nil
+	}
+	f.t.Fatalf("unexpected tmux call: %v", args)
+	return nil, nil
+}
+
+// killed returns the targets of the kill-window calls so far.
+func (f *fakeRunner) killed() []string {
+	var ids []string
+	for _, call := range f.calls {
+		if call[0] == "kill-window" {
+			ids = append(ids, call[len(call)-1])
+		}
+	}
+	return ids
+}
+
+// fakeTmux points the package at a fakeRunner for the rest of the test.
+func fakeTmux(t *testing.T, windows []string, current string) *fakeRunner {
+	t.Helper()
+	f := &fakeRunner{t: t, windows: windows, current: current}
+	old := runner
+	runner = f
+	t.Cleanup(func() { runner = old })
+	t.Setenv("TMUX_PANE", "%1")
+	return f
+}
+
+var fakeWindows = []string{
+	"@1\tzsh",
+	"@2\tnetam#12",
+	"@3\tadhoc-2026-03-05-140709",
+	"@4\tmy.repo#7",
+	"@5\tnotes",
+}
 
 func TestBuildWindowName(t *testing.T) {
 	tests := []struct {
@@ -45,8 +120,168 @@ func TestBuildWindowName(t *testing.T) {
 	}
 }
 
+func TestBuildAdhocWindowName(t *testing.T) {
+	got := BuildAdhocWindowName(time.Date(2026, 3, 5, 14, 7, 9, 0, time.UTC))
+	if want := "adhoc-2026-03-05-140709"; got != want {
+		t.Errorf("BuildAdhocWindowName() = %q, want %q", got, want)
+	}
+}
+
+func TestParseWindowName(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   Window
+		wantOK bool
+	}{
+		{"netam#123", Window{Name: "netam#123", Kind: "item", Repo: "netam", Number: 123}, true},
+		{"my-repo.go#7", Window{Name: "my-repo.go#7", Kind: "item", Repo: "my-repo.go", Number: 7}, true},
+		{"adhoc-2026-03-05-140709", Window{Name: "adhoc-2026-03-05-140709", Kind: "adhoc"}, true},
+		{"adhoc-notes", Window{}, false},
+		{"netam#", Window{}, false},
+		{"netam#12a", Window{}, false},
+		{"org/netam#12", Window{}, false},
+		{"zsh", Window{}, false},
+		{"", Window{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseWindowName(tt.name)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParseWindowName(%q) = %+v, %v; want %+v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestListSpawnedWindows(t *testing.T) {
+	fakeTmux(t, fakeWindows, "@3")
+
+	got, err := ListSpawnedWindows()
+	if err != nil {
+		t.Fatalf("ListSpawnedWindows() error = %v", err)
+	}
+	want := []Window{
+		{Name: "netam#12", Kind: "item", Repo: "netam", Number: 12},
+		{Name: "adhoc-2026-03-05-140709", Kind: "adhoc", Current: true},
+		{Name: "my.repo#7", Kind: "item", Repo: "my.repo", Number: 7},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListSpawnedWindows() = %+v, want %+v", got, want)
+	}
+}
+
+func TestKillWindow(t *testing.T) {
+	f := fakeTmux(t, fakeWindows, "@1")
+
+	// Any window can be killed by name; the target is the window ID
+	if err := KillWindow("my.repo#7"); err != nil {
+		t.Fatalf("KillWindow() error = %v", err)
+	}
+	if err := KillWindow("notes"); err != nil {
+		t.Fatalf("KillWindow() error = %v", err)
+	}
+	if want := []string{"@4", "@5"}; !reflect.DeepEqual(f.killed(), want) {
+		t.Errorf("killed = %v, want %v", f.killed(), want)
+	}
+
+	if err := KillWindow("netam#99"); !errors.Is(err, ErrWindowNotFound) {
+		t.Errorf("KillWindow(missing) error = %v, want ErrWindowNotFound", err)
+	}
+}
+
+func TestKillSpawnedWindows(t *testing.T) {
+	f := fakeTmux(t, fakeWindows, "@2")
+
+	got, err := KillSpawnedWindows()
+	if err != nil {
+		t.Fatalf("KillSpawnedWindows() error = %v", err)
+	}
+	// Unrelated windows and the current one (@2) are left alone
+	if want := []string{"@3", "@4"}; !reflect.DeepEqual(f.killed(), want) {
+		t.Errorf("killed IDs = %v, want %v", f.killed(), want)
+	}
+	var names []string
+	for _, w := range got {
+		names = append(names, w.Name)
+	}
+	if want := []string{"adhoc-2026-03-05-140709", "my.repo#7"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("killed windows = %v, want %v", names, want)
+	}
+}
+
+func TestCreateWindow(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	f := fakeTmux(t, nil, "")
+	prompt := "Review \"this\" PR; don't expand $HOME"
+
+	if err := CreateWindow("netam#12", "/work/netam", prompt, "https://github.com/org/netam/pull/12"); err != nil {
+		t.Fatalf("CreateWindow() error = %v", err)
+	}
+	if len(f.calls) != 2 {
+		t.Fatalf("tmux calls = %v, want new-window then send-keys", f.calls)
+	}
+	if want := []string{"new-window", "-n", "netam#12", "-c", "/work/netam", "-P"}; !reflect.DeepEqual(f.calls[0], want) {
+		t.Errorf("new-window call = %v, want %v", f.calls[0], want)
+	}
+	sendKeys := f.calls[1]
+	if len(sendKeys) != 5 || !reflect.DeepEqual(sendKeys[:3], []string{"send-keys", "-t", "netam#12"}) || sendKeys[4] != "Enter" {
+		t.Fatalf("send-keys call = %v, want send-keys -t netam#12 <launcher> Enter", sendKeys)
+	}
+
+	// The launcher prints the URL and hands the prompt file to claude
+	launcher, err := os.ReadFile(sendKeys[3])
+	if err != nil {
+		t.Fatalf("reading launcher: %v", err)
+	}
+	if !strings.Contains(string(launcher), "echo 'https://github.com/org/netam/pull/12'") {
+		t.Errorf("launcher does not print the URL:\n%s", launcher)
+	}
+	m := regexp.MustCompile(`prompt=\$\(<'([^']+)'\)`).FindStringSubmatch(string(launcher))
+	if m == nil {
+		t.Fatalf("launcher does not read a prompt file:\n%s", launcher)
+	}
+	if got, err := os.ReadFile(m[1]); err != nil || string(got) != prompt {
+		t.Errorf("prompt file = %q (err %v), want %q", got, err, prompt)
+	}
+}
+
+func TestCreateWindow_TmuxFails(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	f := fakeTmux(t, nil, "")
+	f.failOn = "new-window"
+
+	err := CreateWindow("netam#12", "/work/netam", "prompt", "")
+	if err == nil || !strings.Contains(err.Error(), "creating window: no server running") {
+		t.Fatalf("CreateWindow() error = %v, want creating window failure", err)
+	}
+	if len(f.calls) != 1 {
+		t.Errorf("tmux calls = %v, want only new-window", f.calls)
+	}
+	// The prompt and launcher files are cleaned up
+	if entries, _ := os.ReadDir(tmp); len(entries) != 0 {
+		t.Errorf("temp dir has %d leftover files", len(entries))
+	}
+}
+
+func TestWindowExists(t *testing.T) {
+	fakeTmux(t, fakeWindows, "")
+	if !WindowExists("netam#12") {
+		t.Error("WindowExists(netam#12) = false, want true")
+	}
+	if WindowExists("netam#13") {
+		t.Error("WindowExists(netam#13) = true, want false")
+	}
+}
+
 func TestIsInTmux(t *testing.T) {
-	// This test just verifies the function runs without panic.
-	// The actual result depends on the test environment.
-	_ = IsInTmux()
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	if !IsInTmux() {
+		t.Error("IsInTmux() = false with TMUX set")
+	}
+	t.Setenv("TMUX", "")
+	if IsInTmux() {
+		t.Error("IsInTmux() = true with TMUX empty")
+	}
 }
@@ -40,9 +40,10 @@ type SlackChannelConfig struct {
 // (or opted out) explicitly," and a nil pointer means "inherit from
 // global." Each leaf field overrides independently — see flow.ResolveRepoPath.
 type RepoEntry struct {
-	Repo    string               `yaml:"repo"`
-	Channel string               `yaml:"channel,omitempty"`
-	Layout  *config.LayoutConfig `yaml:"layout,omitempty"`
+	Repo           string               `yaml:"repo"`
+	Channel        string               `yaml:"channel,omitempty"`
+	Layout         *config.LayoutConfig `yaml:"layout,omitempty"`
+	PromptTemplate string               `yaml:"prompt_template,omitempty"` // bip spawn task template, relative to the nexus
 }
 
 // GitHubRef represents a parsed GitHub reference.
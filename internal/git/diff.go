@@ -1,6 +1,8 @@
 package git
 
 import (
+	"reflect"
+
 	"github.com/matsen/bipartite/internal/reference"
 )
 
@@ -23,7 +25,7 @@ func DiffWorkingTree(repoRoot string) (*GitDiff, error) {
 }
 
 // DiffSince compares current refs.jsonl to a specific commit.
-// Returns papers added and removed since that commit.
+// Returns papers added, removed, and modified since that commit.
 func DiffSince(repoRoot, commitRef string) (*GitDiff, error) {
 	// Get refs at the specified commit
 	oldRefs, err := GetRefsJSONLAtCommit(repoRoot, commitRef)
@@ -41,7 +43,8 @@ func DiffSince(repoRoot, commitRef string) (*GitDiff, error) {
 }
 
 // diffRefs computes the difference between two sets of references.
-// Returns papers in current but not old (added), and papers in old but not current (removed).
+// Returns papers in current but not old (added), papers in old but not current (removed),
+// and papers in both whose records differ (modified).
 func diffRefs(oldRefs, currentRefs []reference.Reference) *GitDiff {
 	// Build maps keyed by ID for efficient lookup
 	oldMap := make(map[string]reference.Reference, len(oldRefs))
@@ -54,11 +57,14 @@ func diffRefs(oldRefs, currentRefs []reference.Reference) *GitDiff {
 		currentMap[ref.ID] = ref
 	}
 
-	// Find added papers (in current but not old)
-	var added []reference.Reference
+	// Find added papers (in current but not old) and modified papers (in both, changed)
+	var added, modified []reference.Reference
 	for id, ref := range currentMap {
-		if _, exists := oldMap[id]; !exists {
+		old, exists := oldMap[id]
+		if !exists {
 			added = append(added, ref)
+		} else if !reflect.DeepEqual(old, ref) {
+			modified = append(modified, ref)
 		}
 	}
 
@@ -71,7 +77,8 @@ func diffRefs(oldRefs, currentRefs []reference.Reference) *GitDiff {
 	}
 
 	return &GitDiff{
-		Added:   added,
-		Removed: removed,
+		Added:    added,
+		Removed:  removed,
+		Modified: modified,
 	}
 }
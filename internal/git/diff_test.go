@@ -20,11 +20,12 @@ func TestDiffRefs(t *testing.T) {
 	ref := func(id string) reference.Reference { return reference.Reference{ID: id} }
 
 	tests := []struct {
-		name        string
-		old         []reference.Reference
-		current     []reference.Reference
-		wantAdded   []string
-		wantRemoved []string
+		name         string
+		old          []reference.Reference
+		current      []reference.Reference
+		wantAdded    []string
+		wantRemoved  []string
+		wantModified []string
 	}{
 		{
 			name:        "added only",
@@ -54,6 +55,13 @@ func TestDiffRefs(t *testing.T) {
 			wantAdded:   []string{"c"},
 			wantRemoved: []string{"a"},
 		},
+		{
+			name:         "modified",
+			old:          []reference.Reference{ref("a"), ref("b")},
+			current:      []reference.Reference{ref("a"), {ID: "b", Title: "New title"}, ref("c")},
+			wantAdded:    []string{"c"},
+			wantModified: []string{"b"},
+		},
 		{
 			name:        "both empty",
 			old:         nil,
@@ -71,6 +79,9 @@ func TestDiffRefs(t *testing.T) {
 			if gotRemoved := ids(diff.Removed); !slices.Equal(gotRemoved, tt.wantRemoved) {
 				t.Errorf("Removed = %v, want %v", gotRemoved, tt.wantRemoved)
 			}
+			if gotModified := ids(diff.Modified); !slices.Equal(gotModified, tt.wantModified) {
+				t.Errorf("Modified = %v, want %v", gotModified, tt.wantModified)
+			}
 		})
 	}
 }
@@ -5,8 +5,9 @@ import "github.com/matsen/bipartite/internal/reference"
 
 // GitDiff represents changes to refs.jsonl between two git states.
 type GitDiff struct {
-	Added   []reference.Reference
-	Removed []reference.Reference
+	Added    []reference.Reference
+	Removed  []reference.Reference
+	Modified []reference.Reference // Current version of refs whose record changed
 }
 
 // CommitInfo represents information about a git commit.
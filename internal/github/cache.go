@@ -0,0 +1,98 @@
+package github
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached repo metadata is served before
+// FetchRepoMetadata asks the API again.
+const DefaultCacheTTL = 24 * time.Hour
+
+// repoCacheVersion is bumped when the cache entry format changes; entries
+// from other versions are ignored.
+const repoCacheVersion = 1
+
+// repoCacheEntry is one cached FetchRepoMetadata result.
+type repoCacheEntry struct {
+	Version   int          `json:"version"`
+	URL       string       `json:"url"`        // Normalized GitHub URL
+	FetchedAt time.Time    `json:"fetched_at"` // When the API was queried
+	Metadata  RepoMetadata `json:"metadata"`
+}
+
+// WithCache makes FetchRepoMetadata serve metadata fetched within ttl from
+// JSON files under dir, one per repo, and store fresh fetches there. A
+// non-positive ttl disables the cache.
+func WithCache(dir string, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if ttl > 0 {
+			c.cacheDir = dir
+			c.cacheTTL = ttl
+		}
+	}
+}
+
+// WithFreshFetch makes FetchRepoMetadata always query the API, ignoring
+// cached entries. Fetched metadata is still written to the WithCache
+// directory, so a refresh also updates what later cached reads see.
+func WithFreshFetch() ClientOption {
+	return func(c *Client) {
+		c.cacheFresh = true
+	}
+}
+
+// cachePath returns the cache file for owner/repo, or "" without a cache.
+// GitHub names are case-insensitive, so the path is lowercased.
+func (c *Client) cachePath(owner, repo string) string {
+	if c.cacheDir == "" {
+		return ""
+	}
+	return filepath.Join(c.cacheDir, strings.ToLower(owner), strings.ToLower(repo)+".json")
+}
+
+// loadCachedMetadata returns cached metadata for owner/repo if an entry
+// younger than the TTL exists. Unreadable entries count as misses.
+func (c *Client) loadCachedMetadata(owner, repo string) (*RepoMetadata, bool) {
+	path := c.cachePath(owner, repo)
+	if path == "" || c.cacheFresh {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry repoCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Version != repoCacheVersion {
+		return nil, false
+	}
+	if c.now().Sub(entry.FetchedAt) >= c.cacheTTL {
+		return nil, false
+	}
+	return &entry.Metadata, true
+}
+
+// saveCachedMetadata stores meta for owner/repo. The cache is best-effort:
+// a write failure only costs a later API call, so it is not reported.
+func (c *Client) saveCachedMetadata(owner, repo string, meta *RepoMetadata) {
+	path := c.cachePath(owner, repo)
+	if path == "" {
+		return
+	}
+	data, err := json.MarshalIndent(repoCacheEntry{
+		Version:   repoCacheVersion,
+		URL:       "https://github.com/" + owner + "/" + repo,
+		FetchedAt: c.now(),
+		Metadata:  *meta,
+	}, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newCountingRepoServer serves a fixed repo for every request, counting calls.
+func newCountingRepoServer(t *testing.T, calls *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		_, _ = w.Write([]byte(`{"name": "netam", "full_name": "matsengrp/netam", "language": "Python", "stargazers_count": 12}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchRepoMetadata_Cache(t *testing.T) {
+	var calls int
+	server := newCountingRepoServer(t, &calls)
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	client := NewClient(WithBaseURL(server.URL), WithToken(""), WithCache(dir, DefaultCacheTTL))
+	client.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		meta, err := client.FetchRepoMetadata("matsengrp/netam")
+		if err != nil {
+			t.Fatalf("FetchRepoMetadata() error = %v", err)
+		}
+		if meta.Stars != 12 {
+			t.Errorf("Stars = %d, want 12", meta.Stars)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("API calls = %d, want 1 (second fetch from cache)", calls)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "matsengrp", "netam.json")); err != nil {
+		t.Errorf("cache file not written: %v", err)
+	}
+
+	// Same repo in another spelling hits the same entry
+	if _, err := client.FetchRepoMetadata("https://github.com/MatsenGRP/Netam"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("API calls = %d after URL form, want 1", calls)
+	}
+
+	// Past the TTL the API is asked again
+	now = now.Add(DefaultCacheTTL)
+	if _, err := client.FetchRepoMetadata("matsengrp/netam"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("API calls = %d after TTL, want 2", calls)
+	}
+}
+
+func TestFetchRepoMetadata_FreshFetch(t *testing.T) {
+	var calls int
+	server := newCountingRepoServer(t, &calls)
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	fresh := NewClient(WithBaseURL(server.URL), WithToken(""), WithCache(dir, DefaultCacheTTL), WithFreshFetch())
+	fresh.now = func() time.Time { return now }
+	for i := 0; i < 2; i++ {
+		if _, err := fresh.FetchRepoMetadata("matsengrp/netam"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("API calls = %d, want 2 (fresh fetch ignores the cache)", calls)
+	}
+
+	// The fresh fetch wrote through, so a cached client is served from disk
+	cached := NewClient(WithBaseURL(server.URL), WithToken(""), WithCache(dir, DefaultCacheTTL))
+	cached.now = func() time.Time { return now }
+	if _, err := cached.FetchRepoMetadata("matsengrp/netam"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("API calls = %d, want 2 (entry written by the fresh fetch)", calls)
+	}
+}
+
+func TestFetchRepoMetadata_NoCache(t *testing.T) {
+	var calls int
+	server := newCountingRepoServer(t, &calls)
+	dir := t.TempDir()
+
+	// A zero TTL disables the cache
+	client := NewClient(WithBaseURL(server.URL), WithToken(""), WithCache(dir, 0))
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchRepoMetadata("matsengrp/netam"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("API calls = %d, want 2 without cache", calls)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("cache dir has %d entries, want none", len(entries))
+	}
+}
@@ -11,12 +11,38 @@ import (
 	"time"
 
 	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/logging"
 )
 
+// DefaultBaseURL is the GitHub REST API root.
+const DefaultBaseURL = "https://api.github.com"
+
 // Client is a GitHub API client for fetching repository metadata.
 type Client struct {
 	httpClient *http.Client
 	token      string
+	baseURL    string
+	cacheDir   string        // Repo metadata cache; "" disables it
+	cacheTTL   time.Duration // Age after which cached metadata is refetched
+	cacheFresh bool          // Skip cached entries but still store fetches
+	now        func() time.Time
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithBaseURL sets the API root, e.g. to a test server.
+func WithBaseURL(url string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(url, "/")
+	}
+}
+
+// WithToken overrides the token read from the environment and config.
+func WithToken(token string) ClientOption {
+	return func(c *Client) {
+		c.token = token
+	}
 }
 
 // RepoMetadata contains metadata fetched from the GitHub API.
@@ -27,14 +53,19 @@ type RepoMetadata struct {
 	Language    string   `json:"language"`
 	Topics      []string `json:"topics"`
 	HTMLURL     string   `json:"html_url"`
+	Stars       int      `json:"stargazers_count"`
+	PushedAt    string   `json:"pushed_at"`
 	CreatedAt   string   `json:"created_at"`
 	UpdatedAt   string   `json:"updated_at"`
+	Archived    bool     `json:"archived"`
+	Fork        bool     `json:"fork"`
 }
 
 // Errors.
 var (
 	ErrInvalidURL   = errors.New("invalid GitHub URL format")
 	ErrRepoNotFound = errors.New("repository not found (404)")
+	ErrOrgNotFound  = errors.New("organization or user not found (404)")
 	ErrRateLimited  = errors.New("GitHub API rate limit exceeded")
 	ErrUnauthorized = errors.New("GitHub API authentication failed")
 	ErrAPIError     = errors.New("GitHub API error")
@@ -44,13 +75,17 @@ var (
 // NewClient creates a new GitHub API client.
 // The token is sourced from (in order): $BIP_GITHUB_TOKEN, $GITHUB_TOKEN,
 // $GH_TOKEN, then github_token in the global config file.
-func NewClient() *Client {
-	return &Client{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		token: config.GetGitHubToken(),
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		httpClient: logging.NewHTTPClient("github", 10*time.Second),
+		token:      config.GetGitHubToken(),
+		baseURL:    DefaultBaseURL,
+		now:        time.Now,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // urlPatterns for parsing GitHub URLs.
@@ -103,14 +138,36 @@ func DeriveRepoID(input string) (string, error) {
 }
 
 // FetchRepoMetadata fetches repository metadata from the GitHub API.
+// With WithCache, metadata fetched within the TTL is served from disk,
+// unless WithFreshFetch is also set.
 func (c *Client) FetchRepoMetadata(urlOrShorthand string) (*RepoMetadata, error) {
 	owner, repo, err := ParseGitHubURL(urlOrShorthand)
 	if err != nil {
 		return nil, err
 	}
+	if meta, ok := c.loadCachedMetadata(owner, repo); ok {
+		return meta, nil
+	}
+
+	resp, err := c.get(fmt.Sprintf("%s/repos/%s/%s", c.baseURL, owner, repo), ErrRepoNotFound)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var meta RepoMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return nil, fmt.Errorf("%w: decoding response: %v", ErrAPIError, err)
+	}
 
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
+	c.saveCachedMetadata(owner, repo, &meta)
+	return &meta, nil
+}
 
+// get issues an authenticated GET and maps error statuses to the package
+// errors, returning notFound for a 404. On success the caller closes the
+// response body.
+func (c *Client) get(apiURL string, notFound error) (*http.Response, error) {
 	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrNetworkError, err)
@@ -127,28 +184,22 @@ func (c *Client) FetchRepoMetadata(urlOrShorthand string) (*RepoMetadata, error)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrNetworkError, err)
 	}
-	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusOK:
-		// Success
+		return resp, nil
 	case http.StatusNotFound:
-		return nil, ErrRepoNotFound
+		err = notFound
 	case http.StatusUnauthorized, http.StatusForbidden:
+		err = ErrUnauthorized
 		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
-			return nil, ErrRateLimited
+			err = ErrRateLimited
 		}
-		return nil, ErrUnauthorized
 	case http.StatusTooManyRequests:
-		return nil, ErrRateLimited
+		err = ErrRateLimited
 	default:
-		return nil, fmt.Errorf("%w: status %d", ErrAPIError, resp.StatusCode)
+		err = fmt.Errorf("%w: status %d", ErrAPIError, resp.StatusCode)
 	}
-
-	var meta RepoMetadata
-	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
-		return nil, fmt.Errorf("%w: decoding response: %v", ErrAPIError, err)
-	}
-
-	return &meta, nil
+	resp.Body.Close()
+	return nil, err
 }
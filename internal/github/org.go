@@ -0,0 +1,107 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// orgReposPerPage is the page size requested when listing repos; 100 is
+// the API maximum.
+const orgReposPerPage = 100
+
+// maxOrgRepoPages bounds pagination so a malformed Link header cannot loop.
+const maxOrgRepoPages = 100
+
+// ownerPattern matches a GitHub organization or user login.
+var ownerPattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// linkNextPattern extracts the rel="next" URL from a Link header.
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// ListOrgReposOptions filters the repos returned by ListOrgRepos.
+// Zero values disable each filter.
+type ListOrgReposOptions struct {
+	Topic           string // Keep repos with this topic (case-insensitive)
+	Language        string // Keep repos with this primary language (case-insensitive)
+	ExcludeArchived bool   // Drop archived repos
+	ExcludeForks    bool   // Drop forks
+}
+
+// ListOrgRepos lists the repositories of a GitHub organization, following
+// pagination, and filters them by opts. If org is not an organization it
+// is tried as a user account. Returns ErrOrgNotFound if neither exists.
+func (c *Client) ListOrgRepos(org string, opts ListOrgReposOptions) ([]RepoMetadata, error) {
+	if !ownerPattern.MatchString(org) {
+		return nil, fmt.Errorf("%w: %q is not an organization or user name", ErrInvalidURL, org)
+	}
+
+	all, err := c.listRepoPages(fmt.Sprintf("%s/orgs/%s/repos?type=all&per_page=%d", c.baseURL, url.PathEscape(org), orgReposPerPage))
+	if errors.Is(err, ErrOrgNotFound) {
+		all, err = c.listRepoPages(fmt.Sprintf("%s/users/%s/repos?type=owner&per_page=%d", c.baseURL, url.PathEscape(org), orgReposPerPage))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []RepoMetadata
+	for _, r := range all {
+		if opts.matches(r) {
+			repos = append(repos, r)
+		}
+	}
+	return repos, nil
+}
+
+// listRepoPages fetches every page of a repo listing starting at apiURL.
+func (c *Client) listRepoPages(apiURL string) ([]RepoMetadata, error) {
+	var all []RepoMetadata
+	for page := 0; apiURL != "" && page < maxOrgRepoPages; page++ {
+		resp, err := c.get(apiURL, ErrOrgNotFound)
+		if err != nil {
+			return nil, err
+		}
+		var batch []RepoMetadata
+		err = json.NewDecoder(resp.Body).Decode(&batch)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%w: decoding response: %v", ErrAPIError, err)
+		}
+		all = append(all, batch...)
+		apiURL = nextPageURL(resp.Header.Get("Link"))
+	}
+	return all, nil
+}
+
+// nextPageURL returns the rel="next" URL of a Link header, or "".
+func nextPageURL(link string) string {
+	if m := linkNextPattern.FindStringSubmatch(link); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// matches reports whether r passes every filter in o.
+func (o ListOrgReposOptions) matches(r RepoMetadata) bool {
+	if o.ExcludeArchived && r.Archived {
+		return false
+	}
+	if o.ExcludeForks && r.Fork {
+		return false
+	}
+	if o.Language != "" && !strings.EqualFold(r.Language, o.Language) {
+		return false
+	}
+	if o.Topic != "" {
+		for _, t := range r.Topics {
+			if strings.EqualFold(t, o.Topic) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newOrgServer serves the two-page org repo fixture for matsengrp,
+// 404 for every other org, and a one-repo listing for the user "someone".
+func newOrgServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	page1, err := os.ReadFile(filepath.Join("testdata", "org_repos_page1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page2, err := os.ReadFile(filepath.Join("testdata", "org_repos_page2.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/orgs/matsengrp/repos" && r.URL.Query().Get("page") == "":
+			w.Header().Set("Link", `<`+server.URL+`/orgs/matsengrp/repos?page=2>; rel="next", <`+server.URL+`/orgs/matsengrp/repos?page=2>; rel="last"`)
+			_, _ = w.Write(page1)
+		case r.URL.Path == "/orgs/matsengrp/repos":
+			_, _ = w.Write(page2)
+		case r.URL.Path == "/users/someone/repos":
+			_, _ = w.Write([]byte(`[{"name": "dotfiles", "full_name": "someone/dotfiles"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestListOrgRepos_Paginates(t *testing.T) {
+	client := NewClient(WithBaseURL(newOrgServer(t).URL), WithToken(""))
+
+	repos, err := client.ListOrgRepos("matsengrp", ListOrgReposOptions{})
+	if err != nil {
+		t.Fatalf("ListOrgRepos() error = %v", err)
+	}
+	var names []string
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	want := []string{"netam", "old-pipeline", "bito", "pytorch"}
+	if len(names) != len(want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("names[%d] = %q, want %q", i, names[i], want[i])
+		}
+	}
+	if !repos[1].Archived || !repos[3].Fork {
+		t.Errorf("archived/fork flags not decoded: %+v", repos)
+	}
+}
+
+func TestListOrgRepos_Filters(t *testing.T) {
+	client := NewClient(WithBaseURL(newOrgServer(t).URL), WithToken(""))
+
+	tests := []struct {
+		name string
+		opts ListOrgReposOptions
+		want []string
+	}{
+		{"topic", ListOrgReposOptions{Topic: "Antibodies"}, []string{"netam", "old-pipeline"}},
+		{"language", ListOrgReposOptions{Language: "python"}, []string{"netam", "old-pipeline", "pytorch"}},
+		{"exclude archived and forks", ListOrgReposOptions{Language: "Python", ExcludeArchived: true, ExcludeForks: true}, []string{"netam"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, err := client.ListOrgRepos("matsengrp", tt.opts)
+			if err != nil {
+				t.Fatalf("ListOrgRepos() error = %v", err)
+			}
+			if len(repos) != len(tt.want) {
+				t.Fatalf("got %d repos, want %v", len(repos), tt.want)
+			}
+			for i, r := range repos {
+				if r.Name != tt.want[i] {
+					t.Errorf("repos[%d] = %q, want %q", i, r.Name, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestListOrgRepos_UserFallbackAndNotFound(t *testing.T) {
+	client := NewClient(WithBaseURL(newOrgServer(t).URL), WithToken(""))
+
+	repos, err := client.ListOrgRepos("someone", ListOrgReposOptions{})
+	if err != nil {
+		t.Fatalf("ListOrgRepos(user) error = %v", err)
+	}
+	if len(repos) != 1 || repos[0].FullName != "someone/dotfiles" {
+		t.Errorf("user repos = %+v, want someone/dotfiles", repos)
+	}
+
+	if _, err := client.ListOrgRepos("nobody", ListOrgReposOptions{}); !errors.Is(err, ErrOrgNotFound) {
+		t.Errorf("ListOrgRepos(nobody) error = %v, want ErrOrgNotFound", err)
+	}
+	if _, err := client.ListOrgRepos("bad/name", ListOrgReposOptions{}); !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("ListOrgRepos(bad/name) error = %v, want ErrInvalidURL", err)
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	link := `<https://api.github.com/orgs/x/repos?page=3>; rel="next", <https://api.github.com/orgs/x/repos?page=5>; rel="last"`
+	if got := nextPageURL(link); got != "https://api.github.com/orgs/x/repos?page=3" {
+		t.Errorf("nextPageURL() = %q", got)
+	}
+	if got := nextPageURL(`<https://api.github.com/orgs/x/repos?page=1>; rel="prev"`); got != "" {
+		t.Errorf("nextPageURL() without next = %q, want empty", got)
+	}
+}
@@ -0,0 +1,118 @@
+// Package graph provides traversal algorithms over knowledge graph edges.
+package graph
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/matsen/bipartite/internal/edge"
+)
+
+// ErrNodeNotFound indicates the requested node has no edges in the graph.
+var ErrNodeNotFound = errors.New("node not found in edge graph")
+
+// Graph is an undirected adjacency view of the edge list.
+// Edge direction and relationship type are ignored: for discovery purposes a
+// paper that introduces a concept is as close to it as one that applies it.
+type Graph struct {
+	adj map[string][]string
+}
+
+// New builds an undirected graph from edges. Neighbor lists are deduplicated
+// and sorted so traversals are deterministic for a given input.
+func New(edges []edge.Edge) *Graph {
+	sets := make(map[string]map[string]bool)
+	link := func(a, b string) {
+		if sets[a] == nil {
+			sets[a] = make(map[string]bool)
+		}
+		sets[a][b] = true
+	}
+	for _, e := range edges {
+		if e.SourceID == e.TargetID {
+			continue
+		}
+		link(e.SourceID, e.TargetID)
+		link(e.TargetID, e.SourceID)
+	}
+
+	adj := make(map[string][]string, len(sets))
+	for id, set := range sets {
+		neighbors := make([]string, 0, len(set))
+		for n := range set {
+			neighbors = append(neighbors, n)
+		}
+		sort.Strings(neighbors)
+		adj[id] = neighbors
+	}
+	return &Graph{adj: adj}
+}
+
+// Has returns true if the node has at least one edge.
+func (g *Graph) Has(id string) bool {
+	return len(g.adj[id]) > 0
+}
+
+// Neighbors returns the sorted neighbors of a node.
+func (g *Graph) Neighbors(id string) []string {
+	return g.adj[id]
+}
+
+// ShortestPath returns a shortest path from one node to another, inclusive of
+// both endpoints, or nil if they are not connected. Ties are broken by
+// neighbor sort order, so the result is deterministic.
+func (g *Graph) ShortestPath(from, to string) []string {
+	if from == to {
+		return []string{from}
+	}
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, n := range g.adj[cur] {
+			if _, seen := prev[n]; seen {
+				continue
+			}
+			prev[n] = cur
+			if n == to {
+				return buildPath(prev, from, to)
+			}
+			queue = append(queue, n)
+		}
+	}
+	return nil
+}
+
+// shortestPathTree runs one breadth-first search from from and returns the
+// predecessor of every reachable node (from maps to ""). Paths read back
+// from it with buildPath match ShortestPath, which stops at its target.
+func (g *Graph) shortestPathTree(from string) map[string]string {
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, n := range g.adj[cur] {
+			if _, seen := prev[n]; seen {
+				continue
+			}
+			prev[n] = cur
+			queue = append(queue, n)
+		}
+	}
+	return prev
+}
+
+// buildPath walks predecessor links back from to and returns the forward path.
+func buildPath(prev map[string]string, from, to string) []string {
+	var path []string
+	for cur := to; cur != from; cur = prev[cur] {
+		path = append(path, cur)
+	}
+	path = append(path, from)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/edge"
+)
+
+// testEdges builds A→concept:x←B→concept:y←C, plus an isolated pair D→E.
+func testEdges() []edge.Edge {
+	return []edge.Edge{
+		{SourceID: "A", TargetID: "concept:x", RelationshipType: "introduces"},
+		{SourceID: "B", TargetID: "concept:x", RelationshipType: "applies"},
+		{SourceID: "B", TargetID: "concept:y", RelationshipType: "applies"},
+		{SourceID: "C", TargetID: "concept:y", RelationshipType: "models"},
+		{SourceID: "D", TargetID: "E", RelationshipType: "cites"},
+	}
+}
+
+func isPaper(id string) bool {
+	return !strings.HasPrefix(id, "concept:")
+}
+
+func TestNew_UndirectedAndSorted(t *testing.T) {
+	g := New(testEdges())
+	got := g.Neighbors("concept:x")
+	if !reflect.DeepEqual(got, []string{"A", "B"}) {
+		t.Errorf("Neighbors(concept:x) = %v, want [A B]", got)
+	}
+	if !g.Has("E") {
+		t.Error("target-only node should be in the graph")
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := New(testEdges())
+
+	got := g.ShortestPath("A", "C")
+	want := []string{"A", "concept:x", "B", "concept:y", "C"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ShortestPath(A, C) = %v, want %v", got, want)
+	}
+	if p := g.ShortestPath("A", "D"); p != nil {
+		t.Errorf("disconnected nodes should have nil path, got %v", p)
+	}
+}
+
+func TestRandomWalk(t *testing.T) {
+	g := New(testEdges())
+	opts := WalkOptions{Steps: 2000, RestartProb: 0.2, Seed: 42, Include: isPaper}
+
+	visits, err := g.RandomWalk("A", opts)
+	if err != nil {
+		t.Fatalf("RandomWalk() error = %v", err)
+	}
+
+	ids := make([]string, len(visits))
+	for i, v := range visits {
+		ids[i] = v.ID
+		if v.ID == "A" || !isPaper(v.ID) {
+			t.Errorf("unexpected node in results: %s", v.ID)
+		}
+	}
+	// B sits one concept away, C two; the unreachable pair never appears.
+	if !reflect.DeepEqual(ids, []string{"B", "C"}) {
+		t.Fatalf("visit order = %v, want [B C]", ids)
+	}
+	if visits[0].Count <= visits[1].Count {
+		t.Errorf("closer paper should be visited more: %+v", visits)
+	}
+	if len(visits[1].Path) != 5 {
+		t.Errorf("path to C = %v, want 5 nodes", visits[1].Path)
+	}
+	for _, v := range visits {
+		if want := g.ShortestPath("A", v.ID); !reflect.DeepEqual(v.Path, want) {
+			t.Errorf("path to %s = %v, want ShortestPath %v", v.ID, v.Path, want)
+		}
+	}
+
+	// Same seed, same result.
+	again, err := g.RandomWalk("A", opts)
+	if err != nil {
+		t.Fatalf("RandomWalk() error = %v", err)
+	}
+	if !reflect.DeepEqual(visits, again) {
+		t.Error("walk is not reproducible for a fixed seed")
+	}
+}
+
+func TestRandomWalk_Errors(t *testing.T) {
+	g := New(testEdges())
+
+	if _, err := g.RandomWalk("missing", WalkOptions{Steps: 10}); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("expected ErrNodeNotFound, got %v", err)
+	}
+	if _, err := g.RandomWalk("A", WalkOptions{Steps: 0}); err == nil {
+		t.Error("expected error for zero steps")
+	}
+	if _, err := g.RandomWalk("A", WalkOptions{Steps: 10, RestartProb: 1}); err == nil {
+		t.Error("expected error for restart probability of 1")
+	}
+}
@@ -0,0 +1,51 @@
+// Package httpapi holds the request plumbing shared by the metadata API
+// clients (Crossref, arXiv): a GET that returns the whole body, and the
+// clipped body text they report in API errors.
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorMessage is the number of body bytes kept in an error message.
+const maxErrorMessage = 200
+
+// Get sends a GET request for url with the given headers through hc and
+// returns the response status and body. Transport and body read failures
+// are wrapped in networkErr, the calling client's network error sentinel,
+// so callers can match them with errors.Is.
+func Get(ctx context.Context, hc *http.Client, url string, headers map[string]string, networkErr error) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("creating request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", networkErr, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: reading body: %v", networkErr, err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// ErrorMessage returns an error response body trimmed and clipped to a
+// short message, with an ellipsis suffix if it was cut.
+func ErrorMessage(body []byte) string {
+	s := strings.TrimSpace(string(body))
+	if len(s) <= maxErrorMessage {
+		return s
+	}
+	return s[:maxErrorMessage] + "..."
+}
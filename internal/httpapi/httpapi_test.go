@@ -0,0 +1,54 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var errTestNetwork = errors.New("network error")
+
+func TestGet(t *testing.T) {
+	var gotAccept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAccept = r.Header.Get("Accept")
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("body"))
+	}))
+	defer server.Close()
+
+	status, body, err := Get(context.Background(), server.Client(), server.URL, map[string]string{"Accept": "text/plain"}, errTestNetwork)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if status != http.StatusTeapot || string(body) != "body" {
+		t.Errorf("Get() = %d, %q; want %d, \"body\"", status, body, http.StatusTeapot)
+	}
+	if gotAccept != "text/plain" {
+		t.Errorf("Accept header = %q, want text/plain", gotAccept)
+	}
+}
+
+func TestGet_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	_, _, err := Get(context.Background(), http.DefaultClient, url, nil, errTestNetwork)
+	if !errors.Is(err, errTestNetwork) {
+		t.Errorf("Get() error = %v, want errTestNetwork", err)
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	if got := ErrorMessage([]byte("  short \n")); got != "short" {
+		t.Errorf("ErrorMessage(short) = %q, want \"short\"", got)
+	}
+	got := ErrorMessage([]byte(strings.Repeat("x", 300)))
+	if len(got) != maxErrorMessage+3 || !strings.HasSuffix(got, "...") {
+		t.Errorf("ErrorMessage(long) = %d bytes, want %d ending in ...", len(got), maxErrorMessage+3)
+	}
+}
@@ -0,0 +1,20 @@
+// Package logging configures the structured debug log enabled by --verbose
+// and provides an HTTP transport that logs external calls to it.
+package logging
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Setup installs the default slog logger, writing text records to w. With
+// verbose, debug records (external calls, rebuild timings) are written;
+// otherwise only warnings and errors are, which no code path emits today,
+// so default output is unchanged.
+func Setup(w io.Writer, verbose bool) {
+	level := slog.LevelWarn
+	if verbose {
+		level = slog.LevelDebug
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})))
+}
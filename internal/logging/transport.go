@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redactedParams are query parameters whose values are replaced in logged
+// URLs. Credentials are sent in headers, which are never logged; this
+// guards against an API that takes them in the query string.
+var redactedParams = []string{"key", "token", "secret", "password", "signature"}
+
+// Transport is an http.RoundTripper that logs each request's method, URL,
+// status, and duration at debug level. Headers and bodies are not logged.
+type Transport struct {
+	Service string            // Logged as "service", e.g. "github"
+	Base    http.RoundTripper // Defaults to http.DefaultTransport
+}
+
+// NewHTTPClient returns an *http.Client with timeout whose requests are
+// logged under service.
+func NewHTTPClient(service string, timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: &Transport{Service: service}}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if !slog.Default().Enabled(req.Context(), slog.LevelDebug) {
+		return base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	attrs := []any{
+		"service", t.Service,
+		"method", req.Method,
+		"url", RedactURL(req.URL),
+		"duration", time.Since(start).Round(time.Millisecond),
+	}
+	if err != nil {
+		slog.Debug("http request failed", append(attrs, "error", err)...)
+		return nil, err
+	}
+	slog.Debug("http request", append(attrs, "status", resp.StatusCode)...)
+	return resp, nil
+}
+
+// RedactURL renders u without user info and with the values of
+// credential-like query parameters replaced by "REDACTED".
+func RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	redacted := *u
+	redacted.User = nil
+	if redacted.RawQuery != "" {
+		q := redacted.Query()
+		for name := range q {
+			if isSecretParam(name) {
+				q.Set(name, "REDACTED")
+			}
+		}
+		redacted.RawQuery = q.Encode()
+	}
+	return redacted.String()
+}
+
+// isSecretParam reports whether a query parameter name looks like it
+// carries a credential, e.g. "api_key" or "access_token".
+func isSecretParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range redactedParams {
+		if strings.HasSuffix(name, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/matsen/bipartite/internal/logging"
 	"golang.org/x/time/rate"
 )
 
@@ -52,11 +53,13 @@ type Input struct {
 	ID   string
 }
 
-// Client is a rate-limited HTTP client for the NCBI ID Converter.
+// Client is a rate-limited HTTP client for the NCBI ID Converter and
+// PubMed efetch.
 type Client struct {
 	httpClient *http.Client
 	limiter    *rate.Limiter
 	baseURL    string
+	efetchURL  string
 	tool       string
 	email      string
 }
@@ -96,9 +99,10 @@ func WithEmail(email string) ClientOption {
 // NewClient creates a new NCBI ID Converter client.
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
-		httpClient: &http.Client{Timeout: DefaultTimeout},
+		httpClient: logging.NewHTTPClient("ncbi", DefaultTimeout),
 		limiter:    rate.NewLimiter(rate.Limit(RateLimit), 1),
 		baseURL:    BaseURL,
+		efetchURL:  EFetchURL,
 		tool:       DefaultTool,
 	}
 
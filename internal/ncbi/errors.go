@@ -17,6 +17,9 @@ var (
 
 	// ErrInvalidResponse indicates a malformed or unexpected response shape.
 	ErrInvalidResponse = errors.New("invalid response from NCBI")
+
+	// ErrNotFound indicates PubMed has no article for the requested PMID.
+	ErrNotFound = errors.New("not found in PubMed")
 )
 
 // APIError represents a request-wide error returned by the NCBI ID Converter
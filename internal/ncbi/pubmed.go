@@ -0,0 +1,125 @@
+package ncbi
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// EFetchURL is the PubMed E-utilities efetch endpoint.
+const EFetchURL = "https://eutils.ncbi.nlm.nih.gov/entrez/eutils/efetch.fcgi"
+
+// pmidPattern matches a PubMed ID.
+var pmidPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// markupTag matches the inline markup (<i>, <sup>, ...) inside AbstractText.
+var markupTag = regexp.MustCompile(`<[^>]+>`)
+
+// WithEFetchURL sets a custom efetch endpoint (for testing).
+func WithEFetchURL(u string) ClientOption {
+	return func(c *Client) {
+		c.efetchURL = u
+	}
+}
+
+// pubmedArticleSet is the subset of an efetch db=pubmed XML response that
+// carries the abstract.
+type pubmedArticleSet struct {
+	Articles []struct {
+		PMID     string         `xml:"MedlineCitation>PMID"`
+		Sections []abstractText `xml:"MedlineCitation>Article>Abstract>AbstractText"`
+	} `xml:"PubmedArticle"`
+}
+
+// abstractText is one (possibly labeled) section of a PubMed abstract.
+type abstractText struct {
+	Label string `xml:"Label,attr"`
+	Inner string `xml:",innerxml"`
+}
+
+// FetchAbstract fetches the PubMed abstract of pmid. Structured abstracts
+// are joined into one paragraph with each section prefixed by its label.
+// Returns "" with no error for an article without an abstract, and
+// ErrNotFound for a PMID PubMed does not know.
+func (c *Client) FetchAbstract(ctx context.Context, pmid string) (string, error) {
+	pmid = strings.TrimSpace(pmid)
+	if !pmidPattern.MatchString(pmid) {
+		return "", fmt.Errorf("invalid PMID %q", pmid)
+	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return "", fmt.Errorf("rate limiter: %w", err)
+	}
+
+	q := url.Values{}
+	q.Set("db", "pubmed")
+	q.Set("id", pmid)
+	q.Set("retmode", "xml")
+	if c.tool != "" {
+		q.Set("tool", c.tool)
+	}
+	if c.email != "" {
+		q.Set("email", c.email)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.efetchURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "application/xml")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrNetworkError, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: reading body: %v", ErrNetworkError, err)
+	}
+	if resp.StatusCode == 429 {
+		return "", fmt.Errorf("%w: HTTP %d (pmid: %s)", ErrRateLimited, resp.StatusCode, pmid)
+	}
+	if resp.StatusCode >= 400 {
+		return "", &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       "http_error",
+			Message:    fmt.Sprintf("HTTP %d: %s", resp.StatusCode, truncate(string(body), 200)),
+			BatchIDs:   []string{pmid},
+		}
+	}
+
+	var parsed pubmedArticleSet
+	if err := xml.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if len(parsed.Articles) == 0 {
+		return "", fmt.Errorf("%w: PMID %s", ErrNotFound, pmid)
+	}
+
+	var parts []string
+	for _, s := range parsed.Articles[0].Sections {
+		text := cleanAbstractText(s.Inner)
+		if text == "" {
+			continue
+		}
+		if s.Label != "" {
+			text = s.Label + ": " + text
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// cleanAbstractText strips inline markup, unescapes entities, and collapses
+// whitespace.
+func cleanAbstractText(s string) string {
+	s = markupTag.ReplaceAllString(s, "")
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
@@ -0,0 +1,59 @@
+package ncbi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFetchAbstract_Structured(t *testing.T) {
+	fs := newFixtureServer(t, 200, loadFixture(t, "pubmed_abstract.xml"))
+	client := NewClient(WithEFetchURL(fs.server.URL), WithEmail("me@example.com"))
+
+	got, err := client.FetchAbstract(context.Background(), "23903748")
+	if err != nil {
+		t.Fatalf("FetchAbstract: %v", err)
+	}
+	want := "BACKGROUND: Antibodies are produced by B cells & evolve. RESULTS: We model affinity maturation."
+	if got != want {
+		t.Errorf("FetchAbstract = %q, want %q", got, want)
+	}
+	if fs.lastQuery["db"][0] != "pubmed" || fs.lastQuery["id"][0] != "23903748" || fs.lastQuery["email"][0] != "me@example.com" {
+		t.Errorf("unexpected query: %v", fs.lastQuery)
+	}
+}
+
+func TestFetchAbstract_NotFoundAndNoAbstract(t *testing.T) {
+	fs := newFixtureServer(t, 200, []byte(`<?xml version="1.0" ?><PubmedArticleSet></PubmedArticleSet>`))
+	client := NewClient(WithEFetchURL(fs.server.URL))
+	if _, err := client.FetchAbstract(context.Background(), "1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("empty set error = %v, want ErrNotFound", err)
+	}
+
+	fs.body = []byte(`<PubmedArticleSet><PubmedArticle><MedlineCitation><PMID>2</PMID><Article></Article></MedlineCitation></PubmedArticle></PubmedArticleSet>`)
+	got, err := client.FetchAbstract(context.Background(), "2")
+	if err != nil || got != "" {
+		t.Errorf("no-abstract article = %q, %v; want empty, nil", got, err)
+	}
+
+	if _, err := client.FetchAbstract(context.Background(), "10.1038/x"); err == nil {
+		t.Error("expected error for a non-numeric PMID")
+	}
+	if fs.requests != 2 {
+		t.Errorf("requests = %d, want 2 (invalid PMID is not sent)", fs.requests)
+	}
+}
+
+func TestFetchAbstract_HTTPError(t *testing.T) {
+	fs := newFixtureServer(t, 429, nil)
+	client := NewClient(WithEFetchURL(fs.server.URL))
+	if _, err := client.FetchAbstract(context.Background(), "1"); !IsRateLimited(err) {
+		t.Errorf("429 error = %v, want rate limited", err)
+	}
+
+	fs.statusCode = 500
+	var apiErr *APIError
+	if _, err := client.FetchAbstract(context.Background(), "1"); !errors.As(err, &apiErr) || apiErr.StatusCode != 500 {
+		t.Errorf("500 error = %v, want *APIError with status 500", err)
+	}
+}
@@ -1,4 +1,5 @@
-// Package ncbi provides a client for the NCBI PMC ID Converter API.
+// Package ncbi provides a client for the NCBI PMC ID Converter API and
+// PubMed abstract lookup via E-utilities efetch.
 //
 // The NCBI ID Converter (https://pmc.ncbi.nlm.nih.gov/tools/id-converter-api/)
 // resolves between DOI, PMID, PMCID, and MID identifiers. We use it primarily
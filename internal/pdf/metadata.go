@@ -0,0 +1,80 @@
+package pdf
+
+import (
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Info holds the document metadata stored in a PDF's Info dictionary.
+// Any field may be empty; many PDFs carry no metadata or only a filename
+// as the title.
+type Info struct {
+	Title    string `json:"title,omitempty"`
+	Author   string `json:"author,omitempty"`
+	Subject  string `json:"subject,omitempty"`
+	Keywords string `json:"keywords,omitempty"`
+}
+
+// ExtractInfo reads the Info dictionary from a PDF file.
+// Returns an empty Info (not an error) if the PDF has no Info dictionary.
+func ExtractInfo(filePath string) (Info, error) {
+	f, r, err := pdf.Open(filePath)
+	if err != nil {
+		return Info{}, err
+	}
+	defer f.Close()
+
+	dict := r.Trailer().Key("Info")
+	if dict.IsNull() {
+		return Info{}, nil
+	}
+
+	return Info{
+		Title:    cleanInfoValue(dict.Key("Title").Text()),
+		Author:   cleanInfoValue(dict.Key("Author").Text()),
+		Subject:  cleanInfoValue(dict.Key("Subject").Text()),
+		Keywords: cleanInfoValue(dict.Key("Keywords").Text()),
+	}, nil
+}
+
+// DOI returns a DOI found in the metadata fields, or "" if none.
+// Publishers often put "doi:10.xxxx/..." in the Subject or Keywords.
+func (i Info) DOI() string {
+	return findDOI(strings.Join([]string{i.Subject, i.Keywords, i.Title}, "\n"))
+}
+
+// UsableTitle returns the metadata title if it looks like a real paper title,
+// or "" if it is missing or is a placeholder left by authoring tools
+// (e.g. "Microsoft Word - draft3.docx" or "untitled").
+func (i Info) UsableTitle() string {
+	title := i.Title
+	lower := strings.ToLower(title)
+	if len(title) < MinInfoTitleLength {
+		return ""
+	}
+	for _, prefix := range placeholderTitlePrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return ""
+		}
+	}
+	for _, ext := range []string{".pdf", ".doc", ".docx", ".tex", ".dvi"} {
+		if strings.HasSuffix(lower, ext) {
+			return ""
+		}
+	}
+	return title
+}
+
+// MinInfoTitleLength is the shortest metadata title treated as a real title.
+// Matches the >20 character threshold used by ExtractTitle.
+const MinInfoTitleLength = 21
+
+// placeholderTitlePrefixes are lowercase title prefixes written by authoring
+// tools rather than by the paper's authors.
+var placeholderTitlePrefixes = []string{"microsoft word", "untitled", "manuscript", "arxiv:"}
+
+// cleanInfoValue trims whitespace and NUL padding from an Info string.
+func cleanInfoValue(s string) string {
+	return strings.TrimSpace(strings.Trim(s, "\x00"))
+}
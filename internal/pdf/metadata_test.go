@@ -0,0 +1,98 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeInfoPDF writes a minimal one-page PDF whose trailer references the
+// given Info dictionary body (e.g. "/Title (Foo)"), or has no Info if empty.
+func writeInfoPDF(t *testing.T, info string) string {
+	t.Helper()
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+	}
+	if info != "" {
+		objects = append(objects, "<< "+info+" >>")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	trailer := fmt.Sprintf("/Size %d /Root 1 0 R", len(objects)+1)
+	if info != "" {
+		trailer += fmt.Sprintf(" /Info %d 0 R", len(objects))
+	}
+	fmt.Fprintf(&buf, "trailer\n<< %s >>\nstartxref\n%d\n%%%%EOF\n", trailer, xref)
+
+	path := filepath.Join(t.TempDir(), "test.pdf")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("writing test PDF: %v", err)
+	}
+	return path
+}
+
+func TestExtractInfo(t *testing.T) {
+	path := writeInfoPDF(t, "/Title (Phylogenetic inference for everyone) /Author (Ada Lovelace) /Subject (doi:10.1093/sysbio/syy032)")
+
+	info, err := ExtractInfo(path)
+	if err != nil {
+		t.Fatalf("ExtractInfo() error = %v", err)
+	}
+	if info.Title != "Phylogenetic inference for everyone" {
+		t.Errorf("Title = %q", info.Title)
+	}
+	if info.Author != "Ada Lovelace" {
+		t.Errorf("Author = %q", info.Author)
+	}
+	if got := info.DOI(); got != "10.1093/sysbio/syy032" {
+		t.Errorf("DOI() = %q, want 10.1093/sysbio/syy032", got)
+	}
+}
+
+func TestExtractInfo_NoInfoDictionary(t *testing.T) {
+	path := writeInfoPDF(t, "")
+
+	info, err := ExtractInfo(path)
+	if err != nil {
+		t.Fatalf("ExtractInfo() error = %v", err)
+	}
+	if info != (Info{}) {
+		t.Errorf("ExtractInfo() = %+v, want empty", info)
+	}
+}
+
+func TestInfoUsableTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{"Phylogenetic inference for everyone", true},
+		{"short title", false},
+		{"Microsoft Word - final_draft_v3.docx", false},
+		{"Untitled document from the scanner", false},
+		{"smith_2024_supplementary_material.pdf", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		got := Info{Title: tt.title}.UsableTitle()
+		if (got != "") != tt.want {
+			t.Errorf("UsableTitle(%q) = %q, want usable=%v", tt.title, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package reference
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CiteKey generates a citation key from the first author's last name, the
+// publication year, and the title: LastName + Year + "-" + a two-letter
+// suffix from the title's first significant words (e.g. "Zhang2018-vi").
+// An empty last name becomes "Unknown" and a zero year 9999.
+// Note: Not guaranteed globally unique - callers should use
+// storage.GenerateUniqueID() to handle collisions before persisting.
+func CiteKey(lastName string, year int, title string) string {
+	name := sanitizeForCiteKey(lastName)
+	if name == "" {
+		name = "Unknown"
+	}
+	if year == 0 {
+		year = 9999
+	}
+	return fmt.Sprintf("%s%d-%s", name, year, generateTitleSuffix(title))
+}
+
+// sanitizeForCiteKey removes non-alphanumeric characters.
+func sanitizeForCiteKey(s string) string {
+	var result strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
+
+// generateTitleSuffix creates a 2-letter suffix from the title.
+func generateTitleSuffix(title string) string {
+	// Get first letters of first few significant words
+	words := strings.Fields(strings.ToLower(title))
+	stopWords := map[string]bool{"a": true, "an": true, "the": true, "of": true, "and": true, "in": true, "on": true, "for": true, "to": true, "with": true}
+
+	var suffix strings.Builder
+	for _, word := range words {
+		if !stopWords[word] && len(word) > 0 {
+			suffix.WriteByte(word[0])
+			if suffix.Len() >= 2 {
+				break
+			}
+		}
+	}
+
+	// Pad if needed
+	for suffix.Len() < 2 {
+		suffix.WriteByte('x')
+	}
+
+	return suffix.String()
+}
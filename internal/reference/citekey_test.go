@@ -0,0 +1,68 @@
+package reference
+
+import "testing"
+
+func TestCiteKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		last  string
+		year  int
+		title string
+		want  string
+	}{
+		{"basic", "Zhang", 2018, "Variational Inference", "Zhang2018-vi"},
+		{"sanitized name", "O'Brien", 2020, "The Origin of Species", "OBrien2020-os"},
+		{"missing author and year", "", 0, "Phylogenetics", "Unknown9999-px"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CiteKey(tt.last, tt.year, tt.title); got != tt.want {
+				t.Errorf("CiteKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeForCiteKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Smith", "Smith"},
+		{"O'Brien", "OBrien"},
+		{"van der Waals", "vanderWaals"},
+		{"Smith-Jones", "SmithJones"},
+		{"José", "José"},
+		{"Author 3rd", "Author3rd"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := sanitizeForCiteKey(tt.input); got != tt.want {
+				t.Errorf("sanitizeForCiteKey(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateTitleSuffix(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{"two significant words", "Variational Inference", "vi"},
+		{"stop words skipped", "The Origin of Species", "os"},
+		{"single significant word padded", "Phylogenetics", "px"},
+		{"empty padded", "", "xx"},
+		{"all stop words padded", "the of and", "xx"},
+		{"leading stop word", "A Neural Network", "nn"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := generateTitleSuffix(tt.title); got != tt.want {
+				t.Errorf("generateTitleSuffix(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
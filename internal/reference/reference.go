@@ -32,7 +32,7 @@ type Reference struct {
 	Tags []string `json:"tags,omitempty"`
 
 	// Relationships
-	Supersedes string `json:"supersedes,omitempty"` // DOI of paper this replaces
+	Supersedes string `json:"supersedes,omitempty"` // ID (or, in older records, DOI) of paper this replaces
 
 	// External Identifiers (typically populated from Semantic Scholar API)
 	PMID    string `json:"pmid,omitempty"`
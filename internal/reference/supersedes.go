@@ -0,0 +1,91 @@
+package reference
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Supersedes-chain errors.
+var (
+	ErrSupersedesSelf  = errors.New("a paper cannot supersede itself")
+	ErrSupersedesCycle = errors.New("would create a cycle in the supersedes chain")
+)
+
+// ResolveSupersedes returns the index in refs of the paper a Supersedes
+// value points to, or -1. The value is matched as an ID first, then as a
+// DOI, since older records store the replaced paper's DOI.
+func ResolveSupersedes(refs []Reference, value string) int {
+	if value == "" {
+		return -1
+	}
+	for i, r := range refs {
+		if r.ID == value {
+			return i
+		}
+	}
+	for i, r := range refs {
+		if r.DOI == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// SupersededBy returns the ID of the paper whose Supersedes points to
+// refs[idx], or "" if none does.
+func SupersededBy(refs []Reference, idx int) string {
+	for i, r := range refs {
+		if i != idx && r.Supersedes != "" && ResolveSupersedes(refs, r.Supersedes) == idx {
+			return r.ID
+		}
+	}
+	return ""
+}
+
+// SupersedesChain walks forward from refs[idx] to the papers that replace
+// it, returning their IDs from the direct successor to the latest version.
+// A cycle in hand-edited data ends the walk rather than looping.
+func SupersedesChain(refs []Reference, idx int) []string {
+	var chain []string
+	seen := map[int]bool{idx: true}
+	for {
+		next := SupersededBy(refs, idx)
+		if next == "" {
+			return chain
+		}
+		idx = ResolveSupersedes(refs, next)
+		if seen[idx] {
+			return chain
+		}
+		seen[idx] = true
+		chain = append(chain, next)
+	}
+}
+
+// CheckSupersede validates making refs[newIdx] supersede refs[oldIdx].
+// It rejects self-links, a new paper that already supersedes a different
+// one, an old paper already superseded by a different one, and links that
+// would close a cycle.
+func CheckSupersede(refs []Reference, oldIdx, newIdx int) error {
+	if oldIdx == newIdx {
+		return ErrSupersedesSelf
+	}
+	oldRef, newRef := refs[oldIdx], refs[newIdx]
+
+	if current := ResolveSupersedes(refs, newRef.Supersedes); newRef.Supersedes != "" && current != oldIdx {
+		return fmt.Errorf("%s already supersedes %s", newRef.ID, newRef.Supersedes)
+	}
+	if by := SupersededBy(refs, oldIdx); by != "" && by != newRef.ID {
+		return fmt.Errorf("%s is already superseded by %s", oldRef.ID, by)
+	}
+
+	// Walking back from old must not reach new
+	seen := map[int]bool{}
+	for idx := oldIdx; idx >= 0 && !seen[idx]; idx = ResolveSupersedes(refs, refs[idx].Supersedes) {
+		if idx == newIdx {
+			return ErrSupersedesCycle
+		}
+		seen[idx] = true
+	}
+	return nil
+}
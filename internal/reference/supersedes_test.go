@@ -0,0 +1,75 @@
+package reference
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSupersedesChain(t *testing.T) {
+	refs := []Reference{
+		{ID: "Preprint", DOI: "10.1101/pre"},
+		{ID: "Journal", Supersedes: "Preprint"},
+		{ID: "Erratum", Supersedes: "Journal"},
+		{ID: "Other"},
+		{ID: "ByDOI", Supersedes: "10.1101/other"},
+		{ID: "OtherPre", DOI: "10.1101/other"},
+	}
+
+	if got := SupersedesChain(refs, 0); !reflect.DeepEqual(got, []string{"Journal", "Erratum"}) {
+		t.Errorf("SupersedesChain(Preprint) = %v", got)
+	}
+	if got := SupersedesChain(refs, 2); len(got) != 0 {
+		t.Errorf("SupersedesChain(Erratum) = %v, want empty", got)
+	}
+	if got := SupersededBy(refs, 5); got != "ByDOI" {
+		t.Errorf("SupersededBy(OtherPre) = %q, want ByDOI (DOI-valued supersedes)", got)
+	}
+	if got := ResolveSupersedes(refs, "missing"); got != -1 {
+		t.Errorf("ResolveSupersedes(missing) = %d, want -1", got)
+	}
+}
+
+func TestSupersedesChain_Cycle(t *testing.T) {
+	refs := []Reference{
+		{ID: "A", Supersedes: "B"},
+		{ID: "B", Supersedes: "A"},
+	}
+	if got := SupersedesChain(refs, 0); !reflect.DeepEqual(got, []string{"B"}) {
+		t.Errorf("SupersedesChain() on cycle = %v, want [B]", got)
+	}
+}
+
+func TestCheckSupersede(t *testing.T) {
+	refs := []Reference{
+		{ID: "A"},
+		{ID: "B", Supersedes: "A"},
+		{ID: "C", Supersedes: "B"},
+		{ID: "D"},
+	}
+
+	tests := []struct {
+		name     string
+		old, new int
+		wantErr  error
+		wantAny  bool
+	}{
+		{"new link", 3, 0, nil, false},
+		{"existing link is idempotent", 0, 1, nil, false},
+		{"self", 0, 0, ErrSupersedesSelf, true},
+		{"cycle", 2, 0, ErrSupersedesCycle, true},
+		{"old already superseded", 0, 3, nil, true},
+		{"new already supersedes another", 3, 1, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSupersede(refs, tt.old, tt.new)
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckSupersede() = %v, want %v", err, tt.wantErr)
+			}
+			if (err != nil) != tt.wantAny {
+				t.Errorf("CheckSupersede() error = %v, want error: %v", err, tt.wantAny)
+			}
+		})
+	}
+}
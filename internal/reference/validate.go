@@ -0,0 +1,46 @@
+package reference
+
+import (
+	"errors"
+	"strings"
+)
+
+// Validation errors.
+var (
+	ErrEmptyID           = errors.New("id is required")
+	ErrEmptyTitle        = errors.New("title is required")
+	ErrAuthorMissingLast = errors.New("every author needs a last name")
+	ErrInvalidDOI        = errors.New(`doi must start with "10."`)
+	ErrInvalidYear       = errors.New("year must not be negative")
+	ErrInvalidMonth      = errors.New("month must be between 1 and 12")
+	ErrInvalidDay        = errors.New("day must be between 1 and 31")
+)
+
+// Validate checks the fields a reference needs before it is stored.
+// Optional fields (DOI, month, day) are only checked when set.
+func (r *Reference) Validate() error {
+	if strings.TrimSpace(r.ID) == "" {
+		return ErrEmptyID
+	}
+	if strings.TrimSpace(r.Title) == "" {
+		return ErrEmptyTitle
+	}
+	for _, a := range r.Authors {
+		if strings.TrimSpace(a.Last) == "" {
+			return ErrAuthorMissingLast
+		}
+	}
+	if r.DOI != "" && !strings.HasPrefix(r.DOI, "10.") {
+		return ErrInvalidDOI
+	}
+	if r.Published.Year < 0 {
+		return ErrInvalidYear
+	}
+	if r.Published.Month < 0 || r.Published.Month > 12 {
+		return ErrInvalidMonth
+	}
+	if r.Published.Day < 0 || r.Published.Day > 31 {
+		return ErrInvalidDay
+	}
+	return nil
+}
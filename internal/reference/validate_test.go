@@ -0,0 +1,38 @@
+package reference
+
+import "testing"
+
+func TestReference_Validate(t *testing.T) {
+	valid := Reference{
+		ID:        "Smith2024-ab",
+		Title:     "A Paper",
+		Authors:   []Author{{First: "John", Last: "Smith"}},
+		DOI:       "10.1234/x",
+		Published: PublicationDate{Year: 2024, Month: 3, Day: 15},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *Reference)
+		want   error
+	}{
+		{"valid", func(r *Reference) {}, nil},
+		{"minimal", func(r *Reference) { r.Authors, r.DOI, r.Published = nil, "", PublicationDate{} }, nil},
+		{"empty id", func(r *Reference) { r.ID = "" }, ErrEmptyID},
+		{"blank title", func(r *Reference) { r.Title = "  " }, ErrEmptyTitle},
+		{"author without last name", func(r *Reference) { r.Authors = []Author{{First: "John"}} }, ErrAuthorMissingLast},
+		{"bad doi", func(r *Reference) { r.DOI = "doi.org/10.1234/x" }, ErrInvalidDOI},
+		{"negative year", func(r *Reference) { r.Published.Year = -1 }, ErrInvalidYear},
+		{"bad month", func(r *Reference) { r.Published.Month = 13 }, ErrInvalidMonth},
+		{"bad day", func(r *Reference) { r.Published.Day = 32 }, ErrInvalidDay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			tt.modify(&r)
+			if got := r.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,16 +8,18 @@ import (
 
 // Repo represents a GitHub repository belonging to a project.
 type Repo struct {
-	ID          string   `json:"id"`                    // Required: unique identifier
-	Project     string   `json:"project"`               // Required: project ID this repo belongs to
-	Type        string   `json:"type"`                  // Required: "github" or "manual"
-	Name        string   `json:"name"`                  // Required: display name
-	GitHubURL   string   `json:"github_url,omitempty"`  // Required if type=github
-	Description string   `json:"description,omitempty"` // From GitHub or user-provided
-	Topics      []string `json:"topics,omitempty"`      // From GitHub or user-provided
-	Language    string   `json:"language,omitempty"`    // From GitHub
-	CreatedAt   string   `json:"created_at,omitempty"`  // RFC3339, auto-set
-	UpdatedAt   string   `json:"updated_at,omitempty"`  // RFC3339, auto-set
+	ID           string   `json:"id"`                       // Required: unique identifier
+	Project      string   `json:"project"`                  // Required: project ID this repo belongs to
+	Type         string   `json:"type"`                     // Required: "github" or "manual"
+	Name         string   `json:"name"`                     // Required: display name
+	GitHubURL    string   `json:"github_url,omitempty"`     // Required if type=github
+	Description  string   `json:"description,omitempty"`    // From GitHub or user-provided
+	Topics       []string `json:"topics,omitempty"`         // From GitHub or user-provided
+	Language     string   `json:"language,omitempty"`       // From GitHub
+	Stars        *int     `json:"stars,omitempty"`          // From GitHub, as of the last add/refresh; nil if never fetched
+	LastPushedAt string   `json:"last_pushed_at,omitempty"` // RFC3339, from GitHub
+	CreatedAt    string   `json:"created_at,omitempty"`     // RFC3339, auto-set
+	UpdatedAt    string   `json:"updated_at,omitempty"`     // RFC3339, auto-set
 }
 
 // RepoType constants.
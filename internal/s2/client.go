@@ -1,6 +1,7 @@
 package s2
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/matsen/bipartite/internal/config"
+	"github.com/matsen/bipartite/internal/logging"
 	"golang.org/x/time/rate"
 )
 
@@ -30,6 +32,9 @@ const (
 
 	// AuthenticatedRateLimit is the rate limit with an S2 API key (1 req/sec).
 	AuthenticatedRateLimit = 1.0
+
+	// MaxBatchSize is the maximum number of IDs the batch endpoint accepts per request.
+	MaxBatchSize = 500
 )
 
 // Client is a rate-limited HTTP client for the Semantic Scholar API.
@@ -67,7 +72,7 @@ func WithBaseURL(url string) ClientOption {
 // NewClient creates a new Semantic Scholar API client.
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
-		httpClient: &http.Client{Timeout: DefaultTimeout},
+		httpClient: logging.NewHTTPClient("s2", DefaultTimeout),
 		baseURL:    BaseURL,
 	}
 
@@ -204,6 +209,73 @@ func (c *Client) GetReferences(ctx context.Context, paperID string, limit int) (
 	return &result, nil
 }
 
+// GetReferencesBatch fetches the reference lists of up to MaxBatchSize papers
+// in a single request. The result is aligned with paperIDs; entries are nil
+// for IDs that Semantic Scholar doesn't know.
+func (c *Client) GetReferencesBatch(ctx context.Context, paperIDs []string) ([]*PaperReferences, error) {
+	var result []*PaperReferences
+	if err := c.postPaperBatch(ctx, paperIDs, "paperId,externalIds,references.paperId,references.externalIds", &result); err != nil {
+		return nil, err
+	}
+	if len(result) != len(paperIDs) {
+		return nil, fmt.Errorf("batch response has %d entries for %d IDs", len(result), len(paperIDs))
+	}
+	return result, nil
+}
+
+// GetPapersBatch fetches the paper ID and external IDs of up to MaxBatchSize
+// papers in a single request. The result is aligned with paperIDs; entries
+// are nil for IDs that Semantic Scholar doesn't know.
+func (c *Client) GetPapersBatch(ctx context.Context, paperIDs []string) ([]*S2Paper, error) {
+	var result []*S2Paper
+	if err := c.postPaperBatch(ctx, paperIDs, "paperId,externalIds", &result); err != nil {
+		return nil, err
+	}
+	if len(result) != len(paperIDs) {
+		return nil, fmt.Errorf("batch response has %d entries for %d IDs", len(result), len(paperIDs))
+	}
+	return result, nil
+}
+
+// postPaperBatch POSTs paperIDs to the batch endpoint requesting fields and
+// decodes the JSON array response into out.
+func (c *Client) postPaperBatch(ctx context.Context, paperIDs []string, fields string, out any) error {
+	if len(paperIDs) > MaxBatchSize {
+		return fmt.Errorf("batch of %d IDs exceeds maximum of %d", len(paperIDs), MaxBatchSize)
+	}
+
+	body, err := json.Marshal(PaperBatchRequest{IDs: paperIDs})
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/paper/batch", c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	q := req.URL.Query()
+	q.Set("fields", fields)
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
 // SearchByTitle searches for papers by title.
 func (c *Client) SearchByTitle(ctx context.Context, title string, limit int) (*SearchResponse, error) {
 	if limit <= 0 {
@@ -2,6 +2,7 @@ package s2
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
@@ -136,3 +137,82 @@ func TestGetPaperNotFound(t *testing.T) {
 		t.Errorf("GetPaper: IsNotFound = false, want true (err=%v)", err)
 	}
 }
+
+func TestGetReferencesBatch(t *testing.T) {
+	const body = `[
+		{"paperId": "a1", "externalIds": {"DOI": "10.1/a"}, "references": [
+			{"paperId": "b1", "externalIds": {"DOI": "10.1/b"}},
+			{"paperId": null}
+		]},
+		null
+	]`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/paper/batch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req PaperBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		if len(req.IDs) != 2 {
+			t.Errorf("request IDs = %v, want 2 entries", req.IDs)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	papers, err := c.GetReferencesBatch(context.Background(), []string{"DOI:10.1/a", "DOI:10.1/missing"})
+	if err != nil {
+		t.Fatalf("GetReferencesBatch: %v", err)
+	}
+	if len(papers) != 2 {
+		t.Fatalf("got %d results, want 2", len(papers))
+	}
+	if papers[0] == nil || len(papers[0].References) != 2 {
+		t.Fatalf("papers[0] = %+v, want 2 references", papers[0])
+	}
+	if papers[0].References[0].ExternalIDs.DOI != "10.1/b" {
+		t.Errorf("reference DOI = %q, want 10.1/b", papers[0].References[0].ExternalIDs.DOI)
+	}
+	if papers[1] != nil {
+		t.Errorf("papers[1] = %+v, want nil for unknown ID", papers[1])
+	}
+}
+
+func TestGetReferencesBatchTooLarge(t *testing.T) {
+	c := NewClient(WithBaseURL("http://unused"))
+	if _, err := c.GetReferencesBatch(context.Background(), make([]string, MaxBatchSize+1)); err == nil {
+		t.Error("expected error for oversized batch")
+	}
+}
+
+func TestGetPapersBatch(t *testing.T) {
+	const body = `[
+		{"paperId": "a1", "externalIds": {"DOI": "10.1/a", "PubMed": "123", "PubMedCentral": "456", "ArXiv": "2101.00001"}},
+		null
+	]`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fields"); got != "paperId,externalIds" {
+			t.Errorf("fields = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	papers, err := c.GetPapersBatch(context.Background(), []string{"DOI:10.1/a", "DOI:10.1/missing"})
+	if err != nil {
+		t.Fatalf("GetPapersBatch: %v", err)
+	}
+	if len(papers) != 2 || papers[1] != nil {
+		t.Fatalf("papers = %+v, want 2 entries with nil second", papers)
+	}
+	if papers[0].ExternalIDs.PubMed != "123" || papers[0].ExternalIDs.ArXiv != "2101.00001" {
+		t.Errorf("ExternalIDs = %+v", papers[0].ExternalIDs)
+	}
+}
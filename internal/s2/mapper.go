@@ -1,10 +1,8 @@
 package s2
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
-	"unicode"
 
 	"github.com/matsen/bipartite/internal/reference"
 )
@@ -128,59 +126,13 @@ func parsePublicationDate(year int, dateStr string) reference.PublicationDate {
 }
 
 // generateCiteKey generates a citation key from paper metadata.
-// Format: LastName + Year + suffix (e.g., "Zhang2018-vi")
+// Format: LastName + Year + suffix (e.g., "Zhang2018-vi"); see reference.CiteKey.
 // Note: Not guaranteed globally unique - caller should use storage.GenerateUniqueID()
 // to handle collisions before persisting.
 func generateCiteKey(paper S2Paper) string {
-	lastName := "Unknown"
+	last := ""
 	if len(paper.Authors) > 0 {
-		_, last := splitAuthorName(paper.Authors[0].Name)
-		// Remove spaces and special chars from last name
-		lastName = sanitizeForCiteKey(last)
+		_, last = splitAuthorName(paper.Authors[0].Name)
 	}
-
-	year := paper.Year
-	if year == 0 {
-		year = 9999
-	}
-
-	// Generate a short suffix from the title
-	suffix := generateTitleSuffix(paper.Title)
-
-	return fmt.Sprintf("%s%d-%s", lastName, year, suffix)
-}
-
-// sanitizeForCiteKey removes non-alphanumeric characters.
-func sanitizeForCiteKey(s string) string {
-	var result strings.Builder
-	for _, r := range s {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			result.WriteRune(r)
-		}
-	}
-	return result.String()
-}
-
-// generateTitleSuffix creates a 2-letter suffix from the title.
-func generateTitleSuffix(title string) string {
-	// Get first letters of first few significant words
-	words := strings.Fields(strings.ToLower(title))
-	stopWords := map[string]bool{"a": true, "an": true, "the": true, "of": true, "and": true, "in": true, "on": true, "for": true, "to": true, "with": true}
-
-	var suffix strings.Builder
-	for _, word := range words {
-		if !stopWords[word] && len(word) > 0 {
-			suffix.WriteByte(word[0])
-			if suffix.Len() >= 2 {
-				break
-			}
-		}
-	}
-
-	// Pad if needed
-	for suffix.Len() < 2 {
-		suffix.WriteByte('x')
-	}
-
-	return suffix.String()
+	return reference.CiteKey(last, paper.Year, paper.Title)
 }
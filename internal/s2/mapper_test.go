@@ -64,50 +64,6 @@ func TestParsePublicationDate(t *testing.T) {
 	}
 }
 
-func TestSanitizeForCiteKey(t *testing.T) {
-	tests := []struct {
-		input string
-		want  string
-	}{
-		{"Smith", "Smith"},
-		{"O'Brien", "OBrien"},
-		{"van der Waals", "vanderWaals"},
-		{"Smith-Jones", "SmithJones"},
-		{"José", "José"},
-		{"Author 3rd", "Author3rd"},
-		{"", ""},
-	}
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			if got := sanitizeForCiteKey(tt.input); got != tt.want {
-				t.Errorf("sanitizeForCiteKey(%q) = %q, want %q", tt.input, got, tt.want)
-			}
-		})
-	}
-}
-
-func TestGenerateTitleSuffix(t *testing.T) {
-	tests := []struct {
-		name  string
-		title string
-		want  string
-	}{
-		{"two significant words", "Variational Inference", "vi"},
-		{"stop words skipped", "The Origin of Species", "os"},
-		{"single significant word padded", "Phylogenetics", "px"},
-		{"empty padded", "", "xx"},
-		{"all stop words padded", "the of and", "xx"},
-		{"leading stop word", "A Neural Network", "nn"},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := generateTitleSuffix(tt.title); got != tt.want {
-				t.Errorf("generateTitleSuffix(%q) = %q, want %q", tt.title, got, tt.want)
-			}
-		})
-	}
-}
-
 func TestGenerateCiteKey(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -80,3 +80,10 @@ type SearchResponse struct {
 type PaperBatchRequest struct {
 	IDs []string `json:"ids"`
 }
+
+// PaperReferences is a batch lookup result carrying a paper's reference list.
+type PaperReferences struct {
+	PaperID     string      `json:"paperId"`
+	ExternalIDs ExternalIDs `json:"externalIds,omitempty"`
+	References  []S2Paper   `json:"references"`
+}
@@ -31,6 +31,7 @@ type Builder struct {
 	provider embedding.Provider
 	db       *storage.DB
 	progress ProgressReporter
+	force    bool
 }
 
 // NewBuilder creates a new index builder.
@@ -46,23 +47,21 @@ func (b *Builder) SetProgressReporter(reporter ProgressReporter) {
 	b.progress = reporter
 }
 
+// SetForceRebuild makes Update re-embed every paper, as Build does.
+func (b *Builder) SetForceRebuild(force bool) {
+	b.force = force
+}
+
 // Build creates a semantic index from all papers with abstracts.
 //
 // IMPORTANT: This method performs a FULL REBUILD of the index. When a database
 // connection is provided (via NewBuilder), it clears all existing embedding
 // metadata before indexing. This ensures the metadata table stays in sync with
-// the new index. If you need incremental updates, use a different approach.
+// the new index. Use Update to re-embed only new or changed papers.
 //
 // Papers are skipped if they have no abstract or an abstract shorter than
 // MinAbstractLength characters. Long abstracts are truncated to MaxAbstractLength.
 func (b *Builder) Build(ctx context.Context, refs []reference.Reference) (*SemanticIndex, *BuildStats, error) {
-	startTime := time.Now()
-
-	idx := NewSemanticIndex(b.provider.ModelName(), b.provider.Dimensions())
-	stats := &BuildStats{
-		SkippedReason: "no_abstract",
-	}
-
 	// Clear existing embedding metadata
 	if b.db != nil {
 		if err := b.db.ClearEmbeddingMetadata(); err != nil {
@@ -70,6 +69,70 @@ func (b *Builder) Build(ctx context.Context, refs []reference.Reference) (*Seman
 		}
 	}
 
+	idx, stats, err := b.build(ctx, refs, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	stats.FullRebuild = true
+	return idx, stats, nil
+}
+
+// Update builds a new index from refs, reusing vectors from prev for papers
+// whose abstract hash in the embedding metadata still matches and was
+// produced by the same model. Only new or changed papers are embedded, and
+// metadata for papers that dropped out (removed, or abstract deleted) is
+// cleared.
+//
+// Update falls back to Build if forced (SetForceRebuild), if prev is nil or
+// was built with a different provider, model or dimensions, or if there is no database
+// to read metadata from. Removed is counted against prev either way.
+func (b *Builder) Update(ctx context.Context, refs []reference.Reference, prev *SemanticIndex) (*SemanticIndex, *BuildStats, error) {
+	var idx *SemanticIndex
+	var stats *BuildStats
+	var err error
+	if b.force || prev == nil || b.db == nil || prev.ProviderName() != b.provider.Name() || prev.ModelName != b.provider.ModelName() || prev.Dimensions != b.provider.Dimensions() {
+		idx, stats, err = b.Build(ctx, refs)
+	} else {
+		var metas map[string]storage.EmbeddingMetadata
+		metas, err = b.db.GetAllEmbeddingMetadata()
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading embedding metadata: %w", err)
+		}
+		idx, stats, err = b.build(ctx, refs, prev, metas)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if prev == nil {
+		return idx, stats, nil
+	}
+
+	for id := range prev.Embeddings {
+		if idx.HasPaper(id) {
+			continue
+		}
+		// After a full rebuild the metadata was already cleared
+		if !stats.FullRebuild {
+			if _, err := b.db.DeleteEmbeddingMetadata(id); err != nil {
+				return nil, nil, fmt.Errorf("clearing metadata for %s: %w", id, err)
+			}
+		}
+		stats.Removed++
+	}
+	return idx, stats, nil
+}
+
+// build indexes refs, reusing prev's vector for any paper whose metadata in
+// metas matches its current abstract and the provider's model.
+func (b *Builder) build(ctx context.Context, refs []reference.Reference, prev *SemanticIndex, metas map[string]storage.EmbeddingMetadata) (*SemanticIndex, *BuildStats, error) {
+	startTime := time.Now()
+
+	idx := NewSemanticIndex(b.provider.ModelName(), b.provider.Dimensions())
+	idx.Provider = b.provider.Name()
+	stats := &BuildStats{
+		SkippedReason: "no_abstract",
+	}
+
 	total := len(refs)
 	papersExamined := 0
 
@@ -89,11 +152,27 @@ func (b *Builder) Build(ctx context.Context, refs []reference.Reference) (*Seman
 		}
 
 		// Skip papers without abstracts or with short abstracts
-		if ref.Abstract == "" || len(ref.Abstract) < MinAbstractLength {
+		if AbstractTooShort(ref.Abstract) {
 			stats.PapersSkipped++
 			continue
 		}
 
+		abstractHash := HashAbstract(ref.Abstract)
+
+		// Reuse the previous vector if the abstract and model are unchanged
+		if prev != nil {
+			vector, inPrev := prev.Embeddings[ref.ID]
+			meta, hasMeta := metas[ref.ID]
+			if inPrev && hasMeta && meta.AbstractHash == abstractHash && meta.ModelName == b.provider.ModelName() {
+				if err := idx.AddEmbedding(ref.ID, vector); err != nil {
+					return nil, nil, fmt.Errorf("adding embedding for %s: %w", ref.ID, err)
+				}
+				stats.PapersIndexed++
+				stats.Unchanged++
+				continue
+			}
+		}
+
 		// Truncate long abstracts to fit model context
 		abstract := ref.Abstract
 		if len(abstract) > MaxAbstractLength {
@@ -112,10 +191,10 @@ func (b *Builder) Build(ctx context.Context, refs []reference.Reference) (*Seman
 		}
 
 		stats.PapersIndexed++
+		stats.Embedded++
 
 		// Save metadata to database
 		if b.db != nil {
-			abstractHash := hashAbstract(ref.Abstract)
 			meta := storage.EmbeddingMetadata{
 				PaperID:      ref.ID,
 				ModelName:    b.provider.ModelName(),
@@ -137,8 +216,9 @@ func (b *Builder) Build(ctx context.Context, refs []reference.Reference) (*Seman
 	return idx, stats, nil
 }
 
-// hashAbstract computes a SHA256 hash of the abstract text.
-func hashAbstract(abstract string) string {
+// HashAbstract computes a SHA256 hash of the abstract text, as stored in
+// embedding metadata when a paper is indexed.
+func HashAbstract(abstract string) string {
 	h := sha256.New()
 	io.WriteString(h, abstract)
 	return fmt.Sprintf("%x", h.Sum(nil))
@@ -0,0 +1,145 @@
+package semantic
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/embedding"
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+// countingProvider embeds text as a fixed vector and records each call.
+type countingProvider struct {
+	model    string
+	embedded []string
+}
+
+func (p *countingProvider) Embed(_ context.Context, text string) (embedding.Embedding, error) {
+	p.embedded = append(p.embedded, text)
+	return embedding.Embedding{Vector: []float32{float32(len(text)), 1}}, nil
+}
+
+func (p *countingProvider) Name() string                      { return "fake" }
+func (p *countingProvider) ModelName() string                 { return p.model }
+func (p *countingProvider) Dimensions() int                   { return 2 }
+func (p *countingProvider) IsAvailable(context.Context) error { return nil }
+
+func openBuilderTestDB(t *testing.T) *storage.DB {
+	t.Helper()
+	db, err := storage.OpenDB(filepath.Join(t.TempDir(), "refs.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func abstractOf(s string) string {
+	return s + strings.Repeat(".", MinAbstractLength)
+}
+
+func TestBuilderUpdate_Incremental(t *testing.T) {
+	ctx := context.Background()
+	db := openBuilderTestDB(t)
+	provider := &countingProvider{model: "m1"}
+
+	refs := []reference.Reference{
+		{ID: "keep", Abstract: abstractOf("keep")},
+		{ID: "change", Abstract: abstractOf("before")},
+		{ID: "drop", Abstract: abstractOf("drop")},
+		{ID: "short", Abstract: "too short"},
+	}
+	prev, stats, err := NewBuilder(provider, db).Update(ctx, refs, nil)
+	if err != nil {
+		t.Fatalf("first Update() error = %v", err)
+	}
+	if !stats.FullRebuild || stats.Embedded != 3 || stats.PapersSkipped != 1 {
+		t.Errorf("first Update() stats = %+v, want full rebuild embedding 3", stats)
+	}
+
+	provider.embedded = nil
+	refs = []reference.Reference{
+		{ID: "keep", Abstract: abstractOf("keep")},
+		{ID: "change", Abstract: abstractOf("after")},
+		{ID: "new", Abstract: abstractOf("new")},
+		{ID: "short", Abstract: "too short"},
+	}
+	idx, stats, err := NewBuilder(provider, db).Update(ctx, refs, prev)
+	if err != nil {
+		t.Fatalf("second Update() error = %v", err)
+	}
+	if stats.FullRebuild || stats.Embedded != 2 || stats.Unchanged != 1 || stats.Removed != 1 {
+		t.Errorf("second Update() stats = %+v, want 2 embedded, 1 unchanged, 1 removed", stats)
+	}
+	if len(provider.embedded) != 2 {
+		t.Errorf("embedded %d abstracts, want 2", len(provider.embedded))
+	}
+	if idx.PaperCount != 3 || idx.HasPaper("drop") {
+		t.Errorf("index has %d papers (drop present: %v), want 3 without drop", idx.PaperCount, idx.HasPaper("drop"))
+	}
+
+	if meta, _ := db.GetEmbeddingMetadata("drop"); meta != nil {
+		t.Error("metadata for dropped paper was not cleared")
+	}
+	meta, _ := db.GetEmbeddingMetadata("change")
+	if meta == nil || meta.AbstractHash != HashAbstract(abstractOf("after")) {
+		t.Errorf("metadata for changed paper = %+v, want hash of new abstract", meta)
+	}
+}
+
+func TestBuilderUpdate_FullRebuild(t *testing.T) {
+	ctx := context.Background()
+	db := openBuilderTestDB(t)
+	refs := []reference.Reference{
+		{ID: "a", Abstract: abstractOf("a")},
+		{ID: "b", Abstract: abstractOf("b")},
+	}
+
+	prev, _, err := NewBuilder(&countingProvider{model: "m1"}, db).Update(ctx, refs, nil)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	t.Run("model change", func(t *testing.T) {
+		provider := &countingProvider{model: "m2"}
+		idx, stats, err := NewBuilder(provider, db).Update(ctx, refs, prev)
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if !stats.FullRebuild || len(provider.embedded) != 2 || idx.ModelName != "m2" {
+			t.Errorf("Update() with new model: stats = %+v, embedded %d", stats, len(provider.embedded))
+		}
+	})
+
+	t.Run("provider change", func(t *testing.T) {
+		prev := *prev
+		prev.Provider = "ollama"
+		provider := &countingProvider{model: "m1"}
+		idx, stats, err := NewBuilder(provider, db).Update(ctx, refs, &prev)
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if !stats.FullRebuild || idx.Provider != "fake" {
+			t.Errorf("Update() with new provider: stats = %+v, provider %s", stats, idx.Provider)
+		}
+	})
+
+	t.Run("force", func(t *testing.T) {
+		provider := &countingProvider{model: "m2"}
+		prev, _, _ := NewBuilder(provider, db).Update(ctx, refs, nil)
+		provider.embedded = nil
+
+		builder := NewBuilder(provider, db)
+		builder.SetForceRebuild(true)
+		_, stats, err := builder.Update(ctx, refs[:1], prev)
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if !stats.FullRebuild || len(provider.embedded) != 1 || stats.Removed != 1 {
+			t.Errorf("forced Update() stats = %+v, embedded %d", stats, len(provider.embedded))
+		}
+	})
+}
@@ -7,6 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+	"unicode/utf8"
+
+	"github.com/matsen/bipartite/internal/embedding"
 )
 
 // Errors returned by semantic index operations.
@@ -23,6 +26,7 @@ const (
 	// MinAbstractLength is the minimum abstract length (in characters) to index.
 	// Rationale: ~50 characters = ~10-15 words = minimum for meaningful embeddings.
 	// Shorter abstracts lack sufficient semantic content for reliable similarity.
+	// Compare against it with AbstractTooShort.
 	MinAbstractLength = 50
 
 	// MaxAbstractLength is the maximum abstract length (in characters) to embed.
@@ -36,6 +40,13 @@ const (
 	CurrentIndexVersion = 1
 )
 
+// AbstractTooShort reports whether abstract is missing or shorter than
+// MinAbstractLength. Length is counted in characters (runes), as SQLite's
+// LENGTH does for the index's abstract queries, not in bytes.
+func AbstractTooShort(abstract string) bool {
+	return utf8.RuneCountInString(abstract) < MinAbstractLength
+}
+
 // IndexPath returns the path to the semantic index file.
 func IndexPath(repoRoot string) string {
 	return filepath.Join(repoRoot, ".bipartite", "cache", IndexFileName)
@@ -52,6 +63,15 @@ func NewSemanticIndex(modelName string, dimensions int) *SemanticIndex {
 	}
 }
 
+// ProviderName returns the embedding provider the index was built with.
+// Indexes written before the provider was recorded were built with Ollama.
+func (idx *SemanticIndex) ProviderName() string {
+	if idx.Provider == "" {
+		return embedding.ProviderOllama
+	}
+	return idx.Provider
+}
+
 // AddEmbedding adds a paper embedding to the index.
 // The PaperCount field is automatically updated to reflect the current number of embeddings.
 func (idx *SemanticIndex) AddEmbedding(paperID string, embedding []float32) error {
@@ -0,0 +1,46 @@
+package semantic
+
+import (
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+// RehashResult summarizes a RecomputeHashes pass.
+type RehashResult struct {
+	Updated     []storage.EmbeddingMetadata // Metadata rows to write
+	Unchanged   int                         // Indexed papers whose hash already matched
+	NotIndexed  int                         // Papers not in the index (left alone)
+	PapersTotal int
+}
+
+// RecomputeHashes re-baselines staleness detection: for every paper in idx
+// it computes HashAbstract of the current abstract and returns the metadata
+// rows whose stored hash differs (or is missing). Existing model names and
+// indexed-at times are kept; papers without metadata get the index's model
+// and creation time. No embeddings are touched, so a paper whose abstract
+// really changed will look current afterwards.
+func RecomputeHashes(refs []reference.Reference, idx *SemanticIndex, metas map[string]storage.EmbeddingMetadata) RehashResult {
+	result := RehashResult{PapersTotal: len(refs)}
+	for _, ref := range refs {
+		if !idx.HasPaper(ref.ID) {
+			result.NotIndexed++
+			continue
+		}
+		hash := HashAbstract(ref.Abstract)
+		meta, ok := metas[ref.ID]
+		if ok && meta.AbstractHash == hash {
+			result.Unchanged++
+			continue
+		}
+		if !ok {
+			meta = storage.EmbeddingMetadata{
+				PaperID:   ref.ID,
+				ModelName: idx.ModelName,
+				IndexedAt: idx.CreatedAt.Unix(),
+			}
+		}
+		meta.AbstractHash = hash
+		result.Updated = append(result.Updated, meta)
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package semantic
+
+import (
+	"testing"
+
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+func TestRecomputeHashes(t *testing.T) {
+	idx := NewSemanticIndex("test-model", 3)
+	for _, id := range []string{"current", "changed", "no-meta"} {
+		if err := idx.AddEmbedding(id, []float32{1, 0, 0}); err != nil {
+			t.Fatalf("AddEmbedding: %v", err)
+		}
+	}
+
+	refs := []reference.Reference{
+		{ID: "current", Abstract: "same abstract"},
+		{ID: "changed", Abstract: "new abstract"},
+		{ID: "no-meta", Abstract: "some abstract"},
+		{ID: "unindexed", Abstract: "never embedded"},
+	}
+	metas := map[string]storage.EmbeddingMetadata{
+		"current": {PaperID: "current", ModelName: "old-model", IndexedAt: 10, AbstractHash: HashAbstract("same abstract")},
+		"changed": {PaperID: "changed", ModelName: "old-model", IndexedAt: 20, AbstractHash: "legacy-hash"},
+	}
+
+	result := RecomputeHashes(refs, idx, metas)
+
+	if result.Unchanged != 1 || result.NotIndexed != 1 || result.PapersTotal != 4 {
+		t.Errorf("counts = %+v", result)
+	}
+	if len(result.Updated) != 2 {
+		t.Fatalf("expected 2 updated rows, got %+v", result.Updated)
+	}
+	byID := map[string]storage.EmbeddingMetadata{}
+	for _, m := range result.Updated {
+		byID[m.PaperID] = m
+	}
+	changed := byID["changed"]
+	if changed.AbstractHash != HashAbstract("new abstract") || changed.ModelName != "old-model" || changed.IndexedAt != 20 {
+		t.Errorf("changed row = %+v, want new hash with original model and time", changed)
+	}
+	noMeta := byID["no-meta"]
+	if noMeta.AbstractHash != HashAbstract("some abstract") || noMeta.ModelName != "test-model" || noMeta.IndexedAt != idx.CreatedAt.Unix() {
+		t.Errorf("no-meta row = %+v, want index model and creation time", noMeta)
+	}
+}
@@ -82,6 +82,12 @@ func applyLimit(results []SearchResult, limit int) []SearchResult {
 // Results are sorted by similarity (highest first) and filtered by threshold.
 // Returns an error if the index is empty, query dimensions don't match, or limit is negative.
 func (idx *SemanticIndex) Search(query []float32, limit int, threshold float32) ([]SearchResult, error) {
+	return idx.SearchExcluding(query, limit, threshold, nil)
+}
+
+// SearchExcluding is Search with the papers in exclude left out of the
+// results, e.g. papers already linked to the concept being matched.
+func (idx *SemanticIndex) SearchExcluding(query []float32, limit int, threshold float32, exclude map[string]bool) ([]SearchResult, error) {
 	if idx.Embeddings == nil || len(idx.Embeddings) == 0 {
 		return nil, ErrEmptyIndex
 	}
@@ -92,8 +98,8 @@ func (idx *SemanticIndex) Search(query []float32, limit int, threshold float32)
 		return nil, ErrNegativeLimit
 	}
 
-	results := idx.findMatchingPapers(query, func(_ string, sim float32) bool {
-		return sim >= threshold
+	results := idx.findMatchingPapers(query, func(id string, sim float32) bool {
+		return !exclude[id] && sim >= threshold
 	})
 
 	return applyLimit(results, limit), nil
@@ -102,6 +108,12 @@ func (idx *SemanticIndex) Search(query []float32, limit int, threshold float32)
 // FindSimilar finds papers similar to a given paper by ID.
 // The source paper is excluded from results.
 func (idx *SemanticIndex) FindSimilar(paperID string, limit int) ([]SearchResult, error) {
+	return idx.SearchByPaper(paperID, limit, float32(math.Inf(-1)))
+}
+
+// SearchByPaper is Search using an indexed paper's stored embedding as the
+// query, so no embedding model is needed. The paper itself is excluded.
+func (idx *SemanticIndex) SearchByPaper(paperID string, limit int, threshold float32) ([]SearchResult, error) {
 	embedding, exists := idx.Embeddings[paperID]
 	if !exists {
 		return nil, ErrPaperNotIndexed
@@ -110,8 +122,8 @@ func (idx *SemanticIndex) FindSimilar(paperID string, limit int) ([]SearchResult
 		return nil, ErrNegativeLimit
 	}
 
-	results := idx.findMatchingPapers(embedding, func(id string, _ float32) bool {
-		return id != paperID
+	results := idx.findMatchingPapers(embedding, func(id string, sim float32) bool {
+		return id != paperID && sim >= threshold
 	})
 
 	return applyLimit(results, limit), nil
@@ -1,6 +1,7 @@
 package semantic
 
 import (
+	"errors"
 	"math"
 	"testing"
 )
@@ -273,6 +274,25 @@ func TestFindSimilar(t *testing.T) {
 	})
 }
 
+func TestSearchByPaper(t *testing.T) {
+	idx := NewSemanticIndex("test-model", 3)
+	idx.AddEmbedding("paper1", []float32{1, 0, 0})
+	idx.AddEmbedding("paper2", []float32{0.9, 0.1, 0})
+	idx.AddEmbedding("paper3", []float32{0, 1, 0})
+
+	results, err := idx.SearchByPaper("paper1", 10, 0.5)
+	if err != nil {
+		t.Fatalf("SearchByPaper failed: %v", err)
+	}
+	if len(results) != 1 || results[0].PaperID != "paper2" {
+		t.Errorf("SearchByPaper(paper1, threshold 0.5) = %v, want only paper2", results)
+	}
+
+	if _, err := idx.SearchByPaper("nonexistent", 10, 0.5); err != ErrPaperNotIndexed {
+		t.Errorf("expected ErrPaperNotIndexed, got %v", err)
+	}
+}
+
 func TestHasPaper(t *testing.T) {
 	idx := NewSemanticIndex("test-model", 3)
 	idx.AddEmbedding("paper1", []float32{1, 0, 0})
@@ -285,3 +305,22 @@ func TestHasPaper(t *testing.T) {
 		t.Error("HasPaper should return false for non-existing paper")
 	}
 }
+
+func TestSearchExcluding(t *testing.T) {
+	idx := NewSemanticIndex("test-model", 3)
+	idx.AddEmbedding("paper1", []float32{1, 0, 0})
+	idx.AddEmbedding("paper2", []float32{0.9, 0.1, 0})
+	idx.AddEmbedding("paper3", []float32{0, 1, 0})
+
+	results, err := idx.SearchExcluding([]float32{1, 0, 0}, 10, 0.5, map[string]bool{"paper1": true})
+	if err != nil {
+		t.Fatalf("SearchExcluding failed: %v", err)
+	}
+	if len(results) != 1 || results[0].PaperID != "paper2" {
+		t.Errorf("SearchExcluding(excluding paper1, threshold 0.5) = %v, want only paper2", results)
+	}
+
+	if _, err := idx.SearchExcluding([]float32{1, 0}, 10, 0.5, nil); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
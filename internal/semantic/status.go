@@ -0,0 +1,76 @@
+package semantic
+
+import (
+	"sort"
+
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+// Paper index statuses reported by PaperIndexStatus.
+const (
+	// IndexStatusIndexed means the paper is in the index under its current abstract.
+	IndexStatusIndexed = "indexed"
+	// IndexStatusNoAbstract means the paper has no abstract to embed.
+	IndexStatusNoAbstract = "no_abstract"
+	// IndexStatusTooShort means the abstract is shorter than MinAbstractLength.
+	IndexStatusTooShort = "abstract_too_short"
+	// IndexStatusNotIndexed means the paper is eligible but was added (or got
+	// an abstract) after the last index build.
+	IndexStatusNotIndexed = "not_indexed"
+	// IndexStatusStale means the paper is in the index, but its abstract has
+	// changed since it was embedded.
+	IndexStatusStale = "stale"
+)
+
+// PaperIndexStatus explains whether a paper is searchable in the semantic
+// index and, if not, why. inIndex reports whether the index holds an
+// embedding for the paper; meta is its embedding metadata, or nil if none
+// was recorded. Without metadata an indexed paper cannot be checked for
+// staleness and is reported as indexed.
+func PaperIndexStatus(abstract string, inIndex bool, meta *storage.EmbeddingMetadata) string {
+	if inIndex {
+		if meta != nil && meta.AbstractHash != HashAbstract(abstract) {
+			return IndexStatusStale
+		}
+		return IndexStatusIndexed
+	}
+	switch {
+	case abstract == "":
+		return IndexStatusNoAbstract
+	case AbstractTooShort(abstract):
+		return IndexStatusTooShort
+	default:
+		return IndexStatusNotIndexed
+	}
+}
+
+// StaleIDs returns, sorted, the IDs of papers whose embedding metadata
+// records a different abstract hash than their current abstract. Metadata
+// for papers no longer in refs is ignored.
+func StaleIDs(refs []reference.Reference, metas map[string]storage.EmbeddingMetadata) []string {
+	var stale []string
+	for _, ref := range refs {
+		if meta, ok := metas[ref.ID]; ok && meta.AbstractHash != HashAbstract(ref.Abstract) {
+			stale = append(stale, ref.ID)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// PrimaryModel returns the embedding model recorded for the most papers,
+// breaking ties alphabetically, or "" if there is no metadata.
+func PrimaryModel(metas map[string]storage.EmbeddingMetadata) string {
+	counts := make(map[string]int)
+	for _, meta := range metas {
+		counts[meta.ModelName]++
+	}
+	best := ""
+	for model, n := range counts {
+		if n > counts[best] || (n == counts[best] && model < best) {
+			best = model
+		}
+	}
+	return best
+}
@@ -0,0 +1,80 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/reference"
+	"github.com/matsen/bipartite/internal/storage"
+)
+
+func TestPaperIndexStatus(t *testing.T) {
+	long := strings.Repeat("a", MinAbstractLength)
+	current := &storage.EmbeddingMetadata{PaperID: "p", AbstractHash: HashAbstract(long)}
+	outdated := &storage.EmbeddingMetadata{PaperID: "p", AbstractHash: HashAbstract("old abstract")}
+
+	tests := []struct {
+		name     string
+		abstract string
+		inIndex  bool
+		meta     *storage.EmbeddingMetadata
+		want     string
+	}{
+		{"indexed with matching hash", long, true, current, IndexStatusIndexed},
+		{"indexed without metadata", long, true, nil, IndexStatusIndexed},
+		{"indexed with changed abstract", long, true, outdated, IndexStatusStale},
+		{"no abstract", "", false, nil, IndexStatusNoAbstract},
+		{"short abstract", "too short", false, nil, IndexStatusTooShort},
+		{"short multibyte abstract", strings.Repeat("é", MinAbstractLength-1), false, nil, IndexStatusTooShort},
+		{"multibyte abstract at the limit", strings.Repeat("é", MinAbstractLength), false, nil, IndexStatusNotIndexed},
+		{"eligible but not indexed", long, false, nil, IndexStatusNotIndexed},
+		{"metadata without embedding", long, false, current, IndexStatusNotIndexed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PaperIndexStatus(tt.abstract, tt.inIndex, tt.meta); got != tt.want {
+				t.Errorf("PaperIndexStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaleIDs(t *testing.T) {
+	refs := []reference.Reference{
+		{ID: "b-changed", Abstract: "new abstract"},
+		{ID: "a-changed", Abstract: "new abstract"},
+		{ID: "current", Abstract: "same abstract"},
+		{ID: "unindexed", Abstract: "never embedded"},
+	}
+	metas := map[string]storage.EmbeddingMetadata{
+		"b-changed": {PaperID: "b-changed", AbstractHash: HashAbstract("old abstract")},
+		"a-changed": {PaperID: "a-changed", AbstractHash: HashAbstract("old abstract")},
+		"current":   {PaperID: "current", AbstractHash: HashAbstract("same abstract")},
+		"deleted":   {PaperID: "deleted", AbstractHash: "x"},
+	}
+
+	got := StaleIDs(refs, metas)
+	if len(got) != 2 || got[0] != "a-changed" || got[1] != "b-changed" {
+		t.Errorf("StaleIDs() = %v, want [a-changed b-changed]", got)
+	}
+}
+
+func TestPrimaryModel(t *testing.T) {
+	metas := map[string]storage.EmbeddingMetadata{
+		"p1": {ModelName: "nomic-embed-text"},
+		"p2": {ModelName: "nomic-embed-text"},
+		"p3": {ModelName: "all-minilm"},
+	}
+	if got := PrimaryModel(metas); got != "nomic-embed-text" {
+		t.Errorf("PrimaryModel() = %q, want nomic-embed-text", got)
+	}
+
+	metas["p4"] = storage.EmbeddingMetadata{ModelName: "all-minilm"}
+	if got := PrimaryModel(metas); got != "all-minilm" {
+		t.Errorf("PrimaryModel() on tie = %q, want all-minilm", got)
+	}
+	if got := PrimaryModel(nil); got != "" {
+		t.Errorf("PrimaryModel(nil) = %q, want empty", got)
+	}
+}
@@ -10,6 +10,7 @@ type SemanticIndex struct {
 	Version int `json:"version"`
 
 	// Metadata about the index
+	Provider        string    `json:"provider"`          // Embedding provider; "" in indexes built before it was recorded means ollama
 	ModelName       string    `json:"model_name"`        // e.g., "all-minilm:l6-v2"
 	Dimensions      int       `json:"dimensions"`        // 384 for all-minilm
 	CreatedAt       time.Time `json:"created_at"`        // When index was built
@@ -32,6 +33,10 @@ type BuildStats struct {
 	PapersIndexed  int           `json:"papers_indexed"`
 	PapersSkipped  int           `json:"papers_skipped"`
 	SkippedReason  string        `json:"skipped_reason"`
+	Embedded       int           `json:"embedded"`     // Papers embedded in this build
+	Unchanged      int           `json:"unchanged"`    // Papers whose previous vector was reused
+	Removed        int           `json:"removed"`      // Papers in the previous index that dropped out
+	FullRebuild    bool          `json:"full_rebuild"` // Every paper was re-embedded
 	Duration       time.Duration `json:"duration"`
 	IndexSizeBytes int64         `json:"index_size_bytes"`
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/matsen/bipartite/internal/concept"
 )
@@ -36,7 +37,8 @@ func (d *DB) ensureConceptsSchema() error {
 }
 
 // RebuildConceptsFromJSONL clears the concepts tables and rebuilds them from a JSONL file.
-func (d *DB) RebuildConceptsFromJSONL(jsonlPath string) (int, error) {
+func (d *DB) RebuildConceptsFromJSONL(jsonlPath string) (n int, err error) {
+	defer logRebuild("concepts", jsonlPath, time.Now(), &n, &err)
 	if err := d.ensureConceptsSchema(); err != nil {
 		return 0, err
 	}
@@ -75,14 +77,9 @@ func (d *DB) RebuildConceptsFromJSONL(jsonlPath string) (int, error) {
 	defer ftsStmt.Close()
 
 	for _, c := range concepts {
-		// Serialize aliases to JSON
-		var aliasesJSON string
-		if len(c.Aliases) > 0 {
-			aliasesBytes, err := json.Marshal(c.Aliases)
-			if err != nil {
-				return 0, fmt.Errorf("marshaling aliases for %s: %w", c.ID, err)
-			}
-			aliasesJSON = string(aliasesBytes)
+		aliasesJSON, err := conceptAliasesJSON(c)
+		if err != nil {
+			return 0, err
 		}
 
 		// Insert into concepts table
@@ -91,19 +88,64 @@ func (d *DB) RebuildConceptsFromJSONL(jsonlPath string) (int, error) {
 			return 0, fmt.Errorf("inserting concept %s: %w", c.ID, err)
 		}
 
-		// Build aliases text for FTS (space-joined)
-		aliasesText := strings.Join(c.Aliases, " ")
-
 		// Insert into FTS table
-		_, err = ftsStmt.Exec(c.ID, c.Name, aliasesText, c.Description)
+		_, err = ftsStmt.Exec(c.ID, c.Name, strings.Join(c.Aliases, " "), c.Description)
 		if err != nil {
 			return 0, fmt.Errorf("inserting concepts_fts for %s: %w", c.ID, err)
 		}
 	}
 
+	if err := d.recordIndexHash(IndexConcepts, jsonlPath); err != nil {
+		return 0, err
+	}
 	return len(concepts), nil
 }
 
+// UpsertConcept inserts or replaces a single concept and its FTS row.
+func (d *DB) UpsertConcept(c concept.Concept) error {
+	if err := d.ensureConceptsSchema(); err != nil {
+		return err
+	}
+
+	aliasesJSON, err := conceptAliasesJSON(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.Exec(`
+		INSERT OR REPLACE INTO concepts (id, name, aliases_json, description)
+		VALUES (?, ?, ?, ?)
+	`, c.ID, c.Name, nullableStringFromGo(aliasesJSON), c.Description)
+	if err != nil {
+		return fmt.Errorf("upserting concept %s: %w", c.ID, err)
+	}
+
+	// FTS5 tables have no primary key to replace on
+	if _, err := d.db.Exec(`DELETE FROM concepts_fts WHERE id = ?`, c.ID); err != nil {
+		return fmt.Errorf("clearing concepts_fts for %s: %w", c.ID, err)
+	}
+	_, err = d.db.Exec(`
+		INSERT INTO concepts_fts (id, name, aliases_text, description)
+		VALUES (?, ?, ?, ?)
+	`, c.ID, c.Name, strings.Join(c.Aliases, " "), c.Description)
+	if err != nil {
+		return fmt.Errorf("inserting concepts_fts for %s: %w", c.ID, err)
+	}
+	return nil
+}
+
+// conceptAliasesJSON serializes a concept's aliases, or "" if it has none.
+func conceptAliasesJSON(c concept.Concept) (string, error) {
+	if len(c.Aliases) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(c.Aliases)
+	if err != nil {
+		return "", fmt.Errorf("marshaling aliases for %s: %w", c.ID, err)
+	}
+	return string(b), nil
+}
+
 // nullableStringFromGo converts a Go string to sql.NullString.
 func nullableStringFromGo(s string) sql.NullString {
 	if s == "" {
@@ -146,7 +188,35 @@ func (d *DB) GetAllConcepts() ([]concept.Concept, error) {
 	return scanConcepts(rows)
 }
 
+// GetConceptsPaged returns one page of concepts ordered by ID: up to limit
+// (0 = no limit) after skipping offset.
+func (d *DB) GetConceptsPaged(limit, offset int) ([]concept.Concept, error) {
+	return collect(func(fn func(concept.Concept) error) error {
+		return d.GetConceptsPagedEach(limit, offset, fn)
+	})
+}
+
+// GetConceptsPagedEach is GetConceptsPaged calling fn with each concept as
+// it is read.
+func (d *DB) GetConceptsPagedEach(limit, offset int, fn func(concept.Concept) error) error {
+	if err := d.ensureConceptsSchema(); err != nil {
+		return err
+	}
+
+	rows, err := d.db.Query(`
+		SELECT id, name, aliases_json, description
+		FROM concepts
+		ORDER BY id
+		LIMIT ? OFFSET ?
+	`, sqlLimit(limit), offset)
+	if err != nil {
+		return fmt.Errorf("querying concepts: %w", err)
+	}
+	return eachRow(rows, scanConceptRow, fn)
+}
+
 // SearchConcepts performs a full-text search on concepts.
+// A limit of 0 means no limit.
 func (d *DB) SearchConcepts(query string, limit int) ([]concept.Concept, error) {
 	if err := d.ensureConceptsSchema(); err != nil {
 		return nil, err
@@ -159,7 +229,7 @@ func (d *DB) SearchConcepts(query string, limit int) ([]concept.Concept, error)
 		FROM concepts c
 		WHERE c.id IN (SELECT id FROM concepts_fts WHERE concepts_fts MATCH ?)
 		LIMIT ?
-	`, ftsQuery, limit)
+	`, ftsQuery, sqlLimit(limit))
 	if err != nil {
 		return nil, fmt.Errorf("searching concepts: %w", err)
 	}
@@ -187,8 +257,16 @@ func (d *DB) CountConcepts() (int, error) {
 
 // GetPapersByConcept returns all papers linked to a concept, optionally filtered by relationship type.
 func (d *DB) GetPapersByConcept(conceptID string, relationshipType string) ([]PaperConceptEdge, error) {
+	return collect(func(fn func(PaperConceptEdge) error) error {
+		return d.GetPapersByConceptEach(conceptID, relationshipType, fn)
+	})
+}
+
+// GetPapersByConceptEach is GetPapersByConcept calling fn with each paper
+// link as it is read.
+func (d *DB) GetPapersByConceptEach(conceptID string, relationshipType string, fn func(PaperConceptEdge) error) error {
 	if err := d.ensureEdgesSchema(); err != nil {
-		return nil, err
+		return err
 	}
 
 	// Edges store concept targets with "concept:" prefix
@@ -199,7 +277,7 @@ func (d *DB) GetPapersByConcept(conceptID string, relationshipType string) ([]Pa
 
 	if relationshipType != "" {
 		query = `
-			SELECT e.source_id, e.relationship_type, e.summary
+			SELECT e.source_id, e.relationship_type, e.summary, e.strength, e.created_at
 			FROM edges e
 			WHERE e.target_id = ? AND e.relationship_type = ?
 			ORDER BY e.relationship_type, e.source_id
@@ -207,7 +285,7 @@ func (d *DB) GetPapersByConcept(conceptID string, relationshipType string) ([]Pa
 		args = []interface{}{prefixedID, relationshipType}
 	} else {
 		query = `
-			SELECT e.source_id, e.relationship_type, e.summary
+			SELECT e.source_id, e.relationship_type, e.summary, e.strength, e.created_at
 			FROM edges e
 			WHERE e.target_id = ?
 			ORDER BY e.relationship_type, e.source_id
@@ -217,29 +295,30 @@ func (d *DB) GetPapersByConcept(conceptID string, relationshipType string) ([]Pa
 
 	rows, err := d.db.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("querying papers by concept: %w", err)
+		return fmt.Errorf("querying papers by concept: %w", err)
 	}
-	defer rows.Close()
-
-	var results []PaperConceptEdge
-	for rows.Next() {
+	return eachRow(rows, func(s scanner) (PaperConceptEdge, error) {
 		var pce PaperConceptEdge
-		if err := rows.Scan(&pce.PaperID, &pce.RelationshipType, &pce.Summary); err != nil {
-			return nil, err
-		}
-		results = append(results, pce)
-	}
-
-	return results, rows.Err()
+		err := scanPaperConceptEdge(s, &pce, &pce.PaperID)
+		return pce, err
+	}, fn)
 }
 
 // GetConceptsByPaper returns all concepts linked to a paper, optionally filtered by relationship type.
 func (d *DB) GetConceptsByPaper(paperID string, relationshipType string) ([]PaperConceptEdge, error) {
+	return collect(func(fn func(PaperConceptEdge) error) error {
+		return d.GetConceptsByPaperEach(paperID, relationshipType, fn)
+	})
+}
+
+// GetConceptsByPaperEach is GetConceptsByPaper calling fn with each concept
+// link as it is read.
+func (d *DB) GetConceptsByPaperEach(paperID string, relationshipType string, fn func(PaperConceptEdge) error) error {
 	if err := d.ensureEdgesSchema(); err != nil {
-		return nil, err
+		return err
 	}
 	if err := d.ensureConceptsSchema(); err != nil {
-		return nil, err
+		return err
 	}
 
 	var query string
@@ -247,7 +326,7 @@ func (d *DB) GetConceptsByPaper(paperID string, relationshipType string) ([]Pape
 
 	if relationshipType != "" {
 		query = `
-			SELECT e.target_id, e.relationship_type, e.summary
+			SELECT e.target_id, e.relationship_type, e.summary, e.strength, e.created_at
 			FROM edges e
 			WHERE e.source_id = ? AND e.relationship_type = ?
 			  AND e.target_id IN (SELECT 'concept:' || id FROM concepts)
@@ -256,7 +335,7 @@ func (d *DB) GetConceptsByPaper(paperID string, relationshipType string) ([]Pape
 		args = []interface{}{paperID, relationshipType}
 	} else {
 		query = `
-			SELECT e.target_id, e.relationship_type, e.summary
+			SELECT e.target_id, e.relationship_type, e.summary, e.strength, e.created_at
 			FROM edges e
 			WHERE e.source_id = ?
 			  AND e.target_id IN (SELECT 'concept:' || id FROM concepts)
@@ -267,20 +346,91 @@ func (d *DB) GetConceptsByPaper(paperID string, relationshipType string) ([]Pape
 
 	rows, err := d.db.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("querying concepts by paper: %w", err)
+		return fmt.Errorf("querying concepts by paper: %w", err)
+	}
+	return eachRow(rows, func(s scanner) (PaperConceptEdge, error) {
+		var pce PaperConceptEdge
+		err := scanPaperConceptEdge(s, &pce, &pce.ConceptID)
+		return pce, err
+	}, fn)
+}
+
+// scanPaperConceptEdge scans a paper-concept link row into pce, reading the
+// row's first column, the endpoint at the other end of the query, into id.
+func scanPaperConceptEdge(s scanner, pce *PaperConceptEdge, id *string) error {
+	var strength sql.NullFloat64
+	var createdAt sql.NullString
+	if err := s.Scan(id, &pce.RelationshipType, &pce.Summary, &strength, &createdAt); err != nil {
+		return err
+	}
+	if strength.Valid {
+		pce.Strength = &strength.Float64
+	}
+	pce.CreatedAt = createdAt.String
+	return nil
+}
+
+// ConceptEdgeStats counts the edges touching one concept.
+type ConceptEdgeStats struct {
+	ConceptID     string `json:"concept_id"`
+	PaperCount    int    `json:"paper_count"`    // Edges linking a paper, either direction
+	OutgoingCount int    `json:"outgoing_count"` // Edges with the concept as source
+	ProjectCount  int    `json:"project_count"`  // Edges linking a project, either direction
+	Total         int    `json:"total"`          // All edges touching the concept
+}
+
+// GetConceptEdgeStats returns edge counts for every concept, ordered by ID.
+// Concepts without edges are included with zero counts. Counting is done in
+// SQL, grouping edge endpoints by concept.
+func (d *DB) GetConceptEdgeStats() ([]ConceptEdgeStats, error) {
+	if err := d.ensureConceptsSchema(); err != nil {
+		return nil, err
+	}
+	if err := d.ensureEdgesSchema(); err != nil {
+		return nil, err
+	}
+
+	// Each edge contributes one row per endpoint; other is the far end.
+	// Unprefixed IDs are papers.
+	rows, err := d.db.Query(`
+		WITH ends AS (
+			SELECT target_id AS node, source_id AS other, 0 AS outgoing FROM edges
+			UNION ALL
+			SELECT source_id AS node, target_id AS other, 1 AS outgoing FROM edges
+		),
+		stats AS (
+			SELECT substr(node, length('concept:') + 1) AS concept_id,
+				SUM(CASE WHEN other NOT LIKE 'concept:%'
+					AND other NOT LIKE 'project:%'
+					AND other NOT LIKE 'repo:%' THEN 1 ELSE 0 END) AS paper_count,
+				SUM(outgoing) AS outgoing_count,
+				SUM(CASE WHEN other LIKE 'project:%' THEN 1 ELSE 0 END) AS project_count,
+				COUNT(*) AS total
+			FROM ends
+			WHERE node LIKE 'concept:%'
+			GROUP BY node
+		)
+		SELECT c.id,
+			COALESCE(s.paper_count, 0), COALESCE(s.outgoing_count, 0),
+			COALESCE(s.project_count, 0), COALESCE(s.total, 0)
+		FROM concepts c
+		LEFT JOIN stats s ON s.concept_id = c.id
+		ORDER BY c.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying concept edge stats: %w", err)
 	}
 	defer rows.Close()
 
-	var results []PaperConceptEdge
+	var stats []ConceptEdgeStats
 	for rows.Next() {
-		var pce PaperConceptEdge
-		if err := rows.Scan(&pce.ConceptID, &pce.RelationshipType, &pce.Summary); err != nil {
+		var cs ConceptEdgeStats
+		if err := rows.Scan(&cs.ConceptID, &cs.PaperCount, &cs.OutgoingCount, &cs.ProjectCount, &cs.Total); err != nil {
 			return nil, err
 		}
-		results = append(results, pce)
+		stats = append(stats, cs)
 	}
-
-	return results, rows.Err()
+	return stats, rows.Err()
 }
 
 // CountEdgesByTarget returns the number of edges pointing to a given target.
@@ -302,10 +452,12 @@ func (d *DB) CountEdgesByTarget(targetID string) (int, error) {
 //
 // The omitempty tags ensure only the relevant ID field appears in JSON output.
 type PaperConceptEdge struct {
-	PaperID          string `json:"paper_id,omitempty"`
-	ConceptID        string `json:"concept_id,omitempty"`
-	RelationshipType string `json:"relationship_type"`
-	Summary          string `json:"summary"`
+	PaperID          string   `json:"paper_id,omitempty"`
+	ConceptID        string   `json:"concept_id,omitempty"`
+	RelationshipType string   `json:"relationship_type"`
+	Summary          string   `json:"summary"`
+	Strength         *float64 `json:"strength,omitempty"`
+	CreatedAt        string   `json:"created_at,omitempty"`
 }
 
 // populateConceptFields deserializes aliasesJSON and description into a concept.
@@ -339,20 +491,23 @@ func scanConcept(row *sql.Row) (*concept.Concept, error) {
 	return &c, nil
 }
 
-// scanConcepts scans multiple concepts from rows.
-func scanConcepts(rows *sql.Rows) ([]concept.Concept, error) {
-	var concepts []concept.Concept
-	for rows.Next() {
-		var c concept.Concept
-		var aliasesJSON, description sql.NullString
+// scanConceptRow scans one concept from a result row.
+func scanConceptRow(s scanner) (concept.Concept, error) {
+	var c concept.Concept
+	var aliasesJSON, description sql.NullString
 
-		if err := rows.Scan(&c.ID, &c.Name, &aliasesJSON, &description); err != nil {
-			return nil, err
-		}
-		if err := populateConceptFields(&c, aliasesJSON, description); err != nil {
-			return nil, err
-		}
-		concepts = append(concepts, c)
+	if err := s.Scan(&c.ID, &c.Name, &aliasesJSON, &description); err != nil {
+		return concept.Concept{}, err
 	}
-	return concepts, rows.Err()
+	if err := populateConceptFields(&c, aliasesJSON, description); err != nil {
+		return concept.Concept{}, err
+	}
+	return c, nil
+}
+
+// scanConcepts scans multiple concepts from rows.
+func scanConcepts(rows *sql.Rows) ([]concept.Concept, error) {
+	return collect(func(fn func(concept.Concept) error) error {
+		return eachRow(rows, scanConceptRow, fn)
+	})
 }
@@ -123,6 +123,52 @@ func TestGetAllConcepts(t *testing.T) {
 	}
 }
 
+func TestGetConceptsPaged(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	db, err := OpenDB(dbPath)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	conceptsPath := filepath.Join("..", "..", "testdata", "concepts", "test-concepts.jsonl")
+	if _, err := db.RebuildConceptsFromJSONL(conceptsPath); err != nil {
+		t.Fatalf("RebuildConceptsFromJSONL() error = %v", err)
+	}
+	all, err := db.GetAllConcepts()
+	if err != nil {
+		t.Fatalf("GetAllConcepts() error = %v", err)
+	}
+
+	tests := []struct {
+		limit, offset int
+		wantFirst     int // Index into all; -1 for an empty page
+		wantLen       int
+	}{
+		{0, 0, 0, 4}, // No limit
+		{2, 0, 0, 2},
+		{2, 2, 2, 2},
+		{2, 3, 3, 1},
+		{2, 4, -1, 0},
+		{0, 1, 1, 3},
+	}
+	for _, tt := range tests {
+		page, err := db.GetConceptsPaged(tt.limit, tt.offset)
+		if err != nil {
+			t.Fatalf("GetConceptsPaged(%d, %d) error = %v", tt.limit, tt.offset, err)
+		}
+		if len(page) != tt.wantLen {
+			t.Errorf("GetConceptsPaged(%d, %d) returned %d, want %d", tt.limit, tt.offset, len(page), tt.wantLen)
+			continue
+		}
+		if tt.wantFirst >= 0 && page[0].ID != all[tt.wantFirst].ID {
+			t.Errorf("GetConceptsPaged(%d, %d) first ID = %q, want %q", tt.limit, tt.offset, page[0].ID, all[tt.wantFirst].ID)
+		}
+	}
+}
+
 func TestSearchConcepts(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "test.db")
@@ -213,7 +259,7 @@ func TestGetPapersByConcept(t *testing.T) {
 
 	// Create test edges file and rebuild
 	edgesPath := filepath.Join(tmpDir, "edges.jsonl")
-	testEdges := `{"source_id": "Paper1", "target_id": "concept:test-concept", "relationship_type": "introduces", "summary": "Test 1"}
+	testEdges := `{"source_id": "Paper1", "target_id": "concept:test-concept", "relationship_type": "introduces", "summary": "Test 1", "created_at": "2026-01-15T10:00:00Z"}
 {"source_id": "Paper2", "target_id": "concept:test-concept", "relationship_type": "applies", "summary": "Test 2"}
 {"source_id": "Paper3", "target_id": "concept:other-concept", "relationship_type": "applies", "summary": "Test 3"}
 `
@@ -239,7 +285,10 @@ func TestGetPapersByConcept(t *testing.T) {
 		t.Fatalf("GetPapersByConcept() error = %v", err)
 	}
 	if len(papers) != 1 {
-		t.Errorf("GetPapersByConcept() with type filter returned %d papers, want 1", len(papers))
+		t.Fatalf("GetPapersByConcept() with type filter returned %d papers, want 1", len(papers))
+	}
+	if papers[0].CreatedAt != "2026-01-15T10:00:00Z" {
+		t.Errorf("GetPapersByConcept() CreatedAt = %q, want 2026-01-15T10:00:00Z", papers[0].CreatedAt)
 	}
 }
 
@@ -411,3 +460,60 @@ func TestCountEdgesByTarget(t *testing.T) {
 		t.Errorf("CountEdgesByTarget() for nonexistent = %d, want 0", count)
 	}
 }
+
+func TestGetConceptEdgeStats(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := OpenDB(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	conceptsPath := filepath.Join(tmpDir, "concepts.jsonl")
+	testConcepts := []concept.Concept{
+		{ID: "hub", Name: "Hub"},
+		{ID: "leaf", Name: "Leaf"},
+		{ID: "lonely", Name: "Lonely"},
+	}
+	if err := WriteAllConcepts(conceptsPath, testConcepts); err != nil {
+		t.Fatalf("WriteAllConcepts error = %v", err)
+	}
+	if _, err := db.RebuildConceptsFromJSONL(conceptsPath); err != nil {
+		t.Fatalf("RebuildConceptsFromJSONL() error = %v", err)
+	}
+
+	edgesPath := filepath.Join(tmpDir, "edges.jsonl")
+	testEdges := `{"source_id": "PaperA", "target_id": "concept:hub", "relationship_type": "introduces", "summary": "s"}
+{"source_id": "PaperB", "target_id": "concept:hub", "relationship_type": "applies", "summary": "s"}
+{"source_id": "concept:hub", "target_id": "PaperC", "relationship_type": "cites", "summary": "s"}
+{"source_id": "concept:hub", "target_id": "project:p1", "relationship_type": "implemented-in", "summary": "s"}
+{"source_id": "concept:leaf", "target_id": "concept:hub", "relationship_type": "subconcept-of", "summary": "s"}
+{"source_id": "PaperA", "target_id": "PaperB", "relationship_type": "cites", "summary": "s"}
+{"source_id": "PaperA", "target_id": "concept:missing", "relationship_type": "applies", "summary": "s"}
+`
+	if err := os.WriteFile(edgesPath, []byte(testEdges), 0644); err != nil {
+		t.Fatalf("WriteFile error = %v", err)
+	}
+	if _, err := db.RebuildEdgesFromJSONL(edgesPath); err != nil {
+		t.Fatalf("RebuildEdgesFromJSONL() error = %v", err)
+	}
+
+	stats, err := db.GetConceptEdgeStats()
+	if err != nil {
+		t.Fatalf("GetConceptEdgeStats() error = %v", err)
+	}
+	// Concepts missing from the concepts table are not reported
+	want := []ConceptEdgeStats{
+		{ConceptID: "hub", PaperCount: 3, OutgoingCount: 2, ProjectCount: 1, Total: 5},
+		{ConceptID: "leaf", PaperCount: 0, OutgoingCount: 1, ProjectCount: 0, Total: 1},
+		{ConceptID: "lonely"},
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("GetConceptEdgeStats() = %+v, want %+v", stats, want)
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("stats[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+}
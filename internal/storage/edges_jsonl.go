@@ -114,13 +114,15 @@ func FindEdgeInSlice(edges []edge.Edge, key edge.EdgeKey) (int, bool) {
 	return -1, false
 }
 
-// UpsertEdgeInSlice adds or updates an edge in an in-memory slice.
+// UpsertEdgeInSlice adds or updates an edge in an in-memory slice. An update
+// keeps the existing created_at and strength when the new edge leaves them unset.
 // Returns the updated slice and true if the edge was updated, false if added.
 func UpsertEdgeInSlice(edges []edge.Edge, newEdge edge.Edge) ([]edge.Edge, bool) {
 	key := newEdge.Key()
 	idx, found := FindEdgeInSlice(edges, key)
 	if found {
 		newEdge.MergeCreatedAt(edges[idx])
+		newEdge.MergeStrength(edges[idx])
 		edges[idx] = newEdge
 		return edges, true
 	}
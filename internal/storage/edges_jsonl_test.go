@@ -237,4 +237,21 @@ func TestUpsertEdge(t *testing.T) {
 			t.Errorf("expected CreatedAt to be preserved, got %q", result[0].CreatedAt)
 		}
 	})
+
+	t.Run("update keeps strength unless set", func(t *testing.T) {
+		old, changed := 0.8, 0.3
+		edges := []edge.Edge{
+			{SourceID: "A", TargetID: "B", RelationshipType: "cites", Summary: "Original summary", Strength: &old},
+		}
+
+		result, _ := UpsertEdge(edges, edge.Edge{SourceID: "A", TargetID: "B", RelationshipType: "cites", Summary: "Updated summary"})
+		if result[0].Strength == nil || *result[0].Strength != 0.8 {
+			t.Errorf("expected strength 0.8 to be preserved, got %v", result[0].Strength)
+		}
+
+		result, _ = UpsertEdge(result, edge.Edge{SourceID: "A", TargetID: "B", RelationshipType: "cites", Summary: "Updated summary", Strength: &changed})
+		if result[0].Strength == nil || *result[0].Strength != 0.3 {
+			t.Errorf("expected strength 0.3 to replace the old one, got %v", result[0].Strength)
+		}
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/matsen/bipartite/internal/edge"
 )
@@ -16,6 +17,7 @@ func (d *DB) ensureEdgesSchema() error {
 			target_id TEXT NOT NULL,
 			relationship_type TEXT NOT NULL,
 			summary TEXT NOT NULL,
+			strength REAL,
 			created_at TEXT,
 			PRIMARY KEY (source_id, target_id, relationship_type)
 		);
@@ -28,25 +30,35 @@ func (d *DB) ensureEdgesSchema() error {
 	if err != nil {
 		return fmt.Errorf("creating edges schema: %w", err)
 	}
-	return nil
+
+	// Indexes built before strength existed get the column added in place;
+	// the rows are repopulated on the next rebuild.
+	return d.addColumnIfMissing("edges", "strength", "REAL")
 }
 
 // queryEdges executes a query and scans the results into edges.
 // Ensures schema exists before querying.
 func (d *DB) queryEdges(query string, errorContext string, args ...interface{}) ([]edge.Edge, error) {
+	return collect(func(fn func(edge.Edge) error) error {
+		return d.queryEdgesEach(fn, query, errorContext, args...)
+	})
+}
+
+// queryEdgesEach is queryEdges calling fn with each edge as it is read.
+func (d *DB) queryEdgesEach(fn func(edge.Edge) error, query string, errorContext string, args ...interface{}) error {
 	if err := d.ensureEdgesSchema(); err != nil {
-		return nil, err
+		return err
 	}
 	rows, err := d.db.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errorContext, err)
+		return fmt.Errorf("%s: %w", errorContext, err)
 	}
-	defer rows.Close()
-	return scanEdges(rows)
+	return eachRow(rows, scanEdge, fn)
 }
 
 // RebuildEdgesFromJSONL clears the edges table and rebuilds it from a JSONL file.
-func (d *DB) RebuildEdgesFromJSONL(jsonlPath string) (int, error) {
+func (d *DB) RebuildEdgesFromJSONL(jsonlPath string) (n int, err error) {
+	defer logRebuild("edges", jsonlPath, time.Now(), &n, &err)
 	if err := d.ensureEdgesSchema(); err != nil {
 		return 0, err
 	}
@@ -64,8 +76,8 @@ func (d *DB) RebuildEdgesFromJSONL(jsonlPath string) (int, error) {
 
 	// Prepare insert statement
 	stmt, err := d.db.Prepare(`
-		INSERT INTO edges (source_id, target_id, relationship_type, summary, created_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO edges (source_id, target_id, relationship_type, summary, strength, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return 0, fmt.Errorf("preparing edges insert: %w", err)
@@ -73,32 +85,37 @@ func (d *DB) RebuildEdgesFromJSONL(jsonlPath string) (int, error) {
 	defer stmt.Close()
 
 	for _, e := range edges {
-		_, err = stmt.Exec(e.SourceID, e.TargetID, e.RelationshipType, e.Summary, e.CreatedAt)
+		_, err = stmt.Exec(e.SourceID, e.TargetID, e.RelationshipType, e.Summary, e.Strength, e.CreatedAt)
 		if err != nil {
 			return 0, fmt.Errorf("inserting edge: %w", err)
 		}
 	}
 
+	if err := d.recordIndexHash(IndexEdges, jsonlPath); err != nil {
+		return 0, err
+	}
+
 	return len(edges), nil
 }
 
-// InsertEdge inserts a single edge into the database.
+// InsertEdge inserts a single edge into the database, replacing any edge
+// with the same key.
 func (d *DB) InsertEdge(e edge.Edge) error {
 	if err := d.ensureEdgesSchema(); err != nil {
 		return err
 	}
 
 	_, err := d.db.Exec(`
-		INSERT OR REPLACE INTO edges (source_id, target_id, relationship_type, summary, created_at)
-		VALUES (?, ?, ?, ?, ?)
-	`, e.SourceID, e.TargetID, e.RelationshipType, e.Summary, e.CreatedAt)
+		INSERT OR REPLACE INTO edges (source_id, target_id, relationship_type, summary, strength, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, e.SourceID, e.TargetID, e.RelationshipType, e.Summary, e.Strength, e.CreatedAt)
 	return err
 }
 
 // GetEdgesBySource returns all edges where the given paper is the source.
 func (d *DB) GetEdgesBySource(sourceID string) ([]edge.Edge, error) {
 	return d.queryEdges(`
-		SELECT source_id, target_id, relationship_type, summary, created_at
+		SELECT source_id, target_id, relationship_type, summary, strength, created_at
 		FROM edges
 		WHERE source_id = ?
 		ORDER BY target_id
@@ -107,8 +124,16 @@ func (d *DB) GetEdgesBySource(sourceID string) ([]edge.Edge, error) {
 
 // GetEdgesByTarget returns all edges where the given paper is the target.
 func (d *DB) GetEdgesByTarget(targetID string) ([]edge.Edge, error) {
-	return d.queryEdges(`
-		SELECT source_id, target_id, relationship_type, summary, created_at
+	return collect(func(fn func(edge.Edge) error) error {
+		return d.GetEdgesByTargetEach(targetID, fn)
+	})
+}
+
+// GetEdgesByTargetEach is GetEdgesByTarget calling fn with each edge as it
+// is read.
+func (d *DB) GetEdgesByTargetEach(targetID string, fn func(edge.Edge) error) error {
+	return d.queryEdgesEach(fn, `
+		SELECT source_id, target_id, relationship_type, summary, strength, created_at
 		FROM edges
 		WHERE target_id = ?
 		ORDER BY source_id
@@ -117,8 +142,16 @@ func (d *DB) GetEdgesByTarget(targetID string) ([]edge.Edge, error) {
 
 // GetEdgesByType returns all edges with the given relationship type.
 func (d *DB) GetEdgesByType(relationshipType string) ([]edge.Edge, error) {
-	return d.queryEdges(`
-		SELECT source_id, target_id, relationship_type, summary, created_at
+	return collect(func(fn func(edge.Edge) error) error {
+		return d.GetEdgesByTypeEach(relationshipType, fn)
+	})
+}
+
+// GetEdgesByTypeEach is GetEdgesByType calling fn with each edge as it is
+// read.
+func (d *DB) GetEdgesByTypeEach(relationshipType string, fn func(edge.Edge) error) error {
+	return d.queryEdgesEach(fn, `
+		SELECT source_id, target_id, relationship_type, summary, strength, created_at
 		FROM edges
 		WHERE relationship_type = ?
 		ORDER BY source_id, target_id
@@ -127,8 +160,13 @@ func (d *DB) GetEdgesByType(relationshipType string) ([]edge.Edge, error) {
 
 // GetAllEdges returns all edges in the database.
 func (d *DB) GetAllEdges() ([]edge.Edge, error) {
-	return d.queryEdges(`
-		SELECT source_id, target_id, relationship_type, summary, created_at
+	return collect(d.GetAllEdgesEach)
+}
+
+// GetAllEdgesEach is GetAllEdges calling fn with each edge as it is read.
+func (d *DB) GetAllEdgesEach(fn func(edge.Edge) error) error {
+	return d.queryEdgesEach(fn, `
+		SELECT source_id, target_id, relationship_type, summary, strength, created_at
 		FROM edges
 		ORDER BY source_id, target_id, relationship_type
 	`, "querying all edges")
@@ -137,7 +175,7 @@ func (d *DB) GetAllEdges() ([]edge.Edge, error) {
 // GetEdgesByPaper returns all edges involving the given paper (as source or target).
 func (d *DB) GetEdgesByPaper(paperID string) ([]edge.Edge, error) {
 	return d.queryEdges(`
-		SELECT source_id, target_id, relationship_type, summary, created_at
+		SELECT source_id, target_id, relationship_type, summary, strength, created_at
 		FROM edges
 		WHERE source_id = ? OR target_id = ?
 		ORDER BY source_id, target_id, relationship_type
@@ -164,9 +202,17 @@ func (d *DB) CountEdges() (int, error) {
 
 // GetEdgesByProject returns all edges where the project (with prefix) is source or target.
 func (d *DB) GetEdgesByProject(projectID string) ([]edge.Edge, error) {
+	return collect(func(fn func(edge.Edge) error) error {
+		return d.GetEdgesByProjectEach(projectID, fn)
+	})
+}
+
+// GetEdgesByProjectEach is GetEdgesByProject calling fn with each edge as
+// it is read.
+func (d *DB) GetEdgesByProjectEach(projectID string, fn func(edge.Edge) error) error {
 	prefixedID := "project:" + projectID
-	return d.queryEdges(`
-		SELECT source_id, target_id, relationship_type, summary, created_at
+	return d.queryEdgesEach(fn, `
+		SELECT source_id, target_id, relationship_type, summary, strength, created_at
 		FROM edges
 		WHERE source_id = ? OR target_id = ?
 		ORDER BY source_id, target_id, relationship_type
@@ -177,7 +223,7 @@ func (d *DB) GetEdgesByProject(projectID string) ([]edge.Edge, error) {
 func (d *DB) GetConceptsByProject(projectID string) ([]edge.Edge, error) {
 	prefixedID := "project:" + projectID
 	return d.queryEdges(`
-		SELECT source_id, target_id, relationship_type, summary, created_at
+		SELECT source_id, target_id, relationship_type, summary, strength, created_at
 		FROM edges
 		WHERE (source_id = ? AND target_id LIKE 'concept:%')
 		   OR (target_id = ? AND source_id LIKE 'concept:%')
@@ -202,7 +248,7 @@ func (d *DB) GetPapersByProjectTransitive(projectID string) ([]edge.Edge, error)
 			WHERE (source_id = ? AND target_id LIKE 'concept:%')
 			   OR (target_id = ? AND source_id LIKE 'concept:%')
 		)
-		SELECT e.source_id, e.target_id, e.relationship_type, e.summary, e.created_at
+		SELECT e.source_id, e.target_id, e.relationship_type, e.summary, e.strength, e.created_at
 		FROM edges e
 		JOIN project_concepts pc ON e.target_id = pc.concept_id
 		WHERE e.source_id NOT LIKE '%:%'
@@ -210,20 +256,82 @@ func (d *DB) GetPapersByProjectTransitive(projectID string) ([]edge.Edge, error)
 	`, "querying papers by project transitive", prefixedID, prefixedID, prefixedID, prefixedID)
 }
 
-// scanEdges scans rows into a slice of edges.
-func scanEdges(rows *sql.Rows) ([]edge.Edge, error) {
-	var edges []edge.Edge
+// scanEdge scans one edge from a result row.
+func scanEdge(s scanner) (edge.Edge, error) {
+	var e edge.Edge
+	var strength sql.NullFloat64
+	var createdAt sql.NullString
+	err := s.Scan(&e.SourceID, &e.TargetID, &e.RelationshipType, &e.Summary, &strength, &createdAt)
+	if err != nil {
+		return edge.Edge{}, err
+	}
+	if strength.Valid {
+		e.Strength = &strength.Float64
+	}
+	if createdAt.Valid {
+		e.CreatedAt = createdAt.String
+	}
+	return e, nil
+}
+
+// RelationshipTypeCount is the number of edges with one relationship type.
+type RelationshipTypeCount struct {
+	RelationshipType string `json:"relationship_type"`
+	Count            int    `json:"count"`
+}
+
+// edgeEndpointNodeTypes are the node types accepted by GetRelationshipTypeCounts.
+var edgeEndpointNodeTypes = []string{"paper", "concept", "project"}
+
+// endpointTypeCondition returns a SQL condition that holds when column is
+// an endpoint ID of the given node type. Unprefixed IDs are papers.
+func endpointTypeCondition(column, nodeType string) string {
+	if nodeType == "paper" {
+		return fmt.Sprintf("(%[1]s NOT LIKE 'concept:%%' AND %[1]s NOT LIKE 'project:%%' AND %[1]s NOT LIKE 'repo:%%')", column)
+	}
+	return fmt.Sprintf("%s LIKE '%s:%%'", column, nodeType)
+}
+
+// GetRelationshipTypeCounts returns the number of edges per relationship
+// type, most common first, with ties ordered by type. With a nodeType
+// ("paper", "concept", or "project"), only edges with at least one endpoint
+// of that type are counted; "" counts all edges.
+func (d *DB) GetRelationshipTypeCounts(nodeType string) ([]RelationshipTypeCount, error) {
+	if err := d.ensureEdgesSchema(); err != nil {
+		return nil, err
+	}
+
+	where := ""
+	if nodeType != "" {
+		valid := false
+		for _, t := range edgeEndpointNodeTypes {
+			valid = valid || t == nodeType
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid node type %q: must be one of %s", nodeType, strings.Join(edgeEndpointNodeTypes, ", "))
+		}
+		where = "WHERE " + endpointTypeCondition("source_id", nodeType) + " OR " + endpointTypeCondition("target_id", nodeType)
+	}
+
+	rows, err := d.db.Query(`
+		SELECT relationship_type, COUNT(*) AS n
+		FROM edges
+		` + where + `
+		GROUP BY relationship_type
+		ORDER BY n DESC, relationship_type
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("counting edges by relationship type: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []RelationshipTypeCount
 	for rows.Next() {
-		var e edge.Edge
-		var createdAt sql.NullString
-		err := rows.Scan(&e.SourceID, &e.TargetID, &e.RelationshipType, &e.Summary, &createdAt)
-		if err != nil {
+		var c RelationshipTypeCount
+		if err := rows.Scan(&c.RelationshipType, &c.Count); err != nil {
 			return nil, err
 		}
-		if createdAt.Valid {
-			e.CreatedAt = createdAt.String
-		}
-		edges = append(edges, e)
+		counts = append(counts, c)
 	}
-	return edges, rows.Err()
+	return counts, rows.Err()
 }
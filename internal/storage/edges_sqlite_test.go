@@ -332,3 +332,115 @@ func TestDB_GetAllEdges_Empty(t *testing.T) {
 		t.Errorf("expected 0 edges, got %d", len(edges))
 	}
 }
+
+func TestDB_EdgeStrength(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonlPath := filepath.Join(tmpDir, "edges.jsonl")
+	content := `{"source_id":"A","target_id":"B","relationship_type":"cites","summary":"weighted","strength":0.25}
+{"source_id":"A","target_id":"C","relationship_type":"cites","summary":"unweighted"}`
+	if err := os.WriteFile(jsonlPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := OpenDB(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.RebuildEdgesFromJSONL(jsonlPath); err != nil {
+		t.Fatalf("RebuildEdgesFromJSONL failed: %v", err)
+	}
+	edges, err := db.GetEdgesBySource("A")
+	if err != nil {
+		t.Fatalf("GetEdgesBySource failed: %v", err)
+	}
+	if len(edges) != 2 {
+		t.Fatalf("expected 2 edges, got %d", len(edges))
+	}
+	if edges[0].Strength == nil || *edges[0].Strength != 0.25 {
+		t.Errorf("weighted edge strength = %v, want 0.25", edges[0].Strength)
+	}
+	if edges[1].Strength != nil {
+		t.Errorf("unweighted edge strength = %v, want nil", *edges[1].Strength)
+	}
+}
+
+func TestEnsureEdgesSchema_AddsStrengthColumn(t *testing.T) {
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	// Simulate an index created before strength was added
+	if _, err := db.db.Exec(`
+		CREATE TABLE edges (
+			source_id TEXT NOT NULL, target_id TEXT NOT NULL, relationship_type TEXT NOT NULL,
+			summary TEXT NOT NULL, created_at TEXT,
+			PRIMARY KEY (source_id, target_id, relationship_type)
+		);
+		INSERT INTO edges (source_id, target_id, relationship_type, summary) VALUES ('A', 'B', 'cites', 'old');
+	`); err != nil {
+		t.Fatalf("creating legacy table: %v", err)
+	}
+
+	edges, err := db.GetAllEdges()
+	if err != nil {
+		t.Fatalf("GetAllEdges() error = %v", err)
+	}
+	if len(edges) != 1 || edges[0].Strength != nil {
+		t.Errorf("GetAllEdges() = %+v, want one edge without strength", edges)
+	}
+}
+
+func TestDB_GetRelationshipTypeCounts(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonlPath := filepath.Join(tmpDir, "edges.jsonl")
+	content := `{"source_id":"A","target_id":"B","relationship_type":"cites","summary":"s"}
+{"source_id":"B","target_id":"C","relationship_type":"cites","summary":"s"}
+{"source_id":"A","target_id":"concept:vae","relationship_type":"introduces","summary":"s"}
+{"source_id":"B","target_id":"concept:vae","relationship_type":"applies","summary":"s"}
+{"source_id":"concept:vae","target_id":"project:dasm","relationship_type":"applied-in","summary":"s"}
+{"source_id":"concept:mcmc","target_id":"project:dasm","relationship_type":"applied-in","summary":"s"}`
+	if err := os.WriteFile(jsonlPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := OpenDB(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.RebuildEdgesFromJSONL(jsonlPath); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		nodeType string
+		want     []RelationshipTypeCount
+	}{
+		{"", []RelationshipTypeCount{{"applied-in", 2}, {"cites", 2}, {"applies", 1}, {"introduces", 1}}},
+		{"paper", []RelationshipTypeCount{{"cites", 2}, {"applies", 1}, {"introduces", 1}}},
+		{"concept", []RelationshipTypeCount{{"applied-in", 2}, {"applies", 1}, {"introduces", 1}}},
+		{"project", []RelationshipTypeCount{{"applied-in", 2}}},
+	}
+	for _, tt := range tests {
+		got, err := db.GetRelationshipTypeCounts(tt.nodeType)
+		if err != nil {
+			t.Fatalf("GetRelationshipTypeCounts(%q) error = %v", tt.nodeType, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("GetRelationshipTypeCounts(%q) = %v, want %v", tt.nodeType, got, tt.want)
+		}
+		for i := range tt.want {
+			if got[i] != tt.want[i] {
+				t.Errorf("GetRelationshipTypeCounts(%q)[%d] = %v, want %v", tt.nodeType, i, got[i], tt.want[i])
+			}
+		}
+	}
+
+	if _, err := db.GetRelationshipTypeCounts("repo"); err == nil {
+		t.Error("GetRelationshipTypeCounts(\"repo\") error = nil, want error")
+	}
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Index names for JSONL staleness tracking. Each names a group of SQLite
+// tables that is rebuilt from one JSONL file.
+const (
+	IndexConcepts = "concepts"
+	IndexEdges    = "edges"
+	IndexProjects = "projects"
+	IndexRefs     = "refs"
+	IndexRepos    = "repos"
+)
+
+// ensureIndexMetaSchema creates the table recording which JSONL contents each
+// index was last built from.
+func (d *DB) ensureIndexMetaSchema() error {
+	_, err := d.db.Exec(`
+		CREATE TABLE IF NOT EXISTS index_meta (
+			name TEXT PRIMARY KEY,
+			jsonl_hash TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("creating index_meta schema: %w", err)
+	}
+	return nil
+}
+
+// jsonlHash returns the hex SHA-256 of the file at path. A missing file
+// hashes as empty, matching a JSONL file with no records.
+func jsonlHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			h := sha256.Sum256(nil)
+			return hex.EncodeToString(h[:]), nil
+		}
+		return "", fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("reading file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// recordIndexHash stores the current hash of jsonlPath as the source of index.
+func (d *DB) recordIndexHash(index, jsonlPath string) error {
+	if err := d.ensureIndexMetaSchema(); err != nil {
+		return err
+	}
+	hash, err := jsonlHash(jsonlPath)
+	if err != nil {
+		return fmt.Errorf("hashing %s: %w", jsonlPath, err)
+	}
+	_, err = d.db.Exec(`INSERT OR REPLACE INTO index_meta (name, jsonl_hash) VALUES (?, ?)`, index, hash)
+	if err != nil {
+		return fmt.Errorf("recording %s index hash: %w", index, err)
+	}
+	return nil
+}
+
+// IndexIsCurrent reports whether index was last built from the current
+// contents of jsonlPath. Any error (no recorded hash, unreadable file) is
+// reported as not current, so callers fall back to a full rebuild.
+//
+// Call this before modifying jsonlPath and pass the result to
+// ApplyIndexUpdate afterwards.
+func (d *DB) IndexIsCurrent(index, jsonlPath string) bool {
+	if err := d.ensureIndexMetaSchema(); err != nil {
+		return false
+	}
+	var stored string
+	err := d.db.QueryRow(`SELECT jsonl_hash FROM index_meta WHERE name = ?`, index).Scan(&stored)
+	if err != nil {
+		return false
+	}
+	current, err := jsonlHash(jsonlPath)
+	if err != nil {
+		return false
+	}
+	return stored == current
+}
+
+// ApplyIndexUpdate brings index up to date after a write to jsonlPath.
+//
+// If the index was current before the write (wasCurrent, from
+// IndexIsCurrent), the write changed only the records that upsert re-indexes,
+// so upsert runs and the new JSONL hash is recorded. Otherwise the JSONL has
+// drifted from the index (e.g. after a git pull) and the index is rebuilt in
+// full from jsonlPath.
+//
+// The single-record writers (UpsertRef, DeleteRef, UpsertConcept,
+// UpsertProject, UpsertRepo, InsertEdge) leave the recorded hash alone, so
+// call them through upsert rather than directly; this is what lets a stale
+// index fall back to a full rebuild.
+func (d *DB) ApplyIndexUpdate(index, jsonlPath string, wasCurrent bool, upsert func() error) error {
+	if !wasCurrent {
+		return d.rebuildIndex(index, jsonlPath)
+	}
+	if err := upsert(); err != nil {
+		return err
+	}
+	return d.recordIndexHash(index, jsonlPath)
+}
+
+// rebuildIndex dispatches to the full rebuild for index.
+func (d *DB) rebuildIndex(index, jsonlPath string) error {
+	var err error
+	switch index {
+	case IndexConcepts:
+		_, err = d.RebuildConceptsFromJSONL(jsonlPath)
+	case IndexEdges:
+		_, err = d.RebuildEdgesFromJSONL(jsonlPath)
+	case IndexProjects:
+		_, err = d.RebuildProjectsFromJSONL(jsonlPath)
+	case IndexRefs:
+		_, err = d.RebuildFromJSONL(jsonlPath)
+	case IndexRepos:
+		_, err = d.RebuildReposFromJSONL(jsonlPath)
+	default:
+		err = fmt.Errorf("unknown index %q", index)
+	}
+	return err
+}
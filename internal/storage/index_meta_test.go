@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matsen/bipartite/internal/concept"
+	"github.com/matsen/bipartite/internal/repo"
+)
+
+func TestJSONLHash(t *testing.T) {
+	tmpDir := t.TempDir()
+	missing, err := jsonlHash(filepath.Join(tmpDir, "missing.jsonl"))
+	if err != nil {
+		t.Fatalf("jsonlHash(missing) error = %v", err)
+	}
+	emptyPath := filepath.Join(tmpDir, "empty.jsonl")
+	if err := os.WriteFile(emptyPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	empty, err := jsonlHash(emptyPath)
+	if err != nil {
+		t.Fatalf("jsonlHash(empty) error = %v", err)
+	}
+	if missing != empty {
+		t.Errorf("missing file hash %s != empty file hash %s", missing, empty)
+	}
+}
+
+func TestIndexIsCurrent(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := OpenDB(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	conceptsPath := filepath.Join(tmpDir, "concepts.jsonl")
+	if err := WriteAllConcepts(conceptsPath, []concept.Concept{{ID: "shm", Name: "Somatic hypermutation"}}); err != nil {
+		t.Fatalf("WriteAllConcepts() error = %v", err)
+	}
+
+	if db.IndexIsCurrent(IndexConcepts, conceptsPath) {
+		t.Error("IndexIsCurrent() = true before any rebuild")
+	}
+
+	if _, err := db.RebuildConceptsFromJSONL(conceptsPath); err != nil {
+		t.Fatalf("RebuildConceptsFromJSONL() error = %v", err)
+	}
+	if !db.IndexIsCurrent(IndexConcepts, conceptsPath) {
+		t.Error("IndexIsCurrent() = false right after rebuild")
+	}
+
+	// An edit outside bip (e.g. git pull) makes the index stale
+	if err := AppendConcept(conceptsPath, concept.Concept{ID: "vae", Name: "VAE"}); err != nil {
+		t.Fatalf("AppendConcept() error = %v", err)
+	}
+	if db.IndexIsCurrent(IndexConcepts, conceptsPath) {
+		t.Error("IndexIsCurrent() = true after external change")
+	}
+}
+
+func TestApplyIndexUpdate_Upsert(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := OpenDB(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	conceptsPath := filepath.Join(tmpDir, "concepts.jsonl")
+	orig := concept.Concept{ID: "shm", Name: "Somatic hypermutation"}
+	if err := WriteAllConcepts(conceptsPath, []concept.Concept{orig}); err != nil {
+		t.Fatalf("WriteAllConcepts() error = %v", err)
+	}
+	if _, err := db.RebuildConceptsFromJSONL(conceptsPath); err != nil {
+		t.Fatalf("RebuildConceptsFromJSONL() error = %v", err)
+	}
+
+	current := db.IndexIsCurrent(IndexConcepts, conceptsPath)
+	updated := concept.Concept{ID: "shm", Name: "Somatic hypermutation", Aliases: []string{"SHM"}}
+	if err := WriteAllConcepts(conceptsPath, []concept.Concept{updated}); err != nil {
+		t.Fatalf("WriteAllConcepts() error = %v", err)
+	}
+
+	upserted := false
+	err = db.ApplyIndexUpdate(IndexConcepts, conceptsPath, current, func() error {
+		upserted = true
+		return db.UpsertConcept(updated)
+	})
+	if err != nil {
+		t.Fatalf("ApplyIndexUpdate() error = %v", err)
+	}
+	if !upserted {
+		t.Error("ApplyIndexUpdate() did not take the upsert path on a current index")
+	}
+	if !db.IndexIsCurrent(IndexConcepts, conceptsPath) {
+		t.Error("IndexIsCurrent() = false after upsert")
+	}
+
+	// The old FTS row is replaced, not duplicated
+	results, err := db.SearchConcepts("SHM", 10)
+	if err != nil {
+		t.Fatalf("SearchConcepts() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("SearchConcepts('SHM') returned %d results, want 1", len(results))
+	}
+	results, err = db.SearchConcepts("Somatic", 10)
+	if err != nil {
+		t.Fatalf("SearchConcepts() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("SearchConcepts('Somatic') returned %d results, want 1", len(results))
+	}
+}
+
+func TestApplyIndexUpdate_StaleRebuilds(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := OpenDB(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	// Start from the fixture, then drift: another process adds a repo
+	reposPath := filepath.Join(tmpDir, "repos.jsonl")
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", "repos", "valid.jsonl"))
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	if err := os.WriteFile(reposPath, data, 0644); err != nil {
+		t.Fatalf("writing repos: %v", err)
+	}
+	if _, err := db.RebuildReposFromJSONL(reposPath); err != nil {
+		t.Fatalf("RebuildReposFromJSONL() error = %v", err)
+	}
+	pulled := repo.Repo{ID: "pulled", Project: "dasm2", Type: repo.TypeManual, Name: "Pulled"}
+	if err := AppendRepo(reposPath, pulled); err != nil {
+		t.Fatalf("AppendRepo() error = %v", err)
+	}
+
+	current := db.IndexIsCurrent(IndexRepos, reposPath)
+	added := repo.Repo{ID: "added", Project: "dasm2", Type: repo.TypeManual, Name: "Added"}
+	if err := AppendRepo(reposPath, added); err != nil {
+		t.Fatalf("AppendRepo() error = %v", err)
+	}
+
+	upserted := false
+	err = db.ApplyIndexUpdate(IndexRepos, reposPath, current, func() error {
+		upserted = true
+		return db.UpsertRepo(added)
+	})
+	if err != nil {
+		t.Fatalf("ApplyIndexUpdate() error = %v", err)
+	}
+	if upserted {
+		t.Error("ApplyIndexUpdate() upserted on a stale index, want full rebuild")
+	}
+
+	// The rebuild picks up both the drifted and the new record
+	for _, id := range []string{"pulled", "added"} {
+		r, err := db.GetRepoByID(id)
+		if err != nil {
+			t.Fatalf("GetRepoByID(%q) error = %v", id, err)
+		}
+		if r == nil {
+			t.Errorf("GetRepoByID(%q) = nil after rebuild", id)
+		}
+	}
+	if !db.IndexIsCurrent(IndexRepos, reposPath) {
+		t.Error("IndexIsCurrent() = false after rebuild")
+	}
+}
@@ -132,6 +132,16 @@ func FindByID(refs []reference.Reference, id string) (int, bool) {
 	return -1, false
 }
 
+// DeleteByID removes the reference with the given ID, preserving the order
+// of the rest. Returns the updated slice and whether a reference was removed.
+func DeleteByID(refs []reference.Reference, id string) ([]reference.Reference, bool) {
+	idx, found := FindByID(refs, id)
+	if !found {
+		return refs, false
+	}
+	return append(refs[:idx], refs[idx+1:]...), true
+}
+
 // FindBySourceID searches for a reference by import source type and ID.
 func FindBySourceID(refs []reference.Reference, sourceType, sourceID string) (int, bool) {
 	if sourceID == "" {
@@ -313,6 +313,30 @@ func TestFindByID(t *testing.T) {
 	}
 }
 
+func TestDeleteByID(t *testing.T) {
+	refs := []reference.Reference{
+		{ID: "Smith2026"},
+		{ID: "Jones2025"},
+		{ID: "Brown2024"},
+	}
+
+	result, deleted := DeleteByID(refs, "Jones2025")
+	if !deleted {
+		t.Error("DeleteByID() deleted = false, want true")
+	}
+	if len(result) != 2 || result[0].ID != "Smith2026" || result[1].ID != "Brown2024" {
+		t.Errorf("DeleteByID() = %v, want [Smith2026 Brown2024] in order", result)
+	}
+
+	result, deleted = DeleteByID(result, "NotFound")
+	if deleted {
+		t.Error("DeleteByID() deleted = true for nonexistent, want false")
+	}
+	if len(result) != 2 {
+		t.Errorf("DeleteByID() len = %d, want 2", len(result))
+	}
+}
+
 func TestFindBySourceID(t *testing.T) {
 	refs := []reference.Reference{
 		{ID: "A", Source: reference.ImportSource{Type: "paperpile", ID: "pp-uuid-1"}},
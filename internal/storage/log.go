@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"log/slog"
+	"time"
+)
+
+// logRebuild logs, at debug level, how long rebuilding table from jsonlPath
+// took. Rebuild functions defer it with pointers to their named results.
+func logRebuild(table, jsonlPath string, start time.Time, n *int, err *error) {
+	attrs := []any{"table", table, "path", jsonlPath, "rows", *n, "duration", time.Since(start).Round(time.Millisecond)}
+	if *err != nil {
+		slog.Debug("sqlite rebuild failed", append(attrs, "error", *err)...)
+		return
+	}
+	slog.Debug("sqlite rebuild", attrs...)
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/matsen/bipartite/internal/project"
 )
@@ -26,7 +27,8 @@ func (d *DB) ensureProjectsSchema() error {
 }
 
 // RebuildProjectsFromJSONL clears the projects table and rebuilds it from a JSONL file.
-func (d *DB) RebuildProjectsFromJSONL(jsonlPath string) (int, error) {
+func (d *DB) RebuildProjectsFromJSONL(jsonlPath string) (n int, err error) {
+	defer logRebuild("projects", jsonlPath, time.Now(), &n, &err)
 	if err := d.ensureProjectsSchema(); err != nil {
 		return 0, err
 	}
@@ -59,9 +61,27 @@ func (d *DB) RebuildProjectsFromJSONL(jsonlPath string) (int, error) {
 		}
 	}
 
+	if err := d.recordIndexHash(IndexProjects, jsonlPath); err != nil {
+		return 0, err
+	}
 	return len(projects), nil
 }
 
+// UpsertProject inserts or replaces a single project.
+func (d *DB) UpsertProject(p project.Project) error {
+	if err := d.ensureProjectsSchema(); err != nil {
+		return err
+	}
+	_, err := d.db.Exec(`
+		INSERT OR REPLACE INTO projects (id, name, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, p.ID, p.Name, nullableStringFromGo(p.Description), p.CreatedAt, p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upserting project %s: %w", p.ID, err)
+	}
+	return nil
+}
+
 // GetProjectByID retrieves a project by its ID.
 func (d *DB) GetProjectByID(id string) (*project.Project, error) {
 	if err := d.ensureProjectsSchema(); err != nil {
@@ -96,6 +116,33 @@ func (d *DB) GetAllProjects() ([]project.Project, error) {
 	return scanProjects(rows)
 }
 
+// GetProjectsPaged returns one page of projects ordered by ID: up to limit
+// (0 = no limit) after skipping offset.
+func (d *DB) GetProjectsPaged(limit, offset int) ([]project.Project, error) {
+	return collect(func(fn func(project.Project) error) error {
+		return d.GetProjectsPagedEach(limit, offset, fn)
+	})
+}
+
+// GetProjectsPagedEach is GetProjectsPaged calling fn with each project as
+// it is read.
+func (d *DB) GetProjectsPagedEach(limit, offset int, fn func(project.Project) error) error {
+	if err := d.ensureProjectsSchema(); err != nil {
+		return err
+	}
+
+	rows, err := d.db.Query(`
+		SELECT id, name, description, created_at, updated_at
+		FROM projects
+		ORDER BY id
+		LIMIT ? OFFSET ?
+	`, sqlLimit(limit), offset)
+	if err != nil {
+		return fmt.Errorf("querying projects: %w", err)
+	}
+	return eachRow(rows, scanProjectRow, fn)
+}
+
 // CountProjects returns the total number of projects.
 func (d *DB) CountProjects() (int, error) {
 	if err := d.ensureProjectsSchema(); err != nil {
@@ -137,15 +184,18 @@ func scanProject(row *sql.Row) (*project.Project, error) {
 	return &p, nil
 }
 
+// scanProjectRow scans one project from a result row.
+func scanProjectRow(s scanner) (project.Project, error) {
+	var f projectScanFields
+	if err := s.Scan(&f.id, &f.name, &f.description, &f.createdAt, &f.updatedAt); err != nil {
+		return project.Project{}, err
+	}
+	return f.toProject(), nil
+}
+
 // scanProjects scans multiple projects from rows.
 func scanProjects(rows *sql.Rows) ([]project.Project, error) {
-	var projects []project.Project
-	for rows.Next() {
-		var f projectScanFields
-		if err := rows.Scan(&f.id, &f.name, &f.description, &f.createdAt, &f.updatedAt); err != nil {
-			return nil, err
-		}
-		projects = append(projects, f.toProject())
-	}
-	return projects, rows.Err()
+	return collect(func(fn func(project.Project) error) error {
+		return eachRow(rows, scanProjectRow, fn)
+	})
 }
@@ -102,6 +102,41 @@ func TestGetAllProjects(t *testing.T) {
 	}
 }
 
+func TestGetProjectsPaged(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	db, err := OpenDB(dbPath)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.RebuildProjectsFromJSONL("../../testdata/projects/valid.jsonl"); err != nil {
+		t.Fatalf("RebuildProjectsFromJSONL() error = %v", err)
+	}
+
+	page, err := db.GetProjectsPaged(2, 1)
+	if err != nil {
+		t.Fatalf("GetProjectsPaged() error = %v", err)
+	}
+	all, err := db.GetAllProjects()
+	if err != nil {
+		t.Fatalf("GetAllProjects() error = %v", err)
+	}
+	if len(page) != 2 || page[0].ID != all[1].ID || page[1].ID != all[2].ID {
+		t.Errorf("GetProjectsPaged(2, 1) = %v, want projects 1-2 of %v", page, all)
+	}
+
+	page, err = db.GetProjectsPaged(0, 0)
+	if err != nil {
+		t.Fatalf("GetProjectsPaged() error = %v", err)
+	}
+	if len(page) != len(all) {
+		t.Errorf("GetProjectsPaged(0, 0) len = %d, want all %d", len(page), len(all))
+	}
+}
+
 func TestRebuildProjectsFromJSONL_Empty(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "test.db")
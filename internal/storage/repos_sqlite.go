@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/matsen/bipartite/internal/repo"
 )
@@ -20,6 +22,8 @@ func (d *DB) ensureReposSchema() error {
 			description TEXT,
 			topics_json TEXT,
 			language TEXT,
+			stars INTEGER,
+			last_pushed_at TEXT,
 			created_at TEXT,
 			updated_at TEXT,
 			UNIQUE(github_url)
@@ -32,11 +36,48 @@ func (d *DB) ensureReposSchema() error {
 	if err != nil {
 		return fmt.Errorf("creating repos schema: %w", err)
 	}
+
+	// Indexes built before these columns existed need them added in place;
+	// the rows are repopulated on the next rebuild.
+	if err := d.addColumnIfMissing("repos", "stars", "INTEGER"); err != nil {
+		return err
+	}
+	return d.addColumnIfMissing("repos", "last_pushed_at", "TEXT")
+}
+
+// addColumnIfMissing adds a column to an existing table unless it is already present.
+func (d *DB) addColumnIfMissing(table, column, definition string) error {
+	rows, err := d.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("reading %s schema: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return fmt.Errorf("reading %s schema: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("reading %s schema: %w", table, err)
+	}
+	rows.Close()
+
+	if _, err := d.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("adding %s.%s column: %w", table, column, err)
+	}
 	return nil
 }
 
 // RebuildReposFromJSONL clears the repos table and rebuilds it from a JSONL file.
-func (d *DB) RebuildReposFromJSONL(jsonlPath string) (int, error) {
+func (d *DB) RebuildReposFromJSONL(jsonlPath string) (n int, err error) {
+	defer logRebuild("repos", jsonlPath, time.Now(), &n, &err)
 	if err := d.ensureReposSchema(); err != nil {
 		return 0, err
 	}
@@ -54,8 +95,8 @@ func (d *DB) RebuildReposFromJSONL(jsonlPath string) (int, error) {
 
 	// Prepare insert statement
 	stmt, err := d.db.Prepare(`
-		INSERT INTO repos (id, project, type, name, github_url, description, topics_json, language, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO repos (id, project, type, name, github_url, description, topics_json, language, stars, last_pushed_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return 0, fmt.Errorf("preparing repos insert: %w", err)
@@ -63,32 +104,62 @@ func (d *DB) RebuildReposFromJSONL(jsonlPath string) (int, error) {
 	defer stmt.Close()
 
 	for _, r := range repos {
-		// Serialize topics to JSON
-		var topicsJSON string
-		if len(r.Topics) > 0 {
-			topicsBytes, err := json.Marshal(r.Topics)
-			if err != nil {
-				return 0, fmt.Errorf("marshaling topics for %s: %w", r.ID, err)
-			}
-			topicsJSON = string(topicsBytes)
-		}
-
-		_, err = stmt.Exec(
-			r.ID, r.Project, r.Type, r.Name,
-			nullableStringFromGo(r.GitHubURL),
-			nullableStringFromGo(r.Description),
-			nullableStringFromGo(topicsJSON),
-			nullableStringFromGo(r.Language),
-			r.CreatedAt, r.UpdatedAt,
-		)
+		args, err := repoRowArgs(r)
 		if err != nil {
+			return 0, err
+		}
+		if _, err := stmt.Exec(args...); err != nil {
 			return 0, fmt.Errorf("inserting repo %s: %w", r.ID, err)
 		}
 	}
 
+	if err := d.recordIndexHash(IndexRepos, jsonlPath); err != nil {
+		return 0, err
+	}
 	return len(repos), nil
 }
 
+// UpsertRepo inserts or replaces a single repo.
+func (d *DB) UpsertRepo(r repo.Repo) error {
+	if err := d.ensureReposSchema(); err != nil {
+		return err
+	}
+	args, err := repoRowArgs(r)
+	if err != nil {
+		return err
+	}
+	_, err = d.db.Exec(`
+		INSERT OR REPLACE INTO repos (id, project, type, name, github_url, description, topics_json, language, stars, last_pushed_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, args...)
+	if err != nil {
+		return fmt.Errorf("upserting repo %s: %w", r.ID, err)
+	}
+	return nil
+}
+
+// repoRowArgs returns the column values for a repos row, in insert order.
+func repoRowArgs(r repo.Repo) ([]any, error) {
+	var topicsJSON string
+	if len(r.Topics) > 0 {
+		topicsBytes, err := json.Marshal(r.Topics)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling topics for %s: %w", r.ID, err)
+		}
+		topicsJSON = string(topicsBytes)
+	}
+	return []any{
+		r.ID, r.Project, r.Type, r.Name,
+		nullableStringFromGo(r.GitHubURL),
+		nullableStringFromGo(r.Description),
+		nullableStringFromGo(topicsJSON),
+		nullableStringFromGo(r.Language),
+		r.Stars,
+		nullableStringFromGo(r.LastPushedAt),
+		r.CreatedAt, r.UpdatedAt,
+	}, nil
+}
+
 // GetRepoByID retrieves a repo by its ID.
 func (d *DB) GetRepoByID(id string) (*repo.Repo, error) {
 	if err := d.ensureReposSchema(); err != nil {
@@ -96,7 +167,7 @@ func (d *DB) GetRepoByID(id string) (*repo.Repo, error) {
 	}
 
 	row := d.db.QueryRow(`
-		SELECT id, project, type, name, github_url, description, topics_json, language, created_at, updated_at
+		SELECT id, project, type, name, github_url, description, topics_json, language, stars, last_pushed_at, created_at, updated_at
 		FROM repos
 		WHERE id = ?
 	`, id)
@@ -106,41 +177,98 @@ func (d *DB) GetRepoByID(id string) (*repo.Repo, error) {
 
 // GetAllRepos returns all repos in the database.
 func (d *DB) GetAllRepos() ([]repo.Repo, error) {
+	return collect(d.GetAllReposEach)
+}
+
+// GetAllReposEach is GetAllRepos calling fn with each repo as it is read.
+func (d *DB) GetAllReposEach(fn func(repo.Repo) error) error {
 	if err := d.ensureReposSchema(); err != nil {
-		return nil, err
+		return err
 	}
 
 	rows, err := d.db.Query(`
-		SELECT id, project, type, name, github_url, description, topics_json, language, created_at, updated_at
+		SELECT id, project, type, name, github_url, description, topics_json, language, stars, last_pushed_at, created_at, updated_at
 		FROM repos
 		ORDER BY project, id
 	`)
 	if err != nil {
-		return nil, fmt.Errorf("querying repos: %w", err)
+		return fmt.Errorf("querying repos: %w", err)
 	}
-	defer rows.Close()
-
-	return scanRepos(rows)
+	return eachRow(rows, scanRepoRow, fn)
 }
 
 // GetReposByProject returns all repos belonging to a project.
 func (d *DB) GetReposByProject(projectID string) ([]repo.Repo, error) {
+	return collect(func(fn func(repo.Repo) error) error {
+		return d.GetReposByProjectEach(projectID, fn)
+	})
+}
+
+// GetReposByProjectEach is GetReposByProject calling fn with each repo as
+// it is read.
+func (d *DB) GetReposByProjectEach(projectID string, fn func(repo.Repo) error) error {
 	if err := d.ensureReposSchema(); err != nil {
-		return nil, err
+		return err
 	}
 
 	rows, err := d.db.Query(`
-		SELECT id, project, type, name, github_url, description, topics_json, language, created_at, updated_at
+		SELECT id, project, type, name, github_url, description, topics_json, language, stars, last_pushed_at, created_at, updated_at
 		FROM repos
 		WHERE project = ?
 		ORDER BY id
 	`, projectID)
 	if err != nil {
-		return nil, fmt.Errorf("querying repos by project: %w", err)
+		return fmt.Errorf("querying repos by project: %w", err)
 	}
-	defer rows.Close()
+	return eachRow(rows, scanRepoRow, fn)
+}
 
-	return scanRepos(rows)
+// SearchRepos returns repos matching all given filters (AND semantics).
+// An empty language matches any language; each topic in topics must be present.
+// Language and topic comparisons are case-insensitive.
+//
+// Topics stay in the topics_json column and are matched with SQLite's
+// json_each rather than a normalized repo_topics table. A separate table would
+// allow an index on topic, but it would have to be kept in sync by every
+// repo write path, not just RebuildReposFromJSONL. Repo counts are small
+// (tens to hundreds), so a scan is cheap, and filtering in SQL rather than in
+// Go keeps the result ordering and future LIMIT/COUNT queries in one place.
+func (d *DB) SearchRepos(language string, topics []string) ([]repo.Repo, error) {
+	return collect(func(fn func(repo.Repo) error) error {
+		return d.SearchReposEach(language, topics, fn)
+	})
+}
+
+// SearchReposEach is SearchRepos calling fn with each match as it is read.
+func (d *DB) SearchReposEach(language string, topics []string, fn func(repo.Repo) error) error {
+	if err := d.ensureReposSchema(); err != nil {
+		return err
+	}
+
+	var where []string
+	var args []any
+	if language != "" {
+		where = append(where, "language = ? COLLATE NOCASE")
+		args = append(args, language)
+	}
+	for _, topic := range topics {
+		where = append(where, "EXISTS (SELECT 1 FROM json_each(repos.topics_json) WHERE lower(json_each.value) = lower(?))")
+		args = append(args, topic)
+	}
+
+	query := `
+		SELECT id, project, type, name, github_url, description, topics_json, language, stars, last_pushed_at, created_at, updated_at
+		FROM repos`
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	query += "\n\t\tORDER BY project, id"
+
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("searching repos: %w", err)
+	}
+	return eachRow(rows, scanRepoRow, fn)
 }
 
 // CountRepos returns the total number of repos.
@@ -158,21 +286,27 @@ func (d *DB) CountRepos() (int, error) {
 type repoScanFields struct {
 	id, project, repoType, name                  sql.NullString
 	githubURL, description, topicsJSON, language sql.NullString
-	createdAt, updatedAt                         sql.NullString
+	stars                                        sql.NullInt64
+	lastPushedAt, createdAt, updatedAt           sql.NullString
 }
 
 // toRepo converts scanned fields to a Repo struct, parsing topics JSON.
 func (f *repoScanFields) toRepo() (repo.Repo, error) {
 	r := repo.Repo{
-		ID:          f.id.String,
-		Project:     f.project.String,
-		Type:        f.repoType.String,
-		Name:        f.name.String,
-		GitHubURL:   f.githubURL.String,
-		Description: f.description.String,
-		Language:    f.language.String,
-		CreatedAt:   f.createdAt.String,
-		UpdatedAt:   f.updatedAt.String,
+		ID:           f.id.String,
+		Project:      f.project.String,
+		Type:         f.repoType.String,
+		Name:         f.name.String,
+		GitHubURL:    f.githubURL.String,
+		Description:  f.description.String,
+		Language:     f.language.String,
+		LastPushedAt: f.lastPushedAt.String,
+		CreatedAt:    f.createdAt.String,
+		UpdatedAt:    f.updatedAt.String,
+	}
+	if f.stars.Valid {
+		stars := int(f.stars.Int64)
+		r.Stars = &stars
 	}
 	if f.topicsJSON.Valid && f.topicsJSON.String != "" {
 		if err := json.Unmarshal([]byte(f.topicsJSON.String), &r.Topics); err != nil {
@@ -185,7 +319,7 @@ func (f *repoScanFields) toRepo() (repo.Repo, error) {
 // scanRepo scans a single repo from a row.
 func scanRepo(row *sql.Row) (*repo.Repo, error) {
 	var f repoScanFields
-	err := row.Scan(&f.id, &f.project, &f.repoType, &f.name, &f.githubURL, &f.description, &f.topicsJSON, &f.language, &f.createdAt, &f.updatedAt)
+	err := row.Scan(&f.id, &f.project, &f.repoType, &f.name, &f.githubURL, &f.description, &f.topicsJSON, &f.language, &f.stars, &f.lastPushedAt, &f.createdAt, &f.updatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -199,19 +333,11 @@ func scanRepo(row *sql.Row) (*repo.Repo, error) {
 	return &r, nil
 }
 
-// scanRepos scans multiple repos from rows.
-func scanRepos(rows *sql.Rows) ([]repo.Repo, error) {
-	var repos []repo.Repo
-	for rows.Next() {
-		var f repoScanFields
-		if err := rows.Scan(&f.id, &f.project, &f.repoType, &f.name, &f.githubURL, &f.description, &f.topicsJSON, &f.language, &f.createdAt, &f.updatedAt); err != nil {
-			return nil, err
-		}
-		r, err := f.toRepo()
-		if err != nil {
-			return nil, err
-		}
-		repos = append(repos, r)
+// scanRepoRow scans one repo from a result row.
+func scanRepoRow(s scanner) (repo.Repo, error) {
+	var f repoScanFields
+	if err := s.Scan(&f.id, &f.project, &f.repoType, &f.name, &f.githubURL, &f.description, &f.topicsJSON, &f.language, &f.stars, &f.lastPushedAt, &f.createdAt, &f.updatedAt); err != nil {
+		return repo.Repo{}, err
 	}
-	return repos, rows.Err()
+	return f.toRepo()
 }
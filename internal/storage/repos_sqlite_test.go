@@ -183,3 +183,130 @@ func TestRebuildReposFromJSONL_NotFound(t *testing.T) {
 		t.Errorf("RebuildReposFromJSONL() count = %d, want 0", count)
 	}
 }
+
+func TestSearchRepos(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	db, err := OpenDB(dbPath)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.RebuildReposFromJSONL("../../testdata/repos/valid.jsonl")
+	if err != nil {
+		t.Fatalf("RebuildReposFromJSONL() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		language string
+		topics   []string
+		want     []string
+	}{
+		{"no filters", "", nil, []string{"bipartite-code", "dasm2-code", "dasm2-paper", "internal-tools"}},
+		{"language case-insensitive", "go", nil, []string{"bipartite-code"}},
+		{"single topic", "", []string{"antibodies"}, []string{"dasm2-code"}},
+		{"topics are ANDed", "", []string{"antibodies", "ml"}, []string{"dasm2-code"}},
+		{"topic and language are ANDed", "Go", []string{"antibodies"}, nil},
+		{"topic case-insensitive", "", []string{"CLI"}, []string{"bipartite-code"}},
+		{"unknown topic", "", []string{"nope"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, err := db.SearchRepos(tt.language, tt.topics)
+			if err != nil {
+				t.Fatalf("SearchRepos() error = %v", err)
+			}
+			var got []string
+			for _, r := range repos {
+				got = append(got, r.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SearchRepos() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("SearchRepos() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRepoActivityFields(t *testing.T) {
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.RebuildReposFromJSONL("../../testdata/repos/valid.jsonl"); err != nil {
+		t.Fatalf("RebuildReposFromJSONL() error = %v", err)
+	}
+
+	r, err := db.GetRepoByID("bipartite-code")
+	if err != nil || r == nil {
+		t.Fatalf("GetRepoByID() = %v, %v", r, err)
+	}
+	if r.Stars == nil || *r.Stars != 42 || r.LastPushedAt != "2026-01-20T08:30:00Z" {
+		t.Errorf("Stars = %v, LastPushedAt = %q; want 42, 2026-01-20T08:30:00Z", r.Stars, r.LastPushedAt)
+	}
+
+	// Records written before these fields existed load as never fetched
+	r, err = db.GetRepoByID("dasm2-code")
+	if err != nil || r == nil {
+		t.Fatalf("GetRepoByID() = %v, %v", r, err)
+	}
+	if r.Stars != nil || r.LastPushedAt != "" {
+		t.Errorf("Stars = %v, LastPushedAt = %q; want nil, empty", r.Stars, r.LastPushedAt)
+	}
+
+	// A fetched repo with no stars keeps its 0, distinct from never fetched
+	zero := 0
+	r.Stars = &zero
+	if err := db.UpsertRepo(*r); err != nil {
+		t.Fatalf("UpsertRepo() error = %v", err)
+	}
+	r, err = db.GetRepoByID("dasm2-code")
+	if err != nil || r == nil {
+		t.Fatalf("GetRepoByID() = %v, %v", r, err)
+	}
+	if r.Stars == nil || *r.Stars != 0 {
+		t.Errorf("Stars = %v after upserting 0, want 0", r.Stars)
+	}
+}
+
+func TestEnsureReposSchema_AddsActivityColumns(t *testing.T) {
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	// Simulate an index created before stars/last_pushed_at were added
+	if _, err := db.db.Exec(`
+		CREATE TABLE repos (
+			id TEXT PRIMARY KEY, project TEXT NOT NULL, type TEXT NOT NULL, name TEXT NOT NULL,
+			github_url TEXT, description TEXT, topics_json TEXT, language TEXT,
+			created_at TEXT, updated_at TEXT, UNIQUE(github_url)
+		);
+		INSERT INTO repos (id, project, type, name) VALUES ('old', 'p', 'manual', 'Old');
+	`); err != nil {
+		t.Fatalf("creating legacy table: %v", err)
+	}
+
+	repos, err := db.GetAllRepos()
+	if err != nil {
+		t.Fatalf("GetAllRepos() error = %v", err)
+	}
+	if len(repos) != 1 || repos[0].Stars != nil {
+		t.Errorf("GetAllRepos() = %+v, want one repo with no stars", repos)
+	}
+
+	if _, err := db.RebuildReposFromJSONL("../../testdata/repos/valid.jsonl"); err != nil {
+		t.Fatalf("RebuildReposFromJSONL() after migration error = %v", err)
+	}
+}
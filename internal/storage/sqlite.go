@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/matsen/bipartite/internal/author"
 	"github.com/matsen/bipartite/internal/reference"
@@ -78,6 +79,9 @@ func createSchema(db *sql.DB) error {
 		-- Index for DOI lookups
 		CREATE INDEX IF NOT EXISTS idx_refs_doi ON refs(doi) WHERE doi IS NOT NULL AND doi != '';
 
+		-- Index for reverse supersedes lookups
+		CREATE INDEX IF NOT EXISTS idx_refs_supersedes ON refs(supersedes) WHERE supersedes IS NOT NULL AND supersedes != '';
+
 		-- Full-text search virtual table (standalone, not external content)
 		CREATE VIRTUAL TABLE IF NOT EXISTS refs_fts USING fts5(
 			id,
@@ -103,7 +107,8 @@ func createSchema(db *sql.DB) error {
 }
 
 // RebuildFromJSONL clears the database and rebuilds it from a JSONL file.
-func (d *DB) RebuildFromJSONL(jsonlPath string) (int, error) {
+func (d *DB) RebuildFromJSONL(jsonlPath string) (n int, err error) {
+	defer logRebuild("refs", jsonlPath, time.Now(), &n, &err)
 	// Read all references from JSONL
 	refs, err := ReadAll(jsonlPath)
 	if err != nil {
@@ -143,53 +148,106 @@ func (d *DB) RebuildFromJSONL(jsonlPath string) (int, error) {
 	defer ftsStmt.Close()
 
 	for _, ref := range refs {
-		authorsJSON, err := json.Marshal(ref.Authors)
+		args, err := refRowArgs(ref)
 		if err != nil {
-			return 0, fmt.Errorf("marshaling authors for %s: %w", ref.ID, err)
+			return 0, err
 		}
-		var supplementJSON []byte
-		if len(ref.SupplementPaths) > 0 {
-			supplementJSON, err = json.Marshal(ref.SupplementPaths)
-			if err != nil {
-				return 0, fmt.Errorf("marshaling supplement paths for %s: %w", ref.ID, err)
-			}
+		if _, err := refsStmt.Exec(args...); err != nil {
+			return 0, fmt.Errorf("inserting ref %s: %w", ref.ID, err)
 		}
-		var tagsJSON []byte
-		if len(ref.Tags) > 0 {
-			tagsJSON, err = json.Marshal(ref.Tags)
-			if err != nil {
-				return 0, fmt.Errorf("marshaling tags for %s: %w", ref.ID, err)
-			}
+		if _, err := ftsStmt.Exec(refFTSArgs(ref)...); err != nil {
+			return 0, fmt.Errorf("inserting fts for %s: %w", ref.ID, err)
 		}
+	}
 
-		// Insert into refs table
-		_, err = refsStmt.Exec(
-			ref.ID, ref.DOI, ref.Title, ref.Abstract, ref.Venue,
-			ref.Published.Year, ref.Published.Month, ref.Published.Day,
-			ref.PDFPath, ref.Source.Type, ref.Source.ID, ref.Supersedes,
-			string(authorsJSON), nullableString(supplementJSON),
-			nullableStringValue(ref.PMID), nullableStringValue(ref.PMCID),
-			nullableStringValue(ref.ArXivID), nullableStringValue(ref.S2ID),
-			nullableStringValue(ref.Note), nullableString(tagsJSON),
-		)
-		if err != nil {
-			return 0, fmt.Errorf("inserting ref %s: %w", ref.ID, err)
-		}
+	if err := d.recordIndexHash(IndexRefs, jsonlPath); err != nil {
+		return 0, err
+	}
+	return len(refs), nil
+}
 
-		// Build authors text for FTS
-		authorsText := formatAuthorsText(ref.Authors)
+// UpsertRef inserts or replaces a single reference and its FTS row.
+func (d *DB) UpsertRef(ref reference.Reference) error {
+	args, err := refRowArgs(ref)
+	if err != nil {
+		return err
+	}
+	_, err = d.db.Exec(`
+		INSERT OR REPLACE INTO refs (
+			id, doi, title, abstract, venue,
+			pub_year, pub_month, pub_day,
+			pdf_path, source_type, source_id, supersedes,
+			authors_json, supplement_paths_json,
+			pmid, pmcid, arxiv_id, s2_id, notes, tags_json
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, args...)
+	if err != nil {
+		return fmt.Errorf("upserting ref %s: %w", ref.ID, err)
+	}
 
-		// Build tags text for FTS (space-separated)
-		tagsText := strings.Join(ref.Tags, " ")
+	// FTS5 tables have no primary key to replace on
+	if _, err := d.db.Exec(`DELETE FROM refs_fts WHERE id = ?`, ref.ID); err != nil {
+		return fmt.Errorf("clearing refs_fts for %s: %w", ref.ID, err)
+	}
+	_, err = d.db.Exec(`
+		INSERT INTO refs_fts (id, title, abstract, authors_text, pub_year, notes, tags_text)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, refFTSArgs(ref)...)
+	if err != nil {
+		return fmt.Errorf("inserting refs_fts for %s: %w", ref.ID, err)
+	}
+	return nil
+}
+
+// DeleteRef removes a single reference and its FTS row. Deleting an ID that
+// is not indexed is not an error.
+func (d *DB) DeleteRef(id string) error {
+	if _, err := d.db.Exec(`DELETE FROM refs WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("deleting ref %s: %w", id, err)
+	}
+	if _, err := d.db.Exec(`DELETE FROM refs_fts WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("deleting refs_fts for %s: %w", id, err)
+	}
+	return nil
+}
 
-		// Insert into FTS table
-		_, err = ftsStmt.Exec(ref.ID, ref.Title, ref.Abstract, authorsText, strconv.Itoa(ref.Published.Year), ref.Note, tagsText)
+// refRowArgs returns the column values for a refs row, in insert order.
+func refRowArgs(ref reference.Reference) ([]any, error) {
+	authorsJSON, err := json.Marshal(ref.Authors)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling authors for %s: %w", ref.ID, err)
+	}
+	var supplementJSON []byte
+	if len(ref.SupplementPaths) > 0 {
+		supplementJSON, err = json.Marshal(ref.SupplementPaths)
 		if err != nil {
-			return 0, fmt.Errorf("inserting fts for %s: %w", ref.ID, err)
+			return nil, fmt.Errorf("marshaling supplement paths for %s: %w", ref.ID, err)
 		}
 	}
+	var tagsJSON []byte
+	if len(ref.Tags) > 0 {
+		tagsJSON, err = json.Marshal(ref.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling tags for %s: %w", ref.ID, err)
+		}
+	}
+	return []any{
+		ref.ID, ref.DOI, ref.Title, ref.Abstract, ref.Venue,
+		ref.Published.Year, ref.Published.Month, ref.Published.Day,
+		ref.PDFPath, ref.Source.Type, ref.Source.ID, ref.Supersedes,
+		string(authorsJSON), nullableString(supplementJSON),
+		nullableStringValue(ref.PMID), nullableStringValue(ref.PMCID),
+		nullableStringValue(ref.ArXivID), nullableStringValue(ref.S2ID),
+		nullableStringValue(ref.Note), nullableString(tagsJSON),
+	}, nil
+}
 
-	return len(refs), nil
+// refFTSArgs returns the column values for a refs_fts row, in insert order.
+func refFTSArgs(ref reference.Reference) []any {
+	return []any{
+		ref.ID, ref.Title, ref.Abstract, formatAuthorsText(ref.Authors),
+		strconv.Itoa(ref.Published.Year), ref.Note, strings.Join(ref.Tags, " "),
+	}
 }
 
 // formatAuthorsText creates a searchable text representation of authors.
@@ -211,26 +269,106 @@ func (d *DB) GetByID(id string) (*reference.Reference, error) {
 	return scanReference(row)
 }
 
-// Search performs a full-text search and returns matching references.
-func (d *DB) Search(query string, limit int) ([]reference.Reference, error) {
+// GetSupersededBy returns the ID of the reference whose Supersedes points
+// to ref, by ID or by DOI as in reference.ResolveSupersedes, or "" if none
+// does. It is the indexed form of reference.SupersededBy.
+func (d *DB) GetSupersededBy(ref reference.Reference) (string, error) {
+	doi := ref.DOI
+	if doi == "" {
+		doi = ref.ID
+	}
+	var id string
+	err := d.db.QueryRow(`
+		SELECT id FROM refs
+		WHERE supersedes IN (?, ?) AND supersedes != '' AND id != ?
+		ORDER BY rowid LIMIT 1
+	`, ref.ID, doi, ref.ID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("querying superseded_by: %w", err)
+	}
+	return id, nil
+}
+
+// External identifier kinds accepted by GetByExternalID.
+const (
+	ExternalIDDOI   = "doi"
+	ExternalIDPMID  = "pmid"
+	ExternalIDPMCID = "pmcid"
+	ExternalIDArXiv = "arxiv"
+	ExternalIDS2    = "s2"
+)
+
+// GetByExternalID returns the reference with an exact match for an external
+// identifier of the given kind, or nil if there is none or value is empty.
+// If several references share the identifier, the first found is returned.
+func (d *DB) GetByExternalID(kind, value string) (*reference.Reference, error) {
+	var filters SearchFilters
+	switch kind {
+	case ExternalIDDOI:
+		filters.DOI = value
+	case ExternalIDPMID:
+		filters.PMID = value
+	case ExternalIDPMCID:
+		filters.PMCID = value
+	case ExternalIDArXiv:
+		filters.ArXivID = value
+	case ExternalIDS2:
+		filters.S2ID = value
+	default:
+		return nil, fmt.Errorf("unknown external ID kind %q", kind)
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	refs, err := d.SearchWithFilters(filters, 1, SortDefault)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	return &refs[0], nil
+}
+
+// Search performs a full-text search and returns matching references in
+// the given order. A limit of 0 means no limit.
+func (d *DB) Search(query string, limit int, order SortOrder) ([]reference.Reference, error) {
+	return collect(func(fn func(reference.Reference) error) error {
+		return d.SearchEach(query, limit, order, fn)
+	})
+}
+
+// SearchEach is Search calling fn with each match as it is read.
+func (d *DB) SearchEach(query string, limit int, order SortOrder, fn func(reference.Reference) error) error {
 	// Escape special FTS5 characters and prepare query
 	ftsQuery := prepareFTSQuery(query)
 
 	rows, err := d.db.Query(`
 		SELECT `+selectRefFields+`
 		FROM refs
-		WHERE id IN (SELECT id FROM refs_fts WHERE refs_fts MATCH ?)
-		LIMIT ?`, ftsQuery, limit)
+		WHERE id IN (SELECT id FROM refs_fts WHERE refs_fts MATCH ?)`+
+		order.orderBy()+`
+		LIMIT ?`, ftsQuery, sqlLimit(limit))
 	if err != nil {
-		return nil, fmt.Errorf("searching: %w", err)
+		return fmt.Errorf("searching: %w", err)
 	}
-	defer rows.Close()
+	return eachRow(rows, scanReferenceRow, fn)
+}
 
-	return scanReferences(rows)
+// SearchField performs a search on a specific field, returning matches in
+// the given order. A limit of 0 means no limit.
+func (d *DB) SearchField(field, value string, limit int, order SortOrder) ([]reference.Reference, error) {
+	return collect(func(fn func(reference.Reference) error) error {
+		return d.SearchFieldEach(field, value, limit, order, fn)
+	})
 }
 
-// SearchField performs a search on a specific field.
-func (d *DB) SearchField(field, value string, limit int) ([]reference.Reference, error) {
+// SearchFieldEach is SearchField calling fn with each match as it is read.
+func (d *DB) SearchFieldEach(field, value string, limit int, order SortOrder, fn func(reference.Reference) error) error {
 	var ftsQuery string
 
 	switch field {
@@ -239,21 +377,67 @@ func (d *DB) SearchField(field, value string, limit int) ([]reference.Reference,
 	case "title":
 		ftsQuery = "title:" + prepareFTSQuery(value)
 	default:
-		return nil, fmt.Errorf("unknown search field: %s", field)
+		return fmt.Errorf("unknown search field: %s", field)
 	}
 
 	rows, err := d.db.Query(`
 		SELECT `+selectRefFields+`
 		FROM refs
-		WHERE id IN (SELECT id FROM refs_fts WHERE refs_fts MATCH ?)
+		WHERE id IN (SELECT id FROM refs_fts WHERE refs_fts MATCH ?)`+
+		order.orderBy()+`
 		LIMIT ?
-	`, ftsQuery, limit)
+	`, ftsQuery, sqlLimit(limit))
 	if err != nil {
-		return nil, fmt.Errorf("searching %s: %w", field, err)
+		return fmt.Errorf("searching %s: %w", field, err)
 	}
-	defer rows.Close()
+	return eachRow(rows, scanReferenceRow, fn)
+}
+
+// sqlLimit converts a limit where 0 means no limit to SQLite's LIMIT value,
+// for which -1 means no limit.
+func sqlLimit(limit int) int {
+	if limit <= 0 {
+		return -1
+	}
+	return limit
+}
 
-	return scanReferences(rows)
+// SortOrder is a result order for ListAll and the search methods.
+type SortOrder string
+
+// Sort orders. Year orders break ties by month, day, then ID; title order
+// is case-insensitive.
+const (
+	SortDefault  SortOrder = ""
+	SortYear     SortOrder = "year"
+	SortYearDesc SortOrder = "-year"
+	SortTitle    SortOrder = "title"
+	SortID       SortOrder = "id"
+)
+
+// ParseSortOrder validates a user-supplied sort name.
+func ParseSortOrder(s string) (SortOrder, error) {
+	switch order := SortOrder(s); order {
+	case SortDefault, SortYear, SortYearDesc, SortTitle, SortID:
+		return order, nil
+	}
+	return "", fmt.Errorf("invalid sort %q: must be year, -year, title, or id", s)
+}
+
+// orderBy returns the ORDER BY clause for o. Clauses are fixed strings, so
+// no user input reaches the SQL.
+func (o SortOrder) orderBy() string {
+	switch o {
+	case SortYear:
+		return " ORDER BY pub_year, pub_month, pub_day, id"
+	case SortYearDesc:
+		return " ORDER BY pub_year DESC, pub_month DESC, pub_day DESC, id"
+	case SortTitle:
+		return " ORDER BY title COLLATE NOCASE, id"
+	case SortID:
+		return " ORDER BY id"
+	}
+	return ""
 }
 
 // SearchFilters contains optional filters for SearchWithFilters.
@@ -268,22 +452,37 @@ func (d *DB) SearchField(field, value string, limit int) ([]reference.Reference,
 // The current approach mixes FTS5 (text search) and SQL WHERE (exact/range).
 // Both support OR natively, so adding same-type ORs is straightforward.
 type SearchFilters struct {
-	Keyword  string   // General keyword search across all fields
-	Authors  []string // Author names to search for (AND logic, exact last name match)
-	YearFrom int      // Minimum publication year (0 = no minimum)
-	YearTo   int      // Maximum publication year (0 = no maximum)
-	Title    string   // Search in title only (FTS)
-	Venue    string   // Filter by venue (SQL LIKE, case-insensitive)
-	DOI      string   // Exact DOI match (SQL)
-	Tag      string   // Filter by tag (SQL LIKE on tags_json, partial match)
+	Keyword      string   // General keyword search across all fields
+	Authors      []string // Author names to search for (AND logic, exact last name match)
+	ExactAuthors bool     // Match author first names in full instead of by prefix
+	YearFrom     int      // Minimum publication year (0 = no minimum)
+	YearTo       int      // Maximum publication year (0 = no maximum)
+	Title        string   // Search in title only (FTS)
+	Venue        string   // Filter by venue (SQL LIKE, case-insensitive)
+	DOI          string   // Exact DOI match (SQL)
+	Tag          string   // Filter by tag (SQL LIKE on tags_json, partial match)
+	PMID         string   // Exact PubMed ID match (SQL)
+	PMCID        string   // Exact PubMed Central ID match (SQL)
+	ArXivID      string   // Exact arXiv ID match (SQL)
+	S2ID         string   // Exact Semantic Scholar ID match (SQL)
 }
 
 // SearchWithFilters performs a search with multiple optional filters.
-// Returns references matching ALL specified criteria (AND logic).
+// Returns references matching ALL specified criteria (AND logic), up to
+// limit (0 = no limit), in the given order (SortDefault leaves it to SQLite).
 //
 // Author filtering uses exact last name matching to avoid false positives.
-// For example, -a "Yu" matches "Timothy Yu" but not "Yujia Chan".
-func (d *DB) SearchWithFilters(filters SearchFilters, limit int) ([]reference.Reference, error) {
+// For example, -a "Yu" matches "Timothy Yu" but not "Yujia Chan". First
+// names match by prefix unless ExactAuthors is set.
+func (d *DB) SearchWithFilters(filters SearchFilters, limit int, order SortOrder) ([]reference.Reference, error) {
+	return collect(func(fn func(reference.Reference) error) error {
+		return d.SearchWithFiltersEach(filters, limit, order, fn)
+	})
+}
+
+// SearchWithFiltersEach is SearchWithFilters calling fn with each match as
+// it is read.
+func (d *DB) SearchWithFiltersEach(filters SearchFilters, limit int, order SortOrder, fn func(reference.Reference) error) error {
 	var ftsTerms []string
 	var sqlConditions []string
 	var args []interface{}
@@ -292,7 +491,9 @@ func (d *DB) SearchWithFilters(filters SearchFilters, limit int) ([]reference.Re
 	var authorQueries []author.Query
 	for _, a := range filters.Authors {
 		if a != "" {
-			authorQueries = append(authorQueries, author.ParseQuery(a))
+			q := author.ParseQuery(a)
+			q.Exact = filters.ExactAuthors
+			authorQueries = append(authorQueries, q)
 		}
 	}
 
@@ -304,6 +505,19 @@ func (d *DB) SearchWithFilters(filters SearchFilters, limit int) ([]reference.Re
 		ftsTerms = append(ftsTerms, "title:"+prepareFTSQuery(filters.Title))
 	}
 
+	// Narrow author searches through the FTS index first: by name prefix,
+	// or by whole names with ExactAuthors
+	for _, a := range filters.Authors {
+		if strings.TrimSpace(a) == "" {
+			continue
+		}
+		if filters.ExactAuthors {
+			ftsTerms = append(ftsTerms, "authors_text:"+authorNameToFTSExactQuery(a))
+		} else {
+			ftsTerms = append(ftsTerms, "authors_text:"+authorNameToFTSPrefixQuery(a))
+		}
+	}
+
 	// Build SQL conditions for authors using LIKE on authors_json.
 	// This directly queries the JSON field for exact last name matches.
 	// Post-filtering handles case-insensitive matching and first name prefixes.
@@ -353,41 +567,43 @@ func (d *DB) SearchWithFilters(filters SearchFilters, limit int) ([]reference.Re
 		query += " AND tags_json LIKE ?"
 		args = append(args, "%"+filters.Tag+"%")
 	}
+	if filters.PMID != "" {
+		query += " AND pmid = ?"
+		args = append(args, filters.PMID)
+	}
+	if filters.PMCID != "" {
+		query += " AND pmcid = ?"
+		args = append(args, filters.PMCID)
+	}
+	if filters.ArXivID != "" {
+		query += " AND arxiv_id = ?"
+		args = append(args, filters.ArXivID)
+	}
+	if filters.S2ID != "" {
+		query += " AND s2_id = ?"
+		args = append(args, filters.S2ID)
+	}
 
-	query += " LIMIT ?"
-	args = append(args, limit)
+	// Ordering applies to the outer query, after the FTS MATCH subquery
+	query += order.orderBy()
 
-	rows, err := d.db.Query(query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("searching with filters: %w", err)
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
 	}
-	defer rows.Close()
 
-	refs, err := scanReferences(rows)
+	rows, err := d.db.Query(query, args...)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("searching with filters: %w", err)
 	}
 
 	// Post-filter by authors for case-insensitive matching and first name prefixes
-	if len(authorQueries) > 0 {
-		refs = filterByAuthors(refs, authorQueries, limit)
-	}
-
-	return refs, nil
-}
-
-// filterByAuthors filters references to those matching all author queries.
-func filterByAuthors(refs []reference.Reference, queries []author.Query, limit int) []reference.Reference {
-	var result []reference.Reference
-	for _, ref := range refs {
-		if author.AllMatch(queries, ref.Authors) {
-			result = append(result, ref)
-			if len(result) >= limit {
-				break
-			}
+	return eachRow(rows, scanReferenceRow, func(ref reference.Reference) error {
+		if len(authorQueries) > 0 && !author.AllMatch(authorQueries, ref.Authors) {
+			return nil
 		}
-	}
-	return result
+		return fn(ref)
+	})
 }
 
 // authorNameToFTSPrefixQuery converts an author name to an FTS5 query with prefix matching.
@@ -395,6 +611,20 @@ func filterByAuthors(refs []reference.Reference, queries []author.Query, limit i
 // Multi-word names use OR logic (match any part), e.g., "John Smith" matches papers
 // by anyone named John OR anyone named Smith.
 func authorNameToFTSPrefixQuery(author string) string {
+	return authorNameFTSTerms(author, "*", " OR ")
+}
+
+// authorNameToFTSExactQuery is the whole-word variant of
+// authorNameToFTSPrefixQuery: each word is quoted without the prefix
+// wildcard and all of them must match (AND), so "Li" no longer matches
+// "Lin" and "Wei Li" needs both names.
+func authorNameToFTSExactQuery(author string) string {
+	return authorNameFTSTerms(author, "", " AND ")
+}
+
+// authorNameFTSTerms quotes each word of author as an FTS5 string with
+// suffix appended, joined by op and parenthesized.
+func authorNameFTSTerms(author, suffix, op string) string {
 	author = strings.TrimSpace(author)
 	if author == "" {
 		return author
@@ -404,19 +634,29 @@ func authorNameToFTSPrefixQuery(author string) string {
 	parts := strings.Fields(author)
 	var terms []string
 	for _, part := range parts {
-		// Escape special characters and add prefix wildcard
+		// Escape special characters
 		escaped := strings.ReplaceAll(part, "\"", "\"\"")
-		// Add * for prefix matching
-		terms = append(terms, "\""+escaped+"\"*")
+		terms = append(terms, "\""+escaped+"\""+suffix)
 	}
 
-	// Use OR for multi-word author queries (match any part)
-	return "(" + strings.Join(terms, " OR ") + ")"
+	return "(" + strings.Join(terms, op) + ")"
+}
+
+// ListAll returns all references, optionally limited, in the given order
+// (SortDefault is by ID).
+func (d *DB) ListAll(limit int, order SortOrder) ([]reference.Reference, error) {
+	return collect(func(fn func(reference.Reference) error) error {
+		return d.ListAllEach(limit, order, fn)
+	})
 }
 
-// ListAll returns all references, optionally limited.
-func (d *DB) ListAll(limit int) ([]reference.Reference, error) {
-	query := `SELECT ` + selectRefFields + ` FROM refs ORDER BY id`
+// ListAllEach is ListAll calling fn with each reference as it is read, so
+// a long listing can be written out without holding it all in memory.
+func (d *DB) ListAllEach(limit int, order SortOrder, fn func(reference.Reference) error) error {
+	if order == SortDefault {
+		order = SortID
+	}
+	query := `SELECT ` + selectRefFields + ` FROM refs` + order.orderBy()
 	var args []interface{}
 
 	if limit > 0 {
@@ -426,11 +666,9 @@ func (d *DB) ListAll(limit int) ([]reference.Reference, error) {
 
 	rows, err := d.db.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("listing refs: %w", err)
+		return fmt.Errorf("listing refs: %w", err)
 	}
-	defer rows.Close()
-
-	return scanReferences(rows)
+	return eachRow(rows, scanReferenceRow, fn)
 }
 
 // Count returns the total number of references.
@@ -445,6 +683,37 @@ type scanner interface {
 	Scan(dest ...interface{}) error
 }
 
+// eachRow calls fn with each of rows, as converted by scan, while the rows
+// are read, and closes rows. It stops at and returns the first error from
+// scan or fn.
+func eachRow[T any](rows *sql.Rows, scan func(scanner) (T, error), fn func(T) error) error {
+	defer rows.Close()
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// collect returns the items each passes to its callback, in order. The
+// slice-returning queries use it to share the SQL of their Each variants.
+func collect[T any](each func(fn func(T) error) error) ([]T, error) {
+	var items []T
+	err := each(func(item T) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 func scanReference(s scanner) (*reference.Reference, error) {
 	var ref reference.Reference
 	var authorsJSON, supplementJSON, tagsJSON sql.NullString
@@ -506,18 +775,19 @@ func scanReference(s scanner) (*reference.Reference, error) {
 	return &ref, nil
 }
 
-func scanReferences(rows *sql.Rows) ([]reference.Reference, error) {
-	var refs []reference.Reference
-	for rows.Next() {
-		ref, err := scanReference(rows)
-		if err != nil {
-			return nil, err
-		}
-		if ref != nil {
-			refs = append(refs, *ref)
-		}
+// scanReferenceRow scans one reference from a result row, for eachRow.
+func scanReferenceRow(s scanner) (reference.Reference, error) {
+	ref, err := scanReference(s)
+	if err != nil {
+		return reference.Reference{}, err
 	}
-	return refs, rows.Err()
+	return *ref, nil
+}
+
+func scanReferences(rows *sql.Rows) ([]reference.Reference, error) {
+	return collect(func(fn func(reference.Reference) error) error {
+		return eachRow(rows, scanReferenceRow, fn)
+	})
 }
 
 func nullableString(b []byte) sql.NullString {
@@ -588,6 +858,39 @@ func (d *DB) GetEmbeddingMetadata(paperID string) (*EmbeddingMetadata, error) {
 	return &meta, nil
 }
 
+// GetAllEmbeddingMetadata returns embedding metadata for every indexed paper, keyed by paper ID.
+func (d *DB) GetAllEmbeddingMetadata() (map[string]EmbeddingMetadata, error) {
+	rows, err := d.db.Query(`
+		SELECT paper_id, model_name, indexed_at, abstract_hash
+		FROM embedding_metadata
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	metas := make(map[string]EmbeddingMetadata)
+	for rows.Next() {
+		var meta EmbeddingMetadata
+		if err := rows.Scan(&meta.PaperID, &meta.ModelName, &meta.IndexedAt, &meta.AbstractHash); err != nil {
+			return nil, err
+		}
+		metas[meta.PaperID] = meta
+	}
+	return metas, rows.Err()
+}
+
+// DeleteEmbeddingMetadata removes the embedding metadata for one paper.
+// Returns whether a row was removed.
+func (d *DB) DeleteEmbeddingMetadata(paperID string) (bool, error) {
+	res, err := d.db.Exec("DELETE FROM embedding_metadata WHERE paper_id = ?", paperID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
 // ClearEmbeddingMetadata removes all embedding metadata.
 func (d *DB) ClearEmbeddingMetadata() error {
 	_, err := d.db.Exec("DELETE FROM embedding_metadata")
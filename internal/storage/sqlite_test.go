@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/matsen/bipartite/internal/reference"
@@ -257,7 +258,7 @@ func TestDB_Search_Notes(t *testing.T) {
 	defer cleanup()
 
 	// Search for text that only appears in notes
-	refs, err := db.Search("SONIA", 10)
+	refs, err := db.Search("SONIA", 10, SortDefault)
 	if err != nil {
 		t.Fatalf("Search() error = %v", err)
 	}
@@ -310,7 +311,7 @@ func TestDB_Search(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.query, func(t *testing.T) {
-			refs, err := db.Search(tt.query, tt.limit)
+			refs, err := db.Search(tt.query, tt.limit, SortDefault)
 			if err != nil {
 				t.Fatalf("Search() error = %v", err)
 			}
@@ -342,7 +343,7 @@ func TestDB_SearchField(t *testing.T) {
 	defer cleanup()
 
 	// Author search
-	refs, err := db.SearchField("author", "Smith", 10)
+	refs, err := db.SearchField("author", "Smith", 10, SortDefault)
 	if err != nil {
 		t.Fatalf("SearchField(author) error = %v", err)
 	}
@@ -351,7 +352,7 @@ func TestDB_SearchField(t *testing.T) {
 	}
 
 	// Title search
-	refs, err = db.SearchField("title", "Machine", 10)
+	refs, err = db.SearchField("title", "Machine", 10, SortDefault)
 	if err != nil {
 		t.Fatalf("SearchField(title) error = %v", err)
 	}
@@ -360,7 +361,7 @@ func TestDB_SearchField(t *testing.T) {
 	}
 
 	// Invalid field
-	_, err = db.SearchField("invalid", "test", 10)
+	_, err = db.SearchField("invalid", "test", 10, SortDefault)
 	if err == nil {
 		t.Error("SearchField(invalid) should return error")
 	}
@@ -385,6 +386,14 @@ func TestDB_SearchWithFilters(t *testing.T) {
 			wantIDs: []string{"Smith2026-ab"},
 			wantMin: 1,
 		},
+		{
+			name:    "year range without limit",
+			filters: SearchFilters{YearFrom: 2025},
+			limit:   0, // No limit
+			wantIDs: []string{"Smith2026-ab", "Jones2025-cd"},
+			wantMin: 2,
+			wantMax: 2,
+		},
 		{
 			name:    "single author",
 			filters: SearchFilters{Authors: []string{"Smith"}},
@@ -557,7 +566,7 @@ func TestDB_SearchWithFilters(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			refs, err := db.SearchWithFilters(tt.filters, tt.limit)
+			refs, err := db.SearchWithFilters(tt.filters, tt.limit, SortDefault)
 			if err != nil {
 				t.Fatalf("SearchWithFilters() error = %v", err)
 			}
@@ -633,7 +642,7 @@ func TestDB_SearchWithFilters_LargeDB(t *testing.T) {
 	}
 
 	// Search for rare author - should find all 5 papers
-	results, err := db.SearchWithFilters(SearchFilters{Authors: []string{"Rareauthor"}}, 50)
+	results, err := db.SearchWithFilters(SearchFilters{Authors: []string{"Rareauthor"}}, 50, SortDefault)
 	if err != nil {
 		t.Fatalf("SearchWithFilters() error = %v", err)
 	}
@@ -650,12 +659,65 @@ func TestDB_SearchWithFilters_LargeDB(t *testing.T) {
 	}
 }
 
+func TestDB_SearchWithFilters_ExactAuthors(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonlPath := filepath.Join(tmpDir, "refs.jsonl")
+	refs := []reference.Reference{
+		{ID: "Li2020-wei", Title: "A", Authors: []reference.Author{{First: "Wei", Last: "Li"}}},
+		{ID: "Li2021-weiran", Title: "B", Authors: []reference.Author{{First: "Weiran", Last: "Li"}}},
+		{ID: "Lin2022-wei", Title: "C", Authors: []reference.Author{{First: "Wei", Last: "Lin"}}},
+	}
+	for i := range refs {
+		refs[i].Published = reference.PublicationDate{Year: 2020 + i}
+		refs[i].Source = reference.ImportSource{Type: "test"}
+	}
+	if err := WriteAll(jsonlPath, refs); err != nil {
+		t.Fatalf("WriteAll() error = %v", err)
+	}
+	db, err := OpenDB(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+	if _, err := db.RebuildFromJSONL(jsonlPath); err != nil {
+		t.Fatalf("RebuildFromJSONL() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filters SearchFilters
+		want    []string
+	}{
+		{"last name never matches Lin", SearchFilters{Authors: []string{"Li"}}, []string{"Li2020-wei", "Li2021-weiran"}},
+		{"exact last name only", SearchFilters{Authors: []string{"Li"}, ExactAuthors: true}, []string{"Li2020-wei", "Li2021-weiran"}},
+		{"first name prefix", SearchFilters{Authors: []string{"Wei Li"}}, []string{"Li2020-wei", "Li2021-weiran"}},
+		{"first name exact", SearchFilters{Authors: []string{"Wei Li"}, ExactAuthors: true}, []string{"Li2020-wei"}},
+		{"exact is case-insensitive", SearchFilters{Authors: []string{"li, wei"}, ExactAuthors: true}, []string{"Li2020-wei"}},
+		{"exact rejects an initial", SearchFilters{Authors: []string{"W Li"}, ExactAuthors: true}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := db.SearchWithFilters(tt.filters, 0, SortID)
+			if err != nil {
+				t.Fatalf("SearchWithFilters() error = %v", err)
+			}
+			var ids []string
+			for _, r := range got {
+				ids = append(ids, r.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SearchWithFilters() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
 func TestDB_ListAll(t *testing.T) {
 	db, _, cleanup := setupTestDB(t)
 	defer cleanup()
 
 	// List all
-	refs, err := db.ListAll(0)
+	refs, err := db.ListAll(0, SortDefault)
 	if err != nil {
 		t.Fatalf("ListAll() error = %v", err)
 	}
@@ -664,7 +726,7 @@ func TestDB_ListAll(t *testing.T) {
 	}
 
 	// With limit
-	refs, err = db.ListAll(2)
+	refs, err = db.ListAll(2, SortDefault)
 	if err != nil {
 		t.Fatalf("ListAll(2) error = %v", err)
 	}
@@ -673,7 +735,7 @@ func TestDB_ListAll(t *testing.T) {
 	}
 
 	// Limit greater than count
-	refs, err = db.ListAll(100)
+	refs, err = db.ListAll(100, SortDefault)
 	if err != nil {
 		t.Fatalf("ListAll(100) error = %v", err)
 	}
@@ -682,6 +744,197 @@ func TestDB_ListAll(t *testing.T) {
 	}
 }
 
+func TestDB_ListAllEach(t *testing.T) {
+	db, _, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	want, err := db.ListAll(0, SortDefault)
+	if err != nil {
+		t.Fatalf("ListAll() error = %v", err)
+	}
+	var got []string
+	err = db.ListAllEach(0, SortDefault, func(ref reference.Reference) error {
+		got = append(got, ref.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ListAllEach() error = %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ListAllEach() visited %d refs, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i].ID {
+			t.Errorf("ListAllEach() ref %d = %s, want %s", i, got[i], want[i].ID)
+		}
+	}
+
+	// An error from the callback stops the iteration and is returned
+	errStop := fmt.Errorf("stop")
+	calls := 0
+	err = db.ListAllEach(0, SortDefault, func(reference.Reference) error {
+		calls++
+		return errStop
+	})
+	if err != errStop || calls != 1 {
+		t.Errorf("ListAllEach() with failing callback = %v after %d calls, want errStop after 1", err, calls)
+	}
+}
+
+func TestDB_UpsertRef(t *testing.T) {
+	db, _, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ref, err := db.GetByID("Jones2025-cd")
+	if err != nil || ref == nil {
+		t.Fatalf("GetByID() = %v, %v", ref, err)
+	}
+	ref.Title = "Transformers for Protein Folding"
+	if err := db.UpsertRef(*ref); err != nil {
+		t.Fatalf("UpsertRef() error = %v", err)
+	}
+
+	got, err := db.GetByID("Jones2025-cd")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Title != ref.Title {
+		t.Errorf("GetByID().Title = %q, want %q", got.Title, ref.Title)
+	}
+
+	// The old FTS row is replaced, not duplicated
+	results, err := db.Search("Transformers", 10, SortDefault)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Search('Transformers') returned %d results, want 1", len(results))
+	}
+	results, err = db.Search("Structure", 10, SortDefault)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Search('Structure') returned %d results, want 0", len(results))
+	}
+
+	count, err := db.Count()
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Count() = %d after replacing a ref, want 3", count)
+	}
+}
+
+func TestDB_DeleteRef(t *testing.T) {
+	db, _, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := db.DeleteRef("Jones2025-cd"); err != nil {
+		t.Fatalf("DeleteRef() error = %v", err)
+	}
+	got, err := db.GetByID("Jones2025-cd")
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got != nil {
+		t.Errorf("GetByID() = %v after DeleteRef, want nil", got.ID)
+	}
+	results, err := db.Search("protein", 10, SortDefault)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Search('protein') returned %d results after DeleteRef, want 0", len(results))
+	}
+
+	// Deleting an unindexed ID is not an error
+	if err := db.DeleteRef("Missing2020-xx"); err != nil {
+		t.Errorf("DeleteRef(missing) error = %v", err)
+	}
+}
+
+func TestDB_SortOrder(t *testing.T) {
+	db, _, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ids := func(refs []reference.Reference) string {
+		var out []string
+		for _, r := range refs {
+			out = append(out, r.ID)
+		}
+		return fmt.Sprint(out)
+	}
+
+	tests := []struct {
+		order SortOrder
+		want  string
+	}{
+		{SortDefault, "[Brown2024-ef Jones2025-cd Smith2026-ab]"},
+		{SortID, "[Brown2024-ef Jones2025-cd Smith2026-ab]"},
+		{SortYear, "[Brown2024-ef Jones2025-cd Smith2026-ab]"},
+		{SortYearDesc, "[Smith2026-ab Jones2025-cd Brown2024-ef]"},
+		{SortTitle, "[Jones2025-cd Smith2026-ab Brown2024-ef]"},
+	}
+	for _, tt := range tests {
+		t.Run("ListAll "+string(tt.order), func(t *testing.T) {
+			refs, err := db.ListAll(0, tt.order)
+			if err != nil {
+				t.Fatalf("ListAll() error = %v", err)
+			}
+			if got := ids(refs); got != tt.want {
+				t.Errorf("ListAll(%q) = %s, want %s", tt.order, got, tt.want)
+			}
+		})
+	}
+
+	// Ordering applies after the FTS match
+	refs, err := db.SearchWithFilters(SearchFilters{Keyword: "learning"}, 10, SortYear)
+	if err != nil {
+		t.Fatalf("SearchWithFilters() error = %v", err)
+	}
+	if got, want := ids(refs), "[Jones2025-cd Smith2026-ab]"; got != want {
+		t.Errorf("SearchWithFilters(learning, year) = %s, want %s", got, want)
+	}
+	refs, err = db.SearchWithFilters(SearchFilters{YearFrom: 2024}, 1, SortYearDesc)
+	if err != nil {
+		t.Fatalf("SearchWithFilters() error = %v", err)
+	}
+	if got, want := ids(refs), "[Smith2026-ab]"; got != want {
+		t.Errorf("SearchWithFilters(limit 1, -year) = %s, want %s", got, want)
+	}
+
+	// Plain and field searches take the same orders
+	refs, err = db.Search("learning", 10, SortYearDesc)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got, want := ids(refs), "[Smith2026-ab Jones2025-cd]"; got != want {
+		t.Errorf("Search(learning, -year) = %s, want %s", got, want)
+	}
+	refs, err = db.SearchField("title", "learning", 1, SortYear)
+	if err != nil {
+		t.Fatalf("SearchField() error = %v", err)
+	}
+	if got, want := ids(refs), "[Jones2025-cd]"; got != want {
+		t.Errorf("SearchField(title learning, limit 1, year) = %s, want %s", got, want)
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	for _, s := range []string{"", "year", "-year", "title", "id"} {
+		if _, err := ParseSortOrder(s); err != nil {
+			t.Errorf("ParseSortOrder(%q) error = %v", s, err)
+		}
+	}
+	for _, s := range []string{"-title", "year; DROP TABLE refs", "YEAR"} {
+		if _, err := ParseSortOrder(s); err == nil {
+			t.Errorf("ParseSortOrder(%q) should fail", s)
+		}
+	}
+}
+
 func TestDB_Count(t *testing.T) {
 	db, _, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -695,6 +948,71 @@ func TestDB_Count(t *testing.T) {
 	}
 }
 
+func TestDB_GetAllEmbeddingMetadata(t *testing.T) {
+	db, _, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	metas, err := db.GetAllEmbeddingMetadata()
+	if err != nil {
+		t.Fatalf("GetAllEmbeddingMetadata() error = %v", err)
+	}
+	if len(metas) != 0 {
+		t.Errorf("GetAllEmbeddingMetadata() on empty table = %v, want none", metas)
+	}
+
+	for _, id := range []string{"Smith2026-ab", "Jones2025-cd"} {
+		meta := EmbeddingMetadata{PaperID: id, ModelName: "m", IndexedAt: 1, AbstractHash: "h-" + id}
+		if err := db.SaveEmbeddingMetadata(meta); err != nil {
+			t.Fatalf("SaveEmbeddingMetadata() error = %v", err)
+		}
+	}
+
+	metas, err = db.GetAllEmbeddingMetadata()
+	if err != nil {
+		t.Fatalf("GetAllEmbeddingMetadata() error = %v", err)
+	}
+	if len(metas) != 2 {
+		t.Fatalf("GetAllEmbeddingMetadata() returned %d entries, want 2", len(metas))
+	}
+	if got := metas["Jones2025-cd"].AbstractHash; got != "h-Jones2025-cd" {
+		t.Errorf("AbstractHash for Jones2025-cd = %q, want h-Jones2025-cd", got)
+	}
+}
+
+func TestDB_DeleteEmbeddingMetadata(t *testing.T) {
+	db, _, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for _, id := range []string{"Smith2026-ab", "Jones2025-cd"} {
+		meta := EmbeddingMetadata{PaperID: id, ModelName: "m", IndexedAt: 1, AbstractHash: "h-" + id}
+		if err := db.SaveEmbeddingMetadata(meta); err != nil {
+			t.Fatalf("SaveEmbeddingMetadata() error = %v", err)
+		}
+	}
+
+	deleted, err := db.DeleteEmbeddingMetadata("Smith2026-ab")
+	if err != nil {
+		t.Fatalf("DeleteEmbeddingMetadata() error = %v", err)
+	}
+	if !deleted {
+		t.Error("DeleteEmbeddingMetadata() = false, want true")
+	}
+	if meta, _ := db.GetEmbeddingMetadata("Smith2026-ab"); meta != nil {
+		t.Errorf("metadata for Smith2026-ab still present: %+v", meta)
+	}
+	if meta, _ := db.GetEmbeddingMetadata("Jones2025-cd"); meta == nil {
+		t.Error("metadata for Jones2025-cd was removed")
+	}
+
+	deleted, err = db.DeleteEmbeddingMetadata("Smith2026-ab")
+	if err != nil {
+		t.Fatalf("DeleteEmbeddingMetadata() second call error = %v", err)
+	}
+	if deleted {
+		t.Error("DeleteEmbeddingMetadata() second call = true, want false")
+	}
+}
+
 func TestDB_SupplementPaths(t *testing.T) {
 	db, _, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -715,6 +1033,111 @@ func TestDB_SupplementPaths(t *testing.T) {
 	}
 }
 
+func TestDB_GetByExternalID(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonlPath := filepath.Join(tmpDir, "refs.jsonl")
+	refs := []reference.Reference{
+		{ID: "Full2024", DOI: "10.1234/full", Title: "Full", PMID: "111", PMCID: "PMC111", ArXivID: "2401.00001", S2ID: "s2full"},
+		{ID: "Bare2024", Title: "Bare"},
+	}
+	if err := WriteAll(jsonlPath, refs); err != nil {
+		t.Fatalf("WriteAll() error = %v", err)
+	}
+	db, err := OpenDB(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+	if _, err := db.RebuildFromJSONL(jsonlPath); err != nil {
+		t.Fatalf("RebuildFromJSONL() error = %v", err)
+	}
+
+	tests := []struct {
+		kind   string
+		value  string
+		wantID string // "" for no match
+	}{
+		{ExternalIDDOI, "10.1234/full", "Full2024"},
+		{ExternalIDPMID, "111", "Full2024"},
+		{ExternalIDPMCID, "PMC111", "Full2024"},
+		{ExternalIDArXiv, "2401.00001", "Full2024"},
+		{ExternalIDS2, "s2full", "Full2024"},
+		{ExternalIDPMID, "222", ""},
+		{ExternalIDPMCID, "PMC11", ""}, // Exact match only
+		// Empty values must not match the references lacking the ID
+		{ExternalIDDOI, "", ""},
+		{ExternalIDPMID, "", ""},
+		{ExternalIDPMCID, "", ""},
+		{ExternalIDArXiv, "", ""},
+		{ExternalIDS2, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind+"="+tt.value, func(t *testing.T) {
+			ref, err := db.GetByExternalID(tt.kind, tt.value)
+			if err != nil {
+				t.Fatalf("GetByExternalID() error = %v", err)
+			}
+			if tt.wantID == "" {
+				if ref != nil {
+					t.Errorf("GetByExternalID() = %s, want no match", ref.ID)
+				}
+				return
+			}
+			if ref == nil || ref.ID != tt.wantID {
+				t.Errorf("GetByExternalID() = %v, want %s", ref, tt.wantID)
+			}
+		})
+	}
+
+	if _, err := db.GetByExternalID("isbn", "123"); err == nil {
+		t.Error("GetByExternalID() with unknown kind should error")
+	}
+
+	// The same fields filter SearchWithFilters
+	found, err := db.SearchWithFilters(SearchFilters{ArXivID: "2401.00001"}, 10, SortDefault)
+	if err != nil {
+		t.Fatalf("SearchWithFilters() error = %v", err)
+	}
+	if len(found) != 1 || found[0].ID != "Full2024" {
+		t.Errorf("SearchWithFilters(ArXivID) = %v, want [Full2024]", found)
+	}
+}
+
+func TestDB_GetSupersededBy(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonlPath := filepath.Join(tmpDir, "refs.jsonl")
+	refs := []reference.Reference{
+		{ID: "Old2023", DOI: "10.1234/old", Title: "Preprint"},
+		{ID: "New2024", Title: "Published", Supersedes: "Old2023"},
+		{ID: "Legacy2020", DOI: "10.1234/legacy", Title: "Legacy"},
+		{ID: "ByDOI2021", Title: "By DOI", Supersedes: "10.1234/legacy"},
+		{ID: "Self2022", Title: "Self", Supersedes: "Self2022"},
+	}
+	if err := WriteAll(jsonlPath, refs); err != nil {
+		t.Fatalf("WriteAll() error = %v", err)
+	}
+	db, err := OpenDB(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+	if _, err := db.RebuildFromJSONL(jsonlPath); err != nil {
+		t.Fatalf("RebuildFromJSONL() error = %v", err)
+	}
+
+	for i, ref := range refs {
+		t.Run(ref.ID, func(t *testing.T) {
+			got, err := db.GetSupersededBy(ref)
+			if err != nil {
+				t.Fatalf("GetSupersededBy() error = %v", err)
+			}
+			if want := reference.SupersededBy(refs, i); got != want {
+				t.Errorf("GetSupersededBy() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
 func TestDB_EmptyJSONL(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "test.db")
@@ -769,6 +1192,30 @@ func TestPrepareFTSQuery(t *testing.T) {
 	}
 }
 
+func TestAuthorNameToFTSQuery(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantPrefix string
+		wantExact  string
+	}{
+		{"Li", `("Li"*)`, `("Li")`},
+		{"Wei Li", `("Wei"* OR "Li"*)`, `("Wei" AND "Li")`},
+		{`O"Brien`, `("O""Brien"*)`, `("O""Brien")`},
+		{"  ", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := authorNameToFTSPrefixQuery(tt.input); got != tt.wantPrefix {
+				t.Errorf("authorNameToFTSPrefixQuery(%q) = %q, want %q", tt.input, got, tt.wantPrefix)
+			}
+			if got := authorNameToFTSExactQuery(tt.input); got != tt.wantExact {
+				t.Errorf("authorNameToFTSExactQuery(%q) = %q, want %q", tt.input, got, tt.wantExact)
+			}
+		})
+	}
+}
+
 func TestDB_Close(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "test.db")
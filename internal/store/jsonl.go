@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -82,34 +83,35 @@ func CheckDuplicatePrimaryKey(path string, pkField string, pkValue any) (bool, e
 		return false, err
 	}
 
-	pkStr := fmt.Sprintf("%v", pkValue)
-	for _, record := range records {
-		if fmt.Sprintf("%v", record[pkField]) == pkStr {
-			return true, nil
-		}
-	}
-
-	return false, nil
+	err = newUniqueValuesFor(pkField, nil, records).check(Record{pkField: pkValue})
+	return errors.Is(err, ErrDuplicatePrimaryKey), nil
 }
 
 // AppendRecord appends a single record to a JSONL file.
 func AppendRecord(path string, record Record) error {
+	return AppendRecords(path, []Record{record})
+}
+
+// AppendRecords appends records to a JSONL file in a single open.
+func AppendRecords(path string, records []Record) error {
 	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return fmt.Errorf("opening file for append: %w", err)
 	}
 	defer f.Close()
 
-	data, err := json.Marshal(record)
-	if err != nil {
-		return fmt.Errorf("encoding record: %w", err)
-	}
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("encoding record: %w", err)
+		}
 
-	if _, err := f.Write(data); err != nil {
-		return fmt.Errorf("writing record: %w", err)
-	}
-	if _, err := f.WriteString("\n"); err != nil {
-		return fmt.Errorf("writing newline: %w", err)
+		if _, err := f.Write(data); err != nil {
+			return fmt.Errorf("writing record: %w", err)
+		}
+		if _, err := f.WriteString("\n"); err != nil {
+			return fmt.Errorf("writing newline: %w", err)
+		}
 	}
 
 	return nil
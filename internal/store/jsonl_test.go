@@ -187,6 +187,15 @@ func TestCheckDuplicatePrimaryKey(t *testing.T) {
 	if isDupe {
 		t.Error("expected no duplicate for nonexistent file")
 	}
+
+	// Values compare as Append and Import do, so 1 conflicts with "1"
+	isDupe, err = CheckDuplicatePrimaryKey(path, "id", 1)
+	if err != nil {
+		t.Fatalf("CheckDuplicatePrimaryKey (numeric): %v", err)
+	}
+	if !isDupe {
+		t.Error("expected duplicate for numeric id=1")
+	}
 }
 
 func TestAppendRecord(t *testing.T) {
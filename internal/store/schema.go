@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -41,6 +42,7 @@ type Field struct {
 	Type    FieldType `json:"type"`
 	Primary bool      `json:"primary,omitempty"`
 	Index   bool      `json:"index,omitempty"`
+	Unique  bool      `json:"unique,omitempty"` // No two records share a value; implies an index
 	FTS     bool      `json:"fts,omitempty"`
 	Enum    []string  `json:"enum,omitempty"`
 }
@@ -135,6 +137,19 @@ func (s *Schema) PrimaryKeyField() string {
 	panic("schema has no primary key field")
 }
 
+// UniqueFields returns the names of the non-primary fields marked unique,
+// sorted.
+func (s *Schema) UniqueFields() []string {
+	var names []string
+	for name, field := range s.Fields {
+		if field.Unique && !field.Primary {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ValidateRecord validates a record against this schema.
 func (s *Schema) ValidateRecord(record Record) error {
 	pkField := s.PrimaryKeyField()
@@ -39,10 +39,15 @@ func GenerateDDL(schema *Schema) string {
 		strings.Join(cols, ",\n  "))
 }
 
-// GenerateIndexDDL generates a CREATE INDEX statement for a field.
-func GenerateIndexDDL(tableName, fieldName string) string {
-	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_%s ON %s(%s)",
-		tableName, fieldName, tableName, fieldName)
+// GenerateIndexDDL generates a CREATE INDEX statement for a field, or
+// CREATE UNIQUE INDEX if unique is set.
+func GenerateIndexDDL(tableName, fieldName string, unique bool) string {
+	kind := "INDEX"
+	if unique {
+		kind = "UNIQUE INDEX"
+	}
+	return fmt.Sprintf("CREATE %s IF NOT EXISTS idx_%s_%s ON %s(%s)",
+		kind, tableName, fieldName, tableName, fieldName)
 }
 
 // GenerateFTS5DDL generates a CREATE VIRTUAL TABLE statement for FTS5.
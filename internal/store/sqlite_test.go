@@ -49,12 +49,18 @@ func TestGenerateDDL(t *testing.T) {
 }
 
 func TestGenerateIndexDDL(t *testing.T) {
-	ddl := GenerateIndexDDL("my_table", "my_field")
+	ddl := GenerateIndexDDL("my_table", "my_field", false)
 
 	expected := "CREATE INDEX IF NOT EXISTS idx_my_table_my_field ON my_table(my_field)"
 	if ddl != expected {
 		t.Errorf("GenerateIndexDDL = %q, want %q", ddl, expected)
 	}
+
+	ddl = GenerateIndexDDL("my_table", "my_field", true)
+	expected = "CREATE UNIQUE INDEX IF NOT EXISTS idx_my_table_my_field ON my_table(my_field)"
+	if ddl != expected {
+		t.Errorf("GenerateIndexDDL(unique) = %q, want %q", ddl, expected)
+	}
 }
 
 func TestGenerateFTS5DDL(t *testing.T) {
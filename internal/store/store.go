@@ -1,10 +1,12 @@
 package store
 
 import (
+	"bufio"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,6 +16,17 @@ import (
 // ErrDuplicatePrimaryKey is returned when attempting to append a record with an existing primary key.
 var ErrDuplicatePrimaryKey = errors.New("duplicate primary key")
 
+// ErrDuplicateUniqueValue is returned when attempting to append a record
+// whose value for a unique field is already taken.
+var ErrDuplicateUniqueValue = errors.New("duplicate value for unique field")
+
+// ErrRecordNotFound is returned when no record has the requested primary key.
+var ErrRecordNotFound = errors.New("record not found")
+
+// ErrPrimaryKeyChange is returned when an update would change a record's
+// primary key.
+var ErrPrimaryKeyChange = errors.New("primary key cannot be updated")
+
 // Store represents a registered data store.
 type Store struct {
 	Name       string
@@ -23,6 +36,10 @@ type Store struct {
 	jsonlPath  string // Derived: Dir/<name>.jsonl
 	dbPath     string // Derived: Dir/<name>.db
 	db         *sql.DB
+
+	// AutoSync makes Query and SearchFTS rebuild the SQLite index first
+	// whenever the JSONL content hash has changed since the last sync.
+	AutoSync bool
 }
 
 // StoreInfo contains detailed information about a store.
@@ -162,8 +179,8 @@ func (s *Store) createTables(db *sql.DB) error {
 
 	// Indexes
 	for name, field := range s.Schema.Fields {
-		if field.Index && !field.Primary {
-			indexDDL := GenerateIndexDDL(s.Schema.Name, name)
+		if (field.Index || field.Unique) && !field.Primary {
+			indexDDL := GenerateIndexDDL(s.Schema.Name, name, field.Unique)
 			if _, err := db.Exec(indexDDL); err != nil {
 				return fmt.Errorf("creating index for %s: %w", name, err)
 			}
@@ -269,6 +286,22 @@ func (s *Store) NeedsSync() (bool, error) {
 	return currentHash != storedHash, nil
 }
 
+// SyncIfNeeded rebuilds the SQLite database only if the JSONL source changed.
+// Returns true if a rebuild happened.
+func (s *Store) SyncIfNeeded() (bool, error) {
+	needsSync, err := s.NeedsSync()
+	if err != nil {
+		return false, fmt.Errorf("checking sync status: %w", err)
+	}
+	if !needsSync {
+		return false, nil
+	}
+	if _, err := s.Sync(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Sync rebuilds the SQLite database from the JSONL source.
 func (s *Store) Sync() (int, error) {
 	// Read all records
@@ -408,16 +441,13 @@ func (s *Store) Append(record Record) error {
 		return fmt.Errorf("validation error: %w", err)
 	}
 
-	// Check for duplicate primary key
-	pkField := s.Schema.PrimaryKeyField()
-	pkValue := record[pkField]
-
-	isDupe, err := CheckDuplicatePrimaryKey(s.jsonlPath, pkField, pkValue)
+	// Check for duplicate primary key and unique field values
+	existing, err := ReadAllRecords(s.jsonlPath)
 	if err != nil {
 		return fmt.Errorf("checking duplicates: %w", err)
 	}
-	if isDupe {
-		return fmt.Errorf("%w: %q already exists", ErrDuplicatePrimaryKey, pkValue)
+	if err := s.newUniqueValues(existing).check(record); err != nil {
+		return err
 	}
 
 	// Append to JSONL
@@ -428,8 +458,153 @@ func (s *Store) Append(record Record) error {
 	return nil
 }
 
+// ImportResult summarizes an Import, in the same shape as edge import.
+type ImportResult struct {
+	Added   int           `json:"added"`
+	Skipped int           `json:"skipped"`
+	Errors  []ImportError `json:"errors"`
+}
+
+// ImportError reports why a line of an import file was skipped.
+type ImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// uniqueValues tracks the values taken for the primary key and each unique
+// field, formatted with %v so that 1 and "1" conflict, as
+// CheckDuplicatePrimaryKey compares them.
+type uniqueValues struct {
+	pkField string
+	fields  []string
+	taken   map[string]map[string]bool // Field name -> taken values
+}
+
+// newUniqueValues records the key and unique field values of records.
+func (s *Store) newUniqueValues(records []Record) *uniqueValues {
+	return newUniqueValuesFor(s.Schema.PrimaryKeyField(), s.Schema.UniqueFields(), records)
+}
+
+// newUniqueValuesFor records the pkField and fields values of records.
+func newUniqueValuesFor(pkField string, fields []string, records []Record) *uniqueValues {
+	u := &uniqueValues{
+		pkField: pkField,
+		fields:  fields,
+		taken:   make(map[string]map[string]bool),
+	}
+	for _, name := range append([]string{u.pkField}, u.fields...) {
+		u.taken[name] = make(map[string]bool)
+	}
+	for _, r := range records {
+		u.add(r)
+	}
+	return u
+}
+
+// check returns ErrDuplicatePrimaryKey or ErrDuplicateUniqueValue if record
+// reuses a taken value. Records missing a unique field don't conflict.
+func (u *uniqueValues) check(record Record) error {
+	if pk := fmt.Sprintf("%v", record[u.pkField]); u.taken[u.pkField][pk] {
+		return fmt.Errorf("%w: %q already exists", ErrDuplicatePrimaryKey, pk)
+	}
+	for _, name := range u.fields {
+		value, ok := record[name]
+		if !ok || value == nil {
+			continue
+		}
+		if v := fmt.Sprintf("%v", value); u.taken[name][v] {
+			return fmt.Errorf("%w %s: %q already exists", ErrDuplicateUniqueValue, name, v)
+		}
+	}
+	return nil
+}
+
+// add marks record's key and unique field values as taken.
+func (u *uniqueValues) add(record Record) {
+	u.taken[u.pkField][fmt.Sprintf("%v", record[u.pkField])] = true
+	for _, name := range u.fields {
+		if value, ok := record[name]; ok && value != nil {
+			u.taken[name][fmt.Sprintf("%v", value)] = true
+		}
+	}
+}
+
+// Import appends the records of a JSONL file to the store. Each line is
+// validated against the schema and its primary key and unique fields checked
+// against the existing records and earlier lines; lines that fail are skipped
+// and reported in the result. Valid records are appended together, followed
+// by a single Sync. With dryRun, nothing is written.
+func (s *Store) Import(path string, dryRun bool) (*ImportResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening import file: %w", err)
+	}
+	defer f.Close()
+
+	existing, err := ReadAllRecords(s.jsonlPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading records: %w", err)
+	}
+	seen := s.newUniqueValues(existing)
+
+	result := &ImportResult{Errors: []ImportError{}}
+	skip := func(line int, msg string) {
+		result.Errors = append(result.Errors, ImportError{Line: line, Error: msg})
+		result.Skipped++
+	}
+
+	var valid []Record
+	scanner := bufio.NewScanner(f)
+	buf := make([]byte, MaxJSONLLineCapacity)
+	scanner.Buffer(buf, MaxJSONLLineCapacity)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			skip(lineNum, fmt.Sprintf("invalid JSON: %v", err))
+			continue
+		}
+		if err := s.Schema.ValidateRecord(record); err != nil {
+			skip(lineNum, fmt.Sprintf("validation error: %v", err))
+			continue
+		}
+		if err := seen.check(record); err != nil {
+			skip(lineNum, err.Error())
+			continue
+		}
+		seen.add(record)
+		valid = append(valid, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading import file: %w", err)
+	}
+
+	result.Added = len(valid)
+	if dryRun || len(valid) == 0 {
+		return result, nil
+	}
+
+	if err := AppendRecords(s.jsonlPath, valid); err != nil {
+		return nil, fmt.Errorf("appending records: %w", err)
+	}
+	if _, err := s.Sync(); err != nil {
+		return nil, fmt.Errorf("syncing: %w", err)
+	}
+	return result, nil
+}
+
 // Query executes a SQL query against the store's database.
 func (s *Store) Query(sql string) ([]Record, error) {
+	if err := s.autoSync(); err != nil {
+		return nil, err
+	}
+
 	db, err := openStoreDB(s.dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("opening database: %w", err)
@@ -445,6 +620,55 @@ func (s *Store) Query(sql string) ([]Record, error) {
 	return scanRecords(rows)
 }
 
+// SearchFTS runs a full-text search over the store's FTS fields and returns
+// matching records from the main table, best matches first.
+// A limit of 0 or less returns all matches.
+func (s *Store) SearchFTS(query string, limit int) ([]Record, error) {
+	if GenerateFTS5DDL(s.Schema) == "" {
+		return nil, fmt.Errorf("store %q has no FTS fields", s.Name)
+	}
+
+	if err := s.autoSync(); err != nil {
+		return nil, err
+	}
+
+	db, err := openStoreDB(s.dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	pkField := s.Schema.PrimaryKeyField()
+	sql := fmt.Sprintf(`SELECT t.* FROM %[1]s t
+JOIN %[1]s_fts f ON t.%[2]s = f.%[2]s
+WHERE %[1]s_fts MATCH ?
+ORDER BY f.rank`, s.Schema.Name, pkField)
+	args := []any{PrepareFTSQuery(query)}
+	if limit > 0 {
+		sql += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := db.Query(sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("executing search: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRecords(rows)
+}
+
+// autoSync syncs the store before a read when AutoSync is enabled.
+func (s *Store) autoSync() error {
+	if !s.AutoSync {
+		return nil
+	}
+	if _, err := s.SyncIfNeeded(); err != nil {
+		return fmt.Errorf("auto-sync: %w", err)
+	}
+	return nil
+}
+
 // scanRecords converts SQL rows to records.
 func scanRecords(rows *sql.Rows) ([]Record, error) {
 	cols, err := rows.Columns()
@@ -508,36 +732,64 @@ func (s *Store) DeleteByID(id any) error {
 	return nil
 }
 
-// DeleteWhere deletes records matching a SQL WHERE clause.
-// Returns the number of records deleted.
-func (s *Store) DeleteWhere(whereClause string) (int, error) {
-	// First, find matching IDs using SQL
-	db, err := openStoreDB(s.dbPath)
-	if err != nil {
-		return 0, fmt.Errorf("opening database: %w", err)
-	}
-	defer db.Close()
-
+// UpdateByID merges updates into the record with the given primary key,
+// leaving the other records in place. The merged record must pass schema
+// validation and unique checks, and updates may not change the primary key.
+func (s *Store) UpdateByID(id any, updates Record) error {
 	pkField := s.Schema.PrimaryKeyField()
-	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", pkField, s.Schema.Name, whereClause)
+	key := fmt.Sprintf("%v", id)
+	if newID, ok := updates[pkField]; ok && fmt.Sprintf("%v", newID) != key {
+		return fmt.Errorf("%w: cannot change %s of %q", ErrPrimaryKeyChange, pkField, key)
+	}
 
-	rows, err := db.Query(query)
+	records, err := ReadAllRecords(s.jsonlPath)
 	if err != nil {
-		return 0, fmt.Errorf("finding matching records: %w", err)
+		return fmt.Errorf("reading records: %w", err)
 	}
-	defer rows.Close()
 
-	// Collect IDs to delete
-	var idsToDelete []any
-	for rows.Next() {
-		var id any
-		if err := rows.Scan(&id); err != nil {
-			return 0, err
+	idx := -1
+	var others []Record
+	for i, record := range records {
+		if fmt.Sprintf("%v", record[pkField]) == key {
+			idx = i
+			continue
 		}
-		idsToDelete = append(idsToDelete, id)
+		others = append(others, record)
+	}
+	if idx < 0 {
+		return fmt.Errorf("%w: %q", ErrRecordNotFound, key)
+	}
+
+	merged := make(Record, len(records[idx])+len(updates))
+	for k, v := range records[idx] {
+		merged[k] = v
+	}
+	for k, v := range updates {
+		merged[k] = v
+	}
+	if err := s.Schema.ValidateRecord(merged); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if err := s.newUniqueValues(others).check(merged); err != nil {
+		return err
 	}
 
-	if len(idsToDelete) == 0 {
+	records[idx] = merged
+	if err := WriteAllRecords(s.jsonlPath, records); err != nil {
+		return fmt.Errorf("writing records: %w", err)
+	}
+	return nil
+}
+
+// DeleteWhere deletes records matching a SQL WHERE clause, with any ?
+// placeholders in it bound to args (see primaryKeysWhere).
+// Returns the number of records deleted.
+func (s *Store) DeleteWhere(whereClause string, args ...any) (int, error) {
+	deleteSet, err := s.primaryKeysWhere(whereClause, args...)
+	if err != nil {
+		return 0, err
+	}
+	if len(deleteSet) == 0 {
 		return 0, nil
 	}
 
@@ -547,13 +799,8 @@ func (s *Store) DeleteWhere(whereClause string) (int, error) {
 		return 0, fmt.Errorf("reading records: %w", err)
 	}
 
-	// Build set of IDs to delete
-	deleteSet := make(map[string]bool)
-	for _, id := range idsToDelete {
-		deleteSet[fmt.Sprintf("%v", id)] = true
-	}
-
 	// Filter out deleted records
+	pkField := s.Schema.PrimaryKeyField()
 	var newRecords []Record
 	for _, record := range records {
 		idStr := fmt.Sprintf("%v", record[pkField])
@@ -567,7 +814,105 @@ func (s *Store) DeleteWhere(whereClause string) (int, error) {
 		return 0, fmt.Errorf("writing records: %w", err)
 	}
 
-	return len(idsToDelete), nil
+	return len(deleteSet), nil
+}
+
+// primaryKeysWhere returns the primary keys (formatted with %v) of the
+// records in the SQLite index matching a SQL WHERE clause.
+//
+// The clause is interpolated into "SELECT <pk> FROM <store> WHERE <clause>"
+// as-is and is not sanitized: any SQL it contains runs against the index
+// with write access. Only pass clauses from a trusted source, such as the
+// user's own command line, and bind untrusted values through ? placeholders
+// and args instead of formatting them into the clause.
+func (s *Store) primaryKeysWhere(whereClause string, args ...any) (map[string]bool, error) {
+	db, err := openStoreDB(s.dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	defer db.Close()
+
+	pkField := s.Schema.PrimaryKeyField()
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", pkField, s.Schema.Name, whereClause)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("finding matching records: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make(map[string]bool)
+	for rows.Next() {
+		var id any
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		keys[fmt.Sprintf("%v", id)] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("finding matching records: %w", err)
+	}
+	return keys, nil
+}
+
+// Export writes the store's JSONL lines to w, byte for byte as stored, so
+// values keep the types SQLite would coerce. With a non-empty WHERE clause
+// (see primaryKeysWhere for its trust requirements; args bind its ?
+// placeholders), only lines whose primary key matches in the SQLite index
+// are written; the index is synced first if the JSONL changed. Returns the
+// number of records written.
+func (s *Store) Export(w io.Writer, whereClause string, args ...any) (int, error) {
+	var keep map[string]bool
+	if whereClause != "" {
+		if _, err := s.SyncIfNeeded(); err != nil {
+			return 0, fmt.Errorf("syncing: %w", err)
+		}
+		var err error
+		keep, err = s.primaryKeysWhere(whereClause, args...)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	f, err := os.Open(s.jsonlPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	pkField := s.Schema.PrimaryKeyField()
+	scanner := bufio.NewScanner(f)
+	buf := make([]byte, MaxJSONLLineCapacity)
+	scanner.Buffer(buf, MaxJSONLLineCapacity)
+	written := 0
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if keep != nil {
+			var record Record
+			if err := json.Unmarshal(line, &record); err != nil {
+				return written, fmt.Errorf("parsing line %d: %w", lineNum, err)
+			}
+			if !keep[fmt.Sprintf("%v", record[pkField])] {
+				continue
+			}
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return written, fmt.Errorf("writing record: %w", err)
+		}
+		written++
+	}
+	if err := scanner.Err(); err != nil {
+		return written, fmt.Errorf("reading file: %w", err)
+	}
+	return written, nil
 }
 
 // getLastSyncTime returns the last sync time from the database.
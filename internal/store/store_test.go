@@ -1,8 +1,11 @@
 package store
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -160,6 +163,78 @@ func TestStoreAppendAndSync(t *testing.T) {
 	}
 }
 
+func TestStoreImport(t *testing.T) {
+	store, dir := setupTestStore(t)
+	if err := os.MkdirAll(filepath.Join(dir, ".bipartite"), 0755); err != nil {
+		t.Fatalf("creating .bipartite dir: %v", err)
+	}
+	if err := store.Init(dir); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := store.Append(Record{"id": "1", "name": "existing", "count": float64(1), "active": true, "status": "done"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	importPath := filepath.Join(dir, "import.jsonl")
+	lines := `{"id": "2", "name": "new", "count": 2, "active": true, "status": "pending"}
+{"id": "1", "name": "duplicate of existing", "count": 3, "active": true, "status": "done"}
+not json
+
+{"id": "3", "name": "bad enum", "count": 4, "active": true, "status": "unknown"}
+{"id": "2", "name": "duplicate within file", "count": 5, "active": true, "status": "done"}
+{"id": "4", "name": "also new", "count": 6, "active": false, "status": "active"}
+`
+	if err := os.WriteFile(importPath, []byte(lines), 0644); err != nil {
+		t.Fatalf("writing import file: %v", err)
+	}
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		result, err := store.Import(importPath, true)
+		if err != nil {
+			t.Fatalf("Import: %v", err)
+		}
+		if result.Added != 2 || result.Skipped != 4 {
+			t.Errorf("Import(dry run) = %+v, want 2 added, 4 skipped", result)
+		}
+		records, _ := ReadAllRecords(store.JSONLPath())
+		if len(records) != 1 {
+			t.Errorf("dry run wrote records: have %d, want 1", len(records))
+		}
+	})
+
+	t.Run("import appends and syncs", func(t *testing.T) {
+		result, err := store.Import(importPath, false)
+		if err != nil {
+			t.Fatalf("Import: %v", err)
+		}
+		if result.Added != 2 || result.Skipped != 4 {
+			t.Errorf("Import() = %+v, want 2 added, 4 skipped", result)
+		}
+		wantLines := []int{2, 3, 5, 6}
+		for i, e := range result.Errors {
+			if i >= len(wantLines) || e.Line != wantLines[i] {
+				t.Errorf("error lines = %+v, want lines %v", result.Errors, wantLines)
+				break
+			}
+		}
+
+		count, err := store.Count()
+		if err != nil {
+			t.Fatalf("Count: %v", err)
+		}
+		if count != 3 {
+			t.Errorf("Count = %d, want 3", count)
+		}
+		needsSync, err := store.NeedsSync()
+		if err != nil {
+			t.Fatalf("NeedsSync: %v", err)
+		}
+		if needsSync {
+			t.Error("store should be synced after import")
+		}
+	})
+}
+
 func TestStoreQuery(t *testing.T) {
 	store, dir := setupTestStore(t)
 
@@ -311,6 +386,62 @@ func TestStoreAppend_DuplicateKey(t *testing.T) {
 	}
 }
 
+func TestStoreAppend_UniqueField(t *testing.T) {
+	dir := t.TempDir()
+	schema := &Schema{
+		Name: "people",
+		Fields: map[string]*Field{
+			"id":    {Type: FieldTypeString, Primary: true},
+			"email": {Type: FieldTypeString, Unique: true},
+			"team":  {Type: FieldTypeString, Index: true},
+		},
+	}
+	store := NewStore("people", schema, dir, filepath.Join(dir, "schema.json"))
+	if err := os.MkdirAll(filepath.Join(dir, ".bipartite"), 0755); err != nil {
+		t.Fatalf("creating .bipartite dir: %v", err)
+	}
+	if err := store.Init(dir); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	if err := store.Append(Record{"id": "1", "email": "a@example.com", "team": "x"}); err != nil {
+		t.Fatalf("first Append: %v", err)
+	}
+
+	// A duplicate non-unique value is allowed, as is a missing unique one
+	if err := store.Append(Record{"id": "2", "email": "b@example.com", "team": "x"}); err != nil {
+		t.Errorf("Append with duplicate non-unique value: %v", err)
+	}
+	if err := store.Append(Record{"id": "3", "team": "y"}); err != nil {
+		t.Errorf("Append without unique value: %v", err)
+	}
+
+	err := store.Append(Record{"id": "4", "email": "a@example.com"})
+	if !errors.Is(err, ErrDuplicateUniqueValue) {
+		t.Fatalf("Append with duplicate unique value: err = %v, want ErrDuplicateUniqueValue", err)
+	}
+	if !contains(err.Error(), "duplicate value for unique field email") {
+		t.Errorf("error should name the field: %v", err)
+	}
+
+	// Import applies the same check against existing records and earlier lines
+	importPath := filepath.Join(dir, "import.jsonl")
+	lines := `{"id": "5", "email": "a@example.com"}
+{"id": "6", "email": "c@example.com"}
+{"id": "7", "email": "c@example.com"}
+`
+	if err := os.WriteFile(importPath, []byte(lines), 0644); err != nil {
+		t.Fatalf("writing import file: %v", err)
+	}
+	result, err := store.Import(importPath, false)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Added != 1 || result.Skipped != 2 {
+		t.Errorf("Import added %d, skipped %d; want 1, 2", result.Added, result.Skipped)
+	}
+}
+
 func TestStoreDeleteByID(t *testing.T) {
 	store, dir := setupTestStore(t)
 
@@ -363,6 +494,71 @@ func TestStoreDeleteByID(t *testing.T) {
 	}
 }
 
+func TestStoreUpdateByID(t *testing.T) {
+	store, dir := setupTestStore(t)
+
+	bipartiteDir := filepath.Join(dir, ".bipartite")
+	if err := os.MkdirAll(bipartiteDir, 0755); err != nil {
+		t.Fatalf("creating .bipartite dir: %v", err)
+	}
+
+	if err := store.Init(dir); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	records := []Record{
+		{"id": "1", "name": "first", "count": float64(1), "status": "pending"},
+		{"id": "2", "name": "second", "count": float64(2), "status": "pending"},
+		{"id": "3", "name": "third", "count": float64(3), "status": "pending"},
+	}
+	for _, r := range records {
+		if err := store.Append(r); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	// Partial update changes only the given fields
+	if err := store.UpdateByID("2", Record{"status": "done"}); err != nil {
+		t.Fatalf("UpdateByID: %v", err)
+	}
+
+	got, err := ReadAllRecords(store.JSONLPath())
+	if err != nil {
+		t.Fatalf("ReadAllRecords: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d records, want 3", len(got))
+	}
+	for i, r := range got {
+		if want := records[i]["id"]; r["id"] != want {
+			t.Errorf("record %d id = %v, want %v (order should be kept)", i, r["id"], want)
+		}
+	}
+	if got[1]["status"] != "done" || got[1]["name"] != "second" || got[1]["count"] != float64(2) {
+		t.Errorf("updated record = %v, want status done with other fields kept", got[1])
+	}
+	if got[0]["status"] != "pending" {
+		t.Errorf("other record changed: %v", got[0])
+	}
+
+	// Merged record must validate
+	if err := store.UpdateByID("1", Record{"status": "bogus"}); err == nil {
+		t.Error("expected validation error for invalid enum value")
+	}
+
+	// Primary key cannot change, but repeating it is fine
+	if err := store.UpdateByID("1", Record{"id": "9"}); !errors.Is(err, ErrPrimaryKeyChange) {
+		t.Errorf("changing primary key: err = %v, want ErrPrimaryKeyChange", err)
+	}
+	if err := store.UpdateByID("1", Record{"id": "1", "name": "renamed"}); err != nil {
+		t.Errorf("UpdateByID with unchanged primary key: %v", err)
+	}
+
+	if err := store.UpdateByID("nope", Record{"name": "x"}); !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("missing id: err = %v, want ErrRecordNotFound", err)
+	}
+}
+
 func TestStoreDeleteWhere(t *testing.T) {
 	store, dir := setupTestStore(t)
 
@@ -423,6 +619,67 @@ func TestStoreDeleteWhere(t *testing.T) {
 	}
 }
 
+func TestStoreExport(t *testing.T) {
+	store, dir := setupTestStore(t)
+	if err := os.MkdirAll(filepath.Join(dir, ".bipartite"), 0755); err != nil {
+		t.Fatalf("creating .bipartite dir: %v", err)
+	}
+	if err := store.Init(dir); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	// Raw lines, including formatting that re-encoding would not keep
+	lines := []string{
+		`{"id":"1","name":"a","count":10,"status":"pending"}`,
+		`{"status":"active","id":"2","name":"b","count":20}`,
+		`{"id":"3","name":"c","count":30,"status":"pending","count_str":"0030"}`,
+	}
+	content := strings.Join(lines, "\n") + "\n"
+	if err := os.WriteFile(store.JSONLPath(), []byte(content), 0644); err != nil {
+		t.Fatalf("writing JSONL: %v", err)
+	}
+
+	t.Run("everything", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := store.Export(&buf, "")
+		if err != nil {
+			t.Fatalf("Export: %v", err)
+		}
+		if n != 3 || buf.String() != content {
+			t.Errorf("Export() = %d records:\n%s\nwant the JSONL unchanged", n, buf.String())
+		}
+	})
+
+	t.Run("where clause syncs and filters", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := store.Export(&buf, "status = 'pending'")
+		if err != nil {
+			t.Fatalf("Export: %v", err)
+		}
+		want := lines[0] + "\n" + lines[2] + "\n"
+		if n != 2 || buf.String() != want {
+			t.Errorf("Export(where) = %d records:\n%s\nwant:\n%s", n, buf.String(), want)
+		}
+	})
+
+	t.Run("where clause with bound args", func(t *testing.T) {
+		var buf bytes.Buffer
+		n, err := store.Export(&buf, "status = ? AND count > ?", "pending", 15)
+		if err != nil {
+			t.Fatalf("Export: %v", err)
+		}
+		if want := lines[2] + "\n"; n != 1 || buf.String() != want {
+			t.Errorf("Export(where, args) = %d records:\n%s\nwant:\n%s", n, buf.String(), want)
+		}
+	})
+
+	t.Run("invalid where clause", func(t *testing.T) {
+		if _, err := store.Export(&bytes.Buffer{}, "no_such_column = 1"); err == nil {
+			t.Error("Export() with invalid WHERE clause should fail")
+		}
+	})
+}
+
 func TestStoreInfo(t *testing.T) {
 	store, dir := setupTestStore(t)
 
@@ -528,6 +785,108 @@ func TestStoreFTS(t *testing.T) {
 	}
 }
 
+func TestStoreSearchFTS(t *testing.T) {
+	store, dir := setupTestStore(t)
+
+	bipartiteDir := filepath.Join(dir, ".bipartite")
+	if err := os.MkdirAll(bipartiteDir, 0755); err != nil {
+		t.Fatalf("creating .bipartite dir: %v", err)
+	}
+
+	if err := store.Init(dir); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	for _, r := range []Record{
+		{"id": "1", "name": "hello world"},
+		{"id": "2", "name": "goodbye world"},
+		{"id": "3", "name": "hello there"},
+	} {
+		if err := store.Append(r); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if _, err := store.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	results, err := store.SearchFTS("hello", 0)
+	if err != nil {
+		t.Fatalf("SearchFTS: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("SearchFTS: got %d results, want 2", len(results))
+	}
+	for _, r := range results {
+		if r["name"] == nil {
+			t.Errorf("SearchFTS should return main table columns, got %v", r)
+		}
+	}
+
+	results, err = store.SearchFTS("hello", 1)
+	if err != nil {
+		t.Fatalf("SearchFTS with limit: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("SearchFTS with limit: got %d results, want 1", len(results))
+	}
+}
+
+func TestStoreAutoSync(t *testing.T) {
+	store, dir := setupTestStore(t)
+
+	bipartiteDir := filepath.Join(dir, ".bipartite")
+	if err := os.MkdirAll(bipartiteDir, 0755); err != nil {
+		t.Fatalf("creating .bipartite dir: %v", err)
+	}
+
+	if err := store.Init(dir); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := store.Append(Record{"id": "1", "name": "hello world"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	// Without auto-sync the index is stale and the query sees nothing
+	results, err := store.Query("SELECT id FROM test_store")
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("stale Query: got %d results, want 0", len(results))
+	}
+
+	store.AutoSync = true
+
+	results, err = store.Query("SELECT id FROM test_store")
+	if err != nil {
+		t.Fatalf("Query with AutoSync: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Query with AutoSync: got %d results, want 1", len(results))
+	}
+
+	// Appending again changes the hash, so SearchFTS resyncs too
+	if err := store.Append(Record{"id": "2", "name": "hello again"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	results, err = store.SearchFTS("hello", 0)
+	if err != nil {
+		t.Fatalf("SearchFTS with AutoSync: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("SearchFTS with AutoSync: got %d results, want 2", len(results))
+	}
+
+	synced, err := store.SyncIfNeeded()
+	if err != nil {
+		t.Fatalf("SyncIfNeeded: %v", err)
+	}
+	if synced {
+		t.Error("SyncIfNeeded should be a no-op when already in sync")
+	}
+}
+
 func TestOpenStore(t *testing.T) {
 	store, dir := setupTestStore(t)
 
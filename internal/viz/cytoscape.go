@@ -23,11 +23,12 @@ type CytoscapeEdge struct {
 
 // CytoscapeEdgeData contains the edge data fields.
 type CytoscapeEdgeData struct {
-	ID               string `json:"id"`
-	Source           string `json:"source"`
-	Target           string `json:"target"`
-	RelationshipType string `json:"relationshipType"`
-	Summary          string `json:"summary"`
+	ID               string   `json:"id"`
+	Source           string   `json:"source"`
+	Target           string   `json:"target"`
+	RelationshipType string   `json:"relationshipType"`
+	Summary          string   `json:"summary"`
+	Strength         *float64 `json:"strength,omitempty"`
 }
 
 // ToCytoscapeJSON converts GraphData to Cytoscape.js JSON format.
@@ -49,6 +50,7 @@ func (g *GraphData) ToCytoscapeJSON() (string, error) {
 				Target:           e.Target,
 				RelationshipType: e.RelationshipType,
 				Summary:          e.Summary,
+				Strength:         e.Strength,
 			},
 		}
 		elements.Edges = append(elements.Edges, cyEdge)
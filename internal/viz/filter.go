@@ -0,0 +1,82 @@
+package viz
+
+import "fmt"
+
+// GraphFilter selects a subgraph around one or more seed nodes.
+// Multiple projects and concepts may be combined; the result is the union of
+// each seed plus its direct neighbors.
+type GraphFilter struct {
+	Projects []string // Project IDs to center the subgraph on
+	Concepts []string // Concept IDs to center the subgraph on
+}
+
+// IsEmpty returns true if no filter was requested.
+func (f GraphFilter) IsEmpty() bool {
+	return len(f.Projects) == 0 && len(f.Concepts) == 0
+}
+
+// FilterSubgraph returns the induced subgraph on the seed nodes named by the
+// filter plus their direct neighbors. Every edge whose endpoints are both kept
+// is retained, so neighbor-to-neighbor edges survive.
+//
+// The second return value names each filter that matched no node (e.g.,
+// "project dasm2"), so callers can explain an empty result.
+func (g *GraphData) FilterSubgraph(f GraphFilter) (*GraphData, []string) {
+	if f.IsEmpty() {
+		return g, nil
+	}
+
+	// A seed must exist as a node of the requested type, so --project foo
+	// doesn't silently match a concept that happens to be named foo.
+	present := make(map[string]map[string]bool)
+	for _, n := range g.Nodes {
+		if present[n.Type] == nil {
+			present[n.Type] = make(map[string]bool)
+		}
+		present[n.Type][n.ID] = true
+	}
+
+	seeds := make(map[string]bool)
+	var missing []string
+	for _, id := range f.Projects {
+		if present[NodeTypeProject][id] {
+			seeds[id] = true
+		} else {
+			missing = append(missing, fmt.Sprintf("project %s", id))
+		}
+	}
+	for _, id := range f.Concepts {
+		if present[NodeTypeConcept][id] {
+			seeds[id] = true
+		} else {
+			missing = append(missing, fmt.Sprintf("concept %s", id))
+		}
+	}
+
+	keep := make(map[string]bool, len(seeds))
+	for id := range seeds {
+		keep[id] = true
+	}
+	for _, e := range g.Edges {
+		if seeds[e.Source] {
+			keep[e.Target] = true
+		}
+		if seeds[e.Target] {
+			keep[e.Source] = true
+		}
+	}
+
+	sub := &GraphData{}
+	for _, n := range g.Nodes {
+		if keep[n.ID] {
+			sub.Nodes = append(sub.Nodes, n)
+		}
+	}
+	for _, e := range g.Edges {
+		if keep[e.Source] && keep[e.Target] {
+			sub.Edges = append(sub.Edges, e)
+		}
+	}
+
+	return sub, missing
+}
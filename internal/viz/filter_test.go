@@ -0,0 +1,115 @@
+package viz
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// filterTestGraph builds: paperA→concept c1→project p1←repo:r1, paperB→c2, c2→p2.
+func filterTestGraph() *GraphData {
+	return &GraphData{
+		Nodes: []Node{
+			{ID: "PaperA", Type: NodeTypePaper},
+			{ID: "PaperB", Type: NodeTypePaper},
+			{ID: "c1", Type: NodeTypeConcept},
+			{ID: "c2", Type: NodeTypeConcept},
+			{ID: "p1", Type: NodeTypeProject},
+			{ID: "p2", Type: NodeTypeProject},
+			{ID: "repo:r1", Type: NodeTypeRepo},
+		},
+		Edges: []Edge{
+			{Source: "PaperA", Target: "c1", RelationshipType: "introduces"},
+			{Source: "PaperB", Target: "c2", RelationshipType: "applies"},
+			{Source: "c1", Target: "p1", RelationshipType: "implemented-in"},
+			{Source: "c2", Target: "p2", RelationshipType: "applied-in"},
+			{Source: "repo:r1", Target: "p1", RelationshipType: RelationshipBelongsTo},
+		},
+	}
+}
+
+func nodeIDs(g *GraphData) []string {
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestFilterSubgraph(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      GraphFilter
+		wantNodes   string
+		wantEdges   int
+		wantMissing []string
+	}{
+		{
+			name:      "no filter returns full graph",
+			filter:    GraphFilter{},
+			wantNodes: "PaperA,PaperB,c1,c2,p1,p2,repo:r1",
+			wantEdges: 5,
+		},
+		{
+			name:      "project with neighbors",
+			filter:    GraphFilter{Projects: []string{"p1"}},
+			wantNodes: "c1,p1,repo:r1",
+			wantEdges: 2,
+		},
+		{
+			name:      "multiple projects combine",
+			filter:    GraphFilter{Projects: []string{"p1", "p2"}},
+			wantNodes: "c1,c2,p1,p2,repo:r1",
+			wantEdges: 3,
+		},
+		{
+			name:      "concept with neighbors",
+			filter:    GraphFilter{Concepts: []string{"c1"}},
+			wantNodes: "PaperA,c1,p1",
+			wantEdges: 2,
+		},
+		{
+			name:        "concept ID passed as project is missing",
+			filter:      GraphFilter{Projects: []string{"c1"}},
+			wantNodes:   "",
+			wantEdges:   0,
+			wantMissing: []string{"project c1"},
+		},
+		{
+			name:        "partial match keeps found seeds",
+			filter:      GraphFilter{Projects: []string{"p2", "nope"}},
+			wantNodes:   "c2,p2",
+			wantEdges:   1,
+			wantMissing: []string{"project nope"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, missing := filterTestGraph().FilterSubgraph(tt.filter)
+			if ids := strings.Join(nodeIDs(got), ","); ids != tt.wantNodes {
+				t.Errorf("nodes = %s, want %s", ids, tt.wantNodes)
+			}
+			if len(got.Edges) != tt.wantEdges {
+				t.Errorf("edges = %d, want %d", len(got.Edges), tt.wantEdges)
+			}
+			if strings.Join(missing, ";") != strings.Join(tt.wantMissing, ";") {
+				t.Errorf("missing = %v, want %v", missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestGenerateHTML_EmptyMessage(t *testing.T) {
+	html, err := GenerateHTML(&GraphData{}, HTMLOptions{EmptyMessage: "No node found for project <x>."})
+	if err != nil {
+		t.Fatalf("GenerateHTML() error = %v", err)
+	}
+	if !strings.Contains(html, "No node found for project &lt;x&gt;.") {
+		t.Error("empty page should show the escaped filter message")
+	}
+	if strings.Contains(html, "bip concept add") {
+		t.Error("custom message should replace the default hint")
+	}
+}
@@ -17,6 +17,7 @@ const (
 	conceptPrefix = "concept:"
 	projectPrefix = "project:"
 	repoPrefix    = "repo:"
+	groupPrefix   = "group:"
 )
 
 // Relationship types for derived edges (not stored in database).
@@ -121,6 +122,7 @@ func processEdges(allEdges []edge.Edge, conceptIDs, projectIDs map[string]bool)
 				Target:           targetID,
 				RelationshipType: e.RelationshipType,
 				Summary:          e.Summary,
+				Strength:         e.Strength,
 			})
 			continue
 		}
@@ -134,6 +136,7 @@ func processEdges(allEdges []edge.Edge, conceptIDs, projectIDs map[string]bool)
 				Target:           targetID,
 				RelationshipType: e.RelationshipType,
 				Summary:          e.Summary,
+				Strength:         e.Strength,
 			})
 			continue
 		}
@@ -147,6 +150,7 @@ func processEdges(allEdges []edge.Edge, conceptIDs, projectIDs map[string]bool)
 				Target:           targetID,
 				RelationshipType: e.RelationshipType,
 				Summary:          e.Summary,
+				Strength:         e.Strength,
 			})
 			continue
 		}
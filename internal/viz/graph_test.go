@@ -389,3 +389,29 @@ func TestNormalizeID(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessEdges_Strength(t *testing.T) {
+	strength := 0.8
+	edges := []edge.Edge{
+		{SourceID: "Paper2023-ab", TargetID: "concept:shm", RelationshipType: "introduces", Summary: "Foundational", Strength: &strength},
+		{SourceID: "Paper2023-cd", TargetID: "concept:shm", RelationshipType: "applies", Summary: "Tangential"},
+	}
+	vizEdges, _, _, _ := processEdges(edges, map[string]bool{"shm": true}, map[string]bool{})
+	if len(vizEdges) != 2 {
+		t.Fatalf("got %d edges, want 2", len(vizEdges))
+	}
+	if vizEdges[0].Strength == nil || *vizEdges[0].Strength != 0.8 {
+		t.Errorf("weighted edge strength = %v, want 0.8", vizEdges[0].Strength)
+	}
+	if vizEdges[1].Strength != nil {
+		t.Errorf("unweighted edge strength = %v, want nil", *vizEdges[1].Strength)
+	}
+
+	js, err := (&GraphData{Edges: vizEdges}).ToCytoscapeJSON()
+	if err != nil {
+		t.Fatalf("ToCytoscapeJSON() error = %v", err)
+	}
+	if strings.Count(js, `"strength"`) != 1 || !strings.Contains(js, `"strength":0.8`) {
+		t.Errorf("Cytoscape JSON should carry strength only for the weighted edge: %s", js)
+	}
+}
@@ -0,0 +1,133 @@
+package viz
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidGroupBy lists the supported --group-by values.
+var ValidGroupBy = []string{"project"}
+
+// ApplyGrouping groups the graph by the named key. An empty key returns the
+// graph unchanged.
+func (g *GraphData) ApplyGrouping(groupBy string) (*GraphData, error) {
+	switch groupBy {
+	case "":
+		return g, nil
+	case "project":
+		return g.GroupByProject(), nil
+	default:
+		return nil, fmt.Errorf("invalid group-by %q: must be one of %s", groupBy, strings.Join(ValidGroupBy, ", "))
+	}
+}
+
+// GroupByProject returns a copy of the graph in which every node that can be
+// tied to a project is nested inside a compound group node for that project.
+//
+// Membership is computed as follows:
+//   - a project belongs to its own group;
+//   - a repo belongs to the project it has a belongs-to edge to;
+//   - a concept belongs to the project it shares the most edges with;
+//   - a paper belongs to the group holding the most of its concepts.
+//
+// Ties go to the project with the alphabetically first ID, so the layout is
+// deterministic. Nodes with no project connection stay ungrouped. If the
+// graph has no projects, it is returned unchanged.
+func (g *GraphData) GroupByProject() *GraphData {
+	projectIDs := make(map[string]bool)
+	conceptIDs := make(map[string]bool)
+	for _, n := range g.Nodes {
+		switch n.Type {
+		case NodeTypeProject:
+			projectIDs[n.ID] = true
+		case NodeTypeConcept:
+			conceptIDs[n.ID] = true
+		}
+	}
+	if len(projectIDs) == 0 {
+		return g
+	}
+
+	group := make(map[string]string, len(g.Nodes))
+	for id := range projectIDs {
+		group[id] = id
+	}
+	for _, e := range g.Edges {
+		if e.RelationshipType == RelationshipBelongsTo && projectIDs[e.Target] {
+			group[e.Source] = e.Target
+		}
+	}
+
+	// Concepts: count edges to each project in either direction
+	conceptVotes := make(map[string]map[string]int)
+	for _, e := range g.Edges {
+		switch {
+		case conceptIDs[e.Source] && projectIDs[e.Target]:
+			addVote(conceptVotes, e.Source, e.Target)
+		case conceptIDs[e.Target] && projectIDs[e.Source]:
+			addVote(conceptVotes, e.Target, e.Source)
+		}
+	}
+	for id, votes := range conceptVotes {
+		group[id] = pickGroup(votes)
+	}
+
+	// Papers: follow their concepts into a group
+	paperVotes := make(map[string]map[string]int)
+	for _, e := range g.Edges {
+		if !conceptIDs[e.Target] || conceptIDs[e.Source] || projectIDs[e.Source] {
+			continue
+		}
+		if p, ok := group[e.Target]; ok {
+			addVote(paperVotes, e.Source, p)
+		}
+	}
+	for id, votes := range paperVotes {
+		group[id] = pickGroup(votes)
+	}
+
+	grouped := &GraphData{Edges: g.Edges}
+	used := make(map[string]bool)
+	for _, n := range g.Nodes {
+		if p, ok := group[n.ID]; ok {
+			n.Parent = groupPrefix + p
+			used[p] = true
+		}
+		grouped.Nodes = append(grouped.Nodes, n)
+	}
+
+	// Emit one container per project, in node order for stable output
+	for _, n := range g.Nodes {
+		if n.Type != NodeTypeProject || !used[n.ID] {
+			continue
+		}
+		grouped.Nodes = append(grouped.Nodes, Node{
+			ID:    groupPrefix + n.ID,
+			Type:  NodeTypeGroup,
+			Label: n.Label,
+			Name:  n.Name,
+		})
+	}
+
+	return grouped
+}
+
+// addVote increments votes[node][project].
+func addVote(votes map[string]map[string]int, node, project string) {
+	if votes[node] == nil {
+		votes[node] = make(map[string]int)
+	}
+	votes[node][project]++
+}
+
+// pickGroup returns the project with the most votes, breaking ties by the
+// alphabetically first project ID.
+func pickGroup(votes map[string]int) string {
+	best := ""
+	for p, n := range votes {
+		if best == "" || n > votes[best] || (n == votes[best] && p < best) {
+			best = p
+		}
+	}
+	return best
+}
@@ -0,0 +1,98 @@
+package viz
+
+import (
+	"strings"
+	"testing"
+)
+
+func parents(g *GraphData) map[string]string {
+	m := make(map[string]string)
+	for _, n := range g.Nodes {
+		m[n.ID] = n.Parent
+	}
+	return m
+}
+
+func TestGroupByProject(t *testing.T) {
+	g := filterTestGraph()
+	// PaperC links to c1 and c2, which sit in p1 and p2: a tie
+	g.Nodes = append(g.Nodes, Node{ID: "PaperC", Type: NodeTypePaper}, Node{ID: "c3", Type: NodeTypeConcept})
+	g.Edges = append(g.Edges,
+		Edge{Source: "PaperC", Target: "c1"},
+		Edge{Source: "PaperC", Target: "c2"},
+		// c2 has two edges to p2 and one to p1, so p2 wins
+		Edge{Source: "p1", Target: "c2"},
+		Edge{Source: "p2", Target: "c2"},
+	)
+
+	grouped := g.GroupByProject()
+	got := parents(grouped)
+
+	want := map[string]string{
+		"p1":      "group:p1",
+		"p2":      "group:p2",
+		"repo:r1": "group:p1",
+		"c1":      "group:p1",
+		"c2":      "group:p2",
+		"PaperA":  "group:p1",
+		"PaperB":  "group:p2",
+		"PaperC":  "group:p1", // tie broken by project ID
+		"c3":      "",         // no project connection
+	}
+	for id, parent := range want {
+		if got[id] != parent {
+			t.Errorf("parent of %s = %q, want %q", id, got[id], parent)
+		}
+	}
+
+	var groups []string
+	for _, n := range grouped.Nodes {
+		if n.Type == NodeTypeGroup {
+			groups = append(groups, n.ID)
+		}
+	}
+	if strings.Join(groups, ",") != "group:p1,group:p2" {
+		t.Errorf("group nodes = %v, want [group:p1 group:p2]", groups)
+	}
+
+	// The input graph is left untouched
+	for _, n := range g.Nodes {
+		if n.Parent != "" {
+			t.Errorf("input node %s was modified", n.ID)
+		}
+	}
+}
+
+func TestGroupByProject_NoProjects(t *testing.T) {
+	g := &GraphData{
+		Nodes: []Node{{ID: "PaperA", Type: NodeTypePaper}, {ID: "c1", Type: NodeTypeConcept}},
+		Edges: []Edge{{Source: "PaperA", Target: "c1"}},
+	}
+	if grouped := g.GroupByProject(); grouped != g {
+		t.Error("GroupByProject() without projects should return the graph unchanged")
+	}
+}
+
+func TestApplyGrouping_Valid(t *testing.T) {
+	for _, groupBy := range append([]string{""}, ValidGroupBy...) {
+		if _, err := filterTestGraph().ApplyGrouping(groupBy); err != nil {
+			t.Errorf("ApplyGrouping(%q) error = %v", groupBy, err)
+		}
+	}
+}
+
+func TestApplyGrouping_Invalid(t *testing.T) {
+	if _, err := filterTestGraph().ApplyGrouping("concept"); err == nil {
+		t.Error("ApplyGrouping(\"concept\") error = nil, want error")
+	}
+}
+
+func TestToCytoscapeJSON_Parent(t *testing.T) {
+	js, err := filterTestGraph().GroupByProject().ToCytoscapeJSON()
+	if err != nil {
+		t.Fatalf("ToCytoscapeJSON() error = %v", err)
+	}
+	if !strings.Contains(js, `"parent":"group:p1"`) {
+		t.Error("Cytoscape JSON missing parent reference for grouped nodes")
+	}
+}
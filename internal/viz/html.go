@@ -2,7 +2,9 @@ package viz
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"html"
 	"html/template"
 )
 
@@ -15,8 +17,12 @@ func init() {
 
 // HTMLOptions configures HTML generation.
 type HTMLOptions struct {
-	Layout  string // "force", "circle", or "grid"
+	Layout  string // "force", "circle", "grid", or "hierarchical"
 	Offline bool   // Whether to embed Cytoscape.js inline
+
+	// EmptyMessage replaces the default hint on the empty-graph page,
+	// e.g. to name a filter that matched nothing.
+	EmptyMessage string
 }
 
 // DefaultOptions returns default HTML generation options.
@@ -28,7 +34,7 @@ func DefaultOptions() HTMLOptions {
 }
 
 // ValidLayouts lists the supported layout algorithm names.
-var ValidLayouts = []string{"force", "circle", "grid"}
+var ValidLayouts = []string{"force", "circle", "grid", "hierarchical"}
 
 // GenerateHTML generates a self-contained HTML file for the graph visualization.
 func GenerateHTML(graph *GraphData, opts HTMLOptions) (string, error) {
@@ -42,7 +48,7 @@ func GenerateHTML(graph *GraphData, opts HTMLOptions) (string, error) {
 	}
 
 	if graph.IsEmpty() {
-		return generateEmptyHTML(), nil
+		return generateEmptyHTML(opts.EmptyMessage), nil
 	}
 
 	graphJSON, err := graph.ToCytoscapeJSON()
@@ -53,10 +59,18 @@ func GenerateHTML(graph *GraphData, opts HTMLOptions) (string, error) {
 	layout := layoutToCytoscape(opts.Layout)
 	scriptTag := buildScriptTag(opts.Offline)
 
+	layoutOpts, err := json.Marshal(layoutOptions(layout))
+	if err != nil {
+		return "", fmt.Errorf("marshaling layout options: %w", err)
+	}
+
 	data := templateData{
-		ScriptTag: template.HTML(scriptTag),
-		GraphJSON: template.JS(graphJSON),
-		Layout:    layout,
+		ScriptTag:     template.HTML(scriptTag),
+		GraphJSON:     template.JS(graphJSON),
+		Layout:        layout,
+		LayoutOptions: template.JS(layoutOpts),
+		Legend:        buildLegend(graph),
+		EdgeCount:     len(graph.Edges),
 	}
 
 	var buf bytes.Buffer
@@ -70,18 +84,67 @@ func GenerateHTML(graph *GraphData, opts HTMLOptions) (string, error) {
 // validateLayout checks if the layout option is valid.
 func validateLayout(layout string) error {
 	switch layout {
-	case "", "force", "circle", "grid":
+	case "", "force", "circle", "grid", "hierarchical":
 		return nil
 	default:
-		return fmt.Errorf("invalid layout %q: must be force, circle, or grid", layout)
+		return fmt.Errorf("invalid layout %q: must be force, circle, grid, or hierarchical", layout)
 	}
 }
 
 // templateData holds data for the HTML template.
 type templateData struct {
-	ScriptTag template.HTML
-	GraphJSON template.JS
-	Layout    string
+	ScriptTag     template.HTML
+	GraphJSON     template.JS
+	Layout        string
+	LayoutOptions template.JS // Algorithm-specific Cytoscape.js layout options
+	Legend        []legendEntry
+	EdgeCount     int
+}
+
+// legendEntry is one row of the counts/legend panel.
+type legendEntry struct {
+	Label string // Plural display name, e.g. "Papers"
+	Shape string // CSS class for the swatch: circle, diamond, hexagon, square
+	Color string
+	Count int
+}
+
+// nodeTypeStyles lists each node type's legend appearance in display order.
+// Colors and shapes must match the node styles in htmlTemplate.
+var nodeTypeStyles = []struct {
+	Type  string
+	Label string
+	Shape string
+	Color string
+}{
+	{NodeTypePaper, "Papers", "circle", "#4A90D9"},
+	{NodeTypeConcept, "Concepts", "diamond", "#E8923A"},
+	{NodeTypeProject, "Projects", "hexagon", "#27AE60"},
+	{NodeTypeRepo, "Repos", "square", "#7F8C8D"},
+}
+
+// buildLegend counts nodes per type and returns a legend row for each type
+// present in the graph. Counting here rather than in JS keeps the panel
+// correct even before Cytoscape.js has loaded.
+func buildLegend(graph *GraphData) []legendEntry {
+	counts := make(map[string]int)
+	for _, n := range graph.Nodes {
+		counts[n.Type]++
+	}
+
+	var legend []legendEntry
+	for _, style := range nodeTypeStyles {
+		if counts[style.Type] == 0 {
+			continue
+		}
+		legend = append(legend, legendEntry{
+			Label: style.Label,
+			Shape: style.Shape,
+			Color: style.Color,
+			Count: counts[style.Type],
+		})
+	}
+	return legend
 }
 
 // layoutToCytoscape converts user-friendly layout names to Cytoscape.js layout algorithm names.
@@ -91,6 +154,8 @@ func layoutToCytoscape(layout string) string {
 		return "circle"
 	case "grid":
 		return "grid"
+	case "hierarchical":
+		return "breadthfirst"
 	case "", "force":
 		return "cose"
 	default:
@@ -98,6 +163,29 @@ func layoutToCytoscape(layout string) string {
 	}
 }
 
+// layoutOptions returns the algorithm-specific options for a Cytoscape.js layout name.
+func layoutOptions(cyLayout string) map[string]interface{} {
+	switch cyLayout {
+	case "cose":
+		return map[string]interface{}{
+			"nodeRepulsion":   8000,
+			"idealEdgeLength": 100,
+			"edgeElasticity":  100,
+		}
+	case "breadthfirst":
+		// Projects sit on the top layer, concepts below, papers at the bottom.
+		// Stored edges point upward (paper→concept→project), so the traversal
+		// from the project roots must ignore edge direction to reach papers.
+		return map[string]interface{}{
+			"roots":         `node[type="project"]`,
+			"directed":      false,
+			"spacingFactor": 1.25,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
 // buildScriptTag returns either inline script or CDN reference.
 func buildScriptTag(offline bool) string {
 	if offline {
@@ -106,8 +194,18 @@ func buildScriptTag(offline bool) string {
 	return `<script src="https://unpkg.com/cytoscape@3/dist/cytoscape.min.js"></script>`
 }
 
+// defaultEmptyHint is shown on the empty-graph page when no message is given.
+const defaultEmptyHint = `<p>Your library doesn't have any concept edges yet.</p>
+    <p>Add concepts using <code>bip concept add</code></p>
+    <p>Add edges using <code>bip edge add</code></p>`
+
 // generateEmptyHTML returns HTML for an empty graph state.
-func generateEmptyHTML() string {
+// A non-empty message is shown (escaped) in place of the default hint.
+func generateEmptyHTML(message string) string {
+	hint := defaultEmptyHint
+	if message != "" {
+		hint = "<p>" + html.EscapeString(message) + "</p>"
+	}
 	return `<!DOCTYPE html>
 <html>
 <head>
@@ -144,9 +242,7 @@ func generateEmptyHTML() string {
 <body>
   <div class="empty-state">
     <h2>No graph data</h2>
-    <p>Your library doesn't have any concept edges yet.</p>
-    <p>Add concepts using <code>bip concept add</code></p>
-    <p>Add edges using <code>bip edge add</code></p>
+    ` + hint + `
   </div>
 </body>
 </html>`
@@ -206,15 +302,68 @@ const htmlTemplate = `<!DOCTYPE html>
       color: #666;
       margin-top: 4px;
     }
+    /* Counts and legend panel */
+    #legend {
+      position: fixed;
+      top: 12px;
+      left: 12px;
+      background: rgba(255,255,255,0.92);
+      border: 1px solid #ddd;
+      border-radius: 4px;
+      padding: 8px 12px;
+      box-shadow: 0 1px 4px rgba(0,0,0,0.1);
+      font-size: 12px;
+      color: #333;
+      z-index: 900;
+    }
+    #legend .row {
+      display: flex;
+      align-items: center;
+      margin: 3px 0;
+    }
+    #legend .swatch {
+      width: 12px;
+      height: 12px;
+      margin-right: 8px;
+      flex-shrink: 0;
+    }
+    #legend .swatch.circle {
+      border-radius: 50%;
+    }
+    #legend .swatch.diamond {
+      width: 10px;
+      height: 10px;
+      margin: 0 9px 0 1px;
+      transform: rotate(45deg);
+    }
+    #legend .swatch.hexagon {
+      clip-path: polygon(25% 0, 75% 0, 100% 50%, 75% 100%, 25% 100%, 0 50%);
+    }
+    #legend .count {
+      margin-left: auto;
+      padding-left: 12px;
+      font-weight: bold;
+    }
+    #legend .edges {
+      margin-top: 6px;
+      padding-top: 4px;
+      border-top: 1px solid #eee;
+      color: #666;
+    }
   </style>
 </head>
 <body>
   <div id="cy"></div>
+  <div id="legend">
+    {{range .Legend}}<div class="row"><span class="swatch {{.Shape}}" style="background: {{.Color}}"></span>{{.Label}}<span class="count">{{.Count}}</span></div>
+    {{end}}<div class="row edges">Edges<span class="count">{{.EdgeCount}}</span></div>
+  </div>
   <div id="tooltip"></div>
   <script>
     (function() {
       const graphData = {{.GraphJSON}};
       const layout = "{{.Layout}}";
+      const layoutOptions = {{.LayoutOptions}};
 
       // Initialize Cytoscape
       const cy = cytoscape({
@@ -281,6 +430,24 @@ const htmlTemplate = `<!DOCTYPE html>
               'height': '20px'
             }
           },
+          // Project groups (--group-by project) - translucent green containers
+          {
+            selector: 'node[type="group"]',
+            style: {
+              'background-color': '#27AE60',
+              'background-opacity': 0.08,
+              'border-color': '#27AE60',
+              'border-width': '1px',
+              'shape': 'round-rectangle',
+              'label': 'data(label)',
+              'color': '#1E8449',
+              'font-size': '12px',
+              'font-weight': 'bold',
+              'text-valign': 'top',
+              'text-halign': 'center',
+              'padding': '20px'
+            }
+          },
           // Edge styling by relationship type
           {
             selector: 'edge[relationshipType="introduces"]',
@@ -368,6 +535,13 @@ const htmlTemplate = `<!DOCTYPE html>
               'width': 2
             }
           },
+          // Weighted edges: width scales with strength; unweighted keep the default
+          {
+            selector: 'edge[strength]',
+            style: {
+              'width': 'mapData(strength, 0, 1, 1, 6)'
+            }
+          },
           // Highlighted state
           {
             selector: 'node.highlighted',
@@ -389,14 +563,7 @@ const htmlTemplate = `<!DOCTYPE html>
             }
           }
         ],
-        layout: {
-          name: layout,
-          animate: false,
-          // cose-specific options
-          nodeRepulsion: 8000,
-          idealEdgeLength: 100,
-          edgeElasticity: 100
-        }
+        layout: Object.assign({ name: layout, animate: false }, layoutOptions)
       });
 
       // Tooltip handling
@@ -452,6 +619,7 @@ const htmlTemplate = `<!DOCTYPE html>
         let html = '<div class="type">' + data.relationshipType + '</div>';
         html += '<div class="label">' + escapeHtml(data.source) + ' → ' + escapeHtml(data.target) + '</div>';
         if (data.summary) html += '<div class="summary">' + escapeHtml(data.summary) + '</div>';
+        if (data.strength !== undefined) html += '<div class="detail">Strength: ' + data.strength + '</div>';
         return html;
       }
 
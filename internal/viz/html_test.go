@@ -0,0 +1,114 @@
+package viz
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateLayout(t *testing.T) {
+	for _, layout := range append([]string{""}, ValidLayouts...) {
+		if err := validateLayout(layout); err != nil {
+			t.Errorf("validateLayout(%q) error = %v", layout, err)
+		}
+	}
+	if err := validateLayout("dagre"); err == nil {
+		t.Error("validateLayout(dagre) should fail")
+	}
+}
+
+func TestLayoutToCytoscape(t *testing.T) {
+	tests := map[string]string{
+		"":             "cose",
+		"force":        "cose",
+		"circle":       "circle",
+		"grid":         "grid",
+		"hierarchical": "breadthfirst",
+	}
+	for in, want := range tests {
+		if got := layoutToCytoscape(in); got != want {
+			t.Errorf("layoutToCytoscape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateHTML_HierarchicalLayoutOptions(t *testing.T) {
+	graph := &GraphData{
+		Nodes: []Node{
+			{ID: "p1", Type: NodeTypeProject, Label: "P1"},
+			{ID: "c1", Type: NodeTypeConcept, Label: "C1"},
+		},
+		Edges: []Edge{{Source: "c1", Target: "p1", RelationshipType: "implemented-in"}},
+	}
+
+	html, err := GenerateHTML(graph, HTMLOptions{Layout: "hierarchical"})
+	if err != nil {
+		t.Fatalf("GenerateHTML() error = %v", err)
+	}
+	if !strings.Contains(html, `const layout = "breadthfirst"`) {
+		t.Error("hierarchical layout should render with breadthfirst")
+	}
+	if !strings.Contains(html, `"roots":"node[type=\"project\"]"`) {
+		t.Error("hierarchical layout should root on project nodes")
+	}
+
+	html, err = GenerateHTML(graph, HTMLOptions{Layout: "force"})
+	if err != nil {
+		t.Fatalf("GenerateHTML() error = %v", err)
+	}
+	if !strings.Contains(html, `"nodeRepulsion":8000`) {
+		t.Error("force layout should pass cose options")
+	}
+}
+
+func TestBuildLegend(t *testing.T) {
+	graph := &GraphData{
+		Nodes: []Node{
+			{ID: "c1", Type: NodeTypeConcept},
+			{ID: "a", Type: NodeTypePaper},
+			{ID: "b", Type: NodeTypePaper},
+			{ID: "p1", Type: NodeTypeProject},
+		},
+	}
+
+	legend := buildLegend(graph)
+	want := []struct {
+		label string
+		count int
+	}{{"Papers", 2}, {"Concepts", 1}, {"Projects", 1}}
+	if len(legend) != len(want) {
+		t.Fatalf("buildLegend() returned %d entries, want %d (repos are absent)", len(legend), len(want))
+	}
+	for i, w := range want {
+		if legend[i].Label != w.label || legend[i].Count != w.count {
+			t.Errorf("legend[%d] = %s %d, want %s %d", i, legend[i].Label, legend[i].Count, w.label, w.count)
+		}
+	}
+}
+
+func TestGenerateHTML_LegendPanel(t *testing.T) {
+	graph := &GraphData{
+		Nodes: []Node{
+			{ID: "a", Type: NodeTypePaper, Label: "A"},
+			{ID: "c1", Type: NodeTypeConcept, Label: "C1"},
+		},
+		Edges: []Edge{{Source: "a", Target: "c1", RelationshipType: "introduces"}},
+	}
+
+	html, err := GenerateHTML(graph, HTMLOptions{Layout: "force", Offline: true})
+	if err != nil {
+		t.Fatalf("GenerateHTML() error = %v", err)
+	}
+	for _, want := range []string{
+		`<div id="legend">`,
+		`<span class="swatch circle" style="background: #4A90D9"></span>Papers<span class="count">1</span>`,
+		`<span class="swatch diamond" style="background: #E8923A"></span>Concepts<span class="count">1</span>`,
+		`Edges<span class="count">1</span>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("generated HTML missing %q", want)
+		}
+	}
+	if strings.Contains(html, "Repos<span") {
+		t.Error("legend should omit node types absent from the graph")
+	}
+}
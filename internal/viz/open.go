@@ -0,0 +1,36 @@
+package viz
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// OpenInBrowser opens an HTML file in the system's default browser. It
+// returns once the opener has started, without waiting for the browser.
+func OpenInBrowser(path string) error {
+	args, err := browserCommand(runtime.GOOS, path)
+	if err != nil {
+		return err
+	}
+	if err := exec.Command(args[0], args[1:]...).Start(); err != nil {
+		return fmt.Errorf("opening %s with %s: %w", path, args[0], err)
+	}
+	return nil
+}
+
+// browserCommand returns the command line that opens path in the default
+// browser on the given GOOS.
+func browserCommand(goos, path string) ([]string, error) {
+	switch goos {
+	case "darwin":
+		return []string{"open", path}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return []string{"xdg-open", path}, nil
+	case "windows":
+		// start is a cmd builtin; its first quoted argument is the window title
+		return []string{"cmd", "/c", "start", "", path}, nil
+	default:
+		return nil, fmt.Errorf("unsupported platform: %s", goos)
+	}
+}
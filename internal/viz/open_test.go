@@ -0,0 +1,31 @@
+package viz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBrowserCommand(t *testing.T) {
+	tests := []struct {
+		goos string
+		want []string
+	}{
+		{"darwin", []string{"open", "g.html"}},
+		{"linux", []string{"xdg-open", "g.html"}},
+		{"windows", []string{"cmd", "/c", "start", "", "g.html"}},
+	}
+	for _, tt := range tests {
+		got, err := browserCommand(tt.goos, "g.html")
+		if err != nil {
+			t.Errorf("browserCommand(%q) error = %v", tt.goos, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("browserCommand(%q) = %v, want %v", tt.goos, got, tt.want)
+		}
+	}
+
+	if _, err := browserCommand("plan9", "g.html"); err == nil {
+		t.Error("browserCommand(plan9) should fail")
+	}
+}
@@ -7,6 +7,7 @@ const (
 	NodeTypeConcept = "concept"
 	NodeTypeProject = "project"
 	NodeTypeRepo    = "repo"
+	NodeTypeGroup   = "group" // Compound container added by GroupByProject
 )
 
 // GraphData contains all data needed to render the visualization.
@@ -43,14 +44,18 @@ type Node struct {
 
 	// Sizing (for concept and project nodes)
 	ConnectionCount int `json:"connectionCount"`
+
+	// Parent is the ID of the enclosing compound node, if grouped
+	Parent string `json:"parent,omitempty"`
 }
 
 // Edge represents a paper-concept relationship.
 type Edge struct {
-	Source           string `json:"source"`
-	Target           string `json:"target"`
-	RelationshipType string `json:"relationshipType"`
-	Summary          string `json:"summary"`
+	Source           string   `json:"source"`
+	Target           string   `json:"target"`
+	RelationshipType string   `json:"relationshipType"`
+	Summary          string   `json:"summary"`
+	Strength         *float64 `json:"strength,omitempty"` // nil renders at default width
 }
 
 // IsEmpty returns true if the graph has no nodes.
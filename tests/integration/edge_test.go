@@ -2,6 +2,7 @@
 package integration
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"os"
 	"os/exec"
@@ -323,6 +324,41 @@ func TestEdgeSearch(t *testing.T) {
 	}
 }
 
+func TestEdgeSearchJSONLines(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperC", "-r", "extends", "-m", "A extends C")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperB", "-t", "PaperC", "-r", "cites", "-m", "B cites C")
+
+	output, err := runBP(t, repoDir, "edge", "search", "--type", "cites", "--limit", "1", "--json-lines")
+	if err != nil {
+		t.Fatalf("edge search --json-lines failed: %v\nOutput: %s", err, output)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line with --limit 1, got %d: %q", len(lines), output)
+	}
+	var e struct {
+		SourceID string `json:"source_id"`
+		TargetID string `json:"target_id"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &e); err != nil {
+		t.Fatalf("failed to parse line: %v\nLine: %s", err, lines[0])
+	}
+	if e.SourceID != "PaperA" || e.TargetID != "PaperB" {
+		t.Errorf("expected the first cites edge A->B, got %+v", e)
+	}
+
+	output, err = runBP(t, repoDir, "edge", "search", "--type", "nonexistent", "--json-lines")
+	if err != nil {
+		t.Fatalf("edge search --json-lines (empty) failed: %v", err)
+	}
+	if strings.TrimSpace(output) != "" {
+		t.Errorf("expected no lines for a non-existent type, got %q", output)
+	}
+}
+
 func TestEdgeExport(t *testing.T) {
 	repoDir := setupTestRepo(t)
 
@@ -365,6 +401,142 @@ func TestEdgeExport(t *testing.T) {
 	}
 }
 
+func TestEdgeExportPagination(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	// Add edges out of sort order
+	runBP(t, repoDir, "edge", "add", "-s", "PaperC", "-t", "PaperA", "-r", "cites", "-m", "C cites A")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperC", "-r", "extends", "-m", "A extends C")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperB", "-t", "PaperC", "-r", "cites", "-m", "B cites C")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "extends", "-m", "A extends B")
+
+	full, err := runBP(t, repoDir, "edge", "export")
+	if err != nil {
+		t.Fatalf("edge export failed: %v\nOutput: %s", err, full)
+	}
+
+	first, err := runBP(t, repoDir, "edge", "export", "--limit", "3")
+	if err != nil {
+		t.Fatalf("edge export --limit failed: %v\nOutput: %s", err, first)
+	}
+	second, err := runBP(t, repoDir, "edge", "export", "--limit", "3", "--offset", "3")
+	if err != nil {
+		t.Fatalf("edge export --offset failed: %v\nOutput: %s", err, second)
+	}
+
+	if n := len(strings.Split(strings.TrimSpace(first), "\n")); n != 3 {
+		t.Errorf("first page has %d edges, want 3", n)
+	}
+	if n := len(strings.Split(strings.TrimSpace(second), "\n")); n != 2 {
+		t.Errorf("second page has %d edges, want 2", n)
+	}
+	if first+second != full {
+		t.Errorf("pages do not concatenate to full export\nfull:\n%s\npages:\n%s%s", full, first, second)
+	}
+
+	// Offset past the end exports nothing
+	output, err := runBP(t, repoDir, "edge", "export", "--offset", "10")
+	if err != nil {
+		t.Fatalf("edge export --offset 10 failed: %v", err)
+	}
+	if strings.TrimSpace(output) != "" {
+		t.Errorf("expected no output past the end, got %q", output)
+	}
+}
+
+func TestEdgeExportCSV(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B, twice")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperB", "-t", "PaperC", "-r", "extends", "-m", "line one\nline \"two\"")
+
+	output, err := runBP(t, repoDir, "edge", "export", "--format", "csv")
+	if err != nil {
+		t.Fatalf("edge export --format csv failed: %v\nOutput: %s", err, output)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\nOutput: %s", err, output)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d records", len(records))
+	}
+	if strings.Join(records[0], ",") != "source_id,target_id,relationship_type,summary,created_at" {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+	if records[1][3] != "A cites B, twice" {
+		t.Errorf("summary with comma = %q", records[1][3])
+	}
+	if records[2][3] != "line one\nline \"two\"" {
+		t.Errorf("summary with newline and quotes = %q", records[2][3])
+	}
+
+	// --paper filter applies to CSV too
+	output, err = runBP(t, repoDir, "edge", "export", "--format", "csv", "--paper", "PaperA")
+	if err != nil {
+		t.Fatalf("edge export --format csv --paper failed: %v", err)
+	}
+	records, err = csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(records) != 2 || records[1][0] != "PaperA" {
+		t.Errorf("expected header + PaperA edge, got %v", records)
+	}
+
+	if _, err := runBP(t, repoDir, "edge", "export", "--format", "xml"); err == nil {
+		t.Error("expected error for unknown --format")
+	}
+}
+
+func TestEdgeTypesVocabulary(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	// Without a vocabulary file, any type is accepted and types lists what's in use
+	if output, err := runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cite", "-m", "typo"); err != nil {
+		t.Fatalf("edge add without vocabulary failed: %v\nOutput: %s", err, output)
+	}
+	output, err := runBP(t, repoDir, "edge", "types")
+	if err != nil {
+		t.Fatalf("edge types failed: %v\nOutput: %s", err, output)
+	}
+	var result struct {
+		Source string              `json:"source"`
+		Pairs  map[string][]string `json:"pairs"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("parsing output: %v\nOutput: %s", err, output)
+	}
+	if result.Source != "edges" || len(result.Pairs["paper-paper"]) != 1 || result.Pairs["paper-paper"][0] != "cite" {
+		t.Errorf("edge types without vocabulary = %+v", result)
+	}
+
+	vocabPath := filepath.Join(repoDir, ".bipartite", "edge-types.yml")
+	if err := os.WriteFile(vocabPath, []byte("paper-paper: [cites, extends]\n"), 0644); err != nil {
+		t.Fatalf("writing vocabulary: %v", err)
+	}
+
+	if _, err := runBP(t, repoDir, "edge", "add", "-s", "PaperB", "-t", "PaperC", "-r", "cite", "-m", "typo"); err == nil {
+		t.Error("expected edge add to reject a type outside the vocabulary")
+	}
+	if output, err := runBP(t, repoDir, "edge", "add", "-s", "PaperB", "-t", "PaperC", "-r", "cites", "-m", "B cites C"); err != nil {
+		t.Errorf("edge add with permitted type failed: %v\nOutput: %s", err, output)
+	}
+
+	output, err = runBP(t, repoDir, "edge", "types")
+	if err != nil {
+		t.Fatalf("edge types failed: %v\nOutput: %s", err, output)
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("parsing output: %v\nOutput: %s", err, output)
+	}
+	if result.Source != "vocabulary" || strings.Join(result.Pairs["paper-paper"], ",") != "cites,extends" {
+		t.Errorf("edge types with vocabulary = %+v", result)
+	}
+}
+
 func TestEdgeImport(t *testing.T) {
 	repoDir := setupTestRepo(t)
 
@@ -408,6 +580,61 @@ func TestEdgeImport(t *testing.T) {
 	}
 }
 
+func TestEdgeBackdated(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	output, err := runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B",
+		"--at", "2019-06-01T12:00:00+02:00")
+	if err != nil {
+		t.Fatalf("edge add --at failed: %v\nOutput: %s", err, output)
+	}
+	var addResult struct {
+		Edge struct {
+			CreatedAt string `json:"created_at"`
+		} `json:"edge"`
+	}
+	if err := json.Unmarshal([]byte(output), &addResult); err != nil {
+		t.Fatalf("failed to parse output: %v\nOutput: %s", err, output)
+	}
+	if addResult.Edge.CreatedAt != "2019-06-01T10:00:00Z" {
+		t.Errorf("created_at = %q, want 2019-06-01T10:00:00Z", addResult.Edge.CreatedAt)
+	}
+
+	if _, err := runBP(t, repoDir, "edge", "add", "-s", "PaperB", "-t", "PaperC", "-r", "cites", "-m", "x", "--at", "June 2019"); err == nil {
+		t.Error("expected error for unparseable --at")
+	}
+
+	importContent := `{"source_id":"PaperB","target_id":"PaperC","relationship_type":"extends","summary":"B extends C","at":"2020-01-02T03:04:05Z"}
+{"source_id":"PaperA","target_id":"PaperC","relationship_type":"cites","summary":"bad time","at":"2020-01-02"}
+`
+	importPath := filepath.Join(repoDir, "import.jsonl")
+	if err := os.WriteFile(importPath, []byte(importContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output, err = runBP(t, repoDir, "edge", "import", importPath)
+	if err != nil {
+		t.Fatalf("edge import failed: %v\nOutput: %s", err, output)
+	}
+	var importResult struct {
+		Added   int `json:"added"`
+		Skipped int `json:"skipped"`
+	}
+	if err := json.Unmarshal([]byte(output), &importResult); err != nil {
+		t.Fatalf("failed to parse import output: %v\nOutput: %s", err, output)
+	}
+	if importResult.Added != 1 || importResult.Skipped != 1 {
+		t.Errorf("import added %d, skipped %d; want 1 and 1", importResult.Added, importResult.Skipped)
+	}
+
+	output, err = runBP(t, repoDir, "edge", "export", "--paper", "PaperC")
+	if err != nil {
+		t.Fatalf("edge export failed: %v", err)
+	}
+	if !strings.Contains(output, `"created_at":"2020-01-02T03:04:05Z"`) {
+		t.Errorf("imported edge did not keep its at timestamp:\n%s", output)
+	}
+}
+
 func TestEdgeExportImportRoundTrip(t *testing.T) {
 	repoDir := setupTestRepo(t)
 
@@ -626,6 +853,206 @@ func TestCheckWithEdges(t *testing.T) {
 	}
 }
 
+func TestCheckFix(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B")
+
+	edgesPath := filepath.Join(repoDir, ".bipartite", "edges.jsonl")
+	f, err := os.OpenFile(edgesPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"source_id":"PaperA","target_id":"NonExistent","relationship_type":"cites","summary":"Orphaned"}` + "\n")
+	f.Close()
+
+	type checkOutput struct {
+		Status string `json:"status"`
+		Issues []struct {
+			Type   string `json:"type"`
+			Action string `json:"action"`
+		} `json:"issues"`
+	}
+	exitCode := func(err error) int {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return exitErr.ExitCode()
+		}
+		return 0
+	}
+
+	// Read-only check reports the orphan and leaves it in place
+	output, err := runBP(t, repoDir, "check")
+	if code := exitCode(err); code != 7 {
+		t.Fatalf("check with issues: exit code %d, want 7\nOutput: %s", code, output)
+	}
+	var result checkOutput
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse check output: %v\nOutput: %s", err, output)
+	}
+	if result.Status != "issues" || len(result.Issues) != 1 || result.Issues[0].Action != "" {
+		t.Errorf("read-only check = %+v", result)
+	}
+
+	// --fix removes it and marks the issue
+	output, err = runBP(t, repoDir, "check", "--fix")
+	if code := exitCode(err); code != 8 {
+		t.Fatalf("check --fix: exit code %d, want 8\nOutput: %s", code, output)
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse check --fix output: %v\nOutput: %s", err, output)
+	}
+	if result.Status != "fixed" || len(result.Issues) != 1 || result.Issues[0].Action != "removed" {
+		t.Errorf("check --fix = %+v", result)
+	}
+
+	// Now clean
+	output, err = runBP(t, repoDir, "check")
+	if err != nil {
+		t.Fatalf("check after fix failed: %v\nOutput: %s", err, output)
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse check output: %v", err)
+	}
+	if result.Status != "ok" {
+		t.Errorf("expected status 'ok' after fix, got %q", result.Status)
+	}
+}
+
+func TestCheckDuplicateEdges(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	edgesPath := filepath.Join(repoDir, ".bipartite", "edges.jsonl")
+	seed := `{"source_id":"PaperA","target_id":"PaperB","relationship_type":"cites","summary":"second import","created_at":"2024-02-01T00:00:00Z"}
+{"source_id":"PaperA","target_id":"PaperB","relationship_type":"cites","summary":"first import","created_at":"2024-01-01T00:00:00Z"}
+{"source_id":"PaperB","target_id":"PaperC","relationship_type":"extends","summary":"unique","created_at":"2024-01-05T00:00:00Z"}
+`
+	if err := os.WriteFile(edgesPath, []byte(seed), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	type checkOutput struct {
+		Status string `json:"status"`
+		Issues []struct {
+			Type       string   `json:"type"`
+			SourceID   string   `json:"source_id"`
+			Count      int      `json:"count"`
+			CreatedAts []string `json:"created_ats"`
+			Action     string   `json:"action"`
+		} `json:"issues"`
+	}
+
+	output, _ := runBP(t, repoDir, "check")
+	var result checkOutput
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse check output: %v\nOutput: %s", err, output)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Type != "duplicate_edge" {
+		t.Fatalf("expected one duplicate_edge issue, got %+v", result.Issues)
+	}
+	dup := result.Issues[0]
+	if dup.Count != 2 || strings.Join(dup.CreatedAts, ",") != "2024-02-01T00:00:00Z,2024-01-01T00:00:00Z" {
+		t.Errorf("duplicate issue = %+v", dup)
+	}
+
+	output, _ = runBP(t, repoDir, "check", "--fix")
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse check --fix output: %v\nOutput: %s", err, output)
+	}
+	if result.Status != "fixed" || result.Issues[0].Action != "removed" {
+		t.Errorf("check --fix = %+v", result)
+	}
+
+	output, err := runBP(t, repoDir, "edge", "export")
+	if err != nil {
+		t.Fatalf("edge export failed: %v", err)
+	}
+	if strings.Count(output, "\n") != 2 || !strings.Contains(output, "first import") || strings.Contains(output, "second import") {
+		t.Errorf("expected duplicates collapsed to the earliest edge, got:\n%s", output)
+	}
+}
+
+func TestDeletePaperCascade(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	runBP(t, repoDir, "rebuild")
+
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperC", "-t", "PaperA", "-r", "cites", "-m", "C cites A")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperB", "-t", "PaperC", "-r", "cites", "-m", "B cites C")
+
+	// Blocked without --force
+	output, err := runBP(t, repoDir, "delete", "PaperA")
+	if err == nil {
+		t.Fatalf("delete with linked edges should fail\nOutput: %s", output)
+	}
+	var blocked struct {
+		ErrorCode string `json:"error_code"`
+		ID        string `json:"id"`
+		EdgeCount int    `json:"edge_count"`
+		Hint      string `json:"hint"`
+	}
+	if err := json.Unmarshal([]byte(output), &blocked); err != nil {
+		t.Fatalf("failed to parse blocked delete output: %v\nOutput: %s", err, output)
+	}
+	if blocked.ErrorCode != "validation" || blocked.ID != "PaperA" || blocked.EdgeCount != 2 {
+		t.Errorf("blocked delete should report 2 edges on PaperA as a validation error, got: %s", output)
+	}
+	if blocked.Hint != "bip delete PaperA --force" {
+		t.Errorf("blocked delete hint = %q", blocked.Hint)
+	}
+
+	output, err = runBP(t, repoDir, "delete", "PaperA", "--force")
+	if err != nil {
+		t.Fatalf("delete --force failed: %v\nOutput: %s", err, output)
+	}
+	var result struct {
+		Status       string `json:"status"`
+		EdgesRemoved int    `json:"edges_removed"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse delete output: %v\nOutput: %s", err, output)
+	}
+	if result.Status != "deleted" || result.EdgesRemoved != 2 {
+		t.Errorf("delete --force = %+v, want deleted with 2 edges removed", result)
+	}
+
+	refs, _ := os.ReadFile(filepath.Join(repoDir, ".bipartite", "refs.jsonl"))
+	if strings.Contains(string(refs), "PaperA") {
+		t.Error("PaperA still in refs.jsonl")
+	}
+	edges, _ := os.ReadFile(filepath.Join(repoDir, ".bipartite", "edges.jsonl"))
+	if strings.Contains(string(edges), "PaperA") || !strings.Contains(string(edges), "PaperB") {
+		t.Errorf("edges.jsonl after delete:\n%s", edges)
+	}
+
+	// Nothing left for check to flag
+	output, err = runBP(t, repoDir, "check")
+	if err != nil {
+		t.Errorf("check after delete failed: %v\nOutput: %s", err, output)
+	}
+}
+
+func TestDeletePaperBlockedByUnindexedEdge(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	runBP(t, repoDir, "rebuild")
+
+	// An edge written to edges.jsonl (e.g. by git pull) but not yet indexed
+	edgesPath := filepath.Join(repoDir, ".bipartite", "edges.jsonl")
+	f, err := os.OpenFile(edgesPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("opening edges.jsonl: %v", err)
+	}
+	f.WriteString(`{"source_id":"PaperB","target_id":"PaperC","relationship_type":"cites","summary":"pulled"}` + "\n")
+	f.Close()
+
+	output, err := runBP(t, repoDir, "delete", "PaperB")
+	if err == nil {
+		t.Fatalf("delete with an unindexed edge should fail\nOutput: %s", output)
+	}
+	if !strings.Contains(output, `"edge_count":1`) {
+		t.Errorf("blocked delete should count the JSONL edge, got: %s", output)
+	}
+}
+
 func TestFullEdgeWorkflow(t *testing.T) {
 	repoDir := setupTestRepo(t)
 
@@ -687,3 +1114,115 @@ func TestFullEdgeWorkflow(t *testing.T) {
 		t.Errorf("expected 2 outgoing after rebuild, got %d", len(listResult.Outgoing))
 	}
 }
+
+func TestEdgeListType(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperC", "-r", "extends", "-m", "A extends C")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperB", "-t", "PaperA", "-r", "cites", "-m", "B cites A")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperC", "-t", "PaperA", "-r", "builds-on", "-m", "C builds on A")
+
+	output, err := runBP(t, repoDir, "edge", "list", "PaperA", "--all", "--type", "cites")
+	if err != nil {
+		t.Fatalf("edge list --all --type failed: %v\nOutput: %s", err, output)
+	}
+
+	type listedEdge struct {
+		SourceID         string `json:"source_id"`
+		TargetID         string `json:"target_id"`
+		RelationshipType string `json:"relationship_type"`
+	}
+	var result struct {
+		Outgoing []listedEdge `json:"outgoing"`
+		Incoming []listedEdge `json:"incoming"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\nOutput: %s", err, output)
+	}
+	if len(result.Outgoing) != 1 || result.Outgoing[0].TargetID != "PaperB" {
+		t.Errorf("expected only the outgoing cites edge to PaperB, got %+v", result.Outgoing)
+	}
+	if len(result.Incoming) != 1 || result.Incoming[0].SourceID != "PaperB" {
+		t.Errorf("expected only the incoming cites edge from PaperB, got %+v", result.Incoming)
+	}
+	for _, e := range append(result.Outgoing, result.Incoming...) {
+		if e.RelationshipType != "cites" {
+			t.Errorf("got %s edge, want only cites", e.RelationshipType)
+		}
+	}
+
+	// A type with no matching edges lists nothing
+	output, err = runBP(t, repoDir, "edge", "list", "PaperA", "--type", "contradicts")
+	if err != nil {
+		t.Fatalf("edge list --type failed: %v\nOutput: %s", err, output)
+	}
+	result.Outgoing = nil
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse output: %v\nOutput: %s", err, output)
+	}
+	if len(result.Outgoing) != 0 {
+		t.Errorf("expected no outgoing edges, got %+v", result.Outgoing)
+	}
+
+	// --type only applies to a single paper's edges
+	if _, err := runBP(t, repoDir, "edge", "list", "--type", "cites"); err == nil {
+		t.Error("expected error for --type without a paper ID")
+	}
+}
+
+func TestEdgeAddStrength(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	if _, err := runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B", "--strength", "1.5"); err == nil {
+		t.Fatal("expected error for strength outside [0,1]")
+	}
+
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperC", "-r", "extends", "-m", "A extends C")
+
+	// Weighting an existing edge that is not the last one in the file
+	output, err := runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "PaperB", "-r", "cites", "-m", "A cites B", "--strength", "0.75")
+	if err != nil {
+		t.Fatalf("edge add --strength failed: %v\nOutput: %s", err, output)
+	}
+	var addResult struct {
+		Action string `json:"action"`
+		Edge   struct {
+			TargetID string   `json:"target_id"`
+			Strength *float64 `json:"strength"`
+		} `json:"edge"`
+	}
+	if err := json.Unmarshal([]byte(output), &addResult); err != nil {
+		t.Fatalf("failed to parse output: %v\nOutput: %s", err, output)
+	}
+	if addResult.Action != "updated" || addResult.Edge.TargetID != "PaperB" {
+		t.Errorf("expected updated edge to PaperB, got %s edge to %s", addResult.Action, addResult.Edge.TargetID)
+	}
+
+	output, err = runBP(t, repoDir, "edge", "list", "PaperA")
+	if err != nil {
+		t.Fatalf("edge list failed: %v\nOutput: %s", err, output)
+	}
+	var listResult struct {
+		Outgoing []struct {
+			TargetID string   `json:"target_id"`
+			Strength *float64 `json:"strength"`
+		} `json:"outgoing"`
+	}
+	if err := json.Unmarshal([]byte(output), &listResult); err != nil {
+		t.Fatalf("failed to parse list output: %v\nOutput: %s", err, output)
+	}
+	for _, e := range listResult.Outgoing {
+		switch e.TargetID {
+		case "PaperB":
+			if e.Strength == nil || *e.Strength != 0.75 {
+				t.Errorf("PaperB edge strength = %v, want 0.75", e.Strength)
+			}
+		case "PaperC":
+			if e.Strength != nil {
+				t.Errorf("PaperC edge strength = %v, want unset", *e.Strength)
+			}
+		}
+	}
+}
@@ -2,9 +2,11 @@
 package integration
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -478,6 +480,52 @@ func TestProjectPapersTransitive(t *testing.T) {
 	}
 }
 
+func TestPapersCSV(t *testing.T) {
+	repoDir := setupTestRepoWithConcepts(t)
+	runBP(t, repoDir, "rebuild")
+
+	runBP(t, repoDir, "project", "add", "dasm2", "--name", "DASM2")
+	runBP(t, repoDir, "edge", "add", "-s", "concept:vi", "-t", "project:dasm2", "-r", "implemented-in", "-m", "DASM2 uses VI")
+	runBP(t, repoDir, "edge", "add", "-s", "PaperA", "-t", "concept:vi", "-r", "introduces", "-m", "Introduces VI, with \"ELBO\"")
+
+	header := "paper_id,via_concept,relationship_type,summary"
+	for _, args := range [][]string{
+		{"project", "papers", "dasm2", "--format", "csv"},
+		{"concept", "papers", "vi", "--format", "csv"},
+	} {
+		output, err := runBP(t, repoDir, args...)
+		if err != nil {
+			t.Fatalf("%v failed: %v\nOutput: %s", args, err, output)
+		}
+		records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+		if err != nil {
+			t.Fatalf("%v output is not valid CSV: %v\nOutput: %s", args, err, output)
+		}
+		if len(records) != 2 {
+			t.Fatalf("%v: expected header + 1 row, got %v", args, records)
+		}
+		if strings.Join(records[0], ",") != header {
+			t.Errorf("%v: unexpected header: %v", args, records[0])
+		}
+		if records[1][0] != "PaperA" || records[1][2] != "introduces" || records[1][3] != "Introduces VI, with \"ELBO\"" {
+			t.Errorf("%v: unexpected row: %v", args, records[1])
+		}
+	}
+
+	// A concept with no papers still gets the header
+	output, err := runBP(t, repoDir, "concept", "papers", "mcmc", "--format", "csv")
+	if err != nil {
+		t.Fatalf("concept papers --format csv failed: %v\nOutput: %s", err, output)
+	}
+	if output != header+"\n" {
+		t.Errorf("expected only the header, got %q", output)
+	}
+
+	if _, err := runBP(t, repoDir, "project", "papers", "dasm2", "--format", "xml"); err == nil {
+		t.Error("expected error for unknown --format")
+	}
+}
+
 // T069: Integration test for rebuild with projects/repos
 func TestRebuildWithProjectsRepos(t *testing.T) {
 	repoDir := setupTestRepoWithConcepts(t)
@@ -0,0 +1,124 @@
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// vizResult mirrors the JSON printed by bip viz when it writes a file.
+type vizResult struct {
+	Output string `json:"output"`
+	Opened bool   `json:"opened"`
+	Empty  bool   `json:"empty"`
+}
+
+// setupVizRepo creates a repo whose graph has one paper linked to one concept.
+func setupVizRepo(t *testing.T) string {
+	t.Helper()
+	repoDir := setupTestRepoWithConcepts(t)
+
+	if output, err := runBP(t, repoDir, "rebuild"); err != nil {
+		t.Fatalf("rebuild failed: %v\nOutput: %s", err, output)
+	}
+	output, err := runBP(t, repoDir, "edge", "add",
+		"--source", "PaperA",
+		"--target", "concept:vi",
+		"--type", "introduces",
+		"--summary", "Paper A introduces VI")
+	if err != nil {
+		t.Fatalf("edge add failed: %v\nOutput: %s", err, output)
+	}
+	return repoDir
+}
+
+func parseVizResult(t *testing.T, output string) vizResult {
+	t.Helper()
+	var result vizResult
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("failed to parse viz output: %v\nOutput: %s", err, output)
+	}
+	return result
+}
+
+func TestVizDefaultCachePath(t *testing.T) {
+	repoDir := setupVizRepo(t)
+
+	output, err := runBP(t, repoDir, "viz")
+	if err != nil {
+		t.Fatalf("viz failed: %v\nOutput: %s", err, output)
+	}
+
+	result := parseVizResult(t, output)
+	want := filepath.Join(repoDir, ".bipartite", "cache", "graph.html")
+	if result.Output != want {
+		t.Errorf("expected output %q, got %q", want, result.Output)
+	}
+	if result.Opened || result.Empty {
+		t.Errorf("expected opened and empty false, got %+v", result)
+	}
+
+	html, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatalf("reading default output: %v", err)
+	}
+	if !strings.Contains(string(html), `"id":"PaperA"`) || !strings.Contains(string(html), `"id":"vi"`) {
+		t.Error("expected the written graph to contain the PaperA and vi nodes")
+	}
+}
+
+func TestVizOut(t *testing.T) {
+	repoDir := setupVizRepo(t)
+	outPath := filepath.Join(repoDir, "graph.html")
+
+	output, err := runBP(t, repoDir, "viz", "--out", outPath)
+	if err != nil {
+		t.Fatalf("viz --out failed: %v\nOutput: %s", err, output)
+	}
+	if result := parseVizResult(t, output); result.Output != outPath {
+		t.Errorf("expected output %q, got %q", outPath, result.Output)
+	}
+	if _, err := os.Stat(outPath); err != nil {
+		t.Errorf("expected %s to be written: %v", outPath, err)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, ".bipartite", "cache", "graph.html")); !os.IsNotExist(err) {
+		t.Error("expected --out to skip the default cache file")
+	}
+
+	// --out - writes the HTML itself to stdout
+	output, err = runBP(t, repoDir, "viz", "--out", "-")
+	if err != nil {
+		t.Fatalf("viz --out - failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(output), "<!DOCTYPE html>") {
+		t.Errorf("expected HTML on stdout, got %.80q", output)
+	}
+
+	// --open needs a file to open
+	if output, err = runBP(t, repoDir, "viz", "--out", "-", "--open"); err == nil {
+		t.Errorf("expected --open with --out - to fail\nOutput: %s", output)
+	}
+}
+
+func TestVizEmptyGraph(t *testing.T) {
+	repoDir := setupTestRepo(t)
+
+	output, err := runBP(t, repoDir, "viz")
+	if err != nil {
+		t.Fatalf("viz failed: %v\nOutput: %s", err, output)
+	}
+
+	result := parseVizResult(t, output)
+	if !result.Empty {
+		t.Errorf("expected empty true for a graph with no edges, got %+v", result)
+	}
+	html, err := os.ReadFile(result.Output)
+	if err != nil {
+		t.Fatalf("reading output: %v", err)
+	}
+	if !strings.Contains(string(html), "bip edge add") {
+		t.Error("expected the empty page to explain how to add edges")
+	}
+}